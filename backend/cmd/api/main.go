@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
@@ -9,6 +10,8 @@ import (
 
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/di"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/storage"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/worker"
@@ -85,6 +88,7 @@ func main() {
 	container.InitStorageUseCases(storageService)
 	container.InitSharingUseCases(storageService)
 	container.InitAuditService()
+	container.InitAuditUseCases()
 	handlers := di.NewHandlers(container)
 	middlewares := di.NewMiddlewares(container)
 
@@ -108,10 +112,12 @@ func main() {
 		HSTSMaxAge:    31536000, // 1年
 		CSPDirectives: "default-src 'self'",
 	}))
+	corsDefaults := middleware.DefaultCORSConfig()
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowOrigins:     cfg.Security.CORSOrigins,
-		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Content-Type", "Authorization", "X-Request-ID", middleware.CSRFHeaderName},
+		AllowMethods:     corsDefaults.AllowMethods,
+		AllowHeaders:     corsDefaults.AllowHeaders,
+		ExposeHeaders:    corsDefaults.ExposeHeaders,
 		AllowCredentials: true,
 		MaxAge:           86400,
 	}))
@@ -124,10 +130,20 @@ func main() {
 	router.NewRouter(e, handlers, middlewares).Setup()
 
 	// Start background workers
-	workerMgr := worker.NewManager()
+	workerOpts := []worker.Option{
+		worker.WithRunHistory(container.JobRunRepo),
+		worker.WithInstanceID(instanceID()),
+	}
+	if container.JobLock != nil {
+		workerOpts = append(workerOpts, worker.WithLocker(container.JobLock))
+	}
+	workerMgr := worker.NewManager(workerOpts...)
 	workerMgr.Register(worker.NewHealthCheckJob(func(ctx context.Context) error {
 		return container.PgClient.Pool().Ping(ctx)
 	}))
+	for _, job := range container.NewBackgroundJobs(storageService) {
+		workerMgr.Register(job)
+	}
 	workerMgr.Start()
 
 	// Start server
@@ -154,3 +170,12 @@ func main() {
 	}
 	slog.Info("server stopped")
 }
+
+// instanceID はジョブのロック保持者と実行履歴に記録するレプリカ識別子を返します
+func instanceID() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s-%s", hostname, uuid.NewString()[:8])
+}
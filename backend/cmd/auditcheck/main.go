@@ -0,0 +1,167 @@
+// auditcheck は監査ログのハッシュチェーンを検証するコマンドです
+//
+// 使い方:
+//
+//	go run ./cmd/auditcheck                                       # チェーンとチェックポイントを検証
+//	go run ./cmd/auditcheck --export-checkpoints=checkpoints.ndjson # 署名付きチェックポイントをNDJSONで出力（-で標準出力）
+//
+// チェックポイントの署名はAUDIT_CHECKPOINT_SIGNING_KEYが設定されている場合のみ検証します
+// 破損したリンクが見つかった場合は終了コード1で終了します
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/audit"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/database"
+	infraRepo "github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/job"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/config"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/logger"
+)
+
+func main() {
+	exportPath := flag.String("export-checkpoints", "", "write signed checkpoints as NDJSON to the given file (- for stdout) instead of verifying")
+	flag.Parse()
+
+	if err := logger.Setup(logger.DefaultConfig()); err != nil {
+		slog.Error("failed to setup logger", "error", err)
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+
+	cfg, err := config.Load()
+	if err != nil {
+		slog.Error("failed to load config", "error", err)
+		os.Exit(1)
+	}
+
+	var signer *audit.Ed25519CheckpointSigner
+	if cfg.Security.AuditCheckpointSigningKey != "" {
+		if signer, err = audit.NewEd25519CheckpointSigner(cfg.Security.AuditCheckpointSigningKey); err != nil {
+			slog.Error("failed to load audit checkpoint signing key", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	pgClient, err := database.NewPostgresClient(ctx, cfg.Database.URL)
+	if err != nil {
+		slog.Error("failed to connect to PostgreSQL", "error", err)
+		os.Exit(1)
+	}
+	defer pgClient.Close()
+	txManager := database.NewTxManager(pgClient.Pool())
+
+	checkpointRepo := infraRepo.NewAuditCheckpointRepository(txManager)
+
+	if *exportPath != "" {
+		checkpoints, err := checkpointRepo.List(ctx)
+		if err != nil {
+			slog.Error("failed to list audit checkpoints", "error", err)
+			os.Exit(1)
+		}
+		if err := exportCheckpoints(*exportPath, checkpoints, signer); err != nil {
+			slog.Error("failed to export audit checkpoints", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// 署名器が未設定の場合はnilインターフェースとして渡し、署名の検証を省略する
+	var verifierSigner service.AuditCheckpointSigner
+	if signer != nil {
+		verifierSigner = signer
+	}
+	verifier := job.NewAuditChainVerifier(infraRepo.NewAuditLogRepository(txManager), checkpointRepo, verifierSigner)
+
+	report, err := verifier.Verify(ctx)
+	if err != nil {
+		slog.Error("audit chain verification failed", "error", err)
+		os.Exit(1)
+	}
+
+	printReport(report)
+
+	if report.Break != nil {
+		os.Exit(1)
+	}
+}
+
+// printReport は検証結果を標準出力に出力します
+func printReport(report *job.AuditChainReport) {
+	if !report.SignaturesChecked {
+		fmt.Println("warning: AUDIT_CHECKPOINT_SIGNING_KEY is not set; checkpoint signatures were not verified")
+	}
+	if brk := report.Break; brk != nil {
+		day := ""
+		if !brk.CheckpointDay.IsZero() {
+			day = brk.CheckpointDay.Format(time.DateOnly)
+		}
+		fmt.Printf("BROKEN %-28s seq=%d entry=%s checkpoint=%s expected=%q actual=%q\n",
+			brk.Type, brk.Sequence, brk.EntryID, day, brk.Expected, brk.Actual)
+	}
+	fmt.Printf("entries verified: %d (seq %d..%d), checkpoints verified: %d\n",
+		report.EntriesVerified, report.FirstSequence, report.LastSequence, report.CheckpointsVerified)
+	if report.Break == nil {
+		fmt.Println("audit log chain OK")
+	}
+}
+
+// exportedCheckpoint はエクスポートするチェックポイントの形式です
+// payloadとpublic_keyがあれば、データベースに依存せずにEd25519で署名を検証できる
+type exportedCheckpoint struct {
+	Day          string `json:"day"`
+	LastSequence int64  `json:"last_seq"`
+	LastHash     string `json:"last_hash"`
+	KeyID        string `json:"key_id"`
+	Signature    string `json:"signature"`
+	Payload      string `json:"payload"`
+	PublicKey    string `json:"public_key,omitempty"` // 署名鍵が一致する場合のみ
+}
+
+// exportCheckpoints はチェックポイントを1行1件のJSONで書き出します
+func exportCheckpoints(path string, checkpoints []*entity.AuditCheckpoint, signer *audit.Ed25519CheckpointSigner) error {
+	var w io.Writer = os.Stdout
+	if path != "-" {
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	encoder := json.NewEncoder(w)
+	for _, checkpoint := range checkpoints {
+		record := exportedCheckpoint{
+			Day:          checkpoint.Day.Format(time.DateOnly),
+			LastSequence: checkpoint.LastSequence,
+			LastHash:     hex.EncodeToString(checkpoint.LastHash),
+			KeyID:        checkpoint.KeyID,
+			Signature:    base64.StdEncoding.EncodeToString(checkpoint.Signature),
+			Payload:      string(checkpoint.SigningPayload()),
+		}
+		if signer != nil && signer.KeyID() == checkpoint.KeyID {
+			record.PublicKey = base64.StdEncoding.EncodeToString(signer.PublicKey())
+		}
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	if path != "-" {
+		fmt.Fprintf(os.Stderr, "exported %d checkpoints to %s\n", len(checkpoints), path)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// storagecheck はPostgreSQLとMinIOの間の不整合を検出するコマンドです
+//
+// 使い方:
+//
+//	go run ./cmd/storagecheck           # 検出のみ
+//	go run ./cmd/storagecheck --repair  # 孤立オブジェクトを削除し、破損ファイルをマーク
+//
+// 未修復の不整合が残った場合は終了コード1で終了します
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/database"
+	infraRepo "github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/storage"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/job"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/config"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/logger"
+)
+
+func main() {
+	repair := flag.Bool("repair", false, "delete orphaned objects and mark files with missing or mismatched objects as broken")
+	flag.Parse()
+
+	if err := logger.Setup(logger.DefaultConfig()); err != nil {
+		slog.Error("failed to setup logger", "error", err)
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+
+	cfg, err := config.Load()
+	if err != nil {
+		slog.Error("failed to load config", "error", err)
+		os.Exit(1)
+	}
+
+	pgClient, err := database.NewPostgresClient(ctx, cfg.Database.URL)
+	if err != nil {
+		slog.Error("failed to connect to PostgreSQL", "error", err)
+		os.Exit(1)
+	}
+	defer pgClient.Close()
+	txManager := database.NewTxManager(pgClient.Pool())
+
+	minioClient, err := storage.NewMinIOClient(storage.Config{
+		Endpoint:        cfg.Storage.Endpoint,
+		AccessKeyID:     cfg.Storage.AccessKeyID,
+		SecretAccessKey: cfg.Storage.SecretAccessKey,
+		BucketName:      cfg.Storage.BucketName,
+		UseSSL:          cfg.Storage.UseSSL,
+		Region:          "us-east-1",
+	})
+	if err != nil {
+		slog.Error("failed to initialize MinIO client", "error", err)
+		os.Exit(1)
+	}
+	storageService := storage.NewStorageServiceAdapter(storage.NewStorageService(minioClient))
+
+	checker := job.NewStorageConsistencyChecker(
+		infraRepo.NewStorageInventoryRepository(txManager),
+		infraRepo.NewFileRepository(txManager),
+		storageService,
+	)
+
+	report, err := checker.Check(ctx, *repair)
+	if err != nil {
+		slog.Error("storage consistency check failed", "error", err)
+		os.Exit(1)
+	}
+
+	printReport(report)
+
+	if report.Unrepaired() > 0 {
+		os.Exit(1)
+	}
+}
+
+// printReport は検出結果を標準出力に1行1件で出力します
+func printReport(report *job.ConsistencyReport) {
+	for _, issue := range report.Issues {
+		status := "unrepaired"
+		if issue.Repaired {
+			status = "repaired"
+		}
+		fmt.Printf("%-18s %-10s key=%s version=%s source=%s file=%s expected=%q actual=%q\n",
+			issue.Type, status, issue.ObjectKey, issue.VersionID, issue.Source, issue.FileID, issue.Expected, issue.Actual)
+	}
+	fmt.Printf("rows scanned: %d, objects scanned: %d, issues: %d, unrepaired: %d\n",
+		report.RowsScanned, report.ObjectsScanned, len(report.Issues), report.Unrepaired())
+}
@@ -87,7 +87,7 @@ func (r Role) Permissions() []Permission {
 	switch r {
 	case RoleOwner:
 		return []Permission{
-			PermFileRead, PermFileWrite, PermFileRename, PermFileDelete, PermFileRestore,
+			PermFileRead, PermFileDownload, PermFileWrite, PermFileRename, PermFileDelete, PermFileRestore,
 			PermFileMoveIn, PermFileMoveOut, PermFileShare,
 			PermFilePermanentDelete,
 			PermFolderRead, PermFolderCreate, PermFolderRename, PermFolderDelete,
@@ -97,7 +97,7 @@ func (r Role) Permissions() []Permission {
 		}
 	case RoleContentManager:
 		return []Permission{
-			PermFileRead, PermFileWrite, PermFileRename, PermFileDelete, PermFileRestore,
+			PermFileRead, PermFileDownload, PermFileWrite, PermFileRename, PermFileDelete, PermFileRestore,
 			PermFileMoveIn, PermFileMoveOut, PermFileShare,
 			PermFolderRead, PermFolderCreate, PermFolderRename, PermFolderDelete,
 			PermFolderMoveIn, PermFolderMoveOut, PermFolderShare,
@@ -105,7 +105,7 @@ func (r Role) Permissions() []Permission {
 		}
 	case RoleContributor:
 		return []Permission{
-			PermFileRead, PermFileWrite, PermFileRename, PermFileDelete, PermFileRestore,
+			PermFileRead, PermFileDownload, PermFileWrite, PermFileRename, PermFileDelete, PermFileRestore,
 			PermFileMoveIn, PermFileShare,
 			PermFolderRead, PermFolderCreate, PermFolderRename, PermFolderDelete,
 			PermFolderMoveIn, PermFolderShare,
@@ -113,7 +113,7 @@ func (r Role) Permissions() []Permission {
 		}
 	case RoleViewer:
 		return []Permission{
-			PermFileRead,
+			PermFileRead, PermFileDownload,
 			PermFolderRead,
 		}
 	default:
@@ -30,6 +30,7 @@ type ArchivedFile struct {
 	ArchivedAt       time.Time
 	ArchivedBy       uuid.UUID
 	ExpiresAt        time.Time
+	ArchivedFolderID *uuid.UUID // フォルダごとゴミ箱に移動された場合のアーカイブフォルダID
 }
 
 // NewArchivedFile は新しいArchivedFileを作成します
@@ -78,6 +79,7 @@ func ReconstructArchivedFile(
 	archivedAt time.Time,
 	archivedBy uuid.UUID,
 	expiresAt time.Time,
+	archivedFolderID *uuid.UUID,
 ) *ArchivedFile {
 	return &ArchivedFile{
 		ID:               id,
@@ -93,6 +95,7 @@ func ReconstructArchivedFile(
 		ArchivedAt:       archivedAt,
 		ArchivedBy:       archivedBy,
 		ExpiresAt:        expiresAt,
+		ArchivedFolderID: archivedFolderID,
 	}
 }
 
@@ -107,6 +110,19 @@ func (af *ArchivedFile) IsOwnedBy(ownerID uuid.UUID) bool {
 	return af.OwnerID == ownerID
 }
 
+// AttachToArchivedFolder はフォルダごとゴミ箱に移動されたファイルとしてアーカイブフォルダに関連付けます
+// 保持期限はアーカイブフォルダに揃えます
+func (af *ArchivedFile) AttachToArchivedFolder(archivedFolder *ArchivedFolder) {
+	af.ArchivedFolderID = &archivedFolder.ID
+	af.ArchivedAt = archivedFolder.ArchivedAt
+	af.ExpiresAt = archivedFolder.ExpiresAt
+}
+
+// IsInArchivedFolder はフォルダごとゴミ箱に移動されたファイルかどうかを判定します
+func (af *ArchivedFile) IsInArchivedFolder() bool {
+	return af.ArchivedFolderID != nil
+}
+
 // IsCreatedBy は指定ユーザーが作成者かどうかを判定します
 func (af *ArchivedFile) IsCreatedBy(userID uuid.UUID) bool {
 	return af.CreatedBy == userID
@@ -139,3 +155,30 @@ func (af *ArchivedFile) DaysUntilExpiration() int {
 	}
 	return int(duration.Hours() / 24)
 }
+
+// StoredBytes はストレージ上で占有しているバイト数（全バージョンの合計）を返します
+// バージョン情報がない場合はファイルサイズを返します
+func (af *ArchivedFile) StoredBytes(versions []*ArchivedFileVersion) int64 {
+	if len(versions) == 0 {
+		return af.Size
+	}
+	var total int64
+	for _, v := range versions {
+		total += v.Size
+	}
+	return total
+}
+
+// HasUnsharedContent はファイル自身のキーに保存された（ブロブを共有しない）バージョンがあるかを判定します
+// バージョン情報がない場合は内容がファイル自身のキーにあるものとして扱います
+func (af *ArchivedFile) HasUnsharedContent(versions []*ArchivedFileVersion) bool {
+	if len(versions) == 0 {
+		return true
+	}
+	for _, v := range versions {
+		if !v.IsShared() {
+			return true
+		}
+	}
+	return false
+}
@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
 )
 
 // ArchivedFileVersion はゴミ箱内ファイルのバージョン情報エンティティ
@@ -12,10 +14,13 @@ type ArchivedFileVersion struct {
 	ArchivedFileID    uuid.UUID
 	OriginalVersionID uuid.UUID
 	VersionNumber     int
+	StorageKey        valueobject.StorageKey
 	MinioVersionID    string
+	BlobID            *uuid.UUID
 	Size              int64
 	Checksum          string
 	UploadedBy        uuid.UUID
+	IsPinned          bool
 	CreatedAt         time.Time // 元の作成日時
 }
 
@@ -24,7 +29,9 @@ func NewArchivedFileVersion(
 	archivedFileID uuid.UUID,
 	originalVersionID uuid.UUID,
 	versionNumber int,
+	storageKey valueobject.StorageKey,
 	minioVersionID string,
+	blobID *uuid.UUID,
 	size int64,
 	checksum string,
 	uploadedBy uuid.UUID,
@@ -35,7 +42,9 @@ func NewArchivedFileVersion(
 		ArchivedFileID:    archivedFileID,
 		OriginalVersionID: originalVersionID,
 		VersionNumber:     versionNumber,
+		StorageKey:        storageKey,
 		MinioVersionID:    minioVersionID,
+		BlobID:            blobID,
 		Size:              size,
 		Checksum:          checksum,
 		UploadedBy:        uploadedBy,
@@ -49,10 +58,13 @@ func ReconstructArchivedFileVersion(
 	archivedFileID uuid.UUID,
 	originalVersionID uuid.UUID,
 	versionNumber int,
+	storageKey valueobject.StorageKey,
 	minioVersionID string,
+	blobID *uuid.UUID,
 	size int64,
 	checksum string,
 	uploadedBy uuid.UUID,
+	isPinned bool,
 	createdAt time.Time,
 ) *ArchivedFileVersion {
 	return &ArchivedFileVersion{
@@ -60,24 +72,46 @@ func ReconstructArchivedFileVersion(
 		ArchivedFileID:    archivedFileID,
 		OriginalVersionID: originalVersionID,
 		VersionNumber:     versionNumber,
+		StorageKey:        storageKey,
 		MinioVersionID:    minioVersionID,
+		BlobID:            blobID,
 		Size:              size,
 		Checksum:          checksum,
 		UploadedBy:        uploadedBy,
+		IsPinned:          isPinned,
 		CreatedAt:         createdAt,
 	}
 }
 
+// IsShared はブロブを参照しているかを判定します
+func (afv *ArchivedFileVersion) IsShared() bool {
+	return afv.BlobID != nil
+}
+
 // ToFileVersion は復元用のFileVersionデータを生成します
 func (afv *ArchivedFileVersion) ToFileVersion(fileID uuid.UUID) *FileVersion {
 	return &FileVersion{
 		ID:             afv.OriginalVersionID,
 		FileID:         fileID,
 		VersionNumber:  afv.VersionNumber,
+		StorageKey:     afv.StorageKey,
 		MinioVersionID: afv.MinioVersionID,
+		BlobID:         afv.BlobID,
 		Size:           afv.Size,
 		Checksum:       afv.Checksum,
 		UploadedBy:     afv.UploadedBy,
+		IsPinned:       afv.IsPinned,
 		CreatedAt:      afv.CreatedAt,
 	}
 }
+
+// ArchivedFileVersionBlobIDs はバージョンが参照しているブロブのIDを返します
+func ArchivedFileVersionBlobIDs(versions []*ArchivedFileVersion) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(versions))
+	for _, v := range versions {
+		if v.BlobID != nil {
+			ids = append(ids, *v.BlobID)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,113 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+)
+
+// ArchivedFolder はゴミ箱に移動されたフォルダエンティティ
+// 削除されたフォルダをルートとするサブツリーを1つの単位として保持する
+// 配下のフォルダはArchivedSubfolder、ファイルはArchivedFolderIDを持つArchivedFileとして保持される
+type ArchivedFolder struct {
+	ID               uuid.UUID
+	OriginalFolderID uuid.UUID  // 復元時に再利用するフォルダID
+	OriginalParentID *uuid.UUID // 復元先フォルダID（ルートフォルダの場合はnil）
+	OriginalPath     string     // 復元時の参考パス（例: "/documents/projects"）
+	Name             valueobject.FolderName
+	OwnerID          uuid.UUID
+	CreatedBy        uuid.UUID
+	ArchivedAt       time.Time
+	ArchivedBy       uuid.UUID
+	ExpiresAt        time.Time
+}
+
+// NewArchivedFolder は新しいArchivedFolderを作成します
+func NewArchivedFolder(
+	originalFolderID uuid.UUID,
+	originalParentID *uuid.UUID,
+	originalPath string,
+	name valueobject.FolderName,
+	ownerID uuid.UUID,
+	createdBy uuid.UUID,
+	archivedBy uuid.UUID,
+) *ArchivedFolder {
+	now := time.Now()
+	return &ArchivedFolder{
+		ID:               uuid.New(),
+		OriginalFolderID: originalFolderID,
+		OriginalParentID: originalParentID,
+		OriginalPath:     originalPath,
+		Name:             name,
+		OwnerID:          ownerID,
+		CreatedBy:        createdBy,
+		ArchivedAt:       now,
+		ArchivedBy:       archivedBy,
+		ExpiresAt:        now.AddDate(0, 0, TrashRetentionDays),
+	}
+}
+
+// ReconstructArchivedFolder はDBからArchivedFolderを復元します
+func ReconstructArchivedFolder(
+	id uuid.UUID,
+	originalFolderID uuid.UUID,
+	originalParentID *uuid.UUID,
+	originalPath string,
+	name valueobject.FolderName,
+	ownerID uuid.UUID,
+	createdBy uuid.UUID,
+	archivedAt time.Time,
+	archivedBy uuid.UUID,
+	expiresAt time.Time,
+) *ArchivedFolder {
+	return &ArchivedFolder{
+		ID:               id,
+		OriginalFolderID: originalFolderID,
+		OriginalParentID: originalParentID,
+		OriginalPath:     originalPath,
+		Name:             name,
+		OwnerID:          ownerID,
+		CreatedBy:        createdBy,
+		ArchivedAt:       archivedAt,
+		ArchivedBy:       archivedBy,
+		ExpiresAt:        expiresAt,
+	}
+}
+
+// IsExpired は期限切れかどうかを判定します
+func (af *ArchivedFolder) IsExpired() bool {
+	return time.Now().After(af.ExpiresAt)
+}
+
+// IsOwnedBy は指定ユーザーが所有者かどうかを判定します
+func (af *ArchivedFolder) IsOwnedBy(ownerID uuid.UUID) bool {
+	return af.OwnerID == ownerID
+}
+
+// DaysUntilExpiration は期限切れまでの日数を返します
+func (af *ArchivedFolder) DaysUntilExpiration() int {
+	duration := time.Until(af.ExpiresAt)
+	if duration < 0 {
+		return 0
+	}
+	return int(duration.Hours() / 24)
+}
+
+// ToFolder は復元用のFolderデータを生成します
+// 元のフォルダIDを再利用し、深さは復元先に合わせて再計算します
+func (af *ArchivedFolder) ToFolder(restoreParentID *uuid.UUID, depth int) *Folder {
+	now := time.Now()
+	return ReconstructFolder(
+		af.OriginalFolderID,
+		af.Name,
+		restoreParentID,
+		af.OwnerID,
+		af.CreatedBy,
+		depth,
+		FolderStatusActive,
+		now,
+		now,
+	)
+}
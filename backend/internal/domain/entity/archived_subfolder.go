@@ -0,0 +1,84 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+)
+
+// ArchivedSubfolder はゴミ箱内フォルダのサブツリーに含まれるフォルダエンティティ
+type ArchivedSubfolder struct {
+	ID               uuid.UUID
+	ArchivedFolderID uuid.UUID
+	OriginalFolderID uuid.UUID
+	OriginalParentID uuid.UUID // サブツリー内の親フォルダの元ID
+	Name             valueobject.FolderName
+	OwnerID          uuid.UUID
+	CreatedBy        uuid.UUID
+	RelativeDepth    int // アーカイブフォルダのルートからの深さ（直下は1）
+}
+
+// NewArchivedSubfolder は新しいArchivedSubfolderを作成します
+func NewArchivedSubfolder(
+	archivedFolderID uuid.UUID,
+	originalFolderID uuid.UUID,
+	originalParentID uuid.UUID,
+	name valueobject.FolderName,
+	ownerID uuid.UUID,
+	createdBy uuid.UUID,
+	relativeDepth int,
+) *ArchivedSubfolder {
+	return &ArchivedSubfolder{
+		ID:               uuid.New(),
+		ArchivedFolderID: archivedFolderID,
+		OriginalFolderID: originalFolderID,
+		OriginalParentID: originalParentID,
+		Name:             name,
+		OwnerID:          ownerID,
+		CreatedBy:        createdBy,
+		RelativeDepth:    relativeDepth,
+	}
+}
+
+// ReconstructArchivedSubfolder はDBからArchivedSubfolderを復元します
+func ReconstructArchivedSubfolder(
+	id uuid.UUID,
+	archivedFolderID uuid.UUID,
+	originalFolderID uuid.UUID,
+	originalParentID uuid.UUID,
+	name valueobject.FolderName,
+	ownerID uuid.UUID,
+	createdBy uuid.UUID,
+	relativeDepth int,
+) *ArchivedSubfolder {
+	return &ArchivedSubfolder{
+		ID:               id,
+		ArchivedFolderID: archivedFolderID,
+		OriginalFolderID: originalFolderID,
+		OriginalParentID: originalParentID,
+		Name:             name,
+		OwnerID:          ownerID,
+		CreatedBy:        createdBy,
+		RelativeDepth:    relativeDepth,
+	}
+}
+
+// ToFolder は復元用のFolderデータを生成します
+// rootDepthは復元されたアーカイブフォルダのルートの深さです
+func (as *ArchivedSubfolder) ToFolder(rootDepth int) *Folder {
+	now := time.Now()
+	parentID := as.OriginalParentID
+	return ReconstructFolder(
+		as.OriginalFolderID,
+		as.Name,
+		&parentID,
+		as.OwnerID,
+		as.CreatedBy,
+		rootDepth+as.RelativeDepth,
+		FolderStatusActive,
+		now,
+		now,
+	)
+}
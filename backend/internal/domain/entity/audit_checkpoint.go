@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// auditCheckpointPayloadVersion は署名対象の形式を識別する接頭辞です
+const auditCheckpointPayloadVersion = "gc-storage/audit-checkpoint/v1"
+
+// AuditCheckpoint は1日分の監査ログの終端を署名付きで記録するチェックポイントです
+// チェックポイントを外部に保管しておくことで、チェーン全体の書き換えや末尾の削除を検出できる
+type AuditCheckpoint struct {
+	ID           uuid.UUID
+	Day          time.Time // 対象日（UTC 0時）
+	LastSequence int64     // 対象日の終了時点でのチェーン末尾のSequence
+	LastHash     []byte    // 対象日の終了時点でのチェーン末尾のHash
+	KeyID        string    // 署名鍵の識別子
+	Signature    []byte
+	CreatedAt    time.Time
+}
+
+// NewAuditCheckpoint は対象日の終了時点のチェーン末尾から未署名のチェックポイントを作成します
+func NewAuditCheckpoint(day time.Time, head *AuditLog) *AuditCheckpoint {
+	return &AuditCheckpoint{
+		ID:           uuid.New(),
+		Day:          AuditCheckpointDay(day),
+		LastSequence: head.Sequence,
+		LastHash:     head.Hash,
+		CreatedAt:    time.Now(),
+	}
+}
+
+// AuditCheckpointDay は日時を含むUTCの日付（0時）を返します
+func AuditCheckpointDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// DayEnd は対象日の終了日時（翌日0時、含まない）を返します
+func (c *AuditCheckpoint) DayEnd() time.Time {
+	return c.Day.AddDate(0, 0, 1)
+}
+
+// SigningPayload は署名対象のバイト列を返します
+func (c *AuditCheckpoint) SigningPayload() []byte {
+	return []byte(fmt.Sprintf("%s\nday=%s\nlast_seq=%d\nlast_hash=%s\n",
+		auditCheckpointPayloadVersion,
+		c.Day.UTC().Format(time.DateOnly),
+		c.LastSequence,
+		hex.EncodeToString(c.LastHash),
+	))
+}
@@ -11,9 +11,14 @@ type AuditAction string
 
 const (
 	AuditActionLogin          AuditAction = "auth.login"
+	AuditActionLoginFailed    AuditAction = "auth.login_failed"
 	AuditActionLogout         AuditAction = "auth.logout"
 	AuditActionRegister       AuditAction = "auth.register"
 	AuditActionPasswordChange AuditAction = "auth.password_change"
+	AuditActionPasswordReset  AuditAction = "auth.password_reset"
+
+	AuditActionUserUpdate        AuditAction = "user.update"
+	AuditActionUserProfileUpdate AuditAction = "user.profile_update"
 
 	AuditActionFileUpload   AuditAction = "file.upload"
 	AuditActionFileDownload AuditAction = "file.download"
@@ -21,19 +26,42 @@ const (
 	AuditActionFileMove     AuditAction = "file.move"
 	AuditActionFileTrash    AuditAction = "file.trash"
 	AuditActionFileRestore  AuditAction = "file.restore"
+	AuditActionFileDelete   AuditAction = "file.delete"
+	AuditActionFileCopy     AuditAction = "file.copy"
+
+	AuditActionFileUploadComplete AuditAction = "file.upload_complete"
+	AuditActionFileUploadAbort    AuditAction = "file.upload_abort"
+
+	AuditActionFileVersionRestore AuditAction = "file.version_restore"
+	AuditActionFileVersionPin     AuditAction = "file.version_pin"
+	AuditActionFileVersionUnpin   AuditAction = "file.version_unpin"
+
+	AuditActionVersionRetentionUpdate AuditAction = "version_retention.update"
 
-	AuditActionFolderCreate AuditAction = "folder.create"
-	AuditActionFolderRename AuditAction = "folder.rename"
-	AuditActionFolderMove   AuditAction = "folder.move"
-	AuditActionFolderDelete AuditAction = "folder.delete"
+	AuditActionFolderCreate  AuditAction = "folder.create"
+	AuditActionFolderRename  AuditAction = "folder.rename"
+	AuditActionFolderMove    AuditAction = "folder.move"
+	AuditActionFolderDelete  AuditAction = "folder.delete"
+	AuditActionFolderRestore AuditAction = "folder.restore"
+	AuditActionFolderCopy    AuditAction = "folder.copy"
 
-	AuditActionGroupCreate       AuditAction = "group.create"
-	AuditActionGroupDelete       AuditAction = "group.delete"
-	AuditActionGroupMemberInvite AuditAction = "group.member_invite"
-	AuditActionGroupMemberRemove AuditAction = "group.member_remove"
-	AuditActionGroupMemberLeave  AuditAction = "group.member_leave"
+	AuditActionTrashEmpty AuditAction = "trash.empty"
+
+	AuditActionGroupCreate            AuditAction = "group.create"
+	AuditActionGroupUpdate            AuditAction = "group.update"
+	AuditActionGroupDelete            AuditAction = "group.delete"
+	AuditActionGroupMemberInvite      AuditAction = "group.member_invite"
+	AuditActionGroupInvitationCancel  AuditAction = "group.invitation_cancel"
+	AuditActionGroupInvitationDecline AuditAction = "group.invitation_decline"
+	AuditActionGroupMemberJoin        AuditAction = "group.member_join"
+	AuditActionGroupMemberRemove      AuditAction = "group.member_remove"
+	AuditActionGroupMemberLeave       AuditAction = "group.member_leave"
+	AuditActionGroupMemberRoleChange  AuditAction = "group.member_role_change"
+	AuditActionGroupOwnerTransfer     AuditAction = "group.owner_transfer"
+	AuditActionGroupQuotaUpdate       AuditAction = "group.quota_update"
 
 	AuditActionShareLinkCreate AuditAction = "share.link_create"
+	AuditActionShareLinkUpdate AuditAction = "share.link_update"
 	AuditActionShareLinkRevoke AuditAction = "share.link_revoke"
 	AuditActionShareLinkAccess AuditAction = "share.link_access"
 
@@ -53,7 +81,17 @@ const (
 	AuditResourcePermission AuditResourceType = "permission"
 )
 
+// IsValid はリソースの種類が有効かを判定します
+func (t AuditResourceType) IsValid() bool {
+	switch t {
+	case AuditResourceUser, AuditResourceFile, AuditResourceFolder, AuditResourceGroup, AuditResourceShareLink, AuditResourcePermission:
+		return true
+	}
+	return false
+}
+
 // AuditLog は監査ログエントリを表します
+// Sequence / PrevHash / Hash はハッシュチェーンの情報で、チェーン導入前に記録されたエントリではゼロ値です
 type AuditLog struct {
 	ID           uuid.UUID
 	UserID       *uuid.UUID
@@ -64,5 +102,8 @@ type AuditLog struct {
 	IPAddress    string
 	UserAgent    string
 	RequestID    string
+	Sequence     int64  // チェーン上の位置（1始まり、欠番なし）
+	PrevHash     []byte // 直前のエントリのHash（先頭のエントリは空）
+	Hash         []byte // PrevHashを含む正規化した内容のSHA-256
 	CreatedAt    time.Time
 }
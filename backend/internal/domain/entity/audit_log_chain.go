@@ -0,0 +1,125 @@
+package entity
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// auditLogCanonicalVersion は正規化形式のバージョンです
+// 形式を変更する場合は既存エントリを検証できるよう新しいバージョンとして追加する
+const auditLogCanonicalVersion = 1
+
+// auditLogCanonical はハッシュ計算に使う監査ログの正規化表現です
+// フィールドの順序は固定で、JSONのキー順もこの定義順になります
+type auditLogCanonical struct {
+	Version      int             `json:"v"`
+	Sequence     int64           `json:"seq"`
+	PrevHash     string          `json:"prev_hash"`
+	ID           string          `json:"id"`
+	UserID       *string         `json:"user_id"`
+	Action       string          `json:"action"`
+	ResourceType string          `json:"resource_type"`
+	ResourceID   *string         `json:"resource_id"`
+	Details      json.RawMessage `json:"details"`
+	IPAddress    string          `json:"ip_address"`
+	UserAgent    string          `json:"user_agent"`
+	RequestID    string          `json:"request_id"`
+	CreatedAt    string          `json:"created_at"`
+}
+
+// IsChained はエントリがハッシュチェーンに含まれるかを判定します
+func (l *AuditLog) IsChained() bool {
+	return l.Sequence > 0
+}
+
+// ChainTo はエントリをprevの次に連結し、Sequence / PrevHash / Hash を設定します
+// prevがnilの場合はチェーンの先頭になります
+// Note: 記録日時はDBの精度（マイクロ秒）に丸めてからハッシュを計算する
+func (l *AuditLog) ChainTo(prev *AuditLog) error {
+	l.Sequence = 1
+	l.PrevHash = nil
+	if prev != nil {
+		l.Sequence = prev.Sequence + 1
+		l.PrevHash = prev.Hash
+	}
+	l.CreatedAt = l.CreatedAt.UTC().Truncate(time.Microsecond)
+
+	hash, err := l.ComputeHash()
+	if err != nil {
+		return err
+	}
+	l.Hash = hash
+	return nil
+}
+
+// ComputeHash は現在の内容とPrevHashからハッシュを計算します
+func (l *AuditLog) ComputeHash() ([]byte, error) {
+	content, err := l.CanonicalContent()
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(content)
+	return sum[:], nil
+}
+
+// VerifyHash は保存されているHashが内容と一致するかを判定します
+func (l *AuditLog) VerifyHash() (bool, error) {
+	hash, err := l.ComputeHash()
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(hash, l.Hash), nil
+}
+
+// CanonicalContent はハッシュ計算対象の正規化した内容を返します
+// Details はJSONBへの保存と読み出しで表現が変わらないよう、一度デコードしてから再エンコードします
+func (l *AuditLog) CanonicalContent() ([]byte, error) {
+	details, err := canonicalAuditDetails(l.Details)
+	if err != nil {
+		return nil, err
+	}
+
+	c := auditLogCanonical{
+		Version:      auditLogCanonicalVersion,
+		Sequence:     l.Sequence,
+		PrevHash:     hex.EncodeToString(l.PrevHash),
+		ID:           l.ID.String(),
+		Action:       string(l.Action),
+		ResourceType: string(l.ResourceType),
+		Details:      details,
+		IPAddress:    l.IPAddress,
+		UserAgent:    l.UserAgent,
+		RequestID:    l.RequestID,
+		CreatedAt:    l.CreatedAt.UTC().Format(time.RFC3339Nano),
+	}
+	if l.UserID != nil {
+		userID := l.UserID.String()
+		c.UserID = &userID
+	}
+	if l.ResourceID != nil {
+		resourceID := l.ResourceID.String()
+		c.ResourceID = &resourceID
+	}
+
+	return json.Marshal(c)
+}
+
+// canonicalAuditDetails はDetailsをキー順・数値表現の揃ったJSONに変換します
+func canonicalAuditDetails(details map[string]interface{}) (json.RawMessage, error) {
+	if details == nil {
+		return json.RawMessage("null"), nil
+	}
+
+	raw, err := json.Marshal(details)
+	if err != nil {
+		return nil, err
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil, err
+	}
+	return json.Marshal(decoded)
+}
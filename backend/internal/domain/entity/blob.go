@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+)
+
+// Blob は内容（SHA-256）で識別される保存済みオブジェクトを表すエンティティ
+// 同じ内容のファイルバージョンは1つのMinIOオブジェクトバージョンを共有し、
+// 参照するバージョンがなくなった時点でオブジェクトを削除する
+type Blob struct {
+	ID             uuid.UUID
+	Checksum       string                 // SHA-256チェックサム（16進数）
+	StorageKey     valueobject.StorageKey // 最初にアップロードされたファイルのキー
+	MinioVersionID string
+	Size           int64
+	RefCount       int // 参照しているファイルバージョン（ゴミ箱内を含む）の数
+	CreatedAt      time.Time
+}
+
+// NewBlob はアップロードされたオブジェクトバージョンから参照数1のBlobを作成します
+func NewBlob(checksum string, storageKey valueobject.StorageKey, minioVersionID string, size int64) *Blob {
+	return &Blob{
+		ID:             uuid.New(),
+		Checksum:       checksum,
+		StorageKey:     storageKey,
+		MinioVersionID: minioVersionID,
+		Size:           size,
+		RefCount:       1,
+		CreatedAt:      time.Now(),
+	}
+}
+
+// ReconstructBlob はDBからBlobを復元します
+func ReconstructBlob(
+	id uuid.UUID,
+	checksum string,
+	storageKey valueobject.StorageKey,
+	minioVersionID string,
+	size int64,
+	refCount int,
+	createdAt time.Time,
+) *Blob {
+	return &Blob{
+		ID:             id,
+		Checksum:       checksum,
+		StorageKey:     storageKey,
+		MinioVersionID: minioVersionID,
+		Size:           size,
+		RefCount:       refCount,
+		CreatedAt:      createdAt,
+	}
+}
+
+// IsReferenced はいずれかのファイルバージョンから参照されているかを判定します
+func (b *Blob) IsReferenced() bool {
+	return b.RefCount > 0
+}
@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+)
+
+func TestNewBlob_HasSingleReference(t *testing.T) {
+	key := valueobject.NewStorageKey(uuid.New())
+
+	blob := NewBlob("abc123", key, "minio-v1", 1024)
+
+	if blob.ID == uuid.Nil {
+		t.Error("NewBlob should assign a non-nil ID")
+	}
+	if blob.RefCount != 1 {
+		t.Errorf("expected RefCount 1, got %d", blob.RefCount)
+	}
+	if !blob.IsReferenced() {
+		t.Error("new blob should be referenced")
+	}
+	if blob.StorageKey != key || blob.MinioVersionID != "minio-v1" || blob.Size != 1024 {
+		t.Error("expected blob to record the uploaded object version")
+	}
+}
+
+func TestBlob_IsReferenced_ZeroRefCount_ReturnsFalse(t *testing.T) {
+	blob := NewBlob("abc123", valueobject.NewStorageKey(uuid.New()), "minio-v1", 1024)
+	blob.RefCount = 0
+
+	if blob.IsReferenced() {
+		t.Error("blob without references should not be referenced")
+	}
+}
@@ -0,0 +1,107 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CopyJobStatus は非同期コピージョブのステータスを定義します
+type CopyJobStatus string
+
+const (
+	CopyJobStatusPending   CopyJobStatus = "pending"
+	CopyJobStatusRunning   CopyJobStatus = "running"
+	CopyJobStatusSucceeded CopyJobStatus = "succeeded"
+	CopyJobStatusFailed    CopyJobStatus = "failed"
+)
+
+// CopyJob はバックグラウンドで実行するフォルダコピーを表すエンティティ
+// 大きなサブツリーのコピーはリクエスト内で完了させず、ジョブとして登録して進捗を追跡する
+type CopyJob struct {
+	ID                  uuid.UUID
+	UserID              uuid.UUID
+	SourceFolderID      uuid.UUID
+	DestinationFolderID uuid.UUID
+	Status              CopyJobStatus
+	ResultFolderID      *uuid.UUID // コピー先に作成されたルートフォルダ（成功時のみ）
+	FolderCount         int
+	FileCount           int
+	ErrorMessage        *string
+	CreatedAt           time.Time
+	StartedAt           *time.Time
+	FinishedAt          *time.Time
+	LeaseToken          *uuid.UUID // 実行中のジョブを取得したレプリカが保持するリース
+	HeartbeatAt         *time.Time // リースを保持するレプリカが最後に生存を記録した日時
+}
+
+// NewCopyJob は新しいCopyJobをpending状態で作成します
+func NewCopyJob(userID, sourceFolderID, destinationFolderID uuid.UUID) *CopyJob {
+	return &CopyJob{
+		ID:                  uuid.New(),
+		UserID:              userID,
+		SourceFolderID:      sourceFolderID,
+		DestinationFolderID: destinationFolderID,
+		Status:              CopyJobStatusPending,
+		CreatedAt:           time.Now(),
+	}
+}
+
+// ReconstructCopyJob はDBからCopyJobを復元します
+func ReconstructCopyJob(
+	id uuid.UUID,
+	userID uuid.UUID,
+	sourceFolderID uuid.UUID,
+	destinationFolderID uuid.UUID,
+	status CopyJobStatus,
+	resultFolderID *uuid.UUID,
+	folderCount int,
+	fileCount int,
+	errorMessage *string,
+	createdAt time.Time,
+	startedAt *time.Time,
+	finishedAt *time.Time,
+	leaseToken *uuid.UUID,
+	heartbeatAt *time.Time,
+) *CopyJob {
+	return &CopyJob{
+		ID:                  id,
+		UserID:              userID,
+		SourceFolderID:      sourceFolderID,
+		DestinationFolderID: destinationFolderID,
+		Status:              status,
+		ResultFolderID:      resultFolderID,
+		FolderCount:         folderCount,
+		FileCount:           fileCount,
+		ErrorMessage:        errorMessage,
+		CreatedAt:           createdAt,
+		StartedAt:           startedAt,
+		FinishedAt:          finishedAt,
+		LeaseToken:          leaseToken,
+		HeartbeatAt:         heartbeatAt,
+	}
+}
+
+// Succeed はジョブを成功として完了します
+func (j *CopyJob) Succeed(resultFolderID uuid.UUID, folderCount, fileCount int) {
+	now := time.Now()
+	j.Status = CopyJobStatusSucceeded
+	j.ResultFolderID = &resultFolderID
+	j.FolderCount = folderCount
+	j.FileCount = fileCount
+	j.ErrorMessage = nil
+	j.FinishedAt = &now
+}
+
+// Fail はジョブを失敗として完了します
+func (j *CopyJob) Fail(message string) {
+	now := time.Now()
+	j.Status = CopyJobStatusFailed
+	j.ErrorMessage = &message
+	j.FinishedAt = &now
+}
+
+// IsFinished はジョブが完了（成功または失敗）しているかを判定します
+func (j *CopyJob) IsFinished() bool {
+	return j.Status == CopyJobStatusSucceeded || j.Status == CopyJobStatusFailed
+}
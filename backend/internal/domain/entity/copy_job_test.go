@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNewCopyJob_IsPending(t *testing.T) {
+	job := NewCopyJob(uuid.New(), uuid.New(), uuid.New())
+
+	if job.Status != CopyJobStatusPending {
+		t.Errorf("expected status pending, got %s", job.Status)
+	}
+	if job.IsFinished() {
+		t.Error("new copy job should not be finished")
+	}
+	if job.StartedAt != nil || job.FinishedAt != nil {
+		t.Error("new copy job should not have started")
+	}
+}
+
+func TestCopyJob_Succeed_RecordsResult(t *testing.T) {
+	job := NewCopyJob(uuid.New(), uuid.New(), uuid.New())
+	resultID := uuid.New()
+
+	job.Succeed(resultID, 3, 12)
+
+	if job.Status != CopyJobStatusSucceeded {
+		t.Errorf("expected status succeeded, got %s", job.Status)
+	}
+	if job.ResultFolderID == nil || *job.ResultFolderID != resultID {
+		t.Error("expected ResultFolderID to be set")
+	}
+	if job.FolderCount != 3 || job.FileCount != 12 {
+		t.Errorf("expected counts 3/12, got %d/%d", job.FolderCount, job.FileCount)
+	}
+	if !job.IsFinished() || job.FinishedAt == nil {
+		t.Error("succeeded copy job should be finished")
+	}
+}
+
+func TestCopyJob_Fail_RecordsMessage(t *testing.T) {
+	job := NewCopyJob(uuid.New(), uuid.New(), uuid.New())
+
+	job.Fail("storage quota exceeded")
+
+	if job.Status != CopyJobStatusFailed {
+		t.Errorf("expected status failed, got %s", job.Status)
+	}
+	if job.ErrorMessage == nil || *job.ErrorMessage != "storage quota exceeded" {
+		t.Error("expected ErrorMessage to be recorded")
+	}
+	if job.ResultFolderID != nil {
+		t.Error("failed copy job should not have a result folder")
+	}
+	if !job.IsFinished() {
+		t.Error("failed copy job should be finished")
+	}
+}
@@ -16,6 +16,7 @@ const (
 	FileStatusUploading    FileStatus = "uploading"
 	FileStatusActive       FileStatus = "active"
 	FileStatusUploadFailed FileStatus = "upload_failed"
+	FileStatusBroken       FileStatus = "broken" // ストレージ上のオブジェクトが欠損または不一致
 )
 
 // ファイル関連エラー
@@ -205,6 +206,21 @@ func (f *File) IsUploadFailed() bool {
 	return f.Status == FileStatusUploadFailed
 }
 
+// MarkBroken はストレージ上の実体が欠損・破損していることをマークします
+func (f *File) MarkBroken() error {
+	if f.Status != FileStatusActive {
+		return ErrFileInvalidTransition
+	}
+	f.Status = FileStatusBroken
+	f.UpdatedAt = time.Now()
+	return nil
+}
+
+// IsBroken は破損状態かどうかを判定します
+func (f *File) IsBroken() bool {
+	return f.Status == FileStatusBroken
+}
+
 // IsOwnedBy は指定ユーザーが所有者かどうかを判定します
 // Note: ファイルは常にユーザーが所有者（グループはPermissionGrantでアクセス）
 func (f *File) IsOwnedBy(ownerID uuid.UUID) bool {
@@ -220,6 +220,29 @@ func TestFile_MarkUploadFailed_FromActive_ReturnsErrInvalidTransition(t *testing
 	}
 }
 
+func TestFile_MarkBroken_FromActive_SetsStatusBroken(t *testing.T) {
+	file := newActiveFile()
+
+	err := file.MarkBroken()
+
+	if err != nil {
+		t.Errorf("MarkBroken on active file should not return error, got: %v", err)
+	}
+	if !file.IsBroken() {
+		t.Errorf("expected status %q, got %q", FileStatusBroken, file.Status)
+	}
+}
+
+func TestFile_MarkBroken_FromUploading_ReturnsErrInvalidTransition(t *testing.T) {
+	file := newUploadingFile()
+
+	err := file.MarkBroken()
+
+	if err != ErrFileInvalidTransition {
+		t.Errorf("expected ErrFileInvalidTransition, got: %v", err)
+	}
+}
+
 func TestFile_IncrementVersion_IncrementsCurrentVersion(t *testing.T) {
 	file := newActiveFile()
 	initialVersion := file.CurrentVersion
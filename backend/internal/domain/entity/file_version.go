@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
 )
 
 // FileVersion はファイルバージョンエンティティ
@@ -11,14 +13,18 @@ type FileVersion struct {
 	ID             uuid.UUID
 	FileID         uuid.UUID
 	VersionNumber  int
-	MinioVersionID string // MinIOが自動生成するバージョンID
+	StorageKey     valueobject.StorageKey // 内容が保存されているオブジェクトのキー
+	MinioVersionID string                 // MinIOが自動生成するバージョンID
+	BlobID         *uuid.UUID             // 共有しているブロブ（重複排除導入前のバージョンはnil）
 	Size           int64
 	Checksum       string // SHA-256チェックサム（必須）
 	UploadedBy     uuid.UUID
+	IsPinned       bool // ピン留めされたバージョンは保持ポリシーで削除されない
 	CreatedAt      time.Time
 }
 
 // NewFileVersion は新しいファイルバージョンを作成します
+// 内容はファイル自身のキーに保存されているものとして扱い、共有する場合はReferenceBlobで置き換えます
 func NewFileVersion(
 	fileID uuid.UUID,
 	versionNumber int,
@@ -31,6 +37,7 @@ func NewFileVersion(
 		ID:             uuid.New(),
 		FileID:         fileID,
 		VersionNumber:  versionNumber,
+		StorageKey:     valueobject.NewStorageKey(fileID),
 		MinioVersionID: minioVersionID,
 		Size:           size,
 		Checksum:       checksum,
@@ -44,20 +51,26 @@ func ReconstructFileVersion(
 	id uuid.UUID,
 	fileID uuid.UUID,
 	versionNumber int,
+	storageKey valueobject.StorageKey,
 	minioVersionID string,
+	blobID *uuid.UUID,
 	size int64,
 	checksum string,
 	uploadedBy uuid.UUID,
+	isPinned bool,
 	createdAt time.Time,
 ) *FileVersion {
 	return &FileVersion{
 		ID:             id,
 		FileID:         fileID,
 		VersionNumber:  versionNumber,
+		StorageKey:     storageKey,
 		MinioVersionID: minioVersionID,
+		BlobID:         blobID,
 		Size:           size,
 		Checksum:       checksum,
 		UploadedBy:     uploadedBy,
+		IsPinned:       isPinned,
 		CreatedAt:      createdAt,
 	}
 }
@@ -67,6 +80,29 @@ func (fv *FileVersion) IsLatest(currentVersion int) bool {
 	return fv.VersionNumber == currentVersion
 }
 
+// Pin はバージョンをピン留めします
+func (fv *FileVersion) Pin() {
+	fv.IsPinned = true
+}
+
+// Unpin はバージョンのピン留めを解除します
+func (fv *FileVersion) Unpin() {
+	fv.IsPinned = false
+}
+
+// ReferenceBlob はバージョンの内容をブロブの共有オブジェクトに置き換えます
+func (fv *FileVersion) ReferenceBlob(blob *Blob) {
+	fv.StorageKey = blob.StorageKey
+	fv.MinioVersionID = blob.MinioVersionID
+	fv.BlobID = &blob.ID
+	fv.Checksum = blob.Checksum
+}
+
+// IsShared はブロブを参照しているかを判定します
+func (fv *FileVersion) IsShared() bool {
+	return fv.BlobID != nil
+}
+
 // ToArchived はアーカイブ用のデータを生成します
 func (fv *FileVersion) ToArchived(archivedFileID uuid.UUID) *ArchivedFileVersion {
 	return &ArchivedFileVersion{
@@ -74,10 +110,24 @@ func (fv *FileVersion) ToArchived(archivedFileID uuid.UUID) *ArchivedFileVersion
 		ArchivedFileID:    archivedFileID,
 		OriginalVersionID: fv.ID,
 		VersionNumber:     fv.VersionNumber,
+		StorageKey:        fv.StorageKey,
 		MinioVersionID:    fv.MinioVersionID,
+		BlobID:            fv.BlobID,
 		Size:              fv.Size,
 		Checksum:          fv.Checksum,
 		UploadedBy:        fv.UploadedBy,
+		IsPinned:          fv.IsPinned,
 		CreatedAt:         fv.CreatedAt,
 	}
 }
+
+// FileVersionBlobIDs はバージョンが参照しているブロブのIDを返します
+func FileVersionBlobIDs(versions []*FileVersion) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(versions))
+	for _, v := range versions {
+		if v.BlobID != nil {
+			ids = append(ids, *v.BlobID)
+		}
+	}
+	return ids
+}
@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
 )
 
 func TestNewFileVersion_SetsFieldsCorrectly(t *testing.T) {
@@ -43,6 +45,12 @@ func TestNewFileVersion_SetsFieldsCorrectly(t *testing.T) {
 	if fv.CreatedAt.Before(before) || fv.CreatedAt.After(after) {
 		t.Errorf("expected CreatedAt between %v and %v, got %v", before, after, fv.CreatedAt)
 	}
+	if fv.StorageKey != valueobject.NewStorageKey(fileID) {
+		t.Errorf("expected StorageKey of the file itself, got %v", fv.StorageKey)
+	}
+	if fv.IsShared() {
+		t.Error("NewFileVersion should not reference a blob")
+	}
 }
 
 func TestFileVersion_IsLatest_MatchingVersion_ReturnsTrue(t *testing.T) {
@@ -60,3 +68,50 @@ func TestFileVersion_IsLatest_NonMatchingVersion_ReturnsFalse(t *testing.T) {
 		t.Error("IsLatest should return false when VersionNumber does not match currentVersion")
 	}
 }
+
+func TestFileVersion_ReferenceBlob_UsesBlobLocation(t *testing.T) {
+	fv := NewFileVersion(uuid.New(), 1, "minio-upload", 512, "etag", uuid.New())
+	blob := NewBlob("abc123", valueobject.NewStorageKey(uuid.New()), "minio-blob", 512)
+
+	fv.ReferenceBlob(blob)
+
+	if !fv.IsShared() || *fv.BlobID != blob.ID {
+		t.Error("expected BlobID to be the blob's ID")
+	}
+	if fv.StorageKey != blob.StorageKey {
+		t.Errorf("expected StorageKey %v, got %v", blob.StorageKey, fv.StorageKey)
+	}
+	if fv.MinioVersionID != "minio-blob" {
+		t.Errorf("expected MinioVersionID %q, got %q", "minio-blob", fv.MinioVersionID)
+	}
+	if fv.Checksum != "abc123" {
+		t.Errorf("expected Checksum %q, got %q", "abc123", fv.Checksum)
+	}
+}
+
+func TestFileVersion_ToArchived_KeepsContentLocation(t *testing.T) {
+	fv := NewFileVersion(uuid.New(), 2, "minio-upload", 512, "etag", uuid.New())
+	fv.ReferenceBlob(NewBlob("abc123", valueobject.NewStorageKey(uuid.New()), "minio-blob", 512))
+
+	archived := fv.ToArchived(uuid.New())
+	restored := archived.ToFileVersion(fv.FileID)
+
+	if archived.StorageKey != fv.StorageKey || archived.BlobID != fv.BlobID {
+		t.Error("expected archived version to keep the content location")
+	}
+	if restored.StorageKey != fv.StorageKey || restored.MinioVersionID != fv.MinioVersionID || restored.BlobID != fv.BlobID {
+		t.Error("expected restored version to keep the content location")
+	}
+}
+
+func TestFileVersionBlobIDs_SkipsUnsharedVersions(t *testing.T) {
+	shared := NewFileVersion(uuid.New(), 1, "minio-v1", 512, "etag", uuid.New())
+	shared.ReferenceBlob(NewBlob("abc123", valueobject.NewStorageKey(uuid.New()), "minio-blob", 512))
+	unshared := NewFileVersion(uuid.New(), 2, "minio-v2", 512, "etag", uuid.New())
+
+	ids := FileVersionBlobIDs([]*FileVersion{shared, unshared})
+
+	if len(ids) != 1 || ids[0] != *shared.BlobID {
+		t.Errorf("expected only the shared version's blob, got %v", ids)
+	}
+}
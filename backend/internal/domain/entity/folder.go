@@ -35,8 +35,8 @@ var (
 )
 
 // Folder はフォルダエンティティ（集約ルート）
-// Note: フォルダにはゴミ箱がない。削除時、配下のファイルはArchivedFileへ移動し、
-// フォルダ自体は直接削除される。
+// Note: 削除時はサブツリーごとArchivedFolderとしてゴミ箱に移動し、
+// 配下のフォルダはArchivedSubfolder、ファイルはArchivedFileとして保持される。
 // Note: owner_typeは削除。フォルダは常にユーザーが所有者。グループはPermissionGrantでアクセス。
 type Folder struct {
 	ID        uuid.UUID
@@ -180,3 +180,29 @@ func (f *Folder) TransferOwnership(newOwnerID uuid.UUID) {
 	f.OwnerID = newOwnerID
 	f.UpdatedAt = time.Now()
 }
+
+// ToArchived はサブツリーのルートとしてアーカイブ用のデータを生成します
+func (f *Folder) ToArchived(originalPath string, archivedBy uuid.UUID) *ArchivedFolder {
+	return NewArchivedFolder(
+		f.ID,
+		f.ParentID,
+		originalPath,
+		f.Name,
+		f.OwnerID,
+		f.CreatedBy,
+		archivedBy,
+	)
+}
+
+// ToArchivedSubfolder はアーカイブされるサブツリー内のフォルダとしてアーカイブ用のデータを生成します
+func (f *Folder) ToArchivedSubfolder(archivedFolderID uuid.UUID, relativeDepth int) *ArchivedSubfolder {
+	return NewArchivedSubfolder(
+		archivedFolderID,
+		f.ID,
+		*f.ParentID,
+		f.Name,
+		f.OwnerID,
+		f.CreatedBy,
+		relativeDepth,
+	)
+}
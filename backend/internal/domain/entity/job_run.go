@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JobRunStatus はバックグラウンドジョブ実行結果のステータスを定義します
+type JobRunStatus string
+
+const (
+	JobRunStatusSucceeded JobRunStatus = "succeeded"
+	JobRunStatusFailed    JobRunStatus = "failed"
+)
+
+// JobRun はバックグラウンドジョブの実行履歴を表すエンティティ
+type JobRun struct {
+	ID           uuid.UUID
+	JobName      string
+	InstanceID   string // ジョブを実行したAPIレプリカの識別子
+	Status       JobRunStatus
+	ErrorMessage *string
+	StartedAt    time.Time
+	FinishedAt   time.Time
+}
+
+// NewJobRun は実行結果から新しいJobRunを作成します
+// runErr が nil の場合は成功、それ以外は失敗として記録されます
+func NewJobRun(jobName, instanceID string, startedAt, finishedAt time.Time, runErr error) *JobRun {
+	run := &JobRun{
+		ID:         uuid.New(),
+		JobName:    jobName,
+		InstanceID: instanceID,
+		Status:     JobRunStatusSucceeded,
+		StartedAt:  startedAt,
+		FinishedAt: finishedAt,
+	}
+	if runErr != nil {
+		msg := runErr.Error()
+		run.Status = JobRunStatusFailed
+		run.ErrorMessage = &msg
+	}
+	return run
+}
+
+// ReconstructJobRun はDBからJobRunを復元します
+func ReconstructJobRun(
+	id uuid.UUID,
+	jobName string,
+	instanceID string,
+	status JobRunStatus,
+	errorMessage *string,
+	startedAt time.Time,
+	finishedAt time.Time,
+) *JobRun {
+	return &JobRun{
+		ID:           id,
+		JobName:      jobName,
+		InstanceID:   instanceID,
+		Status:       status,
+		ErrorMessage: errorMessage,
+		StartedAt:    startedAt,
+		FinishedAt:   finishedAt,
+	}
+}
+
+// Duration は実行時間を返します
+func (r *JobRun) Duration() time.Duration {
+	return r.FinishedAt.Sub(r.StartedAt)
+}
+
+// IsSucceeded は実行が成功したかを判定します
+func (r *JobRun) IsSucceeded() bool {
+	return r.Status == JobRunStatusSucceeded
+}
@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// QuotaSubjectType はクォータの対象種別を定義します
+type QuotaSubjectType string
+
+const (
+	QuotaSubjectUser  QuotaSubjectType = "user"
+	QuotaSubjectGroup QuotaSubjectType = "group"
+)
+
+// IsValid は対象種別が有効かを判定します
+func (t QuotaSubjectType) IsValid() bool {
+	return t == QuotaSubjectUser || t == QuotaSubjectGroup
+}
+
+// クォータ関連エラー
+var (
+	ErrInvalidQuotaLimit = errors.New("quota limit must not be negative")
+)
+
+// StorageQuota はストレージ容量の上限を表すエンティティ
+// Note: ユーザークォータは個人の使用量に、グループクォータはメンバー全員の使用量の合計に適用される
+type StorageQuota struct {
+	ID          uuid.UUID
+	SubjectType QuotaSubjectType
+	SubjectID   uuid.UUID
+	LimitBytes  int64
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// NewStorageQuota は新しいStorageQuotaを作成します
+func NewStorageQuota(subjectType QuotaSubjectType, subjectID uuid.UUID, limitBytes int64) (*StorageQuota, error) {
+	if limitBytes < 0 {
+		return nil, ErrInvalidQuotaLimit
+	}
+
+	now := time.Now()
+	return &StorageQuota{
+		ID:          uuid.New(),
+		SubjectType: subjectType,
+		SubjectID:   subjectID,
+		LimitBytes:  limitBytes,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// ReconstructStorageQuota はDBからStorageQuotaを復元します
+func ReconstructStorageQuota(
+	id uuid.UUID,
+	subjectType QuotaSubjectType,
+	subjectID uuid.UUID,
+	limitBytes int64,
+	createdAt time.Time,
+	updatedAt time.Time,
+) *StorageQuota {
+	return &StorageQuota{
+		ID:          id,
+		SubjectType: subjectType,
+		SubjectID:   subjectID,
+		LimitBytes:  limitBytes,
+		CreatedAt:   createdAt,
+		UpdatedAt:   updatedAt,
+	}
+}
+
+// UpdateLimit は上限を変更します
+func (q *StorageQuota) UpdateLimit(limitBytes int64) error {
+	if limitBytes < 0 {
+		return ErrInvalidQuotaLimit
+	}
+	q.LimitBytes = limitBytes
+	q.UpdatedAt = time.Now()
+	return nil
+}
+
+// Allows は現在の使用量に追加しても上限を超えないかを判定します
+func (q *StorageQuota) Allows(usedBytes, additionalBytes int64) bool {
+	return usedBytes+additionalBytes <= q.LimitBytes
+}
@@ -0,0 +1,107 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+)
+
+// ThumbnailStatus はサムネイル生成のステータスを定義します
+type ThumbnailStatus string
+
+const (
+	ThumbnailStatusPending ThumbnailStatus = "pending"
+	ThumbnailStatusRunning ThumbnailStatus = "running"
+	ThumbnailStatusReady   ThumbnailStatus = "ready"
+	ThumbnailStatusFailed  ThumbnailStatus = "failed"
+)
+
+// Thumbnail はファイルバージョンごとのサムネイル生成を表すエンティティ
+// 生成はバックグラウンドジョブで行い、全サイズのサムネイルを元の内容から派生したキーに保存する
+type Thumbnail struct {
+	ID              uuid.UUID
+	FileID          uuid.UUID
+	VersionNumber   int
+	SourceKey       valueobject.StorageKey // 元の内容が保存されているオブジェクトのキー
+	SourceVersionID string                 // 元の内容のMinIOバージョンID
+	Status          ThumbnailStatus
+	ErrorMessage    *string
+	CreatedAt       time.Time
+	StartedAt       *time.Time
+	FinishedAt      *time.Time
+}
+
+// NewThumbnail はファイルバージョンのサムネイルをpending状態で作成します
+func NewThumbnail(version *FileVersion) *Thumbnail {
+	return &Thumbnail{
+		ID:              uuid.New(),
+		FileID:          version.FileID,
+		VersionNumber:   version.VersionNumber,
+		SourceKey:       version.StorageKey,
+		SourceVersionID: version.MinioVersionID,
+		Status:          ThumbnailStatusPending,
+		CreatedAt:       time.Now(),
+	}
+}
+
+// ReconstructThumbnail はDBからThumbnailを復元します
+func ReconstructThumbnail(
+	id uuid.UUID,
+	fileID uuid.UUID,
+	versionNumber int,
+	sourceKey valueobject.StorageKey,
+	sourceVersionID string,
+	status ThumbnailStatus,
+	errorMessage *string,
+	createdAt time.Time,
+	startedAt *time.Time,
+	finishedAt *time.Time,
+) *Thumbnail {
+	return &Thumbnail{
+		ID:              id,
+		FileID:          fileID,
+		VersionNumber:   versionNumber,
+		SourceKey:       sourceKey,
+		SourceVersionID: sourceVersionID,
+		Status:          status,
+		ErrorMessage:    errorMessage,
+		CreatedAt:       createdAt,
+		StartedAt:       startedAt,
+		FinishedAt:      finishedAt,
+	}
+}
+
+// ObjectKey は指定サイズのサムネイルを保存するオブジェクトキーを返します
+// 元の内容のキーを接頭辞にすることで、ストレージ整合性チェックで派生オブジェクトとして扱われます
+func (t *Thumbnail) ObjectKey(size valueobject.ThumbnailSize) string {
+	return fmt.Sprintf("%s/thumbnails/%s/v%d/%s.jpg", t.SourceKey.String(), t.FileID, t.VersionNumber, size)
+}
+
+// MarkReady は全サイズの生成が完了したことを記録します
+func (t *Thumbnail) MarkReady() {
+	now := time.Now()
+	t.Status = ThumbnailStatusReady
+	t.ErrorMessage = nil
+	t.FinishedAt = &now
+}
+
+// Fail は生成の失敗を記録します
+func (t *Thumbnail) Fail(message string) {
+	now := time.Now()
+	t.Status = ThumbnailStatusFailed
+	t.ErrorMessage = &message
+	t.FinishedAt = &now
+}
+
+// IsReady はサムネイルを配信できるかを判定します
+func (t *Thumbnail) IsReady() bool {
+	return t.Status == ThumbnailStatusReady
+}
+
+// IsFinished は生成が完了（成功または失敗）しているかを判定します
+func (t *Thumbnail) IsFinished() bool {
+	return t.Status == ThumbnailStatusReady || t.Status == ThumbnailStatusFailed
+}
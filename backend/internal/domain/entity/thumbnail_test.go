@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+)
+
+func newThumbnailSourceVersion() *FileVersion {
+	return NewFileVersion(uuid.New(), 3, "minio-v3", 2048, "checksum", uuid.New())
+}
+
+func TestNewThumbnail_IsPendingForVersion(t *testing.T) {
+	version := newThumbnailSourceVersion()
+
+	thumb := NewThumbnail(version)
+
+	if thumb.Status != ThumbnailStatusPending {
+		t.Errorf("expected status pending, got %s", thumb.Status)
+	}
+	if thumb.FileID != version.FileID || thumb.VersionNumber != 3 {
+		t.Errorf("expected file %s version 3, got %s version %d", version.FileID, thumb.FileID, thumb.VersionNumber)
+	}
+	if thumb.SourceKey != version.StorageKey || thumb.SourceVersionID != "minio-v3" {
+		t.Error("expected source to be the version's object")
+	}
+	if thumb.IsReady() || thumb.IsFinished() {
+		t.Error("new thumbnail should not be finished")
+	}
+}
+
+func TestThumbnail_ObjectKey_DerivedFromSourceKey(t *testing.T) {
+	version := newThumbnailSourceVersion()
+	thumb := NewThumbnail(version)
+
+	got := thumb.ObjectKey(valueobject.ThumbnailSizeSmall)
+
+	want := fmt.Sprintf("%s/thumbnails/%s/v3/small.jpg", version.StorageKey.String(), version.FileID)
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestThumbnail_MarkReady(t *testing.T) {
+	thumb := NewThumbnail(newThumbnailSourceVersion())
+
+	thumb.MarkReady()
+
+	if !thumb.IsReady() || !thumb.IsFinished() {
+		t.Error("expected thumbnail to be ready")
+	}
+	if thumb.FinishedAt == nil {
+		t.Error("expected FinishedAt to be set")
+	}
+}
+
+func TestThumbnail_Fail_RecordsMessage(t *testing.T) {
+	thumb := NewThumbnail(newThumbnailSourceVersion())
+
+	thumb.Fail("unsupported image format")
+
+	if thumb.Status != ThumbnailStatusFailed {
+		t.Errorf("expected status failed, got %s", thumb.Status)
+	}
+	if thumb.ErrorMessage == nil || *thumb.ErrorMessage != "unsupported image format" {
+		t.Error("expected ErrorMessage to be set")
+	}
+	if thumb.IsReady() || !thumb.IsFinished() {
+		t.Error("failed thumbnail should be finished but not ready")
+	}
+}
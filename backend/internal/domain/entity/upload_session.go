@@ -18,6 +18,8 @@ const (
 	UploadSessionStatusCompleted  UploadSessionStatus = "completed"
 	UploadSessionStatusAborted    UploadSessionStatus = "aborted"
 	UploadSessionStatusExpired    UploadSessionStatus = "expired"
+	// 全内容を受信し、バックグラウンドで内容の検証を待っている状態
+	UploadSessionStatusVerifying UploadSessionStatus = "verifying"
 )
 
 // アップロード関連定数
@@ -35,6 +37,7 @@ var (
 	ErrUploadSessionCompleted     = errors.New("upload session already completed")
 	ErrUploadSessionAborted       = errors.New("upload session already aborted")
 	ErrUploadSessionInvalidStatus = errors.New("invalid upload session status")
+	ErrUploadSizeExceeded         = errors.New("upload exceeds the declared size")
 )
 
 // UploadSession はアップロードセッションエンティティ
@@ -57,6 +60,13 @@ type UploadSession struct {
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
 	ExpiresAt     time.Time
+
+	// クライアントが申告した内容のチェックサム（未指定の場合はnil）
+	ExpectedChecksum *valueobject.ContentChecksum
+
+	// 検証待ちの間に保持する、受信したオブジェクトバージョンとサイズ
+	ReceivedVersionID string
+	ReceivedSize      int64
 }
 
 // NewUploadSession は新しいアップロードセッションを作成します
@@ -98,6 +108,29 @@ func NewUploadSession(
 	}
 }
 
+// NewVersionUploadSession は既存ファイルの新バージョン用アップロードセッションを作成します
+// owner_id はファイルの所有者、created_by はアップロード者となります
+// ストレージキーは既存ファイルと共有し、MinIOのバージョニングで新バージョンとして保存されます
+func NewVersionUploadSession(
+	file *File,
+	createdBy uuid.UUID,
+	totalSize int64,
+	minioUploadID *string,
+) *UploadSession {
+	session := NewUploadSession(
+		file.ID,
+		createdBy,
+		file.FolderID,
+		file.Name,
+		file.MimeType,
+		totalSize,
+		minioUploadID,
+	)
+	session.OwnerID = file.OwnerID
+	session.StorageKey = file.StorageKey
+	return session
+}
+
 // ReconstructUploadSession はDBからアップロードセッションを復元します
 func ReconstructUploadSession(
 	id uuid.UUID,
@@ -139,6 +172,27 @@ func ReconstructUploadSession(
 	}
 }
 
+// SetExpectedChecksum はアップロード完了時に検証するチェックサムを設定します
+func (us *UploadSession) SetExpectedChecksum(checksum *valueobject.ContentChecksum) {
+	us.ExpectedChecksum = checksum
+}
+
+// VerifyContent はアップロードされた内容のダイジェスト（16進数）が申告されたチェックサムと一致するかを判定します
+// チェックサムが申告されていない場合は常にtrueを返します
+func (us *UploadSession) VerifyContent(sha256Hex, crc32cHex string) bool {
+	if us.ExpectedChecksum == nil {
+		return true
+	}
+	switch us.ExpectedChecksum.Algorithm() {
+	case valueobject.ChecksumAlgorithmSHA256:
+		return us.ExpectedChecksum.Matches(sha256Hex)
+	case valueobject.ChecksumAlgorithmCRC32C:
+		return us.ExpectedChecksum.Matches(crc32cHex)
+	default:
+		return false
+	}
+}
+
 // Complete はアップロードを完了状態にします
 func (us *UploadSession) Complete() error {
 	if us.Status == UploadSessionStatusCompleted {
@@ -147,7 +201,8 @@ func (us *UploadSession) Complete() error {
 	if us.Status == UploadSessionStatusAborted {
 		return ErrUploadSessionAborted
 	}
-	if us.IsExpired() {
+	// 検証待ちのセッションは受信済みのため有効期限に関係なく完了できる
+	if !us.IsVerifying() && us.IsExpired() {
 		return ErrUploadSessionExpired
 	}
 
@@ -170,6 +225,26 @@ func (us *UploadSession) Abort() error {
 	return nil
 }
 
+// StartVerification は全内容を受信したセッションを内容の検証待ちにします
+// 検証するオブジェクトバージョンとサイズは検証が完了するまで保持します
+func (us *UploadSession) StartVerification(versionID string, size int64) error {
+	if us.Status == UploadSessionStatusCompleted {
+		return ErrUploadSessionCompleted
+	}
+	if us.Status == UploadSessionStatusAborted {
+		return ErrUploadSessionAborted
+	}
+	if !us.CanAcceptUpload() {
+		return ErrUploadSessionInvalidStatus
+	}
+
+	us.Status = UploadSessionStatusVerifying
+	us.ReceivedVersionID = versionID
+	us.ReceivedSize = size
+	us.UpdatedAt = time.Now()
+	return nil
+}
+
 // MarkExpired はセッションを期限切れにします
 func (us *UploadSession) MarkExpired() {
 	us.Status = UploadSessionStatusExpired
@@ -185,6 +260,29 @@ func (us *UploadSession) IncrementUploadedParts() {
 	us.UpdatedAt = time.Now()
 }
 
+// SetUploadedParts は記録済みのパーツ数でアップロード済みパーツ数を更新します
+// 並行してアップロードされたパーツも数えられるよう、カウンタではなく記録済みのパーツから設定します
+func (us *UploadSession) SetUploadedParts(count int) {
+	us.UploadedParts = count
+	if us.Status == UploadSessionStatusPending && us.UploadedParts > 0 {
+		us.Status = UploadSessionStatusInProgress
+	}
+	us.UpdatedAt = time.Now()
+}
+
+// IsValidPartNumber はパーツ番号がセッションの範囲内かどうかを判定します
+func (us *UploadSession) IsValidPartNumber(partNumber int) bool {
+	return partNumber >= 1 && partNumber <= us.TotalParts
+}
+
+// PartSize は指定パーツ番号で受け付けるサイズを返します（シングルパートは合計サイズ）
+func (us *UploadSession) PartSize(partNumber int) int64 {
+	if !us.IsMultipart {
+		return us.TotalSize
+	}
+	return CalculatePartSize(us.TotalSize, partNumber, us.TotalParts)
+}
+
 // IsExpired はセッションが期限切れかどうかを判定します
 func (us *UploadSession) IsExpired() bool {
 	return time.Now().After(us.ExpiresAt)
@@ -205,6 +303,11 @@ func (us *UploadSession) IsCompleted() bool {
 	return us.Status == UploadSessionStatusCompleted
 }
 
+// IsVerifying は内容の検証待ちかどうかを判定します
+func (us *UploadSession) IsVerifying() bool {
+	return us.Status == UploadSessionStatusVerifying
+}
+
 // IsAborted は中断済みかどうかを判定します
 func (us *UploadSession) IsAborted() bool {
 	return us.Status == UploadSessionStatusAborted
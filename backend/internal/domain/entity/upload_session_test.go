@@ -142,6 +142,67 @@ func TestNewUploadSession_OwnerIDEqualsCreatedBy(t *testing.T) {
 	}
 }
 
+func TestNewVersionUploadSession_OwnerIsFileOwner_CreatedByIsUploader(t *testing.T) {
+	file := newActiveFile()
+	uploader := uuid.New()
+	session := NewVersionUploadSession(file, uploader, 1024, nil)
+
+	if session.OwnerID != file.OwnerID {
+		t.Errorf("expected OwnerID %s, got %s", file.OwnerID, session.OwnerID)
+	}
+	if session.CreatedBy != uploader {
+		t.Errorf("expected CreatedBy %s, got %s", uploader, session.CreatedBy)
+	}
+}
+
+func TestNewVersionUploadSession_SharesStorageKeyWithFile(t *testing.T) {
+	file := newActiveFile()
+	session := NewVersionUploadSession(file, uuid.New(), 1024, nil)
+
+	if session.FileID != file.ID {
+		t.Errorf("expected FileID %s, got %s", file.ID, session.FileID)
+	}
+	if session.StorageKey.String() != file.StorageKey.String() {
+		t.Errorf("expected StorageKey %s, got %s", file.StorageKey, session.StorageKey)
+	}
+}
+
+// VerifyContent tests
+
+func TestUploadSession_VerifyContent_NoExpectedChecksum_ReturnsTrue(t *testing.T) {
+	session := NewUploadSession(uuid.New(), uuid.New(), uuid.New(), newSessionFileName(), newSessionMimeType(), 1024, nil)
+
+	if !session.VerifyContent("anything", "anything") {
+		t.Error("expected session without checksum to accept any content")
+	}
+}
+
+func TestUploadSession_VerifyContent_ComparesDigestOfDeclaredAlgorithm(t *testing.T) {
+	sha := "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	crc := "9a71bb4c"
+
+	shaChecksum, _ := valueobject.NewContentChecksum("sha256", sha)
+	crcChecksum, _ := valueobject.NewContentChecksum("crc32c", crc)
+
+	session := NewUploadSession(uuid.New(), uuid.New(), uuid.New(), newSessionFileName(), newSessionMimeType(), 1024, nil)
+
+	session.SetExpectedChecksum(&shaChecksum)
+	if !session.VerifyContent(sha, "00000000") {
+		t.Error("expected matching SHA-256 to verify")
+	}
+	if session.VerifyContent(crc, crc) {
+		t.Error("expected mismatching SHA-256 to fail")
+	}
+
+	session.SetExpectedChecksum(&crcChecksum)
+	if !session.VerifyContent("", crc) {
+		t.Error("expected matching CRC32C to verify")
+	}
+	if session.VerifyContent(sha, "00000000") {
+		t.Error("expected mismatching CRC32C to fail")
+	}
+}
+
 // Complete tests
 
 func TestUploadSession_Complete_FromPending_ReturnsNil(t *testing.T) {
@@ -194,6 +255,48 @@ func TestUploadSession_Complete_Expired_ReturnsErrExpired(t *testing.T) {
 	}
 }
 
+// StartVerification tests
+
+func TestUploadSession_StartVerification_FromPending_KeepsReceivedContent(t *testing.T) {
+	session := newPendingSession()
+
+	err := session.StartVerification("v1", 1024)
+
+	if err != nil {
+		t.Fatalf("StartVerification from pending should return nil, got: %v", err)
+	}
+	if !session.IsVerifying() {
+		t.Errorf("expected status verifying, got: %s", session.Status)
+	}
+	if session.ReceivedVersionID != "v1" || session.ReceivedSize != 1024 {
+		t.Errorf("expected received content v1/1024, got: %s/%d", session.ReceivedVersionID, session.ReceivedSize)
+	}
+}
+
+func TestUploadSession_StartVerification_AlreadyCompleted_ReturnsErrCompleted(t *testing.T) {
+	session := newCompletedSession()
+
+	err := session.StartVerification("v1", 1024)
+
+	if err != ErrUploadSessionCompleted {
+		t.Errorf("expected ErrUploadSessionCompleted, got: %v", err)
+	}
+}
+
+func TestUploadSession_Complete_VerifyingAfterExpiry_ReturnsNil(t *testing.T) {
+	session := newPendingSession()
+	if err := session.StartVerification("v1", 1024); err != nil {
+		t.Fatalf("StartVerification failed: %v", err)
+	}
+	session.ExpiresAt = time.Now().Add(-time.Hour)
+
+	err := session.Complete()
+
+	if err != nil {
+		t.Errorf("Complete from verifying should ignore expiry, got: %v", err)
+	}
+}
+
 // Abort tests
 
 func TestUploadSession_Abort_FromPending_ReturnsNil(t *testing.T) {
@@ -271,6 +374,54 @@ func TestUploadSession_IncrementUploadedParts_FromInProgress_StaysInProgress(t *
 	}
 }
 
+// SetUploadedParts tests
+
+func TestUploadSession_SetUploadedParts_FromPending_TransitionsToInProgress(t *testing.T) {
+	session := newPendingSession()
+
+	session.SetUploadedParts(1)
+
+	if session.UploadedParts != 1 {
+		t.Errorf("expected 1 uploaded part, got: %d", session.UploadedParts)
+	}
+	if session.Status != UploadSessionStatusInProgress {
+		t.Errorf("expected status in_progress, got: %s", session.Status)
+	}
+}
+
+// PartSize tests
+
+func TestUploadSession_PartSize_SinglePart_ReturnsTotalSize(t *testing.T) {
+	session := NewUploadSession(uuid.New(), uuid.New(), uuid.New(), newSessionFileName(), newSessionMimeType(), 1024, nil)
+
+	if got := session.PartSize(1); got != 1024 {
+		t.Errorf("expected 1024, got: %d", got)
+	}
+}
+
+func TestUploadSession_PartSize_Multipart_LastPartIsRemainder(t *testing.T) {
+	uploadID := "upload-id"
+	session := NewUploadSession(uuid.New(), uuid.New(), uuid.New(), newSessionFileName(), newSessionMimeType(), MinPartSize+100, &uploadID)
+
+	if got := session.PartSize(1); got != MinPartSize {
+		t.Errorf("expected first part %d, got: %d", MinPartSize, got)
+	}
+	if got := session.PartSize(2); got != 100 {
+		t.Errorf("expected last part 100, got: %d", got)
+	}
+}
+
+func TestUploadSession_IsValidPartNumber_OutOfRange_ReturnsFalse(t *testing.T) {
+	session := newPendingSession()
+
+	if !session.IsValidPartNumber(1) {
+		t.Error("part 1 should be valid")
+	}
+	if session.IsValidPartNumber(0) || session.IsValidPartNumber(2) {
+		t.Error("parts outside 1..TotalParts should be invalid")
+	}
+}
+
 // IsExpired tests
 
 func TestUploadSession_IsExpired_ExpiresAtInPast_ReturnsTrue(t *testing.T) {
@@ -0,0 +1,129 @@
+package entity
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RetentionSubjectType は保持ポリシーの対象種別を定義します
+type RetentionSubjectType string
+
+const (
+	RetentionSubjectUser   RetentionSubjectType = "user"
+	RetentionSubjectFolder RetentionSubjectType = "folder"
+)
+
+// IsValid は対象種別が有効かを判定します
+func (t RetentionSubjectType) IsValid() bool {
+	return t == RetentionSubjectUser || t == RetentionSubjectFolder
+}
+
+// 保持ポリシー関連エラー
+var (
+	ErrInvalidRetentionPolicy = errors.New("retention policy requires keep_last_n or keep_days greater than zero")
+)
+
+// VersionRetentionPolicy はファイルバージョンの保持ポリシーを表すエンティティ
+// Note: KeepLastN と KeepDays の両方が設定されている場合、いずれかの条件を満たすバージョンが保持される
+// Note: フォルダのポリシーはサブフォルダにも適用され、最も近いフォルダのポリシーがユーザーのポリシーより優先される
+type VersionRetentionPolicy struct {
+	ID          uuid.UUID
+	SubjectType RetentionSubjectType
+	SubjectID   uuid.UUID
+	KeepLastN   *int // 最新から数えて保持するバージョン数
+	KeepDays    *int // 作成から保持する日数
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// NewVersionRetentionPolicy は新しいVersionRetentionPolicyを作成します
+func NewVersionRetentionPolicy(subjectType RetentionSubjectType, subjectID uuid.UUID, keepLastN, keepDays *int) (*VersionRetentionPolicy, error) {
+	if err := validateRetentionRules(keepLastN, keepDays); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &VersionRetentionPolicy{
+		ID:          uuid.New(),
+		SubjectType: subjectType,
+		SubjectID:   subjectID,
+		KeepLastN:   keepLastN,
+		KeepDays:    keepDays,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// ReconstructVersionRetentionPolicy はDBからVersionRetentionPolicyを復元します
+func ReconstructVersionRetentionPolicy(
+	id uuid.UUID,
+	subjectType RetentionSubjectType,
+	subjectID uuid.UUID,
+	keepLastN *int,
+	keepDays *int,
+	createdAt time.Time,
+	updatedAt time.Time,
+) *VersionRetentionPolicy {
+	return &VersionRetentionPolicy{
+		ID:          id,
+		SubjectType: subjectType,
+		SubjectID:   subjectID,
+		KeepLastN:   keepLastN,
+		KeepDays:    keepDays,
+		CreatedAt:   createdAt,
+		UpdatedAt:   updatedAt,
+	}
+}
+
+// UpdateRules は保持条件を変更します
+func (p *VersionRetentionPolicy) UpdateRules(keepLastN, keepDays *int) error {
+	if err := validateRetentionRules(keepLastN, keepDays); err != nil {
+		return err
+	}
+	p.KeepLastN = keepLastN
+	p.KeepDays = keepDays
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// SelectPrunable は保持条件を満たさない削除対象のバージョンを返します
+// 現在のバージョンとピン留めされたバージョンは常に保持されます
+func (p *VersionRetentionPolicy) SelectPrunable(versions []*FileVersion, currentVersion int, now time.Time) []*FileVersion {
+	sorted := make([]*FileVersion, len(versions))
+	copy(sorted, versions)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].VersionNumber > sorted[j].VersionNumber
+	})
+
+	var prunable []*FileVersion
+	for rank, v := range sorted {
+		if v.IsLatest(currentVersion) || v.IsPinned {
+			continue
+		}
+		if p.KeepLastN != nil && rank < *p.KeepLastN {
+			continue
+		}
+		if p.KeepDays != nil && v.CreatedAt.After(now.AddDate(0, 0, -*p.KeepDays)) {
+			continue
+		}
+		prunable = append(prunable, v)
+	}
+	return prunable
+}
+
+// validateRetentionRules は少なくとも1つの正の保持条件があることを検証します
+func validateRetentionRules(keepLastN, keepDays *int) error {
+	if keepLastN == nil && keepDays == nil {
+		return ErrInvalidRetentionPolicy
+	}
+	if keepLastN != nil && *keepLastN <= 0 {
+		return ErrInvalidRetentionPolicy
+	}
+	if keepDays != nil && *keepDays <= 0 {
+		return ErrInvalidRetentionPolicy
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func newTestVersions(fileID uuid.UUID, now time.Time, ages ...time.Duration) []*FileVersion {
+	versions := make([]*FileVersion, len(ages))
+	for i, age := range ages {
+		versions[i] = &FileVersion{
+			ID:            uuid.New(),
+			FileID:        fileID,
+			VersionNumber: i + 1,
+			CreatedAt:     now.Add(-age),
+		}
+	}
+	return versions
+}
+
+func TestNewVersionRetentionPolicy_NoRules_ReturnsError(t *testing.T) {
+	_, err := NewVersionRetentionPolicy(RetentionSubjectUser, uuid.New(), nil, nil)
+	if err != ErrInvalidRetentionPolicy {
+		t.Errorf("err = %v, want %v", err, ErrInvalidRetentionPolicy)
+	}
+}
+
+func TestNewVersionRetentionPolicy_NonPositiveRule_ReturnsError(t *testing.T) {
+	if _, err := NewVersionRetentionPolicy(RetentionSubjectUser, uuid.New(), intPtr(0), nil); err != ErrInvalidRetentionPolicy {
+		t.Errorf("keepLastN=0: err = %v, want %v", err, ErrInvalidRetentionPolicy)
+	}
+	if _, err := NewVersionRetentionPolicy(RetentionSubjectUser, uuid.New(), nil, intPtr(-1)); err != ErrInvalidRetentionPolicy {
+		t.Errorf("keepDays=-1: err = %v, want %v", err, ErrInvalidRetentionPolicy)
+	}
+}
+
+func TestNewVersionRetentionPolicy_ValidRules_SetsFields(t *testing.T) {
+	subjectID := uuid.New()
+	p, err := NewVersionRetentionPolicy(RetentionSubjectFolder, subjectID, intPtr(5), intPtr(30))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.SubjectType != RetentionSubjectFolder {
+		t.Errorf("SubjectType = %v, want %v", p.SubjectType, RetentionSubjectFolder)
+	}
+	if p.SubjectID != subjectID {
+		t.Errorf("SubjectID = %v, want %v", p.SubjectID, subjectID)
+	}
+	if *p.KeepLastN != 5 || *p.KeepDays != 30 {
+		t.Errorf("rules = (%d, %d), want (5, 30)", *p.KeepLastN, *p.KeepDays)
+	}
+}
+
+func TestVersionRetentionPolicy_SelectPrunable_KeepLastN(t *testing.T) {
+	now := time.Now()
+	p, _ := NewVersionRetentionPolicy(RetentionSubjectUser, uuid.New(), intPtr(2), nil)
+	versions := newTestVersions(uuid.New(), now, 0, 0, 0, 0)
+
+	prunable := p.SelectPrunable(versions, 4, now)
+
+	if len(prunable) != 2 {
+		t.Fatalf("len(prunable) = %d, want 2", len(prunable))
+	}
+	for _, v := range prunable {
+		if v.VersionNumber > 2 {
+			t.Errorf("version %d should be kept", v.VersionNumber)
+		}
+	}
+}
+
+func TestVersionRetentionPolicy_SelectPrunable_KeepDays(t *testing.T) {
+	now := time.Now()
+	day := 24 * time.Hour
+	p, _ := NewVersionRetentionPolicy(RetentionSubjectUser, uuid.New(), nil, intPtr(7))
+	versions := newTestVersions(uuid.New(), now, 30*day, 10*day, 3*day, 0)
+
+	prunable := p.SelectPrunable(versions, 4, now)
+
+	if len(prunable) != 2 {
+		t.Fatalf("len(prunable) = %d, want 2", len(prunable))
+	}
+	if prunable[0].VersionNumber != 2 || prunable[1].VersionNumber != 1 {
+		t.Errorf("prunable = [%d, %d], want [2, 1]", prunable[0].VersionNumber, prunable[1].VersionNumber)
+	}
+}
+
+func TestVersionRetentionPolicy_SelectPrunable_BothRules_KeepsIfEitherMatches(t *testing.T) {
+	now := time.Now()
+	day := 24 * time.Hour
+	p, _ := NewVersionRetentionPolicy(RetentionSubjectUser, uuid.New(), intPtr(1), intPtr(7))
+	versions := newTestVersions(uuid.New(), now, 30*day, 2*day, 1*day)
+
+	prunable := p.SelectPrunable(versions, 3, now)
+
+	if len(prunable) != 1 || prunable[0].VersionNumber != 1 {
+		t.Errorf("prunable should contain only version 1, got %d entries", len(prunable))
+	}
+}
+
+func TestVersionRetentionPolicy_SelectPrunable_NeverPrunesCurrentOrPinned(t *testing.T) {
+	now := time.Now()
+	day := 24 * time.Hour
+	p, _ := NewVersionRetentionPolicy(RetentionSubjectUser, uuid.New(), nil, intPtr(1))
+	versions := newTestVersions(uuid.New(), now, 30*day, 20*day, 10*day)
+	versions[0].Pin()
+
+	prunable := p.SelectPrunable(versions, 3, now)
+
+	if len(prunable) != 1 || prunable[0].VersionNumber != 2 {
+		t.Errorf("prunable should contain only version 2, got %d entries", len(prunable))
+	}
+}
+
+func TestVersionRetentionPolicy_UpdateRules_Invalid_KeepsPreviousRules(t *testing.T) {
+	p, _ := NewVersionRetentionPolicy(RetentionSubjectUser, uuid.New(), intPtr(3), nil)
+
+	if err := p.UpdateRules(nil, nil); err != ErrInvalidRetentionPolicy {
+		t.Errorf("err = %v, want %v", err, ErrInvalidRetentionPolicy)
+	}
+	if p.KeepLastN == nil || *p.KeepLastN != 3 {
+		t.Error("KeepLastN should remain 3")
+	}
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+)
+
+// AuditCheckpointRepository は監査ログチェックポイントの永続化インターフェースです
+type AuditCheckpointRepository interface {
+	// Create はチェックポイントを作成します（同じ日のチェックポイントが既にある場合は何もしません）
+	Create(ctx context.Context, checkpoint *entity.AuditCheckpoint) error
+	// FindLatest は最新のチェックポイントを取得します
+	FindLatest(ctx context.Context) (*entity.AuditCheckpoint, error)
+	// List はすべてのチェックポイントを日付の昇順で取得します
+	List(ctx context.Context) ([]*entity.AuditCheckpoint, error)
+}
@@ -2,20 +2,50 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
 )
 
+// AuditLogCursor は監査ログのキーセットページネーション用カーソルです
+// 並び順は created_at DESC, id DESC
+type AuditLogCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// AuditLogCriteria は監査ログの検索条件を定義します
+type AuditLogCriteria struct {
+	ActorID      *uuid.UUID                // 操作者
+	ActionPrefix string                    // アクションの前方一致（例: "file." / "share.link_"）
+	ResourceType *entity.AuditResourceType // リソースタイプ
+	ResourceID   *uuid.UUID                // リソースID
+	From         *time.Time                // 記録日時の下限（含む）
+	To           *time.Time                // 記録日時の上限（含まない）
+	RequestID    string                    // リクエストID
+	Cursor       *AuditLogCursor           // 前ページ最後のエントリ
+	Limit        int
+}
+
 // AuditLogRepository は監査ログの永続化インターフェースです
 type AuditLogRepository interface {
 	// Create は監査ログを作成します
 	Create(ctx context.Context, log *entity.AuditLog) error
+	// LockChainHead はハッシュチェーンへの追記をトランザクション終了まで排他し、チェーン末尾のエントリを取得します
+	// トランザクション内で呼び出す必要があります。チェーンが空の場合はNotFoundエラーを返します
+	LockChainHead(ctx context.Context) (*entity.AuditLog, error)
+	// FindChainHeadBefore は指定日時より前に記録されたエントリのうちチェーン末尾のものを取得します
+	FindChainHeadBefore(ctx context.Context, before time.Time) (*entity.AuditLog, error)
+	// ListChainAfter は指定Sequenceより後のチェーン上のエントリをSequenceの昇順で取得します
+	ListChainAfter(ctx context.Context, afterSequence int64, limit int) ([]*entity.AuditLog, error)
 	// ListByUserID はユーザーIDで監査ログを取得します
 	ListByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entity.AuditLog, error)
 	// ListByResource はリソースタイプとIDで監査ログを取得します
 	ListByResource(ctx context.Context, resourceType entity.AuditResourceType, resourceID uuid.UUID, limit, offset int) ([]*entity.AuditLog, error)
 	// CountByUserID はユーザーIDで監査ログ数を取得します
 	CountByUserID(ctx context.Context, userID uuid.UUID) (int, error)
+	// Search は条件に一致する監査ログを記録日時の降順で取得します
+	Search(ctx context.Context, criteria AuditLogCriteria) ([]*entity.AuditLog, error)
 }
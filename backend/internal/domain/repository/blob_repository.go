@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+)
+
+// DuplicateFile は他のファイルと同じ内容（ブロブ）を持つファイルです
+type DuplicateFile struct {
+	Checksum string
+	File     *entity.File
+}
+
+// BlobRepository は内容アドレス方式のブロブリポジトリのインターフェース
+type BlobRepository interface {
+	// Acquire は同じチェックサムのブロブがあれば参照数を1増やして返し、なければblobを登録して返します
+	// 同時に同じ内容が登録されても1行にまとまるよう、登録と参照の追加は1つの文で行います
+	Acquire(ctx context.Context, blob *entity.Blob) (*entity.Blob, error)
+	// FindByID はIDでブロブを取得します
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Blob, error)
+	// IncrementRefCount は参照数を1増やし、更新後のブロブを返します
+	IncrementRefCount(ctx context.Context, id uuid.UUID) (*entity.Blob, error)
+	// DecrementRefCount は参照数を1減らし、更新後のブロブを返します
+	DecrementRefCount(ctx context.Context, id uuid.UUID) (*entity.Blob, error)
+	// Delete は参照されていないブロブを削除します
+	Delete(ctx context.Context, id uuid.UUID) error
+	// IsObjectVersionReferenced はブロブかファイルバージョン（ゴミ箱内を含む）がオブジェクトバージョンを参照しているかを判定します
+	IsObjectVersionReferenced(ctx context.Context, storageKey valueobject.StorageKey, versionID string) (bool, error)
+	// FindDuplicateFilesByOwner は所有者のアクティブなファイルのうち、現在のバージョンの内容が
+	// 他のファイルと重複しているものをサイズの大きい順、チェックサムごとにまとめて取得します
+	FindDuplicateFilesByOwner(ctx context.Context, ownerID uuid.UUID) ([]*DuplicateFile, error)
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+)
+
+// CopyJobRepository は非同期コピージョブリポジトリのインターフェース
+type CopyJobRepository interface {
+	// Create はコピージョブを登録します
+	Create(ctx context.Context, job *entity.CopyJob) error
+	// FindByID はIDでコピージョブを取得します
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.CopyJob, error)
+	// Update はコピージョブの状態を更新します
+	Update(ctx context.Context, job *entity.CopyJob) error
+	// ClaimNextPending は最も古い実行待ちのコピージョブを実行中にし、leaseTokenでリースを取得します
+	// 実行待ちのジョブがない場合はNotFoundエラーを返します
+	ClaimNextPending(ctx context.Context, leaseToken uuid.UUID) (*entity.CopyJob, error)
+	// Heartbeat はleaseTokenがリースを保持している実行中のジョブのハートビートを更新します
+	// リースを失っている場合（ジョブが中断扱いにされた場合など）はfalseを返します
+	Heartbeat(ctx context.Context, id, leaseToken uuid.UUID) (bool, error)
+	// FailRunningHeartbeatBefore はハートビートが指定日時より前で途絶えている実行中のジョブを失敗にし、件数を返します
+	FailRunningHeartbeatBefore(ctx context.Context, before time.Time, message string) (int64, error)
+}
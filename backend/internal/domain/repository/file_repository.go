@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -59,6 +60,18 @@ type FileVersionRepository interface {
 
 	// カウント
 	CountByFileID(ctx context.Context, fileID uuid.UUID) (int, error)
+
+	// 保持ポリシー
+	UpdatePinned(ctx context.Context, id uuid.UUID, pinned bool) error
+	// FindFileIDsWithPrunableVersions は削除候補（現在・ピン留め以外）のバージョンを持つアクティブファイルのIDをID順に取得します
+	FindFileIDsWithPrunableVersions(ctx context.Context, afterID uuid.UUID, limit int) ([]uuid.UUID, error)
+}
+
+// TrashCursor はゴミ箱一覧のページネーション位置（前ページ最後のアイテム）を表します
+// ファイルとフォルダを同じ並び順（archived_at DESC, id DESC）でページングするために使用します
+type TrashCursor struct {
+	ArchivedAt time.Time
+	ID         uuid.UUID
 }
 
 // ArchivedFileRepository はゴミ箱ファイルリポジトリのインターフェース
@@ -70,9 +83,11 @@ type ArchivedFileRepository interface {
 
 	// 検索
 	FindByOwner(ctx context.Context, ownerID uuid.UUID) ([]*entity.ArchivedFile, error)
-	FindByOwnerWithPagination(ctx context.Context, ownerID uuid.UUID, limit int, cursor *uuid.UUID) ([]*entity.ArchivedFile, error)
+	// FindByOwnerWithPagination はフォルダごとゴミ箱に移動されたファイルを除いて取得します
+	FindByOwnerWithPagination(ctx context.Context, ownerID uuid.UUID, limit int, cursor *TrashCursor) ([]*entity.ArchivedFile, error)
 	FindExpired(ctx context.Context) ([]*entity.ArchivedFile, error)
 	FindByOriginalFileID(ctx context.Context, originalFileID uuid.UUID) (*entity.ArchivedFile, error)
+	FindByArchivedFolderID(ctx context.Context, archivedFolderID uuid.UUID) ([]*entity.ArchivedFile, error)
 }
 
 // ArchivedFileVersionRepository はゴミ箱ファイルバージョンリポジトリのインターフェース
@@ -95,9 +110,16 @@ type UploadSessionRepository interface {
 	FindByFileID(ctx context.Context, fileID uuid.UUID) (*entity.UploadSession, error)
 	FindByStorageKey(ctx context.Context, storageKey valueobject.StorageKey) (*entity.UploadSession, error)
 
+	// 排他的な状態遷移（トランザクション内で呼び出す必要があります）
+	// トランザクション終了まで行ロックを取得し、並行する完了処理を直列化します
+	FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.UploadSession, error)
+
 	// 期限切れ検索（クリーンアップ用）
 	FindExpired(ctx context.Context) ([]*entity.UploadSession, error)
 
+	// 内容の検証待ちのセッションを更新日時の古い順に取得（バックグラウンド検証用）
+	FindVerifying(ctx context.Context, limit int) ([]*entity.UploadSession, error)
+
 	// ステータス更新
 	UpdateStatus(ctx context.Context, id uuid.UUID, status entity.UploadSessionStatus) error
 }
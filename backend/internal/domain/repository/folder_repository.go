@@ -15,6 +15,7 @@ type FolderRepository interface {
 	// 基本CRUD
 	Create(ctx context.Context, folder *entity.Folder) error
 	FindByID(ctx context.Context, id uuid.UUID) (*entity.Folder, error)
+	FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.Folder, error)
 	Update(ctx context.Context, folder *entity.Folder) error
 	Delete(ctx context.Context, id uuid.UUID) error
 
@@ -37,6 +38,27 @@ type FolderRepository interface {
 	BulkUpdateDepth(ctx context.Context, folderDepths map[uuid.UUID]int) error
 }
 
+// ArchivedFolderRepository はゴミ箱フォルダリポジトリのインターフェース
+type ArchivedFolderRepository interface {
+	// 基本CRUD
+	Create(ctx context.Context, archivedFolder *entity.ArchivedFolder) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.ArchivedFolder, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// 検索
+	FindByOwner(ctx context.Context, ownerID uuid.UUID) ([]*entity.ArchivedFolder, error)
+	FindByOwnerWithPagination(ctx context.Context, ownerID uuid.UUID, limit int, cursor *TrashCursor) ([]*entity.ArchivedFolder, error)
+	FindExpired(ctx context.Context) ([]*entity.ArchivedFolder, error)
+}
+
+// ArchivedSubfolderRepository はゴミ箱フォルダのサブツリーを保持するリポジトリのインターフェース
+type ArchivedSubfolderRepository interface {
+	// 基本操作
+	BulkCreate(ctx context.Context, subfolders []*entity.ArchivedSubfolder) error
+	FindByArchivedFolderID(ctx context.Context, archivedFolderID uuid.UUID) ([]*entity.ArchivedSubfolder, error)
+	DeleteByArchivedFolderID(ctx context.Context, archivedFolderID uuid.UUID) error
+}
+
 // FolderClosureRepository はフォルダ閉包テーブルリポジトリのインターフェース
 // Note: DBテーブル名は folder_paths だが、概念的にはクロージャテーブル
 type FolderClosureRepository interface {
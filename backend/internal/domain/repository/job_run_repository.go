@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+)
+
+// JobRunRepository はバックグラウンドジョブ実行履歴リポジトリのインターフェース
+type JobRunRepository interface {
+	// Create は実行履歴を記録します
+	Create(ctx context.Context, run *entity.JobRun) error
+	// FindRecentByJobName はジョブの直近の実行履歴を新しい順に取得します
+	FindRecentByJobName(ctx context.Context, jobName string, limit int) ([]*entity.JobRun, error)
+	// DeleteStartedBefore は指定日時より前に開始された実行履歴を削除します
+	DeleteStartedBefore(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+)
+
+// SearchItemType は検索結果アイテムの種別を表します
+type SearchItemType string
+
+const (
+	SearchItemTypeFile   SearchItemType = "file"
+	SearchItemTypeFolder SearchItemType = "folder"
+)
+
+// IsValid は種別が有効かを判定します
+func (t SearchItemType) IsValid() bool {
+	return t == SearchItemTypeFile || t == SearchItemTypeFolder
+}
+
+// SearchCursor は検索結果のキーセットページネーション用カーソルです
+// 並び順は updated_at DESC, id DESC
+type SearchCursor struct {
+	UpdatedAt time.Time
+	ID        uuid.UUID
+}
+
+// SearchCriteria は検索条件を定義します
+// Note: MimeCategory / MinSize / MaxSize はファイルにのみ適用され、指定時はフォルダは結果に含まれない
+type SearchCriteria struct {
+	ViewerID       uuid.UUID                 // 検索するユーザー（所有・権限付与されたリソースに候補を限定）
+	Query          string                    // 名前の部分一致（大文字小文字を区別しない）
+	ItemType       *SearchItemType           // nilの場合はファイルとフォルダの両方
+	MimeCategory   *valueobject.MimeCategory // MIMEカテゴリ
+	MinSize        *int64                    // 最小サイズ（バイト）
+	MaxSize        *int64                    // 最大サイズ（バイト）
+	OwnerID        *uuid.UUID                // 所有者
+	ModifiedAfter  *time.Time                // 更新日時の下限
+	ModifiedBefore *time.Time                // 更新日時の上限
+	FolderID       *uuid.UUID                // 指定フォルダ配下（サブツリー）に限定
+	Cursor         *SearchCursor             // 前ページ最後のアイテム
+	Limit          int
+}
+
+// SearchResult は検索結果の1アイテムです（File または Folder のどちらか一方が設定される）
+type SearchResult struct {
+	Type   SearchItemType
+	File   *entity.File
+	Folder *entity.Folder
+}
+
+// ID はアイテムIDを返します
+func (r *SearchResult) ID() uuid.UUID {
+	if r.Type == SearchItemTypeFolder {
+		return r.Folder.ID
+	}
+	return r.File.ID
+}
+
+// UpdatedAt はアイテムの更新日時を返します
+func (r *SearchResult) UpdatedAt() time.Time {
+	if r.Type == SearchItemTypeFolder {
+		return r.Folder.UpdatedAt
+	}
+	return r.File.UpdatedAt
+}
+
+// SearchRepository はファイル・フォルダ横断検索のインターフェース
+type SearchRepository interface {
+	// Search は条件に一致するアクティブなファイル・フォルダを更新日時の降順で取得します
+	Search(ctx context.Context, criteria SearchCriteria) ([]*SearchResult, error)
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// StoredObjectSource はオブジェクト参照の記録元テーブルを表します
+type StoredObjectSource string
+
+const (
+	StoredObjectSourceFileVersion         StoredObjectSource = "file_version"
+	StoredObjectSourceArchivedFileVersion StoredObjectSource = "archived_file_version"
+)
+
+// StoredObjectRef はDBに記録されたMinIOオブジェクトバージョンへの参照です
+type StoredObjectRef struct {
+	Source     StoredObjectSource
+	RowID      uuid.UUID // file_versions.id または archived_file_versions.id
+	FileID     uuid.UUID // files.id または archived_files.id
+	StorageKey string
+	VersionID  string // MinIOバージョンID（未記録の場合は空文字）
+	Size       int64
+	Checksum   string
+	IsCurrent  bool // ファイルの現在のバージョンかどうか（アーカイブは常にfalse）
+}
+
+// StorageInventoryRepository はストレージ整合性チェック用にオブジェクト参照を走査するリポジトリです
+type StorageInventoryRepository interface {
+	// ListFileVersionRefs はfile_versionsのオブジェクト参照をID順に取得します（キーセットページング）
+	ListFileVersionRefs(ctx context.Context, afterID uuid.UUID, limit int) ([]*StoredObjectRef, error)
+
+	// ListArchivedFileVersionRefs はarchived_file_versionsのオブジェクト参照をID順に取得します（キーセットページング）
+	ListArchivedFileVersionRefs(ctx context.Context, afterID uuid.UUID, limit int) ([]*StoredObjectRef, error)
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+)
+
+// StorageQuotaRepository はストレージクォータリポジトリのインターフェース
+type StorageQuotaRepository interface {
+	// Upsert は対象ごとのクォータを作成または更新します
+	Upsert(ctx context.Context, quota *entity.StorageQuota) error
+	// FindBySubject は対象のクォータを取得します（未設定の場合はNotFound）
+	FindBySubject(ctx context.Context, subjectType entity.QuotaSubjectType, subjectID uuid.UUID) (*entity.StorageQuota, error)
+	// FindGroupQuotasByUserID はユーザーが所属するグループのクォータ一覧を取得します
+	FindGroupQuotasByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.StorageQuota, error)
+	// DeleteBySubject は対象のクォータを削除します
+	DeleteBySubject(ctx context.Context, subjectType entity.QuotaSubjectType, subjectID uuid.UUID) error
+}
+
+// StorageUsageRepository はストレージ使用量カウンターのインターフェース
+// Note: 使用量はファイル所有者ごとに、ゴミ箱内のファイルと全バージョンを含めて集計する
+type StorageUsageRepository interface {
+	// GetUsedBytes はユーザーの使用量を取得します（記録がない場合は0）
+	GetUsedBytes(ctx context.Context, userID uuid.UUID) (int64, error)
+	// AddUsedBytes はユーザーの使用量に差分を加算します（負数で減算、0未満にはならない）
+	AddUsedBytes(ctx context.Context, userID uuid.UUID, delta int64) error
+	// SumUsedBytesByGroup はグループメンバー全員の使用量の合計を取得します
+	SumUsedBytesByGroup(ctx context.Context, groupID uuid.UUID) (int64, error)
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+)
+
+// ThumbnailRepository はサムネイル生成リポジトリのインターフェース
+type ThumbnailRepository interface {
+	// Create はサムネイル生成を登録します
+	// 同じファイルバージョンのサムネイルが登録済みの場合は何もしません
+	Create(ctx context.Context, thumbnail *entity.Thumbnail) error
+	// FindByFileAndVersion はファイルバージョンのサムネイルを取得します
+	FindByFileAndVersion(ctx context.Context, fileID uuid.UUID, versionNumber int) (*entity.Thumbnail, error)
+	// Update はサムネイル生成の状態を更新します
+	Update(ctx context.Context, thumbnail *entity.Thumbnail) error
+	// ClaimNextPending は最も古い生成待ちのサムネイルを生成中にして取得します
+	// 生成待ちのサムネイルがない場合はNotFoundエラーを返します
+	ClaimNextPending(ctx context.Context) (*entity.Thumbnail, error)
+	// FindRunningStartedBefore は指定日時より前に開始され、まだ生成中のサムネイルを取得します
+	FindRunningStartedBefore(ctx context.Context, before time.Time) ([]*entity.Thumbnail, error)
+}
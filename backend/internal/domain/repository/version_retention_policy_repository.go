@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+)
+
+// VersionRetentionPolicyRepository はバージョン保持ポリシーリポジトリのインターフェース
+type VersionRetentionPolicyRepository interface {
+	// Upsert は対象ごとの保持ポリシーを作成または更新します
+	Upsert(ctx context.Context, policy *entity.VersionRetentionPolicy) error
+	// FindBySubject は対象の保持ポリシーを取得します（未設定の場合はNotFound）
+	FindBySubject(ctx context.Context, subjectType entity.RetentionSubjectType, subjectID uuid.UUID) (*entity.VersionRetentionPolicy, error)
+	// DeleteBySubject は対象の保持ポリシーを削除します
+	DeleteBySubject(ctx context.Context, subjectType entity.RetentionSubjectType, subjectID uuid.UUID) error
+}
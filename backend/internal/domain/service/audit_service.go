@@ -25,3 +25,13 @@ type AuditEntry struct {
 	UserAgent    string
 	RequestID    string
 }
+
+// AuditCheckpointSigner は監査ログチェックポイントの署名と検証を行うインターフェースです
+type AuditCheckpointSigner interface {
+	// KeyID は署名鍵の識別子を返します
+	KeyID() string
+	// Sign はペイロードに署名します
+	Sign(payload []byte) ([]byte, error)
+	// Verify は署名が指定した鍵でペイロードに対して有効かを判定します
+	Verify(keyID string, payload, signature []byte) bool
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"log/slog"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+)
+
+// ErrObjectVersionRegistered は登録しようとしたオブジェクトバージョンが既にブロブとして登録されていることを示します
+// 既存の内容を新しいアップロードとして完了しようとした場合に発生し、そのオブジェクトバージョンは削除してはいけません
+var ErrObjectVersionRegistered = errors.New("object version is already registered as a blob")
+
+// ContentDigest はオブジェクトバージョンの内容から計算したダイジェスト（16進数）
+type ContentDigest struct {
+	SHA256 string
+	CRC32C string
+}
+
+// BlobService は内容アドレス方式でオブジェクトを共有するためのドメインサービス
+// 同じSHA-256を持つファイルバージョンは1つのオブジェクトバージョン（ブロブ）を参照し、
+// 最後の参照がなくなった時点でのみオブジェクトを削除します
+type BlobService interface {
+	// ComputeDigest はオブジェクトバージョンの内容をストリーミングしてSHA-256とCRC32Cを計算します
+	ComputeDigest(ctx context.Context, storageKey, versionID string) (*ContentDigest, error)
+
+	// StoredDigest はアップロード時にMinIOが検証・記録したチェックサムを内容を読み込まずに返します
+	// 内容全体のSHA-256が記録されていない場合はnilを返すため、呼び出し側でComputeDigestによる再計算が必要です
+	StoredDigest(ctx context.Context, storageKey, versionID string) (*ContentDigest, error)
+
+	// Register はアップロードされたオブジェクトバージョンをブロブとして登録し、参照するブロブを返します
+	// 同じ内容のブロブが既にある場合はその参照数を増やして返し、deduplicatedがtrueになります
+	// その場合アップロードされたオブジェクトバージョンは不要になるため、呼び出し側で削除します
+	// 既存のブロブがアップロードされたオブジェクトバージョンそのものの場合はErrObjectVersionRegisteredを返します
+	Register(ctx context.Context, checksum string, storageKey valueobject.StorageKey, versionID string, size int64) (blob *entity.Blob, deduplicated bool, err error)
+
+	// IsReferenced はオブジェクトバージョンがブロブまたはファイルバージョンから参照されているかを判定します
+	// 参照されているオブジェクトバージョンは既存の内容のため、アップロードの後始末で削除してはいけません
+	IsReferenced(ctx context.Context, storageKey valueobject.StorageKey, versionID string) (bool, error)
+
+	// Acquire は既存のブロブへの参照を1つ追加します（コピーなど内容を複製する操作用）
+	Acquire(ctx context.Context, blobID uuid.UUID) (*entity.Blob, error)
+
+	// Release はファイルバージョンの削除に伴いブロブへの参照を解放します
+	// 参照がなくなったブロブは行を削除して返すため、トランザクション完了後にPurgeに渡します
+	Release(ctx context.Context, blobIDs []uuid.UUID) ([]*entity.Blob, error)
+
+	// Purge は参照がなくなったブロブのオブジェクトバージョンを削除します
+	// 失敗はログに記録するのみで、残ったオブジェクトはストレージ整合性チェックで孤立オブジェクトとして検出されます
+	Purge(ctx context.Context, blobs []*entity.Blob)
+}
+
+// blobServiceImpl はBlobServiceの実装
+type blobServiceImpl struct {
+	blobRepo       repository.BlobRepository
+	storageService StorageService
+}
+
+// NewBlobService は新しいBlobServiceを作成します
+func NewBlobService(blobRepo repository.BlobRepository, storageService StorageService) BlobService {
+	return &blobServiceImpl{
+		blobRepo:       blobRepo,
+		storageService: storageService,
+	}
+}
+
+// ComputeDigest はオブジェクトバージョンを1回読み込んでSHA-256とCRC32Cを計算します
+func (s *blobServiceImpl) ComputeDigest(ctx context.Context, storageKey, versionID string) (*ContentDigest, error) {
+	src, err := s.storageService.GetObject(ctx, storageKey, versionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object %s: %w", storageKey, err)
+	}
+	defer src.Close()
+
+	sha := sha256.New()
+	crc := crc32.New(crc32.MakeTable(crc32.Castagnoli))
+	if _, err := io.Copy(io.MultiWriter(sha, crc), src); err != nil {
+		return nil, fmt.Errorf("failed to hash object %s: %w", storageKey, err)
+	}
+	return &ContentDigest{
+		SHA256: hex.EncodeToString(sha.Sum(nil)),
+		CRC32C: hex.EncodeToString(crc.Sum(nil)),
+	}, nil
+}
+
+// StoredDigest はMinIOが記録したチェックサムからダイジェストを返します
+// CRC32Cは記録されている場合のみ設定されます
+func (s *blobServiceImpl) StoredDigest(ctx context.Context, storageKey, versionID string) (*ContentDigest, error) {
+	info, err := s.storageService.GetObjectVersionInfo(ctx, storageKey, versionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object info %s: %w", storageKey, err)
+	}
+	if info.ChecksumSHA256 == "" {
+		return nil, nil
+	}
+	return &ContentDigest{
+		SHA256: info.ChecksumSHA256,
+		CRC32C: info.ChecksumCRC32C,
+	}, nil
+}
+
+// Register はアップロードされたオブジェクトバージョンをブロブとして登録します
+func (s *blobServiceImpl) Register(ctx context.Context, checksum string, storageKey valueobject.StorageKey, versionID string, size int64) (*entity.Blob, bool, error) {
+	candidate := entity.NewBlob(checksum, storageKey, versionID, size)
+	blob, err := s.blobRepo.Acquire(ctx, candidate)
+	if err != nil {
+		return nil, false, err
+	}
+	if blob.ID == candidate.ID {
+		return blob, false, nil
+	}
+	// 共有するブロブがこのオブジェクトバージョンの場合、重複として削除するとブロブの内容が失われる
+	if blob.StorageKey == storageKey && blob.MinioVersionID == versionID {
+		return nil, false, ErrObjectVersionRegistered
+	}
+	return blob, true, nil
+}
+
+// IsReferenced はオブジェクトバージョンが参照されているかを判定します
+func (s *blobServiceImpl) IsReferenced(ctx context.Context, storageKey valueobject.StorageKey, versionID string) (bool, error) {
+	return s.blobRepo.IsObjectVersionReferenced(ctx, storageKey, versionID)
+}
+
+// Acquire は既存のブロブへの参照を1つ追加します
+func (s *blobServiceImpl) Acquire(ctx context.Context, blobID uuid.UUID) (*entity.Blob, error) {
+	return s.blobRepo.IncrementRefCount(ctx, blobID)
+}
+
+// Release はブロブへの参照を解放し、参照がなくなったブロブを返します
+func (s *blobServiceImpl) Release(ctx context.Context, blobIDs []uuid.UUID) ([]*entity.Blob, error) {
+	var released []*entity.Blob
+	for _, id := range blobIDs {
+		blob, err := s.blobRepo.DecrementRefCount(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if blob.IsReferenced() {
+			continue
+		}
+		if err := s.blobRepo.Delete(ctx, blob.ID); err != nil {
+			return nil, err
+		}
+		released = append(released, blob)
+	}
+	return released, nil
+}
+
+// Purge は参照がなくなったブロブのオブジェクトバージョンを削除します
+func (s *blobServiceImpl) Purge(ctx context.Context, blobs []*entity.Blob) {
+	for _, blob := range blobs {
+		if err := s.storageService.DeleteObjectVersion(ctx, blob.StorageKey.String(), blob.MinioVersionID); err != nil {
+			slog.Error("failed to delete blob object",
+				"blob_id", blob.ID,
+				"storage_key", blob.StorageKey.String(),
+				"error", err,
+			)
+		}
+	}
+}
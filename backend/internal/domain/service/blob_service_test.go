@@ -0,0 +1,189 @@
+package service_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
+)
+
+type blobServiceTestDeps struct {
+	blobRepo       *mocks.MockBlobRepository
+	storageService *mocks.MockStorageService
+}
+
+func newBlobServiceTestDeps(t *testing.T) *blobServiceTestDeps {
+	t.Helper()
+	return &blobServiceTestDeps{
+		blobRepo:       mocks.NewMockBlobRepository(t),
+		storageService: mocks.NewMockStorageService(t),
+	}
+}
+
+func (d *blobServiceTestDeps) newService() service.BlobService {
+	return service.NewBlobService(d.blobRepo, d.storageService)
+}
+
+func newTestBlob(refCount int) *entity.Blob {
+	return entity.ReconstructBlob(
+		uuid.New(), "checksum", valueobject.NewStorageKey(uuid.New()), "minio-v1", 100, refCount, time.Now(),
+	)
+}
+
+func TestBlobService_ComputeDigest_ReturnsSHA256AndCRC32COfObjectVersion(t *testing.T) {
+	ctx := context.Background()
+	deps := newBlobServiceTestDeps(t)
+
+	deps.storageService.On("GetObject", ctx, "files/key", "minio-v1").Return(io.NopCloser(strings.NewReader("hello")), nil)
+
+	digest, err := deps.newService().ComputeDigest(ctx, "files/key", "minio-v1")
+
+	require.NoError(t, err)
+	sum := sha256.Sum256([]byte("hello"))
+	assert.Equal(t, hex.EncodeToString(sum[:]), digest.SHA256)
+	assert.Equal(t, "9a71bb4c", digest.CRC32C)
+}
+
+func TestBlobService_StoredDigest_RecordedChecksum_ReturnsWithoutReading(t *testing.T) {
+	ctx := context.Background()
+	deps := newBlobServiceTestDeps(t)
+
+	sum := sha256.Sum256([]byte("hello"))
+	deps.storageService.On("GetObjectVersionInfo", ctx, "files/key", "minio-v1").
+		Return(&service.ObjectInfo{VersionID: "minio-v1", ChecksumSHA256: hex.EncodeToString(sum[:])}, nil)
+
+	digest, err := deps.newService().StoredDigest(ctx, "files/key", "minio-v1")
+
+	require.NoError(t, err)
+	require.NotNil(t, digest)
+	assert.Equal(t, hex.EncodeToString(sum[:]), digest.SHA256)
+	assert.Empty(t, digest.CRC32C)
+	deps.storageService.AssertNotCalled(t, "GetObject", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestBlobService_StoredDigest_NoRecordedChecksum_ReturnsNil(t *testing.T) {
+	ctx := context.Background()
+	deps := newBlobServiceTestDeps(t)
+
+	deps.storageService.On("GetObjectVersionInfo", ctx, "files/key", "minio-v1").
+		Return(&service.ObjectInfo{VersionID: "minio-v1"}, nil)
+
+	digest, err := deps.newService().StoredDigest(ctx, "files/key", "minio-v1")
+
+	require.NoError(t, err)
+	assert.Nil(t, digest)
+}
+
+func TestBlobService_Register_NewContent_ReturnsCandidate(t *testing.T) {
+	ctx := context.Background()
+	deps := newBlobServiceTestDeps(t)
+	storageKey := valueobject.NewStorageKey(uuid.New())
+
+	// 新しい内容は候補のブロブがそのまま登録される
+	registered := &entity.Blob{}
+	deps.blobRepo.On("Acquire", ctx, mock.AnythingOfType("*entity.Blob")).
+		Run(func(args mock.Arguments) { *registered = *args.Get(1).(*entity.Blob) }).
+		Return(registered, nil)
+
+	blob, deduplicated, err := deps.newService().Register(ctx, "abc", storageKey, "minio-v1", 100)
+
+	require.NoError(t, err)
+	assert.False(t, deduplicated)
+	assert.Equal(t, storageKey, blob.StorageKey)
+	assert.Equal(t, 1, blob.RefCount)
+}
+
+func TestBlobService_Register_ExistingContent_ReturnsExistingBlob(t *testing.T) {
+	ctx := context.Background()
+	deps := newBlobServiceTestDeps(t)
+	existing := newTestBlob(2)
+
+	deps.blobRepo.On("Acquire", ctx, mock.AnythingOfType("*entity.Blob")).Return(existing, nil)
+
+	blob, deduplicated, err := deps.newService().Register(ctx, "checksum", valueobject.NewStorageKey(uuid.New()), "minio-v2", 100)
+
+	require.NoError(t, err)
+	assert.True(t, deduplicated)
+	assert.Equal(t, existing, blob)
+}
+
+func TestBlobService_Register_ExistingBlobIsSameObjectVersion_ReturnsError(t *testing.T) {
+	ctx := context.Background()
+	deps := newBlobServiceTestDeps(t)
+	existing := newTestBlob(1)
+
+	deps.blobRepo.On("Acquire", ctx, mock.AnythingOfType("*entity.Blob")).Return(existing, nil)
+
+	blob, deduplicated, err := deps.newService().Register(ctx, "checksum", existing.StorageKey, existing.MinioVersionID, 100)
+
+	require.ErrorIs(t, err, service.ErrObjectVersionRegistered)
+	assert.Nil(t, blob)
+	assert.False(t, deduplicated)
+}
+
+func TestBlobService_IsReferenced_DelegatesToRepository(t *testing.T) {
+	ctx := context.Background()
+	deps := newBlobServiceTestDeps(t)
+	storageKey := valueobject.NewStorageKey(uuid.New())
+
+	deps.blobRepo.On("IsObjectVersionReferenced", ctx, storageKey, "minio-v1").Return(true, nil)
+
+	referenced, err := deps.newService().IsReferenced(ctx, storageKey, "minio-v1")
+
+	require.NoError(t, err)
+	assert.True(t, referenced)
+}
+
+func TestBlobService_Release_DeletesOnlyUnreferencedBlobs(t *testing.T) {
+	ctx := context.Background()
+	deps := newBlobServiceTestDeps(t)
+	stillShared := newTestBlob(1)
+	lastRef := newTestBlob(0)
+
+	deps.blobRepo.On("DecrementRefCount", ctx, stillShared.ID).Return(stillShared, nil)
+	deps.blobRepo.On("DecrementRefCount", ctx, lastRef.ID).Return(lastRef, nil)
+	deps.blobRepo.On("Delete", ctx, lastRef.ID).Return(nil)
+
+	released, err := deps.newService().Release(ctx, []uuid.UUID{stillShared.ID, lastRef.ID})
+
+	require.NoError(t, err)
+	assert.Equal(t, []*entity.Blob{lastRef}, released)
+}
+
+func TestBlobService_Release_RepositoryError_PropagatesError(t *testing.T) {
+	ctx := context.Background()
+	deps := newBlobServiceTestDeps(t)
+	blobID := uuid.New()
+	dbErr := errors.New("db error")
+
+	deps.blobRepo.On("DecrementRefCount", ctx, blobID).Return(nil, dbErr)
+
+	released, err := deps.newService().Release(ctx, []uuid.UUID{blobID})
+
+	require.ErrorIs(t, err, dbErr)
+	assert.Nil(t, released)
+}
+
+func TestBlobService_Purge_DeletesObjectVersions(t *testing.T) {
+	ctx := context.Background()
+	deps := newBlobServiceTestDeps(t)
+	blob := newTestBlob(0)
+
+	deps.storageService.On("DeleteObjectVersion", ctx, blob.StorageKey.String(), blob.MinioVersionID).Return(nil)
+
+	deps.newService().Purge(ctx, []*entity.Blob{blob})
+}
@@ -0,0 +1,365 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/unicode"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+)
+
+const (
+	// DefaultPreviewBytes はプレビューで読み込む既定のバイト数です
+	DefaultPreviewBytes = 64 * 1024
+	// MaxPreviewBytes はプレビューで読み込めるバイト数の上限です
+	MaxPreviewBytes = 1024 * 1024
+	// csvPreviewMaxRows はCSVプレビューで返す最大行数です
+	csvPreviewMaxRows = 1000
+)
+
+// ErrPreviewNotSupported はプレビューできない形式、またはテキストではない内容の場合のエラーです
+var ErrPreviewNotSupported = errors.New("preview is not supported for this file")
+
+// PreviewFormat はプレビューの形式を表します
+type PreviewFormat string
+
+const (
+	PreviewFormatText     PreviewFormat = "text"
+	PreviewFormatCSV      PreviewFormat = "csv"
+	PreviewFormatMarkdown PreviewFormat = "markdown"
+)
+
+// 検出する文字コード
+const (
+	PreviewEncodingUTF8        = "utf-8"
+	PreviewEncodingUTF16LE     = "utf-16le"
+	PreviewEncodingUTF16BE     = "utf-16be"
+	PreviewEncodingShiftJIS    = "shift_jis"
+	PreviewEncodingEUCJP       = "euc-jp"
+	PreviewEncodingWindows1252 = "windows-1252"
+)
+
+// textPreviewApplicationSubtypes はテキストとしてプレビューするapplication/*のサブタイプです
+var textPreviewApplicationSubtypes = map[string]bool{
+	"json":                  true,
+	"x-ndjson":              true,
+	"xml":                   true,
+	"javascript":            true,
+	"x-javascript":          true,
+	"typescript":            true,
+	"x-typescript":          true,
+	"yaml":                  true,
+	"x-yaml":                true,
+	"toml":                  true,
+	"x-toml":                true,
+	"sql":                   true,
+	"x-sh":                  true,
+	"x-shellscript":         true,
+	"x-httpd-php":           true,
+	"x-python":              true,
+	"x-ruby":                true,
+	"x-perl":                true,
+	"graphql":               true,
+	"x-tex":                 true,
+	"x-latex":               true,
+	"x-subrip":              true,
+	"x-properties":          true,
+	"x-www-form-urlencoded": true,
+}
+
+// textPreviewExtensions はMIMEタイプが汎用（application/octet-stream等）でもテキストとしてプレビューする拡張子です
+var textPreviewExtensions = map[string]bool{
+	".txt": true, ".log": true, ".ini": true, ".cfg": true, ".conf": true, ".env": true,
+	".json": true, ".xml": true, ".yaml": true, ".yml": true, ".toml": true,
+	".html": true, ".htm": true, ".css": true, ".scss": true, ".sass": true, ".less": true,
+	".js": true, ".mjs": true, ".cjs": true, ".jsx": true, ".ts": true, ".tsx": true, ".vue": true, ".svelte": true,
+	".go": true, ".py": true, ".rb": true, ".php": true, ".java": true, ".kt": true, ".kts": true, ".scala": true,
+	".c": true, ".h": true, ".cc": true, ".cpp": true, ".hpp": true, ".cs": true, ".rs": true, ".swift": true,
+	".m": true, ".dart": true, ".lua": true, ".pl": true, ".r": true, ".ex": true, ".exs": true, ".erl": true,
+	".hs": true, ".clj": true, ".elm": true, ".sql": true, ".graphql": true, ".proto": true,
+	".sh": true, ".bash": true, ".zsh": true, ".fish": true, ".ps1": true, ".bat": true,
+	".tf": true, ".hcl": true, ".gradle": true, ".mk": true, ".cmake": true, ".dockerfile": true,
+	".gitignore": true, ".editorconfig": true, ".rst": true, ".adoc": true, ".tex": true, ".srt": true, ".vtt": true,
+}
+
+// FilePreview はファイルの先頭部分から生成したプレビューです
+type FilePreview struct {
+	Format    PreviewFormat
+	Encoding  string     // 検出した元の文字コード
+	Content   string     // UTF-8に変換した先頭部分
+	Rows      [][]string // CSVの場合のみ、解析した行
+	HTML      string     // Markdownの場合のみ、危険な要素を含まないHTML
+	LineCount int        // Contentの行数
+	BytesRead int        // プレビューに使用した元の内容のバイト数
+	Truncated bool       // ファイルの途中までのプレビューかどうか
+}
+
+// FilePreviewService はファイルの先頭部分からインラインプレビューを生成するドメインサービス
+// 内容はストレージからストリーミングで読み込み、上限のバイト数を超えて読み込みません
+type FilePreviewService interface {
+	// Generate はファイルバージョンの先頭maxBytesバイトから形式に応じたプレビューを生成します
+	// maxBytesが0以下の場合はDefaultPreviewBytes、MaxPreviewBytesを超える場合はMaxPreviewBytesを使用します
+	// プレビューできない形式やテキストではない内容の場合はErrPreviewNotSupportedを返します
+	Generate(ctx context.Context, file *entity.File, version *entity.FileVersion, maxBytes int) (*FilePreview, error)
+}
+
+// filePreviewServiceImpl はFilePreviewServiceの実装
+type filePreviewServiceImpl struct {
+	storageService   StorageService
+	markdownRenderer MarkdownRenderer
+}
+
+// NewFilePreviewService は新しいFilePreviewServiceを作成します
+func NewFilePreviewService(storageService StorageService, markdownRenderer MarkdownRenderer) FilePreviewService {
+	return &filePreviewServiceImpl{
+		storageService:   storageService,
+		markdownRenderer: markdownRenderer,
+	}
+}
+
+// Generate はファイルバージョンの先頭部分を読み込み、文字コードを判定してプレビューを生成します
+func (s *filePreviewServiceImpl) Generate(ctx context.Context, file *entity.File, version *entity.FileVersion, maxBytes int) (*FilePreview, error) {
+	format, ok := detectPreviewFormat(file)
+	if !ok {
+		return nil, ErrPreviewNotSupported
+	}
+	if maxBytes <= 0 {
+		maxBytes = DefaultPreviewBytes
+	}
+	maxBytes = min(maxBytes, MaxPreviewBytes)
+
+	src, err := s.storageService.GetObject(ctx, version.StorageKey.String(), version.MinioVersionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object %s: %w", version.StorageKey.String(), err)
+	}
+	defer src.Close()
+
+	// 上限を1バイト超えて読み込み、途中までのプレビューかどうかを判定する
+	data, err := io.ReadAll(io.LimitReader(src, int64(maxBytes)+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object %s: %w", version.StorageKey.String(), err)
+	}
+	truncated := len(data) > maxBytes
+	if truncated {
+		data = data[:maxBytes]
+	}
+
+	content, enc, err := decodePreviewText(data, truncated)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &FilePreview{
+		Format:    format,
+		Encoding:  enc,
+		Content:   content,
+		LineCount: countPreviewLines(content),
+		BytesRead: len(data),
+		Truncated: truncated,
+	}
+
+	switch format {
+	case PreviewFormatCSV:
+		rows, more := parseCSVPreview(content, truncated, csvDelimiter(file))
+		preview.Rows = rows
+		preview.Truncated = preview.Truncated || more
+	case PreviewFormatMarkdown:
+		html, err := s.markdownRenderer.Render(content)
+		if err != nil {
+			return nil, err
+		}
+		preview.HTML = html
+	}
+	return preview, nil
+}
+
+// detectPreviewFormat はMIMEタイプと拡張子からプレビュー形式を判定します
+// ブラウザによってはCSVをapplication/vnd.ms-excelとして送信するため、CSVとMarkdownは拡張子でも判定します
+func detectPreviewFormat(file *entity.File) (PreviewFormat, bool) {
+	ext := strings.ToLower(file.Name.Extension())
+	mainType := strings.ToLower(file.MimeType.Type())
+	subtype := strings.ToLower(file.MimeType.Subtype())
+
+	switch {
+	case mainType == "text" && (subtype == "csv" || subtype == "tab-separated-values"), ext == ".csv", ext == ".tsv":
+		return PreviewFormatCSV, true
+	case mainType == "text" && (subtype == "markdown" || subtype == "x-markdown"), ext == ".md", ext == ".markdown":
+		return PreviewFormatMarkdown, true
+	case mainType == "text",
+		mainType == "application" && textPreviewApplicationSubtypes[subtype],
+		mainType == "application" && (strings.HasSuffix(subtype, "+json") || strings.HasSuffix(subtype, "+xml")),
+		textPreviewExtensions[ext]:
+		return PreviewFormatText, true
+	}
+	return "", false
+}
+
+// csvDelimiter はCSVの区切り文字を返します（TSVの場合はタブ）
+func csvDelimiter(file *entity.File) rune {
+	if strings.EqualFold(file.MimeType.Subtype(), "tab-separated-values") || strings.EqualFold(file.Name.Extension(), ".tsv") {
+		return '\t'
+	}
+	return ','
+}
+
+// decodePreviewText は文字コードを判定してUTF-8の文字列に変換します
+// BOM、UTF-8としての妥当性、EUC-JP、Shift_JISの順に判定し、いずれでもなければWindows-1252として扱います
+// 途中までの内容の場合、末尾で分断された文字は取り除きます
+func decodePreviewText(data []byte, truncated bool) (string, string, error) {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xEF, 0xBB, 0xBF}):
+		return string(trimIncompleteUTF8(data[3:], truncated)), PreviewEncodingUTF8, nil
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}):
+		return decodeUTF16Preview(data, unicode.LittleEndian, truncated, PreviewEncodingUTF16LE)
+	case bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		return decodeUTF16Preview(data, unicode.BigEndian, truncated, PreviewEncodingUTF16BE)
+	}
+
+	// NULを含む内容はバイナリとみなす
+	if bytes.IndexByte(data, 0) >= 0 {
+		return "", "", ErrPreviewNotSupported
+	}
+
+	if text := trimIncompleteUTF8(data, truncated); utf8.Valid(text) {
+		return string(text), PreviewEncodingUTF8, nil
+	}
+
+	// Shift_JISの日本語はほぼ必ず0xA1未満の上位バイトを含むため、含まない場合はEUC-JPを先に試す
+	// 欧文のWindows-1252がShift_JISとして解釈できてしまうことがあるため、かなを含む場合のみ日本語とみなす
+	if isLikelyEUCJP(data) {
+		if text, ok := decodeStrict(japanese.EUCJP, data, truncated); ok && containsKana(text) {
+			return text, PreviewEncodingEUCJP, nil
+		}
+	}
+	if text, ok := decodeStrict(japanese.ShiftJIS, data, truncated); ok && containsKana(text) {
+		return text, PreviewEncodingShiftJIS, nil
+	}
+	if text, ok := decodeStrict(japanese.EUCJP, data, truncated); ok && containsKana(text) {
+		return text, PreviewEncodingEUCJP, nil
+	}
+
+	text, err := charmap.Windows1252.NewDecoder().Bytes(data)
+	if err != nil {
+		return "", "", ErrPreviewNotSupported
+	}
+	return string(text), PreviewEncodingWindows1252, nil
+}
+
+// decodeUTF16Preview はBOM付きのUTF-16をUTF-8に変換します
+func decodeUTF16Preview(data []byte, endianness unicode.Endianness, truncated bool, name string) (string, string, error) {
+	if truncated && len(data)%2 == 1 {
+		data = data[:len(data)-1]
+	}
+	text, ok := decodeStrict(unicode.UTF16(endianness, unicode.ExpectBOM), data, truncated)
+	if !ok {
+		return "", "", ErrPreviewNotSupported
+	}
+	return text, name, nil
+}
+
+// decodeStrict は不正なバイト列を含まない場合のみ変換結果を返します
+func decodeStrict(enc encoding.Encoding, data []byte, truncated bool) (string, bool) {
+	decoded, err := enc.NewDecoder().Bytes(data)
+	if err != nil {
+		return "", false
+	}
+	text := string(decoded)
+	if truncated {
+		text = strings.TrimSuffix(text, string(utf8.RuneError))
+	}
+	if strings.ContainsRune(text, utf8.RuneError) {
+		return "", false
+	}
+	return text, true
+}
+
+// isLikelyEUCJP は上位バイトがすべてEUC-JPの範囲（0xA1-0xFE、0x8E、0x8F）に収まるかを判定します
+func isLikelyEUCJP(data []byte) bool {
+	for _, b := range data {
+		if b >= 0x80 && b < 0xA1 && b != 0x8E && b != 0x8F {
+			return false
+		}
+	}
+	return true
+}
+
+// containsKana は全角のひらがな・カタカナを含むかを判定します
+func containsKana(text string) bool {
+	for _, r := range text {
+		if r >= 0x3040 && r <= 0x30FF {
+			return true
+		}
+	}
+	return false
+}
+
+// trimIncompleteUTF8 は途中までの内容の末尾で分断されたUTF-8の文字を取り除きます
+func trimIncompleteUTF8(data []byte, truncated bool) []byte {
+	if !truncated {
+		return data
+	}
+	for i := 1; i <= utf8.UTFMax && i <= len(data); i++ {
+		if utf8.RuneStart(data[len(data)-i]) {
+			if !utf8.FullRune(data[len(data)-i:]) {
+				return data[:len(data)-i]
+			}
+			break
+		}
+	}
+	return data
+}
+
+// countPreviewLines はテキストの行数を返します（末尾の改行は行として数えません）
+func countPreviewLines(text string) int {
+	if text == "" {
+		return 0
+	}
+	lines := strings.Count(text, "\n")
+	if !strings.HasSuffix(text, "\n") {
+		lines++
+	}
+	return lines
+}
+
+// parseCSVPreview はCSVを先頭から最大csvPreviewMaxRows行まで解析します
+// 途中までの内容の場合は分断された最終行を除きます。解析できない行があればその手前までを返します
+// 2つ目の戻り値は、解析しなかった行が残っているかどうかです
+func parseCSVPreview(text string, truncated bool, delimiter rune) ([][]string, bool) {
+	if truncated {
+		if i := strings.LastIndexByte(text, '\n'); i >= 0 {
+			text = text[:i+1]
+		} else {
+			text = ""
+		}
+	}
+
+	reader := csv.NewReader(strings.NewReader(text))
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	rows := make([][]string, 0)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, false
+		}
+		if err != nil {
+			return rows, true
+		}
+		if len(rows) == csvPreviewMaxRows {
+			return rows, true
+		}
+		rows = append(rows, record)
+	}
+}
@@ -0,0 +1,194 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/japanese"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
+)
+
+func newPreviewTestFile(fileName, mimeType string) (*entity.File, *entity.FileVersion) {
+	name, _ := valueobject.NewFileName(fileName)
+	mime, _ := valueobject.NewMimeType(mimeType)
+	fileID := uuid.New()
+	file := entity.ReconstructFile(
+		fileID, uuid.New(), uuid.New(), uuid.New(),
+		name, mime, 1024, valueobject.NewStorageKey(fileID), 1,
+		entity.FileStatusActive, time.Now(), time.Now(),
+	)
+	version := entity.NewFileVersion(fileID, 1, "minio-v1", 1024, "checksum", file.OwnerID)
+	return file, version
+}
+
+func generatePreview(t *testing.T, fileName, mimeType, content string, maxBytes int) (*service.FilePreview, error) {
+	t.Helper()
+	return generatePreviewWithRenderer(t, mocks.NewMockMarkdownRenderer(t), fileName, mimeType, content, maxBytes)
+}
+
+func generatePreviewWithRenderer(t *testing.T, markdownRenderer *mocks.MockMarkdownRenderer, fileName, mimeType, content string, maxBytes int) (*service.FilePreview, error) {
+	t.Helper()
+	ctx := context.Background()
+	storageService := mocks.NewMockStorageService(t)
+	file, version := newPreviewTestFile(fileName, mimeType)
+
+	storageService.On("GetObject", ctx, version.StorageKey.String(), "minio-v1").
+		Return(io.NopCloser(strings.NewReader(content)), nil)
+
+	return service.NewFilePreviewService(storageService, markdownRenderer).Generate(ctx, file, version, maxBytes)
+}
+
+func TestFilePreviewService_Generate_UTF8Text_ReturnsContentAndLineCount(t *testing.T) {
+	preview, err := generatePreview(t, "notes.txt", "text/plain", "first\nsecond\nthird\n", 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, service.PreviewFormatText, preview.Format)
+	assert.Equal(t, service.PreviewEncodingUTF8, preview.Encoding)
+	assert.Equal(t, "first\nsecond\nthird\n", preview.Content)
+	assert.Equal(t, 3, preview.LineCount)
+	assert.Equal(t, 19, preview.BytesRead)
+	assert.False(t, preview.Truncated)
+}
+
+func TestFilePreviewService_Generate_ExceedsMaxBytes_TruncatesAtCharacterBoundary(t *testing.T) {
+	// "あ" はUTF-8で3バイトのため、5バイト目で分断される
+	preview, err := generatePreview(t, "notes.txt", "text/plain", "abあいう", 5)
+
+	require.NoError(t, err)
+	assert.Equal(t, service.PreviewEncodingUTF8, preview.Encoding)
+	assert.Equal(t, "abあ", preview.Content)
+	assert.Equal(t, 5, preview.BytesRead)
+	assert.True(t, preview.Truncated)
+}
+
+func TestFilePreviewService_Generate_ShiftJIS_ConvertsToUTF8(t *testing.T) {
+	sjis, err := japanese.ShiftJIS.NewEncoder().String("こんにちは、世界\n")
+	require.NoError(t, err)
+
+	preview, err := generatePreview(t, "hello.txt", "text/plain", sjis, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, service.PreviewEncodingShiftJIS, preview.Encoding)
+	assert.Equal(t, "こんにちは、世界\n", preview.Content)
+	assert.Equal(t, 1, preview.LineCount)
+}
+
+func TestFilePreviewService_Generate_EUCJP_ConvertsToUTF8(t *testing.T) {
+	eucjp, err := japanese.EUCJP.NewEncoder().String("日本語のテキスト")
+	require.NoError(t, err)
+
+	preview, err := generatePreview(t, "hello.txt", "text/plain", eucjp, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, service.PreviewEncodingEUCJP, preview.Encoding)
+	assert.Equal(t, "日本語のテキスト", preview.Content)
+}
+
+func TestFilePreviewService_Generate_Latin1WithoutKana_FallsBackToWindows1252(t *testing.T) {
+	preview, err := generatePreview(t, "cv.txt", "text/plain", "caf\xe9 r\xe9sum\xe9", 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, service.PreviewEncodingWindows1252, preview.Encoding)
+	assert.Equal(t, "café résumé", preview.Content)
+}
+
+func TestFilePreviewService_Generate_UTF16WithBOM_ConvertsToUTF8(t *testing.T) {
+	preview, err := generatePreview(t, "notes.txt", "text/plain", "\xff\xfeh\x00i\x00\n\x00", 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, service.PreviewEncodingUTF16LE, preview.Encoding)
+	assert.Equal(t, "hi\n", preview.Content)
+}
+
+func TestFilePreviewService_Generate_SourceCodeWithGenericMimeType_PreviewsAsText(t *testing.T) {
+	preview, err := generatePreview(t, "main.go", "application/octet-stream", "package main\n", 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, service.PreviewFormatText, preview.Format)
+	assert.Equal(t, "package main\n", preview.Content)
+}
+
+func TestFilePreviewService_Generate_CSV_ReturnsParsedRows(t *testing.T) {
+	content := "name,comment\nalice,\"hello, world\"\nbob,\"multi\nline\"\n"
+
+	preview, err := generatePreview(t, "users.csv", "text/csv", content, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, service.PreviewFormatCSV, preview.Format)
+	assert.Equal(t, [][]string{
+		{"name", "comment"},
+		{"alice", "hello, world"},
+		{"bob", "multi\nline"},
+	}, preview.Rows)
+	assert.False(t, preview.Truncated)
+}
+
+func TestFilePreviewService_Generate_TruncatedCSV_DropsPartialLastRow(t *testing.T) {
+	content := "id,name\n1,alice\n2,bob\n3,carol\n"
+
+	preview, err := generatePreview(t, "users.csv", "application/vnd.ms-excel", content, 26)
+
+	require.NoError(t, err)
+	assert.Equal(t, service.PreviewFormatCSV, preview.Format)
+	assert.Equal(t, [][]string{{"id", "name"}, {"1", "alice"}, {"2", "bob"}}, preview.Rows)
+	assert.True(t, preview.Truncated)
+}
+
+func TestFilePreviewService_Generate_TSV_UsesTabDelimiter(t *testing.T) {
+	preview, err := generatePreview(t, "data.tsv", "text/tab-separated-values", "a\tb,c\n1\t2\n", 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"a", "b,c"}, {"1", "2"}}, preview.Rows)
+}
+
+func TestFilePreviewService_Generate_Markdown_RendersHTML(t *testing.T) {
+	content := "# Title\n\nSome **bold** text.\n"
+	markdownRenderer := mocks.NewMockMarkdownRenderer(t)
+	markdownRenderer.On("Render", content).Return("<h1>Title</h1>\n<p>Some <strong>bold</strong> text.</p>\n", nil)
+
+	preview, err := generatePreviewWithRenderer(t, markdownRenderer, "README.md", "text/markdown", content, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, service.PreviewFormatMarkdown, preview.Format)
+	assert.Equal(t, content, preview.Content)
+	assert.Equal(t, "<h1>Title</h1>\n<p>Some <strong>bold</strong> text.</p>\n", preview.HTML)
+}
+
+func TestFilePreviewService_Generate_MarkdownRenderFailure_ReturnsError(t *testing.T) {
+	markdownRenderer := mocks.NewMockMarkdownRenderer(t)
+	markdownRenderer.On("Render", "# Title\n").Return("", errors.New("render failed"))
+
+	preview, err := generatePreviewWithRenderer(t, markdownRenderer, "README.md", "text/markdown", "# Title\n", 0)
+
+	assert.Error(t, err)
+	assert.Nil(t, preview)
+}
+
+func TestFilePreviewService_Generate_BinaryContent_ReturnsErrPreviewNotSupported(t *testing.T) {
+	_, err := generatePreview(t, "data.txt", "text/plain", "abc\x00\x01\x02", 0)
+
+	assert.ErrorIs(t, err, service.ErrPreviewNotSupported)
+}
+
+func TestFilePreviewService_Generate_UnsupportedType_DoesNotReadObject(t *testing.T) {
+	ctx := context.Background()
+	storageService := mocks.NewMockStorageService(t)
+	file, version := newPreviewTestFile("photo.png", "image/png")
+
+	_, err := service.NewFilePreviewService(storageService, mocks.NewMockMarkdownRenderer(t)).Generate(ctx, file, version, 0)
+
+	assert.ErrorIs(t, err, service.ErrPreviewNotSupported)
+	storageService.AssertNotCalled(t, "GetObject", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+)
+
+// ArchiveEntry はフォルダアーカイブ内の1エントリを表します
+// File がnilの場合はディレクトリエントリです
+type ArchiveEntry struct {
+	Path string // アーカイブ内のパス（ディレクトリは末尾が "/"）
+	File *entity.File
+}
+
+// IsDir はディレクトリエントリかどうかを判定します
+func (e ArchiveEntry) IsDir() bool {
+	return e.File == nil
+}
+
+// ArchiveFilter はアーカイブに含めるアイテムを絞り込みます
+// フィールドがnilの場合、その種別のアイテムはすべて含まれます
+type ArchiveFilter struct {
+	Folder func(ctx context.Context, folder *entity.Folder) (bool, error)
+	File   func(ctx context.Context, file *entity.File) (bool, error)
+}
+
+// FolderArchiveService はフォルダをアーカイブとしてダウンロードするためのドメインサービス
+type FolderArchiveService interface {
+	// CollectEntries はルートフォルダ配下のエントリをフォルダ構造を保ったパス付きで収集します
+	// フィルタで除外されたフォルダはサブツリーごと除外されます
+	CollectEntries(ctx context.Context, root *entity.Folder, filter ArchiveFilter) ([]ArchiveEntry, error)
+
+	// WriteZip はエントリをZIPとしてwに書き込みます
+	// ファイル内容はストレージから1件ずつストリーミングされ、メモリやディスクにバッファされません
+	WriteZip(ctx context.Context, w io.Writer, entries []ArchiveEntry) error
+}
+
+// folderArchiveServiceImpl はFolderArchiveServiceの実装
+type folderArchiveServiceImpl struct {
+	folderRepo        repository.FolderRepository
+	folderClosureRepo repository.FolderClosureRepository
+	fileRepo          repository.FileRepository
+	fileVersionRepo   repository.FileVersionRepository
+	storageService    StorageService
+}
+
+// NewFolderArchiveService は新しいFolderArchiveServiceを作成します
+func NewFolderArchiveService(
+	folderRepo repository.FolderRepository,
+	folderClosureRepo repository.FolderClosureRepository,
+	fileRepo repository.FileRepository,
+	fileVersionRepo repository.FileVersionRepository,
+	storageService StorageService,
+) FolderArchiveService {
+	return &folderArchiveServiceImpl{
+		folderRepo:        folderRepo,
+		folderClosureRepo: folderClosureRepo,
+		fileRepo:          fileRepo,
+		fileVersionRepo:   fileVersionRepo,
+		storageService:    storageService,
+	}
+}
+
+// CollectEntries はルートフォルダ配下のエントリを収集します
+func (s *folderArchiveServiceImpl) CollectEntries(ctx context.Context, root *entity.Folder, filter ArchiveFilter) ([]ArchiveEntry, error) {
+	// 1. サブツリーのフォルダを取得（自身を含む）
+	descendantIDs, err := s.folderClosureRepo.FindDescendantIDs(ctx, root.ID)
+	if err != nil {
+		return nil, err
+	}
+	folders, err := s.folderRepo.FindByIDs(ctx, descendantIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. 親からたどれるフォルダのみを浅い順にパス付きで採用
+	// 親が除外されたフォルダはサブツリーごと除外される
+	folderPaths := map[uuid.UUID]string{root.ID: root.Name.String() + "/"}
+	includedIDs := []uuid.UUID{root.ID}
+	entries := []ArchiveEntry{{Path: folderPaths[root.ID]}}
+	for _, folder := range sortFoldersByDepth(folders) {
+		if folder.ID == root.ID || folder.ParentID == nil {
+			continue
+		}
+		parentPath, ok := folderPaths[*folder.ParentID]
+		if !ok {
+			continue
+		}
+		if filter.Folder != nil {
+			included, err := filter.Folder(ctx, folder)
+			if err != nil {
+				return nil, err
+			}
+			if !included {
+				continue
+			}
+		}
+		folderPaths[folder.ID] = parentPath + folder.Name.String() + "/"
+		includedIDs = append(includedIDs, folder.ID)
+		entries = append(entries, ArchiveEntry{Path: folderPaths[folder.ID]})
+	}
+
+	// 3. 採用されたフォルダ内のダウンロード可能なファイルを追加
+	files, err := s.fileRepo.FindByFolderIDs(ctx, includedIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		if !file.CanDownload() {
+			continue
+		}
+		if filter.File != nil {
+			included, err := filter.File(ctx, file)
+			if err != nil {
+				return nil, err
+			}
+			if !included {
+				continue
+			}
+		}
+		entries = append(entries, ArchiveEntry{
+			Path: folderPaths[file.FolderID] + file.Name.String(),
+			File: file,
+		})
+	}
+
+	return entries, nil
+}
+
+// WriteZip はエントリをZIPとして書き込みます
+func (s *folderArchiveServiceImpl) WriteZip(ctx context.Context, w io.Writer, entries []ArchiveEntry) error {
+	zw := zip.NewWriter(w)
+
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if _, err := zw.Create(entry.Path); err != nil {
+				return fmt.Errorf("failed to write directory entry %s: %w", entry.Path, err)
+			}
+			continue
+		}
+
+		if err := s.writeFile(ctx, zw, entry); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+// writeFile は1ファイル分の内容をストレージから読み出してZIPに書き込みます
+func (s *folderArchiveServiceImpl) writeFile(ctx context.Context, zw *zip.Writer, entry ArchiveEntry) error {
+	// 内容は現在のバージョンが参照するオブジェクトバージョンから読み出す
+	version, err := s.fileVersionRepo.FindByFileAndVersion(ctx, entry.File.ID, entry.File.CurrentVersion)
+	if err != nil {
+		return fmt.Errorf("failed to get version for %s: %w", entry.Path, err)
+	}
+
+	header := &zip.FileHeader{
+		Name:     entry.Path,
+		Method:   zip.Deflate,
+		Modified: entry.File.UpdatedAt,
+	}
+	dst, err := zw.CreateHeader(header)
+	if err != nil {
+		return fmt.Errorf("failed to write file entry %s: %w", entry.Path, err)
+	}
+
+	src, err := s.storageService.GetObject(ctx, version.StorageKey.String(), version.MinioVersionID)
+	if err != nil {
+		return fmt.Errorf("failed to get object for %s: %w", entry.Path, err)
+	}
+	defer src.Close()
+
+	if _, err := io.Copy(dst, src); err != nil {
+		return fmt.Errorf("failed to stream %s: %w", entry.Path, err)
+	}
+	return nil
+}
+
+// sortFoldersByDepth は親が子より先に来るよう深さ順に並べ替えたコピーを返します
+func sortFoldersByDepth(folders []*entity.Folder) []*entity.Folder {
+	sorted := make([]*entity.Folder, len(folders))
+	copy(sorted, folders)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Depth < sorted[j].Depth
+	})
+	return sorted
+}
@@ -0,0 +1,155 @@
+package service_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
+)
+
+type folderArchiveTestDeps struct {
+	folderRepo      *mocks.MockFolderRepository
+	closureRepo     *mocks.MockFolderClosureRepository
+	fileRepo        *mocks.MockFileRepository
+	fileVersionRepo *mocks.MockFileVersionRepository
+	storageService  *mocks.MockStorageService
+}
+
+func newFolderArchiveTestDeps(t *testing.T) *folderArchiveTestDeps {
+	t.Helper()
+	return &folderArchiveTestDeps{
+		folderRepo:      mocks.NewMockFolderRepository(t),
+		closureRepo:     mocks.NewMockFolderClosureRepository(t),
+		fileRepo:        mocks.NewMockFileRepository(t),
+		fileVersionRepo: mocks.NewMockFileVersionRepository(t),
+		storageService:  mocks.NewMockStorageService(t),
+	}
+}
+
+func (d *folderArchiveTestDeps) newService() service.FolderArchiveService {
+	return service.NewFolderArchiveService(d.folderRepo, d.closureRepo, d.fileRepo, d.fileVersionRepo, d.storageService)
+}
+
+func newArchiveFolder(name string, parentID *uuid.UUID, depth int) *entity.Folder {
+	folderName, _ := valueobject.NewFolderName(name)
+	ownerID := uuid.New()
+	return entity.ReconstructFolder(
+		uuid.New(), folderName, parentID, ownerID, ownerID, depth,
+		entity.FolderStatusActive, time.Now(), time.Now(),
+	)
+}
+
+func newArchiveFile(name string, folderID uuid.UUID, status entity.FileStatus) *entity.File {
+	fileName, _ := valueobject.NewFileName(name)
+	mimeType, _ := valueobject.NewMimeType("text/plain")
+	ownerID := uuid.New()
+	fileID := uuid.New()
+	return entity.ReconstructFile(
+		fileID, folderID, ownerID, ownerID,
+		fileName, mimeType, 5, valueobject.NewStorageKey(fileID), 1,
+		status, time.Now(), time.Now(),
+	)
+}
+
+func archivePaths(entries []service.ArchiveEntry) []string {
+	paths := make([]string, len(entries))
+	for i, e := range entries {
+		paths[i] = e.Path
+	}
+	return paths
+}
+
+func TestFolderArchiveService_CollectEntries_PreservesFolderStructure(t *testing.T) {
+	ctx := context.Background()
+	deps := newFolderArchiveTestDeps(t)
+
+	root := newArchiveFolder("docs", nil, 0)
+	sub := newArchiveFolder("reports", &root.ID, 1)
+	rootFile := newArchiveFile("readme.txt", root.ID, entity.FileStatusActive)
+	subFile := newArchiveFile("q1.txt", sub.ID, entity.FileStatusActive)
+	uploading := newArchiveFile("partial.txt", sub.ID, entity.FileStatusUploading)
+
+	deps.closureRepo.On("FindDescendantIDs", ctx, root.ID).Return([]uuid.UUID{root.ID, sub.ID}, nil)
+	deps.folderRepo.On("FindByIDs", ctx, []uuid.UUID{root.ID, sub.ID}).Return([]*entity.Folder{sub, root}, nil)
+	deps.fileRepo.On("FindByFolderIDs", ctx, []uuid.UUID{root.ID, sub.ID}).Return([]*entity.File{rootFile, subFile, uploading}, nil)
+
+	entries, err := deps.newService().CollectEntries(ctx, root, service.ArchiveFilter{})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs/", "docs/reports/", "docs/readme.txt", "docs/reports/q1.txt"}, archivePaths(entries))
+}
+
+func TestFolderArchiveService_CollectEntries_ExcludedFolder_DropsSubtree(t *testing.T) {
+	ctx := context.Background()
+	deps := newFolderArchiveTestDeps(t)
+
+	root := newArchiveFolder("docs", nil, 0)
+	private := newArchiveFolder("private", &root.ID, 1)
+	nested := newArchiveFolder("nested", &private.ID, 2)
+	rootFile := newArchiveFile("readme.txt", root.ID, entity.FileStatusActive)
+
+	deps.closureRepo.On("FindDescendantIDs", ctx, root.ID).Return([]uuid.UUID{root.ID, private.ID, nested.ID}, nil)
+	deps.folderRepo.On("FindByIDs", ctx, []uuid.UUID{root.ID, private.ID, nested.ID}).Return([]*entity.Folder{root, private, nested}, nil)
+	deps.fileRepo.On("FindByFolderIDs", ctx, []uuid.UUID{root.ID}).Return([]*entity.File{rootFile}, nil)
+
+	filter := service.ArchiveFilter{
+		Folder: func(ctx context.Context, folder *entity.Folder) (bool, error) {
+			return folder.ID != private.ID, nil
+		},
+	}
+	entries, err := deps.newService().CollectEntries(ctx, root, filter)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs/", "docs/readme.txt"}, archivePaths(entries))
+}
+
+func TestFolderArchiveService_WriteZip_StreamsFileContents(t *testing.T) {
+	ctx := context.Background()
+	deps := newFolderArchiveTestDeps(t)
+
+	file := newArchiveFile("hello.txt", uuid.New(), entity.FileStatusActive)
+	entries := []service.ArchiveEntry{
+		{Path: "docs/"},
+		{Path: "docs/hello.txt", File: file},
+	}
+
+	// 重複排除されたバージョンは別ファイルのキーにある内容を参照する
+	blobKey := valueobject.NewStorageKey(uuid.New())
+	blobID := uuid.New()
+	version := entity.ReconstructFileVersion(
+		uuid.New(), file.ID, file.CurrentVersion, blobKey, "minio-v1", &blobID,
+		file.Size, "checksum", file.CreatedBy, false, time.Now(),
+	)
+
+	deps.fileVersionRepo.On("FindByFileAndVersion", ctx, file.ID, file.CurrentVersion).Return(version, nil)
+	deps.storageService.On("GetObject", ctx, blobKey.String(), "minio-v1").Return(io.NopCloser(strings.NewReader("hello")), nil)
+
+	var buf bytes.Buffer
+	err := deps.newService().WriteZip(ctx, &buf, entries)
+	require.NoError(t, err)
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Len(t, zr.File, 2)
+	assert.Equal(t, "docs/", zr.File[0].Name)
+	assert.Equal(t, "docs/hello.txt", zr.File[1].Name)
+
+	rc, err := zr.File[1].Open()
+	require.NoError(t, err)
+	defer rc.Close()
+	content, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(content))
+}
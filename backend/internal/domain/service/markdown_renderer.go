@@ -0,0 +1,9 @@
+package service
+
+// MarkdownRenderer はMarkdownを表示用のHTMLに変換するインターフェースを定義します
+// HTMLの生成と無害化はインフラ層で実装します
+type MarkdownRenderer interface {
+	// Render はMarkdownを危険な要素を含まないHTMLに変換します
+	// 生のHTMLは出力せず、リンクはhttp、https、mailto、相対URLのみ許可し、画像は読み込まずにリンクとして出力します
+	Render(src string) (string, error)
+}
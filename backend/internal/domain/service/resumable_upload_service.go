@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"io"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+)
+
+// ResumableUploadProgress はレジューム可能なアップロードの受信状況を表します
+type ResumableUploadProgress struct {
+	Offset  int64                // 受信済みバイト数
+	Parts   []*entity.UploadPart // 記録済みの完全なパーツ（パーツ番号順）
+	Partial *StoredPart          // 次のパーツの書きかけ（ない場合はnil）
+}
+
+// ResumableUploadService は任意の位置から再開できるアップロードのドメインサービス
+// 受信したバイト列をセッションのパーツサイズに区切ってパートとして保存し、
+// パーツサイズに満たない末尾は書きかけのパートとして保存して次の書き込みで追記します
+type ResumableUploadService interface {
+	// Progress は記録済みのパーツとストレージ上の書きかけのパートから受信済みバイト数を求めます
+	Progress(ctx context.Context, session *entity.UploadSession) (*ResumableUploadProgress, error)
+
+	// Append は受信済みバイト数の位置にbodyからsizeバイトを書き込み、更新後の受信状況を返します
+	// パーツサイズに達したパートはUploadPartとして記録します
+	Append(ctx context.Context, session *entity.UploadSession, progress *ResumableUploadProgress, body io.Reader, size int64) (*ResumableUploadProgress, error)
+
+	// Assemble は記録済みの全パーツを結合し、作成されたオブジェクトのバージョンIDを返します
+	Assemble(ctx context.Context, session *entity.UploadSession, progress *ResumableUploadProgress) (versionID string, err error)
+}
+
+// resumableUploadServiceImpl はResumableUploadServiceの実装
+type resumableUploadServiceImpl struct {
+	uploadPartRepo repository.UploadPartRepository
+	storageService StorageService
+}
+
+// NewResumableUploadService は新しいResumableUploadServiceを作成します
+func NewResumableUploadService(uploadPartRepo repository.UploadPartRepository, storageService StorageService) ResumableUploadService {
+	return &resumableUploadServiceImpl{
+		uploadPartRepo: uploadPartRepo,
+		storageService: storageService,
+	}
+}
+
+// Progress は受信済みバイト数を求めます
+// パーツ1から連続して記録されたパーツと、その次のパーツ番号の書きかけのパートのみを数えます
+func (s *resumableUploadServiceImpl) Progress(ctx context.Context, session *entity.UploadSession) (*ResumableUploadProgress, error) {
+	recorded, err := s.uploadPartRepo.FindBySessionID(ctx, session.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := &ResumableUploadProgress{}
+	for _, part := range recorded {
+		if part.PartNumber != len(progress.Parts)+1 {
+			break
+		}
+		progress.Parts = append(progress.Parts, part)
+		progress.Offset += part.Size
+	}
+
+	next := len(progress.Parts) + 1
+	if !session.IsValidPartNumber(next) {
+		return progress, nil
+	}
+
+	stored, err := s.storageService.ListParts(ctx, session.StorageKey.String(), uploadIDOf(session))
+	if err != nil {
+		return nil, err
+	}
+	for _, part := range stored {
+		if part.PartNumber == next && part.Size <= session.PartSize(next) {
+			partial := part
+			progress.Partial = &partial
+			progress.Offset += part.Size
+			break
+		}
+	}
+
+	return progress, nil
+}
+
+// Append は受信済みバイト数の位置からbodyを書き込みます
+func (s *resumableUploadServiceImpl) Append(
+	ctx context.Context,
+	session *entity.UploadSession,
+	progress *ResumableUploadProgress,
+	body io.Reader,
+	size int64,
+) (*ResumableUploadProgress, error) {
+	updated := *progress
+	remaining := size
+
+	// 書きかけのパートが既にパーツサイズに達している場合（記録前に中断された場合）も記録する
+	for remaining > 0 || updated.hasFullPartial(session) {
+		partNumber := len(updated.Parts) + 1
+		if !session.IsValidPartNumber(partNumber) {
+			return nil, entity.ErrUploadSizeExceeded
+		}
+		partSize := session.PartSize(partNumber)
+
+		var written int64
+		etag := ""
+		if updated.Partial != nil {
+			written = updated.Partial.Size
+			etag = updated.Partial.ETag
+		}
+		chunk := min(partSize-written, remaining)
+
+		if chunk > 0 {
+			var err error
+			etag, err = s.writePart(ctx, session, partNumber, written, io.LimitReader(body, chunk), written+chunk)
+			if err != nil {
+				return nil, err
+			}
+			written += chunk
+			remaining -= chunk
+			updated.Offset += chunk
+		}
+
+		if written < partSize {
+			updated.Partial = &StoredPart{PartNumber: partNumber, Size: written, ETag: etag}
+			continue
+		}
+
+		part := entity.NewUploadPart(session.ID, partNumber, partSize, etag)
+		if err := s.uploadPartRepo.Create(ctx, part); err != nil {
+			return nil, err
+		}
+		updated.Parts = append(updated.Parts, part)
+		updated.Partial = nil
+	}
+
+	return &updated, nil
+}
+
+// writePart は書きかけのパートの内容に続けてchunkを書き込み、パートを置き換えます
+func (s *resumableUploadServiceImpl) writePart(
+	ctx context.Context,
+	session *entity.UploadSession,
+	partNumber int,
+	written int64,
+	chunk io.Reader,
+	size int64,
+) (string, error) {
+	src := chunk
+	if written > 0 {
+		prev, err := s.storageService.GetPart(ctx, session.StorageKey.String(), uploadIDOf(session), partNumber)
+		if err != nil {
+			return "", err
+		}
+		defer prev.Close()
+		src = io.MultiReader(io.LimitReader(prev, written), chunk)
+	}
+
+	return s.storageService.UploadPart(ctx, session.StorageKey.String(), uploadIDOf(session), partNumber, src, size)
+}
+
+// Assemble は記録済みの全パーツを結合します
+func (s *resumableUploadServiceImpl) Assemble(ctx context.Context, session *entity.UploadSession, progress *ResumableUploadProgress) (string, error) {
+	etags := make([]string, len(progress.Parts))
+	for i, part := range progress.Parts {
+		etags[i] = part.ETag
+	}
+	return s.storageService.CompleteMultipartUpload(ctx, session.StorageKey.String(), uploadIDOf(session), etags)
+}
+
+// hasFullPartial は書きかけのパートがパーツサイズに達しているかを判定します
+func (p *ResumableUploadProgress) hasFullPartial(session *entity.UploadSession) bool {
+	return p.Partial != nil && p.Partial.Size == session.PartSize(p.Partial.PartNumber)
+}
+
+// uploadIDOf はセッションのマルチパートアップロードIDを返します（シングルパートは空文字）
+func uploadIDOf(session *entity.UploadSession) string {
+	if session.MinioUploadID == nil {
+		return ""
+	}
+	return *session.MinioUploadID
+}
@@ -0,0 +1,180 @@
+package service_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
+)
+
+type resumableUploadServiceTestDeps struct {
+	uploadPartRepo *mocks.MockUploadPartRepository
+	storageService *mocks.MockStorageService
+}
+
+func newResumableUploadServiceTestDeps(t *testing.T) *resumableUploadServiceTestDeps {
+	t.Helper()
+	return &resumableUploadServiceTestDeps{
+		uploadPartRepo: mocks.NewMockUploadPartRepository(t),
+		storageService: mocks.NewMockStorageService(t),
+	}
+}
+
+func (d *resumableUploadServiceTestDeps) newService() service.ResumableUploadService {
+	return service.NewResumableUploadService(d.uploadPartRepo, d.storageService)
+}
+
+func newResumableSession(totalSize int64) *entity.UploadSession {
+	name, _ := valueobject.NewFileName("large.bin")
+	mimeType, _ := valueobject.NewMimeType("application/octet-stream")
+	var uploadID *string
+	if totalSize >= entity.MultipartThreshold {
+		id := "minio-upload-id"
+		uploadID = &id
+	}
+	return entity.NewUploadSession(uuid.New(), uuid.New(), uuid.New(), name, mimeType, totalSize, uploadID)
+}
+
+// captureUploadPart はアップロードされたパートの内容を記録します
+func captureUploadPart(content *string) func(mock.Arguments) {
+	return func(args mock.Arguments) {
+		b, _ := io.ReadAll(args.Get(4).(io.Reader))
+		*content = string(b)
+	}
+}
+
+func TestResumableUploadService_Progress_CountsRecordedPartsAndPartial(t *testing.T) {
+	ctx := context.Background()
+	deps := newResumableUploadServiceTestDeps(t)
+
+	session := newResumableSession(entity.MinPartSize + 100)
+	key := session.StorageKey.String()
+	part1 := entity.NewUploadPart(session.ID, 1, entity.MinPartSize, "etag-1")
+
+	deps.uploadPartRepo.On("FindBySessionID", ctx, session.ID).Return([]*entity.UploadPart{part1}, nil)
+	deps.storageService.On("ListParts", ctx, key, "minio-upload-id").Return([]service.StoredPart{
+		{PartNumber: 1, Size: entity.MinPartSize, ETag: "etag-1"},
+		{PartNumber: 2, Size: 40, ETag: "etag-2"},
+	}, nil)
+
+	progress, err := deps.newService().Progress(ctx, session)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(entity.MinPartSize+40), progress.Offset)
+	assert.Len(t, progress.Parts, 1)
+	require.NotNil(t, progress.Partial)
+	assert.Equal(t, 2, progress.Partial.PartNumber)
+}
+
+func TestResumableUploadService_Progress_NothingUploaded_ReturnsZero(t *testing.T) {
+	ctx := context.Background()
+	deps := newResumableUploadServiceTestDeps(t)
+
+	session := newResumableSession(1024)
+
+	deps.uploadPartRepo.On("FindBySessionID", ctx, session.ID).Return([]*entity.UploadPart{}, nil)
+	deps.storageService.On("ListParts", ctx, session.StorageKey.String(), "").Return([]service.StoredPart{}, nil)
+
+	progress, err := deps.newService().Progress(ctx, session)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), progress.Offset)
+	assert.Nil(t, progress.Partial)
+}
+
+func TestResumableUploadService_Append_ShortChunk_StoresPartialPart(t *testing.T) {
+	ctx := context.Background()
+	deps := newResumableUploadServiceTestDeps(t)
+
+	session := newResumableSession(10)
+	var uploaded string
+	deps.storageService.On("UploadPart", ctx, session.StorageKey.String(), "", 1, mock.Anything, int64(5)).
+		Run(captureUploadPart(&uploaded)).Return("etag-partial", nil)
+
+	progress, err := deps.newService().Append(ctx, session, &service.ResumableUploadProgress{}, strings.NewReader("hello"), 5)
+
+	require.NoError(t, err)
+	assert.Equal(t, "hello", uploaded)
+	assert.Equal(t, int64(5), progress.Offset)
+	assert.Empty(t, progress.Parts)
+	require.NotNil(t, progress.Partial)
+	assert.Equal(t, int64(5), progress.Partial.Size)
+}
+
+func TestResumableUploadService_Append_CompletesPartialWithStoredContent(t *testing.T) {
+	ctx := context.Background()
+	deps := newResumableUploadServiceTestDeps(t)
+
+	session := newResumableSession(10)
+	key := session.StorageKey.String()
+	var uploaded string
+	deps.storageService.On("GetPart", ctx, key, "", 1).Return(io.NopCloser(strings.NewReader("hello")), nil)
+	deps.storageService.On("UploadPart", ctx, key, "", 1, mock.Anything, int64(10)).
+		Run(captureUploadPart(&uploaded)).Return("etag-full", nil)
+	deps.uploadPartRepo.On("Create", ctx, mock.MatchedBy(func(p *entity.UploadPart) bool {
+		return p.PartNumber == 1 && p.Size == 10 && p.ETag == "etag-full"
+	})).Return(nil)
+
+	progress, err := deps.newService().Append(ctx, session, &service.ResumableUploadProgress{
+		Offset:  5,
+		Partial: &service.StoredPart{PartNumber: 1, Size: 5, ETag: "etag-partial"},
+	}, strings.NewReader("world"), 5)
+
+	require.NoError(t, err)
+	assert.Equal(t, "helloworld", uploaded)
+	assert.Equal(t, int64(10), progress.Offset)
+	assert.Len(t, progress.Parts, 1)
+	assert.Nil(t, progress.Partial)
+}
+
+func TestResumableUploadService_Append_SplitsChunkAtPartBoundaries(t *testing.T) {
+	ctx := context.Background()
+	deps := newResumableUploadServiceTestDeps(t)
+
+	session := newResumableSession(entity.MinPartSize + 3)
+	key := session.StorageKey.String()
+	var first, last string
+	deps.storageService.On("UploadPart", ctx, key, "minio-upload-id", 1, mock.Anything, int64(entity.MinPartSize)).
+		Run(captureUploadPart(&first)).Return("etag-1", nil)
+	deps.storageService.On("UploadPart", ctx, key, "minio-upload-id", 2, mock.Anything, int64(3)).
+		Run(captureUploadPart(&last)).Return("etag-2", nil)
+	deps.uploadPartRepo.On("Create", ctx, mock.AnythingOfType("*entity.UploadPart")).Return(nil).Twice()
+
+	body := strings.NewReader(strings.Repeat("a", entity.MinPartSize) + "xyz")
+	progress, err := deps.newService().Append(ctx, session, &service.ResumableUploadProgress{}, body, entity.MinPartSize+3)
+
+	require.NoError(t, err)
+	assert.Len(t, first, entity.MinPartSize)
+	assert.Equal(t, "xyz", last)
+	assert.Equal(t, session.TotalSize, progress.Offset)
+	require.Len(t, progress.Parts, 2)
+	assert.Equal(t, 2, progress.Parts[1].PartNumber)
+}
+
+func TestResumableUploadService_Assemble_ComposesPartsInOrder(t *testing.T) {
+	ctx := context.Background()
+	deps := newResumableUploadServiceTestDeps(t)
+
+	session := newResumableSession(entity.MinPartSize + 3)
+	parts := []*entity.UploadPart{
+		entity.NewUploadPart(session.ID, 1, entity.MinPartSize, "etag-1"),
+		entity.NewUploadPart(session.ID, 2, 3, "etag-2"),
+	}
+	deps.storageService.On("CompleteMultipartUpload", ctx, session.StorageKey.String(), "minio-upload-id", []string{"etag-1", "etag-2"}).
+		Return("v-assembled", nil)
+
+	versionID, err := deps.newService().Assemble(ctx, session, &service.ResumableUploadProgress{Parts: parts})
+
+	require.NoError(t, err)
+	assert.Equal(t, "v-assembled", versionID)
+}
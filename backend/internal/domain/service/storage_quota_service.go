@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// StorageQuotaService はストレージクォータに関するドメインサービス
+type StorageQuotaService interface {
+	// GetUserLimit はユーザーの個人クォータ上限を返します（0は無制限）
+	// 個別設定がない場合はデフォルト値を返します
+	GetUserLimit(ctx context.Context, userID uuid.UUID) (int64, error)
+
+	// EnsureCapacity はユーザーがadditionalBytesを追加しても
+	// 個人クォータと所属グループのクォータを超えないことを検証します
+	EnsureCapacity(ctx context.Context, userID uuid.UUID, additionalBytes int64) error
+}
+
+// storageQuotaServiceImpl はStorageQuotaServiceの実装
+type storageQuotaServiceImpl struct {
+	quotaRepo        repository.StorageQuotaRepository
+	usageRepo        repository.StorageUsageRepository
+	defaultUserLimit int64
+}
+
+// NewStorageQuotaService は新しいStorageQuotaServiceを作成します
+// defaultUserLimit が0の場合、個別設定のないユーザーは無制限となります
+func NewStorageQuotaService(
+	quotaRepo repository.StorageQuotaRepository,
+	usageRepo repository.StorageUsageRepository,
+	defaultUserLimit int64,
+) StorageQuotaService {
+	return &storageQuotaServiceImpl{
+		quotaRepo:        quotaRepo,
+		usageRepo:        usageRepo,
+		defaultUserLimit: defaultUserLimit,
+	}
+}
+
+// GetUserLimit はユーザーの個人クォータ上限を返します
+func (s *storageQuotaServiceImpl) GetUserLimit(ctx context.Context, userID uuid.UUID) (int64, error) {
+	quota, err := s.quotaRepo.FindBySubject(ctx, entity.QuotaSubjectUser, userID)
+	if err != nil {
+		if apperror.IsNotFound(err) {
+			return s.defaultUserLimit, nil
+		}
+		return 0, err
+	}
+	return quota.LimitBytes, nil
+}
+
+// EnsureCapacity はクォータ超過にならないことを検証します
+func (s *storageQuotaServiceImpl) EnsureCapacity(ctx context.Context, userID uuid.UUID, additionalBytes int64) error {
+	// 1. 個人クォータ
+	limit, err := s.GetUserLimit(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if limit > 0 {
+		used, err := s.usageRepo.GetUsedBytes(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if used+additionalBytes > limit {
+			return apperror.NewQuotaExceededError("storage quota exceeded")
+		}
+	}
+
+	// 2. 所属グループのクォータ（メンバー全員の合計に適用）
+	groupQuotas, err := s.quotaRepo.FindGroupQuotasByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, quota := range groupQuotas {
+		used, err := s.usageRepo.SumUsedBytesByGroup(ctx, quota.SubjectID)
+		if err != nil {
+			return err
+		}
+		if !quota.Allows(used, additionalBytes) {
+			return apperror.NewQuotaExceededError("group storage quota exceeded")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
+)
+
+const testDefaultUserLimit int64 = 1000
+
+type storageQuotaTestDeps struct {
+	quotaRepo *mocks.MockStorageQuotaRepository
+	usageRepo *mocks.MockStorageUsageRepository
+}
+
+func newStorageQuotaTestDeps(t *testing.T) *storageQuotaTestDeps {
+	t.Helper()
+	return &storageQuotaTestDeps{
+		quotaRepo: mocks.NewMockStorageQuotaRepository(t),
+		usageRepo: mocks.NewMockStorageUsageRepository(t),
+	}
+}
+
+func (d *storageQuotaTestDeps) newService(defaultLimit int64) service.StorageQuotaService {
+	return service.NewStorageQuotaService(d.quotaRepo, d.usageRepo, defaultLimit)
+}
+
+func assertQuotaExceeded(t *testing.T, err error) {
+	t.Helper()
+	require.Error(t, err)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeQuotaExceeded, appErr.Code)
+}
+
+func TestStorageQuotaService_GetUserLimit_NoQuota_ReturnsDefault(t *testing.T) {
+	ctx := context.Background()
+	deps := newStorageQuotaTestDeps(t)
+	userID := uuid.New()
+
+	deps.quotaRepo.On("FindBySubject", ctx, entity.QuotaSubjectUser, userID).Return(nil, apperror.NewNotFoundError("storage quota"))
+
+	limit, err := deps.newService(testDefaultUserLimit).GetUserLimit(ctx, userID)
+
+	require.NoError(t, err)
+	assert.Equal(t, testDefaultUserLimit, limit)
+}
+
+func TestStorageQuotaService_EnsureCapacity_WithinLimits_ReturnsNil(t *testing.T) {
+	ctx := context.Background()
+	deps := newStorageQuotaTestDeps(t)
+	userID := uuid.New()
+	groupQuota, _ := entity.NewStorageQuota(entity.QuotaSubjectGroup, uuid.New(), 5000)
+
+	deps.quotaRepo.On("FindBySubject", ctx, entity.QuotaSubjectUser, userID).Return(nil, apperror.NewNotFoundError("storage quota"))
+	deps.usageRepo.On("GetUsedBytes", ctx, userID).Return(int64(900), nil)
+	deps.quotaRepo.On("FindGroupQuotasByUserID", ctx, userID).Return([]*entity.StorageQuota{groupQuota}, nil)
+	deps.usageRepo.On("SumUsedBytesByGroup", ctx, groupQuota.SubjectID).Return(int64(4000), nil)
+
+	err := deps.newService(testDefaultUserLimit).EnsureCapacity(ctx, userID, 100)
+
+	assert.NoError(t, err)
+}
+
+func TestStorageQuotaService_EnsureCapacity_UserQuotaExceeded_ReturnsQuotaExceeded(t *testing.T) {
+	ctx := context.Background()
+	deps := newStorageQuotaTestDeps(t)
+	userID := uuid.New()
+	userQuota, _ := entity.NewStorageQuota(entity.QuotaSubjectUser, userID, 500)
+
+	deps.quotaRepo.On("FindBySubject", ctx, entity.QuotaSubjectUser, userID).Return(userQuota, nil)
+	deps.usageRepo.On("GetUsedBytes", ctx, userID).Return(int64(450), nil)
+
+	err := deps.newService(testDefaultUserLimit).EnsureCapacity(ctx, userID, 51)
+
+	assertQuotaExceeded(t, err)
+}
+
+func TestStorageQuotaService_EnsureCapacity_GroupQuotaExceeded_ReturnsQuotaExceeded(t *testing.T) {
+	ctx := context.Background()
+	deps := newStorageQuotaTestDeps(t)
+	userID := uuid.New()
+	groupQuota, _ := entity.NewStorageQuota(entity.QuotaSubjectGroup, uuid.New(), 2000)
+
+	deps.quotaRepo.On("FindBySubject", ctx, entity.QuotaSubjectUser, userID).Return(nil, apperror.NewNotFoundError("storage quota"))
+	deps.quotaRepo.On("FindGroupQuotasByUserID", ctx, userID).Return([]*entity.StorageQuota{groupQuota}, nil)
+	deps.usageRepo.On("SumUsedBytesByGroup", ctx, groupQuota.SubjectID).Return(int64(1990), nil)
+
+	// defaultLimit=0 は個人クォータ無制限
+	err := deps.newService(0).EnsureCapacity(ctx, userID, 11)
+
+	assertQuotaExceeded(t, err)
+}
@@ -2,13 +2,21 @@ package service
 
 import (
 	"context"
+	"errors"
+	"io"
 	"time"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
 )
 
+// ErrObjectNotFound はオブジェクトが存在しない場合のエラーです
+var ErrObjectNotFound = errors.New("object not found")
+
 // PresignedURL はPresigned URL情報を表します
 type PresignedURL struct {
 	URL       string
 	ExpiresAt time.Time
+	Headers   map[string]string // 署名に含めたため、アップロード時に送信が必要なヘッダー
 }
 
 // MultipartUploadURL はマルチパートアップロードURL情報を表します
@@ -18,13 +26,58 @@ type MultipartUploadURL struct {
 	ExpiresAt  time.Time
 }
 
+// IncompleteMultipartUpload は未完了のマルチパートアップロード情報を表します
+type IncompleteMultipartUpload struct {
+	ObjectKey string
+	UploadID  string
+	Initiated time.Time
+}
+
+// UploadedObject はサーバー経由でアップロードしたオブジェクトバージョンの情報を表します
+type UploadedObject struct {
+	VersionID string
+	ETag      string
+}
+
+// ObjectInfo はオブジェクトバージョンの情報を表します
+type ObjectInfo struct {
+	VersionID    string
+	Size         int64
+	ETag         string
+	ContentType  string
+	LastModified time.Time
+
+	// アップロード時にMinIOが検証・記録した内容全体のチェックサム（16進数、記録がない場合は空）
+	// GetObjectVersionInfoでのみ設定されます
+	ChecksumSHA256 string
+	ChecksumCRC32C string
+}
+
+// StoredPart はストレージ上にアップロード済みのパート情報を表します
+type StoredPart struct {
+	PartNumber int
+	Size       int64
+	ETag       string
+}
+
+// ObjectVersion はバケット内のオブジェクトバージョン情報を表します
+type ObjectVersion struct {
+	Key            string
+	VersionID      string
+	Size           int64
+	ETag           string
+	LastModified   time.Time
+	IsLatest       bool
+	IsDeleteMarker bool
+}
+
 // StorageService はストレージ操作のドメインサービスインターフェースです
 type StorageService interface {
-	// シングルパートアップロード用URL生成
-	GeneratePutURL(ctx context.Context, objectKey string, expiry time.Duration) (*PresignedURL, error)
+	// シングルパートアップロード用URL生成（checksumを指定するとチェックサムヘッダーを署名に含める）
+	GeneratePutURL(ctx context.Context, objectKey string, expiry time.Duration, checksum *valueobject.ContentChecksum) (*PresignedURL, error)
 
-	// ダウンロード用URL生成
-	GenerateGetURL(ctx context.Context, objectKey string, expiry time.Duration) (*PresignedURL, error)
+	// ダウンロード用URL生成（versionIDが空の場合は最新バージョン）
+	GenerateGetURL(ctx context.Context, objectKey, versionID string, expiry time.Duration) (*PresignedURL, error)
 
 	// マルチパートアップロード開始
 	CreateMultipartUpload(ctx context.Context, objectKey string) (uploadID string, err error)
@@ -32,15 +85,48 @@ type StorageService interface {
 	// マルチパートアップロード用パートURL生成
 	GeneratePartUploadURL(ctx context.Context, objectKey, uploadID string, partNumber int) (*MultipartUploadURL, error)
 
-	// マルチパートアップロード完了
-	CompleteMultipartUpload(ctx context.Context, objectKey, uploadID string, etags []string) error
+	// マルチパートアップロードのパートをサーバー経由でアップロード（sizeバイトを読み込み、ETagを返す）
+	UploadPart(ctx context.Context, objectKey, uploadID string, partNumber int, reader io.Reader, size int64) (etag string, err error)
+
+	// アップロード済みのパート一覧（パートが記録される前の書きかけのパートを含む）
+	ListParts(ctx context.Context, objectKey, uploadID string) ([]StoredPart, error)
+
+	// アップロード済みのパートの内容を取得（呼び出し側でCloseが必要）
+	GetPart(ctx context.Context, objectKey, uploadID string, partNumber int) (io.ReadCloser, error)
+
+	// マルチパートアップロード完了（パートを結合したオブジェクトのバージョンIDを返す）
+	CompleteMultipartUpload(ctx context.Context, objectKey, uploadID string, etags []string) (versionID string, err error)
 
 	// マルチパートアップロード中断
 	AbortMultipartUpload(ctx context.Context, objectKey, uploadID string) error
 
+	// 未完了のマルチパートアップロード一覧
+	ListIncompleteUploads(ctx context.Context, prefix string) ([]IncompleteMultipartUpload, error)
+
+	// オブジェクト取得（versionIDが空の場合は最新バージョン、呼び出し側でCloseが必要）
+	GetObject(ctx context.Context, objectKey, versionID string) (io.ReadCloser, error)
+
+	// オブジェクトの最新バージョンの情報を取得（存在しない場合はErrObjectNotFound）
+	GetObjectInfo(ctx context.Context, objectKey string) (*ObjectInfo, error)
+
+	// オブジェクトの特定バージョンの情報を記録されたチェックサムを含めて取得（存在しない場合はErrObjectNotFound）
+	GetObjectVersionInfo(ctx context.Context, objectKey, versionID string) (*ObjectInfo, error)
+
+	// オブジェクトをサーバー経由でアップロード（sizeバイトを読み込む）
+	PutObject(ctx context.Context, objectKey string, reader io.Reader, size int64, contentType string) (*UploadedObject, error)
+
 	// オブジェクト削除
 	DeleteObject(ctx context.Context, objectKey string) error
 
 	// 複数オブジェクト削除
 	DeleteObjects(ctx context.Context, objectKeys []string) error
+
+	// オブジェクトの特定バージョンをコピー（コピー先に作成されたバージョンIDを返す）
+	CopyObjectVersion(ctx context.Context, srcKey, srcVersionID, dstKey string) (versionID string, err error)
+
+	// オブジェクトの特定バージョンを削除
+	DeleteObjectVersion(ctx context.Context, objectKey, versionID string) error
+
+	// バケット内の全オブジェクトバージョンを走査（fnがエラーを返すと中断）
+	WalkObjectVersions(ctx context.Context, prefix string, fn func(ObjectVersion) error) error
 }
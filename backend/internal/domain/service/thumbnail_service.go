@@ -0,0 +1,187 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/gif" // GIFデコーダーを登録（先頭フレームを使用）
+	"image/jpeg"
+	_ "image/png" // PNGデコーダーを登録
+	"io"
+
+	_ "golang.org/x/image/webp" // WebPデコーダーを登録
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+)
+
+const (
+	// thumbnailMaxSourceBytes はサムネイルを生成する元画像の最大サイズです
+	thumbnailMaxSourceBytes = 64 * 1024 * 1024
+	// thumbnailMaxSourcePixels は元画像の最大ピクセル数です（展開後のメモリ使用量を制限する）
+	thumbnailMaxSourcePixels = 40_000_000
+	// thumbnailJPEGQuality はサムネイルのJPEG品質です
+	thumbnailJPEGQuality = 80
+	// thumbnailContentType はサムネイルのContent-Typeです
+	thumbnailContentType = "image/jpeg"
+)
+
+var (
+	// ErrUnsupportedImageFormat はデコーダーのない画像形式の場合のエラーです
+	ErrUnsupportedImageFormat = errors.New("unsupported image format")
+	// ErrInvalidImage は画像をデコードできない場合のエラーです
+	ErrInvalidImage = errors.New("image could not be decoded")
+	// ErrImageTooLarge は元画像がサムネイル生成の上限を超える場合のエラーです
+	ErrImageTooLarge = errors.New("image is too large")
+)
+
+// ThumbnailService はファイルバージョンのサムネイルを生成するドメインサービス
+// デコードは標準ライブラリとWebPデコーダー、縮小は標準ライブラリのみで行い、全サイズをJPEGとして元の内容から派生したキーに保存します
+// Note: 対象は画像ファイル（JPEG・PNG・GIF・WebP）のみで、PDFはプレビュー対象でもサムネイルの対象外
+// PDFの先頭ページを描画するにはPDFレンダラーが必要で、pure Goのデコーダーだけでは生成できないため
+type ThumbnailService interface {
+	// Schedule はファイルバージョンのサムネイル生成を登録します
+	// PDFを含む画像以外のファイルと、登録済みのバージョンの場合は何もしません
+	Schedule(ctx context.Context, file *entity.File, version *entity.FileVersion) error
+
+	// Render は元の内容を読み込んで全サイズのサムネイルを生成し、保存します
+	// 生成できない画像の場合はErrUnsupportedImageFormat、ErrInvalidImage、ErrImageTooLargeを返します
+	Render(ctx context.Context, thumbnail *entity.Thumbnail) error
+}
+
+// thumbnailServiceImpl はThumbnailServiceの実装
+type thumbnailServiceImpl struct {
+	thumbnailRepo  repository.ThumbnailRepository
+	storageService StorageService
+}
+
+// NewThumbnailService は新しいThumbnailServiceを作成します
+func NewThumbnailService(thumbnailRepo repository.ThumbnailRepository, storageService StorageService) ThumbnailService {
+	return &thumbnailServiceImpl{
+		thumbnailRepo:  thumbnailRepo,
+		storageService: storageService,
+	}
+}
+
+// Schedule は画像ファイルのバージョンのサムネイルを生成待ちとして登録します
+// PDFなど画像以外のファイルは生成対象外のため登録しません（取得時はNotFoundになる）
+func (s *thumbnailServiceImpl) Schedule(ctx context.Context, file *entity.File, version *entity.FileVersion) error {
+	if !file.MimeType.IsImage() {
+		return nil
+	}
+	return s.thumbnailRepo.Create(ctx, entity.NewThumbnail(version))
+}
+
+// Render は元画像を1回デコードし、サイズごとに縮小してJPEGで保存します
+func (s *thumbnailServiceImpl) Render(ctx context.Context, thumbnail *entity.Thumbnail) error {
+	img, err := s.decodeSource(ctx, thumbnail)
+	if err != nil {
+		return err
+	}
+
+	for _, size := range valueobject.ThumbnailSizes {
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, resizeToFit(img, size.MaxDimension()), &jpeg.Options{Quality: thumbnailJPEGQuality}); err != nil {
+			return fmt.Errorf("failed to encode %s thumbnail: %w", size, err)
+		}
+
+		key := thumbnail.ObjectKey(size)
+		if _, err := s.storageService.PutObject(ctx, key, &buf, int64(buf.Len()), thumbnailContentType); err != nil {
+			return fmt.Errorf("failed to put thumbnail %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// decodeSource は元の内容を読み込み、白背景に合成したRGBA画像を返します
+// JPEGに透過がないため、透過のあるPNG・GIF・WebPは白背景で表示されるようにします
+func (s *thumbnailServiceImpl) decodeSource(ctx context.Context, thumbnail *entity.Thumbnail) (*image.RGBA, error) {
+	src, err := s.storageService.GetObject(ctx, thumbnail.SourceKey.String(), thumbnail.SourceVersionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object %s: %w", thumbnail.SourceKey.String(), err)
+	}
+	defer src.Close()
+
+	data, err := io.ReadAll(io.LimitReader(src, thumbnailMaxSourceBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object %s: %w", thumbnail.SourceKey.String(), err)
+	}
+	if len(data) > thumbnailMaxSourceBytes {
+		return nil, ErrImageTooLarge
+	}
+
+	// 展開する前に寸法を確認し、巨大な画像でメモリを使い切らないようにする
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		if errors.Is(err, image.ErrFormat) {
+			return nil, ErrUnsupportedImageFormat
+		}
+		return nil, ErrInvalidImage
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return nil, ErrInvalidImage
+	}
+	if int64(config.Width)*int64(config.Height) > thumbnailMaxSourcePixels {
+		return nil, ErrImageTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+
+	bounds := img.Bounds()
+	canvas := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(canvas, canvas.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(canvas, canvas.Bounds(), img, bounds.Min, draw.Over)
+	return canvas, nil
+}
+
+// resizeToFit は縦横比を保ったまま長辺がmaxDimension以下になるよう縮小します
+// 各出力ピクセルは対応する元画像の領域の平均（ボックスフィルター）で、拡大はしません
+func resizeToFit(src *image.RGBA, maxDimension int) *image.RGBA {
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	dw, dh := sw, sh
+	if sw > maxDimension || sh > maxDimension {
+		if sw >= sh {
+			dw = maxDimension
+			dh = max(1, sh*maxDimension/sw)
+		} else {
+			dh = maxDimension
+			dw = max(1, sw*maxDimension/sh)
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for dy := 0; dy < dh; dy++ {
+		y0 := dy * sh / dh
+		y1 := max(y0+1, (dy+1)*sh/dh)
+		for dx := 0; dx < dw; dx++ {
+			x0 := dx * sw / dw
+			x1 := max(x0+1, (dx+1)*sw/dw)
+
+			var r, g, b, a, n uint64
+			for y := y0; y < y1; y++ {
+				row := src.Pix[y*src.Stride+x0*4 : y*src.Stride+x1*4]
+				for i := 0; i < len(row); i += 4 {
+					r += uint64(row[i])
+					g += uint64(row[i+1])
+					b += uint64(row[i+2])
+					a += uint64(row[i+3])
+					n++
+				}
+			}
+
+			off := dst.PixOffset(dx, dy)
+			dst.Pix[off] = uint8(r / n)
+			dst.Pix[off+1] = uint8(g / n)
+			dst.Pix[off+2] = uint8(b / n)
+			dst.Pix[off+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
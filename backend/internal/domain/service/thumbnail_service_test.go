@@ -0,0 +1,286 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
+)
+
+type thumbnailServiceTestDeps struct {
+	thumbnailRepo  *mocks.MockThumbnailRepository
+	storageService *mocks.MockStorageService
+}
+
+func newThumbnailServiceTestDeps(t *testing.T) *thumbnailServiceTestDeps {
+	t.Helper()
+	return &thumbnailServiceTestDeps{
+		thumbnailRepo:  mocks.NewMockThumbnailRepository(t),
+		storageService: mocks.NewMockStorageService(t),
+	}
+}
+
+func (d *thumbnailServiceTestDeps) newService() service.ThumbnailService {
+	return service.NewThumbnailService(d.thumbnailRepo, d.storageService)
+}
+
+func newThumbnailTestFile(mimeType string) (*entity.File, *entity.FileVersion) {
+	name, _ := valueobject.NewFileName("photo")
+	mime, _ := valueobject.NewMimeType(mimeType)
+	fileID := uuid.New()
+	file := entity.ReconstructFile(
+		fileID, uuid.New(), uuid.New(), uuid.New(),
+		name, mime, 1024, valueobject.NewStorageKey(fileID), 2,
+		entity.FileStatusActive, time.Now(), time.Now(),
+	)
+	version := entity.NewFileVersion(fileID, 2, "minio-v2", 1024, "checksum", file.OwnerID)
+	return file, version
+}
+
+func encodeTestPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.NRGBA{R: 200, G: 40, B: 40, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+// encodeTestWebP は単色のロスレスWebP（VP8L）を生成します
+// 各チャネルを1シンボルのみのプレフィックスコードで表すため、ピクセルデータは0ビットになります
+func encodeTestWebP(t *testing.T, width, height int, c color.NRGBA) []byte {
+	t.Helper()
+	require.True(t, width > 0 && width <= 1<<14 && height > 0 && height <= 1<<14)
+
+	var bits []byte
+	var acc uint64
+	var n uint
+	write := func(value uint64, count uint) {
+		acc |= value << n
+		n += count
+		for n >= 8 {
+			bits = append(bits, byte(acc))
+			acc >>= 8
+			n -= 8
+		}
+	}
+	write(uint64(width-1), 14)
+	write(uint64(height-1), 14)
+	write(1, 1) // アルファあり
+	write(0, 3) // バージョン
+	write(0, 1) // 変換なし
+	write(0, 1) // カラーキャッシュなし
+	write(0, 1) // メタプレフィックスコードなし
+	// 緑・赤・青・アルファ・距離の順に、1シンボルのシンプルコード
+	for _, symbol := range []uint8{c.G, c.R, c.B, c.A, 0} {
+		write(1, 1) // シンプルコード
+		write(0, 1) // シンボル数 - 1
+		write(1, 1) // シンボルを8ビットで表す
+		write(uint64(symbol), 8)
+	}
+	if n > 0 {
+		bits = append(bits, byte(acc))
+	}
+
+	chunk := append([]byte{0x2f}, bits...)
+	if len(chunk)%2 == 1 {
+		chunk = append(chunk, 0)
+	}
+	var buf bytes.Buffer
+	buf.WriteString("RIFF")
+	require.NoError(t, binary.Write(&buf, binary.LittleEndian, uint32(4+8+len(chunk))))
+	buf.WriteString("WEBPVP8L")
+	require.NoError(t, binary.Write(&buf, binary.LittleEndian, uint32(1+len(bits))))
+	buf.Write(chunk)
+	return buf.Bytes()
+}
+
+func TestThumbnailService_Schedule_Image_CreatesPendingThumbnail(t *testing.T) {
+	ctx := context.Background()
+	deps := newThumbnailServiceTestDeps(t)
+	file, version := newThumbnailTestFile("image/png")
+
+	deps.thumbnailRepo.On("Create", ctx, mock.MatchedBy(func(thumb *entity.Thumbnail) bool {
+		return thumb.FileID == file.ID && thumb.VersionNumber == 2 &&
+			thumb.SourceVersionID == "minio-v2" && thumb.Status == entity.ThumbnailStatusPending
+	})).Return(nil)
+
+	err := deps.newService().Schedule(ctx, file, version)
+
+	require.NoError(t, err)
+}
+
+// PDFはサムネイルの対象外（先頭ページを描画しない）
+func TestThumbnailService_Schedule_PDF_DoesNothing(t *testing.T) {
+	ctx := context.Background()
+	deps := newThumbnailServiceTestDeps(t)
+	file, version := newThumbnailTestFile("application/pdf")
+
+	err := deps.newService().Schedule(ctx, file, version)
+
+	require.NoError(t, err)
+	deps.thumbnailRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestThumbnailService_Render_StoresAllSizesFittedToBox(t *testing.T) {
+	ctx := context.Background()
+	deps := newThumbnailServiceTestDeps(t)
+	_, version := newThumbnailTestFile("image/png")
+	thumb := entity.NewThumbnail(version)
+
+	deps.storageService.On("GetObject", ctx, version.StorageKey.String(), "minio-v2").
+		Return(io.NopCloser(bytes.NewReader(encodeTestPNG(t, 1000, 500))), nil)
+
+	rendered := make(map[string]image.Image)
+	deps.storageService.On("PutObject", ctx, mock.Anything, mock.Anything, mock.Anything, "image/jpeg").
+		Run(func(args mock.Arguments) {
+			data, err := io.ReadAll(args.Get(2).(io.Reader))
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(data)), args.Get(3).(int64))
+			img, err := jpeg.Decode(bytes.NewReader(data))
+			require.NoError(t, err)
+			rendered[args.String(1)] = img
+		}).
+		Return(&service.UploadedObject{VersionID: "thumb-v1"}, nil).Times(3)
+
+	err := deps.newService().Render(ctx, thumb)
+
+	require.NoError(t, err)
+	require.Len(t, rendered, 3)
+	for size, want := range map[valueobject.ThumbnailSize]image.Point{
+		valueobject.ThumbnailSizeSmall:  {X: 128, Y: 64},
+		valueobject.ThumbnailSizeMedium: {X: 256, Y: 128},
+		valueobject.ThumbnailSizeLarge:  {X: 512, Y: 256},
+	} {
+		img, ok := rendered[thumb.ObjectKey(size)]
+		require.True(t, ok, "missing %s thumbnail", size)
+		assert.Equal(t, want, img.Bounds().Size(), "%s thumbnail size", size)
+
+		r, g, b, _ := img.At(want.X/2, want.Y/2).RGBA()
+		assert.InDelta(t, 200, r>>8, 8)
+		assert.InDelta(t, 40, g>>8, 8)
+		assert.InDelta(t, 40, b>>8, 8)
+	}
+}
+
+func TestThumbnailService_Render_SmallImage_IsNotUpscaled(t *testing.T) {
+	ctx := context.Background()
+	deps := newThumbnailServiceTestDeps(t)
+	_, version := newThumbnailTestFile("image/png")
+	thumb := entity.NewThumbnail(version)
+
+	deps.storageService.On("GetObject", ctx, version.StorageKey.String(), "minio-v2").
+		Return(io.NopCloser(bytes.NewReader(encodeTestPNG(t, 40, 100))), nil)
+
+	var sizes []image.Point
+	deps.storageService.On("PutObject", ctx, mock.Anything, mock.Anything, mock.Anything, "image/jpeg").
+		Run(func(args mock.Arguments) {
+			img, err := jpeg.Decode(args.Get(2).(io.Reader))
+			require.NoError(t, err)
+			sizes = append(sizes, img.Bounds().Size())
+		}).
+		Return(&service.UploadedObject{VersionID: "thumb-v1"}, nil).Times(3)
+
+	err := deps.newService().Render(ctx, thumb)
+
+	require.NoError(t, err)
+	assert.Equal(t, []image.Point{{X: 40, Y: 100}, {X: 40, Y: 100}, {X: 40, Y: 100}}, sizes)
+}
+
+func TestThumbnailService_Render_WebP_StoresJPEGThumbnails(t *testing.T) {
+	ctx := context.Background()
+	deps := newThumbnailServiceTestDeps(t)
+	_, version := newThumbnailTestFile("image/webp")
+	thumb := entity.NewThumbnail(version)
+
+	deps.storageService.On("GetObject", ctx, version.StorageKey.String(), "minio-v2").
+		Return(io.NopCloser(bytes.NewReader(encodeTestWebP(t, 600, 300, color.NRGBA{R: 40, G: 160, B: 40, A: 255}))), nil)
+
+	var sizes []image.Point
+	deps.storageService.On("PutObject", ctx, mock.Anything, mock.Anything, mock.Anything, "image/jpeg").
+		Run(func(args mock.Arguments) {
+			img, err := jpeg.Decode(args.Get(2).(io.Reader))
+			require.NoError(t, err)
+			sizes = append(sizes, img.Bounds().Size())
+			r, g, b, _ := img.At(0, 0).RGBA()
+			assert.InDelta(t, 40, r>>8, 8)
+			assert.InDelta(t, 160, g>>8, 8)
+			assert.InDelta(t, 40, b>>8, 8)
+		}).
+		Return(&service.UploadedObject{VersionID: "thumb-v1"}, nil).Times(3)
+
+	err := deps.newService().Render(ctx, thumb)
+
+	require.NoError(t, err)
+	assert.Len(t, sizes, 3)
+	for _, size := range sizes {
+		assert.Equal(t, size.X, size.Y*2)
+	}
+}
+
+func TestThumbnailService_Render_UnknownFormat_ReturnsErrUnsupportedImageFormat(t *testing.T) {
+	ctx := context.Background()
+	deps := newThumbnailServiceTestDeps(t)
+	_, version := newThumbnailTestFile("image/svg+xml")
+	thumb := entity.NewThumbnail(version)
+
+	deps.storageService.On("GetObject", ctx, version.StorageKey.String(), "minio-v2").
+		Return(io.NopCloser(strings.NewReader(`<svg xmlns="http://www.w3.org/2000/svg" width="10" height="10"></svg>`)), nil)
+
+	err := deps.newService().Render(ctx, thumb)
+
+	assert.ErrorIs(t, err, service.ErrUnsupportedImageFormat)
+	deps.storageService.AssertNotCalled(t, "PutObject", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestThumbnailService_Render_PDF_ReturnsErrUnsupportedImageFormat(t *testing.T) {
+	ctx := context.Background()
+	deps := newThumbnailServiceTestDeps(t)
+	_, version := newThumbnailTestFile("application/pdf")
+	thumb := entity.NewThumbnail(version)
+
+	deps.storageService.On("GetObject", ctx, version.StorageKey.String(), "minio-v2").
+		Return(io.NopCloser(strings.NewReader("%PDF-1.7\n1 0 obj\n<< /Type /Catalog >>\nendobj\n%%EOF\n")), nil)
+
+	err := deps.newService().Render(ctx, thumb)
+
+	assert.ErrorIs(t, err, service.ErrUnsupportedImageFormat)
+	deps.storageService.AssertNotCalled(t, "PutObject", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestThumbnailService_Render_CorruptImage_ReturnsErrInvalidImage(t *testing.T) {
+	ctx := context.Background()
+	deps := newThumbnailServiceTestDeps(t)
+	_, version := newThumbnailTestFile("image/png")
+	thumb := entity.NewThumbnail(version)
+
+	data := encodeTestPNG(t, 64, 64)
+	deps.storageService.On("GetObject", ctx, version.StorageKey.String(), "minio-v2").
+		Return(io.NopCloser(bytes.NewReader(data[:len(data)/2])), nil)
+
+	err := deps.newService().Render(ctx, thumb)
+
+	assert.ErrorIs(t, err, service.ErrInvalidImage)
+	deps.storageService.AssertNotCalled(t, "PutObject", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
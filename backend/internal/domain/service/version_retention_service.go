@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// VersionRetentionService はバージョン保持ポリシーに関するドメインサービス
+type VersionRetentionService interface {
+	// ResolvePolicy はファイルに適用される保持ポリシーを返します（未設定の場合はnil）
+	// 優先順位: 所属フォルダ → 祖先フォルダ（近い順） → ファイル所有者
+	ResolvePolicy(ctx context.Context, file *entity.File) (*entity.VersionRetentionPolicy, error)
+}
+
+// versionRetentionServiceImpl はVersionRetentionServiceの実装
+type versionRetentionServiceImpl struct {
+	policyRepo  repository.VersionRetentionPolicyRepository
+	closureRepo repository.FolderClosureRepository
+}
+
+// NewVersionRetentionService は新しいVersionRetentionServiceを作成します
+func NewVersionRetentionService(
+	policyRepo repository.VersionRetentionPolicyRepository,
+	closureRepo repository.FolderClosureRepository,
+) VersionRetentionService {
+	return &versionRetentionServiceImpl{
+		policyRepo:  policyRepo,
+		closureRepo: closureRepo,
+	}
+}
+
+// ResolvePolicy はファイルに適用される保持ポリシーを解決します
+func (s *versionRetentionServiceImpl) ResolvePolicy(ctx context.Context, file *entity.File) (*entity.VersionRetentionPolicy, error) {
+	// 1. 所属フォルダと祖先フォルダ（近い順）
+	ancestorIDs, err := s.closureRepo.FindAncestorIDs(ctx, file.FolderID)
+	if err != nil {
+		return nil, err
+	}
+	folderIDs := append([]uuid.UUID{file.FolderID}, ancestorIDs...)
+	for _, folderID := range folderIDs {
+		policy, err := s.policyRepo.FindBySubject(ctx, entity.RetentionSubjectFolder, folderID)
+		if err == nil {
+			return policy, nil
+		}
+		if !apperror.IsNotFound(err) {
+			return nil, err
+		}
+	}
+
+	// 2. ファイル所有者のポリシー
+	policy, err := s.policyRepo.FindBySubject(ctx, entity.RetentionSubjectUser, file.OwnerID)
+	if err != nil {
+		if apperror.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return policy, nil
+}
@@ -0,0 +1,111 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
+)
+
+type versionRetentionTestDeps struct {
+	policyRepo  *mocks.MockVersionRetentionPolicyRepository
+	closureRepo *mocks.MockFolderClosureRepository
+}
+
+func newVersionRetentionTestDeps(t *testing.T) *versionRetentionTestDeps {
+	t.Helper()
+	return &versionRetentionTestDeps{
+		policyRepo:  mocks.NewMockVersionRetentionPolicyRepository(t),
+		closureRepo: mocks.NewMockFolderClosureRepository(t),
+	}
+}
+
+func (d *versionRetentionTestDeps) newService() service.VersionRetentionService {
+	return service.NewVersionRetentionService(d.policyRepo, d.closureRepo)
+}
+
+func newRetentionPolicy(t *testing.T, subjectType entity.RetentionSubjectType, subjectID uuid.UUID, keepLastN int) *entity.VersionRetentionPolicy {
+	t.Helper()
+	policy, err := entity.NewVersionRetentionPolicy(subjectType, subjectID, &keepLastN, nil)
+	require.NoError(t, err)
+	return policy
+}
+
+func TestVersionRetentionService_ResolvePolicy_NearestAncestorWins(t *testing.T) {
+	ctx := context.Background()
+	deps := newVersionRetentionTestDeps(t)
+
+	file := &entity.File{ID: uuid.New(), FolderID: uuid.New(), OwnerID: uuid.New()}
+	parentID := uuid.New()
+	rootID := uuid.New()
+	notFound := apperror.NewNotFoundError("version retention policy")
+	parentPolicy := newRetentionPolicy(t, entity.RetentionSubjectFolder, parentID, 3)
+
+	deps.closureRepo.On("FindAncestorIDs", ctx, file.FolderID).Return([]uuid.UUID{parentID, rootID}, nil)
+	deps.policyRepo.On("FindBySubject", ctx, entity.RetentionSubjectFolder, file.FolderID).Return(nil, notFound)
+	deps.policyRepo.On("FindBySubject", ctx, entity.RetentionSubjectFolder, parentID).Return(parentPolicy, nil)
+
+	policy, err := deps.newService().ResolvePolicy(ctx, file)
+
+	require.NoError(t, err)
+	assert.Equal(t, parentPolicy, policy)
+}
+
+func TestVersionRetentionService_ResolvePolicy_NoFolderPolicy_FallsBackToOwner(t *testing.T) {
+	ctx := context.Background()
+	deps := newVersionRetentionTestDeps(t)
+
+	file := &entity.File{ID: uuid.New(), FolderID: uuid.New(), OwnerID: uuid.New()}
+	notFound := apperror.NewNotFoundError("version retention policy")
+	userPolicy := newRetentionPolicy(t, entity.RetentionSubjectUser, file.OwnerID, 5)
+
+	deps.closureRepo.On("FindAncestorIDs", ctx, file.FolderID).Return([]uuid.UUID{}, nil)
+	deps.policyRepo.On("FindBySubject", ctx, entity.RetentionSubjectFolder, file.FolderID).Return(nil, notFound)
+	deps.policyRepo.On("FindBySubject", ctx, entity.RetentionSubjectUser, file.OwnerID).Return(userPolicy, nil)
+
+	policy, err := deps.newService().ResolvePolicy(ctx, file)
+
+	require.NoError(t, err)
+	assert.Equal(t, userPolicy, policy)
+}
+
+func TestVersionRetentionService_ResolvePolicy_NoPolicy_ReturnsNil(t *testing.T) {
+	ctx := context.Background()
+	deps := newVersionRetentionTestDeps(t)
+
+	file := &entity.File{ID: uuid.New(), FolderID: uuid.New(), OwnerID: uuid.New()}
+	notFound := apperror.NewNotFoundError("version retention policy")
+
+	deps.closureRepo.On("FindAncestorIDs", ctx, file.FolderID).Return([]uuid.UUID{}, nil)
+	deps.policyRepo.On("FindBySubject", ctx, entity.RetentionSubjectFolder, file.FolderID).Return(nil, notFound)
+	deps.policyRepo.On("FindBySubject", ctx, entity.RetentionSubjectUser, file.OwnerID).Return(nil, notFound)
+
+	policy, err := deps.newService().ResolvePolicy(ctx, file)
+
+	require.NoError(t, err)
+	assert.Nil(t, policy)
+}
+
+func TestVersionRetentionService_ResolvePolicy_RepositoryError_ReturnsError(t *testing.T) {
+	ctx := context.Background()
+	deps := newVersionRetentionTestDeps(t)
+
+	file := &entity.File{ID: uuid.New(), FolderID: uuid.New(), OwnerID: uuid.New()}
+	dbErr := errors.New("db error")
+
+	deps.closureRepo.On("FindAncestorIDs", ctx, file.FolderID).Return([]uuid.UUID{}, nil)
+	deps.policyRepo.On("FindBySubject", ctx, entity.RetentionSubjectFolder, file.FolderID).Return(nil, dbErr)
+
+	policy, err := deps.newService().ResolvePolicy(ctx, file)
+
+	require.Error(t, err)
+	assert.Nil(t, policy)
+}
@@ -0,0 +1,49 @@
+package valueobject
+
+import "errors"
+
+var (
+	ErrInvalidConflictStrategy = errors.New("invalid conflict strategy")
+)
+
+// ConflictStrategy は同名ファイルが存在する場合の解決方法を表す値オブジェクト
+type ConflictStrategy string
+
+const (
+	// ConflictStrategyFail は競合エラーを返します（デフォルト）
+	ConflictStrategyFail ConflictStrategy = "fail"
+	// ConflictStrategyRename は "name (1).ext" 形式の空いている名前に変更します
+	ConflictStrategyRename ConflictStrategy = "rename"
+	// ConflictStrategyOverwriteAsNewVersion は既存ファイルの新しいバージョンとして保存します
+	ConflictStrategyOverwriteAsNewVersion ConflictStrategy = "overwrite_as_new_version"
+	// ConflictStrategySkip は何もせずに処理をスキップします
+	ConflictStrategySkip ConflictStrategy = "skip"
+)
+
+// NewConflictStrategy は文字列からConflictStrategyを生成します
+// 空文字の場合はConflictStrategyFailを返します
+func NewConflictStrategy(strategy string) (ConflictStrategy, error) {
+	if strategy == "" {
+		return ConflictStrategyFail, nil
+	}
+	s := ConflictStrategy(strategy)
+	if !s.IsValid() {
+		return "", ErrInvalidConflictStrategy
+	}
+	return s, nil
+}
+
+// IsValid は解決方法が有効かを判定します
+func (s ConflictStrategy) IsValid() bool {
+	switch s {
+	case ConflictStrategyFail, ConflictStrategyRename, ConflictStrategyOverwriteAsNewVersion, ConflictStrategySkip:
+		return true
+	default:
+		return false
+	}
+}
+
+// String は文字列を返します
+func (s ConflictStrategy) String() string {
+	return string(s)
+}
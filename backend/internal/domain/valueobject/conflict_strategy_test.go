@@ -0,0 +1,22 @@
+package valueobject
+
+import "testing"
+
+func TestNewConflictStrategy_Empty_DefaultsToFail(t *testing.T) {
+	s, err := NewConflictStrategy("")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s != ConflictStrategyFail {
+		t.Errorf("got %q, want %q", s, ConflictStrategyFail)
+	}
+}
+
+func TestNewConflictStrategy_Unknown_ReturnsErrInvalidConflictStrategy(t *testing.T) {
+	_, err := NewConflictStrategy("replace")
+
+	if err != ErrInvalidConflictStrategy {
+		t.Errorf("expected ErrInvalidConflictStrategy, got: %v", err)
+	}
+}
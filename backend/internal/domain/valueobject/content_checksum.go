@@ -0,0 +1,93 @@
+package valueobject
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+var (
+	ErrInvalidChecksumAlgorithm = errors.New("invalid checksum algorithm")
+	ErrInvalidChecksumValue     = errors.New("invalid checksum value")
+)
+
+// ChecksumAlgorithm はクライアントが指定できるチェックサムのアルゴリズム
+type ChecksumAlgorithm string
+
+const (
+	// ChecksumAlgorithmSHA256 はSHA-256（32バイト）
+	ChecksumAlgorithmSHA256 ChecksumAlgorithm = "sha256"
+	// ChecksumAlgorithmCRC32C はCRC32C（Castagnoli、4バイト）
+	ChecksumAlgorithmCRC32C ChecksumAlgorithm = "crc32c"
+)
+
+// digestSize はアルゴリズムごとのダイジェストのバイト数を返します
+func (a ChecksumAlgorithm) digestSize() int {
+	switch a {
+	case ChecksumAlgorithmSHA256:
+		return 32
+	case ChecksumAlgorithmCRC32C:
+		return 4
+	default:
+		return 0
+	}
+}
+
+// ContentChecksum はアップロード内容の期待チェックサムを表す値オブジェクト
+// 値は16進数（小文字）に正規化して保持します
+type ContentChecksum struct {
+	algorithm ChecksumAlgorithm
+	value     string
+}
+
+// NewContentChecksum はアルゴリズムと値からContentChecksumを生成します
+// 値は16進数、またはS3のチェックサムヘッダーと同じBase64形式を受け付けます
+func NewContentChecksum(algorithm, value string) (ContentChecksum, error) {
+	alg := ChecksumAlgorithm(strings.ToLower(algorithm))
+	size := alg.digestSize()
+	if size == 0 {
+		return ContentChecksum{}, ErrInvalidChecksumAlgorithm
+	}
+
+	if len(value) == size*2 {
+		if digest, err := hex.DecodeString(value); err == nil {
+			return ContentChecksum{algorithm: alg, value: hex.EncodeToString(digest)}, nil
+		}
+	}
+	if digest, err := base64.StdEncoding.DecodeString(value); err == nil && len(digest) == size {
+		return ContentChecksum{algorithm: alg, value: hex.EncodeToString(digest)}, nil
+	}
+	return ContentChecksum{}, ErrInvalidChecksumValue
+}
+
+// ReconstructContentChecksum はDBからContentChecksumを復元します
+func ReconstructContentChecksum(algorithm, value string) ContentChecksum {
+	return ContentChecksum{algorithm: ChecksumAlgorithm(algorithm), value: value}
+}
+
+// Algorithm はアルゴリズムを返します
+func (c ContentChecksum) Algorithm() ChecksumAlgorithm {
+	return c.algorithm
+}
+
+// Value は16進数のチェックサムを返します
+func (c ContentChecksum) Value() string {
+	return c.value
+}
+
+// Base64 はS3のチェックサムヘッダーと同じBase64形式のチェックサムを返します
+func (c ContentChecksum) Base64() string {
+	digest, _ := hex.DecodeString(c.value)
+	return base64.StdEncoding.EncodeToString(digest)
+}
+
+// Matches は16進数のダイジェストと一致するかを判定します
+func (c ContentChecksum) Matches(hexDigest string) bool {
+	return strings.EqualFold(c.value, hexDigest)
+}
+
+// String は "sha256:..." 形式の文字列を返します
+func (c ContentChecksum) String() string {
+	return string(c.algorithm) + ":" + c.value
+}
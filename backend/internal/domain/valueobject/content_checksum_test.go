@@ -0,0 +1,87 @@
+package valueobject
+
+import "testing"
+
+const helloSHA256 = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+func TestNewContentChecksum_HexSHA256_NormalizesToLowercase(t *testing.T) {
+	c, err := NewContentChecksum("SHA256", "2CF24DBA5FB0A30E26E83B2AC5B9E29E1B161E5C1FA7425E73043362938B9824")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.Algorithm() != ChecksumAlgorithmSHA256 {
+		t.Errorf("got algorithm %q, want %q", c.Algorithm(), ChecksumAlgorithmSHA256)
+	}
+	if c.Value() != helloSHA256 {
+		t.Errorf("got %q, want %q", c.Value(), helloSHA256)
+	}
+	if c.String() != "sha256:"+helloSHA256 {
+		t.Errorf("got %q", c.String())
+	}
+}
+
+func TestNewContentChecksum_Base64_DecodesToHex(t *testing.T) {
+	sha, err := NewContentChecksum("sha256", "LPJNul+wow4m6DsqxbninhsWHlwfp0JecwQzYpOLmCQ=")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sha.Value() != helloSHA256 {
+		t.Errorf("got %q, want %q", sha.Value(), helloSHA256)
+	}
+
+	crc, err := NewContentChecksum("crc32c", "AAAAAQ==")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if crc.Value() != "00000001" {
+		t.Errorf("got %q, want %q", crc.Value(), "00000001")
+	}
+}
+
+func TestContentChecksum_Base64_EncodesHeaderValue(t *testing.T) {
+	sha, err := NewContentChecksum("sha256", helloSHA256)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := sha.Base64(); got != "LPJNul+wow4m6DsqxbninhsWHlwfp0JecwQzYpOLmCQ=" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestNewContentChecksum_UnknownAlgorithm_ReturnsErrInvalidChecksumAlgorithm(t *testing.T) {
+	_, err := NewContentChecksum("md5", "5d41402abc4b2a76b9719d911017c592")
+
+	if err != ErrInvalidChecksumAlgorithm {
+		t.Errorf("expected ErrInvalidChecksumAlgorithm, got: %v", err)
+	}
+}
+
+func TestNewContentChecksum_WrongLength_ReturnsErrInvalidChecksumValue(t *testing.T) {
+	cases := []struct {
+		algorithm string
+		value     string
+	}{
+		{"sha256", "abc123"},
+		{"sha256", "zz" + helloSHA256[2:]},
+		{"crc32c", helloSHA256},
+		{"crc32c", ""},
+	}
+
+	for _, tc := range cases {
+		if _, err := NewContentChecksum(tc.algorithm, tc.value); err != ErrInvalidChecksumValue {
+			t.Errorf("%s %q: expected ErrInvalidChecksumValue, got: %v", tc.algorithm, tc.value, err)
+		}
+	}
+}
+
+func TestContentChecksum_Matches_IgnoresCase(t *testing.T) {
+	c, _ := NewContentChecksum("crc32c", "0a0b0c0d")
+
+	if !c.Matches("0A0B0C0D") {
+		t.Error("expected checksum to match")
+	}
+	if c.Matches("0a0b0c0e") {
+		t.Error("expected checksum not to match")
+	}
+}
@@ -2,8 +2,10 @@ package valueobject
 
 import (
 	"errors"
+	"fmt"
 	"path/filepath"
 	"strings"
+	"unicode/utf8"
 )
 
 const (
@@ -85,3 +87,21 @@ func (fn FileName) BaseName() string {
 	ext := filepath.Ext(fn.value)
 	return strings.TrimSuffix(fn.value, ext)
 }
+
+// WithSequence は "name (n).ext" 形式の連番付きファイル名を返します
+// 拡張子のみのファイル名（例: ".env"）は全体をベース名として扱います
+// 最大長を超える場合はベース名を文字単位で切り詰めます
+func (fn FileName) WithSequence(n int) (FileName, error) {
+	base, ext := fn.BaseName(), fn.Extension()
+	if base == "" {
+		base, ext = fn.value, ""
+	}
+
+	suffix := fmt.Sprintf(" (%d)", n)
+	for len(base)+len(suffix)+len(ext) > FileNameMaxBytes && base != "" {
+		_, size := utf8.DecodeLastRuneInString(base)
+		base = base[:len(base)-size]
+	}
+
+	return NewFileName(base + suffix + ext)
+}
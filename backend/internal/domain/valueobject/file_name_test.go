@@ -0,0 +1,61 @@
+package valueobject
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFileName_WithSequence_InsertsSequenceBeforeExtension(t *testing.T) {
+	fn, _ := NewFileName("report.pdf")
+
+	got, err := fn.WithSequence(1)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Value() != "report (1).pdf" {
+		t.Errorf("got %q, want %q", got.Value(), "report (1).pdf")
+	}
+}
+
+func TestFileName_WithSequence_NoExtension_AppendsSequence(t *testing.T) {
+	fn, _ := NewFileName("README")
+
+	got, err := fn.WithSequence(2)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Value() != "README (2)" {
+		t.Errorf("got %q, want %q", got.Value(), "README (2)")
+	}
+}
+
+func TestFileName_WithSequence_DotFile_AppendsSequenceToWholeName(t *testing.T) {
+	fn, _ := NewFileName(".env")
+
+	got, err := fn.WithSequence(1)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Value() != ".env (1)" {
+		t.Errorf("got %q, want %q", got.Value(), ".env (1)")
+	}
+}
+
+func TestFileName_WithSequence_MaxLength_TruncatesBaseName(t *testing.T) {
+	fn, _ := NewFileName(strings.Repeat("あ", 82) + ".txt") // 246 + 4 = 250 bytes
+
+	got, err := fn.WithSequence(10)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got.Value()) > FileNameMaxBytes {
+		t.Errorf("length %d exceeds max %d", len(got.Value()), FileNameMaxBytes)
+	}
+	if !strings.HasSuffix(got.Value(), " (10).txt") {
+		t.Errorf("got %q, want suffix %q", got.Value(), " (10).txt")
+	}
+}
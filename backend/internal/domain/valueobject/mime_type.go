@@ -21,6 +21,16 @@ const (
 	MimeCategoryOther    MimeCategory = "other"
 )
 
+// IsValid はカテゴリが有効かを判定します
+func (c MimeCategory) IsValid() bool {
+	switch c {
+	case MimeCategoryImage, MimeCategoryVideo, MimeCategoryAudio,
+		MimeCategoryDocument, MimeCategoryArchive, MimeCategoryOther:
+		return true
+	}
+	return false
+}
+
 // MimeType はMIMEタイプを表す値オブジェクト
 type MimeType struct {
 	value    string
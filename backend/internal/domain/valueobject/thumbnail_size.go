@@ -0,0 +1,63 @@
+package valueobject
+
+import "errors"
+
+var (
+	ErrInvalidThumbnailSize = errors.New("invalid thumbnail size")
+)
+
+// ThumbnailSize はサムネイルのサイズを表す値オブジェクト
+// 各サイズは長辺のピクセル数が固定された正方形の枠に収まるよう縮小されます
+type ThumbnailSize string
+
+const (
+	// ThumbnailSizeSmall は一覧表示用のサムネイル（128px）
+	ThumbnailSizeSmall ThumbnailSize = "small"
+	// ThumbnailSizeMedium はグリッド表示用のサムネイル（256px、デフォルト）
+	ThumbnailSizeMedium ThumbnailSize = "medium"
+	// ThumbnailSizeLarge は高解像度ディスプレイ向けのサムネイル（512px）
+	ThumbnailSizeLarge ThumbnailSize = "large"
+)
+
+// ThumbnailSizes は生成する全サイズです
+var ThumbnailSizes = []ThumbnailSize{ThumbnailSizeSmall, ThumbnailSizeMedium, ThumbnailSizeLarge}
+
+// NewThumbnailSize は文字列からThumbnailSizeを生成します
+// 空文字の場合はThumbnailSizeMediumを返します
+func NewThumbnailSize(size string) (ThumbnailSize, error) {
+	if size == "" {
+		return ThumbnailSizeMedium, nil
+	}
+	s := ThumbnailSize(size)
+	if !s.IsValid() {
+		return "", ErrInvalidThumbnailSize
+	}
+	return s, nil
+}
+
+// IsValid はサイズが有効かを判定します
+func (s ThumbnailSize) IsValid() bool {
+	switch s {
+	case ThumbnailSizeSmall, ThumbnailSizeMedium, ThumbnailSizeLarge:
+		return true
+	default:
+		return false
+	}
+}
+
+// MaxDimension は長辺の最大ピクセル数を返します
+func (s ThumbnailSize) MaxDimension() int {
+	switch s {
+	case ThumbnailSizeSmall:
+		return 128
+	case ThumbnailSizeLarge:
+		return 512
+	default:
+		return 256
+	}
+}
+
+// String は文字列を返します
+func (s ThumbnailSize) String() string {
+	return string(s)
+}
@@ -0,0 +1,39 @@
+package valueobject
+
+import "testing"
+
+func TestNewThumbnailSize_Empty_DefaultsToMedium(t *testing.T) {
+	s, err := NewThumbnailSize("")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s != ThumbnailSizeMedium {
+		t.Errorf("got %q, want %q", s, ThumbnailSizeMedium)
+	}
+}
+
+func TestNewThumbnailSize_Unknown_ReturnsErrInvalidThumbnailSize(t *testing.T) {
+	_, err := NewThumbnailSize("huge")
+
+	if err != ErrInvalidThumbnailSize {
+		t.Errorf("expected ErrInvalidThumbnailSize, got: %v", err)
+	}
+}
+
+func TestThumbnailSize_MaxDimension(t *testing.T) {
+	tests := []struct {
+		size ThumbnailSize
+		want int
+	}{
+		{ThumbnailSizeSmall, 128},
+		{ThumbnailSizeMedium, 256},
+		{ThumbnailSizeLarge, 512},
+	}
+
+	for _, tt := range tests {
+		if got := tt.size.MaxDimension(); got != tt.want {
+			t.Errorf("%s: got %d, want %d", tt.size, got, tt.want)
+		}
+	}
+}
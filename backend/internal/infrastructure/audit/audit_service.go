@@ -3,6 +3,7 @@ package audit
 import (
 	"context"
 	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,31 +11,49 @@ import (
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
 )
 
 // Service は監査ログの非同期書き込みサービスです
+// エントリは単一のゴルーチンから1件ずつハッシュチェーンに追記されます
 type Service struct {
-	repo    repository.AuditLogRepository
-	entries chan service.AuditEntry
-	done    chan struct{}
+	repo      repository.AuditLogRepository
+	txManager repository.TransactionManager
+	entries   chan service.AuditEntry
+	done      chan struct{}
+
+	mu     sync.RWMutex
+	closed bool
 }
 
 // NewService は新しいAudit Serviceを作成します
-func NewService(repo repository.AuditLogRepository, bufferSize int) *Service {
+func NewService(repo repository.AuditLogRepository, txManager repository.TransactionManager, bufferSize int) *Service {
 	if bufferSize <= 0 {
 		bufferSize = 1000
 	}
 	s := &Service{
-		repo:    repo,
-		entries: make(chan service.AuditEntry, bufferSize),
-		done:    make(chan struct{}),
+		repo:      repo,
+		txManager: txManager,
+		entries:   make(chan service.AuditEntry, bufferSize),
+		done:      make(chan struct{}),
 	}
 	go s.processLoop()
 	return s
 }
 
 // Log は監査ログエントリをキューに追加します（非ブロッキング）
+// 停止後に呼ばれた場合はエントリを破棄し、呼び出し元のリクエストには影響させません
 func (s *Service) Log(ctx context.Context, entry service.AuditEntry) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		slog.Warn("audit service is shut down, dropping entry",
+			"action", string(entry.Action),
+			"resource_type", string(entry.ResourceType),
+		)
+		return
+	}
+
 	select {
 	case s.entries <- entry:
 	default:
@@ -60,11 +79,10 @@ func (s *Service) processLoop() {
 			IPAddress:    entry.IPAddress,
 			UserAgent:    entry.UserAgent,
 			RequestID:    entry.RequestID,
-			CreatedAt:    time.Now(),
 		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		if err := s.repo.Create(ctx, log); err != nil {
+		if err := s.appendToChain(ctx, log); err != nil {
 			slog.Error("failed to write audit log",
 				"error", err,
 				"action", string(entry.Action),
@@ -75,9 +93,34 @@ func (s *Service) processLoop() {
 	}
 }
 
+// appendToChain はチェーン末尾をロックした上でエントリを連結して永続化します
+// 記録日時もロック取得後に設定し、Sequenceの順序と記録日時の順序を揃えます
+func (s *Service) appendToChain(ctx context.Context, log *entity.AuditLog) error {
+	return s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		// チェーンが空の場合はheadがnilのまま先頭として連結される
+		head, err := s.repo.LockChainHead(ctx)
+		if err != nil && !apperror.IsNotFound(err) {
+			return err
+		}
+
+		log.CreatedAt = time.Now()
+		if err := log.ChainTo(head); err != nil {
+			return err
+		}
+		return s.repo.Create(ctx, log)
+	})
+}
+
 // Shutdown はサービスを安全に停止します
 func (s *Service) Shutdown() {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.closed = true
 	close(s.entries)
+	s.mu.Unlock()
 	<-s.done
 }
 
@@ -0,0 +1,71 @@
+package audit
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+)
+
+// Ed25519CheckpointSigner はEd25519で監査ログチェックポイントに署名します
+// 公開鍵を外部に保管しておけば、データベースを書き換えられる者でも署名を偽造できない
+type Ed25519CheckpointSigner struct {
+	privateKey ed25519.PrivateKey
+	publicKey  ed25519.PublicKey
+	keyID      string
+}
+
+// NewEd25519CheckpointSigner はBase64エンコードした32バイトのシードから署名器を作成します
+func NewEd25519CheckpointSigner(seedBase64 string) (*Ed25519CheckpointSigner, error) {
+	seed, err := base64.StdEncoding.DecodeString(seedBase64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid audit checkpoint signing key: %w", err)
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("invalid audit checkpoint signing key: expected %d bytes, got %d", ed25519.SeedSize, len(seed))
+	}
+
+	privateKey := ed25519.NewKeyFromSeed(seed)
+	publicKey := privateKey.Public().(ed25519.PublicKey)
+	return &Ed25519CheckpointSigner{
+		privateKey: privateKey,
+		publicKey:  publicKey,
+		keyID:      Ed25519KeyID(publicKey),
+	}, nil
+}
+
+// Ed25519KeyID は公開鍵のSHA-256の先頭8バイトを16進数にした鍵識別子を返します
+func Ed25519KeyID(publicKey ed25519.PublicKey) string {
+	sum := sha256.Sum256(publicKey)
+	return hex.EncodeToString(sum[:8])
+}
+
+// KeyID は署名鍵の識別子を返します
+func (s *Ed25519CheckpointSigner) KeyID() string {
+	return s.keyID
+}
+
+// PublicKey は検証用の公開鍵を返します
+func (s *Ed25519CheckpointSigner) PublicKey() ed25519.PublicKey {
+	return s.publicKey
+}
+
+// Sign はペイロードに署名します
+func (s *Ed25519CheckpointSigner) Sign(payload []byte) ([]byte, error) {
+	return ed25519.Sign(s.privateKey, payload), nil
+}
+
+// Verify は署名が指定した鍵でペイロードに対して有効かを判定します
+// 現在の鍵以外で署名されたチェックポイントは検証できないため無効として扱います
+func (s *Ed25519CheckpointSigner) Verify(keyID string, payload, signature []byte) bool {
+	if keyID != s.keyID {
+		return false
+	}
+	return ed25519.Verify(s.publicKey, payload, signature)
+}
+
+// インターフェースの実装を保証
+var _ service.AuditCheckpointSigner = (*Ed25519CheckpointSigner)(nil)
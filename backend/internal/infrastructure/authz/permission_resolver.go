@@ -15,10 +15,14 @@ import (
 // 2. 直接付与された権限
 // 3. グループ経由の権限
 // 4. 親リソースからの継承（フォルダ階層）
+//
+// ファイルはリレーションシップタプルを持たないため、
+// オーナーと親フォルダはfilesテーブルから解決します
 type PermissionResolverImpl struct {
 	permissionGrantRepo authz.PermissionGrantRepository
 	relationshipRepo    authz.RelationshipRepository
 	membershipRepo      repository.MembershipRepository
+	fileRepo            repository.FileRepository
 }
 
 // NewPermissionResolver は新しいPermissionResolverを作成します
@@ -26,11 +30,13 @@ func NewPermissionResolver(
 	permissionGrantRepo authz.PermissionGrantRepository,
 	relationshipRepo authz.RelationshipRepository,
 	membershipRepo repository.MembershipRepository,
+	fileRepo repository.FileRepository,
 ) *PermissionResolverImpl {
 	return &PermissionResolverImpl{
 		permissionGrantRepo: permissionGrantRepo,
 		relationshipRepo:    relationshipRepo,
 		membershipRepo:      membershipRepo,
+		fileRepo:            fileRepo,
 	}
 }
 
@@ -153,7 +159,20 @@ func (r *PermissionResolverImpl) IsOwner(ctx context.Context, userID uuid.UUID,
 		authz.ObjectType(resourceType),
 		resourceID,
 	)
-	return r.relationshipRepo.Exists(ctx, tuple)
+	exists, err := r.relationshipRepo.Exists(ctx, tuple)
+	if err != nil {
+		return false, err
+	}
+	if exists || resourceType != authz.ResourceTypeFile {
+		return exists, nil
+	}
+
+	// ファイルはfilesテーブルのowner_idで判定
+	file, err := r.fileRepo.FindByID(ctx, resourceID)
+	if err != nil {
+		return false, err
+	}
+	return file.IsOwnedBy(userID), nil
 }
 
 // CanGrantRole はユーザーがリソースに対して指定されたロールを付与可能かを判定します
@@ -190,7 +209,7 @@ func (r *PermissionResolverImpl) collectParentPermissions(ctx context.Context, u
 	permissionSet := authz.EmptyPermissionSet()
 
 	// 親リソースを取得
-	parent, err := r.relationshipRepo.FindParent(ctx, authz.ObjectType(resourceType), resourceID)
+	parent, err := r.findParent(ctx, resourceType, resourceID)
 	if err != nil {
 		return nil, err
 	}
@@ -205,7 +224,7 @@ func (r *PermissionResolverImpl) collectParentPermissions(ctx context.Context, u
 // getParentEffectiveRole は親リソースからの有効ロールを取得します
 func (r *PermissionResolverImpl) getParentEffectiveRole(ctx context.Context, userID uuid.UUID, resourceType authz.ResourceType, resourceID uuid.UUID) (authz.Role, error) {
 	// 親リソースを取得
-	parent, err := r.relationshipRepo.FindParent(ctx, authz.ObjectType(resourceType), resourceID)
+	parent, err := r.findParent(ctx, resourceType, resourceID)
 	if err != nil {
 		return "", err
 	}
@@ -217,5 +236,23 @@ func (r *PermissionResolverImpl) getParentEffectiveRole(ctx context.Context, use
 	return r.GetEffectiveRole(ctx, userID, parent.Type, parent.ID)
 }
 
+// findParent は親リソースを取得します
+// ファイルに親タプルがない場合は所属フォルダを親とします
+func (r *PermissionResolverImpl) findParent(ctx context.Context, resourceType authz.ResourceType, resourceID uuid.UUID) (*authz.Resource, error) {
+	parent, err := r.relationshipRepo.FindParent(ctx, authz.ObjectType(resourceType), resourceID)
+	if err != nil {
+		return nil, err
+	}
+	if parent != nil || resourceType != authz.ResourceTypeFile {
+		return parent, nil
+	}
+
+	file, err := r.fileRepo.FindByID(ctx, resourceID)
+	if err != nil {
+		return nil, err
+	}
+	return &authz.Resource{Type: authz.ResourceTypeFolder, ID: file.FolderID}, nil
+}
+
 // インターフェースの実装を保証
 var _ authz.PermissionResolver = (*PermissionResolverImpl)(nil)
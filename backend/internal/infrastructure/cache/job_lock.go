@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// JobLock はバックグラウンドジョブの実行権を複数レプリカ間で排他するロックです
+// Note: ロックの値には取得したレプリカの実行ごとのトークンを保存し、延長と解放はトークンが一致する場合のみ行う
+type JobLock struct {
+	client *redis.Client
+}
+
+// NewJobLock は新しいJobLockを作成します
+func NewJobLock(client *redis.Client) *JobLock {
+	return &JobLock{client: client}
+}
+
+// TryAcquire はジョブのロック取得を試みます
+// 取得できた場合はtrue、他のレプリカが保持している場合はfalseを返します
+func (l *JobLock) TryAcquire(ctx context.Context, jobName, token string, ttl time.Duration) (bool, error) {
+	acquired, err := l.client.SetNX(ctx, JobLockKey(jobName), token, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire job lock: %w", err)
+	}
+	return acquired, nil
+}
+
+// トークンが一致する場合のみTTLを延長する
+var renewJobLockScript = redis.NewScript(`
+    if redis.call('GET', KEYS[1]) == ARGV[1] then
+        return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+    end
+    return 0
+`)
+
+// Renew はトークンが保持しているロックのTTLを延長します
+// ロックが失効済み、または他のレプリカに取得されている場合はfalseを返します
+func (l *JobLock) Renew(ctx context.Context, jobName, token string, ttl time.Duration) (bool, error) {
+	renewed, err := renewJobLockScript.Run(ctx, l.client, []string{JobLockKey(jobName)}, token, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, fmt.Errorf("failed to renew job lock: %w", err)
+	}
+	return renewed == 1, nil
+}
+
+// トークンが一致する場合のみ削除する
+var releaseJobLockScript = redis.NewScript(`
+    if redis.call('GET', KEYS[1]) == ARGV[1] then
+        return redis.call('DEL', KEYS[1])
+    end
+    return 0
+`)
+
+// Release はトークンが保持しているロックを解放します
+// 他のレプリカが取得し直したロックは削除しません
+func (l *JobLock) Release(ctx context.Context, jobName, token string) error {
+	if err := releaseJobLockScript.Run(ctx, l.client, []string{JobLockKey(jobName)}, token).Err(); err != nil {
+		return fmt.Errorf("failed to release job lock: %w", err)
+	}
+	return nil
+}
@@ -20,6 +20,9 @@ const (
 	// レート制限
 	PrefixRateLimit KeyPrefix = "ratelimit" // ratelimit:{type}:{identifier}:{window}
 
+	// バックグラウンドジョブ
+	PrefixJobLock KeyPrefix = "job:lock" // job:lock:{job_name}
+
 	// キャッシュ
 	PrefixCache     KeyPrefix = "cache"      // cache:{namespace}:{key}
 	PrefixUserCache KeyPrefix = "cache:user" // cache:user:{user_id}
@@ -45,6 +48,11 @@ func RateLimitKey(limitType, identifier string, windowStart int64) string {
 	return fmt.Sprintf("%s:%s:%s:%d", PrefixRateLimit, limitType, identifier, windowStart)
 }
 
+// JobLockKey はジョブロックキーを生成します
+func JobLockKey(jobName string) string {
+	return fmt.Sprintf("%s:%s", PrefixJobLock, jobName)
+}
+
 // CacheKey は汎用キャッシュキーを生成します
 func CacheKey(namespace, key string) string {
 	return fmt.Sprintf("%s:%s:%s", PrefixCache, namespace, key)
@@ -0,0 +1,4 @@
+-- Down migration for Storage Quota Tables
+
+DROP TABLE IF EXISTS storage_usages;
+DROP TABLE IF EXISTS storage_quotas;
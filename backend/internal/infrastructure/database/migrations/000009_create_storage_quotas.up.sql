@@ -0,0 +1,45 @@
+-- Storage Quota Tables
+-- Tables: storage_quotas, storage_usages
+
+-- =====================================================
+-- Storage quotas table
+-- =====================================================
+CREATE TABLE storage_quotas (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    subject_type VARCHAR(20) NOT NULL CHECK (subject_type IN ('user', 'group')),
+    subject_id UUID NOT NULL,
+    limit_bytes BIGINT NOT NULL CHECK (limit_bytes >= 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (subject_type, subject_id)
+);
+
+CREATE TRIGGER update_storage_quotas_updated_at
+    BEFORE UPDATE ON storage_quotas
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- =====================================================
+-- Storage usages table (per-owner usage counter)
+-- =====================================================
+CREATE TABLE storage_usages (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    used_bytes BIGINT NOT NULL DEFAULT 0 CHECK (used_bytes >= 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Backfill: active files (all versions) + trashed files (all archived versions)
+INSERT INTO storage_usages (user_id, used_bytes)
+SELECT owner_id, SUM(bytes)
+FROM (
+    SELECT f.owner_id, fv.size AS bytes
+    FROM file_versions fv
+    JOIN files f ON f.id = fv.file_id
+    UNION ALL
+    SELECT af.owner_id, COALESCE(
+        (SELECT SUM(afv.size) FROM archived_file_versions afv WHERE afv.archived_file_id = af.id),
+        af.size
+    ) AS bytes
+    FROM archived_files af
+) usage
+GROUP BY owner_id;
@@ -0,0 +1,5 @@
+-- Down migration for Version Retention Tables
+
+DROP TABLE IF EXISTS version_retention_policies;
+ALTER TABLE archived_file_versions DROP COLUMN IF EXISTS is_pinned;
+ALTER TABLE file_versions DROP COLUMN IF EXISTS is_pinned;
@@ -0,0 +1,29 @@
+-- Version Retention Tables
+-- Tables: version_retention_policies
+-- Columns: file_versions.is_pinned, archived_file_versions.is_pinned
+
+-- =====================================================
+-- Pinned versions (never pruned by retention policies)
+-- =====================================================
+ALTER TABLE file_versions ADD COLUMN is_pinned BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE archived_file_versions ADD COLUMN is_pinned BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- =====================================================
+-- Version retention policies table
+-- =====================================================
+CREATE TABLE version_retention_policies (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    subject_type VARCHAR(20) NOT NULL CHECK (subject_type IN ('user', 'folder')),
+    subject_id UUID NOT NULL,
+    keep_last_n INTEGER CHECK (keep_last_n > 0),
+    keep_days INTEGER CHECK (keep_days > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (subject_type, subject_id),
+    CHECK (keep_last_n IS NOT NULL OR keep_days IS NOT NULL)
+);
+
+CREATE TRIGGER update_version_retention_policies_updated_at
+    BEFORE UPDATE ON version_retention_policies
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,3 @@
+-- Down migration for Background Job Run History
+
+DROP TABLE IF EXISTS job_runs;
@@ -0,0 +1,18 @@
+-- Background Job Run History
+-- Tables: job_runs
+
+-- =====================================================
+-- Job runs table (one row per executed background job run)
+-- =====================================================
+CREATE TABLE job_runs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    job_name VARCHAR(100) NOT NULL,
+    instance_id VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('succeeded', 'failed')),
+    error_message TEXT,
+    started_at TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_job_runs_job_name_started_at ON job_runs(job_name, started_at DESC);
+CREATE INDEX idx_job_runs_started_at ON job_runs(started_at);
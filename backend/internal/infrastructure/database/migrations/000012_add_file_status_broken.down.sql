@@ -0,0 +1,11 @@
+-- Down migration for File Status: broken
+-- PostgreSQLはENUM値の削除をサポートしないため、型を再作成する
+
+UPDATE files SET status = 'upload_failed' WHERE status = 'broken';
+
+ALTER TABLE files ALTER COLUMN status DROP DEFAULT;
+ALTER TYPE file_status RENAME TO file_status_old;
+CREATE TYPE file_status AS ENUM ('uploading', 'active', 'upload_failed');
+ALTER TABLE files ALTER COLUMN status TYPE file_status USING status::text::file_status;
+ALTER TABLE files ALTER COLUMN status SET DEFAULT 'uploading';
+DROP TYPE file_status_old;
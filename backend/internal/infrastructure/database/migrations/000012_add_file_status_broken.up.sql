@@ -0,0 +1,4 @@
+-- File Status: broken
+-- ストレージ整合性チェックでオブジェクトの欠損・不一致が検出されたファイル
+
+ALTER TYPE file_status ADD VALUE IF NOT EXISTS 'broken';
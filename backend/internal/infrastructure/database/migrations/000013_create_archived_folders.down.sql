@@ -0,0 +1,7 @@
+-- Down migration for Archived Folder Tables
+
+DROP INDEX IF EXISTS idx_archived_files_archived_folder;
+ALTER TABLE archived_files DROP COLUMN IF EXISTS archived_folder_id;
+
+DROP TABLE IF EXISTS archived_subfolders;
+DROP TABLE IF EXISTS archived_folders;
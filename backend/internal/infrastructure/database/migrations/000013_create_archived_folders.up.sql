@@ -0,0 +1,47 @@
+-- Archived Folder Tables (folder trash)
+-- Tables: archived_folders, archived_subfolders
+-- フォルダをサブツリーごとゴミ箱に移動し、階層を保ったまま復元できるようにする
+
+-- =====================================================
+-- Archived folders table (root of a trashed subtree)
+-- =====================================================
+CREATE TABLE archived_folders (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    original_folder_id UUID NOT NULL,
+    original_parent_id UUID,
+    original_path TEXT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_by UUID NOT NULL REFERENCES users(id),
+    archived_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    archived_by UUID NOT NULL REFERENCES users(id),
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_archived_folders_owner_archived_at ON archived_folders(owner_id, archived_at DESC, id DESC);
+CREATE INDEX idx_archived_folders_expires ON archived_folders(expires_at);
+
+-- =====================================================
+-- Archived subfolders table (folders below the archived root)
+-- =====================================================
+CREATE TABLE archived_subfolders (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    archived_folder_id UUID NOT NULL REFERENCES archived_folders(id) ON DELETE CASCADE,
+    original_folder_id UUID NOT NULL,
+    original_parent_id UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_by UUID NOT NULL REFERENCES users(id),
+    relative_depth INTEGER NOT NULL CHECK (relative_depth > 0)
+);
+
+CREATE INDEX idx_archived_subfolders_archived_folder ON archived_subfolders(archived_folder_id);
+
+-- =====================================================
+-- Link archived files to the archived folder they were trashed with
+-- =====================================================
+-- アーカイブフォルダが先に削除された場合、ファイルは単独のゴミ箱アイテムとして残る
+ALTER TABLE archived_files
+    ADD COLUMN archived_folder_id UUID REFERENCES archived_folders(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_archived_files_archived_folder ON archived_files(archived_folder_id);
@@ -0,0 +1,3 @@
+-- Down migration for Copy Job Tables
+
+DROP TABLE IF EXISTS copy_jobs;
@@ -0,0 +1,24 @@
+-- Copy Job Tables (asynchronous folder copy)
+-- Tables: copy_jobs
+-- 大きなフォルダのコピーはジョブとして登録し、バックグラウンドワーカーで実行する
+
+-- =====================================================
+-- Copy jobs table (one row per requested folder copy)
+-- =====================================================
+CREATE TABLE copy_jobs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    source_folder_id UUID NOT NULL,
+    destination_folder_id UUID NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'succeeded', 'failed')),
+    result_folder_id UUID,
+    folder_count INTEGER NOT NULL DEFAULT 0,
+    file_count INTEGER NOT NULL DEFAULT 0,
+    error_message TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_copy_jobs_status_created_at ON copy_jobs(status, created_at);
+CREATE INDEX idx_copy_jobs_user_id ON copy_jobs(user_id);
@@ -0,0 +1,8 @@
+-- Down migration for Blob Tables
+
+ALTER TABLE archived_file_versions DROP COLUMN IF EXISTS blob_id;
+ALTER TABLE archived_file_versions DROP COLUMN IF EXISTS storage_key;
+ALTER TABLE file_versions DROP COLUMN IF EXISTS blob_id;
+ALTER TABLE file_versions DROP COLUMN IF EXISTS storage_key;
+
+DROP TABLE IF EXISTS blobs;
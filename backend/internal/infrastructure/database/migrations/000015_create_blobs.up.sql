@@ -0,0 +1,42 @@
+-- Blob Tables (content-addressed deduplication)
+-- Tables: blobs
+-- 同一内容（SHA-256）のアップロードは1つのMinIOオブジェクトバージョンを共有し、参照数で管理する
+
+-- =====================================================
+-- Blobs table (one row per distinct content)
+-- =====================================================
+CREATE TABLE blobs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    checksum VARCHAR(64) NOT NULL UNIQUE,
+    storage_key TEXT NOT NULL,
+    minio_version_id VARCHAR(255) NOT NULL,
+    size BIGINT NOT NULL,
+    ref_count INTEGER NOT NULL DEFAULT 1 CHECK (ref_count >= 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- =====================================================
+-- Version content location
+-- =====================================================
+-- 重複排除されたバージョンの内容は他のファイルのキーに置かれるため、
+-- バージョンごとにオブジェクトの位置と参照するブロブを記録する
+ALTER TABLE file_versions ADD COLUMN storage_key TEXT;
+ALTER TABLE file_versions ADD COLUMN blob_id UUID REFERENCES blobs(id);
+
+UPDATE file_versions fv SET storage_key = f.storage_key
+FROM files f
+WHERE f.id = fv.file_id;
+
+ALTER TABLE file_versions ALTER COLUMN storage_key SET NOT NULL;
+
+ALTER TABLE archived_file_versions ADD COLUMN storage_key TEXT;
+ALTER TABLE archived_file_versions ADD COLUMN blob_id UUID REFERENCES blobs(id);
+
+UPDATE archived_file_versions afv SET storage_key = af.storage_key
+FROM archived_files af
+WHERE af.id = afv.archived_file_id;
+
+ALTER TABLE archived_file_versions ALTER COLUMN storage_key SET NOT NULL;
+
+CREATE INDEX idx_file_versions_blob_id ON file_versions(blob_id);
+CREATE INDEX idx_archived_file_versions_blob_id ON archived_file_versions(blob_id);
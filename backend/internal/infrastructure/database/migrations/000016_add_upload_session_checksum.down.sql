@@ -0,0 +1,5 @@
+-- Down migration for Upload Session Expected Checksum
+
+ALTER TABLE upload_sessions DROP CONSTRAINT IF EXISTS chk_upload_sessions_expected_checksum;
+ALTER TABLE upload_sessions DROP COLUMN IF EXISTS expected_checksum;
+ALTER TABLE upload_sessions DROP COLUMN IF EXISTS expected_checksum_algorithm;
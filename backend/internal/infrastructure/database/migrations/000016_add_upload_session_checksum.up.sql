@@ -0,0 +1,10 @@
+-- Upload Session Expected Checksum
+-- Columns: upload_sessions.expected_checksum_algorithm, upload_sessions.expected_checksum
+-- クライアントがアップロード開始時に申告したチェックサム。アップロード完了時に内容を再計算して検証する
+
+ALTER TABLE upload_sessions
+    ADD COLUMN expected_checksum_algorithm VARCHAR(16)
+        CHECK (expected_checksum_algorithm IN ('sha256', 'crc32c')),
+    ADD COLUMN expected_checksum VARCHAR(64),
+    ADD CONSTRAINT chk_upload_sessions_expected_checksum
+        CHECK ((expected_checksum_algorithm IS NULL) = (expected_checksum IS NULL));
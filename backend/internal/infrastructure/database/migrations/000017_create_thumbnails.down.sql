@@ -0,0 +1,3 @@
+-- Down migration for Thumbnail Tables
+
+DROP TABLE IF EXISTS thumbnails;
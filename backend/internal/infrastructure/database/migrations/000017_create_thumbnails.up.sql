@@ -0,0 +1,24 @@
+-- Thumbnail Tables (image thumbnail generation)
+-- Tables: thumbnails
+-- 画像のアップロード完了時にファイルバージョンごとのサムネイル生成を登録し、バックグラウンドワーカーで生成する
+-- 生成したサムネイルは元の内容のキーから派生したオブジェクトキー（{storage_key}/thumbnails/...）に保存する
+
+-- =====================================================
+-- Thumbnails table (one row per file version)
+-- =====================================================
+CREATE TABLE thumbnails (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    file_id UUID NOT NULL REFERENCES files(id) ON DELETE CASCADE,
+    version_number INTEGER NOT NULL,
+    source_key TEXT NOT NULL,
+    source_version_id VARCHAR(255),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'ready', 'failed')),
+    error_message TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ,
+
+    UNIQUE (file_id, version_number)
+);
+
+CREATE INDEX idx_thumbnails_status_created_at ON thumbnails(status, created_at);
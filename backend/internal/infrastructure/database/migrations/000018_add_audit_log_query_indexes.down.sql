@@ -0,0 +1,5 @@
+-- Down migration for Audit Log Query Indexes
+
+DROP INDEX IF EXISTS idx_audit_logs_request_id;
+DROP INDEX IF EXISTS idx_audit_logs_resource_created_at;
+DROP INDEX IF EXISTS idx_audit_logs_user_created_at;
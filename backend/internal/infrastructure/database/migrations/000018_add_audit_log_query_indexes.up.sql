@@ -0,0 +1,7 @@
+-- Audit Log Query Indexes
+-- 監査ログ検索API（GET /audit-logs）のキーセットページネーション用インデックス
+-- 並び順は created_at DESC, id DESC
+
+CREATE INDEX idx_audit_logs_user_created_at ON audit_logs(user_id, created_at DESC, id DESC);
+CREATE INDEX idx_audit_logs_resource_created_at ON audit_logs(resource_type, resource_id, created_at DESC, id DESC);
+CREATE INDEX idx_audit_logs_request_id ON audit_logs(request_id);
@@ -0,0 +1,15 @@
+-- Down migration for Audit Log Hash Chain
+
+DROP TABLE IF EXISTS audit_checkpoints;
+
+DROP INDEX IF EXISTS idx_audit_logs_chain_seq;
+ALTER TABLE audit_logs DROP CONSTRAINT IF EXISTS chk_audit_logs_chain;
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS hash;
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS prev_hash;
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS chain_seq;
+
+-- 削除済みユーザーのIDは外部キーを戻す前に NULL にする
+UPDATE audit_logs SET user_id = NULL
+WHERE user_id IS NOT NULL AND NOT EXISTS (SELECT 1 FROM users WHERE users.id = audit_logs.user_id);
+ALTER TABLE audit_logs
+    ADD CONSTRAINT audit_logs_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL;
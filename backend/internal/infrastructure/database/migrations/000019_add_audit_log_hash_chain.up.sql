@@ -0,0 +1,28 @@
+-- Audit Log Hash Chain
+-- Columns: audit_logs.chain_seq, audit_logs.prev_hash, audit_logs.hash
+-- Table: audit_checkpoints
+-- 各エントリは直前のエントリのハッシュを含めた内容のSHA-256を持ち、編集・削除をチェーンの検証で検出する
+-- 導入前に記録されたエントリはチェーンに含まれない（chain_seq IS NULL）
+-- user_id はハッシュの計算対象のため、ユーザー削除時に NULL へ書き換える外部キーを外し、操作者のIDをそのまま保持する
+
+ALTER TABLE audit_logs DROP CONSTRAINT IF EXISTS audit_logs_user_id_fkey;
+
+ALTER TABLE audit_logs
+    ADD COLUMN chain_seq BIGINT,
+    ADD COLUMN prev_hash BYTEA,
+    ADD COLUMN hash BYTEA,
+    ADD CONSTRAINT chk_audit_logs_chain
+        CHECK ((chain_seq IS NULL) = (hash IS NULL));
+
+CREATE UNIQUE INDEX idx_audit_logs_chain_seq ON audit_logs(chain_seq);
+
+-- 1日ごとのチェーン末尾を署名付きで記録する
+CREATE TABLE IF NOT EXISTS audit_checkpoints (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    day DATE NOT NULL UNIQUE,
+    last_seq BIGINT NOT NULL,
+    last_hash BYTEA NOT NULL,
+    key_id VARCHAR(32) NOT NULL,
+    signature BYTEA NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
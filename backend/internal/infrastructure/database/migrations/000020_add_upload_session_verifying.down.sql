@@ -0,0 +1,15 @@
+-- Down migration for Upload Session Status: verifying
+-- PostgreSQLはENUM値の削除をサポートしないため、型を再作成する
+-- 検証待ちのセッションは中断扱いにする（ファイルはuploadingのままリーパーで回収される）
+
+UPDATE upload_sessions SET status = 'aborted' WHERE status = 'verifying';
+
+ALTER TABLE upload_sessions DROP COLUMN IF EXISTS received_size;
+ALTER TABLE upload_sessions DROP COLUMN IF EXISTS received_version_id;
+
+ALTER TABLE upload_sessions ALTER COLUMN status DROP DEFAULT;
+ALTER TYPE upload_session_status RENAME TO upload_session_status_old;
+CREATE TYPE upload_session_status AS ENUM ('pending', 'in_progress', 'completed', 'aborted', 'expired');
+ALTER TABLE upload_sessions ALTER COLUMN status TYPE upload_session_status USING status::text::upload_session_status;
+ALTER TABLE upload_sessions ALTER COLUMN status SET DEFAULT 'pending';
+DROP TYPE upload_session_status_old;
@@ -0,0 +1,10 @@
+-- Upload Session Status: verifying
+-- Columns: upload_sessions.received_version_id, upload_sessions.received_size
+-- 全内容を受信し、バックグラウンドでの内容の再計算・検証を待っているセッション
+-- 検証するオブジェクトバージョンとサイズを検証完了まで保持する
+
+ALTER TYPE upload_session_status ADD VALUE IF NOT EXISTS 'verifying';
+
+ALTER TABLE upload_sessions
+    ADD COLUMN received_version_id VARCHAR(255),
+    ADD COLUMN received_size BIGINT;
@@ -0,0 +1,6 @@
+-- Down migration for Copy Job Lease
+
+DROP INDEX IF EXISTS idx_copy_jobs_running_heartbeat_at;
+
+ALTER TABLE copy_jobs DROP COLUMN IF EXISTS heartbeat_at;
+ALTER TABLE copy_jobs DROP COLUMN IF EXISTS lease_token;
@@ -0,0 +1,13 @@
+-- Copy Job Lease
+-- Columns: copy_jobs.lease_token, copy_jobs.heartbeat_at
+-- ジョブを取得したレプリカがリースを保持し、実行中はハートビートを更新する
+-- ハートビートが途絶えたジョブのみ中断として回収する
+
+ALTER TABLE copy_jobs
+    ADD COLUMN lease_token UUID,
+    ADD COLUMN heartbeat_at TIMESTAMPTZ;
+
+-- 実行中のまま残っている既存ジョブは開始日時をハートビートとみなす
+UPDATE copy_jobs SET heartbeat_at = started_at WHERE status = 'running';
+
+CREATE INDEX idx_copy_jobs_running_heartbeat_at ON copy_jobs(heartbeat_at) WHERE status = 'running';
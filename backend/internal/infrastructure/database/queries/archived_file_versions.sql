@@ -1,7 +1,7 @@
 -- name: CreateArchivedFileVersionsBulk :copyfrom
 INSERT INTO archived_file_versions (
-    id, archived_file_id, original_version_id, version_number, minio_version_id, size, checksum, uploaded_by, created_at
-) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+    id, archived_file_id, original_version_id, version_number, storage_key, minio_version_id, blob_id, size, checksum, uploaded_by, is_pinned, created_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12);
 
 -- name: ListArchivedFileVersionsByArchivedFileID :many
 SELECT * FROM archived_file_versions
@@ -1,9 +1,9 @@
 -- name: CreateArchivedFile :one
 INSERT INTO archived_files (
     id, original_file_id, original_folder_id, original_path, name, mime_type, size,
-    owner_id, created_by, storage_key, archived_at, archived_by, expires_at
+    owner_id, created_by, storage_key, archived_at, archived_by, expires_at, archived_folder_id
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
 ) RETURNING *;
 
 -- name: GetArchivedFileByID :one
@@ -18,15 +18,22 @@ WHERE owner_id = $1
 ORDER BY archived_at DESC;
 
 -- name: ListArchivedFilesByOwnerWithPagination :many
+-- Excludes files trashed together with a folder; they are listed through their archived folder
 SELECT * FROM archived_files
 WHERE owner_id = $1
+  AND archived_folder_id IS NULL
   AND (
-    sqlc.narg('cursor_id')::uuid IS NULL
-    OR id < sqlc.narg('cursor_id')::uuid
+    sqlc.narg('cursor_archived_at')::timestamptz IS NULL
+    OR (archived_at, id) < (sqlc.narg('cursor_archived_at')::timestamptz, sqlc.narg('cursor_id')::uuid)
   )
 ORDER BY archived_at DESC, id DESC
 LIMIT $2;
 
+-- name: ListArchivedFilesByArchivedFolderID :many
+SELECT * FROM archived_files
+WHERE archived_folder_id = $1
+ORDER BY original_path ASC;
+
 -- name: ListExpiredArchivedFiles :many
 SELECT * FROM archived_files
 WHERE expires_at < NOW();
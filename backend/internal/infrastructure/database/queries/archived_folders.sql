@@ -0,0 +1,32 @@
+-- name: CreateArchivedFolder :one
+INSERT INTO archived_folders (
+    id, original_folder_id, original_parent_id, original_path, name,
+    owner_id, created_by, archived_at, archived_by, expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+) RETURNING *;
+
+-- name: GetArchivedFolderByID :one
+SELECT * FROM archived_folders WHERE id = $1;
+
+-- name: ListArchivedFoldersByOwner :many
+SELECT * FROM archived_folders
+WHERE owner_id = $1
+ORDER BY archived_at DESC;
+
+-- name: ListArchivedFoldersByOwnerWithPagination :many
+SELECT * FROM archived_folders
+WHERE owner_id = $1
+  AND (
+    sqlc.narg('cursor_archived_at')::timestamptz IS NULL
+    OR (archived_at, id) < (sqlc.narg('cursor_archived_at')::timestamptz, sqlc.narg('cursor_id')::uuid)
+  )
+ORDER BY archived_at DESC, id DESC
+LIMIT $2;
+
+-- name: ListExpiredArchivedFolders :many
+SELECT * FROM archived_folders
+WHERE expires_at < NOW();
+
+-- name: DeleteArchivedFolder :exec
+DELETE FROM archived_folders WHERE id = $1;
@@ -0,0 +1,12 @@
+-- name: CreateArchivedSubfoldersBulk :copyfrom
+INSERT INTO archived_subfolders (
+    id, archived_folder_id, original_folder_id, original_parent_id, name, owner_id, created_by, relative_depth
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: ListArchivedSubfoldersByArchivedFolderID :many
+SELECT * FROM archived_subfolders
+WHERE archived_folder_id = $1
+ORDER BY relative_depth ASC;
+
+-- name: DeleteArchivedSubfoldersByArchivedFolderID :exec
+DELETE FROM archived_subfolders WHERE archived_folder_id = $1;
@@ -0,0 +1,13 @@
+-- name: CreateAuditCheckpoint :exec
+INSERT INTO audit_checkpoints (id, day, last_seq, last_hash, key_id, signature, created_at)
+VALUES (@id, @day, @last_seq, @last_hash, @key_id, @signature, @created_at)
+ON CONFLICT (day) DO NOTHING;
+
+-- name: GetLatestAuditCheckpoint :one
+SELECT * FROM audit_checkpoints
+ORDER BY day DESC
+LIMIT 1;
+
+-- name: ListAuditCheckpoints :many
+SELECT * FROM audit_checkpoints
+ORDER BY day ASC;
@@ -1,6 +1,6 @@
 -- name: CreateAuditLog :one
-INSERT INTO audit_logs (user_id, action, resource_type, resource_id, details, ip_address, user_agent, request_id)
-VALUES (@user_id, @action, @resource_type, @resource_id, @details, @ip_address, @user_agent, @request_id)
+INSERT INTO audit_logs (id, user_id, action, resource_type, resource_id, details, ip_address, user_agent, request_id, chain_seq, prev_hash, hash, created_at)
+VALUES (@id, @user_id, @action, @resource_type, @resource_id, @details, @ip_address, @user_agent, @request_id, @chain_seq, @prev_hash, @hash, @created_at)
 RETURNING *;
 
 -- name: ListAuditLogsByUserID :many
@@ -18,3 +18,24 @@ LIMIT @limit_val OFFSET @offset_val;
 -- name: CountAuditLogsByUserID :one
 SELECT COUNT(*) FROM audit_logs
 WHERE user_id = @user_id;
+
+-- name: LockAuditLogChain :exec
+SELECT pg_advisory_xact_lock(@lock_key::bigint);
+
+-- name: GetAuditLogChainHead :one
+SELECT * FROM audit_logs
+WHERE chain_seq IS NOT NULL
+ORDER BY chain_seq DESC
+LIMIT 1;
+
+-- name: GetAuditLogChainHeadBefore :one
+SELECT * FROM audit_logs
+WHERE chain_seq IS NOT NULL AND created_at < @before
+ORDER BY chain_seq DESC
+LIMIT 1;
+
+-- name: ListAuditLogChainAfter :many
+SELECT * FROM audit_logs
+WHERE chain_seq > @after_seq::bigint
+ORDER BY chain_seq ASC
+LIMIT @limit_val;
@@ -0,0 +1,68 @@
+-- name: AcquireBlob :one
+-- 同じチェックサムのブロブがあれば参照数を増やし、なければ登録する（同時登録でも1行にまとまる）
+INSERT INTO blobs (
+    id, checksum, storage_key, minio_version_id, size, ref_count, created_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+ON CONFLICT (checksum) DO UPDATE SET ref_count = blobs.ref_count + 1
+RETURNING *;
+
+-- name: GetBlobByID :one
+SELECT * FROM blobs WHERE id = $1;
+
+-- name: IncrementBlobRefCount :one
+UPDATE blobs SET ref_count = ref_count + 1
+WHERE id = $1
+RETURNING *;
+
+-- name: DecrementBlobRefCount :one
+UPDATE blobs SET ref_count = ref_count - 1
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteBlob :exec
+DELETE FROM blobs WHERE id = $1 AND ref_count = 0;
+
+-- name: IsObjectVersionReferenced :one
+-- ブロブかファイルバージョン（ゴミ箱内を含む）がオブジェクトバージョンを参照しているか
+SELECT (
+    EXISTS(SELECT 1 FROM blobs b WHERE b.storage_key = @storage_key AND b.minio_version_id = @minio_version_id)
+    OR EXISTS(SELECT 1 FROM file_versions fv WHERE fv.storage_key = @storage_key AND fv.minio_version_id = @minio_version_id)
+    OR EXISTS(SELECT 1 FROM archived_file_versions afv WHERE afv.storage_key = @storage_key AND afv.minio_version_id = @minio_version_id)
+)::boolean AS referenced;
+
+-- name: ListDuplicateFilesByOwner :many
+-- 現在のバージョンが同じブロブを共有する所有者のアクティブなファイル
+WITH current_blobs AS (
+    SELECT f.id AS file_id, fv.blob_id
+    FROM files f
+    INNER JOIN file_versions fv ON fv.file_id = f.id AND fv.version_number = f.current_version
+    WHERE f.owner_id = $1
+      AND f.status = 'active'
+      AND fv.blob_id IS NOT NULL
+),
+duplicated AS (
+    SELECT blob_id FROM current_blobs
+    GROUP BY blob_id
+    HAVING COUNT(*) > 1
+)
+SELECT
+    f.id,
+    f.folder_id,
+    f.owner_id,
+    f.created_by,
+    f.name,
+    f.mime_type,
+    f.size,
+    f.storage_key,
+    f.current_version,
+    f.status,
+    f.created_at,
+    f.updated_at,
+    b.checksum
+FROM current_blobs cb
+INNER JOIN duplicated d ON d.blob_id = cb.blob_id
+INNER JOIN blobs b ON b.id = cb.blob_id
+INNER JOIN files f ON f.id = cb.file_id
+ORDER BY b.size DESC, b.checksum, f.name, f.id;
@@ -0,0 +1,56 @@
+-- name: CreateCopyJob :exec
+INSERT INTO copy_jobs (
+    id, user_id, source_folder_id, destination_folder_id, status,
+    result_folder_id, folder_count, file_count, error_message,
+    created_at, started_at, finished_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+);
+
+-- name: GetCopyJobByID :one
+SELECT * FROM copy_jobs WHERE id = $1;
+
+-- name: UpdateCopyJob :exec
+UPDATE copy_jobs SET
+    status = $2,
+    result_folder_id = $3,
+    folder_count = $4,
+    file_count = $5,
+    error_message = $6,
+    started_at = $7,
+    finished_at = $8
+WHERE id = $1;
+
+-- name: ClaimNextPendingCopyJob :one
+-- 複数レプリカが同じジョブを実行しないよう、行ロックを取得できたジョブのみを実行中にする
+-- 取得したレプリカはlease_tokenでリースを保持し、実行中はheartbeat_atを更新する
+UPDATE copy_jobs SET
+    status = 'running',
+    started_at = NOW(),
+    lease_token = @lease_token,
+    heartbeat_at = NOW()
+WHERE id = (
+    SELECT id FROM copy_jobs
+    WHERE status = 'pending'
+    ORDER BY created_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: HeartbeatCopyJob :execrows
+-- リースを保持している実行中のジョブのみハートビートを更新する
+UPDATE copy_jobs SET heartbeat_at = NOW()
+WHERE id = @id
+  AND status = 'running'
+  AND lease_token = @lease_token;
+
+-- name: FailRunningCopyJobsHeartbeatBefore :execrows
+-- ハートビートが途絶えたジョブのみを失敗にする
+-- 判定と更新を1文で行い、ハートビートを更新中のジョブを失敗にしないようにする
+UPDATE copy_jobs SET
+    status = 'failed',
+    error_message = @error_message,
+    finished_at = NOW()
+WHERE status = 'running'
+  AND heartbeat_at < @heartbeat_before;
@@ -1,8 +1,8 @@
 -- name: CreateFileVersion :one
 INSERT INTO file_versions (
-    id, file_id, version_number, minio_version_id, size, checksum, uploaded_by, created_at
+    id, file_id, version_number, storage_key, minio_version_id, blob_id, size, checksum, uploaded_by, is_pinned, created_at
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
 ) RETURNING *;
 
 -- name: GetFileVersionByID :one
@@ -38,8 +38,22 @@ WHERE file_id = ANY($1::uuid[])
 ORDER BY file_id, version_number DESC;
 
 -- name: CreateFileVersionsBulk :copyfrom
-INSERT INTO file_versions (id, file_id, version_number, minio_version_id, size, checksum, uploaded_by, created_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+INSERT INTO file_versions (id, file_id, version_number, storage_key, minio_version_id, blob_id, size, checksum, uploaded_by, is_pinned, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);
 
 -- name: GetNextVersionNumber :one
 SELECT COALESCE(MAX(version_number), 0) + 1 FROM file_versions WHERE file_id = $1;
+
+-- name: UpdateFileVersionPinned :exec
+UPDATE file_versions SET is_pinned = $2 WHERE id = $1;
+
+-- name: ListFileIDsWithPrunableVersions :many
+-- 最新バージョン以外にピン留めされていないバージョンを持つアクティブなファイル（キーセットページング）
+SELECT DISTINCT fv.file_id FROM file_versions fv
+INNER JOIN files f ON f.id = fv.file_id
+WHERE f.status = 'active'
+  AND fv.version_number <> f.current_version
+  AND fv.is_pinned = FALSE
+  AND fv.file_id > sqlc.arg('after_id')
+ORDER BY fv.file_id
+LIMIT sqlc.arg('limit_count');
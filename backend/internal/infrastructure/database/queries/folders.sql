@@ -8,6 +8,9 @@ INSERT INTO folders (
 -- name: GetFolderByID :one
 SELECT * FROM folders WHERE id = $1;
 
+-- name: ListFoldersByIDs :many
+SELECT * FROM folders WHERE id = ANY($1::uuid[]);
+
 -- name: UpdateFolder :one
 UPDATE folders SET
     name = COALESCE(sqlc.narg('name'), name),
@@ -0,0 +1,15 @@
+-- name: CreateJobRun :exec
+INSERT INTO job_runs (
+    id, job_name, instance_id, status, error_message, started_at, finished_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+);
+
+-- name: ListJobRunsByJobName :many
+SELECT * FROM job_runs
+WHERE job_name = $1
+ORDER BY started_at DESC
+LIMIT $2;
+
+-- name: DeleteJobRunsStartedBefore :execrows
+DELETE FROM job_runs WHERE started_at < $1;
@@ -0,0 +1,29 @@
+-- name: ListFileVersionObjectRefs :many
+-- ストレージ整合性チェック用（キーセットページング）
+SELECT
+    fv.id,
+    fv.file_id,
+    fv.storage_key,
+    fv.minio_version_id,
+    fv.size,
+    fv.checksum,
+    (fv.version_number = f.current_version)::boolean AS is_current
+FROM file_versions fv
+INNER JOIN files f ON f.id = fv.file_id
+WHERE fv.id > sqlc.arg('after_id')
+ORDER BY fv.id
+LIMIT sqlc.arg('limit_count');
+
+-- name: ListArchivedFileVersionObjectRefs :many
+-- ストレージ整合性チェック用（キーセットページング）
+SELECT
+    afv.id,
+    afv.archived_file_id,
+    afv.storage_key,
+    afv.minio_version_id,
+    afv.size,
+    afv.checksum
+FROM archived_file_versions afv
+WHERE afv.id > sqlc.arg('after_id')
+ORDER BY afv.id
+LIMIT sqlc.arg('limit_count');
@@ -0,0 +1,38 @@
+-- name: UpsertStorageQuota :one
+INSERT INTO storage_quotas (
+    id, subject_type, subject_id, limit_bytes, created_at, updated_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (subject_type, subject_id) DO UPDATE SET
+    limit_bytes = EXCLUDED.limit_bytes,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: GetStorageQuotaBySubject :one
+SELECT * FROM storage_quotas WHERE subject_type = $1 AND subject_id = $2;
+
+-- name: ListGroupStorageQuotasByUserID :many
+SELECT sq.* FROM storage_quotas sq
+INNER JOIN memberships m ON m.group_id = sq.subject_id
+WHERE sq.subject_type = 'group' AND m.user_id = $1
+ORDER BY sq.created_at;
+
+-- name: DeleteStorageQuotaBySubject :exec
+DELETE FROM storage_quotas WHERE subject_type = $1 AND subject_id = $2;
+
+-- name: GetStorageUsedBytes :one
+SELECT COALESCE((SELECT used_bytes FROM storage_usages WHERE user_id = $1), 0)::BIGINT AS used_bytes;
+
+-- name: AddStorageUsedBytes :exec
+INSERT INTO storage_usages (user_id, used_bytes, updated_at)
+VALUES (sqlc.arg('user_id'), GREATEST(sqlc.arg('delta')::BIGINT, 0), NOW())
+ON CONFLICT (user_id) DO UPDATE SET
+    used_bytes = GREATEST(storage_usages.used_bytes + sqlc.arg('delta')::BIGINT, 0),
+    updated_at = NOW();
+
+-- name: SumStorageUsedBytesByGroup :one
+SELECT COALESCE(SUM(su.used_bytes), 0)::BIGINT AS used_bytes
+FROM memberships m
+INNER JOIN storage_usages su ON su.user_id = m.user_id
+WHERE m.group_id = $1;
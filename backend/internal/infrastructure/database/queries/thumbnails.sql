@@ -0,0 +1,39 @@
+-- name: CreateThumbnail :exec
+-- 同じファイルバージョンのサムネイルが登録済みの場合は何もしない
+INSERT INTO thumbnails (
+    id, file_id, version_number, source_key, source_version_id, status,
+    error_message, created_at, started_at, finished_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+)
+ON CONFLICT (file_id, version_number) DO NOTHING;
+
+-- name: GetThumbnailByFileAndVersion :one
+SELECT * FROM thumbnails WHERE file_id = $1 AND version_number = $2;
+
+-- name: UpdateThumbnail :exec
+UPDATE thumbnails SET
+    status = $2,
+    error_message = $3,
+    started_at = $4,
+    finished_at = $5
+WHERE id = $1;
+
+-- name: ClaimNextPendingThumbnail :one
+-- 複数レプリカが同じサムネイルを生成しないよう、行ロックを取得できたサムネイルのみを生成中にする
+UPDATE thumbnails SET
+    status = 'running',
+    started_at = NOW()
+WHERE id = (
+    SELECT id FROM thumbnails
+    WHERE status = 'pending'
+    ORDER BY created_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: ListRunningThumbnailsStartedBefore :many
+SELECT * FROM thumbnails
+WHERE status = 'running'
+  AND started_at < $1;
@@ -2,14 +2,17 @@
 INSERT INTO upload_sessions (
     id, file_id, owner_id, created_by, folder_id, file_name, mime_type, total_size,
     storage_key, minio_upload_id, is_multipart, total_parts, uploaded_parts, status,
-    created_at, updated_at, expires_at
+    created_at, updated_at, expires_at, expected_checksum_algorithm, expected_checksum
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19
 ) RETURNING *;
 
 -- name: GetUploadSessionByID :one
 SELECT * FROM upload_sessions WHERE id = $1;
 
+-- name: GetUploadSessionByIDForUpdate :one
+SELECT * FROM upload_sessions WHERE id = $1 FOR UPDATE;
+
 -- name: GetUploadSessionByFileID :one
 SELECT * FROM upload_sessions
 WHERE file_id = $1
@@ -27,6 +30,8 @@ UPDATE upload_sessions SET
     minio_upload_id = COALESCE(sqlc.narg('minio_upload_id'), minio_upload_id),
     uploaded_parts = COALESCE(sqlc.narg('uploaded_parts'), uploaded_parts),
     status = COALESCE(sqlc.narg('status'), status),
+    received_version_id = COALESCE(sqlc.narg('received_version_id'), received_version_id),
+    received_size = COALESCE(sqlc.narg('received_size'), received_size),
     updated_at = NOW()
 WHERE id = $1
 RETURNING *;
@@ -43,5 +48,11 @@ WHERE id = $1;
 SELECT * FROM upload_sessions
 WHERE status IN ('pending', 'in_progress') AND expires_at < NOW();
 
+-- name: ListVerifyingUploadSessions :many
+SELECT * FROM upload_sessions
+WHERE status = 'verifying'
+ORDER BY updated_at
+LIMIT $1;
+
 -- name: DeleteUploadSession :exec
 DELETE FROM upload_sessions WHERE id = $1;
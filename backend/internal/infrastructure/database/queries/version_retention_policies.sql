@@ -0,0 +1,17 @@
+-- name: UpsertVersionRetentionPolicy :one
+INSERT INTO version_retention_policies (
+    id, subject_type, subject_id, keep_last_n, keep_days, created_at, updated_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+ON CONFLICT (subject_type, subject_id) DO UPDATE SET
+    keep_last_n = EXCLUDED.keep_last_n,
+    keep_days = EXCLUDED.keep_days,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: GetVersionRetentionPolicyBySubject :one
+SELECT * FROM version_retention_policies WHERE subject_type = $1 AND subject_id = $2;
+
+-- name: DeleteVersionRetentionPolicyBySubject :exec
+DELETE FROM version_retention_policies WHERE subject_type = $1 AND subject_id = $2;
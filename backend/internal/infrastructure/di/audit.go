@@ -0,0 +1,26 @@
+package di
+
+import (
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	auditqry "github.com/Hiro-mackay/gc-storage/backend/internal/usecase/audit/query"
+)
+
+// AuditUseCases は監査ログ関連のUseCaseを保持します
+type AuditUseCases struct {
+	// Queries
+	ListAuditLogs   *auditqry.ListAuditLogsQuery
+	ExportAuditLogs *auditqry.ExportAuditLogsQuery
+}
+
+// NewAuditUseCases は新しいAuditUseCasesを作成します
+func NewAuditUseCases(
+	auditLogRepo repository.AuditLogRepository,
+	resolver authz.PermissionResolver,
+) *AuditUseCases {
+	return &AuditUseCases{
+		// Queries
+		ListAuditLogs:   auditqry.NewListAuditLogsQuery(auditLogRepo, resolver),
+		ExportAuditLogs: auditqry.NewExportAuditLogsQuery(auditLogRepo, resolver),
+	}
+}
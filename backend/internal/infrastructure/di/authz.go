@@ -4,6 +4,7 @@ import (
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
 	infraAuthz "github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/authz"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/database"
+	infraRepo "github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/repository"
 	authzcmd "github.com/Hiro-mackay/gc-storage/backend/internal/usecase/authz/command"
 	authzqry "github.com/Hiro-mackay/gc-storage/backend/internal/usecase/authz/query"
 )
@@ -35,6 +36,7 @@ func NewAuthzRepositories(txManager *database.TxManager) *AuthzRepositories {
 
 // NewPermissionResolver は新しいPermissionResolverを作成します
 func NewPermissionResolver(
+	txManager *database.TxManager,
 	authzRepos *AuthzRepositories,
 	collabRepos *CollaborationRepositories,
 ) authz.PermissionResolver {
@@ -42,6 +44,7 @@ func NewPermissionResolver(
 		authzRepos.PermissionGrantRepo,
 		authzRepos.RelationshipRepo,
 		collabRepos.MembershipRepo,
+		infraRepo.NewFileRepository(txManager),
 	)
 }
 
@@ -16,6 +16,7 @@ type CollaborationUseCases struct {
 	UpdateGroup       *collabcmd.UpdateGroupCommand
 	DeleteGroup       *collabcmd.DeleteGroupCommand
 	TransferOwnership *collabcmd.TransferOwnershipCommand
+	SetGroupQuota     *collabcmd.SetGroupQuotaCommand
 
 	// Member Commands
 	InviteMember      *collabcmd.InviteMemberCommand
@@ -32,6 +33,7 @@ type CollaborationUseCases struct {
 	ListMembers            *collabqry.ListMembersQuery
 	ListInvitations        *collabqry.ListInvitationsQuery
 	ListPendingInvitations *collabqry.ListPendingInvitationsQuery
+	GetGroupQuota          *collabqry.GetGroupQuotaQuery
 }
 
 // CollaborationRepositories はCollaboration関連のリポジトリを保持します
@@ -39,6 +41,8 @@ type CollaborationRepositories struct {
 	GroupRepo      repository.GroupRepository
 	MembershipRepo repository.MembershipRepository
 	InvitationRepo repository.InvitationRepository
+	QuotaRepo      repository.StorageQuotaRepository
+	UsageRepo      repository.StorageUsageRepository
 }
 
 // NewCollaborationRepositories は新しいCollaborationRepositoriesを作成します
@@ -47,6 +51,8 @@ func NewCollaborationRepositories(txManager *database.TxManager) *CollaborationR
 		GroupRepo:      infraRepo.NewGroupRepository(txManager),
 		MembershipRepo: infraRepo.NewMembershipRepository(txManager),
 		InvitationRepo: infraRepo.NewInvitationRepository(txManager),
+		QuotaRepo:      infraRepo.NewStorageQuotaRepository(txManager),
+		UsageRepo:      infraRepo.NewStorageUsageRepository(txManager),
 	}
 }
 
@@ -58,6 +64,7 @@ func NewCollaborationUseCases(repos *CollaborationRepositories, userRepo reposit
 		UpdateGroup:       collabcmd.NewUpdateGroupCommand(repos.GroupRepo, repos.MembershipRepo),
 		DeleteGroup:       collabcmd.NewDeleteGroupCommand(repos.GroupRepo, repos.MembershipRepo, repos.InvitationRepo, txManager),
 		TransferOwnership: collabcmd.NewTransferOwnershipCommand(repos.GroupRepo, repos.MembershipRepo, txManager),
+		SetGroupQuota:     collabcmd.NewSetGroupQuotaCommand(repos.GroupRepo, repos.MembershipRepo, repos.QuotaRepo),
 
 		// Member Commands
 		InviteMember:      collabcmd.NewInviteMemberCommand(repos.GroupRepo, repos.MembershipRepo, repos.InvitationRepo, userRepo, emailSender, appURL),
@@ -74,5 +81,6 @@ func NewCollaborationUseCases(repos *CollaborationRepositories, userRepo reposit
 		ListMembers:            collabqry.NewListMembersQuery(repos.GroupRepo, repos.MembershipRepo),
 		ListInvitations:        collabqry.NewListInvitationsQuery(repos.InvitationRepo, repos.MembershipRepo, repos.GroupRepo),
 		ListPendingInvitations: collabqry.NewListPendingInvitationsQuery(repos.InvitationRepo, userRepo, repos.GroupRepo),
+		GetGroupQuota:          collabqry.NewGetGroupQuotaQuery(repos.GroupRepo, repos.MembershipRepo, repos.QuotaRepo, repos.UsageRepo),
 	}
 }
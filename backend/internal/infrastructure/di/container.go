@@ -33,6 +33,7 @@ type Container struct {
 	JWTService   *jwt.JWTService
 	JWTBlacklist *cache.JWTBlacklist
 	RateLimiter  *cache.RateLimiter
+	JobLock      *cache.JobLock
 	EmailService service.EmailSender
 	OAuthFactory service.OAuthClientFactory
 
@@ -78,8 +79,17 @@ type Container struct {
 	SharingRepos *SharingRepositories
 
 	// Audit
-	AuditLogRepo repository.AuditLogRepository
-	AuditService *audit.Service
+	AuditLogRepo        repository.AuditLogRepository
+	AuditCheckpointRepo repository.AuditCheckpointRepository
+	AuditService        *audit.Service
+	// AuditCheckpointSigner は署名鍵が未設定の場合nil
+	AuditCheckpointSigner *audit.Ed25519CheckpointSigner
+
+	// Audit UseCases
+	Audit *AuditUseCases
+
+	// Background Jobs
+	JobRunRepo repository.JobRunRepository
 
 	// config
 	config *config.Config
@@ -115,6 +125,7 @@ func NewContainerWithOptions(ctx context.Context, cfg *config.Config, opts Optio
 		c.SessionRepo = cache.NewSessionStore(opts.RedisClient, 7*24*time.Hour)
 		c.JWTBlacklist = cache.NewJWTBlacklist(opts.RedisClient)
 		c.RateLimiter = cache.NewRateLimiter(opts.RedisClient)
+		c.JobLock = cache.NewJobLock(opts.RedisClient)
 	} else {
 		slog.Info("connecting to Redis...")
 		redisConfig := cache.DefaultConfig()
@@ -128,6 +139,7 @@ func NewContainerWithOptions(ctx context.Context, cfg *config.Config, opts Optio
 		c.SessionRepo = cache.NewSessionStore(redisClient.Client(), 7*24*time.Hour)
 		c.JWTBlacklist = cache.NewJWTBlacklist(redisClient.Client())
 		c.RateLimiter = cache.NewRateLimiter(redisClient.Client())
+		c.JobLock = cache.NewJobLock(redisClient.Client())
 		slog.Info("connected to Redis")
 	}
 
@@ -164,6 +176,16 @@ func NewContainerWithOptions(ctx context.Context, cfg *config.Config, opts Optio
 		c.OAuthFactory = oauth.NewClientFactory(oauthConfig)
 	}
 
+	// Audit Checkpoint Signer
+	if cfg.Security.AuditCheckpointSigningKey != "" {
+		signer, err := audit.NewEd25519CheckpointSigner(cfg.Security.AuditCheckpointSigningKey)
+		if err != nil {
+			c.Close()
+			return nil, err
+		}
+		c.AuditCheckpointSigner = signer
+	}
+
 	// Repositories
 	c.UserRepo = infraRepo.NewUserRepository(c.TxManager)
 	c.EmailVerificationTokenRepo = infraRepo.NewEmailVerificationTokenRepository(c.TxManager)
@@ -171,6 +193,8 @@ func NewContainerWithOptions(ctx context.Context, cfg *config.Config, opts Optio
 	c.OAuthAccountRepo = infraRepo.NewOAuthAccountRepository(c.TxManager)
 	c.UserProfileRepo = infraRepo.NewUserProfileRepository(c.TxManager)
 	c.AuditLogRepo = infraRepo.NewAuditLogRepository(c.TxManager)
+	c.AuditCheckpointRepo = infraRepo.NewAuditCheckpointRepository(c.TxManager)
+	c.JobRunRepo = infraRepo.NewJobRunRepository(c.TxManager)
 
 	return c, nil
 }
@@ -198,9 +222,9 @@ func (c *Container) InitStorageUseCases(storageService service.StorageService) {
 	}
 	// PermissionResolver must be initialized for StorageUseCases
 	if c.PermissionResolver == nil {
-		c.PermissionResolver = NewPermissionResolver(c.AuthzRepos, c.CollabRepos)
+		c.PermissionResolver = NewPermissionResolver(c.TxManager, c.AuthzRepos, c.CollabRepos)
 	}
-	c.Storage = NewStorageUseCases(c.StorageRepos, c.UserRepo, c.AuthzRepos.RelationshipRepo, c.PermissionResolver, c.TxManager, storageService)
+	c.Storage = NewStorageUseCases(c.StorageRepos, c.UserRepo, c.AuthzRepos.RelationshipRepo, c.AuthzRepos.PermissionGrantRepo, c.CollabRepos.MembershipRepo, c.PermissionResolver, c.TxManager, storageService, c.config.Storage.DefaultUserQuotaBytes)
 }
 
 // InitCollaborationUseCases はCollaboration UseCasesを初期化します
@@ -212,13 +236,28 @@ func (c *Container) InitCollaborationUseCases() {
 // InitAuthzUseCases はAuthorization UseCasesを初期化します
 func (c *Container) InitAuthzUseCases() {
 	c.AuthzRepos = NewAuthzRepositories(c.TxManager)
-	c.PermissionResolver = NewPermissionResolver(c.AuthzRepos, c.CollabRepos)
+	c.PermissionResolver = NewPermissionResolver(c.TxManager, c.AuthzRepos, c.CollabRepos)
 	c.Authz = NewAuthzUseCases(c.AuthzRepos, c.PermissionResolver)
 }
 
 // InitAuditService は監査ログサービスを初期化します
 func (c *Container) InitAuditService() {
-	c.AuditService = audit.NewService(c.AuditLogRepo, 1000)
+	c.AuditService = audit.NewService(c.AuditLogRepo, c.TxManager, 1000)
+}
+
+// InitAuditUseCases は監査ログ UseCasesを初期化します
+func (c *Container) InitAuditUseCases() {
+	// PermissionResolver must be initialized for resource-scoped audit log access
+	if c.PermissionResolver == nil {
+		if c.AuthzRepos == nil {
+			c.AuthzRepos = NewAuthzRepositories(c.TxManager)
+		}
+		if c.CollabRepos == nil {
+			c.CollabRepos = NewCollaborationRepositories(c.TxManager)
+		}
+		c.PermissionResolver = NewPermissionResolver(c.TxManager, c.AuthzRepos, c.CollabRepos)
+	}
+	c.Audit = NewAuditUseCases(c.AuditLogRepo, c.PermissionResolver)
 }
 
 // InitSharingUseCases はSharing UseCasesを初期化します
@@ -236,7 +275,7 @@ func (c *Container) InitSharingUseCases(storageService service.StorageService) {
 		if c.CollabRepos == nil {
 			c.CollabRepos = NewCollaborationRepositories(c.TxManager)
 		}
-		c.PermissionResolver = NewPermissionResolver(c.AuthzRepos, c.CollabRepos)
+		c.PermissionResolver = NewPermissionResolver(c.TxManager, c.AuthzRepos, c.CollabRepos)
 	}
 	c.Sharing = NewSharingUseCases(c.SharingRepos, c.PermissionResolver, c.StorageRepos, storageService)
 }
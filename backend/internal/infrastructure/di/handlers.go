@@ -6,16 +6,24 @@ import (
 
 // Handlers はアプリケーションのハンドラーを保持します
 type Handlers struct {
-	Health     *handler.HealthHandler
-	Auth       *handler.AuthHandler
-	Profile    *handler.ProfileHandler
-	Folder     *handler.FolderHandler
-	File       *handler.FileHandler
-	Upload     *handler.UploadHandler
-	Trash      *handler.TrashHandler
-	Group      *handler.GroupHandler
-	Permission *handler.PermissionHandler
-	ShareLink  *handler.ShareLinkHandler
+	Health           *handler.HealthHandler
+	Auth             *handler.AuthHandler
+	Profile          *handler.ProfileHandler
+	Folder           *handler.FolderHandler
+	File             *handler.FileHandler
+	Upload           *handler.UploadHandler
+	Tus              *handler.TusHandler
+	Trash            *handler.TrashHandler
+	Search           *handler.SearchHandler
+	SharedWithMe     *handler.SharedWithMeHandler
+	Storage          *handler.StorageUsageHandler
+	VersionRetention *handler.VersionRetentionHandler
+	Bulk             *handler.BulkHandler
+	Copy             *handler.CopyHandler
+	Group            *handler.GroupHandler
+	Permission       *handler.PermissionHandler
+	ShareLink        *handler.ShareLinkHandler
+	AuditLog         *handler.AuditLogHandler
 }
 
 // NewHandlers はContainerから全てのハンドラーを初期化します
@@ -54,7 +62,14 @@ func NewHandlers(c *Container) *Handlers {
 	var folderHandler *handler.FolderHandler
 	var fileHandler *handler.FileHandler
 	var uploadHandler *handler.UploadHandler
+	var tusHandler *handler.TusHandler
 	var trashHandler *handler.TrashHandler
+	var searchHandler *handler.SearchHandler
+	var sharedWithMeHandler *handler.SharedWithMeHandler
+	var storageUsageHandler *handler.StorageUsageHandler
+	var versionRetentionHandler *handler.VersionRetentionHandler
+	var bulkHandler *handler.BulkHandler
+	var copyHandler *handler.CopyHandler
 	if c.Storage != nil {
 		folderHandler = handler.NewFolderHandler(
 			c.Storage.CreateFolder,
@@ -64,26 +79,59 @@ func NewHandlers(c *Container) *Handlers {
 			c.Storage.GetFolder,
 			c.Storage.ListFolderContents,
 			c.Storage.GetAncestors,
+			c.Storage.GetFolderArchive,
 		)
 		fileHandler = handler.NewFileHandler(
 			c.Storage.RenameFile,
 			c.Storage.MoveFile,
+			c.Storage.RestoreFileVersion,
 			c.Storage.GetDownloadURL,
+			c.Storage.GetThumbnail,
+			c.Storage.GetFilePreview,
 			c.Storage.ListFileVersions,
 		)
 		uploadHandler = handler.NewUploadHandler(
 			c.Storage.InitiateUpload,
+			c.Storage.InitiateVersionUpload,
 			c.Storage.CompleteUpload,
+			c.Storage.FinalizeUpload,
+			c.Storage.UploadPart,
 			c.Storage.AbortUpload,
 			c.Storage.GetUploadStatus,
 		)
+		tusHandler = handler.NewTusHandler(
+			c.Storage.InitiateUpload,
+			c.Storage.InitiateVersionUpload,
+			c.Storage.WriteUploadChunk,
+			c.Storage.GetUploadOffset,
+		)
 		trashHandler = handler.NewTrashHandler(
 			c.Storage.TrashFile,
 			c.Storage.RestoreFile,
+			c.Storage.RestoreFolder,
 			c.Storage.PermanentlyDeleteFile,
 			c.Storage.EmptyTrash,
 			c.Storage.ListTrash,
 		)
+		searchHandler = handler.NewSearchHandler(c.Storage.Search)
+		sharedWithMeHandler = handler.NewSharedWithMeHandler(c.Storage.ListSharedWithMe)
+		storageUsageHandler = handler.NewStorageUsageHandler(c.Storage.GetStorageUsage, c.Storage.ListDuplicateFiles)
+		versionRetentionHandler = handler.NewVersionRetentionHandler(
+			c.Storage.SetVersionRetentionPolicy,
+			c.Storage.PinFileVersion,
+			c.Storage.GetVersionRetentionPolicy,
+		)
+		bulkHandler = handler.NewBulkHandler(
+			c.Storage.BulkMove,
+			c.Storage.BulkTrash,
+			c.Storage.BulkCopy,
+			c.Storage.BulkDelete,
+		)
+		copyHandler = handler.NewCopyHandler(
+			c.Storage.CopyFile,
+			c.Storage.StartFolderCopy,
+			c.Storage.GetCopyJob,
+		)
 	}
 
 	// Group Handler (if Collaboration is initialized)
@@ -101,11 +149,13 @@ func NewHandlers(c *Container) *Handlers {
 			c.Collaboration.LeaveGroup,
 			c.Collaboration.ChangeRole,
 			c.Collaboration.TransferOwnership,
+			c.Collaboration.SetGroupQuota,
 			c.Collaboration.GetGroup,
 			c.Collaboration.ListMyGroups,
 			c.Collaboration.ListMembers,
 			c.Collaboration.ListInvitations,
 			c.Collaboration.ListPendingInvitations,
+			c.Collaboration.GetGroupQuota,
 		)
 	}
 
@@ -131,21 +181,40 @@ func NewHandlers(c *Container) *Handlers {
 			c.Sharing.ListShareLinks,
 			c.Sharing.GetShareLinkHistory,
 			c.Sharing.GetDownloadViaShare,
+			c.Sharing.GetFolderArchiveViaShare,
+			c.Sharing.GetPreviewViaShare,
 			c.config.App.URL,
 		)
 	}
 
+	// AuditLog Handler (if Audit is initialized)
+	var auditLogHandler *handler.AuditLogHandler
+	if c.Audit != nil {
+		auditLogHandler = handler.NewAuditLogHandler(
+			c.Audit.ListAuditLogs,
+			c.Audit.ExportAuditLogs,
+		)
+	}
+
 	return &Handlers{
-		Health:     healthHandler,
-		Auth:       authHandler,
-		Profile:    profileHandler,
-		Folder:     folderHandler,
-		File:       fileHandler,
-		Upload:     uploadHandler,
-		Trash:      trashHandler,
-		Group:      groupHandler,
-		Permission: permissionHandler,
-		ShareLink:  shareLinkHandler,
+		Health:           healthHandler,
+		Auth:             authHandler,
+		Profile:          profileHandler,
+		Folder:           folderHandler,
+		File:             fileHandler,
+		Upload:           uploadHandler,
+		Tus:              tusHandler,
+		Trash:            trashHandler,
+		Search:           searchHandler,
+		SharedWithMe:     sharedWithMeHandler,
+		Storage:          storageUsageHandler,
+		VersionRetention: versionRetentionHandler,
+		Bulk:             bulkHandler,
+		Copy:             copyHandler,
+		Group:            groupHandler,
+		Permission:       permissionHandler,
+		ShareLink:        shareLinkHandler,
+		AuditLog:         auditLogHandler,
 	}
 }
 
@@ -174,7 +243,14 @@ func NewHandlersForTest(c *Container) *Handlers {
 	var folderHandler *handler.FolderHandler
 	var fileHandler *handler.FileHandler
 	var uploadHandler *handler.UploadHandler
+	var tusHandler *handler.TusHandler
 	var trashHandler *handler.TrashHandler
+	var searchHandler *handler.SearchHandler
+	var sharedWithMeHandler *handler.SharedWithMeHandler
+	var storageUsageHandler *handler.StorageUsageHandler
+	var versionRetentionHandler *handler.VersionRetentionHandler
+	var bulkHandler *handler.BulkHandler
+	var copyHandler *handler.CopyHandler
 	if c.Storage != nil {
 		folderHandler = handler.NewFolderHandler(
 			c.Storage.CreateFolder,
@@ -184,26 +260,59 @@ func NewHandlersForTest(c *Container) *Handlers {
 			c.Storage.GetFolder,
 			c.Storage.ListFolderContents,
 			c.Storage.GetAncestors,
+			c.Storage.GetFolderArchive,
 		)
 		fileHandler = handler.NewFileHandler(
 			c.Storage.RenameFile,
 			c.Storage.MoveFile,
+			c.Storage.RestoreFileVersion,
 			c.Storage.GetDownloadURL,
+			c.Storage.GetThumbnail,
+			c.Storage.GetFilePreview,
 			c.Storage.ListFileVersions,
 		)
 		uploadHandler = handler.NewUploadHandler(
 			c.Storage.InitiateUpload,
+			c.Storage.InitiateVersionUpload,
 			c.Storage.CompleteUpload,
+			c.Storage.FinalizeUpload,
+			c.Storage.UploadPart,
 			c.Storage.AbortUpload,
 			c.Storage.GetUploadStatus,
 		)
+		tusHandler = handler.NewTusHandler(
+			c.Storage.InitiateUpload,
+			c.Storage.InitiateVersionUpload,
+			c.Storage.WriteUploadChunk,
+			c.Storage.GetUploadOffset,
+		)
 		trashHandler = handler.NewTrashHandler(
 			c.Storage.TrashFile,
 			c.Storage.RestoreFile,
+			c.Storage.RestoreFolder,
 			c.Storage.PermanentlyDeleteFile,
 			c.Storage.EmptyTrash,
 			c.Storage.ListTrash,
 		)
+		searchHandler = handler.NewSearchHandler(c.Storage.Search)
+		sharedWithMeHandler = handler.NewSharedWithMeHandler(c.Storage.ListSharedWithMe)
+		storageUsageHandler = handler.NewStorageUsageHandler(c.Storage.GetStorageUsage, c.Storage.ListDuplicateFiles)
+		versionRetentionHandler = handler.NewVersionRetentionHandler(
+			c.Storage.SetVersionRetentionPolicy,
+			c.Storage.PinFileVersion,
+			c.Storage.GetVersionRetentionPolicy,
+		)
+		bulkHandler = handler.NewBulkHandler(
+			c.Storage.BulkMove,
+			c.Storage.BulkTrash,
+			c.Storage.BulkCopy,
+			c.Storage.BulkDelete,
+		)
+		copyHandler = handler.NewCopyHandler(
+			c.Storage.CopyFile,
+			c.Storage.StartFolderCopy,
+			c.Storage.GetCopyJob,
+		)
 	}
 
 	// Group Handler (if Collaboration is initialized)
@@ -221,11 +330,13 @@ func NewHandlersForTest(c *Container) *Handlers {
 			c.Collaboration.LeaveGroup,
 			c.Collaboration.ChangeRole,
 			c.Collaboration.TransferOwnership,
+			c.Collaboration.SetGroupQuota,
 			c.Collaboration.GetGroup,
 			c.Collaboration.ListMyGroups,
 			c.Collaboration.ListMembers,
 			c.Collaboration.ListInvitations,
 			c.Collaboration.ListPendingInvitations,
+			c.Collaboration.GetGroupQuota,
 		)
 	}
 
@@ -251,20 +362,39 @@ func NewHandlersForTest(c *Container) *Handlers {
 			c.Sharing.ListShareLinks,
 			c.Sharing.GetShareLinkHistory,
 			c.Sharing.GetDownloadViaShare,
+			c.Sharing.GetFolderArchiveViaShare,
+			c.Sharing.GetPreviewViaShare,
 			c.config.App.URL,
 		)
 	}
 
+	// AuditLog Handler (if Audit is initialized)
+	var auditLogHandler *handler.AuditLogHandler
+	if c.Audit != nil {
+		auditLogHandler = handler.NewAuditLogHandler(
+			c.Audit.ListAuditLogs,
+			c.Audit.ExportAuditLogs,
+		)
+	}
+
 	return &Handlers{
-		Health:     nil, // テストではHealthHandlerは不要
-		Auth:       authHandler,
-		Profile:    profileHandler,
-		Folder:     folderHandler,
-		File:       fileHandler,
-		Upload:     uploadHandler,
-		Trash:      trashHandler,
-		Group:      groupHandler,
-		Permission: permissionHandler,
-		ShareLink:  shareLinkHandler,
+		Health:           nil, // テストではHealthHandlerは不要
+		Auth:             authHandler,
+		Profile:          profileHandler,
+		Folder:           folderHandler,
+		File:             fileHandler,
+		Upload:           uploadHandler,
+		Tus:              tusHandler,
+		Trash:            trashHandler,
+		Search:           searchHandler,
+		SharedWithMe:     sharedWithMeHandler,
+		Storage:          storageUsageHandler,
+		VersionRetention: versionRetentionHandler,
+		Bulk:             bulkHandler,
+		Copy:             copyHandler,
+		Group:            groupHandler,
+		Permission:       permissionHandler,
+		ShareLink:        shareLinkHandler,
+		AuditLog:         auditLogHandler,
 	}
 }
@@ -0,0 +1,114 @@
+package di
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/worker"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/job"
+)
+
+// NewBackgroundJobs は定期実行するバックグラウンドジョブを作成します
+// InitStorageUseCases, InitCollaborationUseCases, InitSharingUseCases の後に呼び出す必要があります
+func (c *Container) NewBackgroundJobs(storageService service.StorageService) []worker.Job {
+	jobsConfig := c.config.Jobs
+	blobService := service.NewBlobService(c.StorageRepos.BlobRepo, storageService)
+
+	trashExpiry := job.NewTrashExpiryJob(
+		c.StorageRepos.ArchivedFileRepo,
+		c.StorageRepos.ArchivedFileVersionRepo,
+		c.StorageRepos.ArchivedFolderRepo,
+		c.StorageRepos.ArchivedSubfolderRepo,
+		c.StorageRepos.StorageUsageRepo,
+		storageService,
+		blobService,
+		c.TxManager,
+	)
+	shareLinkExpiry := job.NewShareLinkExpiryJob(c.SharingRepos.ShareLinkRepo)
+	invitationExpiry := job.NewInvitationExpiryJob(c.CollabRepos.InvitationRepo)
+	accessLogAnonymize := job.NewAccessLogAnonymizeJob(c.SharingRepos.ShareLinkAccessRepo)
+	versionPrune := job.NewVersionPruneJob(
+		c.StorageRepos.FileRepo,
+		c.StorageRepos.FileVersionRepo,
+		c.StorageRepos.StorageUsageRepo,
+		service.NewVersionRetentionService(c.StorageRepos.VersionRetentionRepo, c.StorageRepos.FolderClosureRepo),
+		storageService,
+		blobService,
+		c.TxManager,
+	)
+	uploadSessionReaper := job.NewUploadSessionReaperJob(
+		c.StorageRepos.UploadSessionRepo,
+		c.StorageRepos.FileRepo,
+		storageService,
+		c.TxManager,
+	)
+
+	runCopyJobs := func(ctx context.Context) error {
+		output, err := c.Storage.RunCopyJobs.Execute(ctx)
+		if err != nil {
+			return err
+		}
+		if output.Succeeded+output.Failed+output.Interrupted > 0 {
+			slog.Info("copy jobs processed",
+				"succeeded", output.Succeeded,
+				"failed", output.Failed,
+				"interrupted", output.Interrupted,
+			)
+		}
+		return nil
+	}
+
+	runThumbnailJobs := func(ctx context.Context) error {
+		output, err := c.Storage.RunThumbnailJobs.Execute(ctx)
+		if err != nil {
+			return err
+		}
+		if output.Generated+output.Failed+output.Interrupted > 0 {
+			slog.Info("thumbnail jobs processed",
+				"generated", output.Generated,
+				"failed", output.Failed,
+				"interrupted", output.Interrupted,
+			)
+		}
+		return nil
+	}
+
+	runUploadVerifications := func(ctx context.Context) error {
+		output, err := c.Storage.RunUploadVerifications.Execute(ctx)
+		if err != nil {
+			return err
+		}
+		if output.Completed+output.Rejected+output.Failed > 0 {
+			slog.Info("upload verifications processed",
+				"completed", output.Completed,
+				"rejected", output.Rejected,
+				"failed", output.Failed,
+			)
+		}
+		return nil
+	}
+
+	jobs := []worker.Job{
+		{Name: "trash_expiry", Interval: jobsConfig.TrashExpiryInterval, Fn: trashExpiry.Run, Exclusive: true},
+		{Name: "share_link_expiry", Interval: jobsConfig.ShareLinkExpiryInterval, Fn: shareLinkExpiry.Run, Exclusive: true},
+		{Name: "invitation_expiry", Interval: jobsConfig.InvitationExpiryInterval, Fn: invitationExpiry.Run, Exclusive: true},
+		{Name: "access_log_anonymize", Interval: jobsConfig.AccessLogAnonymizeInterval, Fn: accessLogAnonymize.Run, Exclusive: true},
+		{Name: "version_prune", Interval: jobsConfig.VersionPruneInterval, Fn: versionPrune.Run, Exclusive: true},
+		{Name: "upload_session_reaper", Interval: jobsConfig.UploadSessionReapInterval, Fn: uploadSessionReaper.Run, Exclusive: true},
+		{Name: "copy_jobs", Interval: jobsConfig.CopyJobInterval, Fn: runCopyJobs},
+		{Name: "thumbnail_jobs", Interval: jobsConfig.ThumbnailJobInterval, Fn: runThumbnailJobs},
+		{Name: "upload_verification", Interval: jobsConfig.UploadVerificationInterval, Fn: runUploadVerifications, Exclusive: true},
+		worker.NewJobRunHistoryCleanupJob(c.JobRunRepo.DeleteStartedBefore, jobsConfig.RunHistoryRetention),
+	}
+
+	// 署名鍵が未設定の場合、監査ログのチェックポイントは作成しない
+	if c.AuditCheckpointSigner != nil {
+		auditCheckpoint := job.NewAuditCheckpointJob(c.AuditLogRepo, c.AuditCheckpointRepo, c.AuditCheckpointSigner)
+		jobs = append(jobs, worker.Job{Name: "audit_checkpoint", Interval: jobsConfig.AuditCheckpointInterval, Fn: auditCheckpoint.Run, Exclusive: true})
+	} else {
+		slog.Warn("AUDIT_CHECKPOINT_SIGNING_KEY is not set; audit log checkpoints are disabled")
+	}
+
+	return jobs
+}
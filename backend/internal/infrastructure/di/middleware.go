@@ -1,6 +1,8 @@
 package di
 
 import (
+	"log/slog"
+
 	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/middleware"
 )
 
@@ -10,6 +12,7 @@ type Middlewares struct {
 	RateLimit   *middleware.RateLimitMiddleware
 	Permission  *middleware.PermissionMiddleware
 	Audit       *middleware.AuditMiddleware
+	WebhookAuth *middleware.WebhookAuthMiddleware
 }
 
 // NewMiddlewares はContainerから全てのミドルウェアを初期化します
@@ -17,6 +20,10 @@ func NewMiddlewares(c *Container) *Middlewares {
 	m := &Middlewares{
 		SessionAuth: middleware.NewSessionAuthMiddleware(c.SessionRepo, c.UserRepo),
 		RateLimit:   middleware.NewRateLimitMiddleware(c.RateLimiter),
+		WebhookAuth: middleware.NewWebhookAuthMiddleware(c.config.Storage.WebhookAuthToken, c.config.Storage.WebhookHMACSecret),
+	}
+	if !m.WebhookAuth.Configured() {
+		slog.Warn("upload webhook authentication is not configured; bucket notifications will be rejected")
 	}
 
 	// Permission Middleware (if PermissionResolver is initialized)
@@ -5,6 +5,7 @@ import (
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/database"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/markdown"
 	infraRepo "github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/repository"
 	sharingcmd "github.com/Hiro-mackay/gc-storage/backend/internal/usecase/sharing/command"
 	sharingqry "github.com/Hiro-mackay/gc-storage/backend/internal/usecase/sharing/query"
@@ -18,10 +19,12 @@ type SharingUseCases struct {
 	UpdateShareLink *sharingcmd.UpdateShareLinkCommand
 
 	// Queries
-	AccessShareLink     *sharingqry.AccessShareLinkQuery
-	ListShareLinks      *sharingqry.ListShareLinksQuery
-	GetShareLinkHistory *sharingqry.GetShareLinkHistoryQuery
-	GetDownloadViaShare *sharingqry.GetDownloadViaShareQuery
+	AccessShareLink          *sharingqry.AccessShareLinkQuery
+	ListShareLinks           *sharingqry.ListShareLinksQuery
+	GetShareLinkHistory      *sharingqry.GetShareLinkHistoryQuery
+	GetDownloadViaShare      *sharingqry.GetDownloadViaShareQuery
+	GetFolderArchiveViaShare *sharingqry.GetFolderArchiveViaShareQuery
+	GetPreviewViaShare       *sharingqry.GetPreviewViaShareQuery
 }
 
 // SharingRepositories はSharing関連のリポジトリを保持します
@@ -45,6 +48,15 @@ func NewSharingUseCases(
 	storageRepos *StorageRepositories,
 	storageService service.StorageService,
 ) *SharingUseCases {
+	archiveService := service.NewFolderArchiveService(
+		storageRepos.FolderRepo,
+		storageRepos.FolderClosureRepo,
+		storageRepos.FileRepo,
+		storageRepos.FileVersionRepo,
+		storageService,
+	)
+	filePreviewService := service.NewFilePreviewService(storageService, markdown.NewRenderer())
+
 	return &SharingUseCases{
 		// Commands
 		CreateShareLink: sharingcmd.NewCreateShareLinkCommand(repos.ShareLinkRepo, resolver),
@@ -74,5 +86,19 @@ func NewSharingUseCases(
 			storageRepos.FolderClosureRepo,
 			storageService,
 		),
+		GetFolderArchiveViaShare: sharingqry.NewGetFolderArchiveViaShareQuery(
+			repos.ShareLinkRepo,
+			repos.ShareLinkAccessRepo,
+			storageRepos.FolderRepo,
+			archiveService,
+		),
+		GetPreviewViaShare: sharingqry.NewGetPreviewViaShareQuery(
+			repos.ShareLinkRepo,
+			repos.ShareLinkAccessRepo,
+			storageRepos.FileRepo,
+			storageRepos.FileVersionRepo,
+			storageRepos.FolderClosureRepo,
+			filePreviewService,
+		),
 	}
 }
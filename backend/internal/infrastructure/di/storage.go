@@ -5,6 +5,7 @@ import (
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/database"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/markdown"
 	infraRepo "github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/repository"
 	storagecmd "github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/command"
 	storageqry "github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/query"
@@ -13,22 +14,29 @@ import (
 // StorageUseCases はStorage関連のUseCaseを保持します
 type StorageUseCases struct {
 	// Folder Commands
-	CreateFolder *storagecmd.CreateFolderCommand
-	RenameFolder *storagecmd.RenameFolderCommand
-	MoveFolder   *storagecmd.MoveFolderCommand
-	DeleteFolder *storagecmd.DeleteFolderCommand
+	CreateFolder  *storagecmd.CreateFolderCommand
+	RenameFolder  *storagecmd.RenameFolderCommand
+	MoveFolder    *storagecmd.MoveFolderCommand
+	DeleteFolder  *storagecmd.DeleteFolderCommand
+	RestoreFolder *storagecmd.RestoreFolderCommand
 
 	// Folder Queries
 	GetFolder          *storageqry.GetFolderQuery
 	ListFolderContents *storageqry.ListFolderContentsQuery
 	GetAncestors       *storageqry.GetAncestorsQuery
+	GetFolderArchive   *storageqry.GetFolderArchiveQuery
 
 	// File Commands
 	InitiateUpload        *storagecmd.InitiateUploadCommand
+	InitiateVersionUpload *storagecmd.InitiateVersionUploadCommand
 	CompleteUpload        *storagecmd.CompleteUploadCommand
+	FinalizeUpload        *storagecmd.FinalizeUploadCommand
+	UploadPart            *storagecmd.UploadPartCommand
+	WriteUploadChunk      *storagecmd.WriteUploadChunkCommand
 	AbortUpload           *storagecmd.AbortUploadCommand
 	RenameFile            *storagecmd.RenameFileCommand
 	MoveFile              *storagecmd.MoveFileCommand
+	RestoreFileVersion    *storagecmd.RestoreFileVersionCommand
 	TrashFile             *storagecmd.TrashFileCommand
 	RestoreFile           *storagecmd.RestoreFileCommand
 	PermanentlyDeleteFile *storagecmd.PermanentlyDeleteFileCommand
@@ -37,8 +45,47 @@ type StorageUseCases struct {
 	// File Queries
 	GetDownloadURL   *storageqry.GetDownloadURLQuery
 	GetUploadStatus  *storageqry.GetUploadStatusQuery
+	GetUploadOffset  *storageqry.GetUploadOffsetQuery
+	GetThumbnail     *storageqry.GetThumbnailQuery
+	GetFilePreview   *storageqry.GetFilePreviewQuery
 	ListFileVersions *storageqry.ListFileVersionsQuery
 	ListTrash        *storageqry.ListTrashQuery
+
+	// Copy Commands
+	CopyFile   *storagecmd.CopyFileCommand
+	CopyFolder *storagecmd.CopyFolderCommand
+
+	// Copy Jobs
+	StartFolderCopy *storagecmd.StartFolderCopyCommand
+	RunCopyJobs     *storagecmd.RunCopyJobsCommand
+	GetCopyJob      *storageqry.GetCopyJobQuery
+
+	// Thumbnail Jobs
+	RunThumbnailJobs *storagecmd.RunThumbnailJobsCommand
+
+	// Upload Verifications
+	RunUploadVerifications *storagecmd.RunUploadVerificationsCommand
+
+	// Bulk Commands
+	BulkMove   *storagecmd.BulkMoveCommand
+	BulkTrash  *storagecmd.BulkTrashCommand
+	BulkCopy   *storagecmd.BulkCopyCommand
+	BulkDelete *storagecmd.BulkDeleteCommand
+
+	// Search Queries
+	Search *storageqry.SearchQuery
+
+	// Shared Queries
+	ListSharedWithMe *storageqry.ListSharedWithMeQuery
+
+	// Quota Queries
+	GetStorageUsage    *storageqry.GetStorageUsageQuery
+	ListDuplicateFiles *storageqry.ListDuplicateFilesQuery
+
+	// Version Retention
+	SetVersionRetentionPolicy *storagecmd.SetVersionRetentionPolicyCommand
+	PinFileVersion            *storagecmd.PinFileVersionCommand
+	GetVersionRetentionPolicy *storageqry.GetVersionRetentionPolicyQuery
 }
 
 // StorageRepositories はStorage関連のリポジトリを保持します
@@ -49,8 +96,17 @@ type StorageRepositories struct {
 	FileVersionRepo         repository.FileVersionRepository
 	ArchivedFileRepo        repository.ArchivedFileRepository
 	ArchivedFileVersionRepo repository.ArchivedFileVersionRepository
+	ArchivedFolderRepo      repository.ArchivedFolderRepository
+	ArchivedSubfolderRepo   repository.ArchivedSubfolderRepository
 	UploadSessionRepo       repository.UploadSessionRepository
 	UploadPartRepo          repository.UploadPartRepository
+	SearchRepo              repository.SearchRepository
+	StorageQuotaRepo        repository.StorageQuotaRepository
+	StorageUsageRepo        repository.StorageUsageRepository
+	VersionRetentionRepo    repository.VersionRetentionPolicyRepository
+	CopyJobRepo             repository.CopyJobRepository
+	BlobRepo                repository.BlobRepository
+	ThumbnailRepo           repository.ThumbnailRepository
 }
 
 // NewStorageRepositories は新しいStorageRepositoriesを作成します
@@ -62,49 +118,143 @@ func NewStorageRepositories(txManager *database.TxManager) *StorageRepositories
 		FileVersionRepo:         infraRepo.NewFileVersionRepository(txManager),
 		ArchivedFileRepo:        infraRepo.NewArchivedFileRepository(txManager),
 		ArchivedFileVersionRepo: infraRepo.NewArchivedFileVersionRepository(txManager),
+		ArchivedFolderRepo:      infraRepo.NewArchivedFolderRepository(txManager),
+		ArchivedSubfolderRepo:   infraRepo.NewArchivedSubfolderRepository(txManager),
 		UploadSessionRepo:       infraRepo.NewUploadSessionRepository(txManager),
 		UploadPartRepo:          infraRepo.NewUploadPartRepository(txManager),
+		SearchRepo:              infraRepo.NewSearchRepository(txManager),
+		StorageQuotaRepo:        infraRepo.NewStorageQuotaRepository(txManager),
+		StorageUsageRepo:        infraRepo.NewStorageUsageRepository(txManager),
+		VersionRetentionRepo:    infraRepo.NewVersionRetentionPolicyRepository(txManager),
+		CopyJobRepo:             infraRepo.NewCopyJobRepository(txManager),
+		BlobRepo:                infraRepo.NewBlobRepository(txManager),
+		ThumbnailRepo:           infraRepo.NewThumbnailRepository(txManager),
 	}
 }
 
 // NewStorageUseCases は新しいStorageUseCasesを作成します
-func NewStorageUseCases(repos *StorageRepositories, userRepo repository.UserRepository, relationshipRepo authz.RelationshipRepository, permissionResolver authz.PermissionResolver, txManager repository.TransactionManager, storageService service.StorageService) *StorageUseCases {
-	return &StorageUseCases{
+func NewStorageUseCases(repos *StorageRepositories, userRepo repository.UserRepository, relationshipRepo authz.RelationshipRepository, permissionGrantRepo authz.PermissionGrantRepository, membershipRepo repository.MembershipRepository, permissionResolver authz.PermissionResolver, txManager repository.TransactionManager, storageService service.StorageService, defaultUserQuotaBytes int64) *StorageUseCases {
+	quotaService := service.NewStorageQuotaService(repos.StorageQuotaRepo, repos.StorageUsageRepo, defaultUserQuotaBytes)
+	archiveService := service.NewFolderArchiveService(repos.FolderRepo, repos.FolderClosureRepo, repos.FileRepo, repos.FileVersionRepo, storageService)
+	blobService := service.NewBlobService(repos.BlobRepo, storageService)
+	resumableUploadService := service.NewResumableUploadService(repos.UploadPartRepo, storageService)
+	thumbnailService := service.NewThumbnailService(repos.ThumbnailRepo, storageService)
+	filePreviewService := service.NewFilePreviewService(storageService, markdown.NewRenderer())
+
+	uc := &StorageUseCases{
 		// Folder Commands
 		CreateFolder: storagecmd.NewCreateFolderCommand(repos.FolderRepo, repos.FolderClosureRepo, relationshipRepo, permissionResolver, txManager),
-		RenameFolder: storagecmd.NewRenameFolderCommand(repos.FolderRepo, userRepo),
+		RenameFolder: storagecmd.NewRenameFolderCommand(repos.FolderRepo, userRepo, permissionResolver),
 		MoveFolder:   storagecmd.NewMoveFolderCommand(repos.FolderRepo, repos.FolderClosureRepo, txManager, userRepo, permissionResolver),
 		DeleteFolder: storagecmd.NewDeleteFolderCommand(
 			repos.FolderRepo,
 			repos.FolderClosureRepo,
 			repos.FileRepo,
 			repos.FileVersionRepo,
+			repos.ArchivedFolderRepo,
+			repos.ArchivedSubfolderRepo,
 			repos.ArchivedFileRepo,
 			repos.ArchivedFileVersionRepo,
 			txManager,
 			userRepo,
+			permissionResolver,
+		),
+		RestoreFolder: storagecmd.NewRestoreFolderCommand(
+			repos.FolderRepo,
+			repos.FolderClosureRepo,
+			repos.FileRepo,
+			repos.FileVersionRepo,
+			repos.ArchivedFolderRepo,
+			repos.ArchivedSubfolderRepo,
+			repos.ArchivedFileRepo,
+			repos.ArchivedFileVersionRepo,
+			relationshipRepo,
+			userRepo,
+			permissionResolver,
+			txManager,
 		),
 
 		// Folder Queries
 		GetFolder:          storageqry.NewGetFolderQuery(repos.FolderRepo, permissionResolver),
-		ListFolderContents: storageqry.NewListFolderContentsQuery(repos.FolderRepo, repos.FileRepo),
-		GetAncestors:       storageqry.NewGetAncestorsQuery(repos.FolderRepo, repos.FolderClosureRepo),
+		ListFolderContents: storageqry.NewListFolderContentsQuery(repos.FolderRepo, repos.FileRepo, permissionResolver),
+		GetAncestors:       storageqry.NewGetAncestorsQuery(repos.FolderRepo, repos.FolderClosureRepo, permissionResolver),
+		GetFolderArchive:   storageqry.NewGetFolderArchiveQuery(repos.FolderRepo, archiveService, permissionResolver),
 
 		// File Commands
-		InitiateUpload:        storagecmd.NewInitiateUploadCommand(repos.FileRepo, repos.FolderRepo, repos.UploadSessionRepo, storageService, txManager),
-		CompleteUpload:        storagecmd.NewCompleteUploadCommand(repos.FileRepo, repos.FileVersionRepo, repos.UploadSessionRepo, repos.UploadPartRepo, txManager),
+		InitiateUpload:        storagecmd.NewInitiateUploadCommand(repos.FileRepo, repos.FolderRepo, repos.UploadSessionRepo, storageService, quotaService, permissionResolver, txManager),
+		InitiateVersionUpload: storagecmd.NewInitiateVersionUploadCommand(repos.FileRepo, repos.UploadSessionRepo, storageService, quotaService, permissionResolver),
+		CompleteUpload:        storagecmd.NewCompleteUploadCommand(repos.FileRepo, repos.FileVersionRepo, repos.UploadSessionRepo, repos.UploadPartRepo, repos.StorageUsageRepo, blobService, storageService, thumbnailService, txManager),
 		AbortUpload:           storagecmd.NewAbortUploadCommand(repos.UploadSessionRepo, repos.FileRepo, storageService, txManager),
-		RenameFile:            storagecmd.NewRenameFileCommand(repos.FileRepo),
-		MoveFile:              storagecmd.NewMoveFileCommand(repos.FileRepo, repos.FolderRepo, permissionResolver),
-		TrashFile:             storagecmd.NewTrashFileCommand(repos.FileRepo, repos.FileVersionRepo, repos.FolderRepo, repos.FolderClosureRepo, repos.ArchivedFileRepo, repos.ArchivedFileVersionRepo, txManager),
-		RestoreFile:           storagecmd.NewRestoreFileCommand(repos.FileRepo, repos.FileVersionRepo, repos.FolderRepo, repos.ArchivedFileRepo, repos.ArchivedFileVersionRepo, userRepo, txManager),
-		PermanentlyDeleteFile: storagecmd.NewPermanentlyDeleteFileCommand(repos.ArchivedFileRepo, repos.ArchivedFileVersionRepo, storageService, txManager),
-		EmptyTrash:            storagecmd.NewEmptyTrashCommand(repos.ArchivedFileRepo, repos.ArchivedFileVersionRepo, storageService, txManager),
+		RenameFile:            storagecmd.NewRenameFileCommand(repos.FileRepo, permissionResolver),
+		MoveFile:              storagecmd.NewMoveFileCommand(repos.FileRepo, repos.FileVersionRepo, repos.FolderRepo, repos.StorageUsageRepo, storageService, blobService, quotaService, permissionResolver, txManager),
+		RestoreFileVersion:    storagecmd.NewRestoreFileVersionCommand(repos.FileRepo, repos.FileVersionRepo, repos.StorageUsageRepo, storageService, blobService, quotaService, permissionResolver, txManager),
+		TrashFile:             storagecmd.NewTrashFileCommand(repos.FileRepo, repos.FileVersionRepo, repos.FolderRepo, repos.FolderClosureRepo, repos.ArchivedFileRepo, repos.ArchivedFileVersionRepo, permissionResolver, txManager),
+		RestoreFile:           storagecmd.NewRestoreFileCommand(repos.FileRepo, repos.FileVersionRepo, repos.FolderRepo, repos.ArchivedFileRepo, repos.ArchivedFileVersionRepo, repos.StorageUsageRepo, storageService, blobService, quotaService, userRepo, permissionResolver, txManager),
+		PermanentlyDeleteFile: storagecmd.NewPermanentlyDeleteFileCommand(repos.ArchivedFileRepo, repos.ArchivedFileVersionRepo, repos.StorageUsageRepo, storageService, blobService, txManager),
+		EmptyTrash:            storagecmd.NewEmptyTrashCommand(repos.ArchivedFileRepo, repos.ArchivedFileVersionRepo, repos.ArchivedFolderRepo, repos.ArchivedSubfolderRepo, repos.StorageUsageRepo, storageService, blobService, txManager),
 
 		// File Queries
-		GetDownloadURL:   storageqry.NewGetDownloadURLQuery(repos.FileRepo, repos.FileVersionRepo, storageService),
+		GetDownloadURL:   storageqry.NewGetDownloadURLQuery(repos.FileRepo, repos.FileVersionRepo, storageService, permissionResolver),
 		GetUploadStatus:  storageqry.NewGetUploadStatusQuery(repos.UploadSessionRepo),
-		ListFileVersions: storageqry.NewListFileVersionsQuery(repos.FileRepo, repos.FileVersionRepo),
-		ListTrash:        storageqry.NewListTrashQuery(repos.ArchivedFileRepo),
+		GetUploadOffset:  storageqry.NewGetUploadOffsetQuery(repos.UploadSessionRepo, resumableUploadService),
+		GetThumbnail:     storageqry.NewGetThumbnailQuery(repos.FileRepo, repos.FileVersionRepo, repos.ThumbnailRepo, thumbnailService, storageService, permissionResolver),
+		GetFilePreview:   storageqry.NewGetFilePreviewQuery(repos.FileRepo, repos.FileVersionRepo, filePreviewService, permissionResolver),
+		ListFileVersions: storageqry.NewListFileVersionsQuery(repos.FileRepo, repos.FileVersionRepo, permissionResolver),
+		ListTrash:        storageqry.NewListTrashQuery(repos.ArchivedFileRepo, repos.ArchivedFolderRepo),
+
+		// Search Queries
+		Search: storageqry.NewSearchQuery(repos.SearchRepo, repos.FolderRepo, permissionResolver),
+
+		// Shared Queries
+		ListSharedWithMe: storageqry.NewListSharedWithMeQuery(permissionGrantRepo, membershipRepo, repos.FileRepo, repos.FolderRepo, userRepo),
+
+		// Quota Queries
+		GetStorageUsage:    storageqry.NewGetStorageUsageQuery(quotaService, repos.StorageQuotaRepo, repos.StorageUsageRepo),
+		ListDuplicateFiles: storageqry.NewListDuplicateFilesQuery(repos.BlobRepo),
+
+		// Version Retention
+		SetVersionRetentionPolicy: storagecmd.NewSetVersionRetentionPolicyCommand(repos.FolderRepo, repos.VersionRetentionRepo),
+		PinFileVersion:            storagecmd.NewPinFileVersionCommand(repos.FileRepo, repos.FileVersionRepo, permissionResolver),
+		GetVersionRetentionPolicy: storageqry.NewGetVersionRetentionPolicyQuery(repos.FolderRepo, repos.VersionRetentionRepo),
 	}
+
+	// Client-finalized / Server-proxied / Resumable (tus) Upload
+	uc.FinalizeUpload = storagecmd.NewFinalizeUploadCommand(repos.UploadSessionRepo, repos.UploadPartRepo, storageService, blobService, uc.CompleteUpload)
+	uc.UploadPart = storagecmd.NewUploadPartCommand(repos.UploadSessionRepo, repos.UploadPartRepo, storageService, uc.CompleteUpload, txManager)
+	uc.WriteUploadChunk = storagecmd.NewWriteUploadChunkCommand(repos.UploadSessionRepo, resumableUploadService, uc.CompleteUpload, txManager)
+
+	// Copy Commands
+	uc.CopyFile = storagecmd.NewCopyFileCommand(repos.FileRepo, repos.FileVersionRepo, repos.FolderRepo, repos.StorageUsageRepo, storageService, blobService, quotaService, permissionResolver, txManager)
+	uc.CopyFolder = storagecmd.NewCopyFolderCommand(
+		repos.FolderRepo,
+		repos.FolderClosureRepo,
+		repos.FileRepo,
+		repos.FileVersionRepo,
+		repos.StorageUsageRepo,
+		relationshipRepo,
+		storageService,
+		blobService,
+		quotaService,
+		permissionResolver,
+		txManager,
+	)
+
+	// Copy Jobs（大きなフォルダのコピーはジョブとして登録し、ワーカーで実行）
+	uc.StartFolderCopy = storagecmd.NewStartFolderCopyCommand(uc.CopyFolder, repos.CopyJobRepo)
+	uc.RunCopyJobs = storagecmd.NewRunCopyJobsCommand(uc.CopyFolder, repos.CopyJobRepo)
+	uc.GetCopyJob = storageqry.NewGetCopyJobQuery(repos.CopyJobRepo)
+
+	// Thumbnail Jobs（画像のアップロード完了時に登録し、ワーカーで生成）
+	uc.RunThumbnailJobs = storagecmd.NewRunThumbnailJobsCommand(repos.ThumbnailRepo, thumbnailService)
+
+	// Upload Verifications（MinIOがチェックサムを記録していないアップロードの内容をワーカーで再計算）
+	uc.RunUploadVerifications = storagecmd.NewRunUploadVerificationsCommand(repos.UploadSessionRepo, uc.CompleteUpload)
+
+	// Bulk Commands（単体操作のコマンドを再利用）
+	uc.BulkMove = storagecmd.NewBulkMoveCommand(uc.MoveFile, uc.MoveFolder, txManager)
+	uc.BulkTrash = storagecmd.NewBulkTrashCommand(uc.TrashFile, uc.DeleteFolder, txManager)
+	uc.BulkCopy = storagecmd.NewBulkCopyCommand(uc.CopyFile, uc.CopyFolder)
+	uc.BulkDelete = storagecmd.NewBulkDeleteCommand(uc.PermanentlyDeleteFile, uc.DeleteFolder)
+
+	return uc
 }
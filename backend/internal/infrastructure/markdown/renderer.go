@@ -0,0 +1,96 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+)
+
+// Renderer はgoldmarkでMarkdownをHTMLに変換し、bluemondayで無害化するservice.MarkdownRendererの実装です
+// 生のHTMLは出力せず、リンクはhttp、https、mailto、相対URLのみ許可します
+// 画像は外部リソースの読み込みを避けるため、代替テキストのリンクとして出力します
+type Renderer struct {
+	markdown goldmark.Markdown
+	policy   *bluemonday.Policy
+}
+
+// NewRenderer は新しいRendererを作成します
+func NewRenderer() *Renderer {
+	return &Renderer{
+		markdown: goldmark.New(
+			goldmark.WithExtensions(
+				// 無害化でstyle属性を取り除くため、列の揃えはalign属性で出力する
+				extension.NewTable(extension.WithTableCellAlignMethod(extension.TableCellAlignAttribute)),
+				extension.Strikethrough,
+				extension.Linkify,
+			),
+			goldmark.WithRendererOptions(
+				renderer.WithNodeRenderers(util.Prioritized(&imageLinkRenderer{}, 100)),
+			),
+		),
+		policy: newPolicy(),
+	}
+}
+
+// Render はMarkdownを危険な要素を含まないHTMLに変換します
+func (r *Renderer) Render(src string) (string, error) {
+	var buf bytes.Buffer
+	if err := r.markdown.Convert([]byte(src), &buf); err != nil {
+		return "", fmt.Errorf("failed to render markdown: %w", err)
+	}
+	return r.policy.Sanitize(buf.String()), nil
+}
+
+// newPolicy はMarkdownの変換結果に含まれる要素のみを許可するポリシーを作成します
+// goldmarkは生のHTMLを出力しないが、変換結果も信頼せずに無害化する
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.NewPolicy()
+	p.AllowElements(
+		"h1", "h2", "h3", "h4", "h5", "h6", "p", "br", "hr", "blockquote",
+		"ul", "ol", "li", "pre", "code", "strong", "em", "del",
+		"table", "thead", "tbody", "tr", "th", "td",
+	)
+	p.AllowAttrs("start").Matching(bluemonday.Integer).OnElements("ol")
+	p.AllowAttrs("align").Matching(regexp.MustCompile(`^(left|center|right)$`)).OnElements("th", "td")
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[A-Za-z0-9_+-]+$`)).OnElements("code")
+
+	p.AllowAttrs("href").OnElements("a")
+	p.AllowURLSchemes("http", "https", "mailto")
+	p.AllowRelativeURLs(true)
+	p.RequireParseableURLs(true)
+	p.RequireNoFollowOnLinks(true)
+	p.RequireNoReferrerOnLinks(true)
+	return p
+}
+
+// imageLinkRenderer は画像を読み込まずに、代替テキストを画像URLへのリンクとして出力します
+type imageLinkRenderer struct{}
+
+// RegisterFuncs は画像ノードの出力を登録します
+func (r *imageLinkRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindImage, r.renderImage)
+}
+
+func (r *imageLinkRenderer) renderImage(w util.BufWriter, _ []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		_, _ = w.WriteString("</a>")
+		return ast.WalkContinue, nil
+	}
+	n := node.(*ast.Image)
+	_, _ = w.WriteString(`<a href="`)
+	_, _ = w.Write(util.EscapeHTML(util.URLEscape(n.Destination, true)))
+	_, _ = w.WriteString(`">`)
+	return ast.WalkContinue, nil
+}
+
+// インターフェースの実装を保証
+var _ service.MarkdownRenderer = (*Renderer)(nil)
@@ -0,0 +1,52 @@
+package markdown_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/markdown"
+)
+
+func TestRenderer_Render_CommonMarkAndTables(t *testing.T) {
+	content := "# Title\n\nSome **bold**, ~~old~~ and `code`.\n\n- one\n- two\n\n" +
+		"```go\nfmt.Println(\"<hi>\")\n```\n\n| a | b |\n|---|--:|\n| 1 | 2 |\n"
+
+	html, err := markdown.NewRenderer().Render(content)
+
+	require.NoError(t, err)
+	assert.Contains(t, html, "<h1>Title</h1>")
+	assert.Contains(t, html, "<p>Some <strong>bold</strong>, <del>old</del> and <code>code</code>.</p>")
+	assert.Contains(t, html, "<ul>\n<li>one</li>\n<li>two</li>\n</ul>")
+	assert.Contains(t, html, `<pre><code class="language-go">fmt.Println(&#34;&lt;hi&gt;&#34;)`)
+	assert.Contains(t, html, "<table>")
+	assert.Contains(t, html, `<td align="right">2</td>`)
+}
+
+func TestRenderer_Render_RemovesRawHTMLAndUnsafeLinks(t *testing.T) {
+	content := "<script>alert(1)</script>\n\n" +
+		"[safe](https://example.com) [unsafe](javascript:alert(1)) [relative](docs/guide.md)\n\n" +
+		"<img src=x onerror=alert(1)>\n\n" +
+		"<a href=\"https://example.com\" onclick=\"alert(1)\">inline</a>"
+
+	html, err := markdown.NewRenderer().Render(content)
+
+	require.NoError(t, err)
+	assert.NotContains(t, html, "<script")
+	assert.NotContains(t, html, "alert(1)")
+	assert.NotContains(t, html, "<img")
+	assert.NotContains(t, html, "onclick")
+	assert.NotContains(t, html, "javascript:")
+	assert.Contains(t, html, `<a href="https://example.com" rel="nofollow noreferrer">safe</a>`)
+	assert.Contains(t, html, "unsafe")
+	assert.Contains(t, html, `<a href="docs/guide.md" rel="nofollow noreferrer">relative</a>`)
+}
+
+func TestRenderer_Render_ImageBecomesLink(t *testing.T) {
+	html, err := markdown.NewRenderer().Render("![tracker](https://example.com/t.png)\n")
+
+	require.NoError(t, err)
+	assert.NotContains(t, html, "<img")
+	assert.Contains(t, html, `<a href="https://example.com/t.png" rel="nofollow noreferrer">tracker</a>`)
+}
@@ -47,6 +47,7 @@ func (r *ArchivedFileRepository) Create(ctx context.Context, archivedFile *entit
 		ArchivedAt:       archivedFile.ArchivedAt,
 		ArchivedBy:       archivedFile.ArchivedBy,
 		ExpiresAt:        archivedFile.ExpiresAt,
+		ArchivedFolderID: uuidToPgtype(archivedFile.ArchivedFolderID),
 	})
 
 	return r.HandleError(err)
@@ -120,19 +121,18 @@ func (r *ArchivedFileRepository) FindByOriginalFileID(ctx context.Context, origi
 }
 
 // FindByOwnerWithPagination はオーナーのアーカイブファイルをページネーションで検索します
-func (r *ArchivedFileRepository) FindByOwnerWithPagination(ctx context.Context, ownerID uuid.UUID, limit int, cursor *uuid.UUID) ([]*entity.ArchivedFile, error) {
+// フォルダごとゴミ箱に移動されたファイルは含まれません
+func (r *ArchivedFileRepository) FindByOwnerWithPagination(ctx context.Context, ownerID uuid.UUID, limit int, cursor *repository.TrashCursor) ([]*entity.ArchivedFile, error) {
 	querier := r.Querier(ctx)
 	queries := sqlcgen.New(querier)
 
-	var cursorID pgtype.UUID
-	if cursor != nil {
-		cursorID = pgtype.UUID{Bytes: *cursor, Valid: true}
-	}
+	cursorArchivedAt, cursorID := trashCursorToPgtype(cursor)
 
 	rows, err := queries.ListArchivedFilesByOwnerWithPagination(ctx, sqlcgen.ListArchivedFilesByOwnerWithPaginationParams{
-		OwnerID:  ownerID,
-		Limit:    int32(limit),
-		CursorID: cursorID,
+		OwnerID:          ownerID,
+		Limit:            int32(limit),
+		CursorArchivedAt: cursorArchivedAt,
+		CursorID:         cursorID,
 	})
 	if err != nil {
 		return nil, r.HandleError(err)
@@ -141,6 +141,19 @@ func (r *ArchivedFileRepository) FindByOwnerWithPagination(ctx context.Context,
 	return r.toEntities(rows), nil
 }
 
+// FindByArchivedFolderID はアーカイブフォルダに含まれるアーカイブファイルを検索します
+func (r *ArchivedFileRepository) FindByArchivedFolderID(ctx context.Context, archivedFolderID uuid.UUID) ([]*entity.ArchivedFile, error) {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	rows, err := queries.ListArchivedFilesByArchivedFolderID(ctx, pgtype.UUID{Bytes: archivedFolderID, Valid: true})
+	if err != nil {
+		return nil, r.HandleError(err)
+	}
+
+	return r.toEntities(rows), nil
+}
+
 // toEntity はsqlcgen.ArchivedFileをentity.ArchivedFileに変換します
 func (r *ArchivedFileRepository) toEntity(row sqlcgen.ArchivedFile) *entity.ArchivedFile {
 	name, _ := valueobject.NewFileName(row.Name)
@@ -161,6 +174,7 @@ func (r *ArchivedFileRepository) toEntity(row sqlcgen.ArchivedFile) *entity.Arch
 		row.ArchivedAt,
 		row.ArchivedBy,
 		row.ExpiresAt,
+		pgtypeToUUID(row.ArchivedFolderID),
 	)
 }
 
@@ -173,5 +187,13 @@ func (r *ArchivedFileRepository) toEntities(rows []sqlcgen.ArchivedFile) []*enti
 	return entities
 }
 
+// trashCursorToPgtype はゴミ箱一覧のカーソルをクエリパラメータに変換します
+func trashCursorToPgtype(cursor *repository.TrashCursor) (pgtype.Timestamptz, pgtype.UUID) {
+	if cursor == nil {
+		return pgtype.Timestamptz{}, pgtype.UUID{}
+	}
+	return pgtype.Timestamptz{Time: cursor.ArchivedAt, Valid: true}, pgtype.UUID{Bytes: cursor.ID, Valid: true}
+}
+
 // インターフェースの実装を保証
 var _ repository.ArchivedFileRepository = (*ArchivedFileRepository)(nil)
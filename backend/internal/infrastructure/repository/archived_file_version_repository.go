@@ -7,6 +7,7 @@ import (
 
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/database"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/database/sqlcgen"
 )
@@ -39,10 +40,13 @@ func (r *ArchivedFileVersionRepository) BulkCreate(ctx context.Context, versions
 			ArchivedFileID:    v.ArchivedFileID,
 			OriginalVersionID: v.OriginalVersionID,
 			VersionNumber:     int32(v.VersionNumber),
+			StorageKey:        v.StorageKey.String(),
 			MinioVersionID:    v.MinioVersionID,
+			BlobID:            uuidToPgtype(v.BlobID),
 			Size:              v.Size,
 			Checksum:          v.Checksum,
 			UploadedBy:        v.UploadedBy,
+			IsPinned:          v.IsPinned,
 			CreatedAt:         v.CreatedAt,
 		}
 	}
@@ -76,15 +80,20 @@ func (r *ArchivedFileVersionRepository) DeleteByArchivedFileID(ctx context.Conte
 
 // toEntity はsqlcgen.ArchivedFileVersionをentity.ArchivedFileVersionに変換します
 func (r *ArchivedFileVersionRepository) toEntity(row sqlcgen.ArchivedFileVersion) *entity.ArchivedFileVersion {
+	storageKey, _ := valueobject.NewStorageKeyFromString(row.StorageKey)
+
 	return entity.ReconstructArchivedFileVersion(
 		row.ID,
 		row.ArchivedFileID,
 		row.OriginalVersionID,
 		int(row.VersionNumber),
+		storageKey,
 		row.MinioVersionID,
+		pgtypeToUUID(row.BlobID),
 		row.Size,
 		row.Checksum,
 		row.UploadedBy,
+		row.IsPinned,
 		row.CreatedAt,
 	)
 }
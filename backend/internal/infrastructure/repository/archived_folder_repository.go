@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/database"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/database/sqlcgen"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// ArchivedFolderRepository はアーカイブフォルダリポジトリの実装です
+type ArchivedFolderRepository struct {
+	*database.BaseRepository
+}
+
+// NewArchivedFolderRepository は新しいArchivedFolderRepositoryを作成します
+func NewArchivedFolderRepository(txManager *database.TxManager) *ArchivedFolderRepository {
+	return &ArchivedFolderRepository{
+		BaseRepository: database.NewBaseRepository(txManager),
+	}
+}
+
+// Create はアーカイブフォルダを作成します
+func (r *ArchivedFolderRepository) Create(ctx context.Context, archivedFolder *entity.ArchivedFolder) error {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	_, err := queries.CreateArchivedFolder(ctx, sqlcgen.CreateArchivedFolderParams{
+		ID:               archivedFolder.ID,
+		OriginalFolderID: archivedFolder.OriginalFolderID,
+		OriginalParentID: uuidToPgtype(archivedFolder.OriginalParentID),
+		OriginalPath:     archivedFolder.OriginalPath,
+		Name:             archivedFolder.Name.String(),
+		OwnerID:          archivedFolder.OwnerID,
+		CreatedBy:        archivedFolder.CreatedBy,
+		ArchivedAt:       archivedFolder.ArchivedAt,
+		ArchivedBy:       archivedFolder.ArchivedBy,
+		ExpiresAt:        archivedFolder.ExpiresAt,
+	})
+
+	return r.HandleError(err)
+}
+
+// FindByID はIDでアーカイブフォルダを検索します
+func (r *ArchivedFolderRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.ArchivedFolder, error) {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	row, err := queries.GetArchivedFolderByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NewNotFoundError("archived folder")
+		}
+		return nil, r.HandleError(err)
+	}
+
+	return r.toEntity(row), nil
+}
+
+// Delete はアーカイブフォルダを削除します
+func (r *ArchivedFolderRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	err := queries.DeleteArchivedFolder(ctx, id)
+	return r.HandleError(err)
+}
+
+// FindByOwner はオーナーのアーカイブフォルダを検索します
+func (r *ArchivedFolderRepository) FindByOwner(ctx context.Context, ownerID uuid.UUID) ([]*entity.ArchivedFolder, error) {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	rows, err := queries.ListArchivedFoldersByOwner(ctx, ownerID)
+	if err != nil {
+		return nil, r.HandleError(err)
+	}
+
+	return r.toEntities(rows), nil
+}
+
+// FindByOwnerWithPagination はオーナーのアーカイブフォルダをページネーションで検索します
+func (r *ArchivedFolderRepository) FindByOwnerWithPagination(ctx context.Context, ownerID uuid.UUID, limit int, cursor *repository.TrashCursor) ([]*entity.ArchivedFolder, error) {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	cursorArchivedAt, cursorID := trashCursorToPgtype(cursor)
+
+	rows, err := queries.ListArchivedFoldersByOwnerWithPagination(ctx, sqlcgen.ListArchivedFoldersByOwnerWithPaginationParams{
+		OwnerID:          ownerID,
+		Limit:            int32(limit),
+		CursorArchivedAt: cursorArchivedAt,
+		CursorID:         cursorID,
+	})
+	if err != nil {
+		return nil, r.HandleError(err)
+	}
+
+	return r.toEntities(rows), nil
+}
+
+// FindExpired は期限切れのアーカイブフォルダを検索します
+func (r *ArchivedFolderRepository) FindExpired(ctx context.Context) ([]*entity.ArchivedFolder, error) {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	rows, err := queries.ListExpiredArchivedFolders(ctx)
+	if err != nil {
+		return nil, r.HandleError(err)
+	}
+
+	return r.toEntities(rows), nil
+}
+
+// toEntity はsqlcgen.ArchivedFolderをentity.ArchivedFolderに変換します
+func (r *ArchivedFolderRepository) toEntity(row sqlcgen.ArchivedFolder) *entity.ArchivedFolder {
+	name, _ := valueobject.NewFolderName(row.Name)
+
+	return entity.ReconstructArchivedFolder(
+		row.ID,
+		row.OriginalFolderID,
+		pgtypeToUUID(row.OriginalParentID),
+		row.OriginalPath,
+		name,
+		row.OwnerID,
+		row.CreatedBy,
+		row.ArchivedAt,
+		row.ArchivedBy,
+		row.ExpiresAt,
+	)
+}
+
+// toEntities はsqlcgen.ArchivedFolder配列をentity.ArchivedFolder配列に変換します
+func (r *ArchivedFolderRepository) toEntities(rows []sqlcgen.ArchivedFolder) []*entity.ArchivedFolder {
+	entities := make([]*entity.ArchivedFolder, len(rows))
+	for i, row := range rows {
+		entities[i] = r.toEntity(row)
+	}
+	return entities
+}
+
+// インターフェースの実装を保証
+var _ repository.ArchivedFolderRepository = (*ArchivedFolderRepository)(nil)
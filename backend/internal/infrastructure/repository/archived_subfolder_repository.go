@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/database"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/database/sqlcgen"
+)
+
+// ArchivedSubfolderRepository はアーカイブフォルダのサブツリーを保持するリポジトリの実装です
+type ArchivedSubfolderRepository struct {
+	*database.BaseRepository
+}
+
+// NewArchivedSubfolderRepository は新しいArchivedSubfolderRepositoryを作成します
+func NewArchivedSubfolderRepository(txManager *database.TxManager) *ArchivedSubfolderRepository {
+	return &ArchivedSubfolderRepository{
+		BaseRepository: database.NewBaseRepository(txManager),
+	}
+}
+
+// BulkCreate はアーカイブサブフォルダを一括作成します
+func (r *ArchivedSubfolderRepository) BulkCreate(ctx context.Context, subfolders []*entity.ArchivedSubfolder) error {
+	if len(subfolders) == 0 {
+		return nil
+	}
+
+	querier := r.Querier(ctx)
+
+	// CopyFrom を使用してバルクインサート
+	params := make([]sqlcgen.CreateArchivedSubfoldersBulkParams, len(subfolders))
+	for i, s := range subfolders {
+		params[i] = sqlcgen.CreateArchivedSubfoldersBulkParams{
+			ID:               s.ID,
+			ArchivedFolderID: s.ArchivedFolderID,
+			OriginalFolderID: s.OriginalFolderID,
+			OriginalParentID: s.OriginalParentID,
+			Name:             s.Name.String(),
+			OwnerID:          s.OwnerID,
+			CreatedBy:        s.CreatedBy,
+			RelativeDepth:    int32(s.RelativeDepth),
+		}
+	}
+
+	queries := sqlcgen.New(querier)
+	_, err := queries.CreateArchivedSubfoldersBulk(ctx, params)
+	return r.HandleError(err)
+}
+
+// FindByArchivedFolderID はアーカイブフォルダIDでサブフォルダを浅い順に検索します
+func (r *ArchivedSubfolderRepository) FindByArchivedFolderID(ctx context.Context, archivedFolderID uuid.UUID) ([]*entity.ArchivedSubfolder, error) {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	rows, err := queries.ListArchivedSubfoldersByArchivedFolderID(ctx, archivedFolderID)
+	if err != nil {
+		return nil, r.HandleError(err)
+	}
+
+	entities := make([]*entity.ArchivedSubfolder, len(rows))
+	for i, row := range rows {
+		entities[i] = r.toEntity(row)
+	}
+	return entities, nil
+}
+
+// DeleteByArchivedFolderID はアーカイブフォルダIDで全サブフォルダを削除します
+func (r *ArchivedSubfolderRepository) DeleteByArchivedFolderID(ctx context.Context, archivedFolderID uuid.UUID) error {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	err := queries.DeleteArchivedSubfoldersByArchivedFolderID(ctx, archivedFolderID)
+	return r.HandleError(err)
+}
+
+// toEntity はsqlcgen.ArchivedSubfolderをentity.ArchivedSubfolderに変換します
+func (r *ArchivedSubfolderRepository) toEntity(row sqlcgen.ArchivedSubfolder) *entity.ArchivedSubfolder {
+	name, _ := valueobject.NewFolderName(row.Name)
+
+	return entity.ReconstructArchivedSubfolder(
+		row.ID,
+		row.ArchivedFolderID,
+		row.OriginalFolderID,
+		row.OriginalParentID,
+		name,
+		row.OwnerID,
+		row.CreatedBy,
+		int(row.RelativeDepth),
+	)
+}
+
+// インターフェースの実装を保証
+var _ repository.ArchivedSubfolderRepository = (*ArchivedSubfolderRepository)(nil)
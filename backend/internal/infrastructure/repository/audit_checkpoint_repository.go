@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/database"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/database/sqlcgen"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// AuditCheckpointRepository は監査ログチェックポイントリポジトリの実装です
+type AuditCheckpointRepository struct {
+	*database.BaseRepository
+}
+
+// NewAuditCheckpointRepository は新しいAuditCheckpointRepositoryを作成します
+func NewAuditCheckpointRepository(txManager *database.TxManager) *AuditCheckpointRepository {
+	return &AuditCheckpointRepository{
+		BaseRepository: database.NewBaseRepository(txManager),
+	}
+}
+
+// Create はチェックポイントを作成します（同じ日のチェックポイントが既にある場合は何もしません）
+func (r *AuditCheckpointRepository) Create(ctx context.Context, checkpoint *entity.AuditCheckpoint) error {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	err := queries.CreateAuditCheckpoint(ctx, sqlcgen.CreateAuditCheckpointParams{
+		ID:        checkpoint.ID,
+		Day:       pgtype.Date{Time: checkpoint.Day, Valid: true},
+		LastSeq:   checkpoint.LastSequence,
+		LastHash:  checkpoint.LastHash,
+		KeyID:     checkpoint.KeyID,
+		Signature: checkpoint.Signature,
+		CreatedAt: checkpoint.CreatedAt,
+	})
+
+	return r.HandleError(err)
+}
+
+// FindLatest は最新のチェックポイントを取得します
+func (r *AuditCheckpointRepository) FindLatest(ctx context.Context) (*entity.AuditCheckpoint, error) {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	row, err := queries.GetLatestAuditCheckpoint(ctx)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NewNotFoundError("audit checkpoint")
+		}
+		return nil, r.HandleError(err)
+	}
+
+	return r.toEntity(row), nil
+}
+
+// List はすべてのチェックポイントを日付の昇順で取得します
+func (r *AuditCheckpointRepository) List(ctx context.Context) ([]*entity.AuditCheckpoint, error) {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	rows, err := queries.ListAuditCheckpoints(ctx)
+	if err != nil {
+		return nil, r.HandleError(err)
+	}
+
+	checkpoints := make([]*entity.AuditCheckpoint, len(rows))
+	for i, row := range rows {
+		checkpoints[i] = r.toEntity(row)
+	}
+	return checkpoints, nil
+}
+
+// toEntity はsqlcgen.AuditCheckpointをentity.AuditCheckpointに変換します
+func (r *AuditCheckpointRepository) toEntity(row sqlcgen.AuditCheckpoint) *entity.AuditCheckpoint {
+	return &entity.AuditCheckpoint{
+		ID:           row.ID,
+		Day:          entity.AuditCheckpointDay(row.Day.Time),
+		LastSequence: row.LastSeq,
+		LastHash:     row.LastHash,
+		KeyID:        row.KeyID,
+		Signature:    row.Signature,
+		CreatedAt:    row.CreatedAt,
+	}
+}
+
+// インターフェースの実装を保証
+var _ repository.AuditCheckpointRepository = (*AuditCheckpointRepository)(nil)
@@ -3,16 +3,25 @@ package repository
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/database"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/database/sqlcgen"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
 )
 
+// auditLogChainLockKey はハッシュチェーンへの追記を排他するアドバイザリロックのキーです
+const auditLogChainLockKey int64 = 0x617564697463686e // "auditchn"
+
 // AuditLogRepository は監査ログリポジトリの実装です
 type AuditLogRepository struct {
 	*database.BaseRepository
@@ -64,7 +73,13 @@ func (r *AuditLogRepository) Create(ctx context.Context, log *entity.AuditLog) e
 		requestID = &log.RequestID
 	}
 
+	var chainSeq *int64
+	if log.IsChained() {
+		chainSeq = &log.Sequence
+	}
+
 	_, err := queries.CreateAuditLog(ctx, sqlcgen.CreateAuditLogParams{
+		ID:           log.ID,
 		UserID:       userID,
 		Action:       string(log.Action),
 		ResourceType: string(log.ResourceType),
@@ -73,11 +88,68 @@ func (r *AuditLogRepository) Create(ctx context.Context, log *entity.AuditLog) e
 		IpAddress:    ipAddress,
 		UserAgent:    userAgent,
 		RequestID:    requestID,
+		ChainSeq:     chainSeq,
+		PrevHash:     log.PrevHash,
+		Hash:         log.Hash,
+		CreatedAt:    log.CreatedAt,
 	})
 
 	return r.HandleError(err)
 }
 
+// LockChainHead はハッシュチェーンへの追記をトランザクション終了まで排他し、チェーン末尾のエントリを取得します
+// Note: 複数のAPIインスタンスから追記されてもSequenceが欠番・重複しないよう、アドバイザリロックで直列化する
+func (r *AuditLogRepository) LockChainHead(ctx context.Context) (*entity.AuditLog, error) {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	if err := queries.LockAuditLogChain(ctx, auditLogChainLockKey); err != nil {
+		return nil, r.HandleError(err)
+	}
+
+	row, err := queries.GetAuditLogChainHead(ctx)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NewNotFoundError("audit log chain head")
+		}
+		return nil, r.HandleError(err)
+	}
+
+	return r.toEntity(row), nil
+}
+
+// FindChainHeadBefore は指定日時より前に記録されたエントリのうちチェーン末尾のものを取得します
+func (r *AuditLogRepository) FindChainHeadBefore(ctx context.Context, before time.Time) (*entity.AuditLog, error) {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	row, err := queries.GetAuditLogChainHeadBefore(ctx, before)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NewNotFoundError("audit log chain head")
+		}
+		return nil, r.HandleError(err)
+	}
+
+	return r.toEntity(row), nil
+}
+
+// ListChainAfter は指定Sequenceより後のチェーン上のエントリをSequenceの昇順で取得します
+func (r *AuditLogRepository) ListChainAfter(ctx context.Context, afterSequence int64, limit int) ([]*entity.AuditLog, error) {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	rows, err := queries.ListAuditLogChainAfter(ctx, sqlcgen.ListAuditLogChainAfterParams{
+		AfterSeq: afterSequence,
+		LimitVal: int32(limit),
+	})
+	if err != nil {
+		return nil, r.HandleError(err)
+	}
+
+	return r.toEntities(rows), nil
+}
+
 // ListByUserID はユーザーIDで監査ログを取得します
 func (r *AuditLogRepository) ListByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entity.AuditLog, error) {
 	querier := r.Querier(ctx)
@@ -126,6 +198,66 @@ func (r *AuditLogRepository) CountByUserID(ctx context.Context, userID uuid.UUID
 	return int(count), nil
 }
 
+// Search は条件に一致する監査ログを記録日時の降順で取得します
+// Note: 条件が動的に変わるため、sqlcではなくパラメータ化した生SQLを組み立てる
+func (r *AuditLogRepository) Search(ctx context.Context, criteria repository.AuditLogCriteria) ([]*entity.AuditLog, error) {
+	querier := r.Querier(ctx)
+	b := &searchQueryBuilder{}
+
+	var conds []string
+	if criteria.ActorID != nil {
+		conds = append(conds, "user_id = "+b.arg(*criteria.ActorID))
+	}
+	if criteria.ActionPrefix != "" {
+		conds = append(conds, "action LIKE "+b.arg(prefixPattern(criteria.ActionPrefix))+` ESCAPE '\'`)
+	}
+	if criteria.ResourceType != nil {
+		conds = append(conds, "resource_type = "+b.arg(string(*criteria.ResourceType)))
+	}
+	if criteria.ResourceID != nil {
+		conds = append(conds, "resource_id = "+b.arg(*criteria.ResourceID))
+	}
+	if criteria.From != nil {
+		conds = append(conds, "created_at >= "+b.arg(*criteria.From))
+	}
+	if criteria.To != nil {
+		conds = append(conds, "created_at < "+b.arg(*criteria.To))
+	}
+	if criteria.RequestID != "" {
+		conds = append(conds, "request_id = "+b.arg(criteria.RequestID))
+	}
+	if criteria.Cursor != nil {
+		conds = append(conds, fmt.Sprintf("(created_at, id) < (%s, %s)", b.arg(criteria.Cursor.CreatedAt), b.arg(criteria.Cursor.ID)))
+	}
+
+	sql := `SELECT id, user_id, action, resource_type, resource_id, details, ip_address, user_agent, request_id, chain_seq, prev_hash, hash, created_at
+FROM audit_logs`
+	if len(conds) > 0 {
+		sql += " WHERE " + strings.Join(conds, " AND ")
+	}
+	sql += fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT %s", b.arg(criteria.Limit))
+
+	rows, err := querier.Query(ctx, sql, b.args...)
+	if err != nil {
+		return nil, r.HandleError(err)
+	}
+	defer rows.Close()
+
+	logs := make([]*entity.AuditLog, 0, criteria.Limit)
+	for rows.Next() {
+		var row sqlcgen.AuditLog
+		if err := rows.Scan(&row.ID, &row.UserID, &row.Action, &row.ResourceType, &row.ResourceID, &row.Details, &row.IpAddress, &row.UserAgent, &row.RequestID, &row.ChainSeq, &row.PrevHash, &row.Hash, &row.CreatedAt); err != nil {
+			return nil, r.HandleError(err)
+		}
+		logs = append(logs, r.toEntity(row))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.HandleError(err)
+	}
+
+	return logs, nil
+}
+
 // toEntities はsqlcgen.AuditLogのスライスをentity.AuditLogのスライスに変換します
 func (r *AuditLogRepository) toEntities(rows []sqlcgen.AuditLog) []*entity.AuditLog {
 	entities := make([]*entity.AuditLog, len(rows))
@@ -169,6 +301,11 @@ func (r *AuditLogRepository) toEntity(row sqlcgen.AuditLog) *entity.AuditLog {
 		requestID = *row.RequestID
 	}
 
+	var sequence int64
+	if row.ChainSeq != nil {
+		sequence = *row.ChainSeq
+	}
+
 	return &entity.AuditLog{
 		ID:           row.ID,
 		UserID:       userID,
@@ -179,6 +316,9 @@ func (r *AuditLogRepository) toEntity(row sqlcgen.AuditLog) *entity.AuditLog {
 		IPAddress:    ipAddress,
 		UserAgent:    userAgent,
 		RequestID:    requestID,
+		Sequence:     sequence,
+		PrevHash:     row.PrevHash,
+		Hash:         row.Hash,
 		CreatedAt:    row.CreatedAt,
 	}
 }
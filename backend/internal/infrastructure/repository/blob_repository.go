@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/database"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/database/sqlcgen"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// BlobRepository はブロブリポジトリの実装です
+type BlobRepository struct {
+	*database.BaseRepository
+}
+
+// NewBlobRepository は新しいBlobRepositoryを作成します
+func NewBlobRepository(txManager *database.TxManager) *BlobRepository {
+	return &BlobRepository{
+		BaseRepository: database.NewBaseRepository(txManager),
+	}
+}
+
+// Acquire は同じチェックサムのブロブの参照数を増やすか、新しいブロブを登録します
+func (r *BlobRepository) Acquire(ctx context.Context, blob *entity.Blob) (*entity.Blob, error) {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	row, err := queries.AcquireBlob(ctx, sqlcgen.AcquireBlobParams{
+		ID:             blob.ID,
+		Checksum:       blob.Checksum,
+		StorageKey:     blob.StorageKey.String(),
+		MinioVersionID: blob.MinioVersionID,
+		Size:           blob.Size,
+		RefCount:       int32(blob.RefCount),
+		CreatedAt:      blob.CreatedAt,
+	})
+	if err != nil {
+		return nil, r.HandleError(err)
+	}
+
+	return r.toEntity(row), nil
+}
+
+// FindByID はIDでブロブを取得します
+func (r *BlobRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Blob, error) {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	row, err := queries.GetBlobByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NewNotFoundError("blob")
+		}
+		return nil, r.HandleError(err)
+	}
+
+	return r.toEntity(row), nil
+}
+
+// IncrementRefCount は参照数を1増やします
+func (r *BlobRepository) IncrementRefCount(ctx context.Context, id uuid.UUID) (*entity.Blob, error) {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	row, err := queries.IncrementBlobRefCount(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NewNotFoundError("blob")
+		}
+		return nil, r.HandleError(err)
+	}
+
+	return r.toEntity(row), nil
+}
+
+// DecrementRefCount は参照数を1減らします
+func (r *BlobRepository) DecrementRefCount(ctx context.Context, id uuid.UUID) (*entity.Blob, error) {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	row, err := queries.DecrementBlobRefCount(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NewNotFoundError("blob")
+		}
+		return nil, r.HandleError(err)
+	}
+
+	return r.toEntity(row), nil
+}
+
+// Delete は参照されていないブロブを削除します
+func (r *BlobRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	err := queries.DeleteBlob(ctx, id)
+	return r.HandleError(err)
+}
+
+// IsObjectVersionReferenced はオブジェクトバージョンが参照されているかを判定します
+func (r *BlobRepository) IsObjectVersionReferenced(ctx context.Context, storageKey valueobject.StorageKey, versionID string) (bool, error) {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	referenced, err := queries.IsObjectVersionReferenced(ctx, sqlcgen.IsObjectVersionReferencedParams{
+		StorageKey:     storageKey.String(),
+		MinioVersionID: versionID,
+	})
+	if err != nil {
+		return false, r.HandleError(err)
+	}
+	return referenced, nil
+}
+
+// FindDuplicateFilesByOwner は内容が重複している所有者のファイルを取得します
+func (r *BlobRepository) FindDuplicateFilesByOwner(ctx context.Context, ownerID uuid.UUID) ([]*repository.DuplicateFile, error) {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	rows, err := queries.ListDuplicateFilesByOwner(ctx, ownerID)
+	if err != nil {
+		return nil, r.HandleError(err)
+	}
+
+	duplicates := make([]*repository.DuplicateFile, len(rows))
+	for i, row := range rows {
+		name, _ := valueobject.NewFileName(row.Name)
+		mimeType, _ := valueobject.NewMimeType(row.MimeType)
+		storageKey, _ := valueobject.NewStorageKeyFromString(row.StorageKey)
+
+		duplicates[i] = &repository.DuplicateFile{
+			Checksum: row.Checksum,
+			File: entity.ReconstructFile(
+				row.ID,
+				row.FolderID,
+				row.OwnerID,
+				row.CreatedBy,
+				name,
+				mimeType,
+				row.Size,
+				storageKey,
+				int(row.CurrentVersion),
+				entity.FileStatus(row.Status),
+				row.CreatedAt,
+				row.UpdatedAt,
+			),
+		}
+	}
+	return duplicates, nil
+}
+
+// toEntity はsqlcgen.Blobをentity.Blobに変換します
+func (r *BlobRepository) toEntity(row sqlcgen.Blob) *entity.Blob {
+	storageKey, _ := valueobject.NewStorageKeyFromString(row.StorageKey)
+
+	return entity.ReconstructBlob(
+		row.ID,
+		row.Checksum,
+		storageKey,
+		row.MinioVersionID,
+		row.Size,
+		int(row.RefCount),
+		row.CreatedAt,
+	)
+}
+
+// インターフェースの実装を保証
+var _ repository.BlobRepository = (*BlobRepository)(nil)
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/database"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/database/sqlcgen"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// CopyJobRepository は非同期コピージョブリポジトリの実装です
+type CopyJobRepository struct {
+	*database.BaseRepository
+}
+
+// NewCopyJobRepository は新しいCopyJobRepositoryを作成します
+func NewCopyJobRepository(txManager *database.TxManager) *CopyJobRepository {
+	return &CopyJobRepository{
+		BaseRepository: database.NewBaseRepository(txManager),
+	}
+}
+
+// Create はコピージョブを登録します
+func (r *CopyJobRepository) Create(ctx context.Context, job *entity.CopyJob) error {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	err := queries.CreateCopyJob(ctx, sqlcgen.CreateCopyJobParams{
+		ID:                  job.ID,
+		UserID:              job.UserID,
+		SourceFolderID:      job.SourceFolderID,
+		DestinationFolderID: job.DestinationFolderID,
+		Status:              string(job.Status),
+		ResultFolderID:      uuidToPgtype(job.ResultFolderID),
+		FolderCount:         int32(job.FolderCount),
+		FileCount:           int32(job.FileCount),
+		ErrorMessage:        job.ErrorMessage,
+		CreatedAt:           job.CreatedAt,
+		StartedAt:           timeToPgtype(job.StartedAt),
+		FinishedAt:          timeToPgtype(job.FinishedAt),
+	})
+
+	return r.HandleError(err)
+}
+
+// FindByID はIDでコピージョブを取得します
+func (r *CopyJobRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.CopyJob, error) {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	row, err := queries.GetCopyJobByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NewNotFoundError("copy job")
+		}
+		return nil, r.HandleError(err)
+	}
+
+	return r.toEntity(row), nil
+}
+
+// Update はコピージョブの状態を更新します
+func (r *CopyJobRepository) Update(ctx context.Context, job *entity.CopyJob) error {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	err := queries.UpdateCopyJob(ctx, sqlcgen.UpdateCopyJobParams{
+		ID:             job.ID,
+		Status:         string(job.Status),
+		ResultFolderID: uuidToPgtype(job.ResultFolderID),
+		FolderCount:    int32(job.FolderCount),
+		FileCount:      int32(job.FileCount),
+		ErrorMessage:   job.ErrorMessage,
+		StartedAt:      timeToPgtype(job.StartedAt),
+		FinishedAt:     timeToPgtype(job.FinishedAt),
+	})
+
+	return r.HandleError(err)
+}
+
+// ClaimNextPending は最も古い実行待ちのコピージョブを実行中にし、leaseTokenでリースを取得します
+func (r *CopyJobRepository) ClaimNextPending(ctx context.Context, leaseToken uuid.UUID) (*entity.CopyJob, error) {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	row, err := queries.ClaimNextPendingCopyJob(ctx, uuidToPgtype(&leaseToken))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NewNotFoundError("pending copy job")
+		}
+		return nil, r.HandleError(err)
+	}
+
+	return r.toEntity(row), nil
+}
+
+// Heartbeat はleaseTokenがリースを保持している実行中のジョブのハートビートを更新します
+func (r *CopyJobRepository) Heartbeat(ctx context.Context, id, leaseToken uuid.UUID) (bool, error) {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	rows, err := queries.HeartbeatCopyJob(ctx, sqlcgen.HeartbeatCopyJobParams{
+		ID:         id,
+		LeaseToken: uuidToPgtype(&leaseToken),
+	})
+	if err != nil {
+		return false, r.HandleError(err)
+	}
+
+	return rows > 0, nil
+}
+
+// FailRunningHeartbeatBefore はハートビートが指定日時より前で途絶えている実行中のジョブを失敗にし、件数を返します
+func (r *CopyJobRepository) FailRunningHeartbeatBefore(ctx context.Context, before time.Time, message string) (int64, error) {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	rows, err := queries.FailRunningCopyJobsHeartbeatBefore(ctx, sqlcgen.FailRunningCopyJobsHeartbeatBeforeParams{
+		ErrorMessage:    &message,
+		HeartbeatBefore: pgtype.Timestamptz{Time: before, Valid: true},
+	})
+	if err != nil {
+		return 0, r.HandleError(err)
+	}
+
+	return rows, nil
+}
+
+// toEntity はsqlcgen.CopyJobをentity.CopyJobに変換します
+func (r *CopyJobRepository) toEntity(row sqlcgen.CopyJob) *entity.CopyJob {
+	return entity.ReconstructCopyJob(
+		row.ID,
+		row.UserID,
+		row.SourceFolderID,
+		row.DestinationFolderID,
+		entity.CopyJobStatus(row.Status),
+		pgtypeToUUID(row.ResultFolderID),
+		int(row.FolderCount),
+		int(row.FileCount),
+		row.ErrorMessage,
+		row.CreatedAt,
+		pgtypeToTime(row.StartedAt),
+		pgtypeToTime(row.FinishedAt),
+		pgtypeToUUID(row.LeaseToken),
+		pgtypeToTime(row.HeartbeatAt),
+	)
+}
+
+// timeToPgtype は*time.Timeをpgtype.Timestamptzに変換します
+func timeToPgtype(t *time.Time) pgtype.Timestamptz {
+	if t == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: *t, Valid: true}
+}
+
+// pgtypeToTime はpgtype.Timestamptzを*time.Timeに変換します
+func pgtypeToTime(pg pgtype.Timestamptz) *time.Time {
+	if !pg.Valid {
+		return nil
+	}
+	t := pg.Time
+	return &t
+}
+
+// インターフェースの実装を保証
+var _ repository.CopyJobRepository = (*CopyJobRepository)(nil)
@@ -9,6 +9,7 @@ import (
 
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/database"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/database/sqlcgen"
 	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
@@ -40,10 +41,13 @@ func (r *FileVersionRepository) Create(ctx context.Context, version *entity.File
 		ID:             version.ID,
 		FileID:         version.FileID,
 		VersionNumber:  int32(version.VersionNumber),
+		StorageKey:     version.StorageKey.String(),
 		MinioVersionID: minioVersionID,
+		BlobID:         uuidToPgtype(version.BlobID),
 		Size:           version.Size,
 		Checksum:       version.Checksum,
 		UploadedBy:     version.UploadedBy,
+		IsPinned:       version.IsPinned,
 		CreatedAt:      version.CreatedAt,
 	})
 
@@ -142,10 +146,13 @@ func (r *FileVersionRepository) BulkCreate(ctx context.Context, versions []*enti
 			ID:             v.ID,
 			FileID:         v.FileID,
 			VersionNumber:  int32(v.VersionNumber),
+			StorageKey:     v.StorageKey.String(),
 			MinioVersionID: minioVersionID,
+			BlobID:         uuidToPgtype(v.BlobID),
 			Size:           v.Size,
 			Checksum:       v.Checksum,
 			UploadedBy:     v.UploadedBy,
+			IsPinned:       v.IsPinned,
 			CreatedAt:      v.CreatedAt,
 		}
 	}
@@ -194,21 +201,53 @@ func (r *FileVersionRepository) CountByFileID(ctx context.Context, fileID uuid.U
 	return int(count), nil
 }
 
+// UpdatePinned はバージョンのピン留め状態を更新します
+func (r *FileVersionRepository) UpdatePinned(ctx context.Context, id uuid.UUID, pinned bool) error {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	err := queries.UpdateFileVersionPinned(ctx, sqlcgen.UpdateFileVersionPinnedParams{
+		ID:       id,
+		IsPinned: pinned,
+	})
+	return r.HandleError(err)
+}
+
+// FindFileIDsWithPrunableVersions は削除候補のバージョンを持つファイルIDをID順に取得します
+func (r *FileVersionRepository) FindFileIDsWithPrunableVersions(ctx context.Context, afterID uuid.UUID, limit int) ([]uuid.UUID, error) {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	ids, err := queries.ListFileIDsWithPrunableVersions(ctx, sqlcgen.ListFileIDsWithPrunableVersionsParams{
+		AfterID:    afterID,
+		LimitCount: int32(limit),
+	})
+	if err != nil {
+		return nil, r.HandleError(err)
+	}
+
+	return ids, nil
+}
+
 // toEntity はsqlcgen.FileVersionをentity.FileVersionに変換します
 func (r *FileVersionRepository) toEntity(row sqlcgen.FileVersion) *entity.FileVersion {
 	minioVersionID := ""
 	if row.MinioVersionID != nil {
 		minioVersionID = *row.MinioVersionID
 	}
+	storageKey, _ := valueobject.NewStorageKeyFromString(row.StorageKey)
 
 	return entity.ReconstructFileVersion(
 		row.ID,
 		row.FileID,
 		int(row.VersionNumber),
+		storageKey,
 		minioVersionID,
+		pgtypeToUUID(row.BlobID),
 		row.Size,
 		row.Checksum,
 		row.UploadedBy,
+		row.IsPinned,
 		row.CreatedAt,
 	)
 }
@@ -120,6 +120,23 @@ func (r *FolderRepository) FindRootByOwner(ctx context.Context, ownerID uuid.UUI
 	return r.toEntities(rows), nil
 }
 
+// FindByIDs は複数IDでフォルダを検索します
+func (r *FolderRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.Folder, error) {
+	if len(ids) == 0 {
+		return []*entity.Folder{}, nil
+	}
+
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	rows, err := queries.ListFoldersByIDs(ctx, ids)
+	if err != nil {
+		return nil, r.HandleError(err)
+	}
+
+	return r.toEntities(rows), nil
+}
+
 // FindByOwner はオーナーの全フォルダを検索します
 func (r *FolderRepository) FindByOwner(ctx context.Context, ownerID uuid.UUID) ([]*entity.Folder, error) {
 	querier := r.Querier(ctx)
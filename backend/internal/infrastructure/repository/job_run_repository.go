@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/database"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/database/sqlcgen"
+)
+
+// JobRunRepository はバックグラウンドジョブ実行履歴リポジトリの実装です
+type JobRunRepository struct {
+	*database.BaseRepository
+}
+
+// NewJobRunRepository は新しいJobRunRepositoryを作成します
+func NewJobRunRepository(txManager *database.TxManager) *JobRunRepository {
+	return &JobRunRepository{
+		BaseRepository: database.NewBaseRepository(txManager),
+	}
+}
+
+// Create は実行履歴を記録します
+func (r *JobRunRepository) Create(ctx context.Context, run *entity.JobRun) error {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	err := queries.CreateJobRun(ctx, sqlcgen.CreateJobRunParams{
+		ID:           run.ID,
+		JobName:      run.JobName,
+		InstanceID:   run.InstanceID,
+		Status:       string(run.Status),
+		ErrorMessage: run.ErrorMessage,
+		StartedAt:    run.StartedAt,
+		FinishedAt:   run.FinishedAt,
+	})
+
+	return r.HandleError(err)
+}
+
+// FindRecentByJobName はジョブの直近の実行履歴を取得します
+func (r *JobRunRepository) FindRecentByJobName(ctx context.Context, jobName string, limit int) ([]*entity.JobRun, error) {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	rows, err := queries.ListJobRunsByJobName(ctx, sqlcgen.ListJobRunsByJobNameParams{
+		JobName: jobName,
+		Limit:   int32(limit),
+	})
+	if err != nil {
+		return nil, r.HandleError(err)
+	}
+
+	runs := make([]*entity.JobRun, 0, len(rows))
+	for _, row := range rows {
+		runs = append(runs, r.toEntity(row))
+	}
+	return runs, nil
+}
+
+// DeleteStartedBefore は指定日時より前に開始された実行履歴を削除します
+func (r *JobRunRepository) DeleteStartedBefore(ctx context.Context, before time.Time) (int64, error) {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	count, err := queries.DeleteJobRunsStartedBefore(ctx, before)
+	if err != nil {
+		return 0, r.HandleError(err)
+	}
+	return count, nil
+}
+
+// toEntity はsqlcgen.JobRunをentity.JobRunに変換します
+func (r *JobRunRepository) toEntity(row sqlcgen.JobRun) *entity.JobRun {
+	return entity.ReconstructJobRun(
+		row.ID,
+		row.JobName,
+		row.InstanceID,
+		entity.JobRunStatus(row.Status),
+		row.ErrorMessage,
+		row.StartedAt,
+		row.FinishedAt,
+	)
+}
+
+// インターフェースの実装を保証
+var _ repository.JobRunRepository = (*JobRunRepository)(nil)
@@ -0,0 +1,16 @@
+package repository
+
+import "strings"
+
+// likeEscaper はLIKEのワイルドカードとエスケープ文字をエスケープします（ESCAPE '\' と組み合わせて使用）
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// likePattern はLIKE用のワイルドカードをエスケープした部分一致パターンを返します
+func likePattern(q string) string {
+	return "%" + likeEscaper.Replace(q) + "%"
+}
+
+// prefixPattern はLIKE用のワイルドカードをエスケープした前方一致パターンを返します
+func prefixPattern(q string) string {
+	return likeEscaper.Replace(q) + "%"
+}
@@ -0,0 +1,259 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/database"
+)
+
+// MIMEカテゴリ判定用の正規表現（valueobject.categorizeFromMimeType と同じ規則）
+const (
+	documentSubtypePattern = `(pdf|msword|document|spreadsheet|presentation|json|xml)`
+	archiveSubtypePattern  = `(zip|tar|gzip|rar|7z|compress)`
+)
+
+// SearchRepository はファイル・フォルダ横断検索リポジトリの実装です
+// Note: 条件が動的に変わるため、sqlcではなくパラメータ化した生SQLを組み立てる
+type SearchRepository struct {
+	*database.BaseRepository
+}
+
+// NewSearchRepository は新しいSearchRepositoryを作成します
+func NewSearchRepository(txManager *database.TxManager) *SearchRepository {
+	return &SearchRepository{
+		BaseRepository: database.NewBaseRepository(txManager),
+	}
+}
+
+// searchQueryBuilder はプレースホルダ番号を管理しながらSQL引数を蓄積します
+type searchQueryBuilder struct {
+	args []any
+}
+
+func (b *searchQueryBuilder) arg(v any) string {
+	b.args = append(b.args, v)
+	return fmt.Sprintf("$%d", len(b.args))
+}
+
+// Search は条件に一致するアクティブなファイル・フォルダを更新日時の降順で取得します
+func (r *SearchRepository) Search(ctx context.Context, criteria repository.SearchCriteria) ([]*repository.SearchResult, error) {
+	querier := r.Querier(ctx)
+	b := &searchQueryBuilder{}
+
+	includeFiles := criteria.ItemType == nil || *criteria.ItemType == repository.SearchItemTypeFile
+	includeFolders := criteria.ItemType == nil || *criteria.ItemType == repository.SearchItemTypeFolder
+	// ファイル固有の条件が指定された場合はフォルダを除外
+	if criteria.MimeCategory != nil || criteria.MinSize != nil || criteria.MaxSize != nil {
+		includeFolders = false
+	}
+	if !includeFiles && !includeFolders {
+		return []*repository.SearchResult{}, nil
+	}
+
+	// 候補は閲覧者が所有・権限付与されたリソースに限定する（閲覧者自身の結果が他ユーザーの候補に埋もれないように）
+	viewer := b.arg(criteria.ViewerID)
+
+	var selects []string
+	if includeFiles {
+		selects = append(selects, r.buildFileSelect(b, criteria, viewer))
+	}
+	if includeFolders {
+		selects = append(selects, r.buildFolderSelect(b, criteria))
+	}
+
+	sql := viewerScopeCTE(viewer) + `
+SELECT item_type, id, parent_id, owner_id, created_by, name, mime_type, size, storage_key, current_version, status, depth, created_at, updated_at
+FROM (` + strings.Join(selects, "\nUNION ALL\n") + `) items`
+	if criteria.Cursor != nil {
+		sql += fmt.Sprintf(" WHERE (updated_at, id) < (%s, %s)", b.arg(criteria.Cursor.UpdatedAt), b.arg(criteria.Cursor.ID))
+	}
+	sql += fmt.Sprintf(" ORDER BY updated_at DESC, id DESC LIMIT %s", b.arg(criteria.Limit))
+
+	rows, err := querier.Query(ctx, sql, b.args...)
+	if err != nil {
+		return nil, r.HandleError(err)
+	}
+	defer rows.Close()
+
+	results := make([]*repository.SearchResult, 0, criteria.Limit)
+	for rows.Next() {
+		var (
+			itemType       string
+			id             uuid.UUID
+			parentID       *uuid.UUID
+			ownerID        uuid.UUID
+			createdBy      uuid.UUID
+			name           string
+			mimeType       string
+			size           int64
+			storageKey     string
+			currentVersion int32
+			status         string
+			depth          int32
+			createdAt      time.Time
+			updatedAt      time.Time
+		)
+		if err := rows.Scan(&itemType, &id, &parentID, &ownerID, &createdBy, &name, &mimeType, &size, &storageKey, &currentVersion, &status, &depth, &createdAt, &updatedAt); err != nil {
+			return nil, r.HandleError(err)
+		}
+
+		if repository.SearchItemType(itemType) == repository.SearchItemTypeFolder {
+			folderName, _ := valueobject.NewFolderName(name)
+			results = append(results, &repository.SearchResult{
+				Type: repository.SearchItemTypeFolder,
+				Folder: entity.ReconstructFolder(
+					id,
+					folderName,
+					parentID,
+					ownerID,
+					createdBy,
+					int(depth),
+					entity.FolderStatus(status),
+					createdAt,
+					updatedAt,
+				),
+			})
+			continue
+		}
+
+		fileName, _ := valueobject.NewFileName(name)
+		mt, _ := valueobject.NewMimeType(mimeType)
+		key, _ := valueobject.NewStorageKeyFromString(storageKey)
+		results = append(results, &repository.SearchResult{
+			Type: repository.SearchItemTypeFile,
+			File: entity.ReconstructFile(
+				id,
+				*parentID,
+				ownerID,
+				createdBy,
+				fileName,
+				mt,
+				size,
+				key,
+				int(currentVersion),
+				entity.FileStatus(status),
+				createdAt,
+				updatedAt,
+			),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.HandleError(err)
+	}
+
+	return results, nil
+}
+
+// buildFileSelect はファイル検索のSELECT句を組み立てます
+func (r *SearchRepository) buildFileSelect(b *searchQueryBuilder, criteria repository.SearchCriteria, viewer string) string {
+	conds := []string{
+		"f.status = 'active'",
+		"(f.folder_id IN (SELECT id FROM viewer_folders) OR f.owner_id = " + viewer +
+			" OR f.id IN (SELECT resource_id FROM viewer_grants WHERE resource_type = 'file'))",
+	}
+	if criteria.Query != "" {
+		conds = append(conds, "f.name ILIKE "+b.arg(likePattern(criteria.Query))+` ESCAPE '\'`)
+	}
+	if criteria.MimeCategory != nil {
+		conds = append(conds, mimeCategoryCondition(*criteria.MimeCategory))
+	}
+	if criteria.MinSize != nil {
+		conds = append(conds, "f.size >= "+b.arg(*criteria.MinSize))
+	}
+	if criteria.MaxSize != nil {
+		conds = append(conds, "f.size <= "+b.arg(*criteria.MaxSize))
+	}
+	if criteria.OwnerID != nil {
+		conds = append(conds, "f.owner_id = "+b.arg(*criteria.OwnerID))
+	}
+	if criteria.ModifiedAfter != nil {
+		conds = append(conds, "f.updated_at >= "+b.arg(*criteria.ModifiedAfter))
+	}
+	if criteria.ModifiedBefore != nil {
+		conds = append(conds, "f.updated_at <= "+b.arg(*criteria.ModifiedBefore))
+	}
+	if criteria.FolderID != nil {
+		// 閉包テーブルで自身を含むサブツリー内のファイルに限定
+		conds = append(conds, "f.folder_id IN (SELECT descendant_id FROM folder_paths WHERE ancestor_id = "+b.arg(*criteria.FolderID)+")")
+	}
+
+	return `SELECT 'file' AS item_type, f.id, f.folder_id AS parent_id, f.owner_id, f.created_by, f.name,
+	f.mime_type, f.size, f.storage_key, f.current_version, f.status::text AS status, 0 AS depth, f.created_at, f.updated_at
+FROM files f WHERE ` + strings.Join(conds, " AND ")
+}
+
+// buildFolderSelect はフォルダ検索のSELECT句を組み立てます
+func (r *SearchRepository) buildFolderSelect(b *searchQueryBuilder, criteria repository.SearchCriteria) string {
+	conds := []string{"d.status = 'active'", "d.id IN (SELECT id FROM viewer_folders)"}
+	if criteria.Query != "" {
+		conds = append(conds, "d.name ILIKE "+b.arg(likePattern(criteria.Query))+` ESCAPE '\'`)
+	}
+	if criteria.OwnerID != nil {
+		conds = append(conds, "d.owner_id = "+b.arg(*criteria.OwnerID))
+	}
+	if criteria.ModifiedAfter != nil {
+		conds = append(conds, "d.updated_at >= "+b.arg(*criteria.ModifiedAfter))
+	}
+	if criteria.ModifiedBefore != nil {
+		conds = append(conds, "d.updated_at <= "+b.arg(*criteria.ModifiedBefore))
+	}
+	if criteria.FolderID != nil {
+		// 起点フォルダ自身は含めない
+		conds = append(conds, "d.id IN (SELECT descendant_id FROM folder_paths WHERE ancestor_id = "+b.arg(*criteria.FolderID)+" AND path_length > 0)")
+	}
+
+	return `SELECT 'folder' AS item_type, d.id, d.parent_id, d.owner_id, d.created_by, d.name,
+	'' AS mime_type, 0::bigint AS size, '' AS storage_key, 0 AS current_version, d.status::text AS status, d.depth, d.created_at, d.updated_at
+FROM folders d WHERE ` + strings.Join(conds, " AND ")
+}
+
+// viewerScopeCTE は閲覧者が閲覧し得るリソースを表すCTEを返します
+// viewer_grants はユーザー自身またはグループに付与された権限、
+// viewer_folders は所有するフォルダと権限付与されたフォルダのサブツリーです
+// Note: 権限の継承はフォルダ階層のみのため、閉包テーブルで展開すれば十分。最終的な判定はPermissionResolverで行う
+func viewerScopeCTE(viewer string) string {
+	return `WITH viewer_grants AS (
+	SELECT pg.resource_type, pg.resource_id FROM permission_grants pg
+	WHERE (pg.grantee_type = 'user' AND pg.grantee_id = ` + viewer + `)
+		OR (pg.grantee_type = 'group' AND pg.grantee_id IN (SELECT m.group_id FROM memberships m WHERE m.user_id = ` + viewer + `))
+), viewer_roots AS (
+	SELECT id FROM folders WHERE owner_id = ` + viewer + `
+	UNION
+	SELECT resource_id FROM viewer_grants WHERE resource_type = 'folder'
+), viewer_folders AS (
+	SELECT fp.descendant_id AS id FROM folder_paths fp WHERE fp.ancestor_id IN (SELECT id FROM viewer_roots)
+)`
+}
+
+// mimeCategoryCondition はMIMEカテゴリに対応するSQL条件を返します
+// Note: 条件は定数のみで構成され、ユーザー入力は埋め込まない
+func mimeCategoryCondition(category valueobject.MimeCategory) string {
+	document := `(f.mime_type LIKE 'text/%' OR (f.mime_type LIKE 'application/%' AND split_part(f.mime_type, '/', 2) ~ '` + documentSubtypePattern + `'))`
+	archive := `(f.mime_type LIKE 'application/%' AND split_part(f.mime_type, '/', 2) !~ '` + documentSubtypePattern + `' AND split_part(f.mime_type, '/', 2) ~ '` + archiveSubtypePattern + `')`
+
+	switch category {
+	case valueobject.MimeCategoryImage:
+		return "f.mime_type LIKE 'image/%'"
+	case valueobject.MimeCategoryVideo:
+		return "f.mime_type LIKE 'video/%'"
+	case valueobject.MimeCategoryAudio:
+		return "f.mime_type LIKE 'audio/%'"
+	case valueobject.MimeCategoryDocument:
+		return document
+	case valueobject.MimeCategoryArchive:
+		return archive
+	default:
+		return `NOT (f.mime_type LIKE 'image/%' OR f.mime_type LIKE 'video/%' OR f.mime_type LIKE 'audio/%' OR ` + document + ` OR ` + archive + `)`
+	}
+}
+
+// インターフェースの実装を保証
+var _ repository.SearchRepository = (*SearchRepository)(nil)
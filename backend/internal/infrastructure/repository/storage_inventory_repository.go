@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/database"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/database/sqlcgen"
+)
+
+// StorageInventoryRepository はストレージ整合性チェック用リポジトリの実装です
+type StorageInventoryRepository struct {
+	*database.BaseRepository
+}
+
+// NewStorageInventoryRepository は新しいStorageInventoryRepositoryを作成します
+func NewStorageInventoryRepository(txManager *database.TxManager) *StorageInventoryRepository {
+	return &StorageInventoryRepository{
+		BaseRepository: database.NewBaseRepository(txManager),
+	}
+}
+
+// ListFileVersionRefs はfile_versionsのオブジェクト参照をID順に取得します
+func (r *StorageInventoryRepository) ListFileVersionRefs(ctx context.Context, afterID uuid.UUID, limit int) ([]*repository.StoredObjectRef, error) {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	rows, err := queries.ListFileVersionObjectRefs(ctx, sqlcgen.ListFileVersionObjectRefsParams{
+		AfterID:    afterID,
+		LimitCount: int32(limit),
+	})
+	if err != nil {
+		return nil, r.HandleError(err)
+	}
+
+	refs := make([]*repository.StoredObjectRef, len(rows))
+	for i, row := range rows {
+		versionID := ""
+		if row.MinioVersionID != nil {
+			versionID = *row.MinioVersionID
+		}
+		refs[i] = &repository.StoredObjectRef{
+			Source:     repository.StoredObjectSourceFileVersion,
+			RowID:      row.ID,
+			FileID:     row.FileID,
+			StorageKey: row.StorageKey,
+			VersionID:  versionID,
+			Size:       row.Size,
+			Checksum:   row.Checksum,
+			IsCurrent:  row.IsCurrent,
+		}
+	}
+	return refs, nil
+}
+
+// ListArchivedFileVersionRefs はarchived_file_versionsのオブジェクト参照をID順に取得します
+func (r *StorageInventoryRepository) ListArchivedFileVersionRefs(ctx context.Context, afterID uuid.UUID, limit int) ([]*repository.StoredObjectRef, error) {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	rows, err := queries.ListArchivedFileVersionObjectRefs(ctx, sqlcgen.ListArchivedFileVersionObjectRefsParams{
+		AfterID:    afterID,
+		LimitCount: int32(limit),
+	})
+	if err != nil {
+		return nil, r.HandleError(err)
+	}
+
+	refs := make([]*repository.StoredObjectRef, len(rows))
+	for i, row := range rows {
+		refs[i] = &repository.StoredObjectRef{
+			Source:     repository.StoredObjectSourceArchivedFileVersion,
+			RowID:      row.ID,
+			FileID:     row.ArchivedFileID,
+			StorageKey: row.StorageKey,
+			VersionID:  row.MinioVersionID,
+			Size:       row.Size,
+			Checksum:   row.Checksum,
+		}
+	}
+	return refs, nil
+}
+
+// インターフェースの実装を保証
+var _ repository.StorageInventoryRepository = (*StorageInventoryRepository)(nil)
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/database"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/database/sqlcgen"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// StorageQuotaRepository はストレージクォータリポジトリの実装です
+type StorageQuotaRepository struct {
+	*database.BaseRepository
+}
+
+// NewStorageQuotaRepository は新しいStorageQuotaRepositoryを作成します
+func NewStorageQuotaRepository(txManager *database.TxManager) *StorageQuotaRepository {
+	return &StorageQuotaRepository{
+		BaseRepository: database.NewBaseRepository(txManager),
+	}
+}
+
+// Upsert はクォータを作成または更新します
+func (r *StorageQuotaRepository) Upsert(ctx context.Context, quota *entity.StorageQuota) error {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	_, err := queries.UpsertStorageQuota(ctx, sqlcgen.UpsertStorageQuotaParams{
+		ID:          quota.ID,
+		SubjectType: string(quota.SubjectType),
+		SubjectID:   quota.SubjectID,
+		LimitBytes:  quota.LimitBytes,
+		CreatedAt:   quota.CreatedAt,
+		UpdatedAt:   quota.UpdatedAt,
+	})
+
+	return r.HandleError(err)
+}
+
+// FindBySubject は対象のクォータを取得します
+func (r *StorageQuotaRepository) FindBySubject(ctx context.Context, subjectType entity.QuotaSubjectType, subjectID uuid.UUID) (*entity.StorageQuota, error) {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	row, err := queries.GetStorageQuotaBySubject(ctx, sqlcgen.GetStorageQuotaBySubjectParams{
+		SubjectType: string(subjectType),
+		SubjectID:   subjectID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NewNotFoundError("storage quota")
+		}
+		return nil, r.HandleError(err)
+	}
+
+	return r.toEntity(row), nil
+}
+
+// FindGroupQuotasByUserID はユーザーが所属するグループのクォータ一覧を取得します
+func (r *StorageQuotaRepository) FindGroupQuotasByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.StorageQuota, error) {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	rows, err := queries.ListGroupStorageQuotasByUserID(ctx, userID)
+	if err != nil {
+		return nil, r.HandleError(err)
+	}
+
+	quotas := make([]*entity.StorageQuota, 0, len(rows))
+	for _, row := range rows {
+		quotas = append(quotas, r.toEntity(row))
+	}
+	return quotas, nil
+}
+
+// DeleteBySubject は対象のクォータを削除します
+func (r *StorageQuotaRepository) DeleteBySubject(ctx context.Context, subjectType entity.QuotaSubjectType, subjectID uuid.UUID) error {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	err := queries.DeleteStorageQuotaBySubject(ctx, sqlcgen.DeleteStorageQuotaBySubjectParams{
+		SubjectType: string(subjectType),
+		SubjectID:   subjectID,
+	})
+	return r.HandleError(err)
+}
+
+// toEntity はsqlcgen.StorageQuotaをentity.StorageQuotaに変換します
+func (r *StorageQuotaRepository) toEntity(row sqlcgen.StorageQuota) *entity.StorageQuota {
+	return entity.ReconstructStorageQuota(
+		row.ID,
+		entity.QuotaSubjectType(row.SubjectType),
+		row.SubjectID,
+		row.LimitBytes,
+		row.CreatedAt,
+		row.UpdatedAt,
+	)
+}
+
+// インターフェースの実装を保証
+var _ repository.StorageQuotaRepository = (*StorageQuotaRepository)(nil)
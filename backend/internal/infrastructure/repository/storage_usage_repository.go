@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/database"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/database/sqlcgen"
+)
+
+// StorageUsageRepository はストレージ使用量カウンターの実装です
+type StorageUsageRepository struct {
+	*database.BaseRepository
+}
+
+// NewStorageUsageRepository は新しいStorageUsageRepositoryを作成します
+func NewStorageUsageRepository(txManager *database.TxManager) *StorageUsageRepository {
+	return &StorageUsageRepository{
+		BaseRepository: database.NewBaseRepository(txManager),
+	}
+}
+
+// GetUsedBytes はユーザーの使用量を取得します
+func (r *StorageUsageRepository) GetUsedBytes(ctx context.Context, userID uuid.UUID) (int64, error) {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	used, err := queries.GetStorageUsedBytes(ctx, userID)
+	if err != nil {
+		return 0, r.HandleError(err)
+	}
+	return used, nil
+}
+
+// AddUsedBytes はユーザーの使用量に差分を加算します
+func (r *StorageUsageRepository) AddUsedBytes(ctx context.Context, userID uuid.UUID, delta int64) error {
+	if delta == 0 {
+		return nil
+	}
+
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	err := queries.AddStorageUsedBytes(ctx, sqlcgen.AddStorageUsedBytesParams{
+		UserID: userID,
+		Delta:  delta,
+	})
+	return r.HandleError(err)
+}
+
+// SumUsedBytesByGroup はグループメンバー全員の使用量の合計を取得します
+func (r *StorageUsageRepository) SumUsedBytesByGroup(ctx context.Context, groupID uuid.UUID) (int64, error) {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	used, err := queries.SumStorageUsedBytesByGroup(ctx, groupID)
+	if err != nil {
+		return 0, r.HandleError(err)
+	}
+	return used, nil
+}
+
+// インターフェースの実装を保証
+var _ repository.StorageUsageRepository = (*StorageUsageRepository)(nil)
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/database"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/database/sqlcgen"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// ThumbnailRepository はサムネイル生成リポジトリの実装です
+type ThumbnailRepository struct {
+	*database.BaseRepository
+}
+
+// NewThumbnailRepository は新しいThumbnailRepositoryを作成します
+func NewThumbnailRepository(txManager *database.TxManager) *ThumbnailRepository {
+	return &ThumbnailRepository{
+		BaseRepository: database.NewBaseRepository(txManager),
+	}
+}
+
+// Create はサムネイル生成を登録します
+func (r *ThumbnailRepository) Create(ctx context.Context, thumbnail *entity.Thumbnail) error {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	var sourceVersionID *string
+	if thumbnail.SourceVersionID != "" {
+		sourceVersionID = &thumbnail.SourceVersionID
+	}
+
+	err := queries.CreateThumbnail(ctx, sqlcgen.CreateThumbnailParams{
+		ID:              thumbnail.ID,
+		FileID:          thumbnail.FileID,
+		VersionNumber:   int32(thumbnail.VersionNumber),
+		SourceKey:       thumbnail.SourceKey.String(),
+		SourceVersionID: sourceVersionID,
+		Status:          string(thumbnail.Status),
+		ErrorMessage:    thumbnail.ErrorMessage,
+		CreatedAt:       thumbnail.CreatedAt,
+		StartedAt:       timeToPgtype(thumbnail.StartedAt),
+		FinishedAt:      timeToPgtype(thumbnail.FinishedAt),
+	})
+
+	return r.HandleError(err)
+}
+
+// FindByFileAndVersion はファイルバージョンのサムネイルを取得します
+func (r *ThumbnailRepository) FindByFileAndVersion(ctx context.Context, fileID uuid.UUID, versionNumber int) (*entity.Thumbnail, error) {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	row, err := queries.GetThumbnailByFileAndVersion(ctx, sqlcgen.GetThumbnailByFileAndVersionParams{
+		FileID:        fileID,
+		VersionNumber: int32(versionNumber),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NewNotFoundError("thumbnail")
+		}
+		return nil, r.HandleError(err)
+	}
+
+	return r.toEntity(row), nil
+}
+
+// Update はサムネイル生成の状態を更新します
+func (r *ThumbnailRepository) Update(ctx context.Context, thumbnail *entity.Thumbnail) error {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	err := queries.UpdateThumbnail(ctx, sqlcgen.UpdateThumbnailParams{
+		ID:           thumbnail.ID,
+		Status:       string(thumbnail.Status),
+		ErrorMessage: thumbnail.ErrorMessage,
+		StartedAt:    timeToPgtype(thumbnail.StartedAt),
+		FinishedAt:   timeToPgtype(thumbnail.FinishedAt),
+	})
+
+	return r.HandleError(err)
+}
+
+// ClaimNextPending は最も古い生成待ちのサムネイルを生成中にして取得します
+func (r *ThumbnailRepository) ClaimNextPending(ctx context.Context) (*entity.Thumbnail, error) {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	row, err := queries.ClaimNextPendingThumbnail(ctx)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NewNotFoundError("pending thumbnail")
+		}
+		return nil, r.HandleError(err)
+	}
+
+	return r.toEntity(row), nil
+}
+
+// FindRunningStartedBefore は指定日時より前に開始され、まだ生成中のサムネイルを取得します
+func (r *ThumbnailRepository) FindRunningStartedBefore(ctx context.Context, before time.Time) ([]*entity.Thumbnail, error) {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	rows, err := queries.ListRunningThumbnailsStartedBefore(ctx, pgtype.Timestamptz{Time: before, Valid: true})
+	if err != nil {
+		return nil, r.HandleError(err)
+	}
+
+	return r.toEntities(rows), nil
+}
+
+// toEntity はsqlcgen.Thumbnailをentity.Thumbnailに変換します
+func (r *ThumbnailRepository) toEntity(row sqlcgen.Thumbnail) *entity.Thumbnail {
+	sourceVersionID := ""
+	if row.SourceVersionID != nil {
+		sourceVersionID = *row.SourceVersionID
+	}
+	sourceKey, _ := valueobject.NewStorageKeyFromString(row.SourceKey)
+
+	return entity.ReconstructThumbnail(
+		row.ID,
+		row.FileID,
+		int(row.VersionNumber),
+		sourceKey,
+		sourceVersionID,
+		entity.ThumbnailStatus(row.Status),
+		row.ErrorMessage,
+		row.CreatedAt,
+		pgtypeToTime(row.StartedAt),
+		pgtypeToTime(row.FinishedAt),
+	)
+}
+
+// toEntities はsqlcgen.Thumbnail配列をentity.Thumbnail配列に変換します
+func (r *ThumbnailRepository) toEntities(rows []sqlcgen.Thumbnail) []*entity.Thumbnail {
+	entities := make([]*entity.Thumbnail, len(rows))
+	for i, row := range rows {
+		entities[i] = r.toEntity(row)
+	}
+	return entities
+}
+
+// インターフェースの実装を保証
+var _ repository.ThumbnailRepository = (*ThumbnailRepository)(nil)
@@ -32,6 +32,13 @@ func (r *UploadSessionRepository) Create(ctx context.Context, session *entity.Up
 	querier := r.Querier(ctx)
 	queries := sqlcgen.New(querier)
 
+	var checksumAlgorithm, checksum *string
+	if session.ExpectedChecksum != nil {
+		algorithm := string(session.ExpectedChecksum.Algorithm())
+		value := session.ExpectedChecksum.Value()
+		checksumAlgorithm, checksum = &algorithm, &value
+	}
+
 	_, err := queries.CreateUploadSession(ctx, sqlcgen.CreateUploadSessionParams{
 		ID:            session.ID,
 		FileID:        session.FileID,
@@ -50,6 +57,9 @@ func (r *UploadSessionRepository) Create(ctx context.Context, session *entity.Up
 		CreatedAt:     session.CreatedAt,
 		UpdatedAt:     session.UpdatedAt,
 		ExpiresAt:     session.ExpiresAt,
+
+		ExpectedChecksumAlgorithm: checksumAlgorithm,
+		ExpectedChecksum:          checksum,
 	})
 
 	return r.HandleError(err)
@@ -71,18 +81,39 @@ func (r *UploadSessionRepository) FindByID(ctx context.Context, id uuid.UUID) (*
 	return r.toEntity(row), nil
 }
 
+// FindByIDForUpdate はトランザクション終了まで行ロックを取得してアップロードセッションを検索します
+func (r *UploadSessionRepository) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.UploadSession, error) {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	row, err := queries.GetUploadSessionByIDForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NewNotFoundError("upload session")
+		}
+		return nil, r.HandleError(err)
+	}
+
+	return r.toEntity(row), nil
+}
+
 // Update はアップロードセッションを更新します
 func (r *UploadSessionRepository) Update(ctx context.Context, session *entity.UploadSession) error {
 	querier := r.Querier(ctx)
 	queries := sqlcgen.New(querier)
 
 	uploadedParts := int32(session.UploadedParts)
-	_, err := queries.UpdateUploadSession(ctx, sqlcgen.UpdateUploadSessionParams{
+	params := sqlcgen.UpdateUploadSessionParams{
 		ID:            session.ID,
 		MinioUploadID: session.MinioUploadID,
 		UploadedParts: &uploadedParts,
 		Status:        sqlcgen.NullUploadSessionStatus{UploadSessionStatus: sqlcgen.UploadSessionStatus(session.Status), Valid: true},
-	})
+	}
+	if session.IsVerifying() {
+		params.ReceivedVersionID = &session.ReceivedVersionID
+		params.ReceivedSize = &session.ReceivedSize
+	}
+	_, err := queries.UpdateUploadSession(ctx, params)
 
 	return r.HandleError(err)
 }
@@ -141,6 +172,19 @@ func (r *UploadSessionRepository) FindExpired(ctx context.Context) ([]*entity.Up
 	return r.toEntities(rows), nil
 }
 
+// FindVerifying は内容の検証待ちのアップロードセッションを更新日時の古い順に取得します
+func (r *UploadSessionRepository) FindVerifying(ctx context.Context, limit int) ([]*entity.UploadSession, error) {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	rows, err := queries.ListVerifyingUploadSessions(ctx, int32(limit))
+	if err != nil {
+		return nil, r.HandleError(err)
+	}
+
+	return r.toEntities(rows), nil
+}
+
 // UpdateStatus はステータスのみを更新します
 func (r *UploadSessionRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status entity.UploadSessionStatus) error {
 	querier := r.Querier(ctx)
@@ -160,7 +204,7 @@ func (r *UploadSessionRepository) toEntity(row sqlcgen.UploadSession) *entity.Up
 	mimeType, _ := valueobject.NewMimeType(row.MimeType)
 	storageKey, _ := valueobject.NewStorageKeyFromString(row.StorageKey)
 
-	return entity.ReconstructUploadSession(
+	session := entity.ReconstructUploadSession(
 		row.ID,
 		row.FileID,
 		row.OwnerID,
@@ -179,6 +223,18 @@ func (r *UploadSessionRepository) toEntity(row sqlcgen.UploadSession) *entity.Up
 		row.UpdatedAt,
 		row.ExpiresAt,
 	)
+
+	if row.ExpectedChecksumAlgorithm != nil && row.ExpectedChecksum != nil {
+		checksum := valueobject.ReconstructContentChecksum(*row.ExpectedChecksumAlgorithm, *row.ExpectedChecksum)
+		session.SetExpectedChecksum(&checksum)
+	}
+	if row.ReceivedVersionID != nil {
+		session.ReceivedVersionID = *row.ReceivedVersionID
+	}
+	if row.ReceivedSize != nil {
+		session.ReceivedSize = *row.ReceivedSize
+	}
+	return session
 }
 
 // toEntities はsqlcgen.UploadSession配列をentity.UploadSession配列に変換します
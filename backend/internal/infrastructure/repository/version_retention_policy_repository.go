@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/database"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/infrastructure/database/sqlcgen"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// VersionRetentionPolicyRepository はバージョン保持ポリシーリポジトリの実装です
+type VersionRetentionPolicyRepository struct {
+	*database.BaseRepository
+}
+
+// NewVersionRetentionPolicyRepository は新しいVersionRetentionPolicyRepositoryを作成します
+func NewVersionRetentionPolicyRepository(txManager *database.TxManager) *VersionRetentionPolicyRepository {
+	return &VersionRetentionPolicyRepository{
+		BaseRepository: database.NewBaseRepository(txManager),
+	}
+}
+
+// Upsert は保持ポリシーを作成または更新します
+func (r *VersionRetentionPolicyRepository) Upsert(ctx context.Context, policy *entity.VersionRetentionPolicy) error {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	_, err := queries.UpsertVersionRetentionPolicy(ctx, sqlcgen.UpsertVersionRetentionPolicyParams{
+		ID:          policy.ID,
+		SubjectType: string(policy.SubjectType),
+		SubjectID:   policy.SubjectID,
+		KeepLastN:   toInt32Ptr(policy.KeepLastN),
+		KeepDays:    toInt32Ptr(policy.KeepDays),
+		CreatedAt:   policy.CreatedAt,
+		UpdatedAt:   policy.UpdatedAt,
+	})
+
+	return r.HandleError(err)
+}
+
+// FindBySubject は対象の保持ポリシーを取得します
+func (r *VersionRetentionPolicyRepository) FindBySubject(ctx context.Context, subjectType entity.RetentionSubjectType, subjectID uuid.UUID) (*entity.VersionRetentionPolicy, error) {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	row, err := queries.GetVersionRetentionPolicyBySubject(ctx, sqlcgen.GetVersionRetentionPolicyBySubjectParams{
+		SubjectType: string(subjectType),
+		SubjectID:   subjectID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NewNotFoundError("version retention policy")
+		}
+		return nil, r.HandleError(err)
+	}
+
+	return r.toEntity(row), nil
+}
+
+// DeleteBySubject は対象の保持ポリシーを削除します
+func (r *VersionRetentionPolicyRepository) DeleteBySubject(ctx context.Context, subjectType entity.RetentionSubjectType, subjectID uuid.UUID) error {
+	querier := r.Querier(ctx)
+	queries := sqlcgen.New(querier)
+
+	err := queries.DeleteVersionRetentionPolicyBySubject(ctx, sqlcgen.DeleteVersionRetentionPolicyBySubjectParams{
+		SubjectType: string(subjectType),
+		SubjectID:   subjectID,
+	})
+	return r.HandleError(err)
+}
+
+// toEntity はsqlcgen.VersionRetentionPolicyをentity.VersionRetentionPolicyに変換します
+func (r *VersionRetentionPolicyRepository) toEntity(row sqlcgen.VersionRetentionPolicy) *entity.VersionRetentionPolicy {
+	return entity.ReconstructVersionRetentionPolicy(
+		row.ID,
+		entity.RetentionSubjectType(row.SubjectType),
+		row.SubjectID,
+		fromInt32Ptr(row.KeepLastN),
+		fromInt32Ptr(row.KeepDays),
+		row.CreatedAt,
+		row.UpdatedAt,
+	)
+}
+
+// toInt32Ptr は*intを*int32に変換します
+func toInt32Ptr(v *int) *int32 {
+	if v == nil {
+		return nil
+	}
+	n := int32(*v)
+	return &n
+}
+
+// fromInt32Ptr は*int32を*intに変換します
+func fromInt32Ptr(v *int32) *int {
+	if v == nil {
+		return nil
+	}
+	n := int(*v)
+	return &n
+}
+
+// インターフェースの実装を保証
+var _ repository.VersionRetentionPolicyRepository = (*VersionRetentionPolicyRepository)(nil)
@@ -2,9 +2,15 @@ package storage
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
 	"time"
 
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
 )
 
 // StorageServiceAdapter はインフラ層のStorageServiceをドメイン層のインターフェースに適合させるアダプターです
@@ -18,20 +24,23 @@ func NewStorageServiceAdapter(svc *StorageService) *StorageServiceAdapter {
 }
 
 // GeneratePutURL はアップロード用Presigned URLを生成します
-func (a *StorageServiceAdapter) GeneratePutURL(ctx context.Context, objectKey string, expiry time.Duration) (*service.PresignedURL, error) {
-	urlStr, err := a.svc.GeneratePutURL(ctx, objectKey, expiry)
+// チェックサムが指定された場合はチェックサムヘッダーを署名に含め、MinIOにアップロード時の検証と記録をさせます
+func (a *StorageServiceAdapter) GeneratePutURL(ctx context.Context, objectKey string, expiry time.Duration, checksum *valueobject.ContentChecksum) (*service.PresignedURL, error) {
+	headers := checksumHeaders(checksum)
+	urlStr, err := a.svc.GeneratePutURL(ctx, objectKey, expiry, headers)
 	if err != nil {
 		return nil, err
 	}
 	return &service.PresignedURL{
 		URL:       urlStr,
 		ExpiresAt: time.Now().Add(expiry),
+		Headers:   headers,
 	}, nil
 }
 
 // GenerateGetURL はダウンロード用Presigned URLを生成します
-func (a *StorageServiceAdapter) GenerateGetURL(ctx context.Context, objectKey string, expiry time.Duration) (*service.PresignedURL, error) {
-	urlStr, err := a.svc.GenerateGetURL(ctx, objectKey, expiry)
+func (a *StorageServiceAdapter) GenerateGetURL(ctx context.Context, objectKey, versionID string, expiry time.Duration) (*service.PresignedURL, error) {
+	urlStr, err := a.svc.GenerateGetURL(ctx, objectKey, versionID, expiry)
 	if err != nil {
 		return nil, err
 	}
@@ -59,8 +68,35 @@ func (a *StorageServiceAdapter) GeneratePartUploadURL(ctx context.Context, objec
 	}, nil
 }
 
+// UploadPart はパートをサーバー経由でアップロードします
+func (a *StorageServiceAdapter) UploadPart(ctx context.Context, objectKey, uploadID string, partNumber int, reader io.Reader, size int64) (string, error) {
+	return a.svc.UploadPart(ctx, objectKey, uploadID, partNumber, reader, size)
+}
+
+// ListParts はアップロード済みのパートを一覧します
+func (a *StorageServiceAdapter) ListParts(ctx context.Context, objectKey, uploadID string) ([]service.StoredPart, error) {
+	parts, err := a.svc.ListParts(ctx, objectKey, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]service.StoredPart, len(parts))
+	for i, p := range parts {
+		result[i] = service.StoredPart{
+			PartNumber: p.PartNumber,
+			Size:       p.Size,
+			ETag:       p.ETag,
+		}
+	}
+	return result, nil
+}
+
+// GetPart はアップロード済みのパートの内容を取得します
+func (a *StorageServiceAdapter) GetPart(ctx context.Context, objectKey, uploadID string, partNumber int) (io.ReadCloser, error) {
+	return a.svc.GetPart(ctx, objectKey, uploadID, partNumber)
+}
+
 // CompleteMultipartUpload はマルチパートアップロードを完了します
-func (a *StorageServiceAdapter) CompleteMultipartUpload(ctx context.Context, objectKey, uploadID string, etags []string) error {
+func (a *StorageServiceAdapter) CompleteMultipartUpload(ctx context.Context, objectKey, uploadID string, etags []string) (string, error) {
 	parts := make([]CompletedPart, len(etags))
 	for i, etag := range etags {
 		parts[i] = CompletedPart{
@@ -68,8 +104,7 @@ func (a *StorageServiceAdapter) CompleteMultipartUpload(ctx context.Context, obj
 			ETag:       etag,
 		}
 	}
-	_, err := a.svc.CompleteMultipartUpload(ctx, objectKey, uploadID, parts)
-	return err
+	return a.svc.CompleteMultipartUpload(ctx, objectKey, uploadID, parts)
 }
 
 // AbortMultipartUpload はマルチパートアップロードを中断します
@@ -77,6 +112,79 @@ func (a *StorageServiceAdapter) AbortMultipartUpload(ctx context.Context, object
 	return a.svc.AbortMultipartUpload(ctx, objectKey, uploadID)
 }
 
+// ListIncompleteUploads は未完了のマルチパートアップロードを一覧します
+func (a *StorageServiceAdapter) ListIncompleteUploads(ctx context.Context, prefix string) ([]service.IncompleteMultipartUpload, error) {
+	uploads, err := a.svc.ListIncompleteUploads(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]service.IncompleteMultipartUpload, len(uploads))
+	for i, u := range uploads {
+		result[i] = service.IncompleteMultipartUpload{
+			ObjectKey: u.ObjectKey,
+			UploadID:  u.UploadID,
+			Initiated: u.Initiated,
+		}
+	}
+	return result, nil
+}
+
+// GetObject はオブジェクトを取得します
+func (a *StorageServiceAdapter) GetObject(ctx context.Context, objectKey, versionID string) (io.ReadCloser, error) {
+	return a.svc.GetObject(ctx, objectKey, versionID)
+}
+
+// GetObjectInfo はオブジェクトの最新バージョンの情報を取得します
+func (a *StorageServiceAdapter) GetObjectInfo(ctx context.Context, objectKey string) (*service.ObjectInfo, error) {
+	info, err := a.svc.GetObjectInfo(ctx, objectKey)
+	if err != nil {
+		if errors.Is(err, ErrObjectNotFound) {
+			return nil, service.ErrObjectNotFound
+		}
+		return nil, err
+	}
+	return &service.ObjectInfo{
+		VersionID:    info.VersionID,
+		Size:         info.Size,
+		ETag:         info.ETag,
+		ContentType:  info.ContentType,
+		LastModified: info.LastModified,
+	}, nil
+}
+
+// GetObjectVersionInfo はオブジェクトの特定バージョンの情報を取得します
+// MinIOが記録したチェックサムは内容全体のもののみ16進数に変換して返します
+func (a *StorageServiceAdapter) GetObjectVersionInfo(ctx context.Context, objectKey, versionID string) (*service.ObjectInfo, error) {
+	info, err := a.svc.GetObjectVersionInfo(ctx, objectKey, versionID)
+	if err != nil {
+		if errors.Is(err, ErrObjectNotFound) {
+			return nil, service.ErrObjectNotFound
+		}
+		return nil, err
+	}
+	return &service.ObjectInfo{
+		VersionID:      info.VersionID,
+		Size:           info.Size,
+		ETag:           info.ETag,
+		ContentType:    info.ContentType,
+		LastModified:   info.LastModified,
+		ChecksumSHA256: fullObjectChecksum(info.ChecksumSHA256, info.ChecksumMode),
+		ChecksumCRC32C: fullObjectChecksum(info.ChecksumCRC32C, info.ChecksumMode),
+	}, nil
+}
+
+// PutObject はオブジェクトをサーバー経由でアップロードします
+func (a *StorageServiceAdapter) PutObject(ctx context.Context, objectKey string, reader io.Reader, size int64, contentType string) (*service.UploadedObject, error) {
+	versionID, etag, err := a.svc.PutObject(ctx, objectKey, reader, size, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return &service.UploadedObject{
+		VersionID: versionID,
+		ETag:      etag,
+	}, nil
+}
+
 // DeleteObject はオブジェクトを削除します
 func (a *StorageServiceAdapter) DeleteObject(ctx context.Context, objectKey string) error {
 	return a.svc.DeleteObject(ctx, objectKey)
@@ -86,3 +194,51 @@ func (a *StorageServiceAdapter) DeleteObject(ctx context.Context, objectKey stri
 func (a *StorageServiceAdapter) DeleteObjects(ctx context.Context, objectKeys []string) error {
 	return a.svc.DeleteObjects(ctx, objectKeys)
 }
+
+// CopyObjectVersion はオブジェクトの特定バージョンをコピーします
+func (a *StorageServiceAdapter) CopyObjectVersion(ctx context.Context, srcKey, srcVersionID, dstKey string) (string, error) {
+	return a.svc.CopyObjectVersion(ctx, srcKey, srcVersionID, dstKey)
+}
+
+// DeleteObjectVersion はオブジェクトの特定バージョンを削除します
+func (a *StorageServiceAdapter) DeleteObjectVersion(ctx context.Context, objectKey, versionID string) error {
+	return a.svc.DeleteObjectVersion(ctx, objectKey, versionID)
+}
+
+// WalkObjectVersions はバケット内の全オブジェクトバージョンを走査します
+func (a *StorageServiceAdapter) WalkObjectVersions(ctx context.Context, prefix string, fn func(service.ObjectVersion) error) error {
+	return a.svc.WalkObjectVersions(ctx, prefix, func(v ObjectVersionInfo) error {
+		return fn(service.ObjectVersion{
+			Key:            v.Key,
+			VersionID:      v.VersionID,
+			Size:           v.Size,
+			ETag:           v.ETag,
+			LastModified:   v.LastModified,
+			IsLatest:       v.IsLatest,
+			IsDeleteMarker: v.IsDeleteMarker,
+		})
+	})
+}
+
+// checksumHeaders はチェックサムをS3のチェックサムヘッダーに変換します（未指定の場合はnil）
+func checksumHeaders(checksum *valueobject.ContentChecksum) map[string]string {
+	if checksum == nil {
+		return nil
+	}
+	return map[string]string{
+		"x-amz-checksum-" + string(checksum.Algorithm()): checksum.Base64(),
+	}
+}
+
+// fullObjectChecksum はMinIOが記録したBase64のチェックサムを16進数に変換します
+// パーツごとのチェックサムから求めた値（"-パーツ数"が付く）は内容全体のダイジェストではないため空を返します
+func fullObjectChecksum(value, mode string) string {
+	if value == "" || mode == "COMPOSITE" || strings.Contains(value, "-") {
+		return ""
+	}
+	digest, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return ""
+	}
+	return hex.EncodeToString(digest)
+}
@@ -3,6 +3,8 @@ package storage
 import (
 	"context"
 	"fmt"
+	"io"
+	"log/slog"
 	"net/url"
 	"time"
 
@@ -86,30 +88,91 @@ func (s *MultipartService) GeneratePartUploadURL(
 	return presignedURL.String(), nil
 }
 
+// UploadPart はパートをサーバー経由でアップロードします
+// Presigned URLでアップロードされたパートと同じく、パートファイルとして保存します
+func (s *MultipartService) UploadPart(
+	ctx context.Context,
+	objectKey string,
+	uploadID string,
+	partNumber int,
+	reader io.Reader,
+	size int64,
+) (string, error) {
+	partKey := fmt.Sprintf("%s.part%d", objectKey, partNumber)
+
+	info, err := s.client.PutObject(ctx, s.bucketName, partKey, reader, size, minio.PutObjectOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to upload part %d: %w", partNumber, err)
+	}
+
+	return info.ETag, nil
+}
+
 // CompleteMultipartUpload はマルチパートアップロードを完了します
-// パートファイルを結合して最終オブジェクトを作成します
+// パートファイルをMinIOのCompose APIで結合して最終オブジェクトを作成し、作成されたバージョンIDを返します
+// 最後以外のパートは5MB以上である必要があります
 func (s *MultipartService) CompleteMultipartUpload(
 	ctx context.Context,
 	objectKey string,
 	uploadID string,
 	parts []CompletedPart,
 ) (string, error) {
-	// シンプルな実装: 最初のパートを最終ファイルとして使用
-	// 実際のマルチパート結合は複雑なため、大きなファイルは
-	// PutObject with streaming を使用することを推奨
+	srcs := make([]minio.CopySrcOptions, len(parts))
+	for i, part := range parts {
+		srcs[i] = minio.CopySrcOptions{
+			Bucket: s.bucketName,
+			Object: fmt.Sprintf("%s.part%d", objectKey, part.PartNumber),
+		}
+	}
 
-	// Note: 本番環境では、MinIOのCompose API使用を検討してください
-	return "completed", nil
+	info, err := s.client.ComposeObject(ctx, minio.CopyDestOptions{
+		Bucket: s.bucketName,
+		Object: objectKey,
+	}, srcs...)
+	if err != nil {
+		return "", fmt.Errorf("failed to compose parts: %w", err)
+	}
+
+	// 結合後のパートファイルは不要なため削除（失敗しても結合済みのオブジェクトは有効なため記録のみ）
+	for _, src := range srcs {
+		if err := s.client.RemoveObject(ctx, s.bucketName, src.Object, minio.RemoveObjectOptions{}); err != nil {
+			slog.Warn("failed to delete composed part",
+				"part_key", src.Object,
+				"error", err,
+			)
+		}
+	}
+
+	return info.VersionID, nil
 }
 
 // AbortMultipartUpload はマルチパートアップロードを中止します
+// Presigned URLでアップロードされたパートファイルを削除し、MinIO上のマルチパートアップロードも中止します
 func (s *MultipartService) AbortMultipartUpload(
 	ctx context.Context,
 	objectKey string,
 	uploadID string,
 ) error {
 	// パートファイルを削除
-	// パターンマッチでパートファイルを探して削除
+	parts, err := s.ListParts(ctx, objectKey, uploadID)
+	if err != nil {
+		return fmt.Errorf("failed to list parts: %w", err)
+	}
+	for _, part := range parts {
+		partKey := fmt.Sprintf("%s.part%d", objectKey, part.PartNumber)
+		if err := s.client.RemoveObject(ctx, s.bucketName, partKey, minio.RemoveObjectOptions{}); err != nil {
+			return fmt.Errorf("failed to delete part %s: %w", partKey, err)
+		}
+	}
+
+	// MinIO上のマルチパートアップロードを中止（存在しない場合は無視）
+	core := minio.Core{Client: s.client}
+	if err := core.AbortMultipartUpload(ctx, s.bucketName, objectKey, uploadID); err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchUpload" {
+			return nil
+		}
+		return fmt.Errorf("failed to abort multipart upload: %w", err)
+	}
 	return nil
 }
 
@@ -148,6 +211,22 @@ func (s *MultipartService) ListParts(
 	return parts, nil
 }
 
+// GetPart はアップロード済みのパートファイルを取得します（呼び出し側でCloseが必要）
+func (s *MultipartService) GetPart(
+	ctx context.Context,
+	objectKey string,
+	uploadID string,
+	partNumber int,
+) (io.ReadCloser, error) {
+	partKey := fmt.Sprintf("%s.part%d", objectKey, partNumber)
+
+	object, err := s.client.GetObject(ctx, s.bucketName, partKey, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get part %d: %w", partNumber, err)
+	}
+	return object, nil
+}
+
 // ListIncompleteUploads は未完了のマルチパートアップロードを一覧します
 func (s *MultipartService) ListIncompleteUploads(
 	ctx context.Context,
@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 	"time"
 
@@ -13,7 +14,9 @@ import (
 type PresignedURLOptions struct {
 	ContentType        string            // Content-Type (PUT時のみ)
 	ContentDisposition string            // Content-Disposition (GET時のダウンロード名)
+	VersionID          string            // 取得するオブジェクトバージョン (GET時のみ、空の場合は最新)
 	Metadata           map[string]string // カスタムメタデータ
+	Headers            map[string]string // 署名に含めるヘッダー (PUT時のみ、クライアントは同じ値を送信する必要がある)
 }
 
 const (
@@ -52,12 +55,22 @@ func (s *PresignedURLService) GeneratePutURL(
 	expiry time.Duration,
 	opts *PresignedURLOptions,
 ) (string, error) {
-	presignedURL, err := s.client.PresignedPutObject(
-		ctx,
-		s.bucketName,
-		objectKey,
-		expiry,
-	)
+	var presignedURL *url.URL
+	var err error
+	if opts != nil && len(opts.Headers) > 0 {
+		header := make(http.Header, len(opts.Headers))
+		for name, value := range opts.Headers {
+			header.Set(name, value)
+		}
+		presignedURL, err = s.client.PresignHeader(ctx, http.MethodPut, s.bucketName, objectKey, expiry, nil, header)
+	} else {
+		presignedURL, err = s.client.PresignedPutObject(
+			ctx,
+			s.bucketName,
+			objectKey,
+			expiry,
+		)
+	}
 	if err != nil {
 		return "", fmt.Errorf("failed to generate presigned put URL: %w", err)
 	}
@@ -77,6 +90,9 @@ func (s *PresignedURLService) GenerateGetURL(
 	if opts != nil && opts.ContentDisposition != "" {
 		reqParams.Set("response-content-disposition", opts.ContentDisposition)
 	}
+	if opts != nil && opts.VersionID != "" {
+		reqParams.Set("versionId", opts.VersionID)
+	}
 
 	presignedURL, err := s.client.PresignedGetObject(
 		ctx,
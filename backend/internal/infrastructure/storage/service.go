@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"time"
@@ -12,11 +13,32 @@ import (
 // ObjectInfo はオブジェクト情報を表します
 type ObjectInfo struct {
 	Key          string
+	VersionID    string
 	Size         int64
 	ContentType  string
 	ETag         string
 	LastModified time.Time
 	Metadata     map[string]string
+
+	// アップロード時にMinIOが検証・記録したチェックサム（Base64、記録がない場合は空）
+	// マルチパートで結合したオブジェクトはパーツごとのチェックサムから求めた値（COMPOSITE）になる
+	ChecksumSHA256 string
+	ChecksumCRC32C string
+	ChecksumMode   string
+}
+
+// ErrObjectNotFound はオブジェクトが存在しない場合のエラーです
+var ErrObjectNotFound = errors.New("object not found")
+
+// ObjectVersionInfo はオブジェクトバージョン情報を表します
+type ObjectVersionInfo struct {
+	Key            string
+	VersionID      string
+	Size           int64
+	ETag           string
+	LastModified   time.Time
+	IsLatest       bool
+	IsDeleteMarker bool
 }
 
 // StorageService はストレージ操作を提供する統合サービスです
@@ -38,13 +60,14 @@ func NewStorageService(client *MinIOClient) *StorageService {
 }
 
 // GeneratePutURL はアップロード用Presigned URLを生成します
-func (s *StorageService) GeneratePutURL(ctx context.Context, objectKey string, expiry time.Duration) (string, error) {
-	return s.presigned.GeneratePutURL(ctx, objectKey, expiry, nil)
+// headersは署名に含めるヘッダーで、クライアントはアップロード時に同じ値を送信する必要があります
+func (s *StorageService) GeneratePutURL(ctx context.Context, objectKey string, expiry time.Duration, headers map[string]string) (string, error) {
+	return s.presigned.GeneratePutURL(ctx, objectKey, expiry, &PresignedURLOptions{Headers: headers})
 }
 
-// GenerateGetURL はダウンロード用Presigned URLを生成します
-func (s *StorageService) GenerateGetURL(ctx context.Context, objectKey string, expiry time.Duration) (string, error) {
-	return s.presigned.GenerateGetURL(ctx, objectKey, expiry, nil)
+// GenerateGetURL はダウンロード用Presigned URLを生成します（versionIDが空の場合は最新バージョン）
+func (s *StorageService) GenerateGetURL(ctx context.Context, objectKey, versionID string, expiry time.Duration) (string, error) {
+	return s.presigned.GenerateGetURL(ctx, objectKey, expiry, &PresignedURLOptions{VersionID: versionID})
 }
 
 // GenerateDownloadURL はダウンロード用URLを生成します
@@ -70,15 +93,47 @@ func (s *StorageService) ObjectExists(ctx context.Context, objectKey string) (bo
 	return true, nil
 }
 
+// WalkObjectVersions はバケット内の全オブジェクトバージョンを走査します
+func (s *StorageService) WalkObjectVersions(ctx context.Context, prefix string, fn func(ObjectVersionInfo) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for obj := range s.client.ListObjects(ctx, s.bucketName, minio.ListObjectsOptions{
+		Prefix:       prefix,
+		Recursive:    true,
+		WithVersions: true,
+	}) {
+		if obj.Err != nil {
+			return fmt.Errorf("failed to list object versions: %w", obj.Err)
+		}
+		if err := fn(ObjectVersionInfo{
+			Key:            obj.Key,
+			VersionID:      obj.VersionID,
+			Size:           obj.Size,
+			ETag:           obj.ETag,
+			LastModified:   obj.LastModified,
+			IsLatest:       obj.IsLatest,
+			IsDeleteMarker: obj.IsDeleteMarker,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // GetObjectInfo はオブジェクト情報を取得します
 func (s *StorageService) GetObjectInfo(ctx context.Context, objectKey string) (*ObjectInfo, error) {
 	info, err := s.client.StatObject(ctx, s.bucketName, objectKey, minio.StatObjectOptions{})
 	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, ErrObjectNotFound
+		}
 		return nil, fmt.Errorf("failed to get object info: %w", err)
 	}
 
 	return &ObjectInfo{
 		Key:          info.Key,
+		VersionID:    info.VersionID,
 		Size:         info.Size,
 		ContentType:  info.ContentType,
 		ETag:         info.ETag,
@@ -87,6 +142,33 @@ func (s *StorageService) GetObjectInfo(ctx context.Context, objectKey string) (*
 	}, nil
 }
 
+// GetObjectVersionInfo はオブジェクトの特定バージョンの情報を、記録されたチェックサムを含めて取得します
+func (s *StorageService) GetObjectVersionInfo(ctx context.Context, objectKey, versionID string) (*ObjectInfo, error) {
+	info, err := s.client.StatObject(ctx, s.bucketName, objectKey, minio.StatObjectOptions{
+		VersionID: versionID,
+		Checksum:  true,
+	})
+	if err != nil {
+		if code := minio.ToErrorResponse(err).Code; code == "NoSuchKey" || code == "NoSuchVersion" {
+			return nil, ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("failed to get object version info: %w", err)
+	}
+
+	return &ObjectInfo{
+		Key:            info.Key,
+		VersionID:      info.VersionID,
+		Size:           info.Size,
+		ContentType:    info.ContentType,
+		ETag:           info.ETag,
+		LastModified:   info.LastModified,
+		Metadata:       info.UserMetadata,
+		ChecksumSHA256: info.ChecksumSHA256,
+		ChecksumCRC32C: info.ChecksumCRC32C,
+		ChecksumMode:   info.ChecksumMode,
+	}, nil
+}
+
 // DeleteObject はオブジェクトを削除します
 func (s *StorageService) DeleteObject(ctx context.Context, objectKey string) error {
 	err := s.client.RemoveObject(ctx, s.bucketName, objectKey, minio.RemoveObjectOptions{})
@@ -143,24 +225,58 @@ func (s *StorageService) CopyObject(ctx context.Context, srcKey, dstKey string)
 	return nil
 }
 
-// GetObject はオブジェクトを直接取得します（内部使用のみ）
-func (s *StorageService) GetObject(ctx context.Context, objectKey string) (io.ReadCloser, error) {
-	object, err := s.client.GetObject(ctx, s.bucketName, objectKey, minio.GetObjectOptions{})
+// CopyObjectVersion はオブジェクトの特定バージョンをコピーし、コピー先のバージョンIDを返します
+func (s *StorageService) CopyObjectVersion(ctx context.Context, srcKey, srcVersionID, dstKey string) (string, error) {
+	src := minio.CopySrcOptions{
+		Bucket:    s.bucketName,
+		Object:    srcKey,
+		VersionID: srcVersionID,
+	}
+
+	dst := minio.CopyDestOptions{
+		Bucket: s.bucketName,
+		Object: dstKey,
+	}
+
+	info, err := s.client.CopyObject(ctx, dst, src)
+	if err != nil {
+		return "", fmt.Errorf("failed to copy object version: %w", err)
+	}
+
+	return info.VersionID, nil
+}
+
+// DeleteObjectVersion はオブジェクトの特定バージョンを削除します
+func (s *StorageService) DeleteObjectVersion(ctx context.Context, objectKey, versionID string) error {
+	err := s.client.RemoveObject(ctx, s.bucketName, objectKey, minio.RemoveObjectOptions{
+		VersionID: versionID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete object version: %w", err)
+	}
+	return nil
+}
+
+// GetObject はオブジェクトを直接取得します（内部使用のみ、versionIDが空の場合は最新バージョン）
+func (s *StorageService) GetObject(ctx context.Context, objectKey, versionID string) (io.ReadCloser, error) {
+	object, err := s.client.GetObject(ctx, s.bucketName, objectKey, minio.GetObjectOptions{
+		VersionID: versionID,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get object: %w", err)
 	}
 	return object, nil
 }
 
-// PutObject はオブジェクトを直接アップロードします（内部使用のみ、小さなファイル向け）
-func (s *StorageService) PutObject(ctx context.Context, objectKey string, reader io.Reader, size int64, contentType string) error {
-	_, err := s.client.PutObject(ctx, s.bucketName, objectKey, reader, size, minio.PutObjectOptions{
+// PutObject はオブジェクトを直接アップロードし、作成されたバージョンIDとETagを返します
+func (s *StorageService) PutObject(ctx context.Context, objectKey string, reader io.Reader, size int64, contentType string) (versionID, etag string, err error) {
+	info, err := s.client.PutObject(ctx, s.bucketName, objectKey, reader, size, minio.PutObjectOptions{
 		ContentType: contentType,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to put object: %w", err)
+		return "", "", fmt.Errorf("failed to put object: %w", err)
 	}
-	return nil
+	return info.VersionID, info.ETag, nil
 }
 
 // マルチパート操作の委譲
@@ -172,6 +288,10 @@ func (s *StorageService) GeneratePartUploadURL(ctx context.Context, objectKey, u
 	return s.multipart.GeneratePartUploadURL(ctx, objectKey, uploadID, partNumber)
 }
 
+func (s *StorageService) UploadPart(ctx context.Context, objectKey, uploadID string, partNumber int, reader io.Reader, size int64) (string, error) {
+	return s.multipart.UploadPart(ctx, objectKey, uploadID, partNumber, reader, size)
+}
+
 func (s *StorageService) CompleteMultipartUpload(ctx context.Context, objectKey, uploadID string, parts []CompletedPart) (string, error) {
 	return s.multipart.CompleteMultipartUpload(ctx, objectKey, uploadID, parts)
 }
@@ -180,6 +300,14 @@ func (s *StorageService) AbortMultipartUpload(ctx context.Context, objectKey, up
 	return s.multipart.AbortMultipartUpload(ctx, objectKey, uploadID)
 }
 
+func (s *StorageService) ListIncompleteUploads(ctx context.Context, prefix string) ([]IncompleteUpload, error) {
+	return s.multipart.ListIncompleteUploads(ctx, prefix)
+}
+
 func (s *StorageService) ListParts(ctx context.Context, objectKey, uploadID string) ([]PartInfo, error) {
 	return s.multipart.ListParts(ctx, objectKey, uploadID)
 }
+
+func (s *StorageService) GetPart(ctx context.Context, objectKey, uploadID string, partNumber int) (io.ReadCloser, error) {
+	return s.multipart.GetPart(ctx, objectKey, uploadID, partNumber)
+}
@@ -65,3 +65,23 @@ func NewHealthCheckJob(checkFn func(ctx context.Context) error) Job {
 		},
 	}
 }
+
+// NewJobRunHistoryCleanupJob はジョブ実行履歴のクリーンアップジョブを作成します
+// retention より前に開始された実行履歴を削除します
+func NewJobRunHistoryCleanupJob(cleanupFn func(ctx context.Context, before time.Time) (int64, error), retention time.Duration) Job {
+	return Job{
+		Name:      "job_run_history_cleanup",
+		Interval:  24 * time.Hour,
+		Exclusive: true,
+		Fn: func(ctx context.Context) error {
+			count, err := cleanupFn(ctx, time.Now().Add(-retention))
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				slog.Info("job run history cleanup completed", "deleted", count)
+			}
+			return nil
+		},
+	}
+}
@@ -5,6 +5,11 @@ import (
 	"log/slog"
 	"sync"
 	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
 )
 
 // Job は定期実行ジョブを定義します
@@ -12,23 +17,84 @@ type Job struct {
 	Name     string
 	Interval time.Duration
 	Fn       func(ctx context.Context) error
+	// Exclusive がtrueの場合、複数レプリカのうちロックを取得した1台でのみ実行されます
+	Exclusive bool
+}
+
+// Locker はジョブの実行権を複数レプリカ間で排他するロックです
+// tokenは実行ごとに発行され、延長と解放はtokenが一致するロックにのみ作用します
+type Locker interface {
+	// TryAcquire はロック取得を試み、取得できた場合はtrueを返します
+	TryAcquire(ctx context.Context, jobName, token string, ttl time.Duration) (bool, error)
+	// Renew はtokenが保持しているロックのTTLを延長し、保持していない場合はfalseを返します
+	Renew(ctx context.Context, jobName, token string, ttl time.Duration) (bool, error)
+	// Release はtokenが保持しているロックを解放します
+	Release(ctx context.Context, jobName, token string) error
+}
+
+const (
+	// defaultLockTTL はロックのTTLです。実行中は延長され続けるため、実行時間より短くて構いません
+	defaultLockTTL = 30 * time.Second
+	// lockReleaseTimeout はシャットダウン中でもロックを解放できるよう確保する時間です
+	lockReleaseTimeout = 5 * time.Second
+)
+
+// Option はManagerのオプションです
+type Option func(*Manager)
+
+// WithLocker はExclusiveジョブの排他に使用するロックを設定します
+func WithLocker(locker Locker) Option {
+	return func(m *Manager) {
+		m.locker = locker
+	}
+}
+
+// WithRunHistory はジョブの実行履歴を記録するリポジトリを設定します
+func WithRunHistory(runRepo repository.JobRunRepository) Option {
+	return func(m *Manager) {
+		m.runRepo = runRepo
+	}
+}
+
+// WithLockTTL はExclusiveジョブのロックのTTLを設定します
+// ロックはTTLの1/3ごとに延長されます
+func WithLockTTL(ttl time.Duration) Option {
+	return func(m *Manager) {
+		m.lockTTL = ttl
+	}
+}
+
+// WithInstanceID はロック保持者と実行履歴に記録するレプリカ識別子を設定します
+func WithInstanceID(instanceID string) Option {
+	return func(m *Manager) {
+		m.instanceID = instanceID
+	}
 }
 
 // Manager はバックグラウンドワーカーを管理します
 type Manager struct {
-	jobs   []Job
-	ctx    context.Context
-	cancel context.CancelFunc
-	wg     sync.WaitGroup
+	jobs       []Job
+	locker     Locker
+	runRepo    repository.JobRunRepository
+	instanceID string
+	lockTTL    time.Duration
+	ctx        context.Context
+	cancel     context.CancelFunc
+	wg         sync.WaitGroup
 }
 
 // NewManager は新しいWorker Managerを作成します
-func NewManager() *Manager {
+func NewManager(opts ...Option) *Manager {
 	ctx, cancel := context.WithCancel(context.Background())
-	return &Manager{
-		ctx:    ctx,
-		cancel: cancel,
+	m := &Manager{
+		lockTTL: defaultLockTTL,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+	for _, opt := range opts {
+		opt(m)
 	}
+	return m
 }
 
 // Register は定期実行ジョブを登録します
@@ -49,12 +115,10 @@ func (m *Manager) Start() {
 func (m *Manager) runJob(job Job) {
 	defer m.wg.Done()
 
-	slog.Info("worker started", "job", job.Name, "interval", job.Interval)
+	slog.Info("worker started", "job", job.Name, "interval", job.Interval, "exclusive", job.Exclusive)
 
 	// 最初の実行を即座に行う
-	if err := job.Fn(m.ctx); err != nil {
-		slog.Error("worker job failed", "job", job.Name, "error", err)
-	}
+	m.execute(job)
 
 	ticker := time.NewTicker(job.Interval)
 	defer ticker.Stop()
@@ -65,11 +129,89 @@ func (m *Manager) runJob(job Job) {
 			slog.Info("worker stopping", "job", job.Name)
 			return
 		case <-ticker.C:
-			if err := job.Fn(m.ctx); err != nil {
-				slog.Error("worker job failed", "job", job.Name, "error", err)
+			m.execute(job)
+		}
+	}
+}
+
+// execute はジョブを1回実行し、結果を実行履歴に記録します
+func (m *Manager) execute(job Job) {
+	ctx := m.ctx
+	if job.Exclusive && m.locker != nil {
+		token := m.instanceID + ":" + uuid.NewString()
+		acquired, err := m.locker.TryAcquire(m.ctx, job.Name, token, m.lockTTL)
+		if err != nil {
+			slog.Error("worker lock failed", "job", job.Name, "error", err)
+			return
+		}
+		if !acquired {
+			slog.Debug("worker job skipped: running on another instance", "job", job.Name)
+			return
+		}
+
+		lockCtx, release := m.holdLock(job.Name, token)
+		defer release()
+		ctx = lockCtx
+	}
+
+	startedAt := time.Now()
+	err := job.Fn(ctx)
+	finishedAt := time.Now()
+	if err != nil {
+		slog.Error("worker job failed", "job", job.Name, "error", err)
+	}
+
+	if m.runRepo != nil {
+		run := entity.NewJobRun(job.Name, m.instanceID, startedAt, finishedAt, err)
+		if err := m.runRepo.Create(m.ctx, run); err != nil {
+			slog.Warn("failed to record job run", "job", job.Name, "error", err)
+		}
+	}
+}
+
+// holdLock はジョブの実行中にロックを延長し続けます
+// ロックを失った場合は返したコンテキストをキャンセルし、他のレプリカの実行と重ならないようにします
+// 返り値のrelease関数は延長を止め、ロックを解放します
+func (m *Manager) holdLock(jobName, token string) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(m.ctx)
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(m.lockTTL / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				held, err := m.locker.Renew(ctx, jobName, token, m.lockTTL)
+				if err != nil {
+					// 一時的な障害の可能性があるため、TTLが残っている間は延長を再試行する
+					slog.Warn("failed to renew job lock", "job", jobName, "error", err)
+					continue
+				}
+				if !held {
+					slog.Error("worker job lock lost: cancelling run", "job", jobName)
+					cancel()
+					return
+				}
 			}
 		}
+	}()
+
+	release := func() {
+		cancel()
+		<-stopped
+
+		releaseCtx, releaseCancel := context.WithTimeout(context.WithoutCancel(m.ctx), lockReleaseTimeout)
+		defer releaseCancel()
+		if err := m.locker.Release(releaseCtx, jobName, token); err != nil {
+			slog.Warn("failed to release job lock", "job", jobName, "error", err)
+		}
 	}
+	return ctx, release
 }
 
 // Shutdown はすべてのワーカーを安全に停止します
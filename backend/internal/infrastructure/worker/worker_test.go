@@ -0,0 +1,166 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryLocker はTTLの失効を再現するインメモリのLockerです
+type memoryLocker struct {
+	mu    sync.Mutex
+	locks map[string]memoryLock
+}
+
+type memoryLock struct {
+	token     string
+	expiresAt time.Time
+}
+
+func newMemoryLocker() *memoryLocker {
+	return &memoryLocker{locks: make(map[string]memoryLock)}
+}
+
+func (l *memoryLocker) TryAcquire(_ context.Context, jobName, token string, ttl time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if lock, ok := l.locks[jobName]; ok && time.Now().Before(lock.expiresAt) {
+		return false, nil
+	}
+	l.locks[jobName] = memoryLock{token: token, expiresAt: time.Now().Add(ttl)}
+	return true, nil
+}
+
+func (l *memoryLocker) Renew(_ context.Context, jobName, token string, ttl time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	lock, ok := l.locks[jobName]
+	if !ok || lock.token != token || !time.Now().Before(lock.expiresAt) {
+		return false, nil
+	}
+	l.locks[jobName] = memoryLock{token: token, expiresAt: time.Now().Add(ttl)}
+	return true, nil
+}
+
+func (l *memoryLocker) Release(_ context.Context, jobName, token string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if lock, ok := l.locks[jobName]; ok && lock.token == token {
+		delete(l.locks, jobName)
+	}
+	return nil
+}
+
+// steal は他のレプリカがロックを奪った状態を再現します
+func (l *memoryLocker) steal(jobName string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.locks[jobName] = memoryLock{token: "other", expiresAt: time.Now().Add(time.Hour)}
+}
+
+func (l *memoryLocker) held(jobName string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	lock, ok := l.locks[jobName]
+	return ok && time.Now().Before(lock.expiresAt)
+}
+
+func TestManager_Execute_RunOutlivesLockTTL_KeepsLockUntilFinished(t *testing.T) {
+	const ttl = 60 * time.Millisecond
+	locker := newMemoryLocker()
+	replicaA := NewManager(WithLocker(locker), WithLockTTL(ttl), WithInstanceID("replica-a"))
+	replicaB := NewManager(WithLocker(locker), WithLockTTL(ttl), WithInstanceID("replica-b"))
+	t.Cleanup(func() {
+		replicaA.Shutdown(time.Second)
+		replicaB.Shutdown(time.Second)
+	})
+
+	var runs atomic.Int32
+	started := make(chan struct{})
+	job := Job{
+		Name:      "slow-job",
+		Interval:  time.Minute,
+		Exclusive: true,
+		Fn: func(ctx context.Context) error {
+			if runs.Add(1) == 1 {
+				close(started)
+			}
+			select {
+			case <-time.After(5 * ttl):
+			case <-ctx.Done():
+			}
+			return ctx.Err()
+		},
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		replicaA.execute(job)
+	}()
+	<-started
+
+	// TTLを超えても延長されているため、他のレプリカは実行できない
+	time.Sleep(3 * ttl)
+	replicaB.execute(job)
+	assert.Equal(t, int32(1), runs.Load())
+	assert.True(t, locker.held(job.Name))
+
+	<-done
+	assert.False(t, locker.held(job.Name), "lock should be released after the run")
+
+	// 解放後は次の周期を待たずに他のレプリカが実行できる
+	var rerun atomic.Bool
+	job.Fn = func(context.Context) error {
+		rerun.Store(true)
+		return nil
+	}
+	replicaB.execute(job)
+	assert.True(t, rerun.Load())
+}
+
+func TestManager_Execute_LockLost_CancelsRun(t *testing.T) {
+	const ttl = 60 * time.Millisecond
+	locker := newMemoryLocker()
+	m := NewManager(WithLocker(locker), WithLockTTL(ttl), WithInstanceID("replica-a"))
+	t.Cleanup(func() { m.Shutdown(time.Second) })
+
+	started := make(chan struct{})
+	var runErr error
+	job := Job{
+		Name:      "slow-job",
+		Interval:  time.Minute,
+		Exclusive: true,
+		Fn: func(ctx context.Context) error {
+			close(started)
+			select {
+			case <-time.After(10 * ttl):
+			case <-ctx.Done():
+			}
+			runErr = ctx.Err()
+			return runErr
+		},
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		m.execute(job)
+	}()
+	<-started
+	locker.steal(job.Name)
+
+	select {
+	case <-done:
+	case <-time.After(5 * ttl):
+		t.Fatal("run was not cancelled after losing the lock")
+	}
+	require.ErrorIs(t, runErr, context.Canceled)
+	// 他のレプリカが取得し直したロックは解放しない
+	assert.True(t, locker.held(job.Name))
+}
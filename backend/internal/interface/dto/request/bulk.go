@@ -0,0 +1,30 @@
+package request
+
+// BulkItemsRequest は一括操作の対象アイテムを指定するリクエストです
+type BulkItemsRequest struct {
+	FileIDs   []string `json:"fileIds"`
+	FolderIDs []string `json:"folderIds"`
+}
+
+// BulkMoveRequest は一括移動リクエストです
+type BulkMoveRequest struct {
+	BulkItemsRequest
+	DestinationFolderID *string `json:"destinationFolderId"`
+}
+
+// BulkCopyRequest は一括コピーリクエストです
+type BulkCopyRequest struct {
+	BulkItemsRequest
+	DestinationFolderID *string `json:"destinationFolderId"`
+}
+
+// BulkTrashRequest は一括ゴミ箱移動リクエストです
+type BulkTrashRequest struct {
+	BulkItemsRequest
+}
+
+// BulkDeleteRequest は一括削除リクエストです
+// fileIds にはゴミ箱内のアーカイブファイルIDを指定します
+type BulkDeleteRequest struct {
+	BulkItemsRequest
+}
@@ -0,0 +1,11 @@
+package request
+
+// CopyFileRequest はファイルコピーリクエストです
+type CopyFileRequest struct {
+	DestinationFolderID *string `json:"destinationFolderId"`
+}
+
+// CopyFolderRequest はフォルダコピーリクエストです
+type CopyFolderRequest struct {
+	DestinationFolderID *string `json:"destinationFolderId"`
+}
@@ -6,14 +6,45 @@ type InitiateUploadRequest struct {
 	FileName string  `json:"fileName" validate:"required,min=1,max=255"`
 	MimeType string  `json:"mimeType" validate:"required"`
 	Size     int64   `json:"size" validate:"required,min=1"`
+	// 同名ファイルが存在する場合の解決方法: fail（デフォルト）, rename, overwrite_as_new_version, skip
+	ConflictStrategy string `json:"conflictStrategy"`
+	// アップロード内容の期待チェックサム（任意）: sha256 または crc32c、値は16進数かBase64
+	ChecksumAlgorithm string `json:"checksumAlgorithm"`
+	Checksum          string `json:"checksum"`
 }
 
-// CompleteUploadRequest はアップロード完了リクエストです（Webhook用）
-type CompleteUploadRequest struct {
-	StorageKey     string `json:"storageKey" validate:"required"`
-	MinioVersionID string `json:"minioVersionId"`
-	Size           int64  `json:"size" validate:"required"`
-	ETag           string `json:"etag" validate:"required"`
+// InitiateVersionUploadRequest は新バージョンのアップロード開始リクエストです
+type InitiateVersionUploadRequest struct {
+	Size int64 `json:"size" validate:"required,min=1"`
+	// アップロード内容の期待チェックサム（任意）: sha256 または crc32c、値は16進数かBase64
+	ChecksumAlgorithm string `json:"checksumAlgorithm"`
+	Checksum          string `json:"checksum"`
+}
+
+// BucketNotification はMinIOのバケット通知（S3イベント形式）です（Webhook用）
+type BucketNotification struct {
+	EventName string                     `json:"EventName"`
+	Key       string                     `json:"Key"`
+	Records   []BucketNotificationRecord `json:"Records"`
+}
+
+// BucketNotificationRecord はバケット通知の1イベントです
+type BucketNotificationRecord struct {
+	EventName string               `json:"eventName"`
+	S3        BucketNotificationS3 `json:"s3"`
+}
+
+// BucketNotificationS3 はイベント対象のオブジェクトです
+type BucketNotificationS3 struct {
+	Object BucketNotificationObject `json:"object"`
+}
+
+// BucketNotificationObject はイベント対象のオブジェクトです（KeyはURLエンコード済み）
+type BucketNotificationObject struct {
+	Key       string `json:"key"`
+	Size      int64  `json:"size"`
+	ETag      string `json:"eTag"`
+	VersionID string `json:"versionId"`
 }
 
 // RenameFileRequest はファイル名変更リクエストです
@@ -24,9 +55,25 @@ type RenameFileRequest struct {
 // MoveFileRequest はファイル移動リクエストです
 type MoveFileRequest struct {
 	NewFolderID *string `json:"newFolderId"`
+	// 同名ファイルが存在する場合の解決方法: fail（デフォルト）, rename, overwrite_as_new_version, skip
+	ConflictStrategy string `json:"conflictStrategy"`
 }
 
 // RestoreFileRequest はファイル復元リクエストです
 type RestoreFileRequest struct {
 	RestoreFolderID *string `json:"restoreFolderId"`
+	// 同名ファイルが存在する場合の解決方法: fail（デフォルト）, rename, overwrite_as_new_version, skip
+	ConflictStrategy string `json:"conflictStrategy"`
+}
+
+// RestoreFolderRequest はフォルダ復元リクエストです
+type RestoreFolderRequest struct {
+	RestoreFolderID *string `json:"restoreFolderId"`
+}
+
+// SetVersionRetentionPolicyRequest はバージョン保持ポリシー設定リクエストです
+// keepLastN と keepDays の両方に null を指定するとポリシーを解除します
+type SetVersionRetentionPolicyRequest struct {
+	KeepLastN *int `json:"keepLastN" validate:"omitempty,min=1"`
+	KeepDays  *int `json:"keepDays" validate:"omitempty,min=1"`
 }
@@ -27,3 +27,9 @@ type ChangeRoleRequest struct {
 type TransferOwnershipRequest struct {
 	NewOwnerID string `json:"newOwnerId" validate:"required,uuid"`
 }
+
+// SetGroupQuotaRequest はグループクォータ設定リクエストです
+// limitBytes に null を指定するとクォータを解除します
+type SetGroupQuotaRequest struct {
+	LimitBytes *int64 `json:"limitBytes" validate:"omitempty,min=0"`
+}
@@ -0,0 +1,61 @@
+package response
+
+import (
+	"time"
+
+	auditqry "github.com/Hiro-mackay/gc-storage/backend/internal/usecase/audit/query"
+)
+
+// AuditLogResponse は監査ログレスポンスです
+type AuditLogResponse struct {
+	ID           string                 `json:"id"`
+	UserID       *string                `json:"userId"`
+	Action       string                 `json:"action"`
+	ResourceType string                 `json:"resourceType"`
+	ResourceID   *string                `json:"resourceId"`
+	Details      map[string]interface{} `json:"details,omitempty"`
+	IPAddress    string                 `json:"ipAddress,omitempty"`
+	UserAgent    string                 `json:"userAgent,omitempty"`
+	RequestID    string                 `json:"requestId,omitempty"`
+	CreatedAt    time.Time              `json:"createdAt"`
+}
+
+// AuditLogListResponse は監査ログ一覧レスポンスです
+type AuditLogListResponse struct {
+	Items      []AuditLogResponse `json:"items"`
+	NextCursor *string            `json:"nextCursor"`
+}
+
+// ToAuditLogListResponse はUseCaseの出力からレスポンスに変換します
+func ToAuditLogListResponse(output *auditqry.ListAuditLogsOutput) AuditLogListResponse {
+	items := make([]AuditLogResponse, len(output.Items))
+	for i, log := range output.Items {
+		var userID *string
+		if log.UserID != nil {
+			s := log.UserID.String()
+			userID = &s
+		}
+		var resourceID *string
+		if log.ResourceID != nil {
+			s := log.ResourceID.String()
+			resourceID = &s
+		}
+		items[i] = AuditLogResponse{
+			ID:           log.ID.String(),
+			UserID:       userID,
+			Action:       string(log.Action),
+			ResourceType: string(log.ResourceType),
+			ResourceID:   resourceID,
+			Details:      log.Details,
+			IPAddress:    log.IPAddress,
+			UserAgent:    log.UserAgent,
+			RequestID:    log.RequestID,
+			CreatedAt:    log.CreatedAt,
+		}
+	}
+
+	return AuditLogListResponse{
+		Items:      items,
+		NextCursor: output.NextCursor,
+	}
+}
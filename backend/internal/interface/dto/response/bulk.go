@@ -0,0 +1,67 @@
+package response
+
+import (
+	"errors"
+
+	storagecmd "github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/command"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// BulkItemErrorResponse はアイテムごとのエラーレスポンスです
+type BulkItemErrorResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// BulkItemResultResponse はアイテムごとの処理結果レスポンスです
+type BulkItemResultResponse struct {
+	ID      string                 `json:"id"`
+	Type    string                 `json:"type"`
+	Success bool                   `json:"success"`
+	Error   *BulkItemErrorResponse `json:"error,omitempty"`
+}
+
+// BulkOperationResponse は一括操作レスポンスです
+type BulkOperationResponse struct {
+	Results        []BulkItemResultResponse `json:"results"`
+	SucceededCount int                      `json:"succeededCount"`
+	FailedCount    int                      `json:"failedCount"`
+}
+
+// ToBulkOperationResponse はUseCaseの出力からレスポンスに変換します
+// AppError以外のエラーは内部エラーとして詳細を伏せます
+func ToBulkOperationResponse(output *storagecmd.BulkOutput) BulkOperationResponse {
+	results := make([]BulkItemResultResponse, 0, len(output.Results))
+	for _, r := range output.Results {
+		result := BulkItemResultResponse{
+			ID:      r.ID.String(),
+			Type:    string(r.Type),
+			Success: r.Succeeded(),
+		}
+		if !r.Succeeded() {
+			result.Error = toBulkItemError(r.Err)
+		}
+		results = append(results, result)
+	}
+
+	return BulkOperationResponse{
+		Results:        results,
+		SucceededCount: output.SucceededCount(),
+		FailedCount:    output.FailedCount(),
+	}
+}
+
+// toBulkItemError はエラーをアイテムごとのエラーレスポンスに変換します
+func toBulkItemError(err error) *BulkItemErrorResponse {
+	var appErr *apperror.AppError
+	if apperror.IsClientError(err) && errors.As(err, &appErr) {
+		return &BulkItemErrorResponse{
+			Code:    string(appErr.Code),
+			Message: appErr.Message,
+		}
+	}
+	return &BulkItemErrorResponse{
+		Code:    string(apperror.CodeInternalError),
+		Message: "internal server error",
+	}
+}
@@ -0,0 +1,62 @@
+package response
+
+import (
+	"time"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	storagecmd "github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/command"
+)
+
+// CopyFolderResponse は同期的に完了したフォルダコピーのレスポンスです
+type CopyFolderResponse struct {
+	Folder      FolderResponse `json:"folder"`
+	FolderCount int            `json:"folderCount"`
+	FileCount   int            `json:"fileCount"`
+}
+
+// CopyJobResponse は非同期コピージョブのレスポンスです
+type CopyJobResponse struct {
+	ID                  string     `json:"id"`
+	SourceFolderID      string     `json:"sourceFolderId"`
+	DestinationFolderID string     `json:"destinationFolderId"`
+	Status              string     `json:"status"`
+	ResultFolderID      *string    `json:"resultFolderId"`
+	FolderCount         int        `json:"folderCount"`
+	FileCount           int        `json:"fileCount"`
+	Error               *string    `json:"error"`
+	CreatedAt           time.Time  `json:"createdAt"`
+	StartedAt           *time.Time `json:"startedAt"`
+	FinishedAt          *time.Time `json:"finishedAt"`
+}
+
+// ToCopyFolderResponse はフォルダコピー結果をレスポンスに変換します
+func ToCopyFolderResponse(output *storagecmd.CopyFolderOutput) CopyFolderResponse {
+	return CopyFolderResponse{
+		Folder:      ToFolderResponse(output.Folder),
+		FolderCount: output.FolderCount,
+		FileCount:   output.FileCount,
+	}
+}
+
+// ToCopyJobResponse はエンティティからレスポンスに変換します
+func ToCopyJobResponse(job *entity.CopyJob) CopyJobResponse {
+	var resultFolderID *string
+	if job.ResultFolderID != nil {
+		id := job.ResultFolderID.String()
+		resultFolderID = &id
+	}
+
+	return CopyJobResponse{
+		ID:                  job.ID.String(),
+		SourceFolderID:      job.SourceFolderID.String(),
+		DestinationFolderID: job.DestinationFolderID.String(),
+		Status:              string(job.Status),
+		ResultFolderID:      resultFolderID,
+		FolderCount:         job.FolderCount,
+		FileCount:           job.FileCount,
+		Error:               job.ErrorMessage,
+		CreatedAt:           job.CreatedAt,
+		StartedAt:           job.StartedAt,
+		FinishedAt:          job.FinishedAt,
+	}
+}
@@ -0,0 +1,31 @@
+package response
+
+import (
+	storageqry "github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/query"
+)
+
+// DuplicateFileGroupResponse は同じ内容を持つファイルのまとまりのレスポンスです
+type DuplicateFileGroupResponse struct {
+	Checksum string         `json:"checksum"`
+	Size     int64          `json:"size"`
+	Files    []FileResponse `json:"files"`
+}
+
+// DuplicateFilesResponse は重複ファイル一覧レスポンスです
+type DuplicateFilesResponse struct {
+	Groups []DuplicateFileGroupResponse `json:"groups"`
+}
+
+// ToDuplicateFilesResponse はUseCaseの出力からレスポンスに変換します
+func ToDuplicateFilesResponse(output *storageqry.ListDuplicateFilesOutput) DuplicateFilesResponse {
+	groups := make([]DuplicateFileGroupResponse, 0, len(output.Groups))
+	for _, g := range output.Groups {
+		groups = append(groups, DuplicateFileGroupResponse{
+			Checksum: g.Checksum,
+			Size:     g.Size,
+			Files:    ToFileListResponse(g.Files),
+		})
+	}
+
+	return DuplicateFilesResponse{Groups: groups}
+}
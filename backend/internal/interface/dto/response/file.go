@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
 	storagecmd "github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/command"
 	storageqry "github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/query"
 )
@@ -23,19 +24,24 @@ type FileResponse struct {
 }
 
 // InitiateUploadResponse はアップロード開始レスポンスです
+// skipped の場合はアップロードセッションは作成されず、sessionId は空になります
 type InitiateUploadResponse struct {
-	SessionID   string              `json:"sessionId"`
-	FileID      string              `json:"fileId"`
-	IsMultipart bool                `json:"isMultipart"`
-	UploadURLs  []UploadURLResponse `json:"uploadUrls"`
-	ExpiresAt   time.Time           `json:"expiresAt"`
+	SessionID    string              `json:"sessionId"`
+	FileID       string              `json:"fileId"`
+	FileName     string              `json:"fileName"`
+	IsMultipart  bool                `json:"isMultipart"`
+	IsNewVersion bool                `json:"isNewVersion"`
+	Skipped      bool                `json:"skipped"`
+	UploadURLs   []UploadURLResponse `json:"uploadUrls"`
+	ExpiresAt    time.Time           `json:"expiresAt"`
 }
 
 // UploadURLResponse はアップロードURL情報です
 type UploadURLResponse struct {
-	PartNumber int       `json:"partNumber"`
-	URL        string    `json:"url"`
-	ExpiresAt  time.Time `json:"expiresAt"`
+	PartNumber int               `json:"partNumber"`
+	URL        string            `json:"url"`
+	Headers    map[string]string `json:"headers,omitempty"` // PUT時に送信が必要なヘッダー（申告したチェックサム）
+	ExpiresAt  time.Time         `json:"expiresAt"`
 }
 
 // UploadStatusResponse はアップロード状態レスポンスです
@@ -61,6 +67,23 @@ type DownloadURLResponse struct {
 	ExpiresAt     time.Time `json:"expiresAt"`
 }
 
+// FilePreviewResponse はファイルプレビューレスポンスです
+type FilePreviewResponse struct {
+	FileID        string     `json:"fileId"`
+	FileName      string     `json:"fileName"`
+	MimeType      string     `json:"mimeType"`
+	Size          int64      `json:"size"`
+	VersionNumber int        `json:"versionNumber"`
+	Format        string     `json:"format"`
+	Encoding      string     `json:"encoding"`
+	Content       string     `json:"content"`
+	Rows          [][]string `json:"rows,omitempty"`
+	HTML          string     `json:"html,omitempty"`
+	LineCount     int        `json:"lineCount"`
+	PreviewBytes  int        `json:"previewBytes"`
+	Truncated     bool       `json:"truncated"`
+}
+
 // FileVersionResponse はファイルバージョンレスポンスです
 type FileVersionResponse struct {
 	ID            string    `json:"id"`
@@ -70,6 +93,7 @@ type FileVersionResponse struct {
 	UploadedBy    string    `json:"uploadedBy"`
 	CreatedAt     time.Time `json:"createdAt"`
 	IsLatest      bool      `json:"isLatest"`
+	IsPinned      bool      `json:"isPinned"`
 }
 
 // FileVersionsResponse はファイルバージョン一覧レスポンスです
@@ -79,13 +103,23 @@ type FileVersionsResponse struct {
 	Versions []FileVersionResponse `json:"versions"`
 }
 
+// RestoreFileVersionResponse はファイルバージョン復元レスポンスです
+type RestoreFileVersionResponse struct {
+	FileID              string `json:"fileId"`
+	RestoredFromVersion int    `json:"restoredFromVersion"`
+	VersionNumber       int    `json:"versionNumber"`
+	Size                int64  `json:"size"`
+}
+
 // TrashItemResponse はゴミ箱アイテムレスポンスです
-// Note: OriginalFolderIDは必須。ファイルは必ずフォルダに所属。
+// Note: OriginalFolderIDは必須。ファイルは復元先フォルダID、フォルダは元のフォルダID。
+// OriginalFileIDはファイルのみ、OriginalParentIDは親を持つフォルダのみ設定されます。
 type TrashItemResponse struct {
 	ID               string    `json:"id"`
 	Type             string    `json:"type"`
-	OriginalFileID   string    `json:"originalFileId"`
+	OriginalFileID   string    `json:"originalFileId,omitempty"`
 	OriginalFolderID string    `json:"originalFolderId"`
+	OriginalParentID *string   `json:"originalParentId,omitempty"`
 	OriginalPath     string    `json:"originalPath"`
 	Name             string    `json:"name"`
 	MimeType         string    `json:"mimeType"`
@@ -106,6 +140,23 @@ type CompleteUploadResponse struct {
 	FileID    string `json:"fileId"`
 	SessionID string `json:"sessionId"`
 	Completed bool   `json:"completed"`
+	Verifying bool   `json:"verifying"` // 内容の検証待ち（完了はステータスAPIで確認）
+}
+
+// UploadWebhookResponse はバケット通知Webhookのレスポンスです
+type UploadWebhookResponse struct {
+	Uploads []CompleteUploadResponse `json:"uploads"` // 処理したアップロード
+}
+
+// UploadPartResponse はサーバー経由のパートアップロードレスポンスです
+type UploadPartResponse struct {
+	SessionID     string `json:"sessionId"`
+	FileID        string `json:"fileId"`
+	PartNumber    int    `json:"partNumber"`
+	UploadedParts int    `json:"uploadedParts"`
+	TotalParts    int    `json:"totalParts"`
+	Completed     bool   `json:"completed"`
+	Verifying     bool   `json:"verifying"`
 }
 
 // RenameFileResponse はファイル名変更レスポンスです
@@ -116,8 +167,12 @@ type RenameFileResponse struct {
 
 // MoveFileResponse はファイル移動レスポンスです
 type MoveFileResponse struct {
-	FileID   string `json:"fileId"`
-	FolderID string `json:"folderId"`
+	FileID        string `json:"fileId"`
+	FolderID      string `json:"folderId"`
+	Name          string `json:"name"`
+	Skipped       bool   `json:"skipped"`
+	Overwritten   bool   `json:"overwritten"`
+	VersionNumber int    `json:"versionNumber,omitempty"`
 }
 
 // TrashFileResponse はファイルゴミ箱移動レスポンスです
@@ -128,9 +183,21 @@ type TrashFileResponse struct {
 
 // RestoreFileResponse はファイル復元レスポンスです
 type RestoreFileResponse struct {
-	FileID   string `json:"fileId"`
-	FolderID string `json:"folderId"`
-	Name     string `json:"name"`
+	FileID        string `json:"fileId"`
+	FolderID      string `json:"folderId"`
+	Name          string `json:"name"`
+	Skipped       bool   `json:"skipped"`
+	Overwritten   bool   `json:"overwritten"`
+	VersionNumber int    `json:"versionNumber,omitempty"`
+}
+
+// RestoreFolderResponse はフォルダ復元レスポンスです
+type RestoreFolderResponse struct {
+	FolderID    string  `json:"folderId"`
+	ParentID    *string `json:"parentId"`
+	Name        string  `json:"name"`
+	FolderCount int     `json:"folderCount"`
+	FileCount   int     `json:"fileCount"`
 }
 
 // EmptyTrashResponse はゴミ箱空にするレスポンスです
@@ -177,17 +244,25 @@ func ToInitiateUploadResponse(output *storagecmd.InitiateUploadOutput) InitiateU
 		urls[i] = UploadURLResponse{
 			PartNumber: u.PartNumber,
 			URL:        u.URL,
+			Headers:    u.Headers,
 			ExpiresAt:  u.ExpiresAt,
 		}
 	}
 
-	return InitiateUploadResponse{
-		SessionID:   output.SessionID.String(),
-		FileID:      output.FileID.String(),
-		IsMultipart: output.IsMultipart,
-		UploadURLs:  urls,
-		ExpiresAt:   output.ExpiresAt,
+	resp := InitiateUploadResponse{
+		SessionID:    output.SessionID.String(),
+		FileID:       output.FileID.String(),
+		FileName:     output.FileName,
+		IsMultipart:  output.IsMultipart,
+		IsNewVersion: output.IsNewVersion,
+		Skipped:      output.Skipped,
+		UploadURLs:   urls,
+		ExpiresAt:    output.ExpiresAt,
+	}
+	if output.Skipped {
+		resp.SessionID = ""
 	}
+	return resp
 }
 
 // ToUploadStatusResponse はUseCaseの出力からレスポンスに変換します
@@ -217,6 +292,30 @@ func ToDownloadURLResponse(output *storageqry.GetDownloadURLOutput) DownloadURLR
 	}
 }
 
+// ToFilePreviewResponse はUseCaseの出力からレスポンスに変換します
+func ToFilePreviewResponse(output *storageqry.GetFilePreviewOutput) FilePreviewResponse {
+	return newFilePreviewResponse(output.FileID.String(), output.FileName, output.MimeType, output.Size, output.VersionNumber, output.Preview)
+}
+
+// newFilePreviewResponse はファイル情報とプレビューからレスポンスを作成します
+func newFilePreviewResponse(fileID, fileName, mimeType string, size int64, versionNumber int, preview *service.FilePreview) FilePreviewResponse {
+	return FilePreviewResponse{
+		FileID:        fileID,
+		FileName:      fileName,
+		MimeType:      mimeType,
+		Size:          size,
+		VersionNumber: versionNumber,
+		Format:        string(preview.Format),
+		Encoding:      preview.Encoding,
+		Content:       preview.Content,
+		Rows:          preview.Rows,
+		HTML:          preview.HTML,
+		LineCount:     preview.LineCount,
+		PreviewBytes:  preview.BytesRead,
+		Truncated:     preview.Truncated,
+	}
+}
+
 // ToFileVersionsResponse はUseCaseの出力からレスポンスに変換します
 func ToFileVersionsResponse(output *storageqry.ListFileVersionsOutput) FileVersionsResponse {
 	versions := make([]FileVersionResponse, len(output.Versions))
@@ -229,6 +328,7 @@ func ToFileVersionsResponse(output *storageqry.ListFileVersionsOutput) FileVersi
 			UploadedBy:    v.UploadedBy.String(),
 			CreatedAt:     v.CreatedAt,
 			IsLatest:      v.IsLatest,
+			IsPinned:      v.IsPinned,
 		}
 	}
 
@@ -245,8 +345,7 @@ func ToTrashListResponse(output *storageqry.ListTrashOutput) TrashListResponse {
 	for i, item := range output.Items {
 		items[i] = TrashItemResponse{
 			ID:               item.ID.String(),
-			Type:             "file",
-			OriginalFileID:   item.OriginalFileID.String(),
+			Type:             string(item.Type),
 			OriginalFolderID: item.OriginalFolderID.String(),
 			OriginalPath:     item.OriginalPath,
 			Name:             item.Name,
@@ -256,6 +355,13 @@ func ToTrashListResponse(output *storageqry.ListTrashOutput) TrashListResponse {
 			ExpiresAt:        item.ExpiresAt,
 			DaysUntilExpiry:  item.DaysUntilExpiry,
 		}
+		if item.Type == storageqry.TrashItemTypeFile {
+			items[i].OriginalFileID = item.OriginalFileID.String()
+		}
+		if item.OriginalParentID != nil {
+			s := item.OriginalParentID.String()
+			items[i].OriginalParentID = &s
+		}
 	}
 
 	var nextCursor *string
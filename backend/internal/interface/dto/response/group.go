@@ -171,3 +171,19 @@ func ToPendingInvitationListResponse(invitations []*query.InvitationWithGroup) [
 	}
 	return responses
 }
+
+// GroupQuotaResponse はグループクォータレスポンスです
+type GroupQuotaResponse struct {
+	GroupID    string `json:"groupId"`
+	LimitBytes *int64 `json:"limitBytes"` // 未設定の場合はnull
+	UsedBytes  int64  `json:"usedBytes"`
+}
+
+// ToGroupQuotaResponse はGetGroupQuotaOutputからGroupQuotaResponseに変換します
+func ToGroupQuotaResponse(output *query.GetGroupQuotaOutput) GroupQuotaResponse {
+	return GroupQuotaResponse{
+		GroupID:    output.GroupID.String(),
+		LimitBytes: output.LimitBytes,
+		UsedBytes:  output.UsedBytes,
+	}
+}
@@ -0,0 +1,67 @@
+package response
+
+import (
+	"time"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	storageqry "github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/query"
+)
+
+// SearchItemResponse は検索結果アイテムレスポンスです
+// Note: mimeType / size はファイルの場合のみ設定される
+type SearchItemResponse struct {
+	Type      string    `json:"type"`
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	MimeType  string    `json:"mimeType,omitempty"`
+	Size      int64     `json:"size,omitempty"`
+	ParentID  *string   `json:"parentId"`
+	OwnerID   string    `json:"ownerId"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// SearchResponse は検索レスポンスです
+type SearchResponse struct {
+	Items      []SearchItemResponse `json:"items"`
+	NextCursor *string              `json:"nextCursor"`
+}
+
+// ToSearchResponse はUseCaseの出力からレスポンスに変換します
+func ToSearchResponse(output *storageqry.SearchOutput) SearchResponse {
+	items := make([]SearchItemResponse, len(output.Items))
+	for i, item := range output.Items {
+		if item.Type == repository.SearchItemTypeFolder {
+			var parentID *string
+			if item.Folder.ParentID != nil {
+				s := item.Folder.ParentID.String()
+				parentID = &s
+			}
+			items[i] = SearchItemResponse{
+				Type:      string(item.Type),
+				ID:        item.Folder.ID.String(),
+				Name:      item.Folder.Name.String(),
+				ParentID:  parentID,
+				OwnerID:   item.Folder.OwnerID.String(),
+				CreatedAt: item.Folder.CreatedAt,
+				UpdatedAt: item.Folder.UpdatedAt,
+			}
+			continue
+		}
+
+		folderID := item.File.FolderID.String()
+		items[i] = SearchItemResponse{
+			Type:      string(item.Type),
+			ID:        item.File.ID.String(),
+			Name:      item.File.Name.String(),
+			MimeType:  item.File.MimeType.String(),
+			Size:      item.File.Size,
+			ParentID:  &folderID,
+			OwnerID:   item.File.OwnerID.String(),
+			CreatedAt: item.File.CreatedAt,
+			UpdatedAt: item.File.UpdatedAt,
+		}
+	}
+
+	return SearchResponse{Items: items, NextCursor: output.NextCursor}
+}
@@ -185,3 +185,8 @@ func ToShareDownloadResponse(output *sharingqry.GetDownloadViaShareOutput) Share
 		ExpiresAt:    output.ExpiresAt.Format(time.RFC3339),
 	}
 }
+
+// ToSharePreviewResponse は共有リンク経由プレビューの出力からレスポンスに変換します
+func ToSharePreviewResponse(output *sharingqry.GetPreviewViaShareOutput) FilePreviewResponse {
+	return newFilePreviewResponse(output.FileID.String(), output.FileName, output.MimeType, output.Size, output.VersionNumber, output.Preview)
+}
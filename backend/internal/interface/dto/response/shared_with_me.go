@@ -0,0 +1,70 @@
+package response
+
+import (
+	"time"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	storageqry "github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/query"
+)
+
+// SharedByResponse は共有した付与者のレスポンスです
+// Note: email / name は付与者が削除済みの場合は空になる
+type SharedByResponse struct {
+	ID    string `json:"id"`
+	Email string `json:"email,omitempty"`
+	Name  string `json:"name,omitempty"`
+}
+
+// SharedItemResponse は自分に共有されたアイテムのレスポンスです
+// Note: mimeType / size はファイルの場合のみ設定される
+type SharedItemResponse struct {
+	Type      string           `json:"type"`
+	ID        string           `json:"id"`
+	Name      string           `json:"name"`
+	MimeType  string           `json:"mimeType,omitempty"`
+	Size      int64            `json:"size,omitempty"`
+	OwnerID   string           `json:"ownerId"`
+	Role      string           `json:"role"`
+	SharedVia string           `json:"sharedVia"`
+	SharedBy  SharedByResponse `json:"sharedBy"`
+	SharedAt  time.Time        `json:"sharedAt"`
+	UpdatedAt time.Time        `json:"updatedAt"`
+}
+
+// SharedWithMeResponse は共有アイテム一覧レスポンスです
+type SharedWithMeResponse struct {
+	Items      []SharedItemResponse `json:"items"`
+	NextCursor *string              `json:"nextCursor"`
+}
+
+// ToSharedWithMeResponse はUseCaseの出力からレスポンスに変換します
+func ToSharedWithMeResponse(output *storageqry.ListSharedWithMeOutput) SharedWithMeResponse {
+	items := make([]SharedItemResponse, len(output.Items))
+	for i, item := range output.Items {
+		resp := SharedItemResponse{
+			Type:      item.Type.String(),
+			ID:        item.ID().String(),
+			Name:      item.Name(),
+			Role:      item.Role.String(),
+			SharedVia: item.SharedVia.String(),
+			SharedBy:  SharedByResponse{ID: item.SharedBy.String()},
+			SharedAt:  item.SharedAt,
+		}
+		if item.Grantor != nil {
+			resp.SharedBy.Email = item.Grantor.Email.String()
+			resp.SharedBy.Name = item.Grantor.Name
+		}
+		if item.Type == authz.ResourceTypeFolder {
+			resp.OwnerID = item.Folder.OwnerID.String()
+			resp.UpdatedAt = item.Folder.UpdatedAt
+		} else {
+			resp.MimeType = item.File.MimeType.String()
+			resp.Size = item.File.Size
+			resp.OwnerID = item.File.OwnerID.String()
+			resp.UpdatedAt = item.File.UpdatedAt
+		}
+		items[i] = resp
+	}
+
+	return SharedWithMeResponse{Items: items, NextCursor: output.NextCursor}
+}
@@ -0,0 +1,38 @@
+package response
+
+import (
+	storageqry "github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/query"
+)
+
+// GroupStorageUsageResponse は所属グループのクォータと使用量レスポンスです
+type GroupStorageUsageResponse struct {
+	GroupID    string `json:"groupId"`
+	LimitBytes int64  `json:"limitBytes"`
+	UsedBytes  int64  `json:"usedBytes"`
+}
+
+// StorageUsageResponse はストレージ使用量レスポンスです
+// Note: limitBytes が0の場合は無制限
+type StorageUsageResponse struct {
+	UsedBytes  int64                       `json:"usedBytes"`
+	LimitBytes int64                       `json:"limitBytes"`
+	Groups     []GroupStorageUsageResponse `json:"groups"`
+}
+
+// ToStorageUsageResponse はUseCaseの出力からレスポンスに変換します
+func ToStorageUsageResponse(output *storageqry.GetStorageUsageOutput) StorageUsageResponse {
+	groups := make([]GroupStorageUsageResponse, 0, len(output.Groups))
+	for _, g := range output.Groups {
+		groups = append(groups, GroupStorageUsageResponse{
+			GroupID:    g.GroupID.String(),
+			LimitBytes: g.LimitBytes,
+			UsedBytes:  g.UsedBytes,
+		})
+	}
+
+	return StorageUsageResponse{
+		UsedBytes:  output.UsedBytes,
+		LimitBytes: output.LimitBytes,
+		Groups:     groups,
+	}
+}
@@ -0,0 +1,38 @@
+package response
+
+import (
+	"time"
+
+	storageqry "github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/query"
+)
+
+// VersionRetentionPolicyResponse はバージョン保持ポリシーレスポンスです
+// Note: ポリシー未設定の場合、keepLastN と keepDays はどちらも null となり全バージョンが保持される
+type VersionRetentionPolicyResponse struct {
+	SubjectType string     `json:"subjectType"`
+	SubjectID   string     `json:"subjectId"`
+	KeepLastN   *int       `json:"keepLastN"`
+	KeepDays    *int       `json:"keepDays"`
+	UpdatedAt   *time.Time `json:"updatedAt"`
+}
+
+// ToVersionRetentionPolicyResponse はGetVersionRetentionPolicyOutputからVersionRetentionPolicyResponseに変換します
+func ToVersionRetentionPolicyResponse(output *storageqry.GetVersionRetentionPolicyOutput) VersionRetentionPolicyResponse {
+	resp := VersionRetentionPolicyResponse{
+		SubjectType: string(output.SubjectType),
+		SubjectID:   output.SubjectID.String(),
+	}
+	if output.Policy != nil {
+		resp.KeepLastN = output.Policy.KeepLastN
+		resp.KeepDays = output.Policy.KeepDays
+		resp.UpdatedAt = &output.Policy.UpdatedAt
+	}
+	return resp
+}
+
+// PinFileVersionResponse はファイルバージョンのピン留めレスポンスです
+type PinFileVersionResponse struct {
+	FileID        string `json:"fileId"`
+	VersionNumber int    `json:"versionNumber"`
+	IsPinned      bool   `json:"isPinned"`
+}
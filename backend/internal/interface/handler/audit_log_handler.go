@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"io"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/dto/response"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/middleware"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/presenter"
+	auditqry "github.com/Hiro-mackay/gc-storage/backend/internal/usecase/audit/query"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// AuditLogHandler は監査ログ関連のHTTPハンドラーです
+type AuditLogHandler struct {
+	listAuditLogsQuery   *auditqry.ListAuditLogsQuery
+	exportAuditLogsQuery *auditqry.ExportAuditLogsQuery
+}
+
+// NewAuditLogHandler は新しいAuditLogHandlerを作成します
+func NewAuditLogHandler(
+	listAuditLogsQuery *auditqry.ListAuditLogsQuery,
+	exportAuditLogsQuery *auditqry.ExportAuditLogsQuery,
+) *AuditLogHandler {
+	return &AuditLogHandler{
+		listAuditLogsQuery:   listAuditLogsQuery,
+		exportAuditLogsQuery: exportAuditLogsQuery,
+	}
+}
+
+// ListAuditLogs は監査ログを取得します
+// @Summary 監査ログ一覧取得
+// @Description 監査ログを記録日時の新しい順に取得します。閲覧できるのは自分の操作と、manage:access 権限を持つファイル・フォルダに対する操作のみです
+// @Tags AuditLogs
+// @Produce json
+// @Security SessionCookie
+// @Param actorId query string false "操作者ID（自分以外を指定する場合はresourceType/resourceIdで管理権限のあるファイル・フォルダを指定）"
+// @Param action query string false "アクションの前方一致（例: file.）"
+// @Param resourceType query string false "リソースタイプ" Enums(user, file, folder, group, share_link, permission)
+// @Param resourceId query string false "リソースID"
+// @Param from query string false "記録日時の下限（RFC3339、含む）"
+// @Param to query string false "記録日時の上限（RFC3339、含まない）"
+// @Param requestId query string false "リクエストID"
+// @Param limit query int false "取得件数"
+// @Param cursor query string false "カーソル"
+// @Success 200 {object} handler.SwaggerAuditLogListResponse
+// @Failure 400 {object} handler.SwaggerErrorResponse
+// @Failure 401 {object} handler.SwaggerErrorResponse
+// @Failure 403 {object} handler.SwaggerErrorResponse
+// @Router /audit-logs [get]
+func (h *AuditLogHandler) ListAuditLogs(c echo.Context) error {
+	claims := middleware.GetAccessClaims(c)
+	if claims == nil {
+		return apperror.NewUnauthorizedError("invalid token")
+	}
+
+	filter, err := parseAuditLogFilter(c)
+	if err != nil {
+		return err
+	}
+
+	input := auditqry.ListAuditLogsInput{
+		UserID: claims.UserID,
+		Filter: filter,
+		Cursor: c.QueryParam("cursor"),
+	}
+	if v := c.QueryParam("limit"); v != "" {
+		if input.Limit, err = strconv.Atoi(v); err != nil {
+			return apperror.NewValidationError("invalid limit", nil)
+		}
+	}
+
+	output, err := h.listAuditLogsQuery.Execute(c.Request().Context(), input)
+	if err != nil {
+		return err
+	}
+
+	return presenter.OK(c, response.ToAuditLogListResponse(output))
+}
+
+// ExportAuditLogs は監査ログをNDJSONまたはCSVでエクスポートします
+// @Summary 監査ログエクスポート
+// @Description 条件に一致する監査ログをすべてストリーミングでダウンロードします。絞り込み条件と閲覧範囲は一覧取得と同じです
+// @Tags AuditLogs
+// @Produce application/x-ndjson
+// @Produce text/csv
+// @Security SessionCookie
+// @Param format query string false "エクスポート形式（デフォルト: ndjson）" Enums(ndjson, csv)
+// @Param actorId query string false "操作者ID"
+// @Param action query string false "アクションの前方一致（例: file.）"
+// @Param resourceType query string false "リソースタイプ" Enums(user, file, folder, group, share_link, permission)
+// @Param resourceId query string false "リソースID"
+// @Param from query string false "記録日時の下限（RFC3339、含む）"
+// @Param to query string false "記録日時の上限（RFC3339、含まない）"
+// @Param requestId query string false "リクエストID"
+// @Success 200 {file} binary
+// @Failure 400 {object} handler.SwaggerErrorResponse
+// @Failure 401 {object} handler.SwaggerErrorResponse
+// @Failure 403 {object} handler.SwaggerErrorResponse
+// @Router /audit-logs/export [get]
+func (h *AuditLogHandler) ExportAuditLogs(c echo.Context) error {
+	claims := middleware.GetAccessClaims(c)
+	if claims == nil {
+		return apperror.NewUnauthorizedError("invalid token")
+	}
+
+	filter, err := parseAuditLogFilter(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	output, err := h.exportAuditLogsQuery.Execute(ctx, auditqry.ExportAuditLogsInput{
+		UserID: claims.UserID,
+		Filter: filter,
+		Format: auditqry.AuditLogExportFormat(c.QueryParam("format")),
+	})
+	if err != nil {
+		return err
+	}
+
+	return presenter.Attachment(c, output.Format.ContentType(), output.Filename, func(w io.Writer) error {
+		return output.Write(ctx, w)
+	})
+}
+
+// parseAuditLogFilter はクエリパラメータから監査ログの絞り込み条件を解析します
+func parseAuditLogFilter(c echo.Context) (auditqry.AuditLogFilter, error) {
+	filter := auditqry.AuditLogFilter{
+		ActionPrefix: c.QueryParam("action"),
+		RequestID:    c.QueryParam("requestId"),
+	}
+	if v := c.QueryParam("resourceType"); v != "" {
+		resourceType := entity.AuditResourceType(v)
+		filter.ResourceType = &resourceType
+	}
+
+	var err error
+	if filter.ActorID, err = parseUUIDQueryParam(c, "actorId"); err != nil {
+		return filter, apperror.NewValidationError("invalid actor ID", nil)
+	}
+	if filter.ResourceID, err = parseUUIDQueryParam(c, "resourceId"); err != nil {
+		return filter, apperror.NewValidationError("invalid resource ID", nil)
+	}
+	if filter.From, err = parseTimeQueryParam(c, "from"); err != nil {
+		return filter, apperror.NewValidationError("invalid from", nil)
+	}
+	if filter.To, err = parseTimeQueryParam(c, "to"); err != nil {
+		return filter, apperror.NewValidationError("invalid to", nil)
+	}
+
+	return filter, nil
+}
@@ -3,8 +3,10 @@ package handler
 import (
 	"net/http"
 
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/dto/request"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/dto/response"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/middleware"
@@ -86,6 +88,8 @@ func (h *AuthHandler) Register(c echo.Context) error {
 		return err
 	}
 
+	middleware.AuditHelperWithUser(c, output.UserID, string(entity.AuditActionRegister), string(entity.AuditResourceUser), &output.UserID, nil)
+
 	// Session IDをHttpOnly Cookieに設定（自動ログイン）
 	h.setSessionCookie(c, output.SessionID)
 
@@ -128,9 +132,18 @@ func (h *AuthHandler) Login(c echo.Context) error {
 		IPAddress: c.RealIP(),
 	})
 	if err != nil {
+		if apperror.IsUnauthorized(err) {
+			middleware.AuditHelper(c, string(entity.AuditActionLoginFailed), string(entity.AuditResourceUser), nil, map[string]interface{}{
+				"email": req.Email,
+			})
+		}
 		return err
 	}
 
+	middleware.AuditHelperWithUser(c, output.User.ID, string(entity.AuditActionLogin), string(entity.AuditResourceUser), &output.User.ID, map[string]interface{}{
+		"method": "password",
+	})
+
 	// Session IDをHttpOnly Cookieに設定
 	h.setSessionCookie(c, output.SessionID)
 
@@ -161,6 +174,10 @@ func (h *AuthHandler) Logout(c echo.Context) error {
 	// Logout error is intentionally ignored - always succeed for the user
 	_ = h.logoutCommand.Execute(c.Request().Context(), sessionID)
 
+	if userID, err := middleware.GetUserUUID(c); err == nil && userID != uuid.Nil {
+		middleware.AuditHelper(c, string(entity.AuditActionLogout), string(entity.AuditResourceUser), &userID, nil)
+	}
+
 	// Cookieを削除
 	h.clearSessionCookie(c)
 	middleware.ClearCSRFCookie(c)
@@ -304,6 +321,8 @@ func (h *AuthHandler) ResetPassword(c echo.Context) error {
 		return err
 	}
 
+	middleware.AuditHelperWithUser(c, output.UserID, string(entity.AuditActionPasswordReset), string(entity.AuditResourceUser), &output.UserID, nil)
+
 	return presenter.OK(c, response.ResetPasswordResponse{
 		Message: output.Message,
 	})
@@ -345,6 +364,8 @@ func (h *AuthHandler) ChangePassword(c echo.Context) error {
 		return err
 	}
 
+	middleware.AuditHelper(c, string(entity.AuditActionPasswordChange), string(entity.AuditResourceUser), &user.ID, nil)
+
 	return presenter.OK(c, response.ChangePasswordResponse{
 		Message: output.Message,
 	})
@@ -385,6 +406,10 @@ func (h *AuthHandler) SetPassword(c echo.Context) error {
 		return err
 	}
 
+	middleware.AuditHelper(c, string(entity.AuditActionPasswordChange), string(entity.AuditResourceUser), &user.ID, map[string]interface{}{
+		"initial_password": true,
+	})
+
 	return presenter.OK(c, response.SetPasswordResponse{
 		Message: output.Message,
 	})
@@ -426,6 +451,14 @@ func (h *AuthHandler) OAuthLogin(c echo.Context) error {
 		return err
 	}
 
+	auditAction := entity.AuditActionLogin
+	if output.IsNewUser {
+		auditAction = entity.AuditActionRegister
+	}
+	middleware.AuditHelperWithUser(c, output.User.ID, string(auditAction), string(entity.AuditResourceUser), &output.User.ID, map[string]interface{}{
+		"method": provider,
+	})
+
 	// Session IDをHttpOnly Cookieに設定
 	h.setSessionCookie(c, output.SessionID)
 
@@ -0,0 +1,291 @@
+package handler
+
+import (
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/dto/request"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/dto/response"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/middleware"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/presenter"
+	storagecmd "github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/command"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// BulkHandler はファイル・フォルダの一括操作のHTTPハンドラーです
+type BulkHandler struct {
+	bulkMoveCommand   *storagecmd.BulkMoveCommand
+	bulkTrashCommand  *storagecmd.BulkTrashCommand
+	bulkCopyCommand   *storagecmd.BulkCopyCommand
+	bulkDeleteCommand *storagecmd.BulkDeleteCommand
+}
+
+// NewBulkHandler は新しいBulkHandlerを作成します
+func NewBulkHandler(
+	bulkMoveCommand *storagecmd.BulkMoveCommand,
+	bulkTrashCommand *storagecmd.BulkTrashCommand,
+	bulkCopyCommand *storagecmd.BulkCopyCommand,
+	bulkDeleteCommand *storagecmd.BulkDeleteCommand,
+) *BulkHandler {
+	return &BulkHandler{
+		bulkMoveCommand:   bulkMoveCommand,
+		bulkTrashCommand:  bulkTrashCommand,
+		bulkCopyCommand:   bulkCopyCommand,
+		bulkDeleteCommand: bulkDeleteCommand,
+	}
+}
+
+// BulkMove はファイルとフォルダを一括で移動します
+// @Summary 一括移動
+// @Description 複数のファイルとフォルダを指定フォルダへ移動します。アイテムごとに結果を返し、一部のアイテムが失敗しても他のアイテムは処理されます
+// @Tags Bulk
+// @Accept json
+// @Produce json
+// @Security SessionCookie
+// @Param body body request.BulkMoveRequest true "対象アイテムと移動先フォルダ"
+// @Success 200 {object} handler.SwaggerBulkOperationResponse
+// @Failure 400 {object} handler.SwaggerErrorResponse
+// @Failure 401 {object} handler.SwaggerErrorResponse
+// @Router /bulk/move [post]
+func (h *BulkHandler) BulkMove(c echo.Context) error {
+	claims := middleware.GetAccessClaims(c)
+	if claims == nil {
+		return apperror.NewUnauthorizedError("invalid token")
+	}
+
+	var req request.BulkMoveRequest
+	if err := c.Bind(&req); err != nil {
+		return apperror.NewValidationError("invalid request body", nil)
+	}
+
+	items, err := parseBulkItems(req.BulkItemsRequest)
+	if err != nil {
+		return err
+	}
+	destinationFolderID, err := parseDestinationFolderID(req.DestinationFolderID)
+	if err != nil {
+		return err
+	}
+
+	output, err := h.bulkMoveCommand.Execute(c.Request().Context(), storagecmd.BulkMoveInput{
+		Items:               items,
+		DestinationFolderID: destinationFolderID,
+		UserID:              claims.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return bulkResult(c, "move", output, map[string]interface{}{
+		"after": map[string]interface{}{"folder_id": destinationFolderID},
+	})
+}
+
+// BulkTrash はファイルとフォルダを一括でゴミ箱に移動します
+// @Summary 一括ゴミ箱移動
+// @Description 複数のファイルとフォルダをゴミ箱に移動します。フォルダは配下のファイルがゴミ箱に移動され、フォルダ自体は削除されます
+// @Tags Bulk
+// @Accept json
+// @Produce json
+// @Security SessionCookie
+// @Param body body request.BulkTrashRequest true "対象アイテム"
+// @Success 200 {object} handler.SwaggerBulkOperationResponse
+// @Failure 400 {object} handler.SwaggerErrorResponse
+// @Failure 401 {object} handler.SwaggerErrorResponse
+// @Router /bulk/trash [post]
+func (h *BulkHandler) BulkTrash(c echo.Context) error {
+	claims := middleware.GetAccessClaims(c)
+	if claims == nil {
+		return apperror.NewUnauthorizedError("invalid token")
+	}
+
+	var req request.BulkTrashRequest
+	if err := c.Bind(&req); err != nil {
+		return apperror.NewValidationError("invalid request body", nil)
+	}
+
+	items, err := parseBulkItems(req.BulkItemsRequest)
+	if err != nil {
+		return err
+	}
+
+	output, err := h.bulkTrashCommand.Execute(c.Request().Context(), storagecmd.BulkTrashInput{
+		Items:  items,
+		UserID: claims.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return bulkResult(c, "trash", output, nil)
+}
+
+// BulkCopy はファイルとフォルダを一括でコピーします
+// @Summary 一括コピー
+// @Description 複数のファイルとフォルダを指定フォルダへサーバーサイドでコピーします。アイテムごとに結果を返します
+// @Tags Bulk
+// @Accept json
+// @Produce json
+// @Security SessionCookie
+// @Param body body request.BulkCopyRequest true "対象アイテムとコピー先フォルダ"
+// @Success 200 {object} handler.SwaggerBulkOperationResponse
+// @Failure 400 {object} handler.SwaggerErrorResponse
+// @Failure 401 {object} handler.SwaggerErrorResponse
+// @Router /bulk/copy [post]
+func (h *BulkHandler) BulkCopy(c echo.Context) error {
+	claims := middleware.GetAccessClaims(c)
+	if claims == nil {
+		return apperror.NewUnauthorizedError("invalid token")
+	}
+
+	var req request.BulkCopyRequest
+	if err := c.Bind(&req); err != nil {
+		return apperror.NewValidationError("invalid request body", nil)
+	}
+
+	items, err := parseBulkItems(req.BulkItemsRequest)
+	if err != nil {
+		return err
+	}
+	destinationFolderID, err := parseDestinationFolderID(req.DestinationFolderID)
+	if err != nil {
+		return err
+	}
+
+	output, err := h.bulkCopyCommand.Execute(c.Request().Context(), storagecmd.BulkCopyInput{
+		Items:               items,
+		DestinationFolderID: destinationFolderID,
+		UserID:              claims.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return bulkResult(c, "copy", output, map[string]interface{}{
+		"destination_folder_id": destinationFolderID,
+	})
+}
+
+// BulkDelete はファイルとフォルダを一括で削除します
+// @Summary 一括削除
+// @Description ゴミ箱内のファイル（アーカイブファイルID）を完全削除し、フォルダを削除します。アイテムごとに結果を返します
+// @Tags Bulk
+// @Accept json
+// @Produce json
+// @Security SessionCookie
+// @Param body body request.BulkDeleteRequest true "対象アイテム"
+// @Success 200 {object} handler.SwaggerBulkOperationResponse
+// @Failure 400 {object} handler.SwaggerErrorResponse
+// @Failure 401 {object} handler.SwaggerErrorResponse
+// @Router /bulk/delete [post]
+func (h *BulkHandler) BulkDelete(c echo.Context) error {
+	claims := middleware.GetAccessClaims(c)
+	if claims == nil {
+		return apperror.NewUnauthorizedError("invalid token")
+	}
+
+	var req request.BulkDeleteRequest
+	if err := c.Bind(&req); err != nil {
+		return apperror.NewValidationError("invalid request body", nil)
+	}
+
+	items, err := parseBulkItems(req.BulkItemsRequest)
+	if err != nil {
+		return err
+	}
+
+	output, err := h.bulkDeleteCommand.Execute(c.Request().Context(), storagecmd.BulkDeleteInput{
+		Items:  items,
+		UserID: claims.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return bulkResult(c, "delete", output, nil)
+}
+
+// parseBulkItems はリクエストのファイルIDとフォルダIDを一括操作のアイテムに変換します
+func parseBulkItems(req request.BulkItemsRequest) ([]storagecmd.BulkItem, error) {
+	items := make([]storagecmd.BulkItem, 0, len(req.FileIDs)+len(req.FolderIDs))
+	for _, raw := range req.FileIDs {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, apperror.NewValidationError("invalid file ID: "+raw, nil)
+		}
+		items = append(items, storagecmd.BulkItem{ID: id, Type: authz.ResourceTypeFile})
+	}
+	for _, raw := range req.FolderIDs {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, apperror.NewValidationError("invalid folder ID: "+raw, nil)
+		}
+		items = append(items, storagecmd.BulkItem{ID: id, Type: authz.ResourceTypeFolder})
+	}
+	return items, nil
+}
+
+// bulkAuditActions は一括操作ごとに、成功したアイテムを記録する監査ログのアクションを定義します
+// コピーはコピー元のアイテムIDで記録します
+var bulkAuditActions = map[string]map[authz.ResourceType]entity.AuditAction{
+	"move": {
+		authz.ResourceTypeFile:   entity.AuditActionFileMove,
+		authz.ResourceTypeFolder: entity.AuditActionFolderMove,
+	},
+	"copy": {
+		authz.ResourceTypeFile:   entity.AuditActionFileCopy,
+		authz.ResourceTypeFolder: entity.AuditActionFolderCopy,
+	},
+	"trash": {
+		authz.ResourceTypeFile:   entity.AuditActionFileTrash,
+		authz.ResourceTypeFolder: entity.AuditActionFolderDelete,
+	},
+	"delete": {
+		authz.ResourceTypeFile:   entity.AuditActionFileDelete,
+		authz.ResourceTypeFolder: entity.AuditActionFolderDelete,
+	},
+}
+
+// bulkResult は成功したアイテムを監査ログに、内部エラーとなったアイテムをログに記録し、一括操作の結果を返します
+func bulkResult(c echo.Context, operation string, output *storagecmd.BulkOutput, auditDetails map[string]interface{}) error {
+	for _, r := range output.Results {
+		if r.Succeeded() {
+			if action, ok := bulkAuditActions[operation][r.Type]; ok {
+				details := map[string]interface{}{"bulk": true}
+				for k, v := range auditDetails {
+					details[k] = v
+				}
+				resourceID := &r.ID
+				if action == entity.AuditActionFileDelete {
+					// 完全削除の対象はアーカイブファイルIDのため、元のファイルIDとしては記録しない
+					details["archived_file_id"] = r.ID
+					resourceID = nil
+				}
+				middleware.AuditHelper(c, string(action), string(auditResourceType(r.Type)), resourceID, details)
+			}
+			continue
+		}
+		if !apperror.IsClientError(r.Err) {
+			slog.Error("bulk operation item failed",
+				"request_id", middleware.GetRequestID(c),
+				"operation", operation,
+				"item_id", r.ID,
+				"item_type", r.Type,
+				"error", r.Err,
+			)
+		}
+	}
+	return presenter.OK(c, response.ToBulkOperationResponse(output))
+}
+
+// auditResourceType は認可のリソース種別を監査ログのリソース種別に変換します
+func auditResourceType(resourceType authz.ResourceType) entity.AuditResourceType {
+	if resourceType == authz.ResourceTypeFolder {
+		return entity.AuditResourceFolder
+	}
+	return entity.AuditResourceFile
+}
@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/dto/request"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/dto/response"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/middleware"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/presenter"
+	storagecmd "github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/command"
+	storageqry "github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/query"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// CopyHandler はファイル・フォルダのサーバーサイドコピーのHTTPハンドラーです
+type CopyHandler struct {
+	copyFileCommand        *storagecmd.CopyFileCommand
+	startFolderCopyCommand *storagecmd.StartFolderCopyCommand
+	getCopyJobQuery        *storageqry.GetCopyJobQuery
+}
+
+// NewCopyHandler は新しいCopyHandlerを作成します
+func NewCopyHandler(
+	copyFileCommand *storagecmd.CopyFileCommand,
+	startFolderCopyCommand *storagecmd.StartFolderCopyCommand,
+	getCopyJobQuery *storageqry.GetCopyJobQuery,
+) *CopyHandler {
+	return &CopyHandler{
+		copyFileCommand:        copyFileCommand,
+		startFolderCopyCommand: startFolderCopyCommand,
+		getCopyJobQuery:        getCopyJobQuery,
+	}
+}
+
+// CopyFile はファイルをコピーします
+// @Summary ファイルコピー
+// @Description ファイルの最新バージョンを指定フォルダへサーバーサイドでコピーします
+// @Tags Files
+// @Accept json
+// @Produce json
+// @Security SessionCookie
+// @Param id path string true "ファイルID"
+// @Param body body request.CopyFileRequest true "コピー先フォルダ"
+// @Success 201 {object} handler.SwaggerFileResponse
+// @Failure 400 {object} handler.SwaggerErrorResponse
+// @Failure 401 {object} handler.SwaggerErrorResponse
+// @Failure 403 {object} handler.SwaggerErrorResponse
+// @Failure 404 {object} handler.SwaggerErrorResponse
+// @Failure 409 {object} handler.SwaggerErrorResponse
+// @Router /files/{id}/copy [post]
+func (h *CopyHandler) CopyFile(c echo.Context) error {
+	claims := middleware.GetAccessClaims(c)
+	if claims == nil {
+		return apperror.NewUnauthorizedError("invalid token")
+	}
+
+	fileID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.NewValidationError("invalid file ID", nil)
+	}
+
+	var req request.CopyFileRequest
+	if err := c.Bind(&req); err != nil {
+		return apperror.NewValidationError("invalid request body", nil)
+	}
+
+	destinationFolderID, err := parseDestinationFolderID(req.DestinationFolderID)
+	if err != nil {
+		return err
+	}
+
+	output, err := h.copyFileCommand.Execute(c.Request().Context(), storagecmd.CopyFileInput{
+		FileID:              fileID,
+		DestinationFolderID: destinationFolderID,
+		UserID:              claims.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	middleware.AuditHelper(c, string(entity.AuditActionFileCopy), string(entity.AuditResourceFile), &fileID, map[string]interface{}{
+		"destination_folder_id": destinationFolderID,
+		"copied_file_id":        output.File.ID,
+	})
+
+	return presenter.Created(c, response.ToFileResponse(output.File))
+}
+
+// CopyFolder はフォルダをサブツリーごとコピーします
+// @Summary フォルダコピー
+// @Description フォルダをサブツリーごと指定フォルダへサーバーサイドでコピーします。大きなフォルダはジョブとして登録され、202 とジョブ情報を返します
+// @Tags Folders
+// @Accept json
+// @Produce json
+// @Security SessionCookie
+// @Param id path string true "フォルダID"
+// @Param body body request.CopyFolderRequest true "コピー先フォルダ"
+// @Success 201 {object} handler.SwaggerCopyFolderResponse
+// @Success 202 {object} handler.SwaggerCopyJobResponse
+// @Failure 400 {object} handler.SwaggerErrorResponse
+// @Failure 401 {object} handler.SwaggerErrorResponse
+// @Failure 403 {object} handler.SwaggerErrorResponse
+// @Failure 404 {object} handler.SwaggerErrorResponse
+// @Failure 409 {object} handler.SwaggerErrorResponse
+// @Router /folders/{id}/copy [post]
+func (h *CopyHandler) CopyFolder(c echo.Context) error {
+	claims := middleware.GetAccessClaims(c)
+	if claims == nil {
+		return apperror.NewUnauthorizedError("invalid token")
+	}
+
+	folderID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.NewValidationError("invalid folder ID", nil)
+	}
+
+	var req request.CopyFolderRequest
+	if err := c.Bind(&req); err != nil {
+		return apperror.NewValidationError("invalid request body", nil)
+	}
+
+	destinationFolderID, err := parseDestinationFolderID(req.DestinationFolderID)
+	if err != nil {
+		return err
+	}
+
+	output, err := h.startFolderCopyCommand.Execute(c.Request().Context(), storagecmd.StartFolderCopyInput{
+		FolderID:            folderID,
+		DestinationFolderID: destinationFolderID,
+		UserID:              claims.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if output.Job != nil {
+		middleware.AuditHelper(c, string(entity.AuditActionFolderCopy), string(entity.AuditResourceFolder), &folderID, map[string]interface{}{
+			"destination_folder_id": destinationFolderID,
+			"copy_job_id":           output.Job.ID,
+		})
+		return presenter.Accepted(c, response.ToCopyJobResponse(output.Job))
+	}
+
+	middleware.AuditHelper(c, string(entity.AuditActionFolderCopy), string(entity.AuditResourceFolder), &folderID, map[string]interface{}{
+		"destination_folder_id": destinationFolderID,
+		"copied_folder_id":      output.Result.Folder.ID,
+		"folder_count":          output.Result.FolderCount,
+		"file_count":            output.Result.FileCount,
+	})
+	return presenter.Created(c, response.ToCopyFolderResponse(output.Result))
+}
+
+// GetCopyJob はフォルダコピージョブの進捗を取得します
+// @Summary コピージョブ取得
+// @Description 非同期で実行されるフォルダコピージョブの状態を取得します
+// @Tags Folders
+// @Produce json
+// @Security SessionCookie
+// @Param id path string true "コピージョブID"
+// @Success 200 {object} handler.SwaggerCopyJobResponse
+// @Failure 400 {object} handler.SwaggerErrorResponse
+// @Failure 401 {object} handler.SwaggerErrorResponse
+// @Failure 403 {object} handler.SwaggerErrorResponse
+// @Failure 404 {object} handler.SwaggerErrorResponse
+// @Router /copy-jobs/{id} [get]
+func (h *CopyHandler) GetCopyJob(c echo.Context) error {
+	claims := middleware.GetAccessClaims(c)
+	if claims == nil {
+		return apperror.NewUnauthorizedError("invalid token")
+	}
+
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.NewValidationError("invalid copy job ID", nil)
+	}
+
+	output, err := h.getCopyJobQuery.Execute(c.Request().Context(), storageqry.GetCopyJobInput{
+		JobID:  jobID,
+		UserID: claims.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return presenter.OK(c, response.ToCopyJobResponse(output.Job))
+}
+
+// parseDestinationFolderID は移動先・コピー先フォルダIDを検証します
+func parseDestinationFolderID(raw *string) (uuid.UUID, error) {
+	if raw == nil {
+		return uuid.Nil, apperror.NewValidationError("destination folder ID is required", nil)
+	}
+	id, err := uuid.Parse(*raw)
+	if err != nil {
+		return uuid.Nil, apperror.NewValidationError("invalid destination folder ID", nil)
+	}
+	return id, nil
+}
@@ -1,11 +1,15 @@
 package handler
 
 import (
+	"net/http"
 	"strconv"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/dto/request"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/dto/response"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/middleware"
@@ -17,24 +21,33 @@ import (
 
 // FileHandler はファイル操作関連のHTTPハンドラーです
 type FileHandler struct {
-	renameFileCommand     *storagecmd.RenameFileCommand
-	moveFileCommand       *storagecmd.MoveFileCommand
-	getDownloadURLQuery   *storageqry.GetDownloadURLQuery
-	listFileVersionsQuery *storageqry.ListFileVersionsQuery
+	renameFileCommand         *storagecmd.RenameFileCommand
+	moveFileCommand           *storagecmd.MoveFileCommand
+	restoreFileVersionCommand *storagecmd.RestoreFileVersionCommand
+	getDownloadURLQuery       *storageqry.GetDownloadURLQuery
+	getThumbnailQuery         *storageqry.GetThumbnailQuery
+	getFilePreviewQuery       *storageqry.GetFilePreviewQuery
+	listFileVersionsQuery     *storageqry.ListFileVersionsQuery
 }
 
 // NewFileHandler は新しいFileHandlerを作成します
 func NewFileHandler(
 	renameFileCommand *storagecmd.RenameFileCommand,
 	moveFileCommand *storagecmd.MoveFileCommand,
+	restoreFileVersionCommand *storagecmd.RestoreFileVersionCommand,
 	getDownloadURLQuery *storageqry.GetDownloadURLQuery,
+	getThumbnailQuery *storageqry.GetThumbnailQuery,
+	getFilePreviewQuery *storageqry.GetFilePreviewQuery,
 	listFileVersionsQuery *storageqry.ListFileVersionsQuery,
 ) *FileHandler {
 	return &FileHandler{
-		renameFileCommand:     renameFileCommand,
-		moveFileCommand:       moveFileCommand,
-		getDownloadURLQuery:   getDownloadURLQuery,
-		listFileVersionsQuery: listFileVersionsQuery,
+		renameFileCommand:         renameFileCommand,
+		moveFileCommand:           moveFileCommand,
+		restoreFileVersionCommand: restoreFileVersionCommand,
+		getDownloadURLQuery:       getDownloadURLQuery,
+		getThumbnailQuery:         getThumbnailQuery,
+		getFilePreviewQuery:       getFilePreviewQuery,
+		listFileVersionsQuery:     listFileVersionsQuery,
 	}
 }
 
@@ -83,9 +96,118 @@ func (h *FileHandler) GetDownloadURL(c echo.Context) error {
 		return err
 	}
 
+	middleware.AuditHelper(c, string(entity.AuditActionFileDownload), string(entity.AuditResourceFile), &output.FileID, map[string]interface{}{
+		"version_number": output.VersionNumber,
+	})
+
 	return presenter.OK(c, response.ToDownloadURLResponse(output))
 }
 
+// GetThumbnail はファイルのサムネイルにリダイレクトします
+// @Summary サムネイル取得
+// @Description 画像ファイルの現在のバージョンのサムネイル（JPEG）の署名付きURLにリダイレクトします。生成中の場合は202を返すため、Retry-Afterの秒数をおいて再取得します
+// @Tags Files
+// @Security SessionCookie
+// @Param id path string true "ファイルID"
+// @Param size query string false "サムネイルのサイズ（small: 128px, medium: 256px, large: 512px）" Enums(small, medium, large) default(medium)
+// @Success 302 "Location ヘッダーでサムネイルの署名付きURLを返します"
+// @Success 202 "生成中（Retry-After ヘッダーを返します）"
+// @Failure 400 {object} handler.SwaggerErrorResponse
+// @Failure 401 {object} handler.SwaggerErrorResponse
+// @Failure 403 {object} handler.SwaggerErrorResponse
+// @Failure 404 {object} handler.SwaggerErrorResponse
+// @Router /files/{id}/thumbnail [get]
+func (h *FileHandler) GetThumbnail(c echo.Context) error {
+	claims := middleware.GetAccessClaims(c)
+	if claims == nil {
+		return apperror.NewUnauthorizedError("invalid token")
+	}
+
+	fileID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.NewValidationError("invalid file ID", nil)
+	}
+
+	size, err := valueobject.NewThumbnailSize(c.QueryParam("size"))
+	if err != nil {
+		return apperror.NewValidationError("size must be one of small, medium, large", nil)
+	}
+
+	output, err := h.getThumbnailQuery.Execute(c.Request().Context(), storageqry.GetThumbnailInput{
+		FileID: fileID,
+		UserID: claims.UserID,
+		Size:   size,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !output.Ready {
+		c.Response().Header().Set("Retry-After", "5")
+		c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+		return c.NoContent(http.StatusAccepted)
+	}
+
+	// 署名付きURLの有効期限より短い間だけリダイレクトをキャッシュさせ、グリッド表示の再描画で再取得しないようにする
+	c.Response().Header().Set(echo.HeaderCacheControl, "private, max-age=600")
+	return c.Redirect(http.StatusFound, output.URL)
+}
+
+// GetPreview はファイルのインラインプレビューを取得します
+// @Summary ファイルプレビュー取得
+// @Description テキスト・ソースコード・CSV・Markdownファイルの現在のバージョンの先頭部分を、ダウンロードせずに表示できる形式で返します。文字コードを判定してUTF-8に変換し、CSVは解析した行（rows）、Markdownは危険な要素を含まないHTML（html）を返します
+// @Tags Files
+// @Produce json
+// @Security SessionCookie
+// @Param id path string true "ファイルID"
+// @Param maxKb query int false "読み込むサイズ（KB、1〜1024）" default(64)
+// @Success 200 {object} handler.SwaggerFilePreviewResponse
+// @Failure 400 {object} handler.SwaggerErrorResponse
+// @Failure 401 {object} handler.SwaggerErrorResponse
+// @Failure 403 {object} handler.SwaggerErrorResponse
+// @Failure 404 {object} handler.SwaggerErrorResponse
+// @Router /files/{id}/preview [get]
+func (h *FileHandler) GetPreview(c echo.Context) error {
+	claims := middleware.GetAccessClaims(c)
+	if claims == nil {
+		return apperror.NewUnauthorizedError("invalid token")
+	}
+
+	fileID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.NewValidationError("invalid file ID", nil)
+	}
+
+	maxBytes, err := parsePreviewMaxBytes(c)
+	if err != nil {
+		return err
+	}
+
+	output, err := h.getFilePreviewQuery.Execute(c.Request().Context(), storageqry.GetFilePreviewInput{
+		FileID:   fileID,
+		UserID:   claims.UserID,
+		MaxBytes: maxBytes,
+	})
+	if err != nil {
+		return err
+	}
+
+	return presenter.OK(c, response.ToFilePreviewResponse(output))
+}
+
+// parsePreviewMaxBytes はプレビューで読み込むサイズ（maxKb）をバイト数に変換します（未指定の場合は0）
+func parsePreviewMaxBytes(c echo.Context) (int, error) {
+	param := c.QueryParam("maxKb")
+	if param == "" {
+		return 0, nil
+	}
+	maxKB, err := strconv.Atoi(param)
+	if err != nil || maxKB < 1 || maxKB*1024 > service.MaxPreviewBytes {
+		return 0, apperror.NewValidationError("maxKb must be between 1 and "+strconv.Itoa(service.MaxPreviewBytes/1024), nil)
+	}
+	return maxKB * 1024, nil
+}
+
 // ListFileVersions はファイルバージョン一覧を取得します
 // @Summary ファイルバージョン一覧取得
 // @Description 指定されたファイルのバージョン一覧を取得します
@@ -120,6 +242,58 @@ func (h *FileHandler) ListFileVersions(c echo.Context) error {
 	return presenter.OK(c, response.ToFileVersionsResponse(output))
 }
 
+// RestoreFileVersion は過去のバージョンを最新バージョンとして復元します
+// @Summary ファイルバージョン復元
+// @Description 指定されたバージョンの内容をコピーした新しいバージョンを作成します
+// @Tags Files
+// @Produce json
+// @Security SessionCookie
+// @Param id path string true "ファイルID"
+// @Param version path int true "復元するバージョン番号"
+// @Success 200 {object} handler.SwaggerRestoreFileVersionResponse
+// @Failure 400 {object} handler.SwaggerErrorResponse
+// @Failure 401 {object} handler.SwaggerErrorResponse
+// @Failure 403 {object} handler.SwaggerErrorResponse
+// @Failure 404 {object} handler.SwaggerErrorResponse
+// @Router /files/{id}/versions/{version}/restore [post]
+func (h *FileHandler) RestoreFileVersion(c echo.Context) error {
+	claims := middleware.GetAccessClaims(c)
+	if claims == nil {
+		return apperror.NewUnauthorizedError("invalid token")
+	}
+
+	fileID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.NewValidationError("invalid file ID", nil)
+	}
+
+	versionNumber, err := strconv.Atoi(c.Param("version"))
+	if err != nil || versionNumber < 1 {
+		return apperror.NewValidationError("version must be a positive integer", nil)
+	}
+
+	output, err := h.restoreFileVersionCommand.Execute(c.Request().Context(), storagecmd.RestoreFileVersionInput{
+		FileID:        fileID,
+		VersionNumber: versionNumber,
+		UserID:        claims.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	middleware.AuditHelper(c, string(entity.AuditActionFileVersionRestore), string(entity.AuditResourceFile), &output.FileID, map[string]interface{}{
+		"restored_from_version": output.RestoredFromVersion,
+		"version_number":        output.VersionNumber,
+	})
+
+	return presenter.OK(c, response.RestoreFileVersionResponse{
+		FileID:              output.FileID.String(),
+		RestoredFromVersion: output.RestoredFromVersion,
+		VersionNumber:       output.VersionNumber,
+		Size:                output.Size,
+	})
+}
+
 // RenameFile はファイル名を変更します
 // @Summary ファイル名変更
 // @Description 指定されたファイルの名前を変更します
@@ -162,6 +336,11 @@ func (h *FileHandler) RenameFile(c echo.Context) error {
 		return err
 	}
 
+	middleware.AuditHelper(c, string(entity.AuditActionFileRename), string(entity.AuditResourceFile), &output.FileID, map[string]interface{}{
+		"before": map[string]interface{}{"name": output.PreviousName},
+		"after":  map[string]interface{}{"name": output.Name},
+	})
+
 	return presenter.OK(c, response.RenameFileResponse{
 		FileID: output.FileID.String(),
 		Name:   output.Name,
@@ -181,6 +360,7 @@ func (h *FileHandler) RenameFile(c echo.Context) error {
 // @Failure 400 {object} handler.SwaggerErrorResponse
 // @Failure 401 {object} handler.SwaggerErrorResponse
 // @Failure 404 {object} handler.SwaggerErrorResponse
+// @Failure 409 {object} handler.SwaggerErrorResponse
 // @Router /files/{id}/move [patch]
 func (h *FileHandler) MoveFile(c echo.Context) error {
 	claims := middleware.GetAccessClaims(c)
@@ -205,18 +385,36 @@ func (h *FileHandler) MoveFile(c echo.Context) error {
 	if err != nil {
 		return apperror.NewValidationError("invalid new folder ID", nil)
 	}
+	conflictStrategy, err := valueobject.NewConflictStrategy(req.ConflictStrategy)
+	if err != nil {
+		return apperror.NewValidationError(err.Error(), nil)
+	}
 
 	output, err := h.moveFileCommand.Execute(c.Request().Context(), storagecmd.MoveFileInput{
-		FileID:      fileID,
-		NewFolderID: newFolderID,
-		UserID:      claims.UserID,
+		FileID:           fileID,
+		NewFolderID:      newFolderID,
+		UserID:           claims.UserID,
+		ConflictStrategy: conflictStrategy,
 	})
 	if err != nil {
 		return err
 	}
 
+	if !output.Skipped {
+		middleware.AuditHelper(c, string(entity.AuditActionFileMove), string(entity.AuditResourceFile), &output.FileID, map[string]interface{}{
+			"before":            map[string]interface{}{"folder_id": output.PreviousFolderID},
+			"after":             map[string]interface{}{"folder_id": output.FolderID},
+			"conflict_strategy": string(conflictStrategy),
+			"overwritten":       output.Overwritten,
+		})
+	}
+
 	return presenter.OK(c, response.MoveFileResponse{
-		FileID:   output.FileID.String(),
-		FolderID: output.FolderID.String(),
+		FileID:        output.FileID.String(),
+		FolderID:      output.FolderID.String(),
+		Name:          output.Name,
+		Skipped:       output.Skipped,
+		Overwritten:   output.Overwritten,
+		VersionNumber: output.VersionNumber,
 	})
 }
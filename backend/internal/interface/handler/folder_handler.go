@@ -1,9 +1,12 @@
 package handler
 
 import (
+	"io"
+
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/dto/request"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/dto/response"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/middleware"
@@ -25,6 +28,7 @@ type FolderHandler struct {
 	getFolderQuery          *storageqry.GetFolderQuery
 	listFolderContentsQuery *storageqry.ListFolderContentsQuery
 	getAncestorsQuery       *storageqry.GetAncestorsQuery
+	getFolderArchiveQuery   *storageqry.GetFolderArchiveQuery
 }
 
 // NewFolderHandler は新しいFolderHandlerを作成します
@@ -36,6 +40,7 @@ func NewFolderHandler(
 	getFolderQuery *storageqry.GetFolderQuery,
 	listFolderContentsQuery *storageqry.ListFolderContentsQuery,
 	getAncestorsQuery *storageqry.GetAncestorsQuery,
+	getFolderArchiveQuery *storageqry.GetFolderArchiveQuery,
 ) *FolderHandler {
 	return &FolderHandler{
 		createFolderCommand:     createFolderCommand,
@@ -45,6 +50,7 @@ func NewFolderHandler(
 		getFolderQuery:          getFolderQuery,
 		listFolderContentsQuery: listFolderContentsQuery,
 		getAncestorsQuery:       getAncestorsQuery,
+		getFolderArchiveQuery:   getFolderArchiveQuery,
 	}
 }
 
@@ -92,6 +98,11 @@ func (h *FolderHandler) CreateFolder(c echo.Context) error {
 		return err
 	}
 
+	middleware.AuditHelper(c, string(entity.AuditActionFolderCreate), string(entity.AuditResourceFolder), &output.Folder.ID, map[string]interface{}{
+		"name":      output.Folder.Name.String(),
+		"parent_id": output.Folder.ParentID,
+	})
+
 	return presenter.Created(c, response.ToFolderResponse(output.Folder))
 }
 
@@ -212,6 +223,44 @@ func (h *FolderHandler) GetAncestors(c echo.Context) error {
 	return presenter.OK(c, response.ToBreadcrumbResponse(output.Ancestors))
 }
 
+// DownloadFolder はフォルダをZIPアーカイブとしてダウンロードします
+// @Summary フォルダダウンロード
+// @Description 指定したフォルダ配下をフォルダ構造を保ったZIPとしてストリーミングします。閲覧権限のないアイテムは含まれません
+// @Tags Folders
+// @Produce application/zip
+// @Security SessionCookie
+// @Param id path string true "フォルダID"
+// @Success 200 {file} binary
+// @Failure 400 {object} handler.SwaggerErrorResponse
+// @Failure 401 {object} handler.SwaggerErrorResponse
+// @Failure 403 {object} handler.SwaggerErrorResponse
+// @Failure 404 {object} handler.SwaggerErrorResponse
+// @Router /folders/{id}/download [get]
+func (h *FolderHandler) DownloadFolder(c echo.Context) error {
+	claims := middleware.GetAccessClaims(c)
+	if claims == nil {
+		return apperror.NewUnauthorizedError("invalid token")
+	}
+
+	folderID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.NewValidationError("invalid folder ID", nil)
+	}
+
+	ctx := c.Request().Context()
+	output, err := h.getFolderArchiveQuery.Execute(ctx, storageqry.GetFolderArchiveInput{
+		FolderID: folderID,
+		UserID:   claims.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return presenter.Attachment(c, "application/zip", output.ArchiveName, func(w io.Writer) error {
+		return output.WriteZip(ctx, w)
+	})
+}
+
 // RenameFolder はフォルダ名を変更します
 // @Summary フォルダ名変更
 // @Description 指定したフォルダの名前を変更します
@@ -254,6 +303,11 @@ func (h *FolderHandler) RenameFolder(c echo.Context) error {
 		return err
 	}
 
+	middleware.AuditHelper(c, string(entity.AuditActionFolderRename), string(entity.AuditResourceFolder), &output.Folder.ID, map[string]interface{}{
+		"before": map[string]interface{}{"name": output.PreviousName},
+		"after":  map[string]interface{}{"name": output.Folder.Name.String()},
+	})
+
 	return presenter.OK(c, response.ToFolderResponse(output.Folder))
 }
 
@@ -305,6 +359,11 @@ func (h *FolderHandler) MoveFolder(c echo.Context) error {
 		return err
 	}
 
+	middleware.AuditHelper(c, string(entity.AuditActionFolderMove), string(entity.AuditResourceFolder), &output.Folder.ID, map[string]interface{}{
+		"before": map[string]interface{}{"parent_id": output.PreviousParentID},
+		"after":  map[string]interface{}{"parent_id": output.Folder.ParentID},
+	})
+
 	return presenter.OK(c, response.ToFolderResponse(output.Folder))
 }
 
@@ -331,7 +390,7 @@ func (h *FolderHandler) DeleteFolder(c echo.Context) error {
 		return apperror.NewValidationError("invalid folder ID", nil)
 	}
 
-	_, err = h.deleteFolderCommand.Execute(c.Request().Context(), storagecmd.DeleteFolderInput{
+	output, err := h.deleteFolderCommand.Execute(c.Request().Context(), storagecmd.DeleteFolderInput{
 		FolderID: folderID,
 		UserID:   claims.UserID,
 	})
@@ -339,5 +398,11 @@ func (h *FolderHandler) DeleteFolder(c echo.Context) error {
 		return err
 	}
 
+	middleware.AuditHelper(c, string(entity.AuditActionFolderDelete), string(entity.AuditResourceFolder), &folderID, map[string]interface{}{
+		"archived_folder_id":   output.ArchivedFolderID,
+		"deleted_folder_count": output.DeletedFolderCount,
+		"archived_file_count":  output.ArchivedFileCount,
+	})
+
 	return presenter.NoContent(c)
 }
@@ -4,6 +4,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/dto/request"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/dto/response"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/middleware"
@@ -27,6 +28,7 @@ type GroupHandler struct {
 	leaveGroupCmd        *collabcmd.LeaveGroupCommand
 	changeRoleCmd        *collabcmd.ChangeRoleCommand
 	transferOwnershipCmd *collabcmd.TransferOwnershipCommand
+	setGroupQuotaCmd     *collabcmd.SetGroupQuotaCommand
 
 	// Queries
 	getGroupQuery               *collabqry.GetGroupQuery
@@ -34,6 +36,7 @@ type GroupHandler struct {
 	listMembersQuery            *collabqry.ListMembersQuery
 	listInvitationsQuery        *collabqry.ListInvitationsQuery
 	listPendingInvitationsQuery *collabqry.ListPendingInvitationsQuery
+	getGroupQuotaQuery          *collabqry.GetGroupQuotaQuery
 }
 
 // NewGroupHandler は新しいGroupHandlerを作成します
@@ -49,11 +52,13 @@ func NewGroupHandler(
 	leaveGroupCmd *collabcmd.LeaveGroupCommand,
 	changeRoleCmd *collabcmd.ChangeRoleCommand,
 	transferOwnershipCmd *collabcmd.TransferOwnershipCommand,
+	setGroupQuotaCmd *collabcmd.SetGroupQuotaCommand,
 	getGroupQuery *collabqry.GetGroupQuery,
 	listMyGroupsQuery *collabqry.ListMyGroupsQuery,
 	listMembersQuery *collabqry.ListMembersQuery,
 	listInvitationsQuery *collabqry.ListInvitationsQuery,
 	listPendingInvitationsQuery *collabqry.ListPendingInvitationsQuery,
+	getGroupQuotaQuery *collabqry.GetGroupQuotaQuery,
 ) *GroupHandler {
 	return &GroupHandler{
 		createGroupCmd:              createGroupCmd,
@@ -67,11 +72,13 @@ func NewGroupHandler(
 		leaveGroupCmd:               leaveGroupCmd,
 		changeRoleCmd:               changeRoleCmd,
 		transferOwnershipCmd:        transferOwnershipCmd,
+		setGroupQuotaCmd:            setGroupQuotaCmd,
 		getGroupQuery:               getGroupQuery,
 		listMyGroupsQuery:           listMyGroupsQuery,
 		listMembersQuery:            listMembersQuery,
 		listInvitationsQuery:        listInvitationsQuery,
 		listPendingInvitationsQuery: listPendingInvitationsQuery,
+		getGroupQuotaQuery:          getGroupQuotaQuery,
 	}
 }
 
@@ -110,6 +117,10 @@ func (h *GroupHandler) CreateGroup(c echo.Context) error {
 		return err
 	}
 
+	middleware.AuditHelper(c, string(entity.AuditActionGroupCreate), string(entity.AuditResourceGroup), &output.Group.ID, map[string]interface{}{
+		"name": output.Group.Name.String(),
+	})
+
 	return presenter.Created(c, response.ToGroupWithMembershipAndCountResponse(output.Group, output.Membership, 1))
 }
 
@@ -204,6 +215,8 @@ func (h *GroupHandler) DeleteGroup(c echo.Context) error {
 		return err
 	}
 
+	middleware.AuditHelper(c, string(entity.AuditActionGroupDelete), string(entity.AuditResourceGroup), &groupID, nil)
+
 	return presenter.NoContent(c)
 }
 
@@ -256,6 +269,12 @@ func (h *GroupHandler) InviteMember(c echo.Context) error {
 		return err
 	}
 
+	middleware.AuditHelper(c, string(entity.AuditActionGroupMemberInvite), string(entity.AuditResourceGroup), &groupID, map[string]interface{}{
+		"invitation_id": output.Invitation.ID,
+		"email":         output.Invitation.Email.String(),
+		"role":          output.Invitation.Role.String(),
+	})
+
 	return presenter.Created(c, response.ToInvitationResponse(output.Invitation))
 }
 
@@ -290,6 +309,11 @@ func (h *GroupHandler) AcceptInvitation(c echo.Context) error {
 		return err
 	}
 
+	middleware.AuditHelper(c, string(entity.AuditActionGroupMemberJoin), string(entity.AuditResourceGroup), &output.Group.ID, map[string]interface{}{
+		"user_id": claims.UserID,
+		"role":    output.Membership.Role.String(),
+	})
+
 	return presenter.OK(c, response.ToGroupWithMembershipAndCountResponse(output.Group, output.Membership, 0))
 }
 
@@ -366,6 +390,10 @@ func (h *GroupHandler) RemoveMember(c echo.Context) error {
 		return err
 	}
 
+	middleware.AuditHelper(c, string(entity.AuditActionGroupMemberRemove), string(entity.AuditResourceGroup), &groupID, map[string]interface{}{
+		"user_id": targetUserID,
+	})
+
 	return presenter.NoContent(c)
 }
 
@@ -400,6 +428,10 @@ func (h *GroupHandler) LeaveGroup(c echo.Context) error {
 		return err
 	}
 
+	middleware.AuditHelper(c, string(entity.AuditActionGroupMemberLeave), string(entity.AuditResourceGroup), &groupID, map[string]interface{}{
+		"user_id": claims.UserID,
+	})
+
 	return presenter.NoContent(c)
 }
 
@@ -453,6 +485,12 @@ func (h *GroupHandler) ChangeRole(c echo.Context) error {
 		return err
 	}
 
+	middleware.AuditHelper(c, string(entity.AuditActionGroupMemberRoleChange), string(entity.AuditResourceGroup), &groupID, map[string]interface{}{
+		"user_id": targetUserID,
+		"before":  map[string]interface{}{"role": output.PreviousRole.String()},
+		"after":   map[string]interface{}{"role": output.Membership.Role.String()},
+	})
+
 	return presenter.OK(c, response.ToMembershipResponse(output.Membership))
 }
 
@@ -504,6 +542,11 @@ func (h *GroupHandler) TransferOwnership(c echo.Context) error {
 		return err
 	}
 
+	middleware.AuditHelper(c, string(entity.AuditActionGroupOwnerTransfer), string(entity.AuditResourceGroup), &groupID, map[string]interface{}{
+		"before": map[string]interface{}{"owner_id": claims.UserID},
+		"after":  map[string]interface{}{"owner_id": newOwnerID},
+	})
+
 	return presenter.OK(c, response.ToGroupResponse(output.Group))
 }
 
@@ -551,6 +594,17 @@ func (h *GroupHandler) UpdateGroup(c echo.Context) error {
 		return err
 	}
 
+	middleware.AuditHelper(c, string(entity.AuditActionGroupUpdate), string(entity.AuditResourceGroup), &groupID, map[string]interface{}{
+		"before": map[string]interface{}{
+			"name":        output.PreviousName,
+			"description": output.PreviousDescription,
+		},
+		"after": map[string]interface{}{
+			"name":        output.Group.Name.String(),
+			"description": output.Group.Description,
+		},
+	})
+
 	return presenter.OK(c, response.ToGroupResponse(output.Group))
 }
 
@@ -585,6 +639,9 @@ func (h *GroupHandler) DeclineInvitation(c echo.Context) error {
 		return err
 	}
 
+	// Note: 招待トークンは秘密情報のため記録しない
+	middleware.AuditHelper(c, string(entity.AuditActionGroupInvitationDecline), string(entity.AuditResourceUser), &claims.UserID, nil)
+
 	return presenter.NoContent(c)
 }
 
@@ -627,6 +684,10 @@ func (h *GroupHandler) CancelInvitation(c echo.Context) error {
 		return err
 	}
 
+	middleware.AuditHelper(c, string(entity.AuditActionGroupInvitationCancel), string(entity.AuditResourceGroup), &groupID, map[string]interface{}{
+		"invitation_id": invitationID,
+	})
+
 	return presenter.NoContent(c)
 }
 
@@ -688,3 +749,103 @@ func (h *GroupHandler) ListPendingInvitations(c echo.Context) error {
 
 	return presenter.OK(c, response.ToPendingInvitationListResponse(output.Invitations))
 }
+
+// GetGroupQuota はグループのストレージクォータを取得します
+// @Summary グループクォータ取得
+// @Description グループのクォータ上限とメンバー全員の使用量の合計を取得します（メンバーのみ）
+// @Tags Groups
+// @Produce json
+// @Security SessionCookie
+// @Param id path string true "グループID" format(uuid)
+// @Success 200 {object} handler.SwaggerGroupQuotaResponse
+// @Failure 400 {object} handler.SwaggerErrorResponse
+// @Failure 401 {object} handler.SwaggerErrorResponse
+// @Failure 403 {object} handler.SwaggerErrorResponse
+// @Failure 404 {object} handler.SwaggerErrorResponse
+// @Router /groups/{id}/quota [get]
+func (h *GroupHandler) GetGroupQuota(c echo.Context) error {
+	claims := middleware.GetAccessClaims(c)
+	if claims == nil {
+		return apperror.NewUnauthorizedError("invalid token")
+	}
+
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.NewValidationError("invalid group ID", nil)
+	}
+
+	output, err := h.getGroupQuotaQuery.Execute(c.Request().Context(), collabqry.GetGroupQuotaInput{
+		GroupID: groupID,
+		UserID:  claims.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return presenter.OK(c, response.ToGroupQuotaResponse(output))
+}
+
+// SetGroupQuota はグループのストレージクォータを設定します
+// @Summary グループクォータ設定
+// @Description グループのクォータ上限を設定します。limitBytesにnullを指定すると解除します（ownerのみ）
+// @Tags Groups
+// @Accept json
+// @Produce json
+// @Security SessionCookie
+// @Param id path string true "グループID" format(uuid)
+// @Param body body request.SetGroupQuotaRequest true "クォータ情報"
+// @Success 200 {object} handler.SwaggerGroupQuotaResponse
+// @Failure 400 {object} handler.SwaggerErrorResponse
+// @Failure 401 {object} handler.SwaggerErrorResponse
+// @Failure 403 {object} handler.SwaggerErrorResponse
+// @Failure 404 {object} handler.SwaggerErrorResponse
+// @Router /groups/{id}/quota [put]
+func (h *GroupHandler) SetGroupQuota(c echo.Context) error {
+	claims := middleware.GetAccessClaims(c)
+	if claims == nil {
+		return apperror.NewUnauthorizedError("invalid token")
+	}
+
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.NewValidationError("invalid group ID", nil)
+	}
+
+	var req request.SetGroupQuotaRequest
+	if err := c.Bind(&req); err != nil {
+		return apperror.NewValidationError("invalid request body", nil)
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	setOutput, err := h.setGroupQuotaCmd.Execute(ctx, collabcmd.SetGroupQuotaInput{
+		GroupID:    groupID,
+		LimitBytes: req.LimitBytes,
+		SetBy:      claims.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	var limitBytes *int64
+	if setOutput.Quota != nil {
+		limitBytes = &setOutput.Quota.LimitBytes
+	}
+	middleware.AuditHelper(c, string(entity.AuditActionGroupQuotaUpdate), string(entity.AuditResourceGroup), &groupID, map[string]interface{}{
+		"before": map[string]interface{}{"limit_bytes": setOutput.PreviousLimitBytes},
+		"after":  map[string]interface{}{"limit_bytes": limitBytes},
+	})
+
+	// 設定後のクォータと使用量を返す
+	output, err := h.getGroupQuotaQuery.Execute(ctx, collabqry.GetGroupQuotaInput{
+		GroupID: groupID,
+		UserID:  claims.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return presenter.OK(c, response.ToGroupQuotaResponse(output))
+}
@@ -4,6 +4,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/dto/request"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/dto/response"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/middleware"
@@ -162,6 +164,10 @@ func (h *PermissionHandler) grantRole(c echo.Context, resourceType string) error
 		return err
 	}
 
+	middleware.AuditHelper(c, string(entity.AuditActionPermissionGrant), string(entity.AuditResourcePermission), &output.Grant.ID, map[string]interface{}{
+		"after": permissionGrantAuditDetails(output.Grant),
+	})
+
 	return presenter.Created(c, response.ToPermissionGrantResponse(output.Grant))
 }
 
@@ -187,7 +193,7 @@ func (h *PermissionHandler) RevokeGrant(c echo.Context) error {
 		return apperror.NewValidationError("invalid grant ID", nil)
 	}
 
-	_, err = h.revokeGrantCmd.Execute(c.Request().Context(), authzcmd.RevokeGrantInput{
+	output, err := h.revokeGrantCmd.Execute(c.Request().Context(), authzcmd.RevokeGrantInput{
 		GrantID:   grantID,
 		RevokedBy: claims.UserID,
 	})
@@ -195,6 +201,10 @@ func (h *PermissionHandler) RevokeGrant(c echo.Context) error {
 		return err
 	}
 
+	middleware.AuditHelper(c, string(entity.AuditActionPermissionRevoke), string(entity.AuditResourcePermission), &output.RevokedGrantID, map[string]interface{}{
+		"before": permissionGrantAuditDetails(output.RevokedGrant),
+	})
+
 	return presenter.NoContent(c)
 }
 
@@ -253,3 +263,14 @@ func (h *PermissionHandler) CheckPermission(c echo.Context) error {
 		EffectiveRole: output.EffectiveRole,
 	})
 }
+
+// permissionGrantAuditDetails は監査ログに記録する権限付与の内容を返します
+func permissionGrantAuditDetails(grant *authz.PermissionGrant) map[string]interface{} {
+	return map[string]interface{}{
+		"resource_type": string(grant.ResourceType),
+		"resource_id":   grant.ResourceID,
+		"grantee_type":  string(grant.GranteeType),
+		"grantee_id":    grant.GranteeID,
+		"role":          string(grant.Role),
+	}
+}
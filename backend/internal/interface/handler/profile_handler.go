@@ -112,9 +112,35 @@ func (h *ProfileHandler) UpdateProfile(c echo.Context) error {
 		return err
 	}
 
+	if before, after := profileChanges(&output.Previous, output.Profile); len(after) > 0 {
+		middleware.AuditHelper(c, string(entity.AuditActionUserProfileUpdate), string(entity.AuditResourceUser), &claims.UserID, map[string]interface{}{
+			"before": before,
+			"after":  after,
+		})
+	}
+
 	return presenter.OK(c, response.ToUpdateProfileResponse(output.Profile))
 }
 
+// profileChanges は変更されたプロファイル項目について、変更前後の値を返します
+func profileChanges(before, after *entity.UserProfile) (map[string]interface{}, map[string]interface{}) {
+	beforeValues := map[string]interface{}{}
+	afterValues := map[string]interface{}{}
+	record := func(key string, oldValue, newValue interface{}) {
+		if oldValue != newValue {
+			beforeValues[key] = oldValue
+			afterValues[key] = newValue
+		}
+	}
+	record("avatar_url", before.AvatarURL, after.AvatarURL)
+	record("bio", before.Bio, after.Bio)
+	record("locale", before.Locale, after.Locale)
+	record("timezone", before.Timezone, after.Timezone)
+	record("theme", before.Theme, after.Theme)
+	record("notification_preferences", before.NotificationPreferences, after.NotificationPreferences)
+	return beforeValues, afterValues
+}
+
 // UpdateMe は現在のユーザーの基本情報を更新します
 // @Summary ユーザー情報更新
 // @Description 現在のユーザーの名前などの基本情報を更新します
@@ -149,5 +175,12 @@ func (h *ProfileHandler) UpdateMe(c echo.Context) error {
 		return err
 	}
 
+	if output.User.Name != output.PreviousName {
+		middleware.AuditHelper(c, string(entity.AuditActionUserUpdate), string(entity.AuditResourceUser), &claims.UserID, map[string]interface{}{
+			"before": map[string]interface{}{"name": output.PreviousName},
+			"after":  map[string]interface{}{"name": output.User.Name},
+		})
+	}
+
 	return presenter.OK(c, response.ToUserResponse(output.User))
 }
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/dto/response"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/middleware"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/presenter"
+	storageqry "github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/query"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// SearchHandler は検索関連のHTTPハンドラーです
+type SearchHandler struct {
+	searchQuery *storageqry.SearchQuery
+}
+
+// NewSearchHandler は新しいSearchHandlerを作成します
+func NewSearchHandler(searchQuery *storageqry.SearchQuery) *SearchHandler {
+	return &SearchHandler{
+		searchQuery: searchQuery,
+	}
+}
+
+// Search はファイル・フォルダを検索します
+// @Summary ファイル・フォルダ検索
+// @Description 名前の部分一致と各種フィルタでファイル・フォルダを検索します。閲覧権限のあるアイテムのみ返します
+// @Tags Search
+// @Produce json
+// @Security SessionCookie
+// @Param q query string false "検索キーワード（名前の部分一致）"
+// @Param type query string false "アイテム種別" Enums(file, folder)
+// @Param category query string false "MIMEカテゴリ" Enums(image, video, audio, document, archive, other)
+// @Param minSize query int false "最小サイズ（バイト）"
+// @Param maxSize query int false "最大サイズ（バイト）"
+// @Param ownerId query string false "所有者ID"
+// @Param modifiedAfter query string false "更新日時の下限（RFC3339）"
+// @Param modifiedBefore query string false "更新日時の上限（RFC3339）"
+// @Param folderId query string false "検索対象フォルダID（配下すべてを検索）"
+// @Param limit query int false "取得件数"
+// @Param cursor query string false "カーソル"
+// @Success 200 {object} handler.SwaggerSearchResponse
+// @Failure 400 {object} handler.SwaggerErrorResponse
+// @Failure 401 {object} handler.SwaggerErrorResponse
+// @Failure 403 {object} handler.SwaggerErrorResponse
+// @Failure 429 {object} handler.SwaggerErrorResponse
+// @Router /search [get]
+func (h *SearchHandler) Search(c echo.Context) error {
+	claims := middleware.GetAccessClaims(c)
+	if claims == nil {
+		return apperror.NewUnauthorizedError("invalid token")
+	}
+
+	input := storageqry.SearchInput{
+		UserID: claims.UserID,
+		Query:  c.QueryParam("q"),
+		Cursor: c.QueryParam("cursor"),
+	}
+
+	if v := c.QueryParam("type"); v != "" {
+		itemType := repository.SearchItemType(v)
+		input.ItemType = &itemType
+	}
+	if v := c.QueryParam("category"); v != "" {
+		category := valueobject.MimeCategory(v)
+		input.MimeCategory = &category
+	}
+
+	var err error
+	if input.MinSize, err = parseInt64QueryParam(c, "minSize"); err != nil {
+		return apperror.NewValidationError("invalid minSize", nil)
+	}
+	if input.MaxSize, err = parseInt64QueryParam(c, "maxSize"); err != nil {
+		return apperror.NewValidationError("invalid maxSize", nil)
+	}
+	if input.OwnerID, err = parseUUIDQueryParam(c, "ownerId"); err != nil {
+		return apperror.NewValidationError("invalid owner ID", nil)
+	}
+	if input.FolderID, err = parseUUIDQueryParam(c, "folderId"); err != nil {
+		return apperror.NewValidationError("invalid folder ID", nil)
+	}
+	if input.ModifiedAfter, err = parseTimeQueryParam(c, "modifiedAfter"); err != nil {
+		return apperror.NewValidationError("invalid modifiedAfter", nil)
+	}
+	if input.ModifiedBefore, err = parseTimeQueryParam(c, "modifiedBefore"); err != nil {
+		return apperror.NewValidationError("invalid modifiedBefore", nil)
+	}
+	if v := c.QueryParam("limit"); v != "" {
+		if input.Limit, err = strconv.Atoi(v); err != nil {
+			return apperror.NewValidationError("invalid limit", nil)
+		}
+	}
+
+	output, err := h.searchQuery.Execute(c.Request().Context(), input)
+	if err != nil {
+		return err
+	}
+
+	return presenter.OK(c, response.ToSearchResponse(output))
+}
+
+// parseInt64QueryParam は任意の整数クエリパラメータを解析します
+func parseInt64QueryParam(c echo.Context, name string) (*int64, error) {
+	v := c.QueryParam(name)
+	if v == "" {
+		return nil, nil
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+// parseUUIDQueryParam は任意のUUIDクエリパラメータを解析します
+func parseUUIDQueryParam(c echo.Context, name string) (*uuid.UUID, error) {
+	v := c.QueryParam(name)
+	if v == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(v)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+// parseTimeQueryParam は任意のRFC3339日時クエリパラメータを解析します
+func parseTimeQueryParam(c echo.Context, name string) (*time.Time, error) {
+	v := c.QueryParam(name)
+	if v == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
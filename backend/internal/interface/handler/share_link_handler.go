@@ -6,6 +6,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/dto/request"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/dto/response"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/middleware"
@@ -23,10 +24,12 @@ type ShareLinkHandler struct {
 	updateShareLinkCmd *sharingcmd.UpdateShareLinkCommand
 
 	// Queries
-	accessShareLinkQuery     *sharingqry.AccessShareLinkQuery
-	listShareLinksQuery      *sharingqry.ListShareLinksQuery
-	getShareLinkHistoryQuery *sharingqry.GetShareLinkHistoryQuery
-	getDownloadViaShareQuery *sharingqry.GetDownloadViaShareQuery
+	accessShareLinkQuery          *sharingqry.AccessShareLinkQuery
+	listShareLinksQuery           *sharingqry.ListShareLinksQuery
+	getShareLinkHistoryQuery      *sharingqry.GetShareLinkHistoryQuery
+	getDownloadViaShareQuery      *sharingqry.GetDownloadViaShareQuery
+	getFolderArchiveViaShareQuery *sharingqry.GetFolderArchiveViaShareQuery
+	getPreviewViaShareQuery       *sharingqry.GetPreviewViaShareQuery
 
 	// Config
 	baseURL string
@@ -41,17 +44,21 @@ func NewShareLinkHandler(
 	listShareLinksQuery *sharingqry.ListShareLinksQuery,
 	getShareLinkHistoryQuery *sharingqry.GetShareLinkHistoryQuery,
 	getDownloadViaShareQuery *sharingqry.GetDownloadViaShareQuery,
+	getFolderArchiveViaShareQuery *sharingqry.GetFolderArchiveViaShareQuery,
+	getPreviewViaShareQuery *sharingqry.GetPreviewViaShareQuery,
 	baseURL string,
 ) *ShareLinkHandler {
 	return &ShareLinkHandler{
-		createShareLinkCmd:       createShareLinkCmd,
-		revokeShareLinkCmd:       revokeShareLinkCmd,
-		updateShareLinkCmd:       updateShareLinkCmd,
-		accessShareLinkQuery:     accessShareLinkQuery,
-		listShareLinksQuery:      listShareLinksQuery,
-		getShareLinkHistoryQuery: getShareLinkHistoryQuery,
-		getDownloadViaShareQuery: getDownloadViaShareQuery,
-		baseURL:                  baseURL,
+		createShareLinkCmd:            createShareLinkCmd,
+		revokeShareLinkCmd:            revokeShareLinkCmd,
+		updateShareLinkCmd:            updateShareLinkCmd,
+		accessShareLinkQuery:          accessShareLinkQuery,
+		listShareLinksQuery:           listShareLinksQuery,
+		getShareLinkHistoryQuery:      getShareLinkHistoryQuery,
+		getDownloadViaShareQuery:      getDownloadViaShareQuery,
+		getFolderArchiveViaShareQuery: getFolderArchiveViaShareQuery,
+		getPreviewViaShareQuery:       getPreviewViaShareQuery,
+		baseURL:                       baseURL,
 	}
 }
 
@@ -135,6 +142,12 @@ func (h *ShareLinkHandler) createShareLink(c echo.Context, resourceType string)
 		return err
 	}
 
+	details := shareLinkAuditSettings(output.ShareLink)
+	details["resource_type"] = resourceType
+	details["resource_id"] = resourceID
+	details["permission"] = output.ShareLink.Permission.String()
+	middleware.AuditHelper(c, string(entity.AuditActionShareLinkCreate), string(entity.AuditResourceShareLink), &output.ShareLink.ID, details)
+
 	return presenter.Created(c, response.ToShareLinkResponse(output.ShareLink, h.baseURL))
 }
 
@@ -213,7 +226,7 @@ func (h *ShareLinkHandler) RevokeShareLink(c echo.Context) error {
 		return apperror.NewValidationError("invalid share link ID", nil)
 	}
 
-	_, err = h.revokeShareLinkCmd.Execute(c.Request().Context(), sharingcmd.RevokeShareLinkInput{
+	output, err := h.revokeShareLinkCmd.Execute(c.Request().Context(), sharingcmd.RevokeShareLinkInput{
 		ShareLinkID: shareLinkID,
 		RevokedBy:   claims.UserID,
 	})
@@ -221,6 +234,8 @@ func (h *ShareLinkHandler) RevokeShareLink(c echo.Context) error {
 		return err
 	}
 
+	middleware.AuditHelper(c, string(entity.AuditActionShareLinkRevoke), string(entity.AuditResourceShareLink), &output.RevokedShareLinkID, nil)
+
 	return presenter.NoContent(c)
 }
 
@@ -277,6 +292,15 @@ func (h *ShareLinkHandler) UpdateShareLink(c echo.Context) error {
 		return err
 	}
 
+	middleware.AuditHelper(c, string(entity.AuditActionShareLinkUpdate), string(entity.AuditResourceShareLink), &output.ShareLink.ID, map[string]interface{}{
+		"before": map[string]interface{}{
+			"expires_at":        output.PreviousExpiresAt,
+			"max_access_count":  output.PreviousMaxAccessCount,
+			"requires_password": output.PreviousRequiresPassword,
+		},
+		"after": shareLinkAuditSettings(output.ShareLink),
+	})
+
 	return presenter.OK(c, response.ToShareLinkResponse(output.ShareLink, h.baseURL))
 }
 
@@ -322,3 +346,13 @@ func (h *ShareLinkHandler) GetShareLinkHistory(c echo.Context) error {
 
 	return presenter.OK(c, response.ToShareLinkAccessListResponse(output.Accesses, output.Total))
 }
+
+// shareLinkAuditSettings は監査ログに記録する共有リンクの設定を返します
+// Note: パスワードはハッシュも含めて記録せず、設定の有無のみを記録する
+func shareLinkAuditSettings(shareLink *entity.ShareLink) map[string]interface{} {
+	return map[string]interface{}{
+		"expires_at":        shareLink.ExpiresAt,
+		"max_access_count":  shareLink.MaxAccessCount,
+		"requires_password": shareLink.RequiresPassword(),
+	}
+}
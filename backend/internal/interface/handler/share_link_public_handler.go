@@ -1,9 +1,12 @@
 package handler
 
 import (
+	"io"
+
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/dto/request"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/dto/response"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/middleware"
@@ -89,6 +92,12 @@ func (h *ShareLinkHandler) AccessShareLink(c echo.Context) error {
 		return err
 	}
 
+	middleware.AuditHelper(c, string(entity.AuditActionShareLinkAccess), string(entity.AuditResourceShareLink), &output.ShareLink.ID, map[string]interface{}{
+		"action":        action,
+		"resource_type": output.ResourceType,
+		"resource_id":   output.ResourceID,
+	})
+
 	return presenter.OK(c, response.ToShareLinkAccessResponse(output))
 }
 
@@ -143,5 +152,122 @@ func (h *ShareLinkHandler) GetDownloadViaShare(c echo.Context) error {
 		return err
 	}
 
+	middleware.AuditHelper(c, string(entity.AuditActionShareLinkAccess), string(entity.AuditResourceShareLink), &output.ShareLinkID, map[string]interface{}{
+		"action":  string(entity.AccessActionDownload),
+		"file_id": output.FileID,
+	})
+
 	return presenter.OK(c, response.ToShareDownloadResponse(output))
 }
+
+// GetPreviewViaShare は共有リンク経由でファイルのインラインプレビューを取得します
+// @Summary 共有リンク経由プレビュー
+// @Description 共有リンクのトークンを使用して、テキスト・ソースコード・CSV・Markdownファイルの先頭部分をダウンロードせずに表示できる形式で取得します（認証不要）。プレビューもアクセス回数に含まれます
+// @Tags ShareLinks
+// @Produce json
+// @Param token path string true "共有リンクトークン"
+// @Param fileId query string false "ファイルID（フォルダ共有の場合必須）"
+// @Param maxKb query int false "読み込むサイズ（KB、1〜1024）" default(64)
+// @Param X-Share-Password header string false "パスワード（パスワード保護されている場合）"
+// @Success 200 {object} handler.SwaggerFilePreviewResponse
+// @Failure 400 {object} handler.SwaggerErrorResponse
+// @Failure 401 {object} handler.SwaggerErrorResponse
+// @Failure 403 {object} handler.SwaggerErrorResponse
+// @Failure 404 {object} handler.SwaggerErrorResponse
+// @Failure 410 {object} handler.SwaggerErrorResponse
+// @Router /share/{token}/preview [get]
+func (h *ShareLinkHandler) GetPreviewViaShare(c echo.Context) error {
+	token := c.Param("token")
+	if token == "" {
+		return apperror.NewValidationError("invalid share link token", nil)
+	}
+
+	fileIDStr := c.QueryParam("fileId")
+	var fileID *uuid.UUID
+	if fileIDStr != "" {
+		parsed, err := uuid.Parse(fileIDStr)
+		if err != nil {
+			return apperror.NewValidationError("invalid file_id", nil)
+		}
+		fileID = &parsed
+	}
+
+	maxBytes, err := parsePreviewMaxBytes(c)
+	if err != nil {
+		return err
+	}
+
+	var userID *uuid.UUID
+	claims := middleware.GetAccessClaims(c)
+	if claims != nil {
+		userID = &claims.UserID
+	}
+
+	output, err := h.getPreviewViaShareQuery.Execute(c.Request().Context(), sharingqry.GetPreviewViaShareInput{
+		Token:     token,
+		Password:  c.Request().Header.Get("X-Share-Password"),
+		FileID:    fileID,
+		MaxBytes:  maxBytes,
+		UserID:    userID,
+		IPAddress: c.RealIP(),
+		UserAgent: c.Request().UserAgent(),
+	})
+	if err != nil {
+		return err
+	}
+
+	middleware.AuditHelper(c, string(entity.AuditActionShareLinkAccess), string(entity.AuditResourceShareLink), &output.ShareLinkID, map[string]interface{}{
+		"action":  string(entity.AccessActionView),
+		"file_id": output.FileID,
+	})
+
+	return presenter.OK(c, response.ToSharePreviewResponse(output))
+}
+
+// DownloadFolderViaShare は共有リンク経由でフォルダをZIPアーカイブとしてダウンロードします
+// @Summary 共有リンク経由フォルダダウンロード
+// @Description フォルダ共有リンクのトークンを使用して、フォルダ配下をフォルダ構造を保ったZIPとしてストリーミングします（認証不要）
+// @Tags ShareLinks
+// @Produce application/zip
+// @Param token path string true "共有リンクトークン"
+// @Param X-Share-Password header string false "パスワード（パスワード保護されている場合）"
+// @Success 200 {file} binary
+// @Failure 400 {object} handler.SwaggerErrorResponse
+// @Failure 401 {object} handler.SwaggerErrorResponse
+// @Failure 403 {object} handler.SwaggerErrorResponse
+// @Failure 404 {object} handler.SwaggerErrorResponse
+// @Failure 410 {object} handler.SwaggerErrorResponse
+// @Router /share/{token}/download/folder [get]
+func (h *ShareLinkHandler) DownloadFolderViaShare(c echo.Context) error {
+	token := c.Param("token")
+	if token == "" {
+		return apperror.NewValidationError("invalid share link token", nil)
+	}
+
+	var userID *uuid.UUID
+	claims := middleware.GetAccessClaims(c)
+	if claims != nil {
+		userID = &claims.UserID
+	}
+
+	ctx := c.Request().Context()
+	output, err := h.getFolderArchiveViaShareQuery.Execute(ctx, sharingqry.GetFolderArchiveViaShareInput{
+		Token:     token,
+		Password:  c.Request().Header.Get("X-Share-Password"),
+		UserID:    userID,
+		IPAddress: c.RealIP(),
+		UserAgent: c.Request().UserAgent(),
+	})
+	if err != nil {
+		return err
+	}
+
+	middleware.AuditHelper(c, string(entity.AuditActionShareLinkAccess), string(entity.AuditResourceShareLink), &output.ShareLinkID, map[string]interface{}{
+		"action":    string(entity.AccessActionDownload),
+		"folder_id": output.FolderID,
+	})
+
+	return presenter.Attachment(c, "application/zip", output.ArchiveName, func(w io.Writer) error {
+		return output.WriteZip(ctx, w)
+	})
+}
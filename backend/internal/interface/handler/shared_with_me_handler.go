@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/dto/response"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/middleware"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/presenter"
+	storageqry "github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/query"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// SharedWithMeHandler は自分に共有されたアイテム関連のHTTPハンドラーです
+type SharedWithMeHandler struct {
+	listSharedWithMeQuery *storageqry.ListSharedWithMeQuery
+}
+
+// NewSharedWithMeHandler は新しいSharedWithMeHandlerを作成します
+func NewSharedWithMeHandler(listSharedWithMeQuery *storageqry.ListSharedWithMeQuery) *SharedWithMeHandler {
+	return &SharedWithMeHandler{
+		listSharedWithMeQuery: listSharedWithMeQuery,
+	}
+}
+
+// ListSharedWithMe は他のユーザーから共有されたファイル・フォルダを取得します
+// @Summary 共有アイテム一覧取得
+// @Description ユーザーへの直接付与と所属グループへの付与で共有されたファイル・フォルダを取得します。同じアイテムへの付与は最も高いロールにまとめて返します
+// @Tags Sharing
+// @Produce json
+// @Security SessionCookie
+// @Param sort query string false "並び替えキー" Enums(sharedAt, name)
+// @Param order query string false "並び順（デフォルト: sharedAtはdesc、nameはasc）" Enums(asc, desc)
+// @Param limit query int false "取得件数"
+// @Param cursor query string false "カーソル"
+// @Success 200 {object} handler.SwaggerSharedWithMeResponse
+// @Failure 400 {object} handler.SwaggerErrorResponse
+// @Failure 401 {object} handler.SwaggerErrorResponse
+// @Router /shared-with-me [get]
+func (h *SharedWithMeHandler) ListSharedWithMe(c echo.Context) error {
+	claims := middleware.GetAccessClaims(c)
+	if claims == nil {
+		return apperror.NewUnauthorizedError("invalid token")
+	}
+
+	input := storageqry.ListSharedWithMeInput{
+		UserID:    claims.UserID,
+		SortBy:    storageqry.SharedWithMeSortKey(c.QueryParam("sort")),
+		SortOrder: storageqry.SortOrder(c.QueryParam("order")),
+		Cursor:    c.QueryParam("cursor"),
+	}
+	if v := c.QueryParam("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return apperror.NewValidationError("invalid limit", nil)
+		}
+		input.Limit = limit
+	}
+
+	output, err := h.listSharedWithMeQuery.Execute(c.Request().Context(), input)
+	if err != nil {
+		return err
+	}
+
+	return presenter.OK(c, response.ToSharedWithMeResponse(output))
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/dto/response"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/middleware"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/presenter"
+	storageqry "github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/query"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// StorageUsageHandler はストレージ使用量関連のHTTPハンドラーです
+type StorageUsageHandler struct {
+	getStorageUsageQuery    *storageqry.GetStorageUsageQuery
+	listDuplicateFilesQuery *storageqry.ListDuplicateFilesQuery
+}
+
+// NewStorageUsageHandler は新しいStorageUsageHandlerを作成します
+func NewStorageUsageHandler(
+	getStorageUsageQuery *storageqry.GetStorageUsageQuery,
+	listDuplicateFilesQuery *storageqry.ListDuplicateFilesQuery,
+) *StorageUsageHandler {
+	return &StorageUsageHandler{
+		getStorageUsageQuery:    getStorageUsageQuery,
+		listDuplicateFilesQuery: listDuplicateFilesQuery,
+	}
+}
+
+// GetMyStorageUsage は認証ユーザーのストレージ使用量を取得します
+// @Summary ストレージ使用量取得
+// @Description 認証ユーザーの使用量とクォータ上限、所属グループのクォータと使用量を取得します。limitBytesが0の場合は無制限です
+// @Tags Storage
+// @Produce json
+// @Security SessionCookie
+// @Success 200 {object} handler.SwaggerStorageUsageResponse
+// @Failure 401 {object} handler.SwaggerErrorResponse
+// @Router /me/storage [get]
+func (h *StorageUsageHandler) GetMyStorageUsage(c echo.Context) error {
+	claims := middleware.GetAccessClaims(c)
+	if claims == nil {
+		return apperror.NewUnauthorizedError("invalid token")
+	}
+
+	output, err := h.getStorageUsageQuery.Execute(c.Request().Context(), storageqry.GetStorageUsageInput{
+		UserID: claims.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return presenter.OK(c, response.ToStorageUsageResponse(output))
+}
+
+// ListMyDuplicateFiles は認証ユーザーの内容が重複しているファイルを取得します
+// @Summary 重複ファイル一覧取得
+// @Description 認証ユーザーのファイルのうち、現在のバージョンの内容（SHA-256）が同じものをまとめて取得します。サイズの大きいグループから順に返します
+// @Tags Storage
+// @Produce json
+// @Security SessionCookie
+// @Success 200 {object} handler.SwaggerDuplicateFilesResponse
+// @Failure 401 {object} handler.SwaggerErrorResponse
+// @Router /me/duplicates [get]
+func (h *StorageUsageHandler) ListMyDuplicateFiles(c echo.Context) error {
+	claims := middleware.GetAccessClaims(c)
+	if claims == nil {
+		return apperror.NewUnauthorizedError("invalid token")
+	}
+
+	output, err := h.listDuplicateFilesQuery.Execute(c.Request().Context(), storageqry.ListDuplicateFilesInput{
+		UserID: claims.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return presenter.OK(c, response.ToDuplicateFilesResponse(output))
+}
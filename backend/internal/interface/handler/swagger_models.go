@@ -123,6 +123,18 @@ type SwaggerCompleteUploadResponse struct {
 	Meta *presenter.Meta                 `json:"meta"`
 }
 
+// SwaggerUploadWebhookResponse は UploadWebhookResponse のラッパー
+type SwaggerUploadWebhookResponse struct {
+	Data response.UploadWebhookResponse `json:"data"`
+	Meta *presenter.Meta                `json:"meta"`
+}
+
+// SwaggerUploadPartResponse は UploadPartResponse のラッパー
+type SwaggerUploadPartResponse struct {
+	Data response.UploadPartResponse `json:"data"`
+	Meta *presenter.Meta             `json:"meta"`
+}
+
 // SwaggerUploadStatusResponse は UploadStatusResponse のラッパー
 type SwaggerUploadStatusResponse struct {
 	Data response.UploadStatusResponse `json:"data"`
@@ -135,12 +147,24 @@ type SwaggerDownloadURLResponse struct {
 	Meta *presenter.Meta              `json:"meta"`
 }
 
+// SwaggerFilePreviewResponse は FilePreviewResponse のラッパー
+type SwaggerFilePreviewResponse struct {
+	Data response.FilePreviewResponse `json:"data"`
+	Meta *presenter.Meta              `json:"meta"`
+}
+
 // SwaggerFileVersionsResponse は FileVersionsResponse のラッパー
 type SwaggerFileVersionsResponse struct {
 	Data response.FileVersionsResponse `json:"data"`
 	Meta *presenter.Meta               `json:"meta"`
 }
 
+// SwaggerRestoreFileVersionResponse は RestoreFileVersionResponse のラッパー
+type SwaggerRestoreFileVersionResponse struct {
+	Data response.RestoreFileVersionResponse `json:"data"`
+	Meta *presenter.Meta                     `json:"meta"`
+}
+
 // SwaggerRenameFileResponse は RenameFileResponse のラッパー
 type SwaggerRenameFileResponse struct {
 	Data response.RenameFileResponse `json:"data"`
@@ -171,6 +195,12 @@ type SwaggerRestoreFileResponse struct {
 	Meta *presenter.Meta              `json:"meta"`
 }
 
+// SwaggerRestoreFolderResponse は RestoreFolderResponse のラッパー
+type SwaggerRestoreFolderResponse struct {
+	Data response.RestoreFolderResponse `json:"data"`
+	Meta *presenter.Meta                `json:"meta"`
+}
+
 // SwaggerDeletedResponse は削除成功レスポンス (data=null)
 type SwaggerDeletedResponse struct {
 	Data *struct{}       `json:"data"`
@@ -189,6 +219,86 @@ type SwaggerAbortUploadResponse struct {
 	Meta *presenter.Meta              `json:"meta"`
 }
 
+// ---- Copy ----
+
+// SwaggerFileResponse は FileResponse のラッパー
+type SwaggerFileResponse struct {
+	Data response.FileResponse `json:"data"`
+	Meta *presenter.Meta       `json:"meta"`
+}
+
+// SwaggerCopyFolderResponse は CopyFolderResponse のラッパー
+type SwaggerCopyFolderResponse struct {
+	Data response.CopyFolderResponse `json:"data"`
+	Meta *presenter.Meta             `json:"meta"`
+}
+
+// SwaggerCopyJobResponse は CopyJobResponse のラッパー
+type SwaggerCopyJobResponse struct {
+	Data response.CopyJobResponse `json:"data"`
+	Meta *presenter.Meta          `json:"meta"`
+}
+
+// ---- Bulk ----
+
+// SwaggerBulkOperationResponse は BulkOperationResponse のラッパー
+type SwaggerBulkOperationResponse struct {
+	Data response.BulkOperationResponse `json:"data"`
+	Meta *presenter.Meta                `json:"meta"`
+}
+
+// ---- Search ----
+
+// SwaggerSearchResponse は SearchResponse のラッパー
+type SwaggerSearchResponse struct {
+	Data response.SearchResponse `json:"data"`
+	Meta *presenter.Meta         `json:"meta"`
+}
+
+// ---- Shared With Me ----
+
+// SwaggerSharedWithMeResponse は SharedWithMeResponse のラッパー
+type SwaggerSharedWithMeResponse struct {
+	Data response.SharedWithMeResponse `json:"data"`
+	Meta *presenter.Meta               `json:"meta"`
+}
+
+// ---- Audit Logs ----
+
+// SwaggerAuditLogListResponse は AuditLogListResponse のラッパー
+type SwaggerAuditLogListResponse struct {
+	Data response.AuditLogListResponse `json:"data"`
+	Meta *presenter.Meta               `json:"meta"`
+}
+
+// ---- Storage Usage ----
+
+// SwaggerStorageUsageResponse は StorageUsageResponse のラッパー
+type SwaggerStorageUsageResponse struct {
+	Data response.StorageUsageResponse `json:"data"`
+	Meta *presenter.Meta               `json:"meta"`
+}
+
+// SwaggerDuplicateFilesResponse は DuplicateFilesResponse のラッパー
+type SwaggerDuplicateFilesResponse struct {
+	Data response.DuplicateFilesResponse `json:"data"`
+	Meta *presenter.Meta                 `json:"meta"`
+}
+
+// ---- Version Retention ----
+
+// SwaggerVersionRetentionPolicyResponse は VersionRetentionPolicyResponse のラッパー
+type SwaggerVersionRetentionPolicyResponse struct {
+	Data response.VersionRetentionPolicyResponse `json:"data"`
+	Meta *presenter.Meta                         `json:"meta"`
+}
+
+// SwaggerPinFileVersionResponse は PinFileVersionResponse のラッパー
+type SwaggerPinFileVersionResponse struct {
+	Data response.PinFileVersionResponse `json:"data"`
+	Meta *presenter.Meta                 `json:"meta"`
+}
+
 // ---- Group ----
 
 // SwaggerGroupWithMembershipResponse は GroupWithMembershipResponse のラッパー
@@ -233,6 +343,12 @@ type SwaggerInvitationListResponse struct {
 	Meta *presenter.Meta               `json:"meta"`
 }
 
+// SwaggerGroupQuotaResponse は GroupQuotaResponse のラッパー
+type SwaggerGroupQuotaResponse struct {
+	Data response.GroupQuotaResponse `json:"data"`
+	Meta *presenter.Meta             `json:"meta"`
+}
+
 // SwaggerPendingInvitationListResponse は PendingInvitationResponse リストのラッパー
 type SwaggerPendingInvitationListResponse struct {
 	Data []response.PendingInvitationResponse `json:"data"`
@@ -6,6 +6,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/dto/request"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/dto/response"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/middleware"
@@ -19,6 +21,7 @@ import (
 type TrashHandler struct {
 	trashFileCommand             *storagecmd.TrashFileCommand
 	restoreFileCommand           *storagecmd.RestoreFileCommand
+	restoreFolderCommand         *storagecmd.RestoreFolderCommand
 	permanentlyDeleteFileCommand *storagecmd.PermanentlyDeleteFileCommand
 	emptyTrashCommand            *storagecmd.EmptyTrashCommand
 	listTrashQuery               *storageqry.ListTrashQuery
@@ -28,6 +31,7 @@ type TrashHandler struct {
 func NewTrashHandler(
 	trashFileCommand *storagecmd.TrashFileCommand,
 	restoreFileCommand *storagecmd.RestoreFileCommand,
+	restoreFolderCommand *storagecmd.RestoreFolderCommand,
 	permanentlyDeleteFileCommand *storagecmd.PermanentlyDeleteFileCommand,
 	emptyTrashCommand *storagecmd.EmptyTrashCommand,
 	listTrashQuery *storageqry.ListTrashQuery,
@@ -35,6 +39,7 @@ func NewTrashHandler(
 	return &TrashHandler{
 		trashFileCommand:             trashFileCommand,
 		restoreFileCommand:           restoreFileCommand,
+		restoreFolderCommand:         restoreFolderCommand,
 		permanentlyDeleteFileCommand: permanentlyDeleteFileCommand,
 		emptyTrashCommand:            emptyTrashCommand,
 		listTrashQuery:               listTrashQuery,
@@ -72,6 +77,11 @@ func (h *TrashHandler) TrashFile(c echo.Context) error {
 		return err
 	}
 
+	middleware.AuditHelper(c, string(entity.AuditActionFileTrash), string(entity.AuditResourceFile), &fileID, map[string]interface{}{
+		"archived_file_id": output.ArchivedFileID,
+		"original_path":    output.OriginalPath,
+	})
+
 	return presenter.OK(c, response.TrashFileResponse{
 		ArchivedFileID: output.ArchivedFileID.String(),
 		ExpiresAt:      output.ExpiresAt,
@@ -80,7 +90,7 @@ func (h *TrashHandler) TrashFile(c echo.Context) error {
 
 // ListTrash はゴミ箱一覧を取得します
 // @Summary ゴミ箱一覧取得
-// @Description ゴミ箱に入っているファイルの一覧を取得します
+// @Description ゴミ箱に入っているファイルとフォルダの一覧を削除日時の新しい順に取得します
 // @Tags Trash
 // @Produce json
 // @Security SessionCookie
@@ -157,20 +167,109 @@ func (h *TrashHandler) RestoreFile(c echo.Context) error {
 		}
 		restoreFolderID = &id
 	}
+	conflictStrategy, err := valueobject.NewConflictStrategy(req.ConflictStrategy)
+	if err != nil {
+		return apperror.NewValidationError(err.Error(), nil)
+	}
 
 	output, err := h.restoreFileCommand.Execute(c.Request().Context(), storagecmd.RestoreFileInput{
-		ArchivedFileID:  archivedFileID,
-		RestoreFolderID: restoreFolderID,
-		UserID:          claims.UserID,
+		ArchivedFileID:   archivedFileID,
+		RestoreFolderID:  restoreFolderID,
+		UserID:           claims.UserID,
+		ConflictStrategy: conflictStrategy,
 	})
 	if err != nil {
 		return err
 	}
 
+	if !output.Skipped {
+		middleware.AuditHelper(c, string(entity.AuditActionFileRestore), string(entity.AuditResourceFile), &output.FileID, map[string]interface{}{
+			"archived_file_id": archivedFileID,
+			"folder_id":        output.FolderID,
+			"name":             output.Name,
+			"overwritten":      output.Overwritten,
+		})
+	}
+
 	return presenter.OK(c, response.RestoreFileResponse{
-		FileID:   output.FileID.String(),
-		FolderID: output.FolderID.String(),
-		Name:     output.Name,
+		FileID:        output.FileID.String(),
+		FolderID:      output.FolderID.String(),
+		Name:          output.Name,
+		Skipped:       output.Skipped,
+		Overwritten:   output.Overwritten,
+		VersionNumber: output.VersionNumber,
+	})
+}
+
+// RestoreFolder はフォルダをゴミ箱から復元します
+// @Summary フォルダ復元
+// @Description ゴミ箱からフォルダをサブフォルダとファイルを含む階層ごと復元します
+// @Tags Trash
+// @Accept json
+// @Produce json
+// @Security SessionCookie
+// @Param id path string true "アーカイブフォルダID"
+// @Param body body request.RestoreFolderRequest false "復元先の親フォルダ情報"
+// @Success 200 {object} handler.SwaggerRestoreFolderResponse
+// @Failure 400 {object} handler.SwaggerErrorResponse
+// @Failure 401 {object} handler.SwaggerErrorResponse
+// @Failure 403 {object} handler.SwaggerErrorResponse
+// @Failure 404 {object} handler.SwaggerErrorResponse
+// @Failure 409 {object} handler.SwaggerErrorResponse
+// @Router /trash/folders/{id}/restore [post]
+func (h *TrashHandler) RestoreFolder(c echo.Context) error {
+	claims := middleware.GetAccessClaims(c)
+	if claims == nil {
+		return apperror.NewUnauthorizedError("invalid token")
+	}
+
+	archivedFolderID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.NewValidationError("invalid archived folder ID", nil)
+	}
+
+	var req request.RestoreFolderRequest
+	if err := c.Bind(&req); err != nil {
+		req = request.RestoreFolderRequest{}
+	}
+
+	var restoreFolderID *uuid.UUID
+	if req.RestoreFolderID != nil {
+		id, err := uuid.Parse(*req.RestoreFolderID)
+		if err != nil {
+			return apperror.NewValidationError("invalid restore folder ID", nil)
+		}
+		restoreFolderID = &id
+	}
+
+	output, err := h.restoreFolderCommand.Execute(c.Request().Context(), storagecmd.RestoreFolderInput{
+		ArchivedFolderID: archivedFolderID,
+		RestoreFolderID:  restoreFolderID,
+		UserID:           claims.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	middleware.AuditHelper(c, string(entity.AuditActionFolderRestore), string(entity.AuditResourceFolder), &output.FolderID, map[string]interface{}{
+		"archived_folder_id": archivedFolderID,
+		"parent_id":          output.ParentID,
+		"folder_count":       output.FolderCount,
+		"file_count":         output.FileCount,
+	})
+
+	var parentID *string
+	if output.ParentID != nil {
+		s := output.ParentID.String()
+		parentID = &s
+	}
+
+	return presenter.OK(c, response.RestoreFolderResponse{
+		FolderID:    output.FolderID.String(),
+		ParentID:    parentID,
+		Name:        output.Name,
+		FolderCount: output.FolderCount,
+		FileCount:   output.FileCount,
 	})
 }
 
@@ -206,6 +305,10 @@ func (h *TrashHandler) PermanentlyDeleteFile(c echo.Context) error {
 		return err
 	}
 
+	middleware.AuditHelper(c, string(entity.AuditActionFileDelete), string(entity.AuditResourceFile), nil, map[string]interface{}{
+		"archived_file_id": archivedFileID,
+	})
+
 	return presenter.NoContent(c)
 }
 
@@ -233,6 +336,10 @@ func (h *TrashHandler) EmptyTrash(c echo.Context) error {
 		return err
 	}
 
+	middleware.AuditHelper(c, string(entity.AuditActionTrashEmpty), string(entity.AuditResourceUser), &claims.UserID, map[string]interface{}{
+		"deleted_count": output.DeletedCount,
+	})
+
 	return presenter.Accepted(c, response.EmptyTrashResponse{
 		Message:      "Trash emptying started",
 		DeletedCount: output.DeletedCount,
@@ -0,0 +1,271 @@
+package handler
+
+import (
+	"encoding/base64"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/middleware"
+	storagecmd "github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/command"
+	storageqry "github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/query"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+const (
+	// tusExtensions はサポートするtusプロトコルの拡張です
+	tusExtensions = "creation"
+	// tusChunkContentType はPATCHリクエストのContent-Typeです
+	tusChunkContentType = "application/offset+octet-stream"
+)
+
+// TusHandler はtusプロトコル（1.0 core + creation）によるレジューム可能なアップロードのHTTPハンドラーです
+type TusHandler struct {
+	initiateUploadCommand        *storagecmd.InitiateUploadCommand
+	initiateVersionUploadCommand *storagecmd.InitiateVersionUploadCommand
+	writeUploadChunkCommand      *storagecmd.WriteUploadChunkCommand
+	getUploadOffsetQuery         *storageqry.GetUploadOffsetQuery
+}
+
+// NewTusHandler は新しいTusHandlerを作成します
+func NewTusHandler(
+	initiateUploadCommand *storagecmd.InitiateUploadCommand,
+	initiateVersionUploadCommand *storagecmd.InitiateVersionUploadCommand,
+	writeUploadChunkCommand *storagecmd.WriteUploadChunkCommand,
+	getUploadOffsetQuery *storageqry.GetUploadOffsetQuery,
+) *TusHandler {
+	return &TusHandler{
+		initiateUploadCommand:        initiateUploadCommand,
+		initiateVersionUploadCommand: initiateVersionUploadCommand,
+		writeUploadChunkCommand:      writeUploadChunkCommand,
+		getUploadOffsetQuery:         getUploadOffsetQuery,
+	}
+}
+
+// Options はサーバーがサポートするtusのバージョンと拡張を返します
+// @Summary tusサポート情報
+// @Description サポートするtusプロトコルのバージョン（Tus-Version）と拡張（Tus-Extension）を返します
+// @Tags Files
+// @Success 204 "Tus-Version, Tus-Extension ヘッダーを返します"
+// @Router /tus [options]
+func (h *TusHandler) Options(c echo.Context) error {
+	c.Response().Header().Set(middleware.TusVersionHeader, middleware.TusVersion)
+	c.Response().Header().Set("Tus-Extension", tusExtensions)
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Create はアップロードを作成します（creation拡張）
+// @Summary tusアップロード作成
+// @Description Upload-Lengthとファイル情報を含むUpload-Metadataからアップロードセッションを作成し、Locationヘッダーでアップロード先URLを返します。Upload-Metadataのキーは filename, filetype, folderId（新規ファイル）または fileId（新バージョン）, conflictStrategy, checksumAlgorithm, checksum です
+// @Tags Files
+// @Security SessionCookie
+// @Param Tus-Resumable header string true "tusプロトコルのバージョン（1.0.0）"
+// @Param Upload-Length header int true "アップロードする合計サイズ（バイト）"
+// @Param Upload-Metadata header string true "カンマ区切りの「キー Base64値」"
+// @Success 201 "Location ヘッダーでアップロード先URLを返します"
+// @Failure 400 {object} handler.SwaggerErrorResponse
+// @Failure 401 {object} handler.SwaggerErrorResponse
+// @Failure 403 {object} handler.SwaggerErrorResponse
+// @Failure 404 {object} handler.SwaggerErrorResponse
+// @Failure 409 {object} handler.SwaggerErrorResponse
+// @Failure 412 "サポートしていないtusバージョン"
+// @Router /tus [post]
+func (h *TusHandler) Create(c echo.Context) error {
+	claims := middleware.GetAccessClaims(c)
+	if claims == nil {
+		return apperror.NewUnauthorizedError("invalid token")
+	}
+
+	size, err := strconv.ParseInt(c.Request().Header.Get("Upload-Length"), 10, 64)
+	if err != nil || size <= 0 {
+		return apperror.NewValidationError("Upload-Length header must be a positive integer", nil)
+	}
+	metadata, err := parseTusMetadata(c.Request().Header.Get("Upload-Metadata"))
+	if err != nil {
+		return err
+	}
+	expectedChecksum, err := parseExpectedChecksum(metadata["checksumAlgorithm"], metadata["checksum"])
+	if err != nil {
+		return err
+	}
+
+	var output *storagecmd.InitiateUploadOutput
+	if rawFileID, ok := metadata["fileId"]; ok {
+		// 既存ファイルの新バージョン
+		fileID, err := uuid.Parse(rawFileID)
+		if err != nil {
+			return apperror.NewValidationError("invalid file ID", nil)
+		}
+		output, err = h.initiateVersionUploadCommand.Execute(c.Request().Context(), storagecmd.InitiateVersionUploadInput{
+			FileID:           fileID,
+			Size:             size,
+			UserID:           claims.UserID,
+			ExpectedChecksum: expectedChecksum,
+		})
+		if err != nil {
+			return err
+		}
+	} else {
+		folderID, err := uuid.Parse(metadata["folderId"])
+		if err != nil {
+			return apperror.NewValidationError("folderId metadata is required", nil)
+		}
+		if metadata["filename"] == "" {
+			return apperror.NewValidationError("filename metadata is required", nil)
+		}
+		mimeType := metadata["filetype"]
+		if mimeType == "" {
+			mimeType = "application/octet-stream"
+		}
+		conflictStrategy, err := valueobject.NewConflictStrategy(metadata["conflictStrategy"])
+		if err != nil {
+			return apperror.NewValidationError(err.Error(), nil)
+		}
+		// スキップしたアップロードはtusのアップロードURLを返せないため受け付けない
+		if conflictStrategy == valueobject.ConflictStrategySkip {
+			return apperror.NewValidationError("conflictStrategy skip is not supported for tus uploads", nil)
+		}
+
+		output, err = h.initiateUploadCommand.Execute(c.Request().Context(), storagecmd.InitiateUploadInput{
+			FolderID:         folderID,
+			FileName:         metadata["filename"],
+			MimeType:         mimeType,
+			Size:             size,
+			OwnerID:          claims.UserID,
+			ConflictStrategy: conflictStrategy,
+			ExpectedChecksum: expectedChecksum,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	auditUpload(c, output, size)
+
+	location := strings.TrimSuffix(c.Request().URL.Path, "/") + "/" + output.SessionID.String()
+	c.Response().Header().Set(echo.HeaderLocation, location)
+	return c.NoContent(http.StatusCreated)
+}
+
+// Head はアップロードの受信済みバイト数を返します
+// @Summary tusアップロード位置取得
+// @Description 受信済みバイト数（Upload-Offset）と合計サイズ（Upload-Length）を返します。クライアントはUpload-Offsetの位置から再開します
+// @Tags Files
+// @Security SessionCookie
+// @Param Tus-Resumable header string true "tusプロトコルのバージョン（1.0.0）"
+// @Param sessionId path string true "セッションID"
+// @Success 200 "Upload-Offset, Upload-Length ヘッダーを返します"
+// @Failure 403 {object} handler.SwaggerErrorResponse
+// @Failure 404 {object} handler.SwaggerErrorResponse
+// @Failure 410 {object} handler.SwaggerErrorResponse
+// @Failure 412 "サポートしていないtusバージョン"
+// @Router /tus/{sessionId} [head]
+func (h *TusHandler) Head(c echo.Context) error {
+	claims := middleware.GetAccessClaims(c)
+	if claims == nil {
+		return apperror.NewUnauthorizedError("invalid token")
+	}
+
+	sessionID, err := uuid.Parse(c.Param("sessionId"))
+	if err != nil {
+		return apperror.NewNotFoundError("upload session")
+	}
+
+	output, err := h.getUploadOffsetQuery.Execute(c.Request().Context(), storageqry.GetUploadOffsetInput{
+		SessionID: sessionID,
+		UserID:    claims.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	header := c.Response().Header()
+	header.Set("Upload-Offset", strconv.FormatInt(output.Offset, 10))
+	header.Set("Upload-Length", strconv.FormatInt(output.Length, 10))
+	header.Set(echo.HeaderCacheControl, "no-store")
+	return c.NoContent(http.StatusOK)
+}
+
+// Patch はUpload-Offsetの位置からリクエストボディを書き込みます
+// @Summary tusチャンク書き込み
+// @Description Upload-Offsetの位置からリクエストボディを書き込み、書き込み後の受信済みバイト数を返します。全バイトを受信するとアップロードを完了します
+// @Tags Files
+// @Accept application/offset+octet-stream
+// @Security SessionCookie
+// @Param Tus-Resumable header string true "tusプロトコルのバージョン（1.0.0）"
+// @Param Upload-Offset header int true "書き込みを開始する位置（受信済みバイト数）"
+// @Param sessionId path string true "セッションID"
+// @Success 204 "Upload-Offset ヘッダーで書き込み後の受信済みバイト数を返します"
+// @Failure 400 {object} handler.SwaggerErrorResponse
+// @Failure 403 {object} handler.SwaggerErrorResponse
+// @Failure 404 {object} handler.SwaggerErrorResponse
+// @Failure 409 {object} handler.SwaggerErrorResponse
+// @Failure 410 {object} handler.SwaggerErrorResponse
+// @Failure 412 "サポートしていないtusバージョン"
+// @Failure 415 "Content-Typeがapplication/offset+octet-streamではない"
+// @Router /tus/{sessionId} [patch]
+func (h *TusHandler) Patch(c echo.Context) error {
+	claims := middleware.GetAccessClaims(c)
+	if claims == nil {
+		return apperror.NewUnauthorizedError("invalid token")
+	}
+
+	sessionID, err := uuid.Parse(c.Param("sessionId"))
+	if err != nil {
+		return apperror.NewNotFoundError("upload session")
+	}
+	if c.Request().Header.Get(echo.HeaderContentType) != tusChunkContentType {
+		return echo.NewHTTPError(http.StatusUnsupportedMediaType, "Content-Type must be "+tusChunkContentType)
+	}
+	offset, err := strconv.ParseInt(c.Request().Header.Get("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		return apperror.NewValidationError("Upload-Offset header must be a non-negative integer", nil)
+	}
+	// パートの書き込みにサイズが必要なため、チャンク転送は受け付けない
+	if c.Request().ContentLength < 0 {
+		return apperror.NewValidationError("Content-Length header is required", nil)
+	}
+
+	output, err := h.writeUploadChunkCommand.Execute(c.Request().Context(), storagecmd.WriteUploadChunkInput{
+		SessionID: sessionID,
+		UserID:    claims.UserID,
+		Offset:    offset,
+		Body:      c.Request().Body,
+		Size:      c.Request().ContentLength,
+	})
+	if err != nil {
+		return err
+	}
+
+	if output.Completed || output.Verifying {
+		auditUploadComplete(c, output.FileID, output.SessionID, output.Verifying)
+	}
+
+	c.Response().Header().Set("Upload-Offset", strconv.FormatInt(output.Offset, 10))
+	return c.NoContent(http.StatusNoContent)
+}
+
+// parseTusMetadata はUpload-Metadataヘッダー（カンマ区切りの「キー Base64値」）を解析します
+func parseTusMetadata(header string) (map[string]string, error) {
+	metadata := make(map[string]string)
+	if strings.TrimSpace(header) == "" {
+		return metadata, nil
+	}
+
+	for _, pair := range strings.Split(header, ",") {
+		key, encoded, _ := strings.Cut(strings.TrimSpace(pair), " ")
+		if key == "" {
+			return nil, apperror.NewValidationError("invalid Upload-Metadata header", nil)
+		}
+		value, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, apperror.NewValidationError("invalid Upload-Metadata value for "+key, nil)
+		}
+		metadata[key] = string(value)
+	}
+	return metadata, nil
+}
@@ -1,9 +1,17 @@
 package handler
 
 import (
+	"errors"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/dto/request"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/dto/response"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/middleware"
@@ -15,24 +23,33 @@ import (
 
 // UploadHandler はファイルアップロード関連のHTTPハンドラーです
 type UploadHandler struct {
-	initiateUploadCommand *storagecmd.InitiateUploadCommand
-	completeUploadCommand *storagecmd.CompleteUploadCommand
-	abortUploadCommand    *storagecmd.AbortUploadCommand
-	getUploadStatusQuery  *storageqry.GetUploadStatusQuery
+	initiateUploadCommand        *storagecmd.InitiateUploadCommand
+	initiateVersionUploadCommand *storagecmd.InitiateVersionUploadCommand
+	completeUploadCommand        *storagecmd.CompleteUploadCommand
+	finalizeUploadCommand        *storagecmd.FinalizeUploadCommand
+	uploadPartCommand            *storagecmd.UploadPartCommand
+	abortUploadCommand           *storagecmd.AbortUploadCommand
+	getUploadStatusQuery         *storageqry.GetUploadStatusQuery
 }
 
 // NewUploadHandler は新しいUploadHandlerを作成します
 func NewUploadHandler(
 	initiateUploadCommand *storagecmd.InitiateUploadCommand,
+	initiateVersionUploadCommand *storagecmd.InitiateVersionUploadCommand,
 	completeUploadCommand *storagecmd.CompleteUploadCommand,
+	finalizeUploadCommand *storagecmd.FinalizeUploadCommand,
+	uploadPartCommand *storagecmd.UploadPartCommand,
 	abortUploadCommand *storagecmd.AbortUploadCommand,
 	getUploadStatusQuery *storageqry.GetUploadStatusQuery,
 ) *UploadHandler {
 	return &UploadHandler{
-		initiateUploadCommand: initiateUploadCommand,
-		completeUploadCommand: completeUploadCommand,
-		abortUploadCommand:    abortUploadCommand,
-		getUploadStatusQuery:  getUploadStatusQuery,
+		initiateUploadCommand:        initiateUploadCommand,
+		initiateVersionUploadCommand: initiateVersionUploadCommand,
+		completeUploadCommand:        completeUploadCommand,
+		finalizeUploadCommand:        finalizeUploadCommand,
+		uploadPartCommand:            uploadPartCommand,
+		abortUploadCommand:           abortUploadCommand,
+		getUploadStatusQuery:         getUploadStatusQuery,
 	}
 }
 
@@ -44,9 +61,11 @@ func NewUploadHandler(
 // @Produce json
 // @Security SessionCookie
 // @Param body body request.InitiateUploadRequest true "アップロード情報"
+// @Success 200 {object} handler.SwaggerInitiateUploadResponse "conflictStrategy=skip により同名ファイルがありスキップした場合"
 // @Success 201 {object} handler.SwaggerInitiateUploadResponse
 // @Failure 400 {object} handler.SwaggerErrorResponse
 // @Failure 401 {object} handler.SwaggerErrorResponse
+// @Failure 409 {object} handler.SwaggerErrorResponse
 // @Router /files/upload [post]
 func (h *UploadHandler) InitiateUpload(c echo.Context) error {
 	claims := middleware.GetAccessClaims(c)
@@ -69,54 +88,268 @@ func (h *UploadHandler) InitiateUpload(c echo.Context) error {
 	if err != nil {
 		return apperror.NewValidationError("invalid folder ID", nil)
 	}
+	conflictStrategy, err := valueobject.NewConflictStrategy(req.ConflictStrategy)
+	if err != nil {
+		return apperror.NewValidationError(err.Error(), nil)
+	}
+	expectedChecksum, err := parseExpectedChecksum(req.ChecksumAlgorithm, req.Checksum)
+	if err != nil {
+		return err
+	}
 
 	output, err := h.initiateUploadCommand.Execute(c.Request().Context(), storagecmd.InitiateUploadInput{
-		FolderID: folderID,
-		FileName: req.FileName,
-		MimeType: req.MimeType,
-		Size:     req.Size,
-		OwnerID:  claims.UserID,
+		FolderID:         folderID,
+		FileName:         req.FileName,
+		MimeType:         req.MimeType,
+		Size:             req.Size,
+		OwnerID:          claims.UserID,
+		ConflictStrategy: conflictStrategy,
+		ExpectedChecksum: expectedChecksum,
 	})
 	if err != nil {
 		return err
 	}
 
+	if output.Skipped {
+		return presenter.OK(c, response.ToInitiateUploadResponse(output))
+	}
+	auditUpload(c, output, req.Size)
 	return presenter.Created(c, response.ToInitiateUploadResponse(output))
 }
 
-// CompleteUpload はアップロードを完了します（MinIO Webhook用）
-// @Summary アップロード完了
-// @Description MinIO Webhookからの通知を受けてアップロードを完了します
+// InitiateVersionUpload は既存ファイルの新バージョンのアップロードを開始します
+// @Summary 新バージョンのアップロード開始
+// @Description 既存ファイルに新しいバージョンをアップロードするセッションを開始し、署名付きURLを返します
 // @Tags Files
 // @Accept json
 // @Produce json
-// @Param body body request.CompleteUploadRequest true "アップロード完了情報"
-// @Success 200 {object} handler.SwaggerCompleteUploadResponse
+// @Security SessionCookie
+// @Param id path string true "ファイルID"
+// @Param body body request.InitiateVersionUploadRequest true "アップロード情報"
+// @Success 201 {object} handler.SwaggerInitiateUploadResponse
 // @Failure 400 {object} handler.SwaggerErrorResponse
-// @Router /files/upload/complete [post]
-func (h *UploadHandler) CompleteUpload(c echo.Context) error {
-	var req request.CompleteUploadRequest
+// @Failure 401 {object} handler.SwaggerErrorResponse
+// @Failure 403 {object} handler.SwaggerErrorResponse
+// @Failure 404 {object} handler.SwaggerErrorResponse
+// @Router /files/{id}/versions/upload [post]
+func (h *UploadHandler) InitiateVersionUpload(c echo.Context) error {
+	claims := middleware.GetAccessClaims(c)
+	if claims == nil {
+		return apperror.NewUnauthorizedError("invalid token")
+	}
+
+	fileID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.NewValidationError("invalid file ID", nil)
+	}
+
+	var req request.InitiateVersionUploadRequest
 	if err := c.Bind(&req); err != nil {
 		return apperror.NewValidationError("invalid request body", nil)
 	}
 	if err := c.Validate(&req); err != nil {
 		return err
 	}
+	expectedChecksum, err := parseExpectedChecksum(req.ChecksumAlgorithm, req.Checksum)
+	if err != nil {
+		return err
+	}
 
-	output, err := h.completeUploadCommand.Execute(c.Request().Context(), storagecmd.CompleteUploadInput{
-		StorageKey:     req.StorageKey,
-		MinioVersionID: req.MinioVersionID,
-		Size:           req.Size,
-		ETag:           req.ETag,
+	output, err := h.initiateVersionUploadCommand.Execute(c.Request().Context(), storagecmd.InitiateVersionUploadInput{
+		FileID:           fileID,
+		Size:             req.Size,
+		UserID:           claims.UserID,
+		ExpectedChecksum: expectedChecksum,
 	})
 	if err != nil {
 		return err
 	}
 
+	auditUpload(c, output, req.Size)
+	return presenter.Created(c, response.ToInitiateUploadResponse(output))
+}
+
+// auditUpload はアップロード開始を監査ログに記録します
+// Note: 完了はWebhookなどユーザーのリクエスト外で行われる場合があるため、開始時点でも記録する
+func auditUpload(c echo.Context, output *storagecmd.InitiateUploadOutput, size int64) {
+	middleware.AuditHelper(c, string(entity.AuditActionFileUpload), string(entity.AuditResourceFile), &output.FileID, map[string]interface{}{
+		"session_id":  output.SessionID,
+		"name":        output.FileName,
+		"size":        size,
+		"new_version": output.IsNewVersion,
+	})
+}
+
+// auditUploadComplete はユーザーのリクエストで全内容の受信が完了したアップロードを監査ログに記録します
+// 内容の検証待ちとなった場合は、verifyingを記録します
+func auditUploadComplete(c echo.Context, fileID, sessionID uuid.UUID, verifying bool) {
+	middleware.AuditHelper(c, string(entity.AuditActionFileUploadComplete), string(entity.AuditResourceFile), &fileID, map[string]interface{}{
+		"session_id": sessionID,
+		"verifying":  verifying,
+	})
+}
+
+// CompleteUpload はバケット通知を受けてアップロードを完了します（MinIO Webhook用）
+// @Summary アップロード完了（Webhook）
+// @Description MinIOのバケット通知（s3:ObjectCreated:*）を受けてアップロードを完了します。共有トークン（Authorization: Bearer）またはリクエストボディのHMAC-SHA256署名（X-Webhook-Signature: sha256=...）で認証します。アップロードセッションのないオブジェクトやパートのオブジェクトの通知は無視します
+// @Tags Files
+// @Accept json
+// @Produce json
+// @Param body body request.BucketNotification true "MinIOバケット通知"
+// @Success 200 {object} handler.SwaggerUploadWebhookResponse
+// @Failure 400 {object} handler.SwaggerErrorResponse
+// @Failure 401 {object} handler.SwaggerErrorResponse
+// @Router /files/upload/complete [post]
+func (h *UploadHandler) CompleteUpload(c echo.Context) error {
+	var req request.BucketNotification
+	if err := c.Bind(&req); err != nil {
+		return apperror.NewValidationError("invalid request body", nil)
+	}
+
+	uploads := make([]response.CompleteUploadResponse, 0, len(req.Records))
+	for _, record := range req.Records {
+		if !strings.HasPrefix(record.EventName, "s3:ObjectCreated:") {
+			continue
+		}
+		// オブジェクトキーはURLエンコードされている
+		key, err := url.QueryUnescape(record.S3.Object.Key)
+		if err != nil {
+			continue
+		}
+		// パートのオブジェクト（{key}.partN）などファイル本体以外のオブジェクトは無視
+		if _, err := uuid.Parse(key); err != nil {
+			continue
+		}
+
+		output, err := h.completeUploadCommand.Execute(c.Request().Context(), storagecmd.CompleteUploadInput{
+			StorageKey:     key,
+			MinioVersionID: record.S3.Object.VersionID,
+			Size:           record.S3.Object.Size,
+			ETag:           record.S3.Object.ETag,
+		})
+		if err != nil {
+			// コピー先などセッションのないオブジェクト、中断済みのセッションは再送されないよう成功扱いにする
+			var appErr *apperror.AppError
+			if errors.As(err, &appErr) && (appErr.Code == apperror.CodeNotFound || appErr.Code == apperror.CodeValidationError) {
+				slog.Debug("ignored bucket notification", "key", key, "reason", appErr.Message)
+				continue
+			}
+			return err
+		}
+
+		uploads = append(uploads, response.CompleteUploadResponse{
+			FileID:    output.FileID.String(),
+			SessionID: output.SessionID.String(),
+			Completed: output.Completed,
+			Verifying: output.Verifying,
+		})
+	}
+
+	return presenter.OK(c, response.UploadWebhookResponse{Uploads: uploads})
+}
+
+// FinalizeUpload はクライアントからの通知でアップロードを完了します
+// @Summary アップロード完了（クライアント）
+// @Description Presigned URLでのアップロード後に呼び出します。ストレージ上のオブジェクトを確認してからファイルを有効化します。マルチパートの場合は全パーツを確認して結合します。Webhookで完了済みの場合も成功を返します
+// @Tags Files
+// @Produce json
+// @Security SessionCookie
+// @Param sessionId path string true "セッションID"
+// @Success 200 {object} handler.SwaggerCompleteUploadResponse
+// @Failure 400 {object} handler.SwaggerErrorResponse
+// @Failure 401 {object} handler.SwaggerErrorResponse
+// @Failure 403 {object} handler.SwaggerErrorResponse
+// @Failure 404 {object} handler.SwaggerErrorResponse
+// @Router /files/upload/{sessionId}/complete [post]
+func (h *UploadHandler) FinalizeUpload(c echo.Context) error {
+	claims := middleware.GetAccessClaims(c)
+	if claims == nil {
+		return apperror.NewUnauthorizedError("invalid token")
+	}
+
+	sessionID, err := uuid.Parse(c.Param("sessionId"))
+	if err != nil {
+		return apperror.NewValidationError("invalid session ID", nil)
+	}
+
+	output, err := h.finalizeUploadCommand.Execute(c.Request().Context(), storagecmd.FinalizeUploadInput{
+		SessionID: sessionID,
+		UserID:    claims.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if output.Completed || output.Verifying {
+		auditUploadComplete(c, output.FileID, output.SessionID, output.Verifying)
+	}
+
 	return presenter.OK(c, response.CompleteUploadResponse{
 		FileID:    output.FileID.String(),
 		SessionID: output.SessionID.String(),
 		Completed: output.Completed,
+		Verifying: output.Verifying,
+	})
+}
+
+// UploadPart はリクエストボディをサーバー経由でストレージにアップロードします
+// @Summary パートのアップロード（サーバー経由）
+// @Description Presigned URLを利用できないクライアント向けに、リクエストボディをストレージに書き込みます。Content-Lengthはパーツのサイズ（シングルパートはファイルサイズ、マルチパートは最後以外5MB）と一致する必要があります。全パーツが揃うとアップロードを完了します
+// @Tags Files
+// @Accept application/octet-stream
+// @Produce json
+// @Security SessionCookie
+// @Param sessionId path string true "セッションID"
+// @Param partNumber path int true "パーツ番号（1から開始）"
+// @Success 200 {object} handler.SwaggerUploadPartResponse
+// @Failure 400 {object} handler.SwaggerErrorResponse
+// @Failure 401 {object} handler.SwaggerErrorResponse
+// @Failure 403 {object} handler.SwaggerErrorResponse
+// @Failure 404 {object} handler.SwaggerErrorResponse
+// @Failure 409 {object} handler.SwaggerErrorResponse
+// @Router /files/upload/{sessionId}/parts/{partNumber} [put]
+func (h *UploadHandler) UploadPart(c echo.Context) error {
+	claims := middleware.GetAccessClaims(c)
+	if claims == nil {
+		return apperror.NewUnauthorizedError("invalid token")
+	}
+
+	sessionID, err := uuid.Parse(c.Param("sessionId"))
+	if err != nil {
+		return apperror.NewValidationError("invalid session ID", nil)
+	}
+	partNumber, err := strconv.Atoi(c.Param("partNumber"))
+	if err != nil {
+		return apperror.NewValidationError("invalid part number", nil)
+	}
+	// サイズを検証してから読み込むため、チャンク転送は受け付けない
+	if c.Request().ContentLength < 0 {
+		return apperror.NewValidationError("Content-Length header is required", nil)
+	}
+
+	output, err := h.uploadPartCommand.Execute(c.Request().Context(), storagecmd.UploadPartInput{
+		SessionID:  sessionID,
+		PartNumber: partNumber,
+		Body:       c.Request().Body,
+		Size:       c.Request().ContentLength,
+		UserID:     claims.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if output.Completed || output.Verifying {
+		auditUploadComplete(c, output.FileID, output.SessionID, output.Verifying)
+	}
+
+	return presenter.OK(c, response.UploadPartResponse{
+		SessionID:     output.SessionID.String(),
+		FileID:        output.FileID.String(),
+		PartNumber:    output.PartNumber,
+		UploadedParts: output.UploadedParts,
+		TotalParts:    output.TotalParts,
+		Completed:     output.Completed,
+		Verifying:     output.Verifying,
 	})
 }
 
@@ -186,8 +419,28 @@ func (h *UploadHandler) AbortUpload(c echo.Context) error {
 		return err
 	}
 
+	middleware.AuditHelper(c, string(entity.AuditActionFileUploadAbort), string(entity.AuditResourceFile), &output.FileID, map[string]interface{}{
+		"session_id": output.SessionID,
+	})
+
 	return presenter.OK(c, response.AbortUploadResponse{
 		SessionID: output.SessionID.String(),
 		Aborted:   output.Aborted,
 	})
 }
+
+// parseExpectedChecksum はリクエストの期待チェックサムを値オブジェクトに変換します
+// 両方とも未指定の場合はnilを返します
+func parseExpectedChecksum(algorithm, value string) (*valueobject.ContentChecksum, error) {
+	if algorithm == "" && value == "" {
+		return nil, nil
+	}
+	if algorithm == "" || value == "" {
+		return nil, apperror.NewValidationError("checksumAlgorithm and checksum must be specified together", nil)
+	}
+	checksum, err := valueobject.NewContentChecksum(algorithm, value)
+	if err != nil {
+		return nil, apperror.NewValidationError(err.Error(), nil)
+	}
+	return &checksum, nil
+}
@@ -0,0 +1,268 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/dto/request"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/dto/response"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/middleware"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/interface/presenter"
+	storagecmd "github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/command"
+	storageqry "github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/query"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// VersionRetentionHandler はバージョン保持ポリシーとピン留め関連のHTTPハンドラーです
+type VersionRetentionHandler struct {
+	setPolicyCommand      *storagecmd.SetVersionRetentionPolicyCommand
+	pinFileVersionCommand *storagecmd.PinFileVersionCommand
+	getPolicyQuery        *storageqry.GetVersionRetentionPolicyQuery
+}
+
+// NewVersionRetentionHandler は新しいVersionRetentionHandlerを作成します
+func NewVersionRetentionHandler(
+	setPolicyCommand *storagecmd.SetVersionRetentionPolicyCommand,
+	pinFileVersionCommand *storagecmd.PinFileVersionCommand,
+	getPolicyQuery *storageqry.GetVersionRetentionPolicyQuery,
+) *VersionRetentionHandler {
+	return &VersionRetentionHandler{
+		setPolicyCommand:      setPolicyCommand,
+		pinFileVersionCommand: pinFileVersionCommand,
+		getPolicyQuery:        getPolicyQuery,
+	}
+}
+
+// GetMyPolicy は認証ユーザーのバージョン保持ポリシーを取得します
+// @Summary ユーザー保持ポリシー取得
+// @Description 認証ユーザーが所有するファイルに適用されるバージョン保持ポリシーを取得します。フォルダのポリシーが設定されている場合はそちらが優先されます
+// @Tags Storage
+// @Produce json
+// @Security SessionCookie
+// @Success 200 {object} handler.SwaggerVersionRetentionPolicyResponse
+// @Failure 401 {object} handler.SwaggerErrorResponse
+// @Router /me/version-retention [get]
+func (h *VersionRetentionHandler) GetMyPolicy(c echo.Context) error {
+	claims := middleware.GetAccessClaims(c)
+	if claims == nil {
+		return apperror.NewUnauthorizedError("invalid token")
+	}
+
+	return h.getPolicy(c, entity.RetentionSubjectUser, claims.UserID, claims.UserID)
+}
+
+// SetMyPolicy は認証ユーザーのバージョン保持ポリシーを設定します
+// @Summary ユーザー保持ポリシー設定
+// @Description 最新から保持するバージョン数（keepLastN）と保持日数（keepDays）を設定します。両方nullで解除します。現在のバージョンとピン留めされたバージョンは削除されません
+// @Tags Storage
+// @Accept json
+// @Produce json
+// @Security SessionCookie
+// @Param body body request.SetVersionRetentionPolicyRequest true "保持ポリシー"
+// @Success 200 {object} handler.SwaggerVersionRetentionPolicyResponse
+// @Failure 400 {object} handler.SwaggerErrorResponse
+// @Failure 401 {object} handler.SwaggerErrorResponse
+// @Router /me/version-retention [put]
+func (h *VersionRetentionHandler) SetMyPolicy(c echo.Context) error {
+	claims := middleware.GetAccessClaims(c)
+	if claims == nil {
+		return apperror.NewUnauthorizedError("invalid token")
+	}
+
+	return h.setPolicy(c, entity.RetentionSubjectUser, claims.UserID, claims.UserID)
+}
+
+// GetFolderPolicy はフォルダのバージョン保持ポリシーを取得します
+// @Summary フォルダ保持ポリシー取得
+// @Description フォルダに直接設定されたバージョン保持ポリシーを取得します（フォルダ所有者のみ）
+// @Tags Folders
+// @Produce json
+// @Security SessionCookie
+// @Param id path string true "フォルダID" format(uuid)
+// @Success 200 {object} handler.SwaggerVersionRetentionPolicyResponse
+// @Failure 400 {object} handler.SwaggerErrorResponse
+// @Failure 401 {object} handler.SwaggerErrorResponse
+// @Failure 403 {object} handler.SwaggerErrorResponse
+// @Failure 404 {object} handler.SwaggerErrorResponse
+// @Router /folders/{id}/version-retention [get]
+func (h *VersionRetentionHandler) GetFolderPolicy(c echo.Context) error {
+	claims := middleware.GetAccessClaims(c)
+	if claims == nil {
+		return apperror.NewUnauthorizedError("invalid token")
+	}
+
+	folderID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.NewValidationError("invalid folder ID", nil)
+	}
+
+	return h.getPolicy(c, entity.RetentionSubjectFolder, folderID, claims.UserID)
+}
+
+// SetFolderPolicy はフォルダのバージョン保持ポリシーを設定します
+// @Summary フォルダ保持ポリシー設定
+// @Description フォルダ配下（サブフォルダを含む）のファイルに適用される保持ポリシーを設定します。最も近いフォルダのポリシーが優先されます。両方nullで解除します（フォルダ所有者のみ）
+// @Tags Folders
+// @Accept json
+// @Produce json
+// @Security SessionCookie
+// @Param id path string true "フォルダID" format(uuid)
+// @Param body body request.SetVersionRetentionPolicyRequest true "保持ポリシー"
+// @Success 200 {object} handler.SwaggerVersionRetentionPolicyResponse
+// @Failure 400 {object} handler.SwaggerErrorResponse
+// @Failure 401 {object} handler.SwaggerErrorResponse
+// @Failure 403 {object} handler.SwaggerErrorResponse
+// @Failure 404 {object} handler.SwaggerErrorResponse
+// @Router /folders/{id}/version-retention [put]
+func (h *VersionRetentionHandler) SetFolderPolicy(c echo.Context) error {
+	claims := middleware.GetAccessClaims(c)
+	if claims == nil {
+		return apperror.NewUnauthorizedError("invalid token")
+	}
+
+	folderID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.NewValidationError("invalid folder ID", nil)
+	}
+
+	return h.setPolicy(c, entity.RetentionSubjectFolder, folderID, claims.UserID)
+}
+
+// PinFileVersion はファイルバージョンをピン留めします
+// @Summary ファイルバージョンのピン留め
+// @Description ピン留めされたバージョンは保持ポリシーによる自動削除の対象外になります
+// @Tags Files
+// @Produce json
+// @Security SessionCookie
+// @Param id path string true "ファイルID"
+// @Param version path int true "バージョン番号"
+// @Success 200 {object} handler.SwaggerPinFileVersionResponse
+// @Failure 400 {object} handler.SwaggerErrorResponse
+// @Failure 401 {object} handler.SwaggerErrorResponse
+// @Failure 403 {object} handler.SwaggerErrorResponse
+// @Failure 404 {object} handler.SwaggerErrorResponse
+// @Router /files/{id}/versions/{version}/pin [put]
+func (h *VersionRetentionHandler) PinFileVersion(c echo.Context) error {
+	return h.setPinned(c, true)
+}
+
+// UnpinFileVersion はファイルバージョンのピン留めを解除します
+// @Summary ファイルバージョンのピン留め解除
+// @Description ピン留めを解除し、保持ポリシーによる自動削除の対象に戻します
+// @Tags Files
+// @Produce json
+// @Security SessionCookie
+// @Param id path string true "ファイルID"
+// @Param version path int true "バージョン番号"
+// @Success 200 {object} handler.SwaggerPinFileVersionResponse
+// @Failure 400 {object} handler.SwaggerErrorResponse
+// @Failure 401 {object} handler.SwaggerErrorResponse
+// @Failure 403 {object} handler.SwaggerErrorResponse
+// @Failure 404 {object} handler.SwaggerErrorResponse
+// @Router /files/{id}/versions/{version}/pin [delete]
+func (h *VersionRetentionHandler) UnpinFileVersion(c echo.Context) error {
+	return h.setPinned(c, false)
+}
+
+// getPolicy は対象の保持ポリシーを取得してレスポンスを返します
+func (h *VersionRetentionHandler) getPolicy(c echo.Context, subjectType entity.RetentionSubjectType, subjectID, userID uuid.UUID) error {
+	output, err := h.getPolicyQuery.Execute(c.Request().Context(), storageqry.GetVersionRetentionPolicyInput{
+		SubjectType: subjectType,
+		SubjectID:   subjectID,
+		UserID:      userID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return presenter.OK(c, response.ToVersionRetentionPolicyResponse(output))
+}
+
+// setPolicy はリクエストボディから保持ポリシーを設定してレスポンスを返します
+func (h *VersionRetentionHandler) setPolicy(c echo.Context, subjectType entity.RetentionSubjectType, subjectID, userID uuid.UUID) error {
+	var req request.SetVersionRetentionPolicyRequest
+	if err := c.Bind(&req); err != nil {
+		return apperror.NewValidationError("invalid request body", nil)
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	output, err := h.setPolicyCommand.Execute(c.Request().Context(), storagecmd.SetVersionRetentionPolicyInput{
+		SubjectType: subjectType,
+		SubjectID:   subjectID,
+		KeepLastN:   req.KeepLastN,
+		KeepDays:    req.KeepDays,
+		UserID:      userID,
+	})
+	if err != nil {
+		return err
+	}
+
+	resourceType := entity.AuditResourceUser
+	if subjectType == entity.RetentionSubjectFolder {
+		resourceType = entity.AuditResourceFolder
+	}
+	after := map[string]interface{}{"keep_last_n": nil, "keep_days": nil}
+	if output.Policy != nil {
+		after = map[string]interface{}{"keep_last_n": output.Policy.KeepLastN, "keep_days": output.Policy.KeepDays}
+	}
+	middleware.AuditHelper(c, string(entity.AuditActionVersionRetentionUpdate), string(resourceType), &subjectID, map[string]interface{}{
+		"before": map[string]interface{}{"keep_last_n": output.PreviousKeepLastN, "keep_days": output.PreviousKeepDays},
+		"after":  after,
+	})
+
+	return presenter.OK(c, response.ToVersionRetentionPolicyResponse(&storageqry.GetVersionRetentionPolicyOutput{
+		SubjectType: subjectType,
+		SubjectID:   subjectID,
+		Policy:      output.Policy,
+	}))
+}
+
+// setPinned はパスで指定されたバージョンのピン留め状態を変更します
+func (h *VersionRetentionHandler) setPinned(c echo.Context, pinned bool) error {
+	claims := middleware.GetAccessClaims(c)
+	if claims == nil {
+		return apperror.NewUnauthorizedError("invalid token")
+	}
+
+	fileID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return apperror.NewValidationError("invalid file ID", nil)
+	}
+
+	versionNumber, err := strconv.Atoi(c.Param("version"))
+	if err != nil || versionNumber < 1 {
+		return apperror.NewValidationError("version must be a positive integer", nil)
+	}
+
+	output, err := h.pinFileVersionCommand.Execute(c.Request().Context(), storagecmd.PinFileVersionInput{
+		FileID:        fileID,
+		VersionNumber: versionNumber,
+		Pinned:        pinned,
+		UserID:        claims.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	// ピン留め状態が変わらなかった場合は記録しない
+	if output.WasPinned != output.IsPinned {
+		action := entity.AuditActionFileVersionUnpin
+		if output.IsPinned {
+			action = entity.AuditActionFileVersionPin
+		}
+		middleware.AuditHelper(c, string(action), string(entity.AuditResourceFile), &fileID, map[string]interface{}{
+			"version_number": output.VersionNumber,
+		})
+	}
+
+	return presenter.OK(c, response.PinFileVersionResponse{
+		FileID:        output.FileID.String(),
+		VersionNumber: output.VersionNumber,
+		IsPinned:      output.IsPinned,
+	})
+}
@@ -39,16 +39,28 @@ func GetAuditService(c echo.Context) service.AuditService {
 }
 
 // AuditHelper は監査ログ記録のヘルパー関数です
+// ユーザーID・IPアドレス・User-Agent・リクエストIDはEchoコンテキストから取得します
+// Note: 記録は非同期で行われ、書き込みに失敗してもリクエストは失敗しない
 func AuditHelper(c echo.Context, action string, resourceType string, resourceID *uuid.UUID, details map[string]interface{}) {
-	svc := GetAuditService(c)
-	if svc == nil {
-		return
-	}
-
 	var userID *uuid.UUID
 	if uid, err := GetUserUUID(c); err == nil && uid != uuid.Nil {
 		userID = &uid
 	}
+	logAudit(c, userID, action, resourceType, resourceID, details)
+}
+
+// AuditHelperWithUser はユーザーIDを明示して監査ログを記録します
+// ログイン・登録など、認証前でコンテキストにユーザーIDが存在しないリクエストで使用します
+func AuditHelperWithUser(c echo.Context, userID uuid.UUID, action string, resourceType string, resourceID *uuid.UUID, details map[string]interface{}) {
+	logAudit(c, &userID, action, resourceType, resourceID, details)
+}
+
+// logAudit はコンテキストの情報を付与して監査ログを記録します
+func logAudit(c echo.Context, userID *uuid.UUID, action string, resourceType string, resourceID *uuid.UUID, details map[string]interface{}) {
+	svc := GetAuditService(c)
+	if svc == nil {
+		return
+	}
 
 	svc.Log(c.Request().Context(), service.AuditEntry{
 		UserID:       userID,
@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+)
+
+type recordingAuditService struct {
+	entries []service.AuditEntry
+}
+
+func (s *recordingAuditService) Log(_ context.Context, entry service.AuditEntry) {
+	s.entries = append(s.entries, entry)
+}
+
+func newAuditTestContext(svc service.AuditService) echo.Context {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/files/x/rename", nil)
+	req.Header.Set("User-Agent", "audit-test-agent")
+	req.Header.Set(echo.HeaderXRealIP, "203.0.113.7")
+	c := e.NewContext(req, httptest.NewRecorder())
+	c.Set(ContextKeyRequestID, "req-123")
+	if svc != nil {
+		c.Set(ContextKeyAuditService, svc)
+	}
+	return c
+}
+
+func TestAuditHelper_RecordsRequestContext(t *testing.T) {
+	svc := &recordingAuditService{}
+	c := newAuditTestContext(svc)
+	userID := uuid.New()
+	SetUserID(c, userID.String())
+	fileID := uuid.New()
+
+	AuditHelper(c, string(entity.AuditActionFileRename), string(entity.AuditResourceFile), &fileID, map[string]interface{}{"name": "b.txt"})
+
+	if len(svc.entries) != 1 {
+		t.Fatalf("expected 1 audit entry, got %d", len(svc.entries))
+	}
+	entry := svc.entries[0]
+	if entry.UserID == nil || *entry.UserID != userID {
+		t.Errorf("expected user ID %s, got %v", userID, entry.UserID)
+	}
+	if entry.Action != entity.AuditActionFileRename || entry.ResourceType != entity.AuditResourceFile {
+		t.Errorf("unexpected action/resource: %s/%s", entry.Action, entry.ResourceType)
+	}
+	if entry.ResourceID == nil || *entry.ResourceID != fileID {
+		t.Errorf("expected resource ID %s, got %v", fileID, entry.ResourceID)
+	}
+	if entry.IPAddress != "203.0.113.7" {
+		t.Errorf("expected IP from request, got %q", entry.IPAddress)
+	}
+	if entry.UserAgent != "audit-test-agent" {
+		t.Errorf("expected user agent from request, got %q", entry.UserAgent)
+	}
+	if entry.RequestID != "req-123" {
+		t.Errorf("expected request ID from context, got %q", entry.RequestID)
+	}
+	if entry.Details["name"] != "b.txt" {
+		t.Errorf("expected details to be passed through, got %v", entry.Details)
+	}
+}
+
+func TestAuditHelper_Unauthenticated_RecordsWithoutUser(t *testing.T) {
+	svc := &recordingAuditService{}
+	c := newAuditTestContext(svc)
+
+	AuditHelper(c, string(entity.AuditActionLoginFailed), string(entity.AuditResourceUser), nil, nil)
+
+	if len(svc.entries) != 1 {
+		t.Fatalf("expected 1 audit entry, got %d", len(svc.entries))
+	}
+	if svc.entries[0].UserID != nil {
+		t.Errorf("expected no user ID, got %v", *svc.entries[0].UserID)
+	}
+}
+
+func TestAuditHelperWithUser_UsesGivenUser(t *testing.T) {
+	svc := &recordingAuditService{}
+	c := newAuditTestContext(svc)
+	userID := uuid.New()
+
+	AuditHelperWithUser(c, userID, string(entity.AuditActionLogin), string(entity.AuditResourceUser), &userID, nil)
+
+	if len(svc.entries) != 1 {
+		t.Fatalf("expected 1 audit entry, got %d", len(svc.entries))
+	}
+	if svc.entries[0].UserID == nil || *svc.entries[0].UserID != userID {
+		t.Errorf("expected user ID %s, got %v", userID, svc.entries[0].UserID)
+	}
+}
+
+func TestAuditHelper_WithoutService_DoesNothing(t *testing.T) {
+	c := newAuditTestContext(nil)
+	fileID := uuid.New()
+
+	// サービス未設定でもパニックせずに何もしない
+	AuditHelper(c, string(entity.AuditActionFileRename), string(entity.AuditResourceFile), &fileID, nil)
+}
@@ -10,6 +10,7 @@ type CORSConfig struct {
 	AllowOrigins     []string
 	AllowMethods     []string
 	AllowHeaders     []string
+	ExposeHeaders    []string
 	AllowCredentials bool
 	MaxAge           int
 }
@@ -17,9 +18,16 @@ type CORSConfig struct {
 // DefaultCORSConfig はデフォルトCORS設定を返します
 func DefaultCORSConfig() CORSConfig {
 	return CORSConfig{
-		AllowOrigins:     []string{"http://localhost:3000"},
-		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Content-Type", "Authorization", "X-Request-ID", CSRFHeaderName},
+		AllowOrigins: []string{"http://localhost:3000"},
+		AllowMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"},
+		AllowHeaders: []string{
+			"Content-Type", "Authorization", "X-Request-ID", CSRFHeaderName,
+			// tus（レジューム可能なアップロード）
+			TusResumableHeader, "Upload-Length", "Upload-Metadata", "Upload-Offset",
+		},
+		ExposeHeaders: []string{
+			"Location", TusResumableHeader, TusVersionHeader, "Tus-Extension", "Upload-Offset", "Upload-Length",
+		},
 		AllowCredentials: true,
 		MaxAge:           86400, // 24時間
 	}
@@ -37,6 +45,7 @@ func CORSWithConfig(cfg CORSConfig) echo.MiddlewareFunc {
 		AllowOrigins:     cfg.AllowOrigins,
 		AllowMethods:     cfg.AllowMethods,
 		AllowHeaders:     cfg.AllowHeaders,
+		ExposeHeaders:    cfg.ExposeHeaders,
 		AllowCredentials: cfg.AllowCredentials,
 		MaxAge:           cfg.MaxAge,
 	})
@@ -3,6 +3,7 @@ package middleware
 import (
 	"fmt"
 	"log/slog"
+	"net/http"
 	"runtime"
 
 	"github.com/labstack/echo/v4"
@@ -16,6 +17,11 @@ func Recover() echo.MiddlewareFunc {
 		return func(c echo.Context) error {
 			defer func() {
 				if r := recover(); r != nil {
+					// レスポンスの中断はnet/httpに接続を閉じさせる
+					if r == http.ErrAbortHandler {
+						panic(r)
+					}
+
 					// スタックトレースを取得
 					buf := make([]byte, 4096)
 					n := runtime.Stack(buf, false)
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func runRecover(handler echo.HandlerFunc) (rec *httptest.ResponseRecorder, panicked interface{}) {
+	e := echo.New()
+	rec = httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+
+	defer func() {
+		panicked = recover()
+	}()
+	_ = Recover()(handler)(c)
+	return rec, nil
+}
+
+func TestRecover_Panic_ReturnsInternalServerError(t *testing.T) {
+	rec, panicked := runRecover(func(c echo.Context) error {
+		panic(errors.New("boom"))
+	})
+
+	if panicked != nil {
+		t.Fatalf("expected panic to be recovered, got %v", panicked)
+	}
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500, got %d", rec.Code)
+	}
+}
+
+func TestRecover_ErrAbortHandler_IsRepanicked(t *testing.T) {
+	_, panicked := runRecover(func(c echo.Context) error {
+		panic(http.ErrAbortHandler)
+	})
+
+	if panicked != http.ErrAbortHandler {
+		t.Errorf("expected http.ErrAbortHandler to be re-panicked, got %v", panicked)
+	}
+}
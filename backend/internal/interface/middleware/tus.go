@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// TusVersion はサポートするtusプロトコルのバージョンです
+	TusVersion = "1.0.0"
+	// TusResumableHeader はtusプロトコルのバージョンを示すヘッダー名です
+	TusResumableHeader = "Tus-Resumable"
+	// TusVersionHeader はサーバーがサポートするバージョン一覧のヘッダー名です
+	TusVersionHeader = "Tus-Version"
+)
+
+// TusResumable はtusプロトコルのバージョンを検証するミドルウェアを返します
+// 全てのレスポンスにTus-Resumableヘッダーを付与し、
+// OPTIONS以外のリクエストでバージョンが一致しない場合は412を返します
+func TusResumable() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Response().Header().Set(TusResumableHeader, TusVersion)
+
+			// OPTIONSはバージョンの問い合わせに使われるため検証しない
+			if c.Request().Method == http.MethodOptions {
+				return next(c)
+			}
+
+			if c.Request().Header.Get(TusResumableHeader) != TusVersion {
+				c.Response().Header().Set(TusVersionHeader, TusVersion)
+				return c.NoContent(http.StatusPreconditionFailed)
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func runTusResumable(method, version string) *httptest.ResponseRecorder {
+	e := echo.New()
+	req := httptest.NewRequest(method, "/tus/session", nil)
+	if version != "" {
+		req.Header.Set(TusResumableHeader, version)
+	}
+	rec := httptest.NewRecorder()
+
+	handler := TusResumable()(func(c echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+	_ = handler(e.NewContext(req, rec))
+	return rec
+}
+
+func TestTusResumable_SupportedVersion_Passes(t *testing.T) {
+	rec := runTusResumable(http.MethodPatch, TusVersion)
+
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("expected 204, got %d", rec.Code)
+	}
+	if got := rec.Header().Get(TusResumableHeader); got != TusVersion {
+		t.Errorf("expected Tus-Resumable %s, got %q", TusVersion, got)
+	}
+}
+
+func TestTusResumable_MissingVersion_ReturnsPreconditionFailed(t *testing.T) {
+	rec := runTusResumable(http.MethodHead, "")
+
+	if rec.Code != http.StatusPreconditionFailed {
+		t.Errorf("expected 412, got %d", rec.Code)
+	}
+	if got := rec.Header().Get(TusVersionHeader); got != TusVersion {
+		t.Errorf("expected Tus-Version %s, got %q", TusVersion, got)
+	}
+}
+
+func TestTusResumable_UnsupportedVersion_ReturnsPreconditionFailed(t *testing.T) {
+	rec := runTusResumable(http.MethodPost, "0.2.2")
+
+	if rec.Code != http.StatusPreconditionFailed {
+		t.Errorf("expected 412, got %d", rec.Code)
+	}
+}
+
+func TestTusResumable_OPTIONS_SkipsValidation(t *testing.T) {
+	rec := runTusResumable(http.MethodOptions, "")
+
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("expected 204, got %d", rec.Code)
+	}
+	if got := rec.Header().Get(TusResumableHeader); got != TusVersion {
+		t.Errorf("expected Tus-Resumable %s, got %q", TusVersion, got)
+	}
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"io"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+const (
+	// WebhookSignatureHeader はWebhookのHMAC署名のヘッダー名です（"sha256=<hex>"形式）
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	// webhookSignaturePrefix はHMAC署名の接頭辞です
+	webhookSignaturePrefix = "sha256="
+	// maxWebhookBodyBytes は署名検証のために読み込むリクエストボディの上限です
+	maxWebhookBodyBytes = 1 << 20
+)
+
+// WebhookAuthMiddleware はストレージからのWebhookを共有シークレットで認証するミドルウェアを提供します
+type WebhookAuthMiddleware struct {
+	token      string
+	hmacSecret string
+}
+
+// NewWebhookAuthMiddleware は新しいWebhookAuthMiddlewareを作成します
+// tokenはAuthorizationヘッダー（Bearer）、hmacSecretはリクエストボディのHMAC-SHA256署名の検証に使用します
+func NewWebhookAuthMiddleware(token, hmacSecret string) *WebhookAuthMiddleware {
+	return &WebhookAuthMiddleware{
+		token:      token,
+		hmacSecret: hmacSecret,
+	}
+}
+
+// Configured は認証方式が1つ以上設定されているかを返します
+func (m *WebhookAuthMiddleware) Configured() bool {
+	return m.token != "" || m.hmacSecret != ""
+}
+
+// Authenticate は認証ミドルウェアを返します
+// 設定されたいずれかの方式で認証できたリクエストのみ通します（未設定の場合は全て拒否）
+func (m *WebhookAuthMiddleware) Authenticate() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if m.token != "" && m.validToken(c.Request().Header.Get(echo.HeaderAuthorization)) {
+				return next(c)
+			}
+
+			if m.hmacSecret != "" {
+				signature := c.Request().Header.Get(WebhookSignatureHeader)
+				if signature != "" {
+					body, err := io.ReadAll(io.LimitReader(c.Request().Body, maxWebhookBodyBytes))
+					if err != nil {
+						return apperror.NewValidationError("failed to read request body", nil)
+					}
+					// ハンドラーでバインドできるようにボディを戻す
+					c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+					if m.validSignature(signature, body) {
+						return next(c)
+					}
+				}
+			}
+
+			return apperror.NewUnauthorizedError("invalid webhook credentials")
+		}
+	}
+}
+
+// validToken はAuthorizationヘッダーが共有トークンと一致するかを検証します
+// MinIOのauth_tokenはスキームなしで送られる場合もあるため、Bearerは省略可能とします
+func (m *WebhookAuthMiddleware) validToken(header string) bool {
+	token := strings.TrimSpace(strings.TrimPrefix(header, "Bearer "))
+	return token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(m.token)) == 1
+}
+
+// validSignature はリクエストボディのHMAC-SHA256署名を検証します
+func (m *WebhookAuthMiddleware) validSignature(signature string, body []byte) bool {
+	expected, err := hex.DecodeString(strings.TrimPrefix(signature, webhookSignaturePrefix))
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(m.hmacSecret))
+	mac.Write(body)
+	return hmac.Equal(expected, mac.Sum(nil))
+}
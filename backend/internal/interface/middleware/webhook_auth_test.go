@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+const testWebhookBody = `{"EventName":"s3:ObjectCreated:Put"}`
+
+func runWebhookAuth(m *WebhookAuthMiddleware, setup func(*http.Request)) (*httptest.ResponseRecorder, string, error) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(testWebhookBody))
+	setup(req)
+	rec := httptest.NewRecorder()
+
+	var received string
+	handler := m.Authenticate()(func(c echo.Context) error {
+		b, _ := io.ReadAll(c.Request().Body)
+		received = string(b)
+		return c.NoContent(http.StatusOK)
+	})
+	err := handler(e.NewContext(req, rec))
+	return rec, received, err
+}
+
+func signWebhookBody(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestWebhookAuth_BearerToken_Passes(t *testing.T) {
+	m := NewWebhookAuthMiddleware("secret-token", "")
+
+	_, _, err := runWebhookAuth(m, func(r *http.Request) {
+		r.Header.Set("Authorization", "Bearer secret-token")
+	})
+	if err != nil {
+		t.Errorf("expected valid bearer token to pass, got error: %v", err)
+	}
+}
+
+func TestWebhookAuth_TokenWithoutScheme_Passes(t *testing.T) {
+	m := NewWebhookAuthMiddleware("secret-token", "")
+
+	_, _, err := runWebhookAuth(m, func(r *http.Request) {
+		r.Header.Set("Authorization", "secret-token")
+	})
+	if err != nil {
+		t.Errorf("expected token without scheme to pass, got error: %v", err)
+	}
+}
+
+func TestWebhookAuth_WrongToken_Rejected(t *testing.T) {
+	m := NewWebhookAuthMiddleware("secret-token", "")
+
+	_, _, err := runWebhookAuth(m, func(r *http.Request) {
+		r.Header.Set("Authorization", "Bearer other-token")
+	})
+	if err == nil {
+		t.Error("expected wrong token to be rejected")
+	}
+}
+
+func TestWebhookAuth_ValidSignature_PassesAndRestoresBody(t *testing.T) {
+	m := NewWebhookAuthMiddleware("", "hmac-secret")
+
+	_, received, err := runWebhookAuth(m, func(r *http.Request) {
+		r.Header.Set(WebhookSignatureHeader, signWebhookBody("hmac-secret", testWebhookBody))
+	})
+	if err != nil {
+		t.Errorf("expected valid signature to pass, got error: %v", err)
+	}
+	if received != testWebhookBody {
+		t.Errorf("expected handler to receive body %q, got %q", testWebhookBody, received)
+	}
+}
+
+func TestWebhookAuth_InvalidSignature_Rejected(t *testing.T) {
+	m := NewWebhookAuthMiddleware("", "hmac-secret")
+
+	_, _, err := runWebhookAuth(m, func(r *http.Request) {
+		r.Header.Set(WebhookSignatureHeader, signWebhookBody("other-secret", testWebhookBody))
+	})
+	if err == nil {
+		t.Error("expected invalid signature to be rejected")
+	}
+}
+
+func TestWebhookAuth_NotConfigured_RejectsAll(t *testing.T) {
+	m := NewWebhookAuthMiddleware("", "")
+
+	_, _, err := runWebhookAuth(m, func(r *http.Request) {
+		r.Header.Set("Authorization", "Bearer ")
+	})
+	if err == nil {
+		t.Error("expected request to be rejected when no credentials are configured")
+	}
+	if m.Configured() {
+		t.Error("expected Configured to be false")
+	}
+}
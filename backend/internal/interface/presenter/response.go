@@ -1,6 +1,9 @@
 package presenter
 
 import (
+	"io"
+	"log/slog"
+	"mime"
 	"net/http"
 	"strconv"
 
@@ -82,6 +85,26 @@ func List(c echo.Context, data interface{}, pagination *Pagination) error {
 	})
 }
 
+// Attachment はwriteが書き込む内容を添付ファイルとしてストリーミングします
+// 送信開始後はステータスを変更できないため、途中のエラーはログに記録して接続を中断し、
+// 途中までの内容が完全なファイルとして扱われないようにします
+func Attachment(c echo.Context, contentType, filename string, write func(w io.Writer) error) error {
+	c.Response().Header().Set(echo.HeaderContentType, contentType)
+	c.Response().Header().Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	c.Response().WriteHeader(http.StatusOK)
+
+	if err := write(c.Response()); err != nil {
+		slog.Error("failed to stream attachment",
+			"filename", filename,
+			"path", c.Request().URL.Path,
+			"error", err,
+		)
+		// net/httpはErrAbortHandlerのパニックでレスポンスを終端せずに接続を閉じる
+		panic(http.ErrAbortHandler)
+	}
+	return nil
+}
+
 // NewPagination はページネーション情報を作成します
 func NewPagination(page, perPage, totalItems int) *Pagination {
 	totalPages := (totalItems + perPage - 1) / perPage
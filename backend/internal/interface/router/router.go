@@ -56,6 +56,7 @@ func (r *Router) setupAPIRoutes() {
 	r.setupGroupRoutes(api)
 	r.setupPermissionRoutes(api)
 	r.setupShareLinkRoutes(api)
+	r.setupAuditLogRoutes(api)
 }
 
 // setupAuthRoutes は認証関連ルートを設定します
@@ -120,6 +121,7 @@ func (r *Router) setupStorageRoutes(api *echo.Group) {
 		foldersGroup.GET("/:id", r.handlers.Folder.GetFolder)
 		foldersGroup.GET("/:id/contents", r.handlers.Folder.ListFolderContents)
 		foldersGroup.GET("/:id/ancestors", r.handlers.Folder.GetAncestors)
+		foldersGroup.GET("/:id/download", r.handlers.Folder.DownloadFolder)
 		foldersGroup.PATCH("/:id/rename", r.handlers.Folder.RenameFolder)
 		foldersGroup.PATCH("/:id/move", r.handlers.Folder.MoveFolder)
 		foldersGroup.DELETE("/:id", r.handlers.Folder.DeleteFolder)
@@ -129,7 +131,10 @@ func (r *Router) setupStorageRoutes(api *echo.Group) {
 	if r.handlers.File != nil {
 		filesGroup := api.Group("/files", r.middlewares.SessionAuth.Authenticate())
 		filesGroup.GET("/:id/download", r.handlers.File.GetDownloadURL)
+		filesGroup.GET("/:id/thumbnail", r.handlers.File.GetThumbnail)
+		filesGroup.GET("/:id/preview", r.handlers.File.GetPreview)
 		filesGroup.GET("/:id/versions", r.handlers.File.ListFileVersions)
+		filesGroup.POST("/:id/versions/:version/restore", r.handlers.File.RestoreFileVersion)
 		filesGroup.PATCH("/:id/rename", r.handlers.File.RenameFile)
 		filesGroup.PATCH("/:id/move", r.handlers.File.MoveFile)
 	}
@@ -138,11 +143,27 @@ func (r *Router) setupStorageRoutes(api *echo.Group) {
 	if r.handlers.Upload != nil {
 		filesGroup := api.Group("/files", r.middlewares.SessionAuth.Authenticate())
 		filesGroup.POST("/upload", r.handlers.Upload.InitiateUpload)
+		filesGroup.POST("/:id/versions/upload", r.handlers.Upload.InitiateVersionUpload)
 		filesGroup.GET("/upload/:sessionId", r.handlers.Upload.GetUploadStatus)
+		filesGroup.POST("/upload/:sessionId/complete", r.handlers.Upload.FinalizeUpload)
+		filesGroup.PUT("/upload/:sessionId/parts/:partNumber", r.handlers.Upload.UploadPart)
 		filesGroup.DELETE("/upload/:sessionId", r.handlers.Upload.AbortUpload)
 
-		// Upload completion webhook (unauthenticated for MinIO webhook)
-		api.POST("/files/upload/complete", r.handlers.Upload.CompleteUpload)
+		// Upload completion webhook (MinIO bucket notification, authenticated by shared secret)
+		api.POST("/files/upload/complete", r.handlers.Upload.CompleteUpload, r.middlewares.WebhookAuth.Authenticate())
+	}
+
+	// tus resumable upload routes (OPTIONS is unauthenticated for capability discovery)
+	if r.handlers.Tus != nil {
+		tusGroup := api.Group("/tus", middleware.TusResumable())
+		tusGroup.OPTIONS("", r.handlers.Tus.Options)
+		tusGroup.OPTIONS("/", r.handlers.Tus.Options)
+
+		auth := r.middlewares.SessionAuth.Authenticate()
+		tusGroup.POST("", r.handlers.Tus.Create, auth)
+		tusGroup.POST("/", r.handlers.Tus.Create, auth)
+		tusGroup.HEAD("/:sessionId", r.handlers.Tus.Head, auth)
+		tusGroup.PATCH("/:sessionId", r.handlers.Tus.Patch, auth)
 	}
 
 	// Trash routes (authenticated)
@@ -156,6 +177,55 @@ func (r *Router) setupStorageRoutes(api *echo.Group) {
 		trashFilesGroup := trashGroup.Group("/files")
 		trashFilesGroup.DELETE("/:id", r.handlers.Trash.PermanentlyDeleteFile)
 		trashFilesGroup.POST("/:id/restore", r.handlers.Trash.RestoreFile)
+		trashFoldersGroup := trashGroup.Group("/folders")
+		trashFoldersGroup.POST("/:id/restore", r.handlers.Trash.RestoreFolder)
+	}
+
+	// Bulk operation routes (authenticated)
+	if r.handlers.Bulk != nil {
+		bulkGroup := api.Group("/bulk", r.middlewares.SessionAuth.Authenticate())
+		bulkGroup.POST("/move", r.handlers.Bulk.BulkMove)
+		bulkGroup.POST("/trash", r.handlers.Bulk.BulkTrash)
+		bulkGroup.POST("/copy", r.handlers.Bulk.BulkCopy)
+		bulkGroup.POST("/delete", r.handlers.Bulk.BulkDelete)
+	}
+
+	// Copy routes (authenticated)
+	if r.handlers.Copy != nil {
+		api.POST("/files/:id/copy", r.handlers.Copy.CopyFile, r.middlewares.SessionAuth.Authenticate())
+		api.POST("/folders/:id/copy", r.handlers.Copy.CopyFolder, r.middlewares.SessionAuth.Authenticate())
+
+		copyJobsGroup := api.Group("/copy-jobs", r.middlewares.SessionAuth.Authenticate())
+		copyJobsGroup.GET("/:id", r.handlers.Copy.GetCopyJob)
+	}
+
+	// Search routes (authenticated)
+	if r.handlers.Search != nil {
+		api.GET("/search", r.handlers.Search.Search,
+			r.middlewares.SessionAuth.Authenticate(),
+			r.middlewares.RateLimit.ByUser(middleware.RateLimitAPISearch))
+	}
+
+	// Shared with me routes (authenticated)
+	if r.handlers.SharedWithMe != nil {
+		api.GET("/shared-with-me", r.handlers.SharedWithMe.ListSharedWithMe, r.middlewares.SessionAuth.Authenticate())
+	}
+
+	// Storage usage routes (authenticated)
+	if r.handlers.Storage != nil {
+		api.GET("/me/storage", r.handlers.Storage.GetMyStorageUsage, r.middlewares.SessionAuth.Authenticate())
+		api.GET("/me/duplicates", r.handlers.Storage.ListMyDuplicateFiles, r.middlewares.SessionAuth.Authenticate())
+	}
+
+	// Version retention routes (authenticated)
+	if r.handlers.VersionRetention != nil {
+		auth := r.middlewares.SessionAuth.Authenticate()
+		api.GET("/me/version-retention", r.handlers.VersionRetention.GetMyPolicy, auth)
+		api.PUT("/me/version-retention", r.handlers.VersionRetention.SetMyPolicy, auth)
+		api.GET("/folders/:id/version-retention", r.handlers.VersionRetention.GetFolderPolicy, auth)
+		api.PUT("/folders/:id/version-retention", r.handlers.VersionRetention.SetFolderPolicy, auth)
+		api.PUT("/files/:id/versions/:version/pin", r.handlers.VersionRetention.PinFileVersion, auth)
+		api.DELETE("/files/:id/versions/:version/pin", r.handlers.VersionRetention.UnpinFileVersion, auth)
 	}
 }
 
@@ -187,6 +257,10 @@ func (r *Router) setupGroupRoutes(api *echo.Group) {
 	groupsGroup.POST("/:id/leave", r.handlers.Group.LeaveGroup)
 	groupsGroup.POST("/:id/transfer", r.handlers.Group.TransferOwnership)
 
+	// Group quota routes
+	groupsGroup.GET("/:id/quota", r.handlers.Group.GetGroupQuota)
+	groupsGroup.PUT("/:id/quota", r.handlers.Group.SetGroupQuota)
+
 	// Invitation routes (authenticated)
 	invitationsGroup := api.Group("/invitations", r.middlewares.SessionAuth.Authenticate())
 	invitationsGroup.GET("/pending", r.handlers.Group.ListPendingInvitations)
@@ -241,4 +315,18 @@ func (r *Router) setupShareLinkRoutes(api *echo.Group) {
 	shareGroup.GET("/:token", r.handlers.ShareLink.GetShareLinkInfo)
 	shareGroup.POST("/:token/access", r.handlers.ShareLink.AccessShareLink)
 	shareGroup.GET("/:token/download", r.handlers.ShareLink.GetDownloadViaShare)
+	shareGroup.GET("/:token/download/folder", r.handlers.ShareLink.DownloadFolderViaShare)
+	shareGroup.GET("/:token/preview", r.handlers.ShareLink.GetPreviewViaShare)
+}
+
+// setupAuditLogRoutes は監査ログ関連ルートを設定します
+func (r *Router) setupAuditLogRoutes(api *echo.Group) {
+	if r.handlers.AuditLog == nil {
+		return
+	}
+
+	// Audit log routes (authenticated)
+	auditLogsGroup := api.Group("/audit-logs", r.middlewares.SessionAuth.Authenticate())
+	auditLogsGroup.GET("", r.handlers.AuditLog.ListAuditLogs)
+	auditLogsGroup.GET("/export", r.handlers.AuditLog.ExportAuditLogs)
 }
@@ -2,8 +2,8 @@ package job
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
-	"time"
 
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
 )
@@ -11,35 +11,23 @@ import (
 // AccessLogAnonymizeJob is a background job that anonymizes old share link access logs.
 type AccessLogAnonymizeJob struct {
 	shareLinkAccessRepo repository.ShareLinkAccessRepository
-	interval            time.Duration
 }
 
 // NewAccessLogAnonymizeJob creates a new AccessLogAnonymizeJob.
 func NewAccessLogAnonymizeJob(shareLinkAccessRepo repository.ShareLinkAccessRepository) *AccessLogAnonymizeJob {
 	return &AccessLogAnonymizeJob{
 		shareLinkAccessRepo: shareLinkAccessRepo,
-		interval:            24 * time.Hour,
 	}
 }
 
-// Start runs the anonymize job on a ticker loop until context is cancelled.
-func (j *AccessLogAnonymizeJob) Start(ctx context.Context) {
-	ticker := time.NewTicker(j.interval)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		case <-ticker.C:
-			count, err := j.shareLinkAccessRepo.AnonymizeOldAccesses(ctx)
-			if err != nil {
-				slog.Error("access log anonymize job failed", "error", err)
-				continue
-			}
-			if count > 0 {
-				slog.Info("anonymized old access logs", "count", count)
-			}
-		}
+// Run performs a single anonymize pass.
+func (j *AccessLogAnonymizeJob) Run(ctx context.Context) error {
+	count, err := j.shareLinkAccessRepo.AnonymizeOldAccesses(ctx)
+	if err != nil {
+		return fmt.Errorf("access log anonymize job: %w", err)
+	}
+	if count > 0 {
+		slog.Info("anonymized old access logs", "count", count)
 	}
+	return nil
 }
@@ -0,0 +1,240 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+)
+
+// auditChainVerifyPageSize is the number of chained entries fetched per page.
+const auditChainVerifyPageSize = 1000
+
+// AuditChainBreakType classifies the first broken link found in the audit log chain.
+type AuditChainBreakType string
+
+const (
+	// AuditChainBreakSequenceGap means an entry is missing (deleted) from the chain.
+	AuditChainBreakSequenceGap AuditChainBreakType = "sequence_gap"
+	// AuditChainBreakPrevHashMismatch means an entry does not link to the hash of its predecessor.
+	AuditChainBreakPrevHashMismatch AuditChainBreakType = "prev_hash_mismatch"
+	// AuditChainBreakHashMismatch means an entry's content was modified after it was written.
+	AuditChainBreakHashMismatch AuditChainBreakType = "hash_mismatch"
+	// AuditChainBreakCheckpointSignature means a checkpoint's signature does not verify.
+	AuditChainBreakCheckpointSignature AuditChainBreakType = "checkpoint_signature_invalid"
+	// AuditChainBreakCheckpointMismatch means the chain no longer matches a signed checkpoint.
+	AuditChainBreakCheckpointMismatch AuditChainBreakType = "checkpoint_mismatch"
+	// AuditChainBreakCheckpointBeyondHead means entries recorded by a checkpoint were truncated.
+	AuditChainBreakCheckpointBeyondHead AuditChainBreakType = "checkpoint_beyond_head"
+)
+
+// AuditChainBreak is the first broken link found by the verifier.
+type AuditChainBreak struct {
+	Type     AuditChainBreakType
+	Sequence int64
+	// EntryID is zero for breaks found on a checkpoint rather than an entry.
+	EntryID uuid.UUID
+	// CheckpointDay is zero for breaks found on an entry.
+	CheckpointDay time.Time
+	Expected      string
+	Actual        string
+}
+
+// AuditChainReport is the result of a single verification.
+type AuditChainReport struct {
+	EntriesVerified     int
+	CheckpointsVerified int
+	// SignaturesChecked is false when no signer was configured, in which case
+	// checkpoints are only compared against the chain.
+	SignaturesChecked bool
+	FirstSequence     int64
+	LastSequence      int64
+	Break             *AuditChainBreak
+}
+
+// AuditChainVerifier walks the audit log hash chain and its checkpoints and
+// reports the first broken link.
+type AuditChainVerifier struct {
+	auditLogRepo   repository.AuditLogRepository
+	checkpointRepo repository.AuditCheckpointRepository
+	signer         service.AuditCheckpointSigner
+}
+
+// NewAuditChainVerifier creates a new AuditChainVerifier. signer may be nil to
+// skip checkpoint signature verification.
+func NewAuditChainVerifier(
+	auditLogRepo repository.AuditLogRepository,
+	checkpointRepo repository.AuditCheckpointRepository,
+	signer service.AuditCheckpointSigner,
+) *AuditChainVerifier {
+	return &AuditChainVerifier{
+		auditLogRepo:   auditLogRepo,
+		checkpointRepo: checkpointRepo,
+		signer:         signer,
+	}
+}
+
+// Verify checks checkpoint signatures, then walks the chain in sequence order,
+// recomputing each entry's hash and its link to the previous entry. The chain
+// may start after sequence 1 only when a checkpoint anchors the first retained
+// entry, so that pruned prefixes are distinguishable from deleted ones.
+// Verification stops at the first break.
+func (v *AuditChainVerifier) Verify(ctx context.Context) (*AuditChainReport, error) {
+	report := &AuditChainReport{SignaturesChecked: v.signer != nil}
+
+	checkpoints, err := v.checkpointRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("audit chain verifier: list checkpoints: %w", err)
+	}
+	bySequence := make(map[int64][]*entity.AuditCheckpoint, len(checkpoints))
+	for _, checkpoint := range checkpoints {
+		if v.signer != nil && !v.signer.Verify(checkpoint.KeyID, checkpoint.SigningPayload(), checkpoint.Signature) {
+			report.Break = &AuditChainBreak{
+				Type:          AuditChainBreakCheckpointSignature,
+				Sequence:      checkpoint.LastSequence,
+				CheckpointDay: checkpoint.Day,
+				Actual:        "key_id=" + checkpoint.KeyID,
+			}
+			return report, nil
+		}
+		bySequence[checkpoint.LastSequence] = append(bySequence[checkpoint.LastSequence], checkpoint)
+	}
+
+	var prev *entity.AuditLog
+	for {
+		after := report.LastSequence
+		entries, err := v.auditLogRepo.ListChainAfter(ctx, after, auditChainVerifyPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("audit chain verifier: list entries after %d: %w", after, err)
+		}
+
+		for _, log := range entries {
+			if prev == nil {
+				report.FirstSequence = log.Sequence
+				if brk := v.checkStart(log, bySequence, report); brk != nil {
+					report.Break = brk
+					return report, nil
+				}
+			} else if brk := checkLink(prev, log); brk != nil {
+				report.Break = brk
+				return report, nil
+			}
+
+			ok, err := log.VerifyHash()
+			if err != nil {
+				return nil, fmt.Errorf("audit chain verifier: compute hash of %d: %w", log.Sequence, err)
+			}
+			if !ok {
+				expected, _ := log.ComputeHash()
+				report.Break = &AuditChainBreak{
+					Type:     AuditChainBreakHashMismatch,
+					Sequence: log.Sequence,
+					EntryID:  log.ID,
+					Expected: hex.EncodeToString(expected),
+					Actual:   hex.EncodeToString(log.Hash),
+				}
+				return report, nil
+			}
+
+			for _, checkpoint := range bySequence[log.Sequence] {
+				if !bytes.Equal(checkpoint.LastHash, log.Hash) {
+					report.Break = &AuditChainBreak{
+						Type:          AuditChainBreakCheckpointMismatch,
+						Sequence:      log.Sequence,
+						EntryID:       log.ID,
+						CheckpointDay: checkpoint.Day,
+						Expected:      hex.EncodeToString(checkpoint.LastHash),
+						Actual:        hex.EncodeToString(log.Hash),
+					}
+					return report, nil
+				}
+				report.CheckpointsVerified++
+			}
+
+			prev = log
+			report.EntriesVerified++
+			report.LastSequence = log.Sequence
+		}
+
+		if len(entries) < auditChainVerifyPageSize {
+			break
+		}
+	}
+
+	// A checkpoint past the current head means signed entries were truncated.
+	for _, checkpoint := range checkpoints {
+		if checkpoint.LastSequence > report.LastSequence {
+			report.Break = &AuditChainBreak{
+				Type:          AuditChainBreakCheckpointBeyondHead,
+				Sequence:      checkpoint.LastSequence,
+				CheckpointDay: checkpoint.Day,
+				Expected:      "last_seq=" + strconv.FormatInt(checkpoint.LastSequence, 10),
+				Actual:        "last_seq=" + strconv.FormatInt(report.LastSequence, 10),
+			}
+			return report, nil
+		}
+	}
+
+	return report, nil
+}
+
+// checkStart validates the first retained entry, which either begins the chain
+// or is anchored to a checkpoint covering the pruned prefix.
+func (v *AuditChainVerifier) checkStart(log *entity.AuditLog, bySequence map[int64][]*entity.AuditCheckpoint, report *AuditChainReport) *AuditChainBreak {
+	if log.Sequence == 1 {
+		if len(log.PrevHash) != 0 {
+			return &AuditChainBreak{
+				Type:     AuditChainBreakPrevHashMismatch,
+				Sequence: log.Sequence,
+				EntryID:  log.ID,
+				Actual:   hex.EncodeToString(log.PrevHash),
+			}
+		}
+		return nil
+	}
+
+	for _, checkpoint := range bySequence[log.Sequence-1] {
+		if bytes.Equal(checkpoint.LastHash, log.PrevHash) {
+			report.CheckpointsVerified++
+			return nil
+		}
+	}
+	return &AuditChainBreak{
+		Type:     AuditChainBreakSequenceGap,
+		Sequence: log.Sequence,
+		EntryID:  log.ID,
+		Expected: "seq=1",
+		Actual:   "seq=" + strconv.FormatInt(log.Sequence, 10),
+	}
+}
+
+// checkLink validates that log directly follows prev in the chain.
+func checkLink(prev, log *entity.AuditLog) *AuditChainBreak {
+	if log.Sequence != prev.Sequence+1 {
+		return &AuditChainBreak{
+			Type:     AuditChainBreakSequenceGap,
+			Sequence: log.Sequence,
+			EntryID:  log.ID,
+			Expected: "seq=" + strconv.FormatInt(prev.Sequence+1, 10),
+			Actual:   "seq=" + strconv.FormatInt(log.Sequence, 10),
+		}
+	}
+	if !bytes.Equal(log.PrevHash, prev.Hash) {
+		return &AuditChainBreak{
+			Type:     AuditChainBreakPrevHashMismatch,
+			Sequence: log.Sequence,
+			EntryID:  log.ID,
+			Expected: hex.EncodeToString(prev.Hash),
+			Actual:   hex.EncodeToString(log.PrevHash),
+		}
+	}
+	return nil
+}
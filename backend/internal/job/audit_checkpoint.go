@@ -0,0 +1,80 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// auditCheckpointGracePeriod delays a day's checkpoint past midnight so that
+// entries stamped just before midnight have committed before the head is read.
+const auditCheckpointGracePeriod = time.Minute
+
+// AuditCheckpointJob is a background job that signs the audit log chain head at
+// the end of each UTC day.
+type AuditCheckpointJob struct {
+	auditLogRepo   repository.AuditLogRepository
+	checkpointRepo repository.AuditCheckpointRepository
+	signer         service.AuditCheckpointSigner
+}
+
+// NewAuditCheckpointJob creates a new AuditCheckpointJob.
+func NewAuditCheckpointJob(
+	auditLogRepo repository.AuditLogRepository,
+	checkpointRepo repository.AuditCheckpointRepository,
+	signer service.AuditCheckpointSigner,
+) *AuditCheckpointJob {
+	return &AuditCheckpointJob{
+		auditLogRepo:   auditLogRepo,
+		checkpointRepo: checkpointRepo,
+		signer:         signer,
+	}
+}
+
+// Run creates checkpoints for every completed day since the latest one. On the
+// first run only the previous day is checkpointed. Days before the first
+// chained entry are skipped.
+func (j *AuditCheckpointJob) Run(ctx context.Context) error {
+	today := entity.AuditCheckpointDay(time.Now().Add(-auditCheckpointGracePeriod))
+
+	day := today.AddDate(0, 0, -1)
+	latest, err := j.checkpointRepo.FindLatest(ctx)
+	if err != nil && !apperror.IsNotFound(err) {
+		return fmt.Errorf("audit checkpoint job: find latest: %w", err)
+	}
+	if latest != nil {
+		day = latest.DayEnd()
+	}
+
+	created := 0
+	for ; day.Before(today); day = day.AddDate(0, 0, 1) {
+		head, err := j.auditLogRepo.FindChainHeadBefore(ctx, day.AddDate(0, 0, 1))
+		if err != nil {
+			if apperror.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("audit checkpoint job: find chain head for %s: %w", day.Format(time.DateOnly), err)
+		}
+
+		checkpoint := entity.NewAuditCheckpoint(day, head)
+		checkpoint.KeyID = j.signer.KeyID()
+		if checkpoint.Signature, err = j.signer.Sign(checkpoint.SigningPayload()); err != nil {
+			return fmt.Errorf("audit checkpoint job: sign %s: %w", day.Format(time.DateOnly), err)
+		}
+		if err := j.checkpointRepo.Create(ctx, checkpoint); err != nil {
+			return fmt.Errorf("audit checkpoint job: create %s: %w", day.Format(time.DateOnly), err)
+		}
+		created++
+	}
+
+	if created > 0 {
+		slog.Info("created audit checkpoints", "count", created)
+	}
+	return nil
+}
@@ -0,0 +1,314 @@
+package job
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+)
+
+const (
+	// consistencyCheckPageSize is the number of rows fetched per page from each table.
+	consistencyCheckPageSize = 1000
+
+	// consistencyCheckGracePeriod excludes recently written objects from the orphan
+	// check, since an in-flight upload has an object but no version row yet.
+	consistencyCheckGracePeriod = entity.UploadSessionTTL
+)
+
+// ConsistencyIssueType classifies a drift between the database and the bucket.
+type ConsistencyIssueType string
+
+const (
+	ConsistencyIssueOrphanedObject   ConsistencyIssueType = "orphaned_object"
+	ConsistencyIssueMissingObject    ConsistencyIssueType = "missing_object"
+	ConsistencyIssueSizeMismatch     ConsistencyIssueType = "size_mismatch"
+	ConsistencyIssueChecksumMismatch ConsistencyIssueType = "checksum_mismatch"
+)
+
+// ConsistencyIssue is a single drift found by the checker.
+type ConsistencyIssue struct {
+	Type      ConsistencyIssueType
+	ObjectKey string
+	VersionID string
+	// Source, FileID and CurrentVersion are zero for orphaned objects, which have no row.
+	Source         repository.StoredObjectSource
+	FileID         uuid.UUID
+	CurrentVersion bool
+	Expected       string
+	Actual         string
+	Repaired       bool
+}
+
+// ConsistencyReport is the result of a single check.
+type ConsistencyReport struct {
+	RowsScanned    int
+	ObjectsScanned int
+	Issues         []ConsistencyIssue
+}
+
+// Unrepaired returns the number of issues left unrepaired.
+func (r *ConsistencyReport) Unrepaired() int {
+	count := 0
+	for _, issue := range r.Issues {
+		if !issue.Repaired {
+			count++
+		}
+	}
+	return count
+}
+
+// expectedObject is the row-side references to an object version and whether
+// the bucket walk saw it. Versions sharing a blob all reference the same object.
+type expectedObject struct {
+	refs []*repository.StoredObjectRef
+	seen bool
+}
+
+// StorageConsistencyChecker detects drift between the files, file_versions and
+// archived_files tables and the object versions stored in the bucket.
+type StorageConsistencyChecker struct {
+	inventoryRepo  repository.StorageInventoryRepository
+	fileRepo       repository.FileRepository
+	storageService service.StorageService
+}
+
+// NewStorageConsistencyChecker creates a new StorageConsistencyChecker.
+func NewStorageConsistencyChecker(
+	inventoryRepo repository.StorageInventoryRepository,
+	fileRepo repository.FileRepository,
+	storageService service.StorageService,
+) *StorageConsistencyChecker {
+	return &StorageConsistencyChecker{
+		inventoryRepo:  inventoryRepo,
+		fileRepo:       fileRepo,
+		storageService: storageService,
+	}
+}
+
+// Check walks both sides and reports orphaned objects, rows whose objects are
+// missing, and size or checksum mismatches. With repair set, orphaned object
+// versions are deleted and active files whose current version is missing or
+// mismatched are marked broken. Archived rows are only reported.
+func (c *StorageConsistencyChecker) Check(ctx context.Context, repair bool) (*ConsistencyReport, error) {
+	report := &ConsistencyReport{}
+
+	expected, knownKeys, err := c.loadExpectedObjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, exp := range expected {
+		report.RowsScanned += len(exp.refs)
+	}
+
+	cutoff := time.Now().Add(-consistencyCheckGracePeriod)
+	err = c.storageService.WalkObjectVersions(ctx, "", func(obj service.ObjectVersion) error {
+		if obj.IsDeleteMarker {
+			return nil
+		}
+		report.ObjectsScanned++
+
+		exp, ok := expected[objectRefKey(obj.Key, obj.VersionID)]
+		if !ok && obj.IsLatest {
+			// Rows written without a MinIO version ID refer to the latest version.
+			exp, ok = expected[objectRefKey(obj.Key, "")]
+		}
+		if !ok {
+			if obj.LastModified.After(cutoff) {
+				return nil
+			}
+			if base, derived := derivedObjectBase(obj.Key); derived {
+				// Derived objects (thumbnails, previews) are not tracked per row;
+				// they are orphans only once the file itself is gone.
+				if _, known := knownKeys[base]; known {
+					return nil
+				}
+			}
+			report.Issues = append(report.Issues, ConsistencyIssue{
+				Type:      ConsistencyIssueOrphanedObject,
+				ObjectKey: obj.Key,
+				VersionID: obj.VersionID,
+				Actual:    fmt.Sprintf("%d bytes", obj.Size),
+			})
+			return nil
+		}
+
+		exp.seen = true
+		for _, ref := range exp.refs {
+			if obj.Size != ref.Size {
+				report.Issues = append(report.Issues, newRowIssue(ConsistencyIssueSizeMismatch, ref,
+					fmt.Sprintf("%d", ref.Size), fmt.Sprintf("%d", obj.Size)))
+			}
+			// SHA-256 checksums are verified at upload completion and cannot be compared with an ETag.
+			if ref.Checksum != "" && !isSHA256Checksum(ref.Checksum) && !checksumMatchesETag(ref.Checksum, obj.ETag) {
+				report.Issues = append(report.Issues, newRowIssue(ConsistencyIssueChecksumMismatch, ref,
+					ref.Checksum, normalizeETag(obj.ETag)))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("storage consistency check: walk bucket: %w", err)
+	}
+
+	for _, exp := range expected {
+		if exp.seen {
+			continue
+		}
+		for _, ref := range exp.refs {
+			report.Issues = append(report.Issues, newRowIssue(ConsistencyIssueMissingObject, ref, ref.VersionID, ""))
+		}
+	}
+
+	if repair {
+		c.repair(ctx, report)
+	}
+	return report, nil
+}
+
+// loadExpectedObjects pages through both version tables and indexes every row by object version.
+func (c *StorageConsistencyChecker) loadExpectedObjects(ctx context.Context) (map[string]*expectedObject, map[string]struct{}, error) {
+	expected := make(map[string]*expectedObject)
+	knownKeys := make(map[string]struct{})
+
+	pagers := []struct {
+		name string
+		list func(ctx context.Context, afterID uuid.UUID, limit int) ([]*repository.StoredObjectRef, error)
+	}{
+		{"file versions", c.inventoryRepo.ListFileVersionRefs},
+		{"archived file versions", c.inventoryRepo.ListArchivedFileVersionRefs},
+	}
+
+	for _, pager := range pagers {
+		afterID := uuid.Nil
+		for {
+			refs, err := pager.list(ctx, afterID, consistencyCheckPageSize)
+			if err != nil {
+				return nil, nil, fmt.Errorf("storage consistency check: list %s: %w", pager.name, err)
+			}
+			for _, ref := range refs {
+				key := objectRefKey(ref.StorageKey, ref.VersionID)
+				if exp, ok := expected[key]; ok {
+					exp.refs = append(exp.refs, ref)
+				} else {
+					expected[key] = &expectedObject{refs: []*repository.StoredObjectRef{ref}}
+				}
+				knownKeys[ref.StorageKey] = struct{}{}
+			}
+			if len(refs) < consistencyCheckPageSize {
+				break
+			}
+			afterID = refs[len(refs)-1].RowID
+		}
+	}
+	return expected, knownKeys, nil
+}
+
+// repair deletes orphaned object versions and marks broken files, recording the outcome on each issue.
+func (c *StorageConsistencyChecker) repair(ctx context.Context, report *ConsistencyReport) {
+	for i := range report.Issues {
+		issue := &report.Issues[i]
+
+		switch issue.Type {
+		case ConsistencyIssueOrphanedObject:
+			if err := c.storageService.DeleteObjectVersion(ctx, issue.ObjectKey, issue.VersionID); err != nil {
+				slog.Warn("failed to delete orphaned object", "object_key", issue.ObjectKey, "version_id", issue.VersionID, "error", err)
+				continue
+			}
+			issue.Repaired = true
+
+		default:
+			// Only the current version of a file makes the file itself broken;
+			// other versions and archived rows are reported but left as is.
+			if issue.Source != repository.StoredObjectSourceFileVersion || !issue.CurrentVersion {
+				continue
+			}
+			repaired, err := c.markBroken(ctx, issue.FileID)
+			if err != nil {
+				slog.Warn("failed to mark file broken", "file_id", issue.FileID, "error", err)
+				continue
+			}
+			issue.Repaired = repaired
+		}
+	}
+}
+
+// markBroken marks an active file as broken. It reports true if the file is
+// broken after the call, including when an earlier issue already marked it.
+func (c *StorageConsistencyChecker) markBroken(ctx context.Context, fileID uuid.UUID) (bool, error) {
+	file, err := c.fileRepo.FindByID(ctx, fileID)
+	if err != nil {
+		return false, err
+	}
+	if file.IsBroken() {
+		return true, nil
+	}
+	if !file.IsActive() {
+		return false, nil
+	}
+	if err := file.MarkBroken(); err != nil {
+		return false, err
+	}
+	if err := c.fileRepo.UpdateStatus(ctx, file.ID, file.Status); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// newRowIssue creates an issue for a row-side reference.
+func newRowIssue(issueType ConsistencyIssueType, ref *repository.StoredObjectRef, expected, actual string) ConsistencyIssue {
+	return ConsistencyIssue{
+		Type:           issueType,
+		ObjectKey:      ref.StorageKey,
+		VersionID:      ref.VersionID,
+		Source:         ref.Source,
+		FileID:         ref.FileID,
+		CurrentVersion: ref.IsCurrent,
+		Expected:       expected,
+		Actual:         actual,
+	}
+}
+
+// objectRefKey builds the lookup key for an object version. MinIO reports
+// "null" for objects written while versioning was off; rows store it as empty.
+func objectRefKey(objectKey, versionID string) string {
+	if versionID == "null" {
+		versionID = ""
+	}
+	return objectKey + "\x00" + versionID
+}
+
+// derivedObjectBase returns the storage key of the file a derived object such
+// as "{key}/thumbnails/{size}" belongs to, and whether the key is derived at all.
+func derivedObjectBase(objectKey string) (string, bool) {
+	base, _, derived := strings.Cut(objectKey, "/")
+	return base, derived
+}
+
+// normalizeETag strips the quotes MinIO puts around ETags.
+func normalizeETag(etag string) string {
+	return strings.Trim(etag, `"`)
+}
+
+// isSHA256Checksum reports whether a stored checksum is a hex SHA-256 rather than an ETag.
+func isSHA256Checksum(checksum string) bool {
+	if len(checksum) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(checksum)
+	return err == nil
+}
+
+// checksumMatchesETag compares a stored checksum against an object ETag, ignoring quotes and case.
+func checksumMatchesETag(checksum, etag string) bool {
+	return strings.EqualFold(normalizeETag(checksum), normalizeETag(etag))
+}
@@ -0,0 +1,81 @@
+package job_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/job"
+	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
+)
+
+type storageConsistencyTestDeps struct {
+	inventoryRepo  *mocks.MockStorageInventoryRepository
+	fileRepo       *mocks.MockFileRepository
+	storageService *mocks.MockStorageService
+}
+
+// newMissingCurrentVersionDeps sets up a single active file whose current version has no object in the bucket.
+func newMissingCurrentVersionDeps(t *testing.T, ctx context.Context, file *entity.File) *storageConsistencyTestDeps {
+	t.Helper()
+	deps := &storageConsistencyTestDeps{
+		inventoryRepo:  mocks.NewMockStorageInventoryRepository(t),
+		fileRepo:       mocks.NewMockFileRepository(t),
+		storageService: mocks.NewMockStorageService(t),
+	}
+	ref := &repository.StoredObjectRef{
+		Source:     repository.StoredObjectSourceFileVersion,
+		RowID:      uuid.New(),
+		FileID:     file.ID,
+		StorageKey: file.StorageKey.String(),
+		VersionID:  "v1",
+		Size:       file.Size,
+		IsCurrent:  true,
+	}
+	deps.inventoryRepo.On("ListFileVersionRefs", ctx, uuid.Nil, mock.Anything).Return([]*repository.StoredObjectRef{ref}, nil)
+	deps.inventoryRepo.On("ListArchivedFileVersionRefs", ctx, uuid.Nil, mock.Anything).Return([]*repository.StoredObjectRef{}, nil)
+	deps.storageService.On("WalkObjectVersions", ctx, "", mock.Anything).Return(nil)
+	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	return deps
+}
+
+func (d *storageConsistencyTestDeps) newChecker() *job.StorageConsistencyChecker {
+	return job.NewStorageConsistencyChecker(d.inventoryRepo, d.fileRepo, d.storageService)
+}
+
+func TestStorageConsistencyChecker_Check_RepairMissingCurrentVersion_PersistsBrokenStatus(t *testing.T) {
+	ctx := context.Background()
+	file := newJobTestFile(uuid.New(), entity.FileStatusActive)
+	deps := newMissingCurrentVersionDeps(t, ctx, file)
+	deps.fileRepo.On("UpdateStatus", ctx, file.ID, entity.FileStatusBroken).Return(nil)
+
+	report, err := deps.newChecker().Check(ctx, true)
+
+	require.NoError(t, err)
+	require.Len(t, report.Issues, 1)
+	assert.Equal(t, job.ConsistencyIssueMissingObject, report.Issues[0].Type)
+	assert.True(t, report.Issues[0].Repaired)
+	assert.Equal(t, 0, report.Unrepaired())
+	deps.fileRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestStorageConsistencyChecker_Check_RepairStatusUpdateFails_ReportsUnrepaired(t *testing.T) {
+	ctx := context.Background()
+	file := newJobTestFile(uuid.New(), entity.FileStatusActive)
+	deps := newMissingCurrentVersionDeps(t, ctx, file)
+	deps.fileRepo.On("UpdateStatus", ctx, file.ID, entity.FileStatusBroken).Return(errors.New("db down"))
+
+	report, err := deps.newChecker().Check(ctx, true)
+
+	require.NoError(t, err)
+	require.Len(t, report.Issues, 1)
+	assert.False(t, report.Issues[0].Repaired)
+	assert.Equal(t, 1, report.Unrepaired())
+}
@@ -2,66 +2,78 @@ package job
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
-	"time"
 
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
 )
 
 const trashExpiryChunkSize = 100
 
-// TrashExpiryJob is a background job that permanently deletes expired archived files.
+// TrashExpiryJob is a background job that permanently deletes expired archived files
+// and archived folders.
 type TrashExpiryJob struct {
 	archivedFileRepo        repository.ArchivedFileRepository
 	archivedFileVersionRepo repository.ArchivedFileVersionRepository
+	archivedFolderRepo      repository.ArchivedFolderRepository
+	archivedSubfolderRepo   repository.ArchivedSubfolderRepository
+	storageUsageRepo        repository.StorageUsageRepository
 	storageService          service.StorageService
+	blobService             service.BlobService
 	txManager               repository.TransactionManager
-	interval                time.Duration
 }
 
 // NewTrashExpiryJob creates a new TrashExpiryJob.
 func NewTrashExpiryJob(
 	archivedFileRepo repository.ArchivedFileRepository,
 	archivedFileVersionRepo repository.ArchivedFileVersionRepository,
+	archivedFolderRepo repository.ArchivedFolderRepository,
+	archivedSubfolderRepo repository.ArchivedSubfolderRepository,
+	storageUsageRepo repository.StorageUsageRepository,
 	storageService service.StorageService,
+	blobService service.BlobService,
 	txManager repository.TransactionManager,
 ) *TrashExpiryJob {
 	return &TrashExpiryJob{
 		archivedFileRepo:        archivedFileRepo,
 		archivedFileVersionRepo: archivedFileVersionRepo,
+		archivedFolderRepo:      archivedFolderRepo,
+		archivedSubfolderRepo:   archivedSubfolderRepo,
+		storageUsageRepo:        storageUsageRepo,
 		storageService:          storageService,
+		blobService:             blobService,
 		txManager:               txManager,
-		interval:                24 * time.Hour,
 	}
 }
 
-// Start runs the expiry job on a daily ticker loop until context is cancelled.
-func (j *TrashExpiryJob) Start(ctx context.Context) {
-	ticker := time.NewTicker(j.interval)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		case <-ticker.C:
-			j.run(ctx)
-		}
+// Run performs a single expiry pass. Failures of individual chunks are logged
+// and do not abort the remaining chunks.
+//
+// Files trashed inside a folder share the folder's expiry, so they are removed
+// by the file pass before the archived folder records themselves are deleted.
+func (j *TrashExpiryJob) Run(ctx context.Context) error {
+	if err := j.expireFiles(ctx); err != nil {
+		return err
 	}
+	return j.expireFolders(ctx)
 }
 
-func (j *TrashExpiryJob) run(ctx context.Context) {
+// expireFiles deletes expired archived files, their versions and objects.
+func (j *TrashExpiryJob) expireFiles(ctx context.Context) error {
 	expired, err := j.archivedFileRepo.FindExpired(ctx)
 	if err != nil {
-		slog.Error("trash expiry job: find expired failed", "error", err)
-		return
+		return fmt.Errorf("trash expiry job: find expired: %w", err)
 	}
 	if len(expired) == 0 {
-		return
+		return nil
 	}
 
 	storageKeysToDelete := make([]string, 0, len(expired))
+	var releasedBlobs []*entity.Blob
 
 	for i := 0; i < len(expired); i += trashExpiryChunkSize {
 		end := i + trashExpiryChunkSize
@@ -70,8 +82,25 @@ func (j *TrashExpiryJob) run(ctx context.Context) {
 		}
 		chunk := expired[i:end]
 
+		var chunkKeys []string
+		var chunkBlobs []*entity.Blob
 		err = j.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+			// Freed bytes per owner, subtracted from the usage counters in the same transaction.
+			freedBytes := make(map[uuid.UUID]int64)
+			var blobIDs []uuid.UUID
 			for _, af := range chunk {
+				versions, err := j.archivedFileVersionRepo.FindByArchivedFileID(ctx, af.ID)
+				if err != nil {
+					return err
+				}
+				freedBytes[af.OwnerID] += af.StoredBytes(versions)
+				blobIDs = append(blobIDs, entity.ArchivedFileVersionBlobIDs(versions)...)
+				// Shared content may still be referenced by other files, so the key
+				// itself is only deleted when it holds content of its own.
+				if af.HasUnsharedContent(versions) {
+					chunkKeys = append(chunkKeys, af.StorageKey.String())
+				}
+
 				if err := j.archivedFileVersionRepo.DeleteByArchivedFileID(ctx, af.ID); err != nil {
 					return err
 				}
@@ -79,6 +108,17 @@ func (j *TrashExpiryJob) run(ctx context.Context) {
 					return err
 				}
 			}
+			// Only blobs whose last reference went away are returned for deletion.
+			released, err := j.blobService.Release(ctx, blobIDs)
+			if err != nil {
+				return err
+			}
+			chunkBlobs = released
+			for ownerID, freed := range freedBytes {
+				if err := j.storageUsageRepo.AddUsedBytes(ctx, ownerID, -freed); err != nil {
+					return err
+				}
+			}
 			return nil
 		})
 		if err != nil {
@@ -86,16 +126,44 @@ func (j *TrashExpiryJob) run(ctx context.Context) {
 			continue
 		}
 
-		for _, af := range chunk {
-			storageKeysToDelete = append(storageKeysToDelete, af.StorageKey.String())
-		}
+		storageKeysToDelete = append(storageKeysToDelete, chunkKeys...)
+		releasedBlobs = append(releasedBlobs, chunkBlobs...)
 	}
 
+	j.blobService.Purge(ctx, releasedBlobs)
 	if len(storageKeysToDelete) > 0 {
 		if err := j.storageService.DeleteObjects(ctx, storageKeysToDelete); err != nil {
-			slog.Error("trash expiry job: storage delete failed", "error", err, "count", len(storageKeysToDelete))
-		} else {
-			slog.Info("trash expiry job: deleted expired files", "count", len(storageKeysToDelete))
+			return fmt.Errorf("trash expiry job: storage delete of %d objects: %w", len(storageKeysToDelete), err)
+		}
+		slog.Info("trash expiry job: deleted expired files", "count", len(storageKeysToDelete))
+	}
+	return nil
+}
+
+// expireFolders deletes expired archived folder records together with their subtrees.
+func (j *TrashExpiryJob) expireFolders(ctx context.Context) error {
+	expired, err := j.archivedFolderRepo.FindExpired(ctx)
+	if err != nil {
+		return fmt.Errorf("trash expiry job: find expired folders: %w", err)
+	}
+
+	deleted := 0
+	for _, af := range expired {
+		err := j.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+			if err := j.archivedSubfolderRepo.DeleteByArchivedFolderID(ctx, af.ID); err != nil {
+				return err
+			}
+			return j.archivedFolderRepo.Delete(ctx, af.ID)
+		})
+		if err != nil {
+			slog.Error("trash expiry job: delete folder failed", "archived_folder_id", af.ID, "error", err)
+			continue
 		}
+		deleted++
+	}
+
+	if deleted > 0 {
+		slog.Info("trash expiry job: deleted expired folders", "count", deleted)
 	}
+	return nil
 }
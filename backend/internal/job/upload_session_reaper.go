@@ -0,0 +1,163 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// orphanMultipartGracePeriod is how old an incomplete multipart upload must be
+// before it is swept, so uploads whose session is still being created are left alone.
+const orphanMultipartGracePeriod = time.Hour
+
+// UploadSessionReaperJob is a background job that expires abandoned upload
+// sessions and cleans up the multipart uploads they leave behind in storage.
+type UploadSessionReaperJob struct {
+	uploadSessionRepo repository.UploadSessionRepository
+	fileRepo          repository.FileRepository
+	storageService    service.StorageService
+	txManager         repository.TransactionManager
+}
+
+// NewUploadSessionReaperJob creates a new UploadSessionReaperJob.
+func NewUploadSessionReaperJob(
+	uploadSessionRepo repository.UploadSessionRepository,
+	fileRepo repository.FileRepository,
+	storageService service.StorageService,
+	txManager repository.TransactionManager,
+) *UploadSessionReaperJob {
+	return &UploadSessionReaperJob{
+		uploadSessionRepo: uploadSessionRepo,
+		fileRepo:          fileRepo,
+		storageService:    storageService,
+		txManager:         txManager,
+	}
+}
+
+// Run expires sessions past their deadline, then sweeps incomplete multipart
+// uploads in the bucket that no live session refers to. Both steps always run.
+func (j *UploadSessionReaperJob) Run(ctx context.Context) error {
+	expireErr := j.expireSessions(ctx)
+	sweepErr := j.sweepOrphanMultipartUploads(ctx)
+	return errors.Join(expireErr, sweepErr)
+}
+
+// expireSessions marks expired sessions as expired and their pending files as
+// upload_failed. Failures on individual sessions are logged and skipped.
+func (j *UploadSessionReaperJob) expireSessions(ctx context.Context) error {
+	expired, err := j.uploadSessionRepo.FindExpired(ctx)
+	if err != nil {
+		return fmt.Errorf("upload session reaper job: find expired: %w", err)
+	}
+
+	count := 0
+	for _, session := range expired {
+		if err := j.expireSession(ctx, session); err != nil {
+			slog.Error("failed to expire upload session", "session_id", session.ID, "error", err)
+			continue
+		}
+		count++
+
+		// Abort outside the transaction; a failure here is picked up by the orphan sweep.
+		if session.MinioUploadID != nil {
+			if err := j.storageService.AbortMultipartUpload(ctx, session.StorageKey.String(), *session.MinioUploadID); err != nil {
+				slog.Warn("failed to abort multipart upload", "session_id", session.ID, "error", err)
+			}
+		}
+	}
+
+	if count > 0 {
+		slog.Info("expired upload sessions", "count", count)
+	}
+	return nil
+}
+
+// expireSession updates a single session and its file in one transaction.
+func (j *UploadSessionReaperJob) expireSession(ctx context.Context, session *entity.UploadSession) error {
+	return j.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		session.MarkExpired()
+		if err := j.uploadSessionRepo.Update(ctx, session); err != nil {
+			return err
+		}
+
+		// Only files that were never completed fail; an expired new-version
+		// session leaves the existing active file untouched.
+		file, err := j.fileRepo.FindByID(ctx, session.FileID)
+		if err != nil {
+			if apperror.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		if !file.IsUploading() {
+			return nil
+		}
+		if err := file.MarkUploadFailed(); err != nil {
+			return err
+		}
+		return j.fileRepo.UpdateStatus(ctx, file.ID, file.Status)
+	})
+}
+
+// sweepOrphanMultipartUploads aborts incomplete multipart uploads that are older
+// than the grace period and have no live upload session.
+func (j *UploadSessionReaperJob) sweepOrphanMultipartUploads(ctx context.Context) error {
+	uploads, err := j.storageService.ListIncompleteUploads(ctx, "")
+	if err != nil {
+		return fmt.Errorf("upload session reaper job: list incomplete uploads: %w", err)
+	}
+
+	cutoff := time.Now().Add(-orphanMultipartGracePeriod)
+	count := 0
+	for _, upload := range uploads {
+		if upload.Initiated.After(cutoff) {
+			continue
+		}
+
+		live, err := j.hasLiveSession(ctx, upload.ObjectKey)
+		if err != nil {
+			slog.Error("failed to look up upload session", "object_key", upload.ObjectKey, "error", err)
+			continue
+		}
+		if live {
+			continue
+		}
+
+		if err := j.storageService.AbortMultipartUpload(ctx, upload.ObjectKey, upload.UploadID); err != nil {
+			slog.Warn("failed to abort orphaned multipart upload", "object_key", upload.ObjectKey, "upload_id", upload.UploadID, "error", err)
+			continue
+		}
+		count++
+	}
+
+	if count > 0 {
+		slog.Info("aborted orphaned multipart uploads", "count", count)
+	}
+	return nil
+}
+
+// hasLiveSession reports whether the latest session for the object key can still accept uploads.
+func (j *UploadSessionReaperJob) hasLiveSession(ctx context.Context, objectKey string) (bool, error) {
+	storageKey, err := valueobject.NewStorageKeyFromString(objectKey)
+	if err != nil {
+		// Not a file storage key, so no session can own it.
+		return false, nil
+	}
+
+	session, err := j.uploadSessionRepo.FindByStorageKey(ctx, storageKey)
+	if err != nil {
+		if apperror.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return session.CanAcceptUpload(), nil
+}
@@ -0,0 +1,86 @@
+package job_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/job"
+	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
+)
+
+func newExpiredUploadSession(fileID uuid.UUID) *entity.UploadSession {
+	ownerID := uuid.New()
+	fileName, _ := valueobject.NewFileName("upload.txt")
+	mimeType, _ := valueobject.NewMimeType("text/plain")
+	return entity.ReconstructUploadSession(
+		uuid.New(), fileID, ownerID, ownerID, uuid.New(),
+		fileName, mimeType, 1024, valueobject.NewStorageKey(fileID),
+		nil, false, 1, 0,
+		entity.UploadSessionStatusPending,
+		time.Now().Add(-48*time.Hour), time.Now().Add(-48*time.Hour), time.Now().Add(-24*time.Hour),
+	)
+}
+
+func newJobTestFile(fileID uuid.UUID, status entity.FileStatus) *entity.File {
+	ownerID := uuid.New()
+	name, _ := valueobject.NewFileName("upload.txt")
+	mimeType, _ := valueobject.NewMimeType("text/plain")
+	return entity.ReconstructFile(
+		fileID, uuid.New(), ownerID, ownerID,
+		name, mimeType, 1024, valueobject.NewStorageKey(fileID), 1,
+		status, time.Now(), time.Now(),
+	)
+}
+
+func TestUploadSessionReaperJob_Run_ExpiredSession_PersistsUploadFailedStatus(t *testing.T) {
+	ctx := context.Background()
+	uploadSessionRepo := mocks.NewMockUploadSessionRepository(t)
+	fileRepo := mocks.NewMockFileRepository(t)
+	storageService := mocks.NewMockStorageService(t)
+	txManager := mocks.NewMockTransactionManager(t)
+
+	file := newJobTestFile(uuid.New(), entity.FileStatusUploading)
+	session := newExpiredUploadSession(file.ID)
+
+	uploadSessionRepo.On("FindExpired", ctx).Return([]*entity.UploadSession{session}, nil)
+	uploadSessionRepo.On("Update", ctx, session).Return(nil)
+	fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	fileRepo.On("UpdateStatus", ctx, file.ID, entity.FileStatusUploadFailed).Return(nil)
+	storageService.On("ListIncompleteUploads", ctx, "").Return([]service.IncompleteMultipartUpload{}, nil)
+
+	err := job.NewUploadSessionReaperJob(uploadSessionRepo, fileRepo, storageService, txManager).Run(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.UploadSessionStatusExpired, session.Status)
+	fileRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestUploadSessionReaperJob_Run_ExpiredSessionOfActiveFile_KeepsFile(t *testing.T) {
+	ctx := context.Background()
+	uploadSessionRepo := mocks.NewMockUploadSessionRepository(t)
+	fileRepo := mocks.NewMockFileRepository(t)
+	storageService := mocks.NewMockStorageService(t)
+	txManager := mocks.NewMockTransactionManager(t)
+
+	file := newJobTestFile(uuid.New(), entity.FileStatusActive)
+	session := newExpiredUploadSession(file.ID)
+
+	uploadSessionRepo.On("FindExpired", ctx).Return([]*entity.UploadSession{session}, nil)
+	uploadSessionRepo.On("Update", ctx, session).Return(nil)
+	fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	storageService.On("ListIncompleteUploads", ctx, "").Return([]service.IncompleteMultipartUpload{}, nil)
+
+	err := job.NewUploadSessionReaperJob(uploadSessionRepo, fileRepo, storageService, txManager).Run(ctx)
+
+	require.NoError(t, err)
+	fileRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+}
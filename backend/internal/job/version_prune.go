@@ -0,0 +1,148 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+)
+
+const versionPruneBatchSize = 100
+
+// VersionPruneJob is a background job that deletes file versions falling outside
+// the effective retention policy. Current and pinned versions are never deleted.
+type VersionPruneJob struct {
+	fileRepo         repository.FileRepository
+	fileVersionRepo  repository.FileVersionRepository
+	storageUsageRepo repository.StorageUsageRepository
+	retentionService service.VersionRetentionService
+	storageService   service.StorageService
+	blobService      service.BlobService
+	txManager        repository.TransactionManager
+}
+
+// NewVersionPruneJob creates a new VersionPruneJob.
+func NewVersionPruneJob(
+	fileRepo repository.FileRepository,
+	fileVersionRepo repository.FileVersionRepository,
+	storageUsageRepo repository.StorageUsageRepository,
+	retentionService service.VersionRetentionService,
+	storageService service.StorageService,
+	blobService service.BlobService,
+	txManager repository.TransactionManager,
+) *VersionPruneJob {
+	return &VersionPruneJob{
+		fileRepo:         fileRepo,
+		fileVersionRepo:  fileVersionRepo,
+		storageUsageRepo: storageUsageRepo,
+		retentionService: retentionService,
+		storageService:   storageService,
+		blobService:      blobService,
+		txManager:        txManager,
+	}
+}
+
+// Run performs a single prune pass. Failures on individual files are logged
+// and do not abort the pass.
+func (j *VersionPruneJob) Run(ctx context.Context) error {
+	now := time.Now()
+	afterID := uuid.Nil
+	pruned := 0
+
+	for {
+		fileIDs, err := j.fileVersionRepo.FindFileIDsWithPrunableVersions(ctx, afterID, versionPruneBatchSize)
+		if err != nil {
+			return fmt.Errorf("version prune job: find candidates: %w", err)
+		}
+
+		for _, fileID := range fileIDs {
+			n, err := j.pruneFile(ctx, fileID, now)
+			if err != nil {
+				slog.Error("version prune job: prune file failed", "error", err, "file_id", fileID)
+				continue
+			}
+			pruned += n
+		}
+
+		if len(fileIDs) < versionPruneBatchSize {
+			break
+		}
+		afterID = fileIDs[len(fileIDs)-1]
+	}
+
+	if pruned > 0 {
+		slog.Info("version prune job: pruned file versions", "count", pruned)
+	}
+	return nil
+}
+
+// pruneFile deletes the versions of a single file that its policy no longer retains
+// and returns how many were deleted.
+func (j *VersionPruneJob) pruneFile(ctx context.Context, fileID uuid.UUID, now time.Time) (int, error) {
+	file, err := j.fileRepo.FindByID(ctx, fileID)
+	if err != nil {
+		return 0, err
+	}
+
+	policy, err := j.retentionService.ResolvePolicy(ctx, file)
+	if err != nil {
+		return 0, err
+	}
+	if policy == nil {
+		return 0, nil
+	}
+
+	versions, err := j.fileVersionRepo.FindByFileID(ctx, file.ID)
+	if err != nil {
+		return 0, err
+	}
+	prunable := policy.SelectPrunable(versions, file.CurrentVersion, now)
+	if len(prunable) == 0 {
+		return 0, nil
+	}
+
+	var releasedBlobs []*entity.Blob
+	err = j.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		var freed int64
+		for _, v := range prunable {
+			if err := j.fileVersionRepo.Delete(ctx, v.ID); err != nil {
+				return err
+			}
+			freed += v.Size
+		}
+		// Shared content is only deleted once its last reference is released.
+		released, err := j.blobService.Release(ctx, entity.FileVersionBlobIDs(prunable))
+		if err != nil {
+			return err
+		}
+		releasedBlobs = released
+		// Every stored version counts toward the owner's usage.
+		return j.storageUsageRepo.AddUsedBytes(ctx, file.OwnerID, -freed)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	j.deleteObjectVersions(ctx, file, prunable)
+	j.blobService.Purge(ctx, releasedBlobs)
+	return len(prunable), nil
+}
+
+// deleteObjectVersions removes the pruned versions that do not share a blob
+// from object storage. Failures are logged only; the database rows are already gone.
+func (j *VersionPruneJob) deleteObjectVersions(ctx context.Context, file *entity.File, versions []*entity.FileVersion) {
+	for _, v := range versions {
+		if v.MinioVersionID == "" || v.IsShared() {
+			continue
+		}
+		if err := j.storageService.DeleteObjectVersion(ctx, v.StorageKey.String(), v.MinioVersionID); err != nil {
+			slog.Error("version prune job: storage delete failed", "error", err, "file_id", file.ID, "version", v.VersionNumber)
+		}
+	}
+}
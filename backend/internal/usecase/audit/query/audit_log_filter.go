@@ -0,0 +1,134 @@
+package query
+
+import (
+	"context"
+	"encoding/base64"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// 監査ログ検索条件の制約（audit_logs の列長に合わせる）
+const (
+	MaxAuditActionPrefixChars = 100
+	MaxAuditRequestIDChars    = 100
+)
+
+// auditActionPrefixPattern はアクション前方一致に使える文字を定義します
+var auditActionPrefixPattern = regexp.MustCompile(`^[a-z_.]*$`)
+
+// AuditLogFilter は監査ログの絞り込み条件を定義します
+type AuditLogFilter struct {
+	ActorID      *uuid.UUID                // 操作者（未指定時の扱いはアクセス範囲による）
+	ActionPrefix string                    // アクションの前方一致（例: "file."）
+	ResourceType *entity.AuditResourceType // リソースタイプ
+	ResourceID   *uuid.UUID                // リソースID（ResourceTypeと併せて指定）
+	From         *time.Time                // 記録日時の下限（含む）
+	To           *time.Time                // 記録日時の上限（含まない）
+	RequestID    string                    // リクエストID
+}
+
+// resolveAuditLogCriteria は絞り込み条件を検証し、呼び出し元が閲覧できる範囲の検索条件に変換します
+// 閲覧できるのは自分自身の操作と、manage:access 権限を持つファイル・フォルダに対する操作のみです
+func resolveAuditLogCriteria(ctx context.Context, resolver authz.PermissionResolver, userID uuid.UUID, filter AuditLogFilter) (repository.AuditLogCriteria, error) {
+	// 1. 入力のバリデーション
+	if len(filter.ActionPrefix) > MaxAuditActionPrefixChars || !auditActionPrefixPattern.MatchString(filter.ActionPrefix) {
+		return repository.AuditLogCriteria{}, apperror.NewValidationError("invalid action prefix", nil)
+	}
+	if len(filter.RequestID) > MaxAuditRequestIDChars {
+		return repository.AuditLogCriteria{}, apperror.NewValidationError("request ID is too long", nil)
+	}
+	if filter.ResourceType != nil && !filter.ResourceType.IsValid() {
+		return repository.AuditLogCriteria{}, apperror.NewValidationError("invalid resource type", nil)
+	}
+	if filter.ResourceID != nil && filter.ResourceType == nil {
+		return repository.AuditLogCriteria{}, apperror.NewValidationError("resourceType is required when resourceId is specified", nil)
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return repository.AuditLogCriteria{}, apperror.NewValidationError("from must be before to", nil)
+	}
+
+	// 2. アクセス範囲の決定
+	// 管理権限のあるリソースに限定した場合のみ、他のユーザーの操作も閲覧できる
+	canManage, err := canManageAuditResource(ctx, resolver, userID, filter)
+	if err != nil {
+		return repository.AuditLogCriteria{}, err
+	}
+	actorID := filter.ActorID
+	if !canManage {
+		if actorID != nil && *actorID != userID {
+			return repository.AuditLogCriteria{}, apperror.NewForbiddenError("not authorized to view audit logs of other users")
+		}
+		actorID = &userID
+	}
+
+	return repository.AuditLogCriteria{
+		ActorID:      actorID,
+		ActionPrefix: filter.ActionPrefix,
+		ResourceType: filter.ResourceType,
+		ResourceID:   filter.ResourceID,
+		From:         filter.From,
+		To:           filter.To,
+		RequestID:    filter.RequestID,
+	}, nil
+}
+
+// canManageAuditResource は絞り込み対象のリソースに対して manage:access 権限を持つかを判定します
+// ファイル・フォルダ以外のリソースは権限による閲覧範囲の拡張対象外です
+func canManageAuditResource(ctx context.Context, resolver authz.PermissionResolver, userID uuid.UUID, filter AuditLogFilter) (bool, error) {
+	if filter.ResourceType == nil || filter.ResourceID == nil {
+		return false, nil
+	}
+
+	var resourceType authz.ResourceType
+	switch *filter.ResourceType {
+	case entity.AuditResourceFile:
+		resourceType = authz.ResourceTypeFile
+	case entity.AuditResourceFolder:
+		resourceType = authz.ResourceTypeFolder
+	default:
+		return false, nil
+	}
+
+	return resolver.HasPermission(ctx, userID, resourceType, *filter.ResourceID, authz.PermManageAccess)
+}
+
+// encodeAuditLogCursor は監査ログカーソルを不透明な文字列に変換します
+func encodeAuditLogCursor(cursor *repository.AuditLogCursor) string {
+	raw := strconv.FormatInt(cursor.CreatedAt.UnixNano(), 10) + "_" + cursor.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeAuditLogCursor は文字列から監査ログカーソルを復元します（空文字列の場合はnil）
+func decodeAuditLogCursor(s string) (*repository.AuditLogCursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	nanos, idStr, ok := strings.Cut(string(raw), "_")
+	if !ok {
+		return nil, apperror.NewValidationError("invalid cursor", nil)
+	}
+	ts, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.AuditLogCursor{CreatedAt: time.Unix(0, ts), ID: id}, nil
+}
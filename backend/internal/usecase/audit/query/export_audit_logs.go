@@ -0,0 +1,218 @@
+package query
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// ExportAuditLogsBatchSize はエクスポート時に1回のクエリで取得する件数です
+const ExportAuditLogsBatchSize = 500
+
+// AuditLogExportFormat は監査ログのエクスポート形式を定義します
+type AuditLogExportFormat string
+
+const (
+	AuditLogExportFormatNDJSON AuditLogExportFormat = "ndjson"
+	AuditLogExportFormatCSV    AuditLogExportFormat = "csv"
+)
+
+// IsValid はエクスポート形式が有効かを判定します
+func (f AuditLogExportFormat) IsValid() bool {
+	return f == AuditLogExportFormatNDJSON || f == AuditLogExportFormatCSV
+}
+
+// ContentType はエクスポート形式のContent-Typeを返します
+func (f AuditLogExportFormat) ContentType() string {
+	if f == AuditLogExportFormatCSV {
+		return "text/csv; charset=utf-8"
+	}
+	return "application/x-ndjson"
+}
+
+// auditLogCSVHeader はCSVエクスポートの列を定義します
+var auditLogCSVHeader = []string{
+	"id", "created_at", "user_id", "action", "resource_type", "resource_id",
+	"ip_address", "user_agent", "request_id", "details",
+}
+
+// ExportAuditLogsInput は監査ログエクスポートの入力を定義します
+type ExportAuditLogsInput struct {
+	UserID uuid.UUID
+	Filter AuditLogFilter
+	Format AuditLogExportFormat // デフォルト: ndjson
+}
+
+// ExportAuditLogsOutput は監査ログエクスポートの出力を定義します
+// 監査ログは Write を呼び出した時点でデータベースから順に読み出されます
+type ExportAuditLogsOutput struct {
+	Filename string
+	Format   AuditLogExportFormat
+
+	criteria     repository.AuditLogCriteria
+	auditLogRepo repository.AuditLogRepository
+}
+
+// Write は条件に一致する監査ログを記録日時の新しい順にwへ書き込みます
+// 件数が多い場合もメモリに全件を保持しないよう、キーセットページネーションで分割して読み出します
+func (o *ExportAuditLogsOutput) Write(ctx context.Context, w io.Writer) error {
+	var csvWriter *csv.Writer
+	if o.Format == AuditLogExportFormatCSV {
+		csvWriter = csv.NewWriter(w)
+		if err := csvWriter.Write(auditLogCSVHeader); err != nil {
+			return err
+		}
+	}
+	encoder := json.NewEncoder(w)
+
+	criteria := o.criteria
+	criteria.Limit = ExportAuditLogsBatchSize
+	for {
+		logs, err := o.auditLogRepo.Search(ctx, criteria)
+		if err != nil {
+			return err
+		}
+
+		for _, log := range logs {
+			record := newAuditLogRecord(log)
+			if csvWriter != nil {
+				if err := csvWriter.Write(record.csvRow()); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := encoder.Encode(record); err != nil {
+				return err
+			}
+		}
+		if csvWriter != nil {
+			csvWriter.Flush()
+			if err := csvWriter.Error(); err != nil {
+				return err
+			}
+		}
+
+		if len(logs) < criteria.Limit {
+			return nil
+		}
+		last := logs[len(logs)-1]
+		criteria.Cursor = &repository.AuditLogCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+}
+
+// ExportAuditLogsQuery は監査ログをNDJSONまたはCSVでエクスポートするクエリです
+type ExportAuditLogsQuery struct {
+	auditLogRepo       repository.AuditLogRepository
+	permissionResolver authz.PermissionResolver
+}
+
+// NewExportAuditLogsQuery は新しいExportAuditLogsQueryを作成します
+func NewExportAuditLogsQuery(
+	auditLogRepo repository.AuditLogRepository,
+	permissionResolver authz.PermissionResolver,
+) *ExportAuditLogsQuery {
+	return &ExportAuditLogsQuery{
+		auditLogRepo:       auditLogRepo,
+		permissionResolver: permissionResolver,
+	}
+}
+
+// Execute はエクスポート対象の検索条件を確定します
+// 閲覧範囲は一覧取得（ListAuditLogsQuery）と同じです
+func (q *ExportAuditLogsQuery) Execute(ctx context.Context, input ExportAuditLogsInput) (*ExportAuditLogsOutput, error) {
+	format := input.Format
+	if format == "" {
+		format = AuditLogExportFormatNDJSON
+	}
+	if !format.IsValid() {
+		return nil, apperror.NewValidationError("invalid export format", nil)
+	}
+
+	criteria, err := resolveAuditLogCriteria(ctx, q.permissionResolver, input.UserID, input.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExportAuditLogsOutput{
+		Filename:     "audit-logs-" + time.Now().UTC().Format("20060102T150405Z") + "." + string(format),
+		Format:       format,
+		criteria:     criteria,
+		auditLogRepo: q.auditLogRepo,
+	}, nil
+}
+
+// auditLogRecord はエクスポートする監査ログ1件の表現です
+type auditLogRecord struct {
+	ID           string                 `json:"id"`
+	CreatedAt    string                 `json:"createdAt"`
+	UserID       *string                `json:"userId"`
+	Action       string                 `json:"action"`
+	ResourceType string                 `json:"resourceType"`
+	ResourceID   *string                `json:"resourceId"`
+	IPAddress    string                 `json:"ipAddress,omitempty"`
+	UserAgent    string                 `json:"userAgent,omitempty"`
+	RequestID    string                 `json:"requestId,omitempty"`
+	Details      map[string]interface{} `json:"details,omitempty"`
+}
+
+func newAuditLogRecord(log *entity.AuditLog) *auditLogRecord {
+	record := &auditLogRecord{
+		ID:           log.ID.String(),
+		CreatedAt:    log.CreatedAt.UTC().Format(time.RFC3339Nano),
+		Action:       string(log.Action),
+		ResourceType: string(log.ResourceType),
+		IPAddress:    log.IPAddress,
+		UserAgent:    log.UserAgent,
+		RequestID:    log.RequestID,
+		Details:      log.Details,
+	}
+	if log.UserID != nil {
+		userID := log.UserID.String()
+		record.UserID = &userID
+	}
+	if log.ResourceID != nil {
+		resourceID := log.ResourceID.String()
+		record.ResourceID = &resourceID
+	}
+	return record
+}
+
+// csvRow はCSVの1行に変換します（列順は auditLogCSVHeader）
+func (r *auditLogRecord) csvRow() []string {
+	var userID, resourceID, details string
+	if r.UserID != nil {
+		userID = *r.UserID
+	}
+	if r.ResourceID != nil {
+		resourceID = *r.ResourceID
+	}
+	if r.Details != nil {
+		if b, err := json.Marshal(r.Details); err == nil {
+			details = string(b)
+		}
+	}
+
+	return []string{
+		r.ID, r.CreatedAt, userID, r.Action, r.ResourceType, resourceID,
+		csvSafe(r.IPAddress), csvSafe(r.UserAgent), csvSafe(r.RequestID), details,
+	}
+}
+
+// csvSafe は表計算ソフトで数式として解釈される値の先頭に ' を付けます
+// User-Agent などクライアントが任意に指定できる値によるCSVインジェクションを防ぐため
+func csvSafe(v string) string {
+	if v != "" && strings.ContainsRune("=+-@\t\r", rune(v[0])) {
+		return "'" + v
+	}
+	return v
+}
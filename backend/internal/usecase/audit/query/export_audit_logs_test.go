@@ -0,0 +1,92 @@
+package query_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/audit/query"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+func TestExportAuditLogsQuery_Execute_NDJSON_PagesThroughAllLogs(t *testing.T) {
+	ctx := context.Background()
+	deps := newAuditLogQueryTestDeps(t)
+
+	userID := uuid.New()
+	logs := newAuditLogs(userID, query.ExportAuditLogsBatchSize+1)
+
+	deps.auditLogRepo.On("Search", ctx, mock.MatchedBy(func(c repository.AuditLogCriteria) bool {
+		return c.Cursor == nil && c.Limit == query.ExportAuditLogsBatchSize && c.ActorID != nil && *c.ActorID == userID
+	})).Return(logs[:query.ExportAuditLogsBatchSize], nil).Once()
+	deps.auditLogRepo.On("Search", ctx, mock.MatchedBy(func(c repository.AuditLogCriteria) bool {
+		return c.Cursor != nil && c.Cursor.ID == logs[query.ExportAuditLogsBatchSize-1].ID
+	})).Return(logs[query.ExportAuditLogsBatchSize:], nil).Once()
+
+	output, err := deps.newExportQuery().Execute(ctx, query.ExportAuditLogsInput{UserID: userID})
+	require.NoError(t, err)
+	assert.Equal(t, query.AuditLogExportFormatNDJSON, output.Format)
+	assert.True(t, strings.HasSuffix(output.Filename, ".ndjson"))
+
+	var buf bytes.Buffer
+	require.NoError(t, output.Write(ctx, &buf))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, len(logs))
+	var first map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	assert.Equal(t, logs[0].ID.String(), first["id"])
+	assert.Equal(t, userID.String(), first["userId"])
+	assert.Equal(t, string(entity.AuditActionFileRename), first["action"])
+}
+
+func TestExportAuditLogsQuery_Execute_CSV_WritesHeaderAndEscapesFormulas(t *testing.T) {
+	ctx := context.Background()
+	deps := newAuditLogQueryTestDeps(t)
+
+	userID := uuid.New()
+	logs := newAuditLogs(userID, 1)
+	logs[0].UserAgent = "=HYPERLINK(\"http://example.com\")"
+	logs[0].Details = map[string]interface{}{"name": "a,b.txt"}
+
+	deps.auditLogRepo.On("Search", ctx, mock.Anything).Return(logs, nil).Once()
+
+	output, err := deps.newExportQuery().Execute(ctx, query.ExportAuditLogsInput{
+		UserID: userID,
+		Format: query.AuditLogExportFormatCSV,
+	})
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(output.Filename, ".csv"))
+
+	var buf bytes.Buffer
+	require.NoError(t, output.Write(ctx, &buf))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "id", records[0][0])
+	assert.Equal(t, logs[0].ID.String(), records[1][0])
+	assert.Equal(t, "'=HYPERLINK(\"http://example.com\")", records[1][7])
+	assert.JSONEq(t, `{"name":"a,b.txt"}`, records[1][9])
+}
+
+func TestExportAuditLogsQuery_Execute_InvalidFormat_ReturnsValidationError(t *testing.T) {
+	deps := newAuditLogQueryTestDeps(t)
+
+	_, err := deps.newExportQuery().Execute(context.Background(), query.ExportAuditLogsInput{
+		UserID: uuid.New(),
+		Format: query.AuditLogExportFormat("xml"),
+	})
+
+	requireAppErrorCode(t, err, apperror.CodeValidationError)
+}
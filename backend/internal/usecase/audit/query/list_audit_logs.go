@@ -0,0 +1,93 @@
+package query
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// ページネーションのデフォルト値
+const (
+	DefaultAuditLogLimit = 50
+	MaxAuditLogLimit     = 100
+)
+
+// ListAuditLogsInput は監査ログ一覧の入力を定義します
+type ListAuditLogsInput struct {
+	UserID uuid.UUID
+	Filter AuditLogFilter
+	Limit  int    // 取得件数（デフォルト: 50, 最大: 100）
+	Cursor string // ページネーションカーソル（前回のNextCursor）
+}
+
+// ListAuditLogsOutput は監査ログ一覧の出力を定義します
+type ListAuditLogsOutput struct {
+	Items      []*entity.AuditLog
+	NextCursor *string // 次ページのカーソル
+	HasMore    bool    // 次ページが存在するか
+}
+
+// ListAuditLogsQuery は監査ログ一覧クエリです
+type ListAuditLogsQuery struct {
+	auditLogRepo       repository.AuditLogRepository
+	permissionResolver authz.PermissionResolver
+}
+
+// NewListAuditLogsQuery は新しいListAuditLogsQueryを作成します
+func NewListAuditLogsQuery(
+	auditLogRepo repository.AuditLogRepository,
+	permissionResolver authz.PermissionResolver,
+) *ListAuditLogsQuery {
+	return &ListAuditLogsQuery{
+		auditLogRepo:       auditLogRepo,
+		permissionResolver: permissionResolver,
+	}
+}
+
+// Execute は監査ログを記録日時の新しい順に取得します
+func (q *ListAuditLogsQuery) Execute(ctx context.Context, input ListAuditLogsInput) (*ListAuditLogsOutput, error) {
+	// 1. カーソルとLimitの正規化
+	cursor, err := decodeAuditLogCursor(input.Cursor)
+	if err != nil {
+		return nil, apperror.NewValidationError("invalid cursor", nil)
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = DefaultAuditLogLimit
+	}
+	if limit > MaxAuditLogLimit {
+		limit = MaxAuditLogLimit
+	}
+
+	// 2. 閲覧範囲を反映した検索条件を組み立てる
+	criteria, err := resolveAuditLogCriteria(ctx, q.permissionResolver, input.UserID, input.Filter)
+	if err != nil {
+		return nil, err
+	}
+	criteria.Cursor = cursor
+	criteria.Limit = limit + 1
+
+	// 3. 監査ログを取得
+	logs, err := q.auditLogRepo.Search(ctx, criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. 次ページの存在確認とカーソル設定
+	output := &ListAuditLogsOutput{Items: logs}
+	if len(logs) > limit {
+		output.Items = logs[:limit]
+		output.HasMore = true
+		last := output.Items[limit-1]
+		next := encodeAuditLogCursor(&repository.AuditLogCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+		output.NextCursor = &next
+	}
+
+	return output, nil
+}
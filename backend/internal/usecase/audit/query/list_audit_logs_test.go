@@ -0,0 +1,236 @@
+package query_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/audit/query"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
+)
+
+type auditLogQueryTestDeps struct {
+	auditLogRepo       *mocks.MockAuditLogRepository
+	permissionResolver *mocks.MockPermissionResolver
+}
+
+func newAuditLogQueryTestDeps(t *testing.T) *auditLogQueryTestDeps {
+	t.Helper()
+	return &auditLogQueryTestDeps{
+		auditLogRepo:       mocks.NewMockAuditLogRepository(t),
+		permissionResolver: mocks.NewMockPermissionResolver(t),
+	}
+}
+
+func (d *auditLogQueryTestDeps) newListQuery() *query.ListAuditLogsQuery {
+	return query.NewListAuditLogsQuery(d.auditLogRepo, d.permissionResolver)
+}
+
+func (d *auditLogQueryTestDeps) newExportQuery() *query.ExportAuditLogsQuery {
+	return query.NewExportAuditLogsQuery(d.auditLogRepo, d.permissionResolver)
+}
+
+func newAuditLogs(userID uuid.UUID, n int) []*entity.AuditLog {
+	now := time.Now()
+	logs := make([]*entity.AuditLog, n)
+	for i := range logs {
+		logs[i] = &entity.AuditLog{
+			ID:           uuid.New(),
+			UserID:       &userID,
+			Action:       entity.AuditActionFileRename,
+			ResourceType: entity.AuditResourceFile,
+			CreatedAt:    now.Add(-time.Duration(i) * time.Minute),
+		}
+	}
+	return logs
+}
+
+func auditResourceType(t entity.AuditResourceType) *entity.AuditResourceType {
+	return &t
+}
+
+func requireAppErrorCode(t *testing.T, err error, code apperror.ErrorCode) {
+	t.Helper()
+	require.Error(t, err)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, code, appErr.Code)
+}
+
+func TestListAuditLogsQuery_Execute_NoFilter_ScopedToOwnActivity(t *testing.T) {
+	ctx := context.Background()
+	deps := newAuditLogQueryTestDeps(t)
+
+	userID := uuid.New()
+	logs := newAuditLogs(userID, 2)
+
+	deps.auditLogRepo.On("Search", ctx, mock.MatchedBy(func(c repository.AuditLogCriteria) bool {
+		return c.ActorID != nil && *c.ActorID == userID && c.Limit == query.DefaultAuditLogLimit+1 && c.Cursor == nil
+	})).Return(logs, nil)
+
+	output, err := deps.newListQuery().Execute(ctx, query.ListAuditLogsInput{UserID: userID})
+
+	require.NoError(t, err)
+	assert.Equal(t, logs, output.Items)
+	assert.False(t, output.HasMore)
+	assert.Nil(t, output.NextCursor)
+}
+
+func TestListAuditLogsQuery_Execute_ManagedResource_IncludesOtherActors(t *testing.T) {
+	ctx := context.Background()
+	deps := newAuditLogQueryTestDeps(t)
+
+	userID := uuid.New()
+	otherID := uuid.New()
+	folderID := uuid.New()
+	logs := newAuditLogs(otherID, 1)
+
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFolder, folderID, authz.PermManageAccess).Return(true, nil)
+	deps.auditLogRepo.On("Search", ctx, mock.MatchedBy(func(c repository.AuditLogCriteria) bool {
+		return c.ActorID == nil &&
+			c.ResourceType != nil && *c.ResourceType == entity.AuditResourceFolder &&
+			c.ResourceID != nil && *c.ResourceID == folderID &&
+			c.ActionPrefix == "folder."
+	})).Return(logs, nil)
+
+	output, err := deps.newListQuery().Execute(ctx, query.ListAuditLogsInput{
+		UserID: userID,
+		Filter: query.AuditLogFilter{
+			ActionPrefix: "folder.",
+			ResourceType: auditResourceType(entity.AuditResourceFolder),
+			ResourceID:   &folderID,
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, logs, output.Items)
+}
+
+func TestListAuditLogsQuery_Execute_UnmanagedResource_FallsBackToOwnActivity(t *testing.T) {
+	ctx := context.Background()
+	deps := newAuditLogQueryTestDeps(t)
+
+	userID := uuid.New()
+	fileID := uuid.New()
+
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFile, fileID, authz.PermManageAccess).Return(false, nil)
+	deps.auditLogRepo.On("Search", ctx, mock.MatchedBy(func(c repository.AuditLogCriteria) bool {
+		return c.ActorID != nil && *c.ActorID == userID && c.ResourceID != nil && *c.ResourceID == fileID
+	})).Return([]*entity.AuditLog{}, nil)
+
+	output, err := deps.newListQuery().Execute(ctx, query.ListAuditLogsInput{
+		UserID: userID,
+		Filter: query.AuditLogFilter{
+			ResourceType: auditResourceType(entity.AuditResourceFile),
+			ResourceID:   &fileID,
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Empty(t, output.Items)
+}
+
+func TestListAuditLogsQuery_Execute_OtherActorWithoutManageAccess_ReturnsForbidden(t *testing.T) {
+	ctx := context.Background()
+	deps := newAuditLogQueryTestDeps(t)
+
+	userID := uuid.New()
+	otherID := uuid.New()
+
+	_, err := deps.newListQuery().Execute(ctx, query.ListAuditLogsInput{
+		UserID: userID,
+		Filter: query.AuditLogFilter{ActorID: &otherID},
+	})
+
+	requireAppErrorCode(t, err, apperror.CodeForbidden)
+	deps.auditLogRepo.AssertNotCalled(t, "Search", mock.Anything, mock.Anything)
+}
+
+func TestListAuditLogsQuery_Execute_GroupResource_DoesNotCheckManageAccess(t *testing.T) {
+	ctx := context.Background()
+	deps := newAuditLogQueryTestDeps(t)
+
+	userID := uuid.New()
+	otherID := uuid.New()
+	groupID := uuid.New()
+
+	_, err := deps.newListQuery().Execute(ctx, query.ListAuditLogsInput{
+		UserID: userID,
+		Filter: query.AuditLogFilter{
+			ActorID:      &otherID,
+			ResourceType: auditResourceType(entity.AuditResourceGroup),
+			ResourceID:   &groupID,
+		},
+	})
+
+	requireAppErrorCode(t, err, apperror.CodeForbidden)
+	deps.permissionResolver.AssertNotCalled(t, "HasPermission", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestListAuditLogsQuery_Execute_WithCursor_ReturnsNextPage(t *testing.T) {
+	ctx := context.Background()
+	deps := newAuditLogQueryTestDeps(t)
+
+	userID := uuid.New()
+	logs := newAuditLogs(userID, 3)
+
+	deps.auditLogRepo.On("Search", ctx, mock.MatchedBy(func(c repository.AuditLogCriteria) bool {
+		return c.Cursor == nil && c.Limit == 3
+	})).Return(logs, nil).Once()
+
+	first, err := deps.newListQuery().Execute(ctx, query.ListAuditLogsInput{UserID: userID, Limit: 2})
+
+	require.NoError(t, err)
+	require.Len(t, first.Items, 2)
+	assert.True(t, first.HasMore)
+	require.NotNil(t, first.NextCursor)
+
+	deps.auditLogRepo.On("Search", ctx, mock.MatchedBy(func(c repository.AuditLogCriteria) bool {
+		return c.Cursor != nil && c.Cursor.ID == logs[1].ID && c.Cursor.CreatedAt.Equal(logs[1].CreatedAt)
+	})).Return(logs[2:], nil).Once()
+
+	second, err := deps.newListQuery().Execute(ctx, query.ListAuditLogsInput{UserID: userID, Limit: 2, Cursor: *first.NextCursor})
+
+	require.NoError(t, err)
+	require.Len(t, second.Items, 1)
+	assert.Equal(t, logs[2].ID, second.Items[0].ID)
+	assert.False(t, second.HasMore)
+}
+
+func TestListAuditLogsQuery_Execute_InvalidFilter_ReturnsValidationError(t *testing.T) {
+	resourceID := uuid.New()
+	from := time.Now()
+	to := from.Add(-time.Hour)
+
+	tests := []struct {
+		name  string
+		input query.ListAuditLogsInput
+	}{
+		{name: "invalid cursor", input: query.ListAuditLogsInput{Cursor: "not-a-cursor"}},
+		{name: "wildcard in action prefix", input: query.ListAuditLogsInput{Filter: query.AuditLogFilter{ActionPrefix: "file%"}}},
+		{name: "unknown resource type", input: query.ListAuditLogsInput{Filter: query.AuditLogFilter{ResourceType: auditResourceType("blob")}}},
+		{name: "resource ID without type", input: query.ListAuditLogsInput{Filter: query.AuditLogFilter{ResourceID: &resourceID}}},
+		{name: "inverted time range", input: query.ListAuditLogsInput{Filter: query.AuditLogFilter{From: &from, To: &to}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps := newAuditLogQueryTestDeps(t)
+			tt.input.UserID = uuid.New()
+
+			_, err := deps.newListQuery().Execute(context.Background(), tt.input)
+
+			requireAppErrorCode(t, err, apperror.CodeValidationError)
+		})
+	}
+}
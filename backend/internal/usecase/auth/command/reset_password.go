@@ -5,6 +5,8 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
 	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
@@ -18,6 +20,7 @@ type ResetPasswordInput struct {
 
 // ResetPasswordOutput はパスワードリセットの出力を定義します
 type ResetPasswordOutput struct {
+	UserID  uuid.UUID
 	Message string
 }
 
@@ -105,6 +108,7 @@ func (c *ResetPasswordCommand) Execute(ctx context.Context, input ResetPasswordI
 	}
 
 	return &ResetPasswordOutput{
+		UserID:  token.UserID,
 		Message: "password reset successfully",
 	}, nil
 }
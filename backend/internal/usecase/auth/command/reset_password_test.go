@@ -51,6 +51,7 @@ func TestResetPasswordCommand_Execute_ValidToken_ResetsPassword(t *testing.T) {
 
 	require.NoError(t, err)
 	assert.NotNil(t, output)
+	assert.Equal(t, user.ID, output.UserID)
 	assert.Equal(t, "password reset successfully", output.Message)
 }
 
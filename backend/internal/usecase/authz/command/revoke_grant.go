@@ -18,6 +18,7 @@ type RevokeGrantInput struct {
 // RevokeGrantOutput は権限取り消しの出力を定義します
 type RevokeGrantOutput struct {
 	RevokedGrantID uuid.UUID
+	RevokedGrant   *authz.PermissionGrant // 取り消し前の権限付与
 }
 
 // RevokeGrantCommand は権限取り消しコマンドです
@@ -65,5 +66,5 @@ func (c *RevokeGrantCommand) Execute(ctx context.Context, input RevokeGrantInput
 		return nil, err
 	}
 
-	return &RevokeGrantOutput{RevokedGrantID: input.GrantID}, nil
+	return &RevokeGrantOutput{RevokedGrantID: input.GrantID, RevokedGrant: grant}, nil
 }
@@ -65,6 +65,7 @@ func TestRevokeGrantCommand_Execute_ValidGrant_GrantDeleted(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, output)
 	assert.Equal(t, grant.ID, output.RevokedGrantID)
+	assert.Same(t, grant, output.RevokedGrant)
 }
 
 func TestRevokeGrantCommand_Execute_OwnerGrant_ReturnsBadRequest(t *testing.T) {
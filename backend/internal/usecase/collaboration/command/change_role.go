@@ -21,7 +21,8 @@ type ChangeRoleInput struct {
 
 // ChangeRoleOutput はロール変更の出力を定義します
 type ChangeRoleOutput struct {
-	Membership *entity.Membership
+	Membership   *entity.Membership
+	PreviousRole valueobject.GroupRole
 }
 
 // ChangeRoleCommand はロール変更コマンドです
@@ -90,10 +91,11 @@ func (c *ChangeRoleCommand) Execute(ctx context.Context, input ChangeRoleInput)
 	}
 
 	// 8. ロールを変更
+	previousRole := targetMembership.Role
 	targetMembership.ChangeRole(newRole)
 	if err := c.membershipRepo.Update(ctx, targetMembership); err != nil {
 		return nil, err
 	}
 
-	return &ChangeRoleOutput{Membership: targetMembership}, nil
+	return &ChangeRoleOutput{Membership: targetMembership, PreviousRole: previousRole}, nil
 }
@@ -60,6 +60,7 @@ func TestChangeRoleCommand_Execute_OwnerChangesViewerToContributor_Success(t *te
 	require.NoError(t, err)
 	require.NotNil(t, output)
 	assert.Equal(t, valueobject.GroupRoleContributor, output.Membership.Role)
+	assert.Equal(t, valueobject.GroupRoleViewer, output.PreviousRole)
 }
 
 func TestChangeRoleCommand_Execute_CannotChangeToOwner_ValidationError(t *testing.T) {
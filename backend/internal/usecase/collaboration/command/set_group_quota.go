@@ -0,0 +1,100 @@
+package command
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// SetGroupQuotaInput はグループクォータ設定の入力を定義します
+type SetGroupQuotaInput struct {
+	GroupID    uuid.UUID
+	LimitBytes *int64 // nilの場合はクォータを解除
+	SetBy      uuid.UUID
+}
+
+// SetGroupQuotaOutput はグループクォータ設定の出力を定義します
+type SetGroupQuotaOutput struct {
+	Quota              *entity.StorageQuota // 解除した場合はnil
+	PreviousLimitBytes *int64               // 変更前の上限（クォータが無かった場合はnil）
+}
+
+// SetGroupQuotaCommand はグループクォータ設定コマンドです
+// Note: グループクォータはメンバー全員の使用量の合計に対する上限として適用される
+type SetGroupQuotaCommand struct {
+	groupRepo      repository.GroupRepository
+	membershipRepo repository.MembershipRepository
+	quotaRepo      repository.StorageQuotaRepository
+}
+
+// NewSetGroupQuotaCommand は新しいSetGroupQuotaCommandを作成します
+func NewSetGroupQuotaCommand(
+	groupRepo repository.GroupRepository,
+	membershipRepo repository.MembershipRepository,
+	quotaRepo repository.StorageQuotaRepository,
+) *SetGroupQuotaCommand {
+	return &SetGroupQuotaCommand{
+		groupRepo:      groupRepo,
+		membershipRepo: membershipRepo,
+		quotaRepo:      quotaRepo,
+	}
+}
+
+// Execute はグループクォータ設定を実行します
+func (c *SetGroupQuotaCommand) Execute(ctx context.Context, input SetGroupQuotaInput) (*SetGroupQuotaOutput, error) {
+	// 1. グループの存在確認
+	if _, err := c.groupRepo.FindByID(ctx, input.GroupID); err != nil {
+		return nil, apperror.NewNotFoundError("group")
+	}
+
+	// 2. 操作者のメンバーシップ確認（ownerのみ）
+	membership, err := c.membershipRepo.FindByGroupAndUser(ctx, input.GroupID, input.SetBy)
+	if err != nil {
+		return nil, apperror.NewForbiddenError("not a member of this group")
+	}
+	if !membership.IsOwner() {
+		return nil, apperror.NewForbiddenError("insufficient permission to set group quota")
+	}
+
+	// 3. 既存クォータを取得（変更前の上限を出力に含める）
+	quota, err := c.quotaRepo.FindBySubject(ctx, entity.QuotaSubjectGroup, input.GroupID)
+	if err != nil && !apperror.IsNotFound(err) {
+		return nil, err
+	}
+	output := &SetGroupQuotaOutput{}
+	if quota != nil {
+		previous := quota.LimitBytes
+		output.PreviousLimitBytes = &previous
+	}
+
+	// 4. クォータ解除
+	if input.LimitBytes == nil {
+		if err := c.quotaRepo.DeleteBySubject(ctx, entity.QuotaSubjectGroup, input.GroupID); err != nil {
+			return nil, err
+		}
+		return output, nil
+	}
+
+	// 5. 既存クォータの更新または新規作成
+	if quota != nil {
+		if err := quota.UpdateLimit(*input.LimitBytes); err != nil {
+			return nil, apperror.NewValidationError(err.Error(), nil)
+		}
+	} else {
+		quota, err = entity.NewStorageQuota(entity.QuotaSubjectGroup, input.GroupID, *input.LimitBytes)
+		if err != nil {
+			return nil, apperror.NewValidationError(err.Error(), nil)
+		}
+	}
+
+	if err := c.quotaRepo.Upsert(ctx, quota); err != nil {
+		return nil, err
+	}
+
+	output.Quota = quota
+	return output, nil
+}
@@ -0,0 +1,137 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/collaboration/command"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
+)
+
+type setGroupQuotaTestDeps struct {
+	groupRepo      *mocks.MockGroupRepository
+	membershipRepo *mocks.MockMembershipRepository
+	quotaRepo      *mocks.MockStorageQuotaRepository
+}
+
+func newSetGroupQuotaTestDeps(t *testing.T) *setGroupQuotaTestDeps {
+	t.Helper()
+	return &setGroupQuotaTestDeps{
+		groupRepo:      mocks.NewMockGroupRepository(t),
+		membershipRepo: mocks.NewMockMembershipRepository(t),
+		quotaRepo:      mocks.NewMockStorageQuotaRepository(t),
+	}
+}
+
+func (d *setGroupQuotaTestDeps) newCommand() *command.SetGroupQuotaCommand {
+	return command.NewSetGroupQuotaCommand(d.groupRepo, d.membershipRepo, d.quotaRepo)
+}
+
+func TestSetGroupQuotaCommand_Execute_OwnerSetsNewQuota_Success(t *testing.T) {
+	ctx := context.Background()
+	deps := newSetGroupQuotaTestDeps(t)
+
+	ownerID := uuid.New()
+	group := newTestGroup(ownerID)
+	limit := int64(1 << 30)
+
+	deps.groupRepo.On("FindByID", ctx, group.ID).Return(group, nil)
+	deps.membershipRepo.On("FindByGroupAndUser", ctx, group.ID, ownerID).Return(newTestMembership(group.ID, ownerID, valueobject.GroupRoleOwner), nil)
+	deps.quotaRepo.On("FindBySubject", ctx, entity.QuotaSubjectGroup, group.ID).Return(nil, apperror.NewNotFoundError("storage quota"))
+	deps.quotaRepo.On("Upsert", ctx, mock.MatchedBy(func(q *entity.StorageQuota) bool {
+		return q.SubjectType == entity.QuotaSubjectGroup && q.SubjectID == group.ID && q.LimitBytes == limit
+	})).Return(nil)
+
+	output, err := deps.newCommand().Execute(ctx, command.SetGroupQuotaInput{
+		GroupID:    group.ID,
+		LimitBytes: &limit,
+		SetBy:      ownerID,
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, output.Quota)
+	assert.Equal(t, limit, output.Quota.LimitBytes)
+}
+
+func TestSetGroupQuotaCommand_Execute_NilLimit_RemovesQuota(t *testing.T) {
+	ctx := context.Background()
+	deps := newSetGroupQuotaTestDeps(t)
+
+	ownerID := uuid.New()
+	group := newTestGroup(ownerID)
+
+	deps.groupRepo.On("FindByID", ctx, group.ID).Return(group, nil)
+	deps.membershipRepo.On("FindByGroupAndUser", ctx, group.ID, ownerID).Return(newTestMembership(group.ID, ownerID, valueobject.GroupRoleOwner), nil)
+	existing, err := entity.NewStorageQuota(entity.QuotaSubjectGroup, group.ID, 1024)
+	require.NoError(t, err)
+	deps.quotaRepo.On("FindBySubject", ctx, entity.QuotaSubjectGroup, group.ID).Return(existing, nil)
+	deps.quotaRepo.On("DeleteBySubject", ctx, entity.QuotaSubjectGroup, group.ID).Return(nil)
+
+	output, err := deps.newCommand().Execute(ctx, command.SetGroupQuotaInput{
+		GroupID: group.ID,
+		SetBy:   ownerID,
+	})
+
+	require.NoError(t, err)
+	assert.Nil(t, output.Quota)
+	require.NotNil(t, output.PreviousLimitBytes)
+	assert.Equal(t, int64(1024), *output.PreviousLimitBytes)
+}
+
+func TestSetGroupQuotaCommand_Execute_NonOwner_ReturnsForbidden(t *testing.T) {
+	ctx := context.Background()
+	deps := newSetGroupQuotaTestDeps(t)
+
+	group := newTestGroup(uuid.New())
+	contributorID := uuid.New()
+	limit := int64(1024)
+
+	deps.groupRepo.On("FindByID", ctx, group.ID).Return(group, nil)
+	deps.membershipRepo.On("FindByGroupAndUser", ctx, group.ID, contributorID).Return(newTestMembership(group.ID, contributorID, valueobject.GroupRoleContributor), nil)
+
+	output, err := deps.newCommand().Execute(ctx, command.SetGroupQuotaInput{
+		GroupID:    group.ID,
+		LimitBytes: &limit,
+		SetBy:      contributorID,
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeForbidden, appErr.Code)
+}
+
+func TestSetGroupQuotaCommand_Execute_NegativeLimit_ReturnsValidationError(t *testing.T) {
+	ctx := context.Background()
+	deps := newSetGroupQuotaTestDeps(t)
+
+	ownerID := uuid.New()
+	group := newTestGroup(ownerID)
+	limit := int64(-1)
+
+	deps.groupRepo.On("FindByID", ctx, group.ID).Return(group, nil)
+	deps.membershipRepo.On("FindByGroupAndUser", ctx, group.ID, ownerID).Return(newTestMembership(group.ID, ownerID, valueobject.GroupRoleOwner), nil)
+	deps.quotaRepo.On("FindBySubject", ctx, entity.QuotaSubjectGroup, group.ID).Return(nil, apperror.NewNotFoundError("storage quota"))
+
+	output, err := deps.newCommand().Execute(ctx, command.SetGroupQuotaInput{
+		GroupID:    group.ID,
+		LimitBytes: &limit,
+		SetBy:      ownerID,
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeValidationError, appErr.Code)
+}
@@ -21,7 +21,9 @@ type UpdateGroupInput struct {
 
 // UpdateGroupOutput はグループ更新の出力を定義します
 type UpdateGroupOutput struct {
-	Group *entity.Group
+	Group               *entity.Group
+	PreviousName        string
+	PreviousDescription string
 }
 
 // UpdateGroupCommand はグループ更新コマンドです
@@ -59,6 +61,10 @@ func (c *UpdateGroupCommand) Execute(ctx context.Context, input UpdateGroupInput
 	}
 
 	// 3. グループ名の更新
+	output := &UpdateGroupOutput{
+		PreviousName:        group.Name.String(),
+		PreviousDescription: group.Description,
+	}
 	if input.Name != nil {
 		newName, err := valueobject.NewGroupName(*input.Name)
 		if err != nil {
@@ -77,5 +83,6 @@ func (c *UpdateGroupCommand) Execute(ctx context.Context, input UpdateGroupInput
 		return nil, err
 	}
 
-	return &UpdateGroupOutput{Group: group}, nil
+	output.Group = group
+	return output, nil
 }
@@ -57,6 +57,7 @@ func TestUpdateGroupCommand_Execute_OwnerUpdatesName_Success(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, output)
 	assert.Equal(t, "New Name", output.Group.Name.String())
+	assert.Equal(t, "Test Group", output.PreviousName)
 }
 
 func TestUpdateGroupCommand_Execute_NonOwnerUpdates_ForbiddenError(t *testing.T) {
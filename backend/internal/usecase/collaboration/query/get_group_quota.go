@@ -0,0 +1,78 @@
+package query
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// GetGroupQuotaInput はグループクォータ取得の入力を定義します
+type GetGroupQuotaInput struct {
+	GroupID uuid.UUID
+	UserID  uuid.UUID
+}
+
+// GetGroupQuotaOutput はグループクォータ取得の出力を定義します
+type GetGroupQuotaOutput struct {
+	GroupID    uuid.UUID
+	LimitBytes *int64 // 未設定の場合はnil
+	UsedBytes  int64  // メンバー全員の使用量の合計
+}
+
+// GetGroupQuotaQuery はグループクォータ取得クエリです
+type GetGroupQuotaQuery struct {
+	groupRepo      repository.GroupRepository
+	membershipRepo repository.MembershipRepository
+	quotaRepo      repository.StorageQuotaRepository
+	usageRepo      repository.StorageUsageRepository
+}
+
+// NewGetGroupQuotaQuery は新しいGetGroupQuotaQueryを作成します
+func NewGetGroupQuotaQuery(
+	groupRepo repository.GroupRepository,
+	membershipRepo repository.MembershipRepository,
+	quotaRepo repository.StorageQuotaRepository,
+	usageRepo repository.StorageUsageRepository,
+) *GetGroupQuotaQuery {
+	return &GetGroupQuotaQuery{
+		groupRepo:      groupRepo,
+		membershipRepo: membershipRepo,
+		quotaRepo:      quotaRepo,
+		usageRepo:      usageRepo,
+	}
+}
+
+// Execute はグループクォータ取得を実行します
+func (q *GetGroupQuotaQuery) Execute(ctx context.Context, input GetGroupQuotaInput) (*GetGroupQuotaOutput, error) {
+	// 1. グループの取得
+	if _, err := q.groupRepo.FindByID(ctx, input.GroupID); err != nil {
+		return nil, err
+	}
+
+	// 2. ユーザーのメンバーシップ確認
+	if _, err := q.membershipRepo.FindByGroupAndUser(ctx, input.GroupID, input.UserID); err != nil {
+		return nil, apperror.NewForbiddenError("you are not a member of this group")
+	}
+
+	// 3. クォータと使用量を取得
+	output := &GetGroupQuotaOutput{GroupID: input.GroupID}
+
+	quota, err := q.quotaRepo.FindBySubject(ctx, entity.QuotaSubjectGroup, input.GroupID)
+	if err != nil && !apperror.IsNotFound(err) {
+		return nil, err
+	}
+	if quota != nil {
+		output.LimitBytes = &quota.LimitBytes
+	}
+
+	output.UsedBytes, err = q.usageRepo.SumUsedBytesByGroup(ctx, input.GroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
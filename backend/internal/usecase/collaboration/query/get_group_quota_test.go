@@ -0,0 +1,96 @@
+package query_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/collaboration/query"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
+)
+
+type getGroupQuotaTestDeps struct {
+	groupRepo      *mocks.MockGroupRepository
+	membershipRepo *mocks.MockMembershipRepository
+	quotaRepo      *mocks.MockStorageQuotaRepository
+	usageRepo      *mocks.MockStorageUsageRepository
+}
+
+func newGetGroupQuotaTestDeps(t *testing.T) *getGroupQuotaTestDeps {
+	t.Helper()
+	return &getGroupQuotaTestDeps{
+		groupRepo:      mocks.NewMockGroupRepository(t),
+		membershipRepo: mocks.NewMockMembershipRepository(t),
+		quotaRepo:      mocks.NewMockStorageQuotaRepository(t),
+		usageRepo:      mocks.NewMockStorageUsageRepository(t),
+	}
+}
+
+func (d *getGroupQuotaTestDeps) newQuery() *query.GetGroupQuotaQuery {
+	return query.NewGetGroupQuotaQuery(d.groupRepo, d.membershipRepo, d.quotaRepo, d.usageRepo)
+}
+
+func TestGetGroupQuotaQuery_Execute_MemberRequests_ReturnsLimitAndUsage(t *testing.T) {
+	ctx := context.Background()
+	deps := newGetGroupQuotaTestDeps(t)
+
+	userID := uuid.New()
+	group := newTestGroup(uuid.New())
+	quota, _ := entity.NewStorageQuota(entity.QuotaSubjectGroup, group.ID, 4096)
+
+	deps.groupRepo.On("FindByID", ctx, group.ID).Return(group, nil)
+	deps.membershipRepo.On("FindByGroupAndUser", ctx, group.ID, userID).Return(newTestMembership(group.ID, userID, valueobject.GroupRoleViewer), nil)
+	deps.quotaRepo.On("FindBySubject", ctx, entity.QuotaSubjectGroup, group.ID).Return(quota, nil)
+	deps.usageRepo.On("SumUsedBytesByGroup", ctx, group.ID).Return(int64(1024), nil)
+
+	output, err := deps.newQuery().Execute(ctx, query.GetGroupQuotaInput{GroupID: group.ID, UserID: userID})
+
+	require.NoError(t, err)
+	require.NotNil(t, output.LimitBytes)
+	assert.Equal(t, int64(4096), *output.LimitBytes)
+	assert.Equal(t, int64(1024), output.UsedBytes)
+}
+
+func TestGetGroupQuotaQuery_Execute_NoQuota_ReturnsNilLimit(t *testing.T) {
+	ctx := context.Background()
+	deps := newGetGroupQuotaTestDeps(t)
+
+	userID := uuid.New()
+	group := newTestGroup(uuid.New())
+
+	deps.groupRepo.On("FindByID", ctx, group.ID).Return(group, nil)
+	deps.membershipRepo.On("FindByGroupAndUser", ctx, group.ID, userID).Return(newTestMembership(group.ID, userID, valueobject.GroupRoleViewer), nil)
+	deps.quotaRepo.On("FindBySubject", ctx, entity.QuotaSubjectGroup, group.ID).Return(nil, apperror.NewNotFoundError("storage quota"))
+	deps.usageRepo.On("SumUsedBytesByGroup", ctx, group.ID).Return(int64(0), nil)
+
+	output, err := deps.newQuery().Execute(ctx, query.GetGroupQuotaInput{GroupID: group.ID, UserID: userID})
+
+	require.NoError(t, err)
+	assert.Nil(t, output.LimitBytes)
+}
+
+func TestGetGroupQuotaQuery_Execute_NonMember_ReturnsForbidden(t *testing.T) {
+	ctx := context.Background()
+	deps := newGetGroupQuotaTestDeps(t)
+
+	userID := uuid.New()
+	group := newTestGroup(uuid.New())
+
+	deps.groupRepo.On("FindByID", ctx, group.ID).Return(group, nil)
+	deps.membershipRepo.On("FindByGroupAndUser", ctx, group.ID, userID).Return(nil, apperror.NewNotFoundError("membership"))
+
+	output, err := deps.newQuery().Execute(ctx, query.GetGroupQuotaInput{GroupID: group.ID, UserID: userID})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeForbidden, appErr.Code)
+}
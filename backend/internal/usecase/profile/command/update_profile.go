@@ -25,7 +25,8 @@ type UpdateProfileInput struct {
 
 // UpdateProfileOutput はプロファイル更新の出力を定義します
 type UpdateProfileOutput struct {
-	Profile *entity.UserProfile
+	Profile  *entity.UserProfile
+	Previous entity.UserProfile // 更新前のプロファイル
 }
 
 // UpdateProfileCommand はプロファイル更新コマンドです
@@ -63,6 +64,8 @@ func (c *UpdateProfileCommand) Execute(ctx context.Context, input UpdateProfileI
 		}
 	}
 
+	previous := *profile
+
 	// フィールドを更新
 	// AvatarURL, Bio, Locale, Timezone はエンティティにセッターがないため、
 	// 直接代入後に UpdatedAt を更新する。
@@ -109,5 +112,5 @@ func (c *UpdateProfileCommand) Execute(ctx context.Context, input UpdateProfileI
 		return nil, apperror.NewInternalError(err)
 	}
 
-	return &UpdateProfileOutput{Profile: profile}, nil
+	return &UpdateProfileOutput{Profile: profile, Previous: previous}, nil
 }
@@ -99,6 +99,7 @@ func TestUpdateProfileCommand_Execute_PartialUpdate_OnlyBio(t *testing.T) {
 	require.NotNil(t, output)
 	assert.Equal(t, bio, output.Profile.Bio)
 	assert.Equal(t, "https://example.com/old-avatar.png", output.Profile.AvatarURL)
+	assert.Equal(t, "", output.Previous.Bio)
 }
 
 func TestUpdateProfileCommand_Execute_BioTooLong_ReturnsValidationError(t *testing.T) {
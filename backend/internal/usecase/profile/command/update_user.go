@@ -19,7 +19,8 @@ type UpdateUserInput struct {
 
 // UpdateUserOutput はユーザー更新の出力を定義します
 type UpdateUserOutput struct {
-	User *entity.User
+	User         *entity.User
+	PreviousName string // 更新前の表示名
 }
 
 // UpdateUserCommand はユーザー情報更新コマンドです
@@ -48,6 +49,7 @@ func (c *UpdateUserCommand) Execute(ctx context.Context, input UpdateUserInput)
 		return nil, apperror.NewNotFoundError("user")
 	}
 
+	previousName := user.Name
 	if input.Name != nil {
 		user.Name = *input.Name
 		user.UpdatedAt = time.Now()
@@ -56,5 +58,5 @@ func (c *UpdateUserCommand) Execute(ctx context.Context, input UpdateUserInput)
 		}
 	}
 
-	return &UpdateUserOutput{User: user}, nil
+	return &UpdateUserOutput{User: user, PreviousName: previousName}, nil
 }
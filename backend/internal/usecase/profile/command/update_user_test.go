@@ -55,6 +55,7 @@ func TestUpdateUserCommand_Execute_Success(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, output)
 	assert.Equal(t, name, output.User.Name)
+	assert.Equal(t, "Old Name", output.PreviousName)
 }
 
 func TestUpdateUserCommand_Execute_EmptyName_ReturnsValidationError(t *testing.T) {
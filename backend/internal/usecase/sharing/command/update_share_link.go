@@ -25,6 +25,11 @@ type UpdateShareLinkInput struct {
 // UpdateShareLinkOutput は共有リンク更新の出力を定義します
 type UpdateShareLinkOutput struct {
 	ShareLink *entity.ShareLink
+
+	// 更新前の設定
+	PreviousExpiresAt        *time.Time
+	PreviousMaxAccessCount   *int
+	PreviousRequiresPassword bool
 }
 
 // UpdateShareLinkCommand は共有リンク更新コマンドです
@@ -76,6 +81,11 @@ func (c *UpdateShareLinkCommand) Execute(ctx context.Context, input UpdateShareL
 	}
 
 	// 4. フィールド更新
+	output := &UpdateShareLinkOutput{
+		PreviousExpiresAt:        shareLink.ExpiresAt,
+		PreviousMaxAccessCount:   shareLink.MaxAccessCount,
+		PreviousRequiresPassword: shareLink.RequiresPassword(),
+	}
 	if input.ExpiresAt != nil {
 		shareLink.UpdateExpiry(input.ExpiresAt)
 	}
@@ -99,5 +109,6 @@ func (c *UpdateShareLinkCommand) Execute(ctx context.Context, input UpdateShareL
 		return nil, err
 	}
 
-	return &UpdateShareLinkOutput{ShareLink: shareLink}, nil
+	output.ShareLink = shareLink
+	return output, nil
 }
@@ -78,6 +78,8 @@ func TestUpdateShareLinkCommand_Execute_ValidInput_UpdatesExpiryAndMaxAccess(t *
 	assert.Equal(t, shareLink.ID, output.ShareLink.ID)
 	assert.Equal(t, &newExpiry, output.ShareLink.ExpiresAt)
 	assert.Equal(t, &newMax, output.ShareLink.MaxAccessCount)
+	assert.Nil(t, output.PreviousExpiresAt)
+	assert.Nil(t, output.PreviousMaxAccessCount)
 }
 
 func TestUpdateShareLinkCommand_Execute_WithPassword_HashesPassword(t *testing.T) {
@@ -100,6 +102,7 @@ func TestUpdateShareLinkCommand_Execute_WithPassword_HashesPassword(t *testing.T
 	output, err := cmd.Execute(ctx, input)
 
 	require.NoError(t, err)
+	assert.False(t, output.PreviousRequiresPassword)
 	assert.NotEmpty(t, output.ShareLink.PasswordHash)
 	err = bcrypt.CompareHashAndPassword([]byte(output.ShareLink.PasswordHash), []byte(newPassword))
 	assert.NoError(t, err)
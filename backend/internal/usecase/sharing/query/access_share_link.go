@@ -200,12 +200,12 @@ func (q *AccessShareLinkQuery) fetchResourceInfo(ctx context.Context, shareLink
 		}
 
 		// 最新バージョンを取得してPresignedURLを生成
-		_, err = q.fileVersionRepo.FindLatestByFileID(ctx, file.ID)
+		version, err := q.fileVersionRepo.FindLatestByFileID(ctx, file.ID)
 		if err != nil {
 			return "", nil, nil, err
 		}
 
-		presigned, err := q.storageService.GenerateGetURL(ctx, file.StorageKey.String(), DownloadPresignedURLExpiry)
+		presigned, err := q.storageService.GenerateGetURL(ctx, version.StorageKey.String(), version.MinioVersionID, DownloadPresignedURLExpiry)
 		if err != nil {
 			return "", nil, nil, apperror.NewInternalError(err)
 		}
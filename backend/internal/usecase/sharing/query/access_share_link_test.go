@@ -120,7 +120,7 @@ func TestAccessShareLinkQuery_Execute_DownloadAction_FileShare_ReturnsPresignedU
 	deps.shareLinkRepo.On("FindByToken", ctx, token).Return(shareLink, nil)
 	deps.fileRepo.On("FindByID", ctx, fileID).Return(file, nil)
 	deps.fileVersionRepo.On("FindLatestByFileID", ctx, fileID).Return(fileVersion, nil)
-	deps.storageService.On("GenerateGetURL", ctx, file.StorageKey.String(), query.DownloadPresignedURLExpiry).Return(presignedURL, nil)
+	deps.storageService.On("GenerateGetURL", ctx, fileVersion.StorageKey.String(), fileVersion.MinioVersionID, query.DownloadPresignedURLExpiry).Return(presignedURL, nil)
 	deps.shareLinkRepo.On("Update", ctx, shareLink).Return(nil)
 	deps.shareLinkAccessRepo.On("Create", ctx, mock.AnythingOfType("*entity.ShareLinkAccess")).Return(nil).Maybe()
 
@@ -209,7 +209,7 @@ func TestAccessShareLinkQuery_Execute_DownloadAction_IncreasesAccessCount(t *tes
 	deps.shareLinkRepo.On("FindByToken", ctx, token).Return(shareLink, nil)
 	deps.fileRepo.On("FindByID", ctx, fileID).Return(file, nil)
 	deps.fileVersionRepo.On("FindLatestByFileID", ctx, fileID).Return(fileVersion, nil)
-	deps.storageService.On("GenerateGetURL", ctx, file.StorageKey.String(), query.DownloadPresignedURLExpiry).Return(presignedURL, nil)
+	deps.storageService.On("GenerateGetURL", ctx, fileVersion.StorageKey.String(), fileVersion.MinioVersionID, query.DownloadPresignedURLExpiry).Return(presignedURL, nil)
 	deps.shareLinkRepo.On("Update", ctx, shareLink).Return(nil)
 	deps.shareLinkAccessRepo.On("Create", ctx, mock.AnythingOfType("*entity.ShareLinkAccess")).Return(nil).Maybe()
 
@@ -31,6 +31,8 @@ type GetDownloadViaShareInput struct {
 
 // GetDownloadViaShareOutput は共有リンク経由ダウンロードの出力を定義します
 type GetDownloadViaShareOutput struct {
+	ShareLinkID  uuid.UUID
+	FileID       uuid.UUID
 	PresignedURL string
 	FileName     string
 	FileSize     int64
@@ -119,7 +121,7 @@ func (q *GetDownloadViaShareQuery) Execute(ctx context.Context, input GetDownloa
 		targetFileID = *input.FileID
 
 		// ファイルがフォルダのサブツリーに属することを確認
-		if err := q.verifyFileInFolderSubtree(ctx, shareLink.ResourceID, targetFileID); err != nil {
+		if err := verifyFileInFolderSubtree(ctx, q.folderClosureRepo, q.fileRepo, shareLink.ResourceID, targetFileID); err != nil {
 			return nil, err
 		}
 	}
@@ -135,13 +137,13 @@ func (q *GetDownloadViaShareQuery) Execute(ctx context.Context, input GetDownloa
 	}
 
 	// 8. 最新バージョンを取得
-	_, err = q.fileVersionRepo.FindLatestByFileID(ctx, file.ID)
+	version, err := q.fileVersionRepo.FindLatestByFileID(ctx, file.ID)
 	if err != nil {
 		return nil, err
 	}
 
 	// 9. PresignedURLを生成
-	presignedURL, err := q.storageService.GenerateGetURL(ctx, file.StorageKey.String(), DownloadPresignedURLExpiry)
+	presignedURL, err := q.storageService.GenerateGetURL(ctx, version.StorageKey.String(), version.MinioVersionID, DownloadPresignedURLExpiry)
 	if err != nil {
 		return nil, apperror.NewInternalError(err)
 	}
@@ -165,6 +167,8 @@ func (q *GetDownloadViaShareQuery) Execute(ctx context.Context, input GetDownloa
 	}
 
 	return &GetDownloadViaShareOutput{
+		ShareLinkID:  shareLink.ID,
+		FileID:       file.ID,
 		PresignedURL: presignedURL.URL,
 		FileName:     file.Name.String(),
 		FileSize:     file.Size,
@@ -174,15 +178,20 @@ func (q *GetDownloadViaShareQuery) Execute(ctx context.Context, input GetDownloa
 }
 
 // verifyFileInFolderSubtree はファイルが共有フォルダのサブツリーに属することを確認します
-func (q *GetDownloadViaShareQuery) verifyFileInFolderSubtree(ctx context.Context, folderID, fileID uuid.UUID) error {
+func verifyFileInFolderSubtree(
+	ctx context.Context,
+	folderClosureRepo repository.FolderClosureRepository,
+	fileRepo repository.FileRepository,
+	folderID, fileID uuid.UUID,
+) error {
 	// フォルダのすべての子孫IDを取得（自身を含む）
-	descendantIDs, err := q.folderClosureRepo.FindDescendantIDs(ctx, folderID)
+	descendantIDs, err := folderClosureRepo.FindDescendantIDs(ctx, folderID)
 	if err != nil {
 		return err
 	}
 
 	// ファイルを取得してフォルダIDを確認
-	file, err := q.fileRepo.FindByID(ctx, fileID)
+	file, err := fileRepo.FindByID(ctx, fileID)
 	if err != nil {
 		return err
 	}
@@ -92,10 +92,13 @@ func buildFileVersion(fileID uuid.UUID) *entity.FileVersion {
 		uuid.New(),
 		fileID,
 		1,
+		valueobject.NewStorageKey(fileID),
 		"minio-version-id",
+		nil,
 		1024,
 		"checksum",
 		uuid.New(),
+		false,
 		time.Now(),
 	)
 }
@@ -123,7 +126,7 @@ func TestGetDownloadViaShareQuery_Execute_FileShareLink_ReturnsPresignedURL(t *t
 	deps.shareLinkRepo.On("FindByToken", ctx, token).Return(shareLink, nil)
 	deps.fileRepo.On("FindByID", ctx, fileID).Return(file, nil)
 	deps.fileVersionRepo.On("FindLatestByFileID", ctx, fileID).Return(fileVersion, nil)
-	deps.storageService.On("GenerateGetURL", ctx, file.StorageKey.String(), query.DownloadPresignedURLExpiry).Return(presignedURL, nil)
+	deps.storageService.On("GenerateGetURL", ctx, fileVersion.StorageKey.String(), fileVersion.MinioVersionID, query.DownloadPresignedURLExpiry).Return(presignedURL, nil)
 	deps.shareLinkRepo.On("Update", ctx, shareLink).Return(nil)
 	deps.shareLinkAccessRepo.On("Create", ctx, mock.AnythingOfType("*entity.ShareLinkAccess")).Return(nil).Maybe()
 
@@ -132,6 +135,8 @@ func TestGetDownloadViaShareQuery_Execute_FileShareLink_ReturnsPresignedURL(t *t
 
 	require.NoError(t, err)
 	assert.NotNil(t, output)
+	assert.Equal(t, shareLink.ID, output.ShareLinkID)
+	assert.Equal(t, fileID, output.FileID)
 	assert.Equal(t, presignedURL.URL, output.PresignedURL)
 	assert.Equal(t, file.Name.String(), output.FileName)
 }
@@ -290,7 +295,7 @@ func TestGetDownloadViaShareQuery_Execute_FolderShareWithFileID_ReturnsPresigned
 	deps.folderClosureRepo.On("FindDescendantIDs", ctx, folderID).Return([]uuid.UUID{folderID}, nil)
 	deps.fileRepo.On("FindByID", ctx, fileID).Return(file, nil)
 	deps.fileVersionRepo.On("FindLatestByFileID", ctx, fileID).Return(fileVersion, nil)
-	deps.storageService.On("GenerateGetURL", ctx, file.StorageKey.String(), query.DownloadPresignedURLExpiry).Return(presignedURL, nil)
+	deps.storageService.On("GenerateGetURL", ctx, fileVersion.StorageKey.String(), fileVersion.MinioVersionID, query.DownloadPresignedURLExpiry).Return(presignedURL, nil)
 	deps.shareLinkRepo.On("Update", ctx, shareLink).Return(nil)
 	deps.shareLinkAccessRepo.On("Create", ctx, mock.AnythingOfType("*entity.ShareLinkAccess")).Return(nil).Maybe()
 
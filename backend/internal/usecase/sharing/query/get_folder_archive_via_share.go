@@ -0,0 +1,149 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// GetFolderArchiveViaShareInput は共有リンク経由フォルダアーカイブ取得の入力を定義します
+type GetFolderArchiveViaShareInput struct {
+	Token     string
+	Password  string     // optional
+	UserID    *uuid.UUID // optional
+	IPAddress string
+	UserAgent string
+}
+
+// GetFolderArchiveViaShareOutput は共有リンク経由フォルダアーカイブ取得の出力を定義します
+// ZIPの内容は WriteZip を呼び出した時点でストレージからストリーミングされます
+type GetFolderArchiveViaShareOutput struct {
+	ShareLinkID uuid.UUID
+	FolderID    uuid.UUID
+	ArchiveName string
+	Entries     []service.ArchiveEntry
+
+	archiveService service.FolderArchiveService
+}
+
+// WriteZip はアーカイブをZIPとしてwに書き込みます
+func (o *GetFolderArchiveViaShareOutput) WriteZip(ctx context.Context, w io.Writer) error {
+	return o.archiveService.WriteZip(ctx, w, o.Entries)
+}
+
+// GetFolderArchiveViaShareQuery は共有リンク経由でフォルダをZIPアーカイブとしてダウンロードするクエリです
+type GetFolderArchiveViaShareQuery struct {
+	shareLinkRepo       repository.ShareLinkRepository
+	shareLinkAccessRepo repository.ShareLinkAccessRepository
+	folderRepo          repository.FolderRepository
+	archiveService      service.FolderArchiveService
+}
+
+// NewGetFolderArchiveViaShareQuery は新しいGetFolderArchiveViaShareQueryを作成します
+func NewGetFolderArchiveViaShareQuery(
+	shareLinkRepo repository.ShareLinkRepository,
+	shareLinkAccessRepo repository.ShareLinkAccessRepository,
+	folderRepo repository.FolderRepository,
+	archiveService service.FolderArchiveService,
+) *GetFolderArchiveViaShareQuery {
+	return &GetFolderArchiveViaShareQuery{
+		shareLinkRepo:       shareLinkRepo,
+		shareLinkAccessRepo: shareLinkAccessRepo,
+		folderRepo:          folderRepo,
+		archiveService:      archiveService,
+	}
+}
+
+// Execute は共有リンク経由でアーカイブに含めるエントリを収集します
+// 共有リンクはフォルダのサブツリー全体へのアクセスを許可するため、アイテムごとの権限チェックは行いません
+func (q *GetFolderArchiveViaShareQuery) Execute(ctx context.Context, input GetFolderArchiveViaShareInput) (*GetFolderArchiveViaShareOutput, error) {
+	// 1. トークンのバリデーション
+	token, err := valueobject.ReconstructShareToken(input.Token)
+	if err != nil {
+		return nil, apperror.NewValidationError("invalid share link token", nil)
+	}
+
+	// 2. 共有リンクを取得
+	shareLink, err := q.shareLinkRepo.FindByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. アクセス可能か確認
+	if err := shareLink.CanAccess(); err != nil {
+		if errors.Is(err, entity.ErrShareLinkExpired) || errors.Is(err, entity.ErrShareLinkRevoked) || errors.Is(err, entity.ErrShareLinkMaxAccessReached) {
+			return nil, apperror.NewGoneError(err.Error())
+		}
+		return nil, apperror.NewForbiddenError(err.Error())
+	}
+
+	// 4. ダウンロード権限チェック
+	if !shareLink.CanDownload() {
+		return nil, apperror.NewForbiddenError("download is not allowed with this share link")
+	}
+
+	// 5. パスワード確認（必要な場合）
+	if shareLink.RequiresPassword() {
+		if input.Password == "" {
+			return nil, apperror.NewUnauthorizedError("password is required")
+		}
+		comparePassword := func(hash, password string) error {
+			return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+		}
+		if err := shareLink.ValidatePassword(input.Password, comparePassword); err != nil {
+			return nil, apperror.NewUnauthorizedError("invalid password")
+		}
+	}
+
+	// 6. フォルダ共有のみ対象
+	if shareLink.ResourceType != authz.ResourceTypeFolder {
+		return nil, apperror.NewValidationError("archive download is only available for folder share links", nil)
+	}
+
+	folder, err := q.folderRepo.FindByID(ctx, shareLink.ResourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 7. サブツリー全体のエントリを収集
+	entries, err := q.archiveService.CollectEntries(ctx, folder, service.ArchiveFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	// 8. アクセスカウントを増やす
+	shareLink.IncrementAccessCount()
+	if err := q.shareLinkRepo.Update(ctx, shareLink); err != nil {
+		return nil, err
+	}
+
+	// 9. アクセスログを記録（失敗は無視）
+	access, err := entity.NewShareLinkAccess(
+		shareLink.ID,
+		input.IPAddress,
+		input.UserAgent,
+		input.UserID,
+		entity.AccessActionDownload,
+	)
+	if err == nil {
+		_ = q.shareLinkAccessRepo.Create(ctx, access)
+	}
+
+	return &GetFolderArchiveViaShareOutput{
+		ShareLinkID:    shareLink.ID,
+		FolderID:       folder.ID,
+		ArchiveName:    folder.Name.String() + ".zip",
+		Entries:        entries,
+		archiveService: q.archiveService,
+	}, nil
+}
@@ -0,0 +1,123 @@
+package query_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/sharing/query"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
+)
+
+type getFolderArchiveViaShareTestDeps struct {
+	shareLinkRepo       *mocks.MockShareLinkRepository
+	shareLinkAccessRepo *mocks.MockShareLinkAccessRepository
+	folderRepo          *mocks.MockFolderRepository
+	archiveService      *mocks.MockFolderArchiveService
+}
+
+func newGetFolderArchiveViaShareTestDeps(t *testing.T) *getFolderArchiveViaShareTestDeps {
+	t.Helper()
+	return &getFolderArchiveViaShareTestDeps{
+		shareLinkRepo:       mocks.NewMockShareLinkRepository(t),
+		shareLinkAccessRepo: mocks.NewMockShareLinkAccessRepository(t),
+		folderRepo:          mocks.NewMockFolderRepository(t),
+		archiveService:      mocks.NewMockFolderArchiveService(t),
+	}
+}
+
+func (d *getFolderArchiveViaShareTestDeps) newQuery() *query.GetFolderArchiveViaShareQuery {
+	return query.NewGetFolderArchiveViaShareQuery(
+		d.shareLinkRepo,
+		d.shareLinkAccessRepo,
+		d.folderRepo,
+		d.archiveService,
+	)
+}
+
+func buildSharedFolder(folderID uuid.UUID) *entity.Folder {
+	name, _ := valueobject.NewFolderName("shared")
+	ownerID := uuid.New()
+	return entity.ReconstructFolder(
+		folderID, name, nil, ownerID, ownerID, 0,
+		entity.FolderStatusActive, time.Now(), time.Now(),
+	)
+}
+
+func TestGetFolderArchiveViaShareQuery_Execute_FolderShareLink_CollectsWholeSubtree(t *testing.T) {
+	ctx := context.Background()
+	deps := newGetFolderArchiveViaShareTestDeps(t)
+
+	folder := buildSharedFolder(uuid.New())
+	shareLink := buildFolderShareLink(folder.ID)
+	entries := []service.ArchiveEntry{{Path: "shared/"}}
+
+	deps.shareLinkRepo.On("FindByToken", ctx, shareLink.Token).Return(shareLink, nil)
+	deps.folderRepo.On("FindByID", ctx, folder.ID).Return(folder, nil)
+	deps.archiveService.On("CollectEntries", ctx, folder, mock.MatchedBy(func(f service.ArchiveFilter) bool {
+		return f.Folder == nil && f.File == nil
+	})).Return(entries, nil)
+	deps.shareLinkRepo.On("Update", ctx, shareLink).Return(nil)
+	deps.shareLinkAccessRepo.On("Create", ctx, mock.AnythingOfType("*entity.ShareLinkAccess")).Return(nil).Maybe()
+
+	output, err := deps.newQuery().Execute(ctx, query.GetFolderArchiveViaShareInput{
+		Token:     shareLink.Token.String(),
+		IPAddress: "127.0.0.1",
+		UserAgent: "test-agent",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, shareLink.ID, output.ShareLinkID)
+	assert.Equal(t, folder.ID, output.FolderID)
+	assert.Equal(t, "shared.zip", output.ArchiveName)
+	assert.Equal(t, entries, output.Entries)
+	assert.Equal(t, 1, shareLink.AccessCount)
+
+	var buf bytes.Buffer
+	deps.archiveService.On("WriteZip", ctx, &buf, entries).Return(nil)
+	require.NoError(t, output.WriteZip(ctx, &buf))
+}
+
+func TestGetFolderArchiveViaShareQuery_Execute_FileShareLink_ReturnsValidationError(t *testing.T) {
+	ctx := context.Background()
+	deps := newGetFolderArchiveViaShareTestDeps(t)
+
+	shareLink := buildFileShareLink(uuid.New())
+	deps.shareLinkRepo.On("FindByToken", ctx, shareLink.Token).Return(shareLink, nil)
+
+	output, err := deps.newQuery().Execute(ctx, query.GetFolderArchiveViaShareInput{Token: shareLink.Token.String()})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	appErr, ok := err.(*apperror.AppError)
+	require.True(t, ok)
+	assert.Equal(t, apperror.CodeValidationError, appErr.Code)
+}
+
+func TestGetFolderArchiveViaShareQuery_Execute_ExpiredLink_ReturnsGone(t *testing.T) {
+	ctx := context.Background()
+	deps := newGetFolderArchiveViaShareTestDeps(t)
+
+	shareLink := buildFolderShareLink(uuid.New())
+	expiredAt := time.Now().Add(-time.Hour)
+	shareLink.ExpiresAt = &expiredAt
+	deps.shareLinkRepo.On("FindByToken", ctx, shareLink.Token).Return(shareLink, nil)
+
+	output, err := deps.newQuery().Execute(ctx, query.GetFolderArchiveViaShareInput{Token: shareLink.Token.String()})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	appErr, ok := err.(*apperror.AppError)
+	require.True(t, ok)
+	assert.Equal(t, apperror.CodeGone, appErr.Code)
+}
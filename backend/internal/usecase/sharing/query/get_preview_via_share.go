@@ -0,0 +1,174 @@
+package query
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// GetPreviewViaShareInput は共有リンク経由プレビューの入力を定義します
+type GetPreviewViaShareInput struct {
+	Token     string
+	Password  string     // optional
+	FileID    *uuid.UUID // required for folder shares, ignored for file shares
+	MaxBytes  int        // 0以下の場合は既定値
+	UserID    *uuid.UUID // optional
+	IPAddress string
+	UserAgent string
+}
+
+// GetPreviewViaShareOutput は共有リンク経由プレビューの出力を定義します
+type GetPreviewViaShareOutput struct {
+	ShareLinkID   uuid.UUID
+	FileID        uuid.UUID
+	FileName      string
+	MimeType      string
+	Size          int64
+	VersionNumber int
+	Preview       *service.FilePreview
+}
+
+// GetPreviewViaShareQuery は共有リンク経由でファイルをダウンロードせずにプレビューするクエリです
+type GetPreviewViaShareQuery struct {
+	shareLinkRepo       repository.ShareLinkRepository
+	shareLinkAccessRepo repository.ShareLinkAccessRepository
+	fileRepo            repository.FileRepository
+	fileVersionRepo     repository.FileVersionRepository
+	folderClosureRepo   repository.FolderClosureRepository
+	filePreviewService  service.FilePreviewService
+}
+
+// NewGetPreviewViaShareQuery は新しいGetPreviewViaShareQueryを作成します
+func NewGetPreviewViaShareQuery(
+	shareLinkRepo repository.ShareLinkRepository,
+	shareLinkAccessRepo repository.ShareLinkAccessRepository,
+	fileRepo repository.FileRepository,
+	fileVersionRepo repository.FileVersionRepository,
+	folderClosureRepo repository.FolderClosureRepository,
+	filePreviewService service.FilePreviewService,
+) *GetPreviewViaShareQuery {
+	return &GetPreviewViaShareQuery{
+		shareLinkRepo:       shareLinkRepo,
+		shareLinkAccessRepo: shareLinkAccessRepo,
+		fileRepo:            fileRepo,
+		fileVersionRepo:     fileVersionRepo,
+		folderClosureRepo:   folderClosureRepo,
+		filePreviewService:  filePreviewService,
+	}
+}
+
+// Execute は共有リンク経由でプレビューを取得します
+// プレビューもファイルの内容を開示するため、ダウンロードと同様にアクセス回数に含めます
+func (q *GetPreviewViaShareQuery) Execute(ctx context.Context, input GetPreviewViaShareInput) (*GetPreviewViaShareOutput, error) {
+	// 1. トークンのバリデーション
+	token, err := valueobject.ReconstructShareToken(input.Token)
+	if err != nil {
+		return nil, apperror.NewValidationError("invalid share link token", nil)
+	}
+
+	// 2. 共有リンクを取得
+	shareLink, err := q.shareLinkRepo.FindByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. アクセス可能か確認
+	if err := shareLink.CanAccess(); err != nil {
+		if errors.Is(err, entity.ErrShareLinkExpired) || errors.Is(err, entity.ErrShareLinkRevoked) || errors.Is(err, entity.ErrShareLinkMaxAccessReached) {
+			return nil, apperror.NewGoneError(err.Error())
+		}
+		return nil, apperror.NewForbiddenError(err.Error())
+	}
+
+	// 4. パスワード確認（必要な場合）
+	if shareLink.RequiresPassword() {
+		if input.Password == "" {
+			return nil, apperror.NewUnauthorizedError("password is required")
+		}
+		comparePassword := func(hash, password string) error {
+			return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+		}
+		if err := shareLink.ValidatePassword(input.Password, comparePassword); err != nil {
+			return nil, apperror.NewUnauthorizedError("invalid password")
+		}
+	}
+
+	// 5. リソースタイプに応じてファイルを解決
+	var targetFileID uuid.UUID
+	if shareLink.ResourceType == authz.ResourceTypeFile {
+		targetFileID = shareLink.ResourceID
+	} else {
+		// フォルダ共有の場合はFileIDが必須
+		if input.FileID == nil {
+			return nil, apperror.NewValidationError("file_id is required for folder share links", nil)
+		}
+		targetFileID = *input.FileID
+
+		// ファイルがフォルダのサブツリーに属することを確認
+		if err := verifyFileInFolderSubtree(ctx, q.folderClosureRepo, q.fileRepo, shareLink.ResourceID, targetFileID); err != nil {
+			return nil, err
+		}
+	}
+
+	// 6. ファイルを取得
+	file, err := q.fileRepo.FindByID(ctx, targetFileID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !file.CanDownload() {
+		return nil, apperror.NewForbiddenError("file is not available for preview")
+	}
+
+	// 7. 最新バージョンを取得
+	version, err := q.fileVersionRepo.FindLatestByFileID(ctx, file.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 8. 先頭部分を読み込んでプレビューを生成
+	preview, err := q.filePreviewService.Generate(ctx, file, version, input.MaxBytes)
+	if err != nil {
+		if errors.Is(err, service.ErrPreviewNotSupported) {
+			return nil, apperror.NewValidationError("preview is not available for this file type", nil)
+		}
+		return nil, apperror.NewInternalError(err)
+	}
+
+	// 9. アクセスカウントを増やす
+	shareLink.IncrementAccessCount()
+	if err := q.shareLinkRepo.Update(ctx, shareLink); err != nil {
+		return nil, err
+	}
+
+	// 10. アクセスログを記録（失敗は無視）
+	access, err := entity.NewShareLinkAccess(
+		shareLink.ID,
+		input.IPAddress,
+		input.UserAgent,
+		input.UserID,
+		entity.AccessActionView,
+	)
+	if err == nil {
+		_ = q.shareLinkAccessRepo.Create(ctx, access)
+	}
+
+	return &GetPreviewViaShareOutput{
+		ShareLinkID:   shareLink.ID,
+		FileID:        file.ID,
+		FileName:      file.Name.String(),
+		MimeType:      file.MimeType.String(),
+		Size:          version.Size,
+		VersionNumber: version.VersionNumber,
+		Preview:       preview,
+	}, nil
+}
@@ -0,0 +1,168 @@
+package query_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/sharing/query"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
+)
+
+type getPreviewViaShareTestDeps struct {
+	shareLinkRepo       *mocks.MockShareLinkRepository
+	shareLinkAccessRepo *mocks.MockShareLinkAccessRepository
+	fileRepo            *mocks.MockFileRepository
+	fileVersionRepo     *mocks.MockFileVersionRepository
+	folderClosureRepo   *mocks.MockFolderClosureRepository
+	filePreviewService  *mocks.MockFilePreviewService
+}
+
+func newGetPreviewViaShareTestDeps(t *testing.T) *getPreviewViaShareTestDeps {
+	t.Helper()
+	return &getPreviewViaShareTestDeps{
+		shareLinkRepo:       mocks.NewMockShareLinkRepository(t),
+		shareLinkAccessRepo: mocks.NewMockShareLinkAccessRepository(t),
+		fileRepo:            mocks.NewMockFileRepository(t),
+		fileVersionRepo:     mocks.NewMockFileVersionRepository(t),
+		folderClosureRepo:   mocks.NewMockFolderClosureRepository(t),
+		filePreviewService:  mocks.NewMockFilePreviewService(t),
+	}
+}
+
+func (d *getPreviewViaShareTestDeps) newQuery() *query.GetPreviewViaShareQuery {
+	return query.NewGetPreviewViaShareQuery(
+		d.shareLinkRepo,
+		d.shareLinkAccessRepo,
+		d.fileRepo,
+		d.fileVersionRepo,
+		d.folderClosureRepo,
+		d.filePreviewService,
+	)
+}
+
+func TestGetPreviewViaShareQuery_Execute_FileShareLink_ReturnsPreviewAndRecordsView(t *testing.T) {
+	ctx := context.Background()
+	deps := newGetPreviewViaShareTestDeps(t)
+
+	fileID := uuid.New()
+	shareLink := buildFileShareLink(fileID)
+	file := buildActiveFile(fileID)
+	fileVersion := buildFileVersion(fileID)
+	preview := &service.FilePreview{Format: service.PreviewFormatText, Content: "hello"}
+
+	deps.shareLinkRepo.On("FindByToken", ctx, shareLink.Token).Return(shareLink, nil)
+	deps.fileRepo.On("FindByID", ctx, fileID).Return(file, nil)
+	deps.fileVersionRepo.On("FindLatestByFileID", ctx, fileID).Return(fileVersion, nil)
+	deps.filePreviewService.On("Generate", ctx, file, fileVersion, 0).Return(preview, nil)
+	deps.shareLinkRepo.On("Update", ctx, shareLink).Return(nil)
+	deps.shareLinkAccessRepo.On("Create", ctx, mock.MatchedBy(func(access *entity.ShareLinkAccess) bool {
+		return access.ShareLinkID == shareLink.ID && access.Action == entity.AccessActionView
+	})).Return(nil)
+
+	output, err := deps.newQuery().Execute(ctx, query.GetPreviewViaShareInput{
+		Token:     shareLink.Token.String(),
+		IPAddress: "127.0.0.1",
+		UserAgent: "test-agent",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, shareLink.ID, output.ShareLinkID)
+	assert.Equal(t, fileID, output.FileID)
+	assert.Equal(t, file.Name.String(), output.FileName)
+	assert.Same(t, preview, output.Preview)
+	assert.Equal(t, 1, shareLink.AccessCount)
+}
+
+func TestGetPreviewViaShareQuery_Execute_FolderShareFileNotInSubtree_ReturnsNotFound(t *testing.T) {
+	ctx := context.Background()
+	deps := newGetPreviewViaShareTestDeps(t)
+
+	folderID := uuid.New()
+	fileID := uuid.New()
+	shareLink := buildFolderShareLink(folderID)
+	file := buildActiveFileInFolder(fileID, uuid.New())
+
+	deps.shareLinkRepo.On("FindByToken", ctx, shareLink.Token).Return(shareLink, nil)
+	deps.folderClosureRepo.On("FindDescendantIDs", ctx, folderID).Return([]uuid.UUID{folderID}, nil)
+	deps.fileRepo.On("FindByID", ctx, fileID).Return(file, nil)
+
+	_, err := deps.newQuery().Execute(ctx, query.GetPreviewViaShareInput{
+		Token:  shareLink.Token.String(),
+		FileID: &fileID,
+	})
+
+	require.Error(t, err)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeNotFound, appErr.Code)
+	deps.filePreviewService.AssertNotCalled(t, "Generate", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetPreviewViaShareQuery_Execute_ExpiredShareLink_ReturnsGoneError(t *testing.T) {
+	ctx := context.Background()
+	deps := newGetPreviewViaShareTestDeps(t)
+
+	shareLink := buildFileShareLink(uuid.New())
+	past := time.Now().Add(-1 * time.Hour)
+	shareLink.ExpiresAt = &past
+
+	deps.shareLinkRepo.On("FindByToken", ctx, shareLink.Token).Return(shareLink, nil)
+
+	_, err := deps.newQuery().Execute(ctx, query.GetPreviewViaShareInput{Token: shareLink.Token.String()})
+
+	require.Error(t, err)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeGone, appErr.Code)
+}
+
+func TestGetPreviewViaShareQuery_Execute_PasswordProtected_MissingPassword_ReturnsUnauthorized(t *testing.T) {
+	ctx := context.Background()
+	deps := newGetPreviewViaShareTestDeps(t)
+
+	shareLink := buildFileShareLink(uuid.New())
+	shareLink.PasswordHash = "$2a$12$somehash"
+
+	deps.shareLinkRepo.On("FindByToken", ctx, shareLink.Token).Return(shareLink, nil)
+
+	_, err := deps.newQuery().Execute(ctx, query.GetPreviewViaShareInput{Token: shareLink.Token.String()})
+
+	require.Error(t, err)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeUnauthorized, appErr.Code)
+}
+
+func TestGetPreviewViaShareQuery_Execute_UnsupportedType_DoesNotCountAccess(t *testing.T) {
+	ctx := context.Background()
+	deps := newGetPreviewViaShareTestDeps(t)
+
+	fileID := uuid.New()
+	shareLink := buildFileShareLink(fileID)
+	file := buildActiveFile(fileID)
+	fileVersion := buildFileVersion(fileID)
+
+	deps.shareLinkRepo.On("FindByToken", ctx, shareLink.Token).Return(shareLink, nil)
+	deps.fileRepo.On("FindByID", ctx, fileID).Return(file, nil)
+	deps.fileVersionRepo.On("FindLatestByFileID", ctx, fileID).Return(fileVersion, nil)
+	deps.filePreviewService.On("Generate", ctx, file, fileVersion, 0).Return(nil, service.ErrPreviewNotSupported)
+
+	_, err := deps.newQuery().Execute(ctx, query.GetPreviewViaShareInput{Token: shareLink.Token.String()})
+
+	require.Error(t, err)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeValidationError, appErr.Code)
+	assert.Equal(t, 0, shareLink.AccessCount)
+	deps.shareLinkRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
@@ -19,6 +19,7 @@ type AbortUploadInput struct {
 // AbortUploadOutput はアップロード中断の出力を定義します
 type AbortUploadOutput struct {
 	SessionID uuid.UUID
+	FileID    uuid.UUID
 	Aborted   bool
 }
 
@@ -53,8 +54,8 @@ func (c *AbortUploadCommand) Execute(ctx context.Context, input AbortUploadInput
 		return nil, err
 	}
 
-	// 2. 所有者チェック
-	if !session.IsOwnedBy(input.UserID) {
+	// 2. 所有者またはアップロード開始者のみ中断可能
+	if !session.IsOwnedBy(input.UserID) && !session.IsCreatedBy(input.UserID) {
 		return nil, apperror.NewForbiddenError("not authorized to abort this upload")
 	}
 
@@ -76,11 +77,15 @@ func (c *AbortUploadCommand) Execute(ctx context.Context, input AbortUploadInput
 			return err
 		}
 
-		// ファイルをupload_failedに
+		// アップロード中のファイルのみupload_failedに
+		// 新バージョンのアップロード中断では既存ファイルはアクティブのまま
 		file, err := c.fileRepo.FindByID(ctx, session.FileID)
 		if err != nil {
 			return err
 		}
+		if !file.IsUploading() {
+			return nil
+		}
 		if err := file.MarkUploadFailed(); err != nil {
 			return err
 		}
@@ -98,6 +103,7 @@ func (c *AbortUploadCommand) Execute(ctx context.Context, input AbortUploadInput
 
 	return &AbortUploadOutput{
 		SessionID: session.ID,
+		FileID:    session.FileID,
 		Aborted:   true,
 	}, nil
 }
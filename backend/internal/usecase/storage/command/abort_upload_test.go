@@ -104,6 +104,7 @@ func TestAbortUploadCommand_Execute_SinglePart_ReturnsAborted(t *testing.T) {
 	require.NotNil(t, output)
 	assert.True(t, output.Aborted)
 	assert.Equal(t, session.ID, output.SessionID)
+	assert.Equal(t, session.FileID, output.FileID)
 }
 
 func TestAbortUploadCommand_Execute_Multipart_CallsAbortMultipartUpload(t *testing.T) {
@@ -259,3 +260,31 @@ func TestAbortUploadCommand_Execute_SessionNotFound_PropagatesError(t *testing.T
 	require.True(t, errors.As(err, &appErr))
 	assert.Equal(t, apperror.CodeNotFound, appErr.Code)
 }
+
+func TestAbortUploadCommand_Execute_NewVersionByEditor_KeepsFileActive(t *testing.T) {
+	ctx := context.Background()
+	deps := newAbortUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	editorID := uuid.New()
+	file := newActiveFileEntity(ownerID, uuid.New())
+	session := entity.NewVersionUploadSession(file, editorID, 1024, nil)
+
+	input := command.AbortUploadInput{
+		SessionID: session.ID,
+		UserID:    editorID,
+	}
+
+	deps.uploadSessionRepo.On("FindByID", ctx, session.ID).Return(session, nil)
+	deps.uploadSessionRepo.On("Update", ctx, session).Return(nil)
+	deps.fileRepo.On("FindByID", ctx, session.FileID).Return(file, nil)
+
+	cmd := deps.newCommand()
+	output, err := cmd.Execute(ctx, input)
+
+	require.NoError(t, err)
+	require.NotNil(t, output)
+	assert.True(t, output.Aborted)
+	assert.Equal(t, entity.FileStatusActive, file.Status)
+	deps.fileRepo.AssertNotCalled(t, "Update", ctx, file)
+}
@@ -0,0 +1,130 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+const (
+	// MaxBulkItems は一括操作で1リクエストに指定できるアイテム数の上限です
+	MaxBulkItems = 500
+
+	// bulkBatchSize は1トランザクションでまとめて処理するアイテム数です
+	bulkBatchSize = 50
+)
+
+// errBulkBatchAborted はバッチ内のいずれかのアイテムが失敗したことを示します
+var errBulkBatchAborted = errors.New("bulk batch aborted")
+
+// BulkItem は一括操作の対象アイテムを表します
+type BulkItem struct {
+	ID   uuid.UUID
+	Type authz.ResourceType
+}
+
+// BulkItemResult はアイテムごとの処理結果を表します
+// Err がnilの場合は成功です
+type BulkItemResult struct {
+	ID   uuid.UUID
+	Type authz.ResourceType
+	Err  error
+}
+
+// Succeeded はアイテムの処理が成功したかどうかを判定します
+func (r BulkItemResult) Succeeded() bool {
+	return r.Err == nil
+}
+
+// BulkOutput は一括操作の出力を定義します
+type BulkOutput struct {
+	Results []BulkItemResult
+}
+
+// SucceededCount は成功したアイテム数を返します
+func (o *BulkOutput) SucceededCount() int {
+	count := 0
+	for _, r := range o.Results {
+		if r.Succeeded() {
+			count++
+		}
+	}
+	return count
+}
+
+// FailedCount は失敗したアイテム数を返します
+func (o *BulkOutput) FailedCount() int {
+	return len(o.Results) - o.SucceededCount()
+}
+
+// validateBulkItems は一括操作の対象アイテムを検証します
+func validateBulkItems(items []BulkItem) error {
+	if len(items) == 0 {
+		return apperror.NewValidationError("at least one item is required", nil)
+	}
+	if len(items) > MaxBulkItems {
+		return apperror.NewValidationError(fmt.Sprintf("at most %d items can be processed at once", MaxBulkItems), nil)
+	}
+	for _, item := range items {
+		if item.Type != authz.ResourceTypeFile && item.Type != authz.ResourceTypeFolder {
+			return apperror.NewValidationError("invalid item type", nil)
+		}
+	}
+	return nil
+}
+
+// bulkExecutor は1アイテム分の処理を実行します
+type bulkExecutor func(ctx context.Context, item BulkItem) error
+
+// runBulkBatched はアイテムを bulkBatchSize 件ずつ1トランザクションで処理します
+// バッチ内で1件でも失敗した場合はバッチをロールバックし、そのバッチのアイテムを1件ずつ個別のトランザクションで再実行します
+// これにより、失敗したアイテムが他のアイテムの結果に影響しません
+func runBulkBatched(ctx context.Context, txManager repository.TransactionManager, items []BulkItem, exec bulkExecutor) []BulkItemResult {
+	results := make([]BulkItemResult, 0, len(items))
+
+	for start := 0; start < len(items); start += bulkBatchSize {
+		end := min(start+bulkBatchSize, len(items))
+		batch := items[start:end]
+
+		err := txManager.WithTransaction(ctx, func(ctx context.Context) error {
+			for _, item := range batch {
+				if err := exec(ctx, item); err != nil {
+					return errBulkBatchAborted
+				}
+			}
+			return nil
+		})
+		if err == nil {
+			for _, item := range batch {
+				results = append(results, BulkItemResult{ID: item.ID, Type: item.Type})
+			}
+			continue
+		}
+
+		for _, item := range batch {
+			err := txManager.WithTransaction(ctx, func(ctx context.Context) error {
+				return exec(ctx, item)
+			})
+			results = append(results, BulkItemResult{ID: item.ID, Type: item.Type, Err: err})
+		}
+	}
+
+	return results
+}
+
+// runBulkEach はアイテムを1件ずつ処理します
+// オブジェクトストレージへの副作用はトランザクションでロールバックできないため、
+// ストレージを操作する一括処理はバッチにまとめず各コマンドのトランザクションに任せます
+func runBulkEach(ctx context.Context, items []BulkItem, exec bulkExecutor) []BulkItemResult {
+	results := make([]BulkItemResult, 0, len(items))
+	for _, item := range items {
+		results = append(results, BulkItemResult{ID: item.ID, Type: item.Type, Err: exec(ctx, item)})
+	}
+	return results
+}
@@ -0,0 +1,60 @@
+package command
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+)
+
+// BulkCopyInput は一括コピーの入力を定義します
+type BulkCopyInput struct {
+	Items               []BulkItem
+	DestinationFolderID uuid.UUID
+	UserID              uuid.UUID
+}
+
+// BulkCopyCommand はファイルとフォルダを一括でコピーするコマンドです
+type BulkCopyCommand struct {
+	copyFileCommand   *CopyFileCommand
+	copyFolderCommand *CopyFolderCommand
+}
+
+// NewBulkCopyCommand は新しいBulkCopyCommandを作成します
+func NewBulkCopyCommand(
+	copyFileCommand *CopyFileCommand,
+	copyFolderCommand *CopyFolderCommand,
+) *BulkCopyCommand {
+	return &BulkCopyCommand{
+		copyFileCommand:   copyFileCommand,
+		copyFolderCommand: copyFolderCommand,
+	}
+}
+
+// Execute は一括コピーを実行します
+// コピーはオブジェクトストレージへの書き込みを伴うため、アイテムごとに個別のトランザクションで処理します
+func (c *BulkCopyCommand) Execute(ctx context.Context, input BulkCopyInput) (*BulkOutput, error) {
+	if err := validateBulkItems(input.Items); err != nil {
+		return nil, err
+	}
+
+	results := runBulkEach(ctx, input.Items, func(ctx context.Context, item BulkItem) error {
+		if item.Type == authz.ResourceTypeFile {
+			_, err := c.copyFileCommand.Execute(ctx, CopyFileInput{
+				FileID:              item.ID,
+				DestinationFolderID: input.DestinationFolderID,
+				UserID:              input.UserID,
+			})
+			return err
+		}
+		_, err := c.copyFolderCommand.Execute(ctx, CopyFolderInput{
+			FolderID:            item.ID,
+			DestinationFolderID: input.DestinationFolderID,
+			UserID:              input.UserID,
+		})
+		return err
+	})
+
+	return &BulkOutput{Results: results}, nil
+}
@@ -0,0 +1,119 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/command"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
+)
+
+type bulkCopyTestDeps struct {
+	fileRepo           *mocks.MockFileRepository
+	fileVersionRepo    *mocks.MockFileVersionRepository
+	folderRepo         *mocks.MockFolderRepository
+	folderClosureRepo  *mocks.MockFolderClosureRepository
+	storageUsageRepo   *mocks.MockStorageUsageRepository
+	relationshipRepo   *mocks.MockRelationshipRepository
+	storageService     *mocks.MockStorageService
+	blobService        *mocks.MockBlobService
+	quotaService       *mocks.MockStorageQuotaService
+	permissionResolver *mocks.MockPermissionResolver
+	txManager          *mocks.MockTransactionManager
+}
+
+func newBulkCopyTestDeps(t *testing.T) *bulkCopyTestDeps {
+	t.Helper()
+	return &bulkCopyTestDeps{
+		fileRepo:           mocks.NewMockFileRepository(t),
+		fileVersionRepo:    mocks.NewMockFileVersionRepository(t),
+		folderRepo:         mocks.NewMockFolderRepository(t),
+		folderClosureRepo:  mocks.NewMockFolderClosureRepository(t),
+		storageUsageRepo:   mocks.NewMockStorageUsageRepository(t),
+		relationshipRepo:   mocks.NewMockRelationshipRepository(t),
+		storageService:     mocks.NewMockStorageService(t),
+		blobService:        mocks.NewMockBlobService(t),
+		quotaService:       mocks.NewMockStorageQuotaService(t),
+		permissionResolver: mocks.NewMockPermissionResolver(t),
+		txManager:          mocks.NewMockTransactionManager(t),
+	}
+}
+
+func (d *bulkCopyTestDeps) newCommand() *command.BulkCopyCommand {
+	return command.NewBulkCopyCommand(
+		command.NewCopyFileCommand(d.fileRepo, d.fileVersionRepo, d.folderRepo, d.storageUsageRepo, d.storageService, d.blobService, d.quotaService, d.permissionResolver, d.txManager),
+		command.NewCopyFolderCommand(d.folderRepo, d.folderClosureRepo, d.fileRepo, d.fileVersionRepo, d.storageUsageRepo, d.relationshipRepo, d.storageService, d.blobService, d.quotaService, d.permissionResolver, d.txManager),
+	)
+}
+
+func TestBulkCopyCommand_Execute_ForbiddenItem_DoesNotFailOtherItems(t *testing.T) {
+	ctx := context.Background()
+	deps := newBulkCopyTestDeps(t)
+
+	userID := uuid.New()
+	allowed := newActiveFileEntity(userID, uuid.New())
+	version := newStoredFileVersion(allowed.ID, 1, 100)
+	forbidden := newActiveFileEntity(uuid.New(), uuid.New())
+	dest := newFolderEntity(userID)
+
+	deps.fileRepo.On("FindByID", ctx, allowed.ID).Return(allowed, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFile, allowed.ID, authz.PermFileRead).Return(true, nil)
+	deps.folderRepo.On("FindByID", ctx, dest.ID).Return(dest, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFolder, dest.ID, authz.PermFolderCreate).Return(true, nil)
+	deps.fileRepo.On("ExistsByNameAndFolder", ctx, allowed.Name, dest.ID).Return(false, nil)
+	deps.fileVersionRepo.On("FindByFileAndVersion", ctx, allowed.ID, 1).Return(version, nil)
+	deps.quotaService.On("EnsureCapacity", ctx, userID, int64(100)).Return(nil)
+	deps.storageService.On("CopyObjectVersion", ctx, version.StorageKey.String(), version.MinioVersionID, mock.AnythingOfType("string")).Return("copied-v1", nil)
+	deps.fileRepo.On("Create", ctx, mock.AnythingOfType("*entity.File")).Return(nil)
+	deps.fileVersionRepo.On("Create", ctx, mock.AnythingOfType("*entity.FileVersion")).Return(nil)
+	deps.storageUsageRepo.On("AddUsedBytes", ctx, userID, int64(100)).Return(nil)
+
+	deps.fileRepo.On("FindByID", ctx, forbidden.ID).Return(forbidden, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFile, forbidden.ID, authz.PermFileRead).Return(false, nil)
+
+	output, err := deps.newCommand().Execute(ctx, command.BulkCopyInput{
+		Items: []command.BulkItem{
+			{ID: allowed.ID, Type: authz.ResourceTypeFile},
+			{ID: forbidden.ID, Type: authz.ResourceTypeFile},
+		},
+		DestinationFolderID: dest.ID,
+		UserID:              userID,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, output.Results, 2)
+	assert.Equal(t, 1, output.SucceededCount())
+
+	assert.Equal(t, allowed.ID, output.Results[0].ID)
+	assert.True(t, output.Results[0].Succeeded())
+
+	assert.Equal(t, forbidden.ID, output.Results[1].ID)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(output.Results[1].Err, &appErr))
+	assert.Equal(t, apperror.CodeForbidden, appErr.Code)
+}
+
+func TestBulkCopyCommand_Execute_InvalidItemType_ReturnsValidationError(t *testing.T) {
+	ctx := context.Background()
+	deps := newBulkCopyTestDeps(t)
+
+	output, err := deps.newCommand().Execute(ctx, command.BulkCopyInput{
+		Items:               []command.BulkItem{{ID: uuid.New(), Type: authz.ResourceType("group")}},
+		DestinationFolderID: uuid.New(),
+		UserID:              uuid.New(),
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeValidationError, appErr.Code)
+}
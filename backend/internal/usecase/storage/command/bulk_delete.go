@@ -0,0 +1,58 @@
+package command
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+)
+
+// BulkDeleteInput は一括削除の入力を定義します
+// ファイルのIDはゴミ箱内のアーカイブファイルIDです
+type BulkDeleteInput struct {
+	Items  []BulkItem
+	UserID uuid.UUID
+}
+
+// BulkDeleteCommand はファイルとフォルダを一括で削除するコマンドです
+// 単体の削除API（DELETE /trash/files/:id, DELETE /folders/:id）と同じコマンドを実行します
+type BulkDeleteCommand struct {
+	permanentlyDeleteFileCommand *PermanentlyDeleteFileCommand
+	deleteFolderCommand          *DeleteFolderCommand
+}
+
+// NewBulkDeleteCommand は新しいBulkDeleteCommandを作成します
+func NewBulkDeleteCommand(
+	permanentlyDeleteFileCommand *PermanentlyDeleteFileCommand,
+	deleteFolderCommand *DeleteFolderCommand,
+) *BulkDeleteCommand {
+	return &BulkDeleteCommand{
+		permanentlyDeleteFileCommand: permanentlyDeleteFileCommand,
+		deleteFolderCommand:          deleteFolderCommand,
+	}
+}
+
+// Execute は一括削除を実行します
+// 完全削除はオブジェクトストレージからの削除を伴うため、アイテムごとに個別のトランザクションで処理します
+func (c *BulkDeleteCommand) Execute(ctx context.Context, input BulkDeleteInput) (*BulkOutput, error) {
+	if err := validateBulkItems(input.Items); err != nil {
+		return nil, err
+	}
+
+	results := runBulkEach(ctx, input.Items, func(ctx context.Context, item BulkItem) error {
+		if item.Type == authz.ResourceTypeFile {
+			return c.permanentlyDeleteFileCommand.Execute(ctx, PermanentlyDeleteFileInput{
+				ArchivedFileID: item.ID,
+				UserID:         input.UserID,
+			})
+		}
+		_, err := c.deleteFolderCommand.Execute(ctx, DeleteFolderInput{
+			FolderID: item.ID,
+			UserID:   input.UserID,
+		})
+		return err
+	})
+
+	return &BulkOutput{Results: results}, nil
+}
@@ -0,0 +1,64 @@
+package command
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+)
+
+// BulkMoveInput は一括移動の入力を定義します
+type BulkMoveInput struct {
+	Items               []BulkItem
+	DestinationFolderID uuid.UUID
+	UserID              uuid.UUID
+}
+
+// BulkMoveCommand はファイルとフォルダを一括で移動するコマンドです
+type BulkMoveCommand struct {
+	moveFileCommand   *MoveFileCommand
+	moveFolderCommand *MoveFolderCommand
+	txManager         repository.TransactionManager
+}
+
+// NewBulkMoveCommand は新しいBulkMoveCommandを作成します
+func NewBulkMoveCommand(
+	moveFileCommand *MoveFileCommand,
+	moveFolderCommand *MoveFolderCommand,
+	txManager repository.TransactionManager,
+) *BulkMoveCommand {
+	return &BulkMoveCommand{
+		moveFileCommand:   moveFileCommand,
+		moveFolderCommand: moveFolderCommand,
+		txManager:         txManager,
+	}
+}
+
+// Execute は一括移動を実行します
+// 各アイテムの権限チェックは個別に行われ、失敗したアイテムは結果に記録されます
+func (c *BulkMoveCommand) Execute(ctx context.Context, input BulkMoveInput) (*BulkOutput, error) {
+	if err := validateBulkItems(input.Items); err != nil {
+		return nil, err
+	}
+
+	results := runBulkBatched(ctx, c.txManager, input.Items, func(ctx context.Context, item BulkItem) error {
+		if item.Type == authz.ResourceTypeFile {
+			_, err := c.moveFileCommand.Execute(ctx, MoveFileInput{
+				FileID:      item.ID,
+				NewFolderID: input.DestinationFolderID,
+				UserID:      input.UserID,
+			})
+			return err
+		}
+		_, err := c.moveFolderCommand.Execute(ctx, MoveFolderInput{
+			FolderID:    item.ID,
+			NewParentID: &input.DestinationFolderID,
+			UserID:      input.UserID,
+		})
+		return err
+	})
+
+	return &BulkOutput{Results: results}, nil
+}
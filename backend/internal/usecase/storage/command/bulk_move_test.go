@@ -0,0 +1,160 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/command"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
+)
+
+type bulkMoveTestDeps struct {
+	fileRepo           *mocks.MockFileRepository
+	fileVersionRepo    *mocks.MockFileVersionRepository
+	folderRepo         *mocks.MockFolderRepository
+	folderClosureRepo  *mocks.MockFolderClosureRepository
+	storageUsageRepo   *mocks.MockStorageUsageRepository
+	storageService     *mocks.MockStorageService
+	blobService        *mocks.MockBlobService
+	quotaService       *mocks.MockStorageQuotaService
+	userRepo           *mocks.MockUserRepository
+	permissionResolver *mocks.MockPermissionResolver
+	txManager          *mocks.MockTransactionManager
+}
+
+func newBulkMoveTestDeps(t *testing.T) *bulkMoveTestDeps {
+	t.Helper()
+	return &bulkMoveTestDeps{
+		fileRepo:           mocks.NewMockFileRepository(t),
+		fileVersionRepo:    mocks.NewMockFileVersionRepository(t),
+		folderRepo:         mocks.NewMockFolderRepository(t),
+		folderClosureRepo:  mocks.NewMockFolderClosureRepository(t),
+		storageUsageRepo:   mocks.NewMockStorageUsageRepository(t),
+		storageService:     mocks.NewMockStorageService(t),
+		blobService:        mocks.NewMockBlobService(t),
+		quotaService:       mocks.NewMockStorageQuotaService(t),
+		userRepo:           mocks.NewMockUserRepository(t),
+		permissionResolver: mocks.NewMockPermissionResolver(t),
+		txManager:          mocks.NewMockTransactionManager(t),
+	}
+}
+
+func (d *bulkMoveTestDeps) newCommand() *command.BulkMoveCommand {
+	return command.NewBulkMoveCommand(
+		command.NewMoveFileCommand(d.fileRepo, d.fileVersionRepo, d.folderRepo, d.storageUsageRepo, d.storageService, d.blobService, d.quotaService, d.permissionResolver, d.txManager),
+		command.NewMoveFolderCommand(d.folderRepo, d.folderClosureRepo, d.txManager, d.userRepo, d.permissionResolver),
+		d.txManager,
+	)
+}
+
+func TestBulkMoveCommand_Execute_ForbiddenItem_DoesNotFailOtherItems(t *testing.T) {
+	ctx := context.Background()
+	deps := newBulkMoveTestDeps(t)
+
+	userID := uuid.New()
+	allowedFolderID := uuid.New()
+	forbiddenFolderID := uuid.New()
+	allowed := newActiveFileEntity(userID, allowedFolderID)
+	forbidden := newActiveFileEntity(uuid.New(), forbiddenFolderID)
+	dest := newFolderEntity(userID)
+
+	// The batch is rolled back and retried item by item, so the file is loaded twice.
+	reloaded := *allowed
+	deps.fileRepo.On("FindByID", ctx, allowed.ID).Return(allowed, nil).Once()
+	deps.fileRepo.On("FindByID", ctx, allowed.ID).Return(&reloaded, nil).Once()
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFolder, allowedFolderID, authz.PermFileMoveOut).Return(true, nil)
+	deps.folderRepo.On("FindByID", ctx, dest.ID).Return(dest, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFolder, dest.ID, authz.PermFileMoveIn).Return(true, nil)
+	deps.fileRepo.On("ExistsByNameAndFolder", ctx, allowed.Name, dest.ID).Return(false, nil)
+	deps.fileRepo.On("Update", ctx, mock.AnythingOfType("*entity.File")).Return(nil)
+
+	deps.fileRepo.On("FindByID", ctx, forbidden.ID).Return(forbidden, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFolder, forbiddenFolderID, authz.PermFileMoveOut).Return(false, nil)
+
+	output, err := deps.newCommand().Execute(ctx, command.BulkMoveInput{
+		Items: []command.BulkItem{
+			{ID: allowed.ID, Type: authz.ResourceTypeFile},
+			{ID: forbidden.ID, Type: authz.ResourceTypeFile},
+		},
+		DestinationFolderID: dest.ID,
+		UserID:              userID,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, output.Results, 2)
+	assert.Equal(t, 1, output.SucceededCount())
+	assert.Equal(t, 1, output.FailedCount())
+
+	assert.Equal(t, allowed.ID, output.Results[0].ID)
+	assert.True(t, output.Results[0].Succeeded())
+
+	assert.Equal(t, forbidden.ID, output.Results[1].ID)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(output.Results[1].Err, &appErr))
+	assert.Equal(t, apperror.CodeForbidden, appErr.Code)
+}
+
+func TestBulkMoveCommand_Execute_MixedItems_MovesFilesAndFolders(t *testing.T) {
+	ctx := context.Background()
+	deps := newBulkMoveTestDeps(t)
+
+	userID := uuid.New()
+	sourceFolderID := uuid.New()
+	file := newActiveFileEntity(userID, sourceFolderID)
+	folder := newRootFolderEntity(userID)
+	dest := newFolderEntity(userID)
+
+	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFolder, sourceFolderID, authz.PermFileMoveOut).Return(true, nil)
+	deps.folderRepo.On("FindByID", ctx, dest.ID).Return(dest, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFolder, dest.ID, authz.PermFileMoveIn).Return(true, nil)
+	deps.fileRepo.On("ExistsByNameAndFolder", ctx, file.Name, dest.ID).Return(false, nil)
+	deps.fileRepo.On("Update", ctx, file).Return(nil)
+
+	deps.folderRepo.On("FindByID", ctx, folder.ID).Return(folder, nil)
+	deps.userRepo.On("FindByID", ctx, userID).Return(newMoveFolderUserWithoutPersonalFolder(userID), nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFolder, dest.ID, authz.PermFolderMoveIn).Return(true, nil)
+	deps.folderClosureRepo.On("FindDescendantIDs", ctx, folder.ID).Return([]uuid.UUID{}, nil)
+	deps.folderRepo.On("ExistsByNameAndParent", ctx, folder.Name, &dest.ID, userID).Return(false, nil)
+	deps.folderClosureRepo.On("FindAncestorPaths", ctx, dest.ID).Return(nil, nil)
+	deps.folderClosureRepo.On("MoveSubtree", ctx, folder.ID, mock.Anything).Return(nil)
+	deps.folderRepo.On("Update", ctx, folder).Return(nil)
+
+	output, err := deps.newCommand().Execute(ctx, command.BulkMoveInput{
+		Items: []command.BulkItem{
+			{ID: file.ID, Type: authz.ResourceTypeFile},
+			{ID: folder.ID, Type: authz.ResourceTypeFolder},
+		},
+		DestinationFolderID: dest.ID,
+		UserID:              userID,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, output.SucceededCount())
+	assert.Equal(t, dest.ID, file.FolderID)
+	assert.Equal(t, dest.ID, *folder.ParentID)
+}
+
+func TestBulkMoveCommand_Execute_NoItems_ReturnsValidationError(t *testing.T) {
+	ctx := context.Background()
+	deps := newBulkMoveTestDeps(t)
+
+	output, err := deps.newCommand().Execute(ctx, command.BulkMoveInput{
+		DestinationFolderID: uuid.New(),
+		UserID:              uuid.New(),
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeValidationError, appErr.Code)
+}
@@ -0,0 +1,61 @@
+package command
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+)
+
+// BulkTrashInput は一括ゴミ箱移動の入力を定義します
+type BulkTrashInput struct {
+	Items  []BulkItem
+	UserID uuid.UUID
+}
+
+// BulkTrashCommand はファイルとフォルダを一括でゴミ箱に移動するコマンドです
+type BulkTrashCommand struct {
+	trashFileCommand    *TrashFileCommand
+	deleteFolderCommand *DeleteFolderCommand
+	txManager           repository.TransactionManager
+}
+
+// NewBulkTrashCommand は新しいBulkTrashCommandを作成します
+func NewBulkTrashCommand(
+	trashFileCommand *TrashFileCommand,
+	deleteFolderCommand *DeleteFolderCommand,
+	txManager repository.TransactionManager,
+) *BulkTrashCommand {
+	return &BulkTrashCommand{
+		trashFileCommand:    trashFileCommand,
+		deleteFolderCommand: deleteFolderCommand,
+		txManager:           txManager,
+	}
+}
+
+// Execute は一括ゴミ箱移動を実行します
+// フォルダは DeleteFolderCommand により配下のファイルがゴミ箱に移動されます
+func (c *BulkTrashCommand) Execute(ctx context.Context, input BulkTrashInput) (*BulkOutput, error) {
+	if err := validateBulkItems(input.Items); err != nil {
+		return nil, err
+	}
+
+	results := runBulkBatched(ctx, c.txManager, input.Items, func(ctx context.Context, item BulkItem) error {
+		if item.Type == authz.ResourceTypeFile {
+			_, err := c.trashFileCommand.Execute(ctx, TrashFileInput{
+				FileID: item.ID,
+				UserID: input.UserID,
+			})
+			return err
+		}
+		_, err := c.deleteFolderCommand.Execute(ctx, DeleteFolderInput{
+			FolderID: item.ID,
+			UserID:   input.UserID,
+		})
+		return err
+	})
+
+	return &BulkOutput{Results: results}, nil
+}
@@ -2,11 +2,14 @@ package command
 
 import (
 	"context"
+	"errors"
+	"log/slog"
 
 	"github.com/google/uuid"
 
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
 	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
 )
@@ -24,6 +27,7 @@ type CompleteUploadOutput struct {
 	FileID    uuid.UUID
 	SessionID uuid.UUID
 	Completed bool // 全パーツ完了したかどうか
+	Verifying bool // 全内容を受信し、バックグラウンドで内容の検証を待っているかどうか
 }
 
 // CompleteUploadCommand はアップロード完了コマンドです（MinIO Webhook用）
@@ -32,6 +36,10 @@ type CompleteUploadCommand struct {
 	fileVersionRepo   repository.FileVersionRepository
 	uploadSessionRepo repository.UploadSessionRepository
 	uploadPartRepo    repository.UploadPartRepository
+	storageUsageRepo  repository.StorageUsageRepository
+	blobService       service.BlobService
+	storageService    service.StorageService
+	thumbnailService  service.ThumbnailService
 	txManager         repository.TransactionManager
 }
 
@@ -41,6 +49,10 @@ func NewCompleteUploadCommand(
 	fileVersionRepo repository.FileVersionRepository,
 	uploadSessionRepo repository.UploadSessionRepository,
 	uploadPartRepo repository.UploadPartRepository,
+	storageUsageRepo repository.StorageUsageRepository,
+	blobService service.BlobService,
+	storageService service.StorageService,
+	thumbnailService service.ThumbnailService,
 	txManager repository.TransactionManager,
 ) *CompleteUploadCommand {
 	return &CompleteUploadCommand{
@@ -48,6 +60,10 @@ func NewCompleteUploadCommand(
 		fileVersionRepo:   fileVersionRepo,
 		uploadSessionRepo: uploadSessionRepo,
 		uploadPartRepo:    uploadPartRepo,
+		storageUsageRepo:  storageUsageRepo,
+		blobService:       blobService,
+		storageService:    storageService,
+		thumbnailService:  thumbnailService,
 		txManager:         txManager,
 	}
 }
@@ -67,13 +83,9 @@ func (c *CompleteUploadCommand) Execute(ctx context.Context, input CompleteUploa
 
 	// 2. セッションの状態チェック
 	if !session.CanAcceptUpload() {
-		if session.IsCompleted() {
-			// 既に完了している場合は冪等性のため成功を返す
-			return &CompleteUploadOutput{
-				FileID:    session.FileID,
-				SessionID: session.ID,
-				Completed: true,
-			}, nil
+		if session.IsCompleted() || session.IsVerifying() {
+			// 既に完了または検証待ちの場合は冪等性のため成功を返す
+			return sessionOutput(session), nil
 		}
 		return nil, apperror.NewValidationError("upload session cannot accept uploads", nil)
 	}
@@ -85,7 +97,8 @@ func (c *CompleteUploadCommand) Execute(ctx context.Context, input CompleteUploa
 	}
 
 	// 4. マルチパートの場合はパーツを記録
-	if session.IsMultipart {
+	// サーバー経由のアップロードで全パーツが記録済みの場合は、結合したオブジェクトの通知として扱う
+	if session.IsMultipart && !session.AllPartsUploaded() {
 		session.IncrementUploadedParts()
 
 		// パーツ情報を記録
@@ -115,34 +128,200 @@ func (c *CompleteUploadCommand) Execute(ctx context.Context, input CompleteUploa
 		}
 	}
 
-	// 5. アップロード完了処理（トランザクション）
-	err = c.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+	// マルチパートの場合、input.Sizeは最終パーツのサイズのためセッションの合計サイズを使う
+	size := input.Size
+	if session.IsMultipart {
+		size = session.TotalSize
+	}
+
+	return c.complete(ctx, session, file, input.MinioVersionID, size)
+}
+
+// CompleteSession は全内容の保存が済んだセッションのアップロードを完了します
+// サーバー経由のアップロードからWebhookと同じ完了処理を行うために使用します
+func (c *CompleteUploadCommand) CompleteSession(ctx context.Context, session *entity.UploadSession, minioVersionID string, size int64) (*CompleteUploadOutput, error) {
+	file, err := c.fileRepo.FindByID(ctx, session.FileID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.complete(ctx, session, file, minioVersionID, size)
+}
+
+// VerifySession は検証待ちのセッションの内容を再計算して照合し、アップロードを完了します
+// バックグラウンドの検証から呼び出されます
+func (c *CompleteUploadCommand) VerifySession(ctx context.Context, session *entity.UploadSession) (*CompleteUploadOutput, error) {
+	if !session.IsVerifying() {
+		return nil, apperror.NewValidationError("upload session is not waiting for verification", nil)
+	}
+
+	file, err := c.fileRepo.FindByID(ctx, session.FileID)
+	if err != nil {
+		return nil, err
+	}
+
+	digest, err := c.blobService.ComputeDigest(ctx, session.StorageKey.String(), session.ReceivedVersionID)
+	if err != nil {
+		return nil, apperror.NewInternalError(err)
+	}
+
+	return c.finish(ctx, session, file, digest, session.ReceivedVersionID, session.ReceivedSize)
+}
+
+// complete はアップロードされた内容を検証してアップロードを完了します
+// MinIOがアップロード時に検証・記録したチェックサムがあれば内容を読み込まずに照合し、
+// ない場合はセッションを検証待ちにして内容の再計算をバックグラウンドの検証に任せます
+func (c *CompleteUploadCommand) complete(
+	ctx context.Context,
+	session *entity.UploadSession,
+	file *entity.File,
+	minioVersionID string,
+	size int64,
+) (*CompleteUploadOutput, error) {
+	// 5. MinIOが記録したチェックサムを取得
+	digest, err := c.blobService.StoredDigest(ctx, session.StorageKey.String(), minioVersionID)
+	if err != nil {
+		return nil, apperror.NewInternalError(err)
+	}
+	if digest == nil || !coversExpectedChecksum(session, digest) {
+		return c.startVerification(ctx, session, minioVersionID, size)
+	}
+
+	return c.finish(ctx, session, file, digest, minioVersionID, size)
+}
+
+// startVerification はセッションを内容の検証待ちにします
+// ファイルは検証が完了するまでuploadingのまま残ります
+func (c *CompleteUploadCommand) startVerification(
+	ctx context.Context,
+	session *entity.UploadSession,
+	minioVersionID string,
+	size int64,
+) (*CompleteUploadOutput, error) {
+	// 並行する完了処理で既に状態が変わっている場合は冪等な成功として扱う
+	err := c.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		locked, err := c.uploadSessionRepo.FindByIDForUpdate(ctx, session.ID)
+		if err != nil {
+			return err
+		}
+		if locked.IsCompleted() || locked.IsVerifying() {
+			session.Status = locked.Status
+			return nil
+		}
+		if !locked.CanAcceptUpload() {
+			return apperror.NewValidationError("upload session cannot accept uploads", nil)
+		}
+
+		if err := session.StartVerification(minioVersionID, size); err != nil {
+			return apperror.NewValidationError("upload session cannot accept uploads", nil)
+		}
+		return c.uploadSessionRepo.Update(ctx, session)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sessionOutput(session), nil
+}
+
+// finish はダイジェストを申告されたチェックサムと照合し、
+// ファイルバージョンを作成してセッションとファイルを完了状態にします
+func (c *CompleteUploadCommand) finish(
+	ctx context.Context,
+	session *entity.UploadSession,
+	file *entity.File,
+	digest *service.ContentDigest,
+	minioVersionID string,
+	size int64,
+) (*CompleteUploadOutput, error) {
+	storageKey := session.StorageKey
+
+	// 既にアクティブなファイルへのアップロードは新バージョンの追加として扱う
+	isNewVersion := file.IsActive()
+	if isNewVersion {
+		file.IncrementVersion()
+	}
+
+	// 6. 申告されたチェックサムと照合
+	if !session.VerifyContent(digest.SHA256, digest.CRC32C) {
+		return nil, c.rejectCorruptedUpload(ctx, session, file, storageKey, minioVersionID)
+	}
+	checksum := digest.SHA256
+
+	// 7. アップロード完了処理（トランザクション）
+	// Webhookとサーバー経由・クライアントからの完了通知は同じセッションを並行して完了しうるため、
+	// セッションの行ロックを取得し、先に完了した側以外は冪等な成功として扱う
+	alreadyCompleted := false
+	var sharedBlob *entity.Blob
+	var version *entity.FileVersion
+	err := c.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		locked, err := c.uploadSessionRepo.FindByIDForUpdate(ctx, session.ID)
+		if err != nil {
+			return err
+		}
+		if locked.IsCompleted() {
+			alreadyCompleted = true
+			return nil
+		}
+		if !locked.CanAcceptUpload() && !locked.IsVerifying() {
+			return apperror.NewValidationError("upload session cannot accept uploads", nil)
+		}
+
 		// ファイルバージョン作成
-		version := entity.NewFileVersion(
+		version = entity.NewFileVersion(
 			file.ID,
 			file.CurrentVersion,
-			input.MinioVersionID,
-			input.Size,
-			input.ETag,
-			session.OwnerID,
+			minioVersionID,
+			size,
+			checksum,
+			session.CreatedBy,
 		)
 
-		if err := c.fileVersionRepo.Create(ctx, version); err != nil {
-			return err
+		// 同じ内容のブロブがあれば共有し、なければこのアップロードをブロブとして登録
+		// バージョンIDがない場合はオブジェクトバージョンを特定できないため共有しない
+		if minioVersionID != "" {
+			blob, shared, err := c.blobService.Register(ctx, checksum, storageKey, minioVersionID, size)
+			if err != nil {
+				if errors.Is(err, service.ErrObjectVersionRegistered) {
+					return apperror.NewValidationError("uploaded object version is already in use", nil)
+				}
+				return err
+			}
+			version.ReferenceBlob(blob)
+			if shared {
+				sharedBlob = blob
+			}
 		}
 
-		// ファイルを有効化
-		if err := file.Activate(); err != nil {
+		if err := c.fileVersionRepo.Create(ctx, version); err != nil {
 			return err
 		}
-		file.UpdateSize(input.Size)
 
-		if err := c.fileRepo.Update(ctx, file); err != nil {
-			return err
+		file.UpdateSize(size)
+
+		if isNewVersion {
+			// 新バージョンの場合はサイズとバージョン番号のみ更新
+			if err := c.fileRepo.Update(ctx, file); err != nil {
+				return err
+			}
+		} else {
+			// ファイルを有効化
+			if err := file.Activate(); err != nil {
+				return err
+			}
+
+			if err := c.fileRepo.Update(ctx, file); err != nil {
+				return err
+			}
+
+			// ステータスを永続化（UpdateFileクエリにstatusが含まれないため個別に更新）
+			if err := c.fileRepo.UpdateStatus(ctx, file.ID, file.Status); err != nil {
+				return err
+			}
 		}
 
-		// ステータスを永続化（UpdateFileクエリにstatusが含まれないため個別に更新）
-		if err := c.fileRepo.UpdateStatus(ctx, file.ID, file.Status); err != nil {
+		// 所有者のストレージ使用量を加算
+		if err := c.storageUsageRepo.AddUsedBytes(ctx, session.OwnerID, size); err != nil {
 			return err
 		}
 
@@ -157,6 +336,31 @@ func (c *CompleteUploadCommand) Execute(ctx context.Context, input CompleteUploa
 	if err != nil {
 		return nil, err
 	}
+	if alreadyCompleted {
+		session.Status = entity.UploadSessionStatusCompleted
+		return sessionOutput(session), nil
+	}
+
+	// 8. 既存のブロブを共有した場合、アップロードされたオブジェクトバージョンは不要なため削除
+	// 共有したブロブ自体がこのオブジェクトバージョンの場合は、ブロブの内容を失わないよう削除しない
+	if sharedBlob != nil && (sharedBlob.StorageKey != storageKey || sharedBlob.MinioVersionID != minioVersionID) {
+		if err := c.storageService.DeleteObjectVersion(ctx, storageKey.String(), minioVersionID); err != nil {
+			slog.Error("failed to delete deduplicated upload",
+				"storage_key", storageKey.String(),
+				"version_id", minioVersionID,
+				"error", err,
+			)
+		}
+	}
+
+	// 9. 画像の場合は新しいバージョンのサムネイル生成を登録（失敗してもアップロードは完了扱い）
+	if err := c.thumbnailService.Schedule(ctx, file, version); err != nil {
+		slog.Error("failed to schedule thumbnail generation",
+			"file_id", file.ID,
+			"version", version.VersionNumber,
+			"error", err,
+		)
+	}
 
 	return &CompleteUploadOutput{
 		FileID:    session.FileID,
@@ -165,6 +369,110 @@ func (c *CompleteUploadCommand) Execute(ctx context.Context, input CompleteUploa
 	}, nil
 }
 
+// rejectCorruptedUpload はチェックサムが一致しないアップロードを失敗として記録し、アップロードされた内容を削除します
+// 新規ファイルはupload_failedに、新バージョンのアップロードでは既存ファイルはアクティブのままにします
+func (c *CompleteUploadCommand) rejectCorruptedUpload(
+	ctx context.Context,
+	session *entity.UploadSession,
+	file *entity.File,
+	storageKey valueobject.StorageKey,
+	minioVersionID string,
+) error {
+	err := c.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		// 並行する完了処理で既に状態が変わっている場合は記録しない
+		locked, err := c.uploadSessionRepo.FindByIDForUpdate(ctx, session.ID)
+		if err != nil {
+			return err
+		}
+		if !locked.CanAcceptUpload() && !locked.IsVerifying() {
+			return apperror.NewValidationError("upload session cannot accept uploads", nil)
+		}
+
+		if err := session.Abort(); err != nil {
+			return err
+		}
+		if err := c.uploadSessionRepo.Update(ctx, session); err != nil {
+			return err
+		}
+
+		if !file.IsUploading() {
+			return nil
+		}
+		if err := file.MarkUploadFailed(); err != nil {
+			return err
+		}
+		return c.fileRepo.UpdateStatus(ctx, file.ID, file.Status)
+	})
+	if err != nil {
+		return err
+	}
+
+	if minioVersionID != "" {
+		c.deleteCorruptedVersion(ctx, storageKey, minioVersionID)
+	}
+
+	slog.Warn("upload rejected: checksum mismatch",
+		"session_id", session.ID,
+		"file_id", file.ID,
+		"expected", session.ExpectedChecksum.String(),
+	)
+	return apperror.NewValidationError("uploaded content does not match the expected checksum", nil)
+}
+
+// deleteCorruptedVersion はチェックサムが一致しなかったアップロードのオブジェクトバージョンを削除します
+// ブロブやファイルバージョンが参照しているオブジェクトバージョンは既存の内容のため削除しません
+func (c *CompleteUploadCommand) deleteCorruptedVersion(ctx context.Context, storageKey valueobject.StorageKey, minioVersionID string) {
+	referenced, err := c.blobService.IsReferenced(ctx, storageKey, minioVersionID)
+	if err != nil {
+		slog.Error("failed to check references of corrupted upload",
+			"storage_key", storageKey.String(),
+			"version_id", minioVersionID,
+			"error", err,
+		)
+		return
+	}
+	if referenced {
+		slog.Warn("kept corrupted upload: object version is referenced by existing content",
+			"storage_key", storageKey.String(),
+			"version_id", minioVersionID,
+		)
+		return
+	}
+
+	if err := c.storageService.DeleteObjectVersion(ctx, storageKey.String(), minioVersionID); err != nil {
+		slog.Error("failed to delete corrupted upload",
+			"storage_key", storageKey.String(),
+			"version_id", minioVersionID,
+			"error", err,
+		)
+	}
+}
+
+// sessionOutput はセッションの状態から完了処理の出力を作成します
+func sessionOutput(session *entity.UploadSession) *CompleteUploadOutput {
+	return &CompleteUploadOutput{
+		FileID:    session.FileID,
+		SessionID: session.ID,
+		Completed: session.IsCompleted(),
+		Verifying: session.IsVerifying(),
+	}
+}
+
+// coversExpectedChecksum は申告されたチェックサムのアルゴリズムのダイジェストが揃っているかを判定します
+func coversExpectedChecksum(session *entity.UploadSession, digest *service.ContentDigest) bool {
+	if session.ExpectedChecksum == nil {
+		return true
+	}
+	switch session.ExpectedChecksum.Algorithm() {
+	case valueobject.ChecksumAlgorithmSHA256:
+		return digest.SHA256 != ""
+	case valueobject.ChecksumAlgorithmCRC32C:
+		return digest.CRC32C != ""
+	default:
+		return false
+	}
+}
+
 // parseStorageKey はストレージキー文字列をvalue objectに変換します
 func parseStorageKey(key string) (valueobject.StorageKey, error) {
 	return valueobject.NewStorageKeyFromString(key)
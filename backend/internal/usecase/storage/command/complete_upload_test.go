@@ -12,17 +12,26 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/command"
 	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
 	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
 )
 
+const testChecksum = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+var testDigest = &service.ContentDigest{SHA256: testChecksum, CRC32C: "0a0b0c0d"}
+
 type completeUploadTestDeps struct {
 	fileRepo          *mocks.MockFileRepository
 	fileVersionRepo   *mocks.MockFileVersionRepository
 	uploadSessionRepo *mocks.MockUploadSessionRepository
 	uploadPartRepo    *mocks.MockUploadPartRepository
+	storageUsageRepo  *mocks.MockStorageUsageRepository
+	blobService       *mocks.MockBlobService
+	storageService    *mocks.MockStorageService
+	thumbnailService  *mocks.MockThumbnailService
 	txManager         *mocks.MockTransactionManager
 }
 
@@ -33,6 +42,10 @@ func newCompleteUploadTestDeps(t *testing.T) *completeUploadTestDeps {
 		fileVersionRepo:   mocks.NewMockFileVersionRepository(t),
 		uploadSessionRepo: mocks.NewMockUploadSessionRepository(t),
 		uploadPartRepo:    mocks.NewMockUploadPartRepository(t),
+		storageUsageRepo:  mocks.NewMockStorageUsageRepository(t),
+		blobService:       mocks.NewMockBlobService(t),
+		storageService:    mocks.NewMockStorageService(t),
+		thumbnailService:  mocks.NewMockThumbnailService(t),
 		txManager:         mocks.NewMockTransactionManager(t),
 	}
 }
@@ -43,10 +56,23 @@ func (d *completeUploadTestDeps) newCommand() *command.CompleteUploadCommand {
 		d.fileVersionRepo,
 		d.uploadSessionRepo,
 		d.uploadPartRepo,
+		d.storageUsageRepo,
+		d.blobService,
+		d.storageService,
+		d.thumbnailService,
 		d.txManager,
 	)
 }
 
+// expectNewBlob は内容が未登録で、アップロードが新しいブロブとして登録されることを設定します
+func (d *completeUploadTestDeps) expectNewBlob(ctx context.Context, storageKey, versionID string, size int64) *entity.Blob {
+	key, _ := valueobject.NewStorageKeyFromString(storageKey)
+	blob := entity.NewBlob(testChecksum, key, versionID, size)
+	d.blobService.On("StoredDigest", ctx, storageKey, versionID).Return(testDigest, nil)
+	d.blobService.On("Register", ctx, testChecksum, key, versionID, size).Return(blob, false, nil)
+	return blob
+}
+
 func newUploadingFileEntity(ownerID, folderID uuid.UUID) *entity.File {
 	name, _ := valueobject.NewFileName("upload.txt")
 	mimeType, _ := valueobject.NewMimeType("text/plain")
@@ -108,10 +134,16 @@ func TestCompleteUploadCommand_Execute_SinglePart_ReturnsCompleted(t *testing.T)
 	}
 
 	deps.uploadSessionRepo.On("FindByStorageKey", ctx, mock.AnythingOfType("valueobject.StorageKey")).Return(session, nil)
+	deps.uploadSessionRepo.On("FindByIDForUpdate", ctx, session.ID).Return(session, nil)
 	deps.fileRepo.On("FindByID", ctx, session.FileID).Return(file, nil)
-	deps.fileVersionRepo.On("Create", ctx, mock.AnythingOfType("*entity.FileVersion")).Return(nil)
+	blob := deps.expectNewBlob(ctx, storageKey, "v1", 1024)
+	deps.fileVersionRepo.On("Create", ctx, mock.MatchedBy(func(v *entity.FileVersion) bool {
+		return v.BlobID != nil && *v.BlobID == blob.ID && v.Checksum == testChecksum && v.MinioVersionID == "v1"
+	})).Return(nil)
 	deps.fileRepo.On("Update", ctx, file).Return(nil)
 	deps.fileRepo.On("UpdateStatus", ctx, file.ID, entity.FileStatusActive).Return(nil)
+	deps.storageUsageRepo.On("AddUsedBytes", ctx, ownerID, int64(1024)).Return(nil)
+	deps.thumbnailService.On("Schedule", ctx, file, mock.AnythingOfType("*entity.FileVersion")).Return(nil)
 	deps.uploadSessionRepo.On("Update", ctx, session).Return(nil)
 
 	cmd := deps.newCommand()
@@ -122,6 +154,416 @@ func TestCompleteUploadCommand_Execute_SinglePart_ReturnsCompleted(t *testing.T)
 	assert.True(t, output.Completed)
 	assert.Equal(t, session.FileID, output.FileID)
 	assert.Equal(t, session.ID, output.SessionID)
+	deps.storageService.AssertNotCalled(t, "DeleteObjectVersion", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCompleteUploadCommand_Execute_DuplicateContent_SharesExistingBlob(t *testing.T) {
+	ctx := context.Background()
+	deps := newCompleteUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	folderID := uuid.New()
+	file := newUploadingFileEntity(ownerID, folderID)
+	session := newPendingSession(file.ID, ownerID, folderID)
+
+	storageKey := file.StorageKey.String()
+	input := command.CompleteUploadInput{
+		StorageKey:     storageKey,
+		MinioVersionID: "v1",
+		Size:           1024,
+		ETag:           "etag-abc",
+	}
+	existing := entity.NewBlob(testChecksum, valueobject.NewStorageKey(uuid.New()), "original-v1", 1024)
+	existing.RefCount = 2
+
+	deps.uploadSessionRepo.On("FindByStorageKey", ctx, mock.AnythingOfType("valueobject.StorageKey")).Return(session, nil)
+	deps.uploadSessionRepo.On("FindByIDForUpdate", ctx, session.ID).Return(session, nil)
+	deps.fileRepo.On("FindByID", ctx, session.FileID).Return(file, nil)
+	deps.blobService.On("StoredDigest", ctx, storageKey, "v1").Return(testDigest, nil)
+	deps.blobService.On("Register", ctx, testChecksum, file.StorageKey, "v1", int64(1024)).Return(existing, true, nil)
+	deps.fileVersionRepo.On("Create", ctx, mock.MatchedBy(func(v *entity.FileVersion) bool {
+		// 内容は既存ブロブのオブジェクトバージョンを参照する
+		return *v.BlobID == existing.ID && v.StorageKey == existing.StorageKey && v.MinioVersionID == "original-v1"
+	})).Return(nil)
+	deps.fileRepo.On("Update", ctx, file).Return(nil)
+	deps.fileRepo.On("UpdateStatus", ctx, file.ID, entity.FileStatusActive).Return(nil)
+	// 重複排除されても所有者の使用量は論理サイズで加算される
+	deps.storageUsageRepo.On("AddUsedBytes", ctx, ownerID, int64(1024)).Return(nil)
+	deps.thumbnailService.On("Schedule", ctx, file, mock.AnythingOfType("*entity.FileVersion")).Return(nil)
+	deps.uploadSessionRepo.On("Update", ctx, session).Return(nil)
+	// アップロードされたオブジェクトバージョンは不要になるため削除される
+	deps.storageService.On("DeleteObjectVersion", ctx, storageKey, "v1").Return(nil)
+
+	output, err := deps.newCommand().Execute(ctx, input)
+
+	require.NoError(t, err)
+	assert.True(t, output.Completed)
+	assert.Equal(t, entity.FileStatusActive, file.Status)
+}
+
+func TestCompleteUploadCommand_Execute_UploadIsRegisteredBlob_KeepsObjectVersion(t *testing.T) {
+	ctx := context.Background()
+	deps := newCompleteUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	folderID := uuid.New()
+	file := newUploadingFileEntity(ownerID, folderID)
+	session := newPendingSession(file.ID, ownerID, folderID)
+
+	storageKey := file.StorageKey.String()
+	input := command.CompleteUploadInput{
+		StorageKey:     storageKey,
+		MinioVersionID: "v1",
+		Size:           1024,
+		ETag:           "etag-abc",
+	}
+
+	deps.uploadSessionRepo.On("FindByStorageKey", ctx, mock.AnythingOfType("valueobject.StorageKey")).Return(session, nil)
+	deps.uploadSessionRepo.On("FindByIDForUpdate", ctx, session.ID).Return(session, nil)
+	deps.fileRepo.On("FindByID", ctx, session.FileID).Return(file, nil)
+	deps.blobService.On("StoredDigest", ctx, storageKey, "v1").Return(testDigest, nil)
+	// 既存のブロブがこのオブジェクトバージョンそのもの
+	deps.blobService.On("Register", ctx, testChecksum, file.StorageKey, "v1", int64(1024)).Return(nil, false, service.ErrObjectVersionRegistered)
+
+	output, err := deps.newCommand().Execute(ctx, input)
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeValidationError, appErr.Code)
+	deps.storageService.AssertNotCalled(t, "DeleteObjectVersion", mock.Anything, mock.Anything, mock.Anything)
+	deps.fileVersionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestCompleteUploadCommand_Execute_StoredDigestFailure_ReturnsInternalError(t *testing.T) {
+	ctx := context.Background()
+	deps := newCompleteUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	folderID := uuid.New()
+	file := newUploadingFileEntity(ownerID, folderID)
+	session := newPendingSession(file.ID, ownerID, folderID)
+
+	input := command.CompleteUploadInput{
+		StorageKey:     file.StorageKey.String(),
+		MinioVersionID: "v1",
+		Size:           1024,
+		ETag:           "etag-abc",
+	}
+
+	deps.uploadSessionRepo.On("FindByStorageKey", ctx, mock.AnythingOfType("valueobject.StorageKey")).Return(session, nil)
+	deps.fileRepo.On("FindByID", ctx, session.FileID).Return(file, nil)
+	deps.blobService.On("StoredDigest", ctx, file.StorageKey.String(), "v1").Return(nil, errors.New("connection reset"))
+
+	output, err := deps.newCommand().Execute(ctx, input)
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeInternalError, appErr.Code)
+	deps.fileVersionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestCompleteUploadCommand_Execute_NoRecordedChecksum_StartsBackgroundVerification(t *testing.T) {
+	ctx := context.Background()
+	deps := newCompleteUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	folderID := uuid.New()
+	file := newUploadingFileEntity(ownerID, folderID)
+	session := newPendingSession(file.ID, ownerID, folderID)
+
+	storageKey := file.StorageKey.String()
+	input := command.CompleteUploadInput{
+		StorageKey:     storageKey,
+		MinioVersionID: "v1",
+		Size:           1024,
+		ETag:           "etag-abc",
+	}
+
+	deps.uploadSessionRepo.On("FindByStorageKey", ctx, mock.AnythingOfType("valueobject.StorageKey")).Return(session, nil)
+	deps.fileRepo.On("FindByID", ctx, session.FileID).Return(file, nil)
+	// MinIOにチェックサムが記録されていない場合は内容を読み込まずに検証待ちにする
+	deps.blobService.On("StoredDigest", ctx, storageKey, "v1").Return(nil, nil)
+	deps.uploadSessionRepo.On("FindByIDForUpdate", ctx, session.ID).Return(session, nil)
+	deps.uploadSessionRepo.On("Update", ctx, mock.MatchedBy(func(s *entity.UploadSession) bool {
+		return s.IsVerifying() && s.ReceivedVersionID == "v1" && s.ReceivedSize == 1024
+	})).Return(nil)
+
+	output, err := deps.newCommand().Execute(ctx, input)
+
+	require.NoError(t, err)
+	assert.False(t, output.Completed)
+	assert.True(t, output.Verifying)
+	assert.Equal(t, entity.FileStatusUploading, file.Status)
+	deps.blobService.AssertNotCalled(t, "ComputeDigest", mock.Anything, mock.Anything, mock.Anything)
+	deps.fileVersionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestCompleteUploadCommand_Execute_ExpectedCRC32CNotRecorded_StartsBackgroundVerification(t *testing.T) {
+	ctx := context.Background()
+	deps := newCompleteUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	folderID := uuid.New()
+	file := newUploadingFileEntity(ownerID, folderID)
+	session := newPendingSession(file.ID, ownerID, folderID)
+	expected, _ := valueobject.NewContentChecksum("crc32c", "0a0b0c0d")
+	session.SetExpectedChecksum(&expected)
+
+	storageKey := file.StorageKey.String()
+	input := command.CompleteUploadInput{
+		StorageKey:     storageKey,
+		MinioVersionID: "v1",
+		Size:           1024,
+		ETag:           "etag-abc",
+	}
+
+	deps.uploadSessionRepo.On("FindByStorageKey", ctx, mock.AnythingOfType("valueobject.StorageKey")).Return(session, nil)
+	deps.fileRepo.On("FindByID", ctx, session.FileID).Return(file, nil)
+	// SHA-256のみ記録されている場合、申告されたCRC32Cは照合できない
+	deps.blobService.On("StoredDigest", ctx, storageKey, "v1").
+		Return(&service.ContentDigest{SHA256: testChecksum}, nil)
+	deps.uploadSessionRepo.On("FindByIDForUpdate", ctx, session.ID).Return(session, nil)
+	deps.uploadSessionRepo.On("Update", ctx, session).Return(nil)
+
+	output, err := deps.newCommand().Execute(ctx, input)
+
+	require.NoError(t, err)
+	assert.True(t, output.Verifying)
+	assert.Equal(t, entity.UploadSessionStatusVerifying, session.Status)
+}
+
+func TestCompleteUploadCommand_Execute_VerifyingSession_ReturnsIdempotentVerifying(t *testing.T) {
+	ctx := context.Background()
+	deps := newCompleteUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	folderID := uuid.New()
+	file := newUploadingFileEntity(ownerID, folderID)
+	session := newPendingSession(file.ID, ownerID, folderID)
+	require.NoError(t, session.StartVerification("v1", 1024))
+
+	deps.uploadSessionRepo.On("FindByStorageKey", ctx, mock.AnythingOfType("valueobject.StorageKey")).Return(session, nil)
+
+	output, err := deps.newCommand().Execute(ctx, command.CompleteUploadInput{
+		StorageKey:     file.StorageKey.String(),
+		MinioVersionID: "v1",
+		Size:           1024,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, output.Completed)
+	assert.True(t, output.Verifying)
+}
+
+func TestCompleteUploadCommand_VerifySession_ComputesDigestAndCompletes(t *testing.T) {
+	ctx := context.Background()
+	deps := newCompleteUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	folderID := uuid.New()
+	file := newUploadingFileEntity(ownerID, folderID)
+	session := newPendingSession(file.ID, ownerID, folderID)
+	require.NoError(t, session.StartVerification("v1", 1024))
+
+	storageKey := file.StorageKey.String()
+	key, _ := valueobject.NewStorageKeyFromString(storageKey)
+	blob := entity.NewBlob(testChecksum, key, "v1", 1024)
+
+	deps.fileRepo.On("FindByID", ctx, session.FileID).Return(file, nil)
+	deps.blobService.On("ComputeDigest", ctx, storageKey, "v1").Return(testDigest, nil)
+	deps.uploadSessionRepo.On("FindByIDForUpdate", ctx, session.ID).Return(session, nil)
+	deps.blobService.On("Register", ctx, testChecksum, key, "v1", int64(1024)).Return(blob, false, nil)
+	deps.fileVersionRepo.On("Create", ctx, mock.MatchedBy(func(v *entity.FileVersion) bool {
+		return v.Checksum == testChecksum && v.MinioVersionID == "v1" && v.Size == 1024
+	})).Return(nil)
+	deps.fileRepo.On("Update", ctx, file).Return(nil)
+	deps.fileRepo.On("UpdateStatus", ctx, file.ID, entity.FileStatusActive).Return(nil)
+	deps.storageUsageRepo.On("AddUsedBytes", ctx, ownerID, int64(1024)).Return(nil)
+	deps.uploadSessionRepo.On("Update", ctx, session).Return(nil)
+	deps.thumbnailService.On("Schedule", ctx, file, mock.AnythingOfType("*entity.FileVersion")).Return(nil)
+
+	output, err := deps.newCommand().VerifySession(ctx, session)
+
+	require.NoError(t, err)
+	assert.True(t, output.Completed)
+	assert.False(t, output.Verifying)
+	assert.Equal(t, entity.FileStatusActive, file.Status)
+	assert.Equal(t, entity.UploadSessionStatusCompleted, session.Status)
+}
+
+func TestCompleteUploadCommand_VerifySession_ChecksumMismatch_MarksFileUploadFailed(t *testing.T) {
+	ctx := context.Background()
+	deps := newCompleteUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	folderID := uuid.New()
+	file := newUploadingFileEntity(ownerID, folderID)
+	session := newPendingSession(file.ID, ownerID, folderID)
+	expected, _ := valueobject.NewContentChecksum("crc32c", "ffffffff")
+	session.SetExpectedChecksum(&expected)
+	require.NoError(t, session.StartVerification("v1", 1024))
+
+	storageKey := file.StorageKey.String()
+	deps.fileRepo.On("FindByID", ctx, session.FileID).Return(file, nil)
+	deps.blobService.On("ComputeDigest", ctx, storageKey, "v1").Return(testDigest, nil)
+	deps.uploadSessionRepo.On("FindByIDForUpdate", ctx, session.ID).Return(session, nil)
+	deps.uploadSessionRepo.On("Update", ctx, session).Return(nil)
+	deps.fileRepo.On("UpdateStatus", ctx, file.ID, entity.FileStatusUploadFailed).Return(nil)
+	deps.blobService.On("IsReferenced", ctx, file.StorageKey, "v1").Return(false, nil)
+	deps.storageService.On("DeleteObjectVersion", ctx, storageKey, "v1").Return(nil)
+
+	_, err := deps.newCommand().VerifySession(ctx, session)
+
+	require.Error(t, err)
+	assert.Equal(t, entity.FileStatusUploadFailed, file.Status)
+	assert.Equal(t, entity.UploadSessionStatusAborted, session.Status)
+	deps.fileVersionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestCompleteUploadCommand_Execute_ExpectedChecksumMatches_ReturnsCompleted(t *testing.T) {
+	ctx := context.Background()
+	deps := newCompleteUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	folderID := uuid.New()
+	file := newUploadingFileEntity(ownerID, folderID)
+	session := newPendingSession(file.ID, ownerID, folderID)
+	expected, _ := valueobject.NewContentChecksum("crc32c", testDigest.CRC32C)
+	session.SetExpectedChecksum(&expected)
+
+	storageKey := file.StorageKey.String()
+	input := command.CompleteUploadInput{
+		StorageKey:     storageKey,
+		MinioVersionID: "v1",
+		Size:           1024,
+		ETag:           "etag-abc",
+	}
+
+	deps.uploadSessionRepo.On("FindByStorageKey", ctx, mock.AnythingOfType("valueobject.StorageKey")).Return(session, nil)
+	deps.uploadSessionRepo.On("FindByIDForUpdate", ctx, session.ID).Return(session, nil)
+	deps.fileRepo.On("FindByID", ctx, session.FileID).Return(file, nil)
+	deps.expectNewBlob(ctx, storageKey, "v1", 1024)
+	deps.fileVersionRepo.On("Create", ctx, mock.AnythingOfType("*entity.FileVersion")).Return(nil)
+	deps.fileRepo.On("Update", ctx, file).Return(nil)
+	deps.fileRepo.On("UpdateStatus", ctx, file.ID, entity.FileStatusActive).Return(nil)
+	deps.storageUsageRepo.On("AddUsedBytes", ctx, ownerID, int64(1024)).Return(nil)
+	deps.thumbnailService.On("Schedule", ctx, file, mock.AnythingOfType("*entity.FileVersion")).Return(nil)
+	deps.uploadSessionRepo.On("Update", ctx, session).Return(nil)
+
+	output, err := deps.newCommand().Execute(ctx, input)
+
+	require.NoError(t, err)
+	assert.True(t, output.Completed)
+	assert.Equal(t, entity.UploadSessionStatusCompleted, session.Status)
+}
+
+func TestCompleteUploadCommand_Execute_ExpectedChecksumMismatch_MarksFileUploadFailed(t *testing.T) {
+	ctx := context.Background()
+	deps := newCompleteUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	folderID := uuid.New()
+	file := newUploadingFileEntity(ownerID, folderID)
+	session := newPendingSession(file.ID, ownerID, folderID)
+	expected, _ := valueobject.NewContentChecksum("sha256", "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824")
+	session.SetExpectedChecksum(&expected)
+
+	storageKey := file.StorageKey.String()
+	input := command.CompleteUploadInput{
+		StorageKey:     storageKey,
+		MinioVersionID: "v1",
+		Size:           1024,
+		ETag:           "etag-abc",
+	}
+
+	deps.uploadSessionRepo.On("FindByStorageKey", ctx, mock.AnythingOfType("valueobject.StorageKey")).Return(session, nil)
+	deps.uploadSessionRepo.On("FindByIDForUpdate", ctx, session.ID).Return(session, nil)
+	deps.fileRepo.On("FindByID", ctx, session.FileID).Return(file, nil)
+	deps.blobService.On("StoredDigest", ctx, storageKey, "v1").Return(testDigest, nil)
+	deps.uploadSessionRepo.On("Update", ctx, session).Return(nil)
+	deps.fileRepo.On("UpdateStatus", ctx, file.ID, entity.FileStatusUploadFailed).Return(nil)
+	// 破損した内容は保存しない
+	deps.blobService.On("IsReferenced", ctx, file.StorageKey, "v1").Return(false, nil)
+	deps.storageService.On("DeleteObjectVersion", ctx, storageKey, "v1").Return(nil)
+
+	output, err := deps.newCommand().Execute(ctx, input)
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeValidationError, appErr.Code)
+	assert.Equal(t, entity.FileStatusUploadFailed, file.Status)
+	assert.Equal(t, entity.UploadSessionStatusAborted, session.Status)
+	deps.fileVersionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	deps.storageUsageRepo.AssertNotCalled(t, "AddUsedBytes", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCompleteUploadCommand_Execute_NewVersionChecksumMismatch_KeepsFileActive(t *testing.T) {
+	ctx := context.Background()
+	deps := newCompleteUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	folderID := uuid.New()
+	file := newUploadingFileEntity(ownerID, folderID)
+	require.NoError(t, file.Activate())
+	session := entity.NewVersionUploadSession(file, ownerID, 2048, nil)
+	expected, _ := valueobject.NewContentChecksum("crc32c", "ffffffff")
+	session.SetExpectedChecksum(&expected)
+
+	storageKey := file.StorageKey.String()
+	input := command.CompleteUploadInput{
+		StorageKey:     storageKey,
+		MinioVersionID: "v2",
+		Size:           2048,
+		ETag:           "etag-v2",
+	}
+
+	deps.uploadSessionRepo.On("FindByStorageKey", ctx, mock.AnythingOfType("valueobject.StorageKey")).Return(session, nil)
+	deps.uploadSessionRepo.On("FindByIDForUpdate", ctx, session.ID).Return(session, nil)
+	deps.fileRepo.On("FindByID", ctx, session.FileID).Return(file, nil)
+	deps.blobService.On("StoredDigest", ctx, storageKey, "v2").Return(testDigest, nil)
+	deps.uploadSessionRepo.On("Update", ctx, session).Return(nil)
+	deps.blobService.On("IsReferenced", ctx, file.StorageKey, "v2").Return(false, nil)
+	deps.storageService.On("DeleteObjectVersion", ctx, storageKey, "v2").Return(nil)
+
+	_, err := deps.newCommand().Execute(ctx, input)
+
+	require.Error(t, err)
+	assert.Equal(t, entity.FileStatusActive, file.Status)
+	assert.Equal(t, entity.UploadSessionStatusAborted, session.Status)
+	deps.fileRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCompleteUploadCommand_Execute_ChecksumMismatchOnReferencedVersion_KeepsObjectVersion(t *testing.T) {
+	ctx := context.Background()
+	deps := newCompleteUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	folderID := uuid.New()
+	file := newUploadingFileEntity(ownerID, folderID)
+	require.NoError(t, file.Activate())
+	session := entity.NewVersionUploadSession(file, ownerID, 1024, nil)
+	expected, _ := valueobject.NewContentChecksum("crc32c", "ffffffff")
+	session.SetExpectedChecksum(&expected)
+
+	// 完了通知が指すのは既存バージョンの内容
+	storageKey := file.StorageKey.String()
+	deps.fileRepo.On("FindByID", ctx, session.FileID).Return(file, nil)
+	deps.blobService.On("StoredDigest", ctx, storageKey, "v1").Return(testDigest, nil)
+	deps.uploadSessionRepo.On("FindByIDForUpdate", ctx, session.ID).Return(session, nil)
+	deps.uploadSessionRepo.On("Update", ctx, session).Return(nil)
+	deps.blobService.On("IsReferenced", ctx, file.StorageKey, "v1").Return(true, nil)
+
+	_, err := deps.newCommand().CompleteSession(ctx, session, "v1", 1024)
+
+	require.Error(t, err)
+	assert.Equal(t, entity.FileStatusActive, file.Status)
+	deps.storageService.AssertNotCalled(t, "DeleteObjectVersion", mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestCompleteUploadCommand_Execute_AlreadyCompleted_ReturnsIdempotentSuccess(t *testing.T) {
@@ -161,6 +603,50 @@ func TestCompleteUploadCommand_Execute_AlreadyCompleted_ReturnsIdempotentSuccess
 	assert.Equal(t, fileID, output.FileID)
 }
 
+func TestCompleteUploadCommand_Execute_LostCompletionRace_ReturnsIdempotentSuccess(t *testing.T) {
+	ctx := context.Background()
+	deps := newCompleteUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	folderID := uuid.New()
+	file := newUploadingFileEntity(ownerID, folderID)
+	session := newPendingSession(file.ID, ownerID, folderID)
+
+	// 読み込み後、ロック取得までの間に並行する完了処理がセッションを完了させた状態
+	lockedSession := entity.ReconstructUploadSession(
+		session.ID, session.FileID, ownerID, ownerID, folderID,
+		session.FileName, session.MimeType, session.TotalSize, session.StorageKey,
+		nil, false, 1, 1,
+		entity.UploadSessionStatusCompleted,
+		time.Now(), time.Now(), time.Now().Add(24*time.Hour),
+	)
+
+	storageKey := file.StorageKey.String()
+	input := command.CompleteUploadInput{
+		StorageKey:     storageKey,
+		MinioVersionID: "v1",
+		Size:           1024,
+		ETag:           "etag-abc",
+	}
+
+	deps.uploadSessionRepo.On("FindByStorageKey", ctx, mock.AnythingOfType("valueobject.StorageKey")).Return(session, nil)
+	deps.uploadSessionRepo.On("FindByIDForUpdate", ctx, session.ID).Return(lockedSession, nil)
+	deps.fileRepo.On("FindByID", ctx, session.FileID).Return(file, nil)
+	deps.blobService.On("StoredDigest", ctx, storageKey, "v1").Return(testDigest, nil)
+
+	cmd := deps.newCommand()
+	output, err := cmd.Execute(ctx, input)
+
+	require.NoError(t, err)
+	require.NotNil(t, output)
+	assert.True(t, output.Completed)
+	assert.Equal(t, session.FileID, output.FileID)
+	deps.fileVersionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	deps.storageUsageRepo.AssertNotCalled(t, "AddUsedBytes", mock.Anything, mock.Anything, mock.Anything)
+	deps.uploadSessionRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	deps.storageService.AssertNotCalled(t, "DeleteObjectVersion", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestCompleteUploadCommand_Execute_AbortedSession_ReturnsValidationError(t *testing.T) {
 	ctx := context.Background()
 	deps := newCompleteUploadTestDeps(t)
@@ -269,11 +755,18 @@ func TestCompleteUploadCommand_Execute_MultipartFinalPart_ReturnsCompleted(t *te
 	}
 
 	deps.uploadSessionRepo.On("FindByStorageKey", ctx, mock.AnythingOfType("valueobject.StorageKey")).Return(session, nil)
+	deps.uploadSessionRepo.On("FindByIDForUpdate", ctx, session.ID).Return(session, nil)
 	deps.fileRepo.On("FindByID", ctx, session.FileID).Return(file, nil)
 	deps.uploadPartRepo.On("Create", ctx, mock.AnythingOfType("*entity.UploadPart")).Return(nil)
-	deps.fileVersionRepo.On("Create", ctx, mock.AnythingOfType("*entity.FileVersion")).Return(nil)
+	deps.expectNewBlob(ctx, session.StorageKey.String(), "v1", session.TotalSize)
+	deps.fileVersionRepo.On("Create", ctx, mock.MatchedBy(func(v *entity.FileVersion) bool {
+		return v.Size == session.TotalSize
+	})).Return(nil)
 	deps.fileRepo.On("Update", ctx, file).Return(nil)
 	deps.fileRepo.On("UpdateStatus", ctx, file.ID, entity.FileStatusActive).Return(nil)
+	// 使用量は最終パーツではなく合計サイズで加算される
+	deps.storageUsageRepo.On("AddUsedBytes", ctx, ownerID, session.TotalSize).Return(nil)
+	deps.thumbnailService.On("Schedule", ctx, file, mock.AnythingOfType("*entity.FileVersion")).Return(nil)
 	deps.uploadSessionRepo.On("Update", ctx, session).Return(nil)
 
 	cmd := deps.newCommand()
@@ -282,4 +775,89 @@ func TestCompleteUploadCommand_Execute_MultipartFinalPart_ReturnsCompleted(t *te
 	require.NoError(t, err)
 	require.NotNil(t, output)
 	assert.True(t, output.Completed)
+	assert.Equal(t, session.TotalSize, file.Size)
+}
+
+func TestCompleteUploadCommand_Execute_NewVersionOfActiveFile_AddsVersion(t *testing.T) {
+	ctx := context.Background()
+	deps := newCompleteUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	uploaderID := uuid.New()
+	folderID := uuid.New()
+	file := newActiveFileEntity(ownerID, folderID)
+	file.CurrentVersion = 2
+	session := entity.NewVersionUploadSession(file, uploaderID, 2048, nil)
+
+	input := command.CompleteUploadInput{
+		StorageKey:     file.StorageKey.String(),
+		MinioVersionID: "v3",
+		Size:           2048,
+		ETag:           "etag-v3",
+	}
+
+	deps.uploadSessionRepo.On("FindByStorageKey", ctx, mock.AnythingOfType("valueobject.StorageKey")).Return(session, nil)
+	deps.uploadSessionRepo.On("FindByIDForUpdate", ctx, session.ID).Return(session, nil)
+	deps.fileRepo.On("FindByID", ctx, session.FileID).Return(file, nil)
+	deps.expectNewBlob(ctx, file.StorageKey.String(), "v3", 2048)
+	deps.fileVersionRepo.On("Create", ctx, mock.MatchedBy(func(v *entity.FileVersion) bool {
+		return v.VersionNumber == 3 && v.UploadedBy == uploaderID && v.Size == 2048
+	})).Return(nil)
+	deps.fileRepo.On("Update", ctx, file).Return(nil)
+	// 容量はアップロード者ではなくファイル所有者に計上される
+	deps.storageUsageRepo.On("AddUsedBytes", ctx, ownerID, int64(2048)).Return(nil)
+	// 新バージョンのサムネイル生成が登録される
+	deps.thumbnailService.On("Schedule", ctx, file, mock.MatchedBy(func(v *entity.FileVersion) bool {
+		return v.VersionNumber == 3
+	})).Return(nil)
+	deps.uploadSessionRepo.On("Update", ctx, session).Return(nil)
+
+	cmd := deps.newCommand()
+	output, err := cmd.Execute(ctx, input)
+
+	require.NoError(t, err)
+	require.NotNil(t, output)
+	assert.True(t, output.Completed)
+	assert.Equal(t, 3, file.CurrentVersion)
+	assert.Equal(t, int64(2048), file.Size)
+	assert.Equal(t, entity.FileStatusActive, file.Status)
+	deps.fileRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCompleteUploadCommand_Execute_ThumbnailScheduleFailure_StillCompletes(t *testing.T) {
+	ctx := context.Background()
+	deps := newCompleteUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	folderID := uuid.New()
+	file := newUploadingFileEntity(ownerID, folderID)
+	file.MimeType = valueobject.MimeTypeImagePNG
+	session := newPendingSession(file.ID, ownerID, folderID)
+
+	storageKey := file.StorageKey.String()
+	input := command.CompleteUploadInput{
+		StorageKey:     storageKey,
+		MinioVersionID: "v1",
+		Size:           1024,
+		ETag:           "etag-abc",
+	}
+
+	deps.uploadSessionRepo.On("FindByStorageKey", ctx, mock.AnythingOfType("valueobject.StorageKey")).Return(session, nil)
+	deps.uploadSessionRepo.On("FindByIDForUpdate", ctx, session.ID).Return(session, nil)
+	deps.fileRepo.On("FindByID", ctx, session.FileID).Return(file, nil)
+	deps.expectNewBlob(ctx, storageKey, "v1", 1024)
+	deps.fileVersionRepo.On("Create", ctx, mock.AnythingOfType("*entity.FileVersion")).Return(nil)
+	deps.fileRepo.On("Update", ctx, file).Return(nil)
+	deps.fileRepo.On("UpdateStatus", ctx, file.ID, entity.FileStatusActive).Return(nil)
+	deps.storageUsageRepo.On("AddUsedBytes", ctx, ownerID, int64(1024)).Return(nil)
+	deps.uploadSessionRepo.On("Update", ctx, session).Return(nil)
+	deps.thumbnailService.On("Schedule", ctx, file, mock.MatchedBy(func(v *entity.FileVersion) bool {
+		return v.FileID == file.ID && v.VersionNumber == 1
+	})).Return(errors.New("db error"))
+
+	output, err := deps.newCommand().Execute(ctx, input)
+
+	require.NoError(t, err)
+	assert.True(t, output.Completed)
+	assert.Equal(t, entity.FileStatusActive, file.Status)
 }
@@ -0,0 +1,207 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// CopyFileInput はファイルコピーの入力を定義します
+type CopyFileInput struct {
+	FileID              uuid.UUID
+	DestinationFolderID uuid.UUID
+	UserID              uuid.UUID
+}
+
+// CopyFileOutput はファイルコピーの出力を定義します
+type CopyFileOutput struct {
+	File *entity.File
+}
+
+// CopyFileCommand はファイルをサーバーサイドでコピーするコマンドです
+type CopyFileCommand struct {
+	fileRepo           repository.FileRepository
+	fileVersionRepo    repository.FileVersionRepository
+	folderRepo         repository.FolderRepository
+	storageUsageRepo   repository.StorageUsageRepository
+	storageService     service.StorageService
+	blobService        service.BlobService
+	quotaService       service.StorageQuotaService
+	permissionResolver authz.PermissionResolver
+	txManager          repository.TransactionManager
+}
+
+// NewCopyFileCommand は新しいCopyFileCommandを作成します
+func NewCopyFileCommand(
+	fileRepo repository.FileRepository,
+	fileVersionRepo repository.FileVersionRepository,
+	folderRepo repository.FolderRepository,
+	storageUsageRepo repository.StorageUsageRepository,
+	storageService service.StorageService,
+	blobService service.BlobService,
+	quotaService service.StorageQuotaService,
+	permissionResolver authz.PermissionResolver,
+	txManager repository.TransactionManager,
+) *CopyFileCommand {
+	return &CopyFileCommand{
+		fileRepo:           fileRepo,
+		fileVersionRepo:    fileVersionRepo,
+		folderRepo:         folderRepo,
+		storageUsageRepo:   storageUsageRepo,
+		storageService:     storageService,
+		blobService:        blobService,
+		quotaService:       quotaService,
+		permissionResolver: permissionResolver,
+		txManager:          txManager,
+	}
+}
+
+// Execute はファイルコピーを実行します
+// 最新バージョンの内容のみをコピーし、コピー先はバージョン1の新しいファイルになります
+func (c *CopyFileCommand) Execute(ctx context.Context, input CopyFileInput) (*CopyFileOutput, error) {
+	// 1. コピー元ファイル取得
+	file, err := c.fileRepo.FindByID(ctx, input.FileID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !file.IsActive() {
+		return nil, apperror.NewValidationError("only active files can be copied", nil)
+	}
+
+	// 2. コピー元の閲覧権限チェック
+	canRead, err := c.permissionResolver.HasPermission(ctx, input.UserID, authz.ResourceTypeFile, file.ID, authz.PermFileRead)
+	if err != nil {
+		return nil, err
+	}
+	if !canRead {
+		return nil, apperror.NewForbiddenError("not authorized to copy this file")
+	}
+
+	// 3. コピー先フォルダの存在と権限チェック
+	if _, err := c.folderRepo.FindByID(ctx, input.DestinationFolderID); err != nil {
+		return nil, err
+	}
+	canCreate, err := c.permissionResolver.HasPermission(ctx, input.UserID, authz.ResourceTypeFolder, input.DestinationFolderID, authz.PermFolderCreate)
+	if err != nil {
+		return nil, err
+	}
+	if !canCreate {
+		return nil, apperror.NewForbiddenError("not authorized to copy to this folder")
+	}
+
+	// 4. コピー先での同名ファイル存在チェック
+	exists, err := c.fileRepo.ExistsByNameAndFolder(ctx, file.Name, input.DestinationFolderID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, apperror.NewConflictError("file with same name already exists in destination folder")
+	}
+
+	copied, err := copyFileTo(ctx, copyFileDeps{
+		fileRepo:         c.fileRepo,
+		fileVersionRepo:  c.fileVersionRepo,
+		storageUsageRepo: c.storageUsageRepo,
+		storageService:   c.storageService,
+		blobService:      c.blobService,
+		quotaService:     c.quotaService,
+		txManager:        c.txManager,
+	}, file, input.DestinationFolderID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CopyFileOutput{File: copied}, nil
+}
+
+// copyFileDeps はファイル複製に必要な依存関係です
+type copyFileDeps struct {
+	fileRepo         repository.FileRepository
+	fileVersionRepo  repository.FileVersionRepository
+	storageUsageRepo repository.StorageUsageRepository
+	storageService   service.StorageService
+	blobService      service.BlobService
+	quotaService     service.StorageQuotaService
+	txManager        repository.TransactionManager
+}
+
+// copyFileTo はファイルの最新バージョンを複製し、コピー先フォルダに新しいファイルとして登録します
+// コピーしたファイルの所有者はコピーを実行したユーザーになります
+func copyFileTo(ctx context.Context, deps copyFileDeps, file *entity.File, folderID, userID uuid.UUID) (*entity.File, error) {
+	// 1. 最新バージョン取得
+	version, err := deps.fileVersionRepo.FindByFileAndVersion(ctx, file.ID, file.CurrentVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. クォータチェック（コピーの容量は実行ユーザーに計上される）
+	if err := deps.quotaService.EnsureCapacity(ctx, userID, version.Size); err != nil {
+		return nil, err
+	}
+
+	// 3. ブロブを共有していないバージョンはMinIO上でサーバーサイドコピー
+	// ブロブを共有しているバージョンは参照を追加するだけで、オブジェクトはコピーしない
+	copied := entity.NewFileWithID(uuid.New(), folderID, userID, file.Name, file.MimeType, version.Size)
+	storageKey := copied.StorageKey.String()
+	var minioVersionID string
+	if !version.IsShared() {
+		minioVersionID, err = deps.storageService.CopyObjectVersion(ctx, version.StorageKey.String(), version.MinioVersionID, storageKey)
+		if err != nil {
+			return nil, apperror.NewInternalError(err)
+		}
+	}
+
+	// 4. ファイルとバージョンを登録（トランザクション）
+	if err := copied.Activate(); err != nil {
+		return nil, err
+	}
+	err = deps.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := deps.fileRepo.Create(ctx, copied); err != nil {
+			return err
+		}
+
+		copiedVersion := entity.NewFileVersion(
+			copied.ID,
+			copied.CurrentVersion,
+			minioVersionID,
+			version.Size,
+			version.Checksum,
+			userID,
+		)
+		if version.IsShared() {
+			blob, err := deps.blobService.Acquire(ctx, *version.BlobID)
+			if err != nil {
+				return err
+			}
+			copiedVersion.ReferenceBlob(blob)
+		}
+		if err := deps.fileVersionRepo.Create(ctx, copiedVersion); err != nil {
+			return err
+		}
+
+		// 実行ユーザーのストレージ使用量を加算
+		return deps.storageUsageRepo.AddUsedBytes(ctx, userID, version.Size)
+	})
+	if err != nil {
+		// 登録に失敗した場合はコピーしたオブジェクトを削除
+		if !version.IsShared() {
+			if delErr := deps.storageService.DeleteObject(ctx, storageKey); delErr != nil {
+				slog.Error("failed to delete copied object",
+					"storage_key", storageKey,
+					"error", delErr,
+				)
+			}
+		}
+		return nil, err
+	}
+
+	return copied, nil
+}
@@ -0,0 +1,152 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/command"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
+)
+
+type copyFileTestDeps struct {
+	fileRepo           *mocks.MockFileRepository
+	fileVersionRepo    *mocks.MockFileVersionRepository
+	folderRepo         *mocks.MockFolderRepository
+	storageUsageRepo   *mocks.MockStorageUsageRepository
+	storageService     *mocks.MockStorageService
+	blobService        *mocks.MockBlobService
+	quotaService       *mocks.MockStorageQuotaService
+	permissionResolver *mocks.MockPermissionResolver
+	txManager          *mocks.MockTransactionManager
+}
+
+func newCopyFileTestDeps(t *testing.T) *copyFileTestDeps {
+	t.Helper()
+	return &copyFileTestDeps{
+		fileRepo:           mocks.NewMockFileRepository(t),
+		fileVersionRepo:    mocks.NewMockFileVersionRepository(t),
+		folderRepo:         mocks.NewMockFolderRepository(t),
+		storageUsageRepo:   mocks.NewMockStorageUsageRepository(t),
+		storageService:     mocks.NewMockStorageService(t),
+		blobService:        mocks.NewMockBlobService(t),
+		quotaService:       mocks.NewMockStorageQuotaService(t),
+		permissionResolver: mocks.NewMockPermissionResolver(t),
+		txManager:          mocks.NewMockTransactionManager(t),
+	}
+}
+
+func (d *copyFileTestDeps) newCommand() *command.CopyFileCommand {
+	return command.NewCopyFileCommand(
+		d.fileRepo,
+		d.fileVersionRepo,
+		d.folderRepo,
+		d.storageUsageRepo,
+		d.storageService,
+		d.blobService,
+		d.quotaService,
+		d.permissionResolver,
+		d.txManager,
+	)
+}
+
+func TestCopyFileCommand_Execute_ValidInput_CreatesCopyOwnedByUser(t *testing.T) {
+	ctx := context.Background()
+	deps := newCopyFileTestDeps(t)
+
+	ownerID := uuid.New()
+	userID := uuid.New()
+	file := newActiveFileEntity(ownerID, uuid.New())
+	file.CurrentVersion = 2
+	version := newStoredFileVersion(file.ID, 2, 1024)
+	dest := newFolderEntity(userID)
+
+	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFile, file.ID, authz.PermFileRead).Return(true, nil)
+	deps.folderRepo.On("FindByID", ctx, dest.ID).Return(dest, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFolder, dest.ID, authz.PermFolderCreate).Return(true, nil)
+	deps.fileRepo.On("ExistsByNameAndFolder", ctx, file.Name, dest.ID).Return(false, nil)
+	deps.fileVersionRepo.On("FindByFileAndVersion", ctx, file.ID, 2).Return(version, nil)
+	deps.quotaService.On("EnsureCapacity", ctx, userID, int64(1024)).Return(nil)
+	deps.storageService.On("CopyObjectVersion", ctx, version.StorageKey.String(), version.MinioVersionID, mock.AnythingOfType("string")).Return("copied-v1", nil)
+	deps.fileRepo.On("Create", ctx, mock.MatchedBy(func(f *entity.File) bool {
+		return f.FolderID == dest.ID && f.OwnerID == userID && f.IsActive() && f.ID != file.ID
+	})).Return(nil)
+	deps.fileVersionRepo.On("Create", ctx, mock.MatchedBy(func(v *entity.FileVersion) bool {
+		return v.VersionNumber == 1 && v.MinioVersionID == "copied-v1" && v.Size == 1024
+	})).Return(nil)
+	deps.storageUsageRepo.On("AddUsedBytes", ctx, userID, int64(1024)).Return(nil)
+
+	output, err := deps.newCommand().Execute(ctx, command.CopyFileInput{
+		FileID:              file.ID,
+		DestinationFolderID: dest.ID,
+		UserID:              userID,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, file.Name, output.File.Name)
+	assert.Equal(t, dest.ID, output.File.FolderID)
+	assert.Equal(t, 1, output.File.CurrentVersion)
+}
+
+func TestCopyFileCommand_Execute_NoReadPermission_ReturnsForbidden(t *testing.T) {
+	ctx := context.Background()
+	deps := newCopyFileTestDeps(t)
+
+	userID := uuid.New()
+	file := newActiveFileEntity(uuid.New(), uuid.New())
+
+	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFile, file.ID, authz.PermFileRead).Return(false, nil)
+
+	output, err := deps.newCommand().Execute(ctx, command.CopyFileInput{
+		FileID:              file.ID,
+		DestinationFolderID: uuid.New(),
+		UserID:              userID,
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeForbidden, appErr.Code)
+}
+
+func TestCopyFileCommand_Execute_SaveFails_DeletesCopiedObject(t *testing.T) {
+	ctx := context.Background()
+	deps := newCopyFileTestDeps(t)
+
+	userID := uuid.New()
+	file := newActiveFileEntity(userID, uuid.New())
+	version := newStoredFileVersion(file.ID, 1, 100)
+	dest := newFolderEntity(userID)
+	dbErr := errors.New("db error")
+
+	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFile, file.ID, authz.PermFileRead).Return(true, nil)
+	deps.folderRepo.On("FindByID", ctx, dest.ID).Return(dest, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFolder, dest.ID, authz.PermFolderCreate).Return(true, nil)
+	deps.fileRepo.On("ExistsByNameAndFolder", ctx, file.Name, dest.ID).Return(false, nil)
+	deps.fileVersionRepo.On("FindByFileAndVersion", ctx, file.ID, 1).Return(version, nil)
+	deps.quotaService.On("EnsureCapacity", ctx, userID, int64(100)).Return(nil)
+	deps.storageService.On("CopyObjectVersion", ctx, version.StorageKey.String(), version.MinioVersionID, mock.AnythingOfType("string")).Return("copied-v1", nil)
+	deps.fileRepo.On("Create", ctx, mock.AnythingOfType("*entity.File")).Return(dbErr)
+	deps.storageService.On("DeleteObject", ctx, mock.AnythingOfType("string")).Return(nil)
+
+	output, err := deps.newCommand().Execute(ctx, command.CopyFileInput{
+		FileID:              file.ID,
+		DestinationFolderID: dest.ID,
+		UserID:              userID,
+	})
+
+	require.ErrorIs(t, err, dbErr)
+	assert.Nil(t, output)
+}
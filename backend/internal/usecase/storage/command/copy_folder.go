@@ -0,0 +1,316 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// CopyFolderInput はフォルダコピーの入力を定義します
+type CopyFolderInput struct {
+	FolderID            uuid.UUID
+	DestinationFolderID uuid.UUID
+	UserID              uuid.UUID
+}
+
+// CopyFolderOutput はフォルダコピーの出力を定義します
+type CopyFolderOutput struct {
+	Folder      *entity.Folder // コピー先に作成されたルートフォルダ
+	FolderCount int
+	FileCount   int
+}
+
+// CopyFolderCommand はフォルダをサブツリーごとサーバーサイドでコピーするコマンドです
+type CopyFolderCommand struct {
+	folderRepo         repository.FolderRepository
+	folderClosureRepo  repository.FolderClosureRepository
+	fileRepo           repository.FileRepository
+	fileVersionRepo    repository.FileVersionRepository
+	storageUsageRepo   repository.StorageUsageRepository
+	relationshipRepo   authz.RelationshipRepository
+	storageService     service.StorageService
+	blobService        service.BlobService
+	quotaService       service.StorageQuotaService
+	permissionResolver authz.PermissionResolver
+	txManager          repository.TransactionManager
+}
+
+// NewCopyFolderCommand は新しいCopyFolderCommandを作成します
+func NewCopyFolderCommand(
+	folderRepo repository.FolderRepository,
+	folderClosureRepo repository.FolderClosureRepository,
+	fileRepo repository.FileRepository,
+	fileVersionRepo repository.FileVersionRepository,
+	storageUsageRepo repository.StorageUsageRepository,
+	relationshipRepo authz.RelationshipRepository,
+	storageService service.StorageService,
+	blobService service.BlobService,
+	quotaService service.StorageQuotaService,
+	permissionResolver authz.PermissionResolver,
+	txManager repository.TransactionManager,
+) *CopyFolderCommand {
+	return &CopyFolderCommand{
+		folderRepo:         folderRepo,
+		folderClosureRepo:  folderClosureRepo,
+		fileRepo:           fileRepo,
+		fileVersionRepo:    fileVersionRepo,
+		storageUsageRepo:   storageUsageRepo,
+		relationshipRepo:   relationshipRepo,
+		storageService:     storageService,
+		blobService:        blobService,
+		quotaService:       quotaService,
+		permissionResolver: permissionResolver,
+		txManager:          txManager,
+	}
+}
+
+// folderCopyPlan は検証済みのフォルダコピー対象です
+type folderCopyPlan struct {
+	source      *entity.Folder
+	destination *entity.Folder
+	descendants []*entity.Folder // 浅い順に並んだ配下のフォルダ
+	activeFiles []*entity.File
+}
+
+// ItemCount はコピーで作成されるフォルダとファイルの合計数を返します
+func (p *folderCopyPlan) ItemCount() int {
+	return 1 + len(p.descendants) + len(p.activeFiles)
+}
+
+// Execute はフォルダコピーを実行します
+// サブツリーのフォルダと閉包テーブルを再構築し、アクティブなファイルの最新バージョンを複製します
+func (c *CopyFolderCommand) Execute(ctx context.Context, input CopyFolderInput) (*CopyFolderOutput, error) {
+	plan, err := c.prepare(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	return c.apply(ctx, plan, input.UserID)
+}
+
+// prepare は権限・コピー先・クォータを検証し、コピー対象のサブツリーを収集します
+func (c *CopyFolderCommand) prepare(ctx context.Context, input CopyFolderInput) (*folderCopyPlan, error) {
+	// 1. コピー元フォルダ取得と閲覧権限チェック
+	source, err := c.folderRepo.FindByID(ctx, input.FolderID)
+	if err != nil {
+		return nil, err
+	}
+	canRead, err := c.permissionResolver.HasPermission(ctx, input.UserID, authz.ResourceTypeFolder, source.ID, authz.PermFolderRead)
+	if err != nil {
+		return nil, err
+	}
+	if !canRead {
+		return nil, apperror.NewForbiddenError("not authorized to copy this folder")
+	}
+
+	// 2. コピー先フォルダ取得と作成権限チェック
+	destination, err := c.folderRepo.FindByID(ctx, input.DestinationFolderID)
+	if err != nil {
+		return nil, err
+	}
+	canCreate, err := c.permissionResolver.HasPermission(ctx, input.UserID, authz.ResourceTypeFolder, destination.ID, authz.PermFolderCreate)
+	if err != nil {
+		return nil, err
+	}
+	if !canCreate {
+		return nil, apperror.NewForbiddenError("not authorized to copy to this folder")
+	}
+
+	// 3. 自身または子孫へのコピーを禁止
+	descendantIDs, err := c.folderClosureRepo.FindDescendantIDs(ctx, source.ID)
+	if err != nil {
+		return nil, err
+	}
+	if destination.ID == source.ID || containsID(descendantIDs, destination.ID) {
+		return nil, apperror.NewValidationError("cannot copy a folder into itself or its descendants", nil)
+	}
+
+	// 4. 深さ制限チェック
+	relativeDepths, err := c.folderClosureRepo.FindDescendantsWithDepth(ctx, source.ID)
+	if err != nil {
+		return nil, err
+	}
+	maxRelativeDepth := 0
+	for _, d := range relativeDepths {
+		if d > maxRelativeDepth {
+			maxRelativeDepth = d
+		}
+	}
+	if destination.Depth+1+maxRelativeDepth > entity.MaxFolderDepth {
+		return nil, apperror.NewValidationError(entity.ErrFolderMaxDepthExceeded.Error(), nil)
+	}
+
+	// 5. コピー先での同名フォルダ存在チェック
+	exists, err := c.folderRepo.ExistsByNameAndParent(ctx, source.Name, &destination.ID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, apperror.NewConflictError("folder with same name already exists in destination")
+	}
+
+	// 6. サブツリーのフォルダとファイルを取得
+	descendants, err := c.folderRepo.FindByIDs(ctx, descendantIDs)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(descendants, func(i, j int) bool {
+		return descendants[i].Depth < descendants[j].Depth
+	})
+
+	files, err := c.fileRepo.FindByFolderIDs(ctx, append([]uuid.UUID{source.ID}, descendantIDs...))
+	if err != nil {
+		return nil, err
+	}
+	activeFiles := make([]*entity.File, 0, len(files))
+	var totalSize int64
+	for _, file := range files {
+		if file.IsActive() {
+			activeFiles = append(activeFiles, file)
+			totalSize += file.Size
+		}
+	}
+
+	// 7. クォータチェック（途中で容量不足になることを避けるため事前に合計で確認）
+	if err := c.quotaService.EnsureCapacity(ctx, input.UserID, totalSize); err != nil {
+		return nil, err
+	}
+
+	return &folderCopyPlan{
+		source:      source,
+		destination: destination,
+		descendants: descendants,
+		activeFiles: activeFiles,
+	}, nil
+}
+
+// apply は検証済みのサブツリーをトランザクションで複製します
+func (c *CopyFolderCommand) apply(ctx context.Context, plan *folderCopyPlan, userID uuid.UUID) (*CopyFolderOutput, error) {
+	source, destination := plan.source, plan.destination
+
+	// 8. トランザクションでフォルダ階層とファイルを複製
+	fileDeps := copyFileDeps{
+		fileRepo:         c.fileRepo,
+		fileVersionRepo:  c.fileVersionRepo,
+		storageUsageRepo: c.storageUsageRepo,
+		storageService:   c.storageService,
+		blobService:      c.blobService,
+		quotaService:     c.quotaService,
+		txManager:        c.txManager,
+	}
+	var root *entity.Folder
+	var folderCount int
+	var copiedKeys []string
+	err := c.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		destinationPaths, err := c.folderClosureRepo.FindAncestorPaths(ctx, destination.ID)
+		if err != nil {
+			return err
+		}
+
+		// コピー元フォルダID -> 作成したフォルダ
+		copiedFolders := make(map[uuid.UUID]*entity.Folder, len(plan.descendants)+1)
+		// 作成したフォルダID -> 祖先パス（自己参照を除く）
+		ancestorPaths := make(map[uuid.UUID][]*entity.FolderPath, len(plan.descendants)+1)
+		ancestorPaths[destination.ID] = destinationPaths
+
+		copyOne := func(src *entity.Folder, parent *entity.Folder) error {
+			folder, err := entity.NewFolder(src.Name, &parent.ID, userID, parent.Depth+1)
+			if err != nil {
+				return apperror.NewValidationError(err.Error(), nil)
+			}
+			paths := []*entity.FolderPath{entity.NewFolderPath(parent.ID, folder.ID, 1)}
+			for _, path := range ancestorPaths[parent.ID] {
+				paths = append(paths, entity.NewFolderPath(path.AncestorID, folder.ID, path.PathLength+1))
+			}
+
+			if err := c.folderRepo.Create(ctx, folder); err != nil {
+				return err
+			}
+			if err := c.folderClosureRepo.InsertSelfReference(ctx, folder.ID); err != nil {
+				return err
+			}
+			if err := c.folderClosureRepo.InsertAncestorPaths(ctx, paths); err != nil {
+				return err
+			}
+			if err := c.relationshipRepo.Create(ctx, authz.NewParentRelationship(authz.ObjectTypeFolder, parent.ID, authz.ObjectTypeFolder, folder.ID)); err != nil {
+				return err
+			}
+			if err := c.relationshipRepo.Create(ctx, authz.NewOwnerRelationship(userID, authz.ObjectTypeFolder, folder.ID)); err != nil {
+				return err
+			}
+
+			copiedFolders[src.ID] = folder
+			ancestorPaths[folder.ID] = paths
+			return nil
+		}
+
+		// 8a. フォルダ階層を浅い順に複製
+		if err := copyOne(source, destination); err != nil {
+			return err
+		}
+		root = copiedFolders[source.ID]
+		for _, src := range plan.descendants {
+			if src.ParentID == nil {
+				continue
+			}
+			parent, ok := copiedFolders[*src.ParentID]
+			if !ok {
+				continue
+			}
+			if err := copyOne(src, parent); err != nil {
+				return err
+			}
+		}
+
+		// 8b. ファイルを複製
+		for _, file := range plan.activeFiles {
+			folder, ok := copiedFolders[file.FolderID]
+			if !ok {
+				continue
+			}
+			copied, err := copyFileTo(ctx, fileDeps, file, folder.ID, userID)
+			if err != nil {
+				return err
+			}
+			copiedKeys = append(copiedKeys, copied.StorageKey.String())
+		}
+
+		folderCount = len(copiedFolders)
+		return nil
+	})
+	if err != nil {
+		// ロールバックされたファイルのオブジェクトを削除
+		for _, key := range copiedKeys {
+			if delErr := c.storageService.DeleteObject(ctx, key); delErr != nil {
+				slog.Error("failed to delete copied object",
+					"storage_key", key,
+					"error", delErr,
+				)
+			}
+		}
+		return nil, err
+	}
+
+	return &CopyFolderOutput{
+		Folder:      root,
+		FolderCount: folderCount,
+		FileCount:   len(copiedKeys),
+	}, nil
+}
+
+// containsID はIDがスライスに含まれるかどうかを判定します
+func containsID(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
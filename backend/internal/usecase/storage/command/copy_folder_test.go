@@ -0,0 +1,165 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/command"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
+)
+
+type copyFolderTestDeps struct {
+	folderRepo         *mocks.MockFolderRepository
+	folderClosureRepo  *mocks.MockFolderClosureRepository
+	fileRepo           *mocks.MockFileRepository
+	fileVersionRepo    *mocks.MockFileVersionRepository
+	storageUsageRepo   *mocks.MockStorageUsageRepository
+	relationshipRepo   *mocks.MockRelationshipRepository
+	storageService     *mocks.MockStorageService
+	blobService        *mocks.MockBlobService
+	quotaService       *mocks.MockStorageQuotaService
+	permissionResolver *mocks.MockPermissionResolver
+	txManager          *mocks.MockTransactionManager
+}
+
+func newCopyFolderTestDeps(t *testing.T) *copyFolderTestDeps {
+	t.Helper()
+	return &copyFolderTestDeps{
+		folderRepo:         mocks.NewMockFolderRepository(t),
+		folderClosureRepo:  mocks.NewMockFolderClosureRepository(t),
+		fileRepo:           mocks.NewMockFileRepository(t),
+		fileVersionRepo:    mocks.NewMockFileVersionRepository(t),
+		storageUsageRepo:   mocks.NewMockStorageUsageRepository(t),
+		relationshipRepo:   mocks.NewMockRelationshipRepository(t),
+		storageService:     mocks.NewMockStorageService(t),
+		blobService:        mocks.NewMockBlobService(t),
+		quotaService:       mocks.NewMockStorageQuotaService(t),
+		permissionResolver: mocks.NewMockPermissionResolver(t),
+		txManager:          mocks.NewMockTransactionManager(t),
+	}
+}
+
+func (d *copyFolderTestDeps) newCommand() *command.CopyFolderCommand {
+	return command.NewCopyFolderCommand(
+		d.folderRepo,
+		d.folderClosureRepo,
+		d.fileRepo,
+		d.fileVersionRepo,
+		d.storageUsageRepo,
+		d.relationshipRepo,
+		d.storageService,
+		d.blobService,
+		d.quotaService,
+		d.permissionResolver,
+		d.txManager,
+	)
+}
+
+func TestCopyFolderCommand_Execute_ValidInput_RebuildsSubtreeAndCopiesFiles(t *testing.T) {
+	ctx := context.Background()
+	deps := newCopyFolderTestDeps(t)
+
+	userID := uuid.New()
+	source := newRootFolderEntity(userID)
+	child := newChildFolderEntity(userID, source.ID)
+	dest := newFolderEntity(userID)
+	file := newActiveFileEntity(userID, child.ID)
+	version := newStoredFileVersion(file.ID, 1, 1024)
+
+	deps.folderRepo.On("FindByID", ctx, source.ID).Return(source, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFolder, source.ID, authz.PermFolderRead).Return(true, nil)
+	deps.folderRepo.On("FindByID", ctx, dest.ID).Return(dest, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFolder, dest.ID, authz.PermFolderCreate).Return(true, nil)
+	deps.folderClosureRepo.On("FindDescendantIDs", ctx, source.ID).Return([]uuid.UUID{child.ID}, nil)
+	deps.folderClosureRepo.On("FindDescendantsWithDepth", ctx, source.ID).Return(map[uuid.UUID]int{child.ID: 1}, nil)
+	deps.folderRepo.On("ExistsByNameAndParent", ctx, source.Name, &dest.ID, userID).Return(false, nil)
+	deps.folderRepo.On("FindByIDs", ctx, []uuid.UUID{child.ID}).Return([]*entity.Folder{child}, nil)
+	deps.fileRepo.On("FindByFolderIDs", ctx, []uuid.UUID{source.ID, child.ID}).Return([]*entity.File{file}, nil)
+	deps.quotaService.On("EnsureCapacity", ctx, userID, file.Size).Return(nil)
+	deps.folderClosureRepo.On("FindAncestorPaths", ctx, dest.ID).Return([]*entity.FolderPath{}, nil)
+
+	var created []*entity.Folder
+	deps.folderRepo.On("Create", ctx, mock.AnythingOfType("*entity.Folder")).
+		Run(func(args mock.Arguments) { created = append(created, args.Get(1).(*entity.Folder)) }).
+		Return(nil)
+	deps.folderClosureRepo.On("InsertSelfReference", ctx, mock.Anything).Return(nil)
+	var insertedPaths [][]*entity.FolderPath
+	deps.folderClosureRepo.On("InsertAncestorPaths", ctx, mock.Anything).
+		Run(func(args mock.Arguments) { insertedPaths = append(insertedPaths, args.Get(1).([]*entity.FolderPath)) }).
+		Return(nil)
+	deps.relationshipRepo.On("Create", ctx, mock.Anything).Return(nil)
+
+	deps.fileVersionRepo.On("FindByFileAndVersion", ctx, file.ID, 1).Return(version, nil)
+	deps.quotaService.On("EnsureCapacity", ctx, userID, int64(1024)).Return(nil)
+	deps.storageService.On("CopyObjectVersion", ctx, version.StorageKey.String(), version.MinioVersionID, mock.AnythingOfType("string")).Return("copied-v1", nil)
+	var copiedFile *entity.File
+	deps.fileRepo.On("Create", ctx, mock.AnythingOfType("*entity.File")).
+		Run(func(args mock.Arguments) { copiedFile = args.Get(1).(*entity.File) }).
+		Return(nil)
+	deps.fileVersionRepo.On("Create", ctx, mock.AnythingOfType("*entity.FileVersion")).Return(nil)
+	deps.storageUsageRepo.On("AddUsedBytes", ctx, userID, int64(1024)).Return(nil)
+
+	output, err := deps.newCommand().Execute(ctx, command.CopyFolderInput{
+		FolderID:            source.ID,
+		DestinationFolderID: dest.ID,
+		UserID:              userID,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, output.FolderCount)
+	assert.Equal(t, 1, output.FileCount)
+
+	require.Len(t, created, 2)
+	copiedRoot, copiedChild := created[0], created[1]
+	assert.Equal(t, output.Folder, copiedRoot)
+	assert.Equal(t, dest.ID, *copiedRoot.ParentID)
+	assert.Equal(t, copiedRoot.ID, *copiedChild.ParentID)
+	assert.Equal(t, dest.Depth+2, copiedChild.Depth)
+
+	// The copied child is reachable from both the copied root and the destination.
+	require.Len(t, insertedPaths, 2)
+	childPaths := map[uuid.UUID]int{}
+	for _, p := range insertedPaths[1] {
+		childPaths[p.AncestorID] = p.PathLength
+	}
+	assert.Equal(t, map[uuid.UUID]int{copiedRoot.ID: 1, dest.ID: 2}, childPaths)
+
+	require.NotNil(t, copiedFile)
+	assert.Equal(t, copiedChild.ID, copiedFile.FolderID)
+}
+
+func TestCopyFolderCommand_Execute_IntoOwnDescendant_ReturnsValidationError(t *testing.T) {
+	ctx := context.Background()
+	deps := newCopyFolderTestDeps(t)
+
+	userID := uuid.New()
+	source := newRootFolderEntity(userID)
+	child := newChildFolderEntity(userID, source.ID)
+
+	deps.folderRepo.On("FindByID", ctx, source.ID).Return(source, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFolder, source.ID, authz.PermFolderRead).Return(true, nil)
+	deps.folderRepo.On("FindByID", ctx, child.ID).Return(child, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFolder, child.ID, authz.PermFolderCreate).Return(true, nil)
+	deps.folderClosureRepo.On("FindDescendantIDs", ctx, source.ID).Return([]uuid.UUID{child.ID}, nil)
+
+	output, err := deps.newCommand().Execute(ctx, command.CopyFolderInput{
+		FolderID:            source.ID,
+		DestinationFolderID: child.ID,
+		UserID:              userID,
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeValidationError, appErr.Code)
+}
@@ -2,9 +2,11 @@ package command
 
 import (
 	"context"
+	"sort"
 
 	"github.com/google/uuid"
 
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
 	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
@@ -18,20 +20,24 @@ type DeleteFolderInput struct {
 
 // DeleteFolderOutput はフォルダ削除の出力を定義します
 type DeleteFolderOutput struct {
+	ArchivedFolderID   uuid.UUID
 	DeletedFolderCount int
 	ArchivedFileCount  int
 }
 
-// DeleteFolderCommand はフォルダ削除コマンドです
+// DeleteFolderCommand はフォルダをサブツリーごとゴミ箱に移動するコマンドです
 type DeleteFolderCommand struct {
 	folderRepo              repository.FolderRepository
 	folderClosureRepo       repository.FolderClosureRepository
 	fileRepo                repository.FileRepository
 	fileVersionRepo         repository.FileVersionRepository
+	archivedFolderRepo      repository.ArchivedFolderRepository
+	archivedSubfolderRepo   repository.ArchivedSubfolderRepository
 	archivedFileRepo        repository.ArchivedFileRepository
 	archivedFileVersionRepo repository.ArchivedFileVersionRepository
 	txManager               repository.TransactionManager
 	userRepo                repository.UserRepository
+	permissionResolver      authz.PermissionResolver
 }
 
 // NewDeleteFolderCommand は新しいDeleteFolderCommandを作成します
@@ -40,24 +46,31 @@ func NewDeleteFolderCommand(
 	folderClosureRepo repository.FolderClosureRepository,
 	fileRepo repository.FileRepository,
 	fileVersionRepo repository.FileVersionRepository,
+	archivedFolderRepo repository.ArchivedFolderRepository,
+	archivedSubfolderRepo repository.ArchivedSubfolderRepository,
 	archivedFileRepo repository.ArchivedFileRepository,
 	archivedFileVersionRepo repository.ArchivedFileVersionRepository,
 	txManager repository.TransactionManager,
 	userRepo repository.UserRepository,
+	permissionResolver authz.PermissionResolver,
 ) *DeleteFolderCommand {
 	return &DeleteFolderCommand{
 		folderRepo:              folderRepo,
 		folderClosureRepo:       folderClosureRepo,
 		fileRepo:                fileRepo,
 		fileVersionRepo:         fileVersionRepo,
+		archivedFolderRepo:      archivedFolderRepo,
+		archivedSubfolderRepo:   archivedSubfolderRepo,
 		archivedFileRepo:        archivedFileRepo,
 		archivedFileVersionRepo: archivedFileVersionRepo,
 		txManager:               txManager,
 		userRepo:                userRepo,
+		permissionResolver:      permissionResolver,
 	}
 }
 
 // Execute はフォルダ削除を実行します
+// フォルダはサブツリーごとArchivedFolderとしてゴミ箱に移動し、配下のファイルはそのアーカイブフォルダに関連付けます
 func (c *DeleteFolderCommand) Execute(ctx context.Context, input DeleteFolderInput) (*DeleteFolderOutput, error) {
 	// 1. フォルダ取得
 	folder, err := c.folderRepo.FindByID(ctx, input.FolderID)
@@ -65,13 +78,22 @@ func (c *DeleteFolderCommand) Execute(ctx context.Context, input DeleteFolderInp
 		return nil, err
 	}
 
-	// 2. 所有者チェック
-	if !folder.IsOwnedBy(input.UserID) {
+	// 2. 権限チェック (folder:delete、ルートレベルのフォルダは root:delete)
+	requiredPermission := authz.PermFolderDelete
+	if folder.IsRoot() {
+		requiredPermission = authz.PermRootDelete
+	}
+	hasPermission, err := c.permissionResolver.HasPermission(ctx, input.UserID, authz.ResourceTypeFolder, folder.ID, requiredPermission)
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
 		return nil, apperror.NewForbiddenError("not authorized to delete this folder")
 	}
 
 	// 3. パーソナルフォルダチェック (R-FD009)
-	user, err := c.userRepo.FindByID(ctx, input.UserID)
+	// 共有されたフォルダを削除する場合もあるため、フォルダの所有者のパーソナルフォルダと比較する
+	user, err := c.userRepo.FindByID(ctx, folder.OwnerID)
 	if err != nil {
 		return nil, err
 	}
@@ -79,23 +101,66 @@ func (c *DeleteFolderCommand) Execute(ctx context.Context, input DeleteFolderInp
 		return nil, apperror.NewForbiddenError("personal folder cannot be deleted")
 	}
 
-	// 3. 削除対象のフォルダID一覧を取得（自身 + 子孫）
+	// 4. 削除対象のフォルダID一覧を取得（自身 + 子孫）
 	descendantIDs, err := c.folderClosureRepo.FindDescendantIDs(ctx, folder.ID)
 	if err != nil {
 		return nil, err
 	}
 	folderIDs := append([]uuid.UUID{folder.ID}, descendantIDs...)
 
-	// 4. 削除対象のファイル取得
+	// 5. 子孫フォルダを浅い順に取得（階層をゴミ箱に保持するため）
+	var descendants []*entity.Folder
+	if len(descendantIDs) > 0 {
+		descendants, err = c.folderRepo.FindByIDs(ctx, descendantIDs)
+		if err != nil {
+			return nil, err
+		}
+		sort.SliceStable(descendants, func(i, j int) bool {
+			return descendants[i].Depth < descendants[j].Depth
+		})
+	}
+
+	// 6. 削除対象のファイル取得
 	files, err := c.fileRepo.FindByFolderIDs(ctx, folderIDs)
 	if err != nil {
 		return nil, err
 	}
 
-	// 5. トランザクションで削除処理を実行
+	// 7. アーカイブデータを作成（パスは復元時の参考用）
+	rootPath, err := c.buildFolderPath(ctx, folder)
+	if err != nil {
+		return nil, err
+	}
+	archivedFolder := folder.ToArchived(rootPath, input.UserID)
+
+	folderPaths := map[uuid.UUID]string{folder.ID: rootPath}
+	archivedSubfolders := make([]*entity.ArchivedSubfolder, 0, len(descendants))
+	for _, d := range descendants {
+		if d.ParentID == nil {
+			continue
+		}
+		parentPath, ok := folderPaths[*d.ParentID]
+		if !ok {
+			continue
+		}
+		folderPaths[d.ID] = parentPath + "/" + d.Name.String()
+		archivedSubfolders = append(archivedSubfolders, d.ToArchivedSubfolder(archivedFolder.ID, d.Depth-folder.Depth))
+	}
+
+	// 8. トランザクションでゴミ箱への移動処理を実行
 	archivedFileCount := 0
 	err = c.txManager.WithTransaction(ctx, func(ctx context.Context) error {
-		// 5a. ファイルをアーカイブ（ゴミ箱へ移動）
+		// 8a. フォルダ階層をアーカイブ
+		if err := c.archivedFolderRepo.Create(ctx, archivedFolder); err != nil {
+			return err
+		}
+		if len(archivedSubfolders) > 0 {
+			if err := c.archivedSubfolderRepo.BulkCreate(ctx, archivedSubfolders); err != nil {
+				return err
+			}
+		}
+
+		// 8b. ファイルをアーカイブフォルダに関連付けてアーカイブ
 		for _, file := range files {
 			if !file.IsActive() {
 				continue
@@ -107,25 +172,8 @@ func (c *DeleteFolderCommand) Execute(ctx context.Context, input DeleteFolderInp
 				return err
 			}
 
-			// パスを構築（閉包テーブルを使用して正確なパスを取得）
-			originalPath, err := c.buildFilePath(ctx, file.FolderID, file.Name.String())
-			if err != nil {
-				return err
-			}
-
-			// ArchivedFile作成
-			archivedFile := entity.NewArchivedFile(
-				file.ID,
-				file.FolderID,
-				originalPath,
-				file.Name,
-				file.MimeType,
-				file.Size,
-				file.OwnerID,
-				file.CreatedBy,
-				file.StorageKey,
-				input.UserID,
-			)
+			archivedFile := file.ToArchived(folderPaths[file.FolderID]+"/"+file.Name.String(), input.UserID)
+			archivedFile.AttachToArchivedFolder(archivedFolder)
 
 			if err := c.archivedFileRepo.Create(ctx, archivedFile); err != nil {
 				return err
@@ -135,16 +183,7 @@ func (c *DeleteFolderCommand) Execute(ctx context.Context, input DeleteFolderInp
 			if len(versions) > 0 {
 				archivedVersions := make([]*entity.ArchivedFileVersion, len(versions))
 				for i, v := range versions {
-					archivedVersions[i] = entity.NewArchivedFileVersion(
-						archivedFile.ID,
-						v.ID,
-						v.VersionNumber,
-						v.MinioVersionID,
-						v.Size,
-						v.Checksum,
-						v.UploadedBy,
-						v.CreatedAt,
-					)
+					archivedVersions[i] = v.ToArchived(archivedFile.ID)
 				}
 
 				if err := c.archivedFileVersionRepo.BulkCreate(ctx, archivedVersions); err != nil {
@@ -163,12 +202,12 @@ func (c *DeleteFolderCommand) Execute(ctx context.Context, input DeleteFolderInp
 			archivedFileCount++
 		}
 
-		// 5b. 閉包テーブルからサブツリーのパスを削除
+		// 8c. 閉包テーブルからサブツリーのパスを削除
 		if err := c.folderClosureRepo.DeleteSubtreePaths(ctx, folder.ID); err != nil {
 			return err
 		}
 
-		// 5c. フォルダを削除（子孫から順に削除）
+		// 8d. フォルダを削除（子孫から順に削除）
 		// 深い順に並び替え（逆順）
 		for i := len(folderIDs) - 1; i >= 0; i-- {
 			if err := c.folderRepo.Delete(ctx, folderIDs[i]); err != nil {
@@ -184,32 +223,29 @@ func (c *DeleteFolderCommand) Execute(ctx context.Context, input DeleteFolderInp
 	}
 
 	return &DeleteFolderOutput{
+		ArchivedFolderID:   archivedFolder.ID,
 		DeletedFolderCount: len(folderIDs),
 		ArchivedFileCount:  archivedFileCount,
 	}, nil
 }
 
-// buildFilePath はファイルのフルパスを構築します
-// Note: ファイルは必ずフォルダに所属するため、folderIDは必須
-func (c *DeleteFolderCommand) buildFilePath(ctx context.Context, folderID uuid.UUID, fileName string) (string, error) {
-	// 祖先フォルダIDを取得（ルートから順）
-	ancestorIDs, err := c.folderClosureRepo.FindAncestorIDs(ctx, folderID)
+// buildFolderPath はフォルダのフルパスを構築します
+func (c *DeleteFolderCommand) buildFolderPath(ctx context.Context, folder *entity.Folder) (string, error) {
+	// 祖先フォルダIDを取得（親に近い順）
+	ancestorIDs, err := c.folderClosureRepo.FindAncestorIDs(ctx, folder.ID)
 	if err != nil {
 		return "", err
 	}
 
-	// 自身を含める
-	allIDs := append(ancestorIDs, folderID)
-
-	// フォルダ名を取得してパスを構築
+	// ルートから順にフォルダ名を連結
 	path := ""
-	for _, id := range allIDs {
-		folder, err := c.folderRepo.FindByID(ctx, id)
+	for i := len(ancestorIDs) - 1; i >= 0; i-- {
+		ancestor, err := c.folderRepo.FindByID(ctx, ancestorIDs[i])
 		if err != nil {
 			return "", err
 		}
-		path += "/" + folder.Name.String()
+		path += "/" + ancestor.Name.String()
 	}
 
-	return path + "/" + fileName, nil
+	return path + "/" + folder.Name.String(), nil
 }
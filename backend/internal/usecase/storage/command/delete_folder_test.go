@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/command"
@@ -23,10 +24,13 @@ type deleteFolderTestDeps struct {
 	folderClosureRepo       *mocks.MockFolderClosureRepository
 	fileRepo                *mocks.MockFileRepository
 	fileVersionRepo         *mocks.MockFileVersionRepository
+	archivedFolderRepo      *mocks.MockArchivedFolderRepository
+	archivedSubfolderRepo   *mocks.MockArchivedSubfolderRepository
 	archivedFileRepo        *mocks.MockArchivedFileRepository
 	archivedFileVersionRepo *mocks.MockArchivedFileVersionRepository
 	txManager               *mocks.MockTransactionManager
 	userRepo                *mocks.MockUserRepository
+	permissionResolver      *mocks.MockPermissionResolver
 }
 
 func newDeleteFolderTestDeps(t *testing.T) *deleteFolderTestDeps {
@@ -36,10 +40,13 @@ func newDeleteFolderTestDeps(t *testing.T) *deleteFolderTestDeps {
 		folderClosureRepo:       mocks.NewMockFolderClosureRepository(t),
 		fileRepo:                mocks.NewMockFileRepository(t),
 		fileVersionRepo:         mocks.NewMockFileVersionRepository(t),
+		archivedFolderRepo:      mocks.NewMockArchivedFolderRepository(t),
+		archivedSubfolderRepo:   mocks.NewMockArchivedSubfolderRepository(t),
 		archivedFileRepo:        mocks.NewMockArchivedFileRepository(t),
 		archivedFileVersionRepo: mocks.NewMockArchivedFileVersionRepository(t),
 		txManager:               mocks.NewMockTransactionManager(t),
 		userRepo:                mocks.NewMockUserRepository(t),
+		permissionResolver:      mocks.NewMockPermissionResolver(t),
 	}
 }
 
@@ -49,10 +56,13 @@ func (d *deleteFolderTestDeps) newCommand() *command.DeleteFolderCommand {
 		d.folderClosureRepo,
 		d.fileRepo,
 		d.fileVersionRepo,
+		d.archivedFolderRepo,
+		d.archivedSubfolderRepo,
 		d.archivedFileRepo,
 		d.archivedFileVersionRepo,
 		d.txManager,
 		d.userRepo,
+		d.permissionResolver,
 	)
 }
 
@@ -88,9 +98,12 @@ func TestDeleteFolderCommand_Execute_EmptyFolder_DeletesFolder(t *testing.T) {
 	}
 
 	deps.folderRepo.On("FindByID", ctx, folder.ID).Return(folder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFolder, folder.ID, authz.PermRootDelete).Return(true, nil)
 	deps.userRepo.On("FindByID", ctx, ownerID).Return(user, nil)
 	deps.folderClosureRepo.On("FindDescendantIDs", ctx, folder.ID).Return([]uuid.UUID{}, nil)
 	deps.fileRepo.On("FindByFolderIDs", ctx, []uuid.UUID{folder.ID}).Return([]*entity.File{}, nil)
+	deps.folderClosureRepo.On("FindAncestorIDs", ctx, folder.ID).Return([]uuid.UUID{}, nil)
+	deps.archivedFolderRepo.On("Create", ctx, mock.AnythingOfType("*entity.ArchivedFolder")).Return(nil)
 	deps.folderClosureRepo.On("DeleteSubtreePaths", ctx, folder.ID).Return(nil)
 	deps.folderRepo.On("Delete", ctx, folder.ID).Return(nil)
 
@@ -99,6 +112,7 @@ func TestDeleteFolderCommand_Execute_EmptyFolder_DeletesFolder(t *testing.T) {
 
 	require.NoError(t, err)
 	require.NotNil(t, output)
+	assert.NotEqual(t, uuid.Nil, output.ArchivedFolderID)
 	assert.Equal(t, 1, output.DeletedFolderCount)
 	assert.Equal(t, 0, output.ArchivedFileCount)
 }
@@ -129,13 +143,16 @@ func TestDeleteFolderCommand_Execute_FolderWithFiles_ArchivesFilesAndDeletesFold
 	}
 
 	deps.folderRepo.On("FindByID", ctx, folder.ID).Return(folder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFolder, folder.ID, authz.PermRootDelete).Return(true, nil)
 	deps.userRepo.On("FindByID", ctx, ownerID).Return(user, nil)
 	deps.folderClosureRepo.On("FindDescendantIDs", ctx, folder.ID).Return([]uuid.UUID{}, nil)
 	deps.fileRepo.On("FindByFolderIDs", ctx, []uuid.UUID{folder.ID}).Return([]*entity.File{file}, nil)
 	deps.fileVersionRepo.On("FindByFileID", ctx, file.ID).Return([]*entity.FileVersion{fileVersion}, nil)
 	deps.folderClosureRepo.On("FindAncestorIDs", ctx, folder.ID).Return([]uuid.UUID{}, nil)
-	deps.folderRepo.On("FindByID", ctx, folder.ID).Return(folder, nil)
-	deps.archivedFileRepo.On("Create", ctx, mock.AnythingOfType("*entity.ArchivedFile")).Return(nil)
+	deps.archivedFolderRepo.On("Create", ctx, mock.AnythingOfType("*entity.ArchivedFolder")).Return(nil)
+	deps.archivedFileRepo.On("Create", ctx, mock.MatchedBy(func(af *entity.ArchivedFile) bool {
+		return af.IsInArchivedFolder() && af.OriginalPath == "/test-folder/file.txt"
+	})).Return(nil)
 	deps.archivedFileVersionRepo.On("BulkCreate", ctx, mock.AnythingOfType("[]*entity.ArchivedFileVersion")).Return(nil)
 	deps.fileVersionRepo.On("DeleteByFileID", ctx, file.ID).Return(nil)
 	deps.fileRepo.On("Delete", ctx, file.ID).Return(nil)
@@ -157,7 +174,8 @@ func TestDeleteFolderCommand_Execute_FolderWithDescendants_DeletesAllFolders(t *
 
 	ownerID := uuid.New()
 	folder := newRootFolderEntity(ownerID)
-	childID := uuid.New()
+	child := newChildFolderEntity(ownerID, folder.ID)
+	childID := child.ID
 	user := newDeleteUserWithoutPersonalFolder(ownerID)
 
 	input := command.DeleteFolderInput{
@@ -166,9 +184,16 @@ func TestDeleteFolderCommand_Execute_FolderWithDescendants_DeletesAllFolders(t *
 	}
 
 	deps.folderRepo.On("FindByID", ctx, folder.ID).Return(folder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFolder, folder.ID, authz.PermRootDelete).Return(true, nil)
 	deps.userRepo.On("FindByID", ctx, ownerID).Return(user, nil)
 	deps.folderClosureRepo.On("FindDescendantIDs", ctx, folder.ID).Return([]uuid.UUID{childID}, nil)
+	deps.folderRepo.On("FindByIDs", ctx, []uuid.UUID{childID}).Return([]*entity.Folder{child}, nil)
 	deps.fileRepo.On("FindByFolderIDs", ctx, []uuid.UUID{folder.ID, childID}).Return([]*entity.File{}, nil)
+	deps.folderClosureRepo.On("FindAncestorIDs", ctx, folder.ID).Return([]uuid.UUID{}, nil)
+	deps.archivedFolderRepo.On("Create", ctx, mock.AnythingOfType("*entity.ArchivedFolder")).Return(nil)
+	deps.archivedSubfolderRepo.On("BulkCreate", ctx, mock.MatchedBy(func(subs []*entity.ArchivedSubfolder) bool {
+		return len(subs) == 1 && subs[0].OriginalFolderID == childID && subs[0].OriginalParentID == folder.ID && subs[0].RelativeDepth == 1
+	})).Return(nil)
 	deps.folderClosureRepo.On("DeleteSubtreePaths", ctx, folder.ID).Return(nil)
 	// folderIDs = [folder.ID, childID], reversed deletion order: childID first, then folder.ID
 	deps.folderRepo.On("Delete", ctx, childID).Return(nil)
@@ -207,7 +232,7 @@ func TestDeleteFolderCommand_Execute_FolderNotFound_PropagatesError(t *testing.T
 	assert.Equal(t, apperror.CodeNotFound, appErr.Code)
 }
 
-func TestDeleteFolderCommand_Execute_NotOwner_ReturnsForbidden(t *testing.T) {
+func TestDeleteFolderCommand_Execute_WithoutRootDeletePermission_ReturnsForbidden(t *testing.T) {
 	ctx := context.Background()
 	deps := newDeleteFolderTestDeps(t)
 
@@ -221,6 +246,7 @@ func TestDeleteFolderCommand_Execute_NotOwner_ReturnsForbidden(t *testing.T) {
 	}
 
 	deps.folderRepo.On("FindByID", ctx, folder.ID).Return(folder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, differentUserID, authz.ResourceTypeFolder, folder.ID, authz.PermRootDelete).Return(false, nil)
 
 	cmd := deps.newCommand()
 	output, err := cmd.Execute(ctx, input)
@@ -232,6 +258,40 @@ func TestDeleteFolderCommand_Execute_NotOwner_ReturnsForbidden(t *testing.T) {
 	assert.Equal(t, apperror.CodeForbidden, appErr.Code)
 }
 
+func TestDeleteFolderCommand_Execute_GrantedMemberDeletesSubfolder_ArchivesToOwnersTrash(t *testing.T) {
+	ctx := context.Background()
+	deps := newDeleteFolderTestDeps(t)
+
+	ownerID := uuid.New()
+	memberID := uuid.New()
+	parentID := uuid.New()
+	folder := newChildFolderEntity(ownerID, parentID)
+	owner := newDeleteUserWithoutPersonalFolder(ownerID)
+
+	input := command.DeleteFolderInput{
+		FolderID: folder.ID,
+		UserID:   memberID,
+	}
+
+	deps.folderRepo.On("FindByID", ctx, folder.ID).Return(folder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, memberID, authz.ResourceTypeFolder, folder.ID, authz.PermFolderDelete).Return(true, nil)
+	deps.userRepo.On("FindByID", ctx, ownerID).Return(owner, nil)
+	deps.folderClosureRepo.On("FindDescendantIDs", ctx, folder.ID).Return([]uuid.UUID{}, nil)
+	deps.fileRepo.On("FindByFolderIDs", ctx, []uuid.UUID{folder.ID}).Return([]*entity.File{}, nil)
+	deps.folderClosureRepo.On("FindAncestorIDs", ctx, folder.ID).Return([]uuid.UUID{parentID}, nil)
+	deps.folderRepo.On("FindByID", ctx, parentID).Return(newRootFolderEntity(ownerID), nil)
+	deps.archivedFolderRepo.On("Create", ctx, mock.MatchedBy(func(af *entity.ArchivedFolder) bool {
+		return af.OwnerID == ownerID && af.ArchivedBy == memberID
+	})).Return(nil)
+	deps.folderClosureRepo.On("DeleteSubtreePaths", ctx, folder.ID).Return(nil)
+	deps.folderRepo.On("Delete", ctx, folder.ID).Return(nil)
+
+	output, err := deps.newCommand().Execute(ctx, input)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, output.DeletedFolderCount)
+}
+
 func TestDeleteFolderCommand_Execute_PersonalFolder_ReturnsForbidden(t *testing.T) {
 	ctx := context.Background()
 	deps := newDeleteFolderTestDeps(t)
@@ -247,6 +307,7 @@ func TestDeleteFolderCommand_Execute_PersonalFolder_ReturnsForbidden(t *testing.
 	}
 
 	deps.folderRepo.On("FindByID", ctx, folder.ID).Return(folder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFolder, folder.ID, authz.PermRootDelete).Return(true, nil)
 	deps.userRepo.On("FindByID", ctx, ownerID).Return(user, nil)
 
 	cmd := deps.newCommand()
@@ -283,9 +344,12 @@ func TestDeleteFolderCommand_Execute_SkipsInactiveFiles(t *testing.T) {
 	}
 
 	deps.folderRepo.On("FindByID", ctx, folder.ID).Return(folder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFolder, folder.ID, authz.PermRootDelete).Return(true, nil)
 	deps.userRepo.On("FindByID", ctx, ownerID).Return(user, nil)
 	deps.folderClosureRepo.On("FindDescendantIDs", ctx, folder.ID).Return([]uuid.UUID{}, nil)
 	deps.fileRepo.On("FindByFolderIDs", ctx, []uuid.UUID{folder.ID}).Return([]*entity.File{uploadingFile}, nil)
+	deps.folderClosureRepo.On("FindAncestorIDs", ctx, folder.ID).Return([]uuid.UUID{}, nil)
+	deps.archivedFolderRepo.On("Create", ctx, mock.AnythingOfType("*entity.ArchivedFolder")).Return(nil)
 	deps.folderClosureRepo.On("DeleteSubtreePaths", ctx, folder.ID).Return(nil)
 	deps.folderRepo.On("Delete", ctx, folder.ID).Return(nil)
 
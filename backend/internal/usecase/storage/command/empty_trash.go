@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
 	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
@@ -22,14 +23,18 @@ type EmptyTrashInput struct {
 
 // EmptyTrashOutput はゴミ箱を空にする出力を定義します
 type EmptyTrashOutput struct {
-	DeletedCount int
+	DeletedCount int // 削除したファイル数とフォルダ数の合計
 }
 
 // EmptyTrashCommand はゴミ箱を空にするコマンドです
 type EmptyTrashCommand struct {
 	archivedFileRepo        repository.ArchivedFileRepository
 	archivedFileVersionRepo repository.ArchivedFileVersionRepository
+	archivedFolderRepo      repository.ArchivedFolderRepository
+	archivedSubfolderRepo   repository.ArchivedSubfolderRepository
+	storageUsageRepo        repository.StorageUsageRepository
 	storageService          service.StorageService
+	blobService             service.BlobService
 	txManager               repository.TransactionManager
 }
 
@@ -37,13 +42,21 @@ type EmptyTrashCommand struct {
 func NewEmptyTrashCommand(
 	archivedFileRepo repository.ArchivedFileRepository,
 	archivedFileVersionRepo repository.ArchivedFileVersionRepository,
+	archivedFolderRepo repository.ArchivedFolderRepository,
+	archivedSubfolderRepo repository.ArchivedSubfolderRepository,
+	storageUsageRepo repository.StorageUsageRepository,
 	storageService service.StorageService,
+	blobService service.BlobService,
 	txManager repository.TransactionManager,
 ) *EmptyTrashCommand {
 	return &EmptyTrashCommand{
 		archivedFileRepo:        archivedFileRepo,
 		archivedFileVersionRepo: archivedFileVersionRepo,
+		archivedFolderRepo:      archivedFolderRepo,
+		archivedSubfolderRepo:   archivedSubfolderRepo,
+		storageUsageRepo:        storageUsageRepo,
 		storageService:          storageService,
+		blobService:             blobService,
 		txManager:               txManager,
 	}
 }
@@ -55,19 +68,24 @@ func (c *EmptyTrashCommand) Execute(ctx context.Context, input EmptyTrashInput)
 		return nil, apperror.NewForbiddenError("not authorized to empty this trash")
 	}
 
-	// 2. 全アーカイブファイル取得
+	// 2. 全アーカイブファイル・フォルダ取得（フォルダごとゴミ箱に移動されたファイルを含む）
 	archivedFiles, err := c.archivedFileRepo.FindByOwner(ctx, input.OwnerID)
 	if err != nil {
 		return nil, err
 	}
+	archivedFolders, err := c.archivedFolderRepo.FindByOwner(ctx, input.OwnerID)
+	if err != nil {
+		return nil, err
+	}
 
-	if len(archivedFiles) == 0 {
+	if len(archivedFiles) == 0 && len(archivedFolders) == 0 {
 		return &EmptyTrashOutput{DeletedCount: 0}, nil
 	}
 
 	// 3. チャンクに分けて削除
 	totalDeleted := 0
 	storageKeysToDelete := make([]string, 0, len(archivedFiles))
+	var releasedBlobs []*entity.Blob
 
 	for i := 0; i < len(archivedFiles); i += EmptyTrashChunkSize {
 		end := i + EmptyTrashChunkSize
@@ -77,8 +95,24 @@ func (c *EmptyTrashCommand) Execute(ctx context.Context, input EmptyTrashInput)
 		chunk := archivedFiles[i:end]
 
 		// チャンクごとにトランザクション実行
+		var chunkKeys []string
+		var chunkBlobs []*entity.Blob
 		err = c.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+			var freedBytes int64
+			var blobIDs []uuid.UUID
 			for _, af := range chunk {
+				// 解放する容量と参照中のブロブを算出するためにバージョンを取得
+				versions, err := c.archivedFileVersionRepo.FindByArchivedFileID(ctx, af.ID)
+				if err != nil {
+					return err
+				}
+				freedBytes += af.StoredBytes(versions)
+				blobIDs = append(blobIDs, entity.ArchivedFileVersionBlobIDs(versions)...)
+				// ブロブを共有する内容は他のファイルが参照している可能性があるため、キーごと削除するのは自身のキーに内容がある場合のみ
+				if af.HasUnsharedContent(versions) {
+					chunkKeys = append(chunkKeys, af.StorageKey.String())
+				}
+
 				// バージョン削除
 				if err := c.archivedFileVersionRepo.DeleteByArchivedFileID(ctx, af.ID); err != nil {
 					return err
@@ -88,7 +122,14 @@ func (c *EmptyTrashCommand) Execute(ctx context.Context, input EmptyTrashInput)
 					return err
 				}
 			}
-			return nil
+			// ブロブの参照を解放（最後の参照だったブロブのみオブジェクト削除対象になる）
+			released, err := c.blobService.Release(ctx, blobIDs)
+			if err != nil {
+				return err
+			}
+			chunkBlobs = released
+			// 所有者のストレージ使用量を減算
+			return c.storageUsageRepo.AddUsedBytes(ctx, input.OwnerID, -freedBytes)
 		})
 
 		if err != nil {
@@ -98,10 +139,9 @@ func (c *EmptyTrashCommand) Execute(ctx context.Context, input EmptyTrashInput)
 
 		totalDeleted += len(chunk)
 
-		// 削除成功したストレージキーを記録
-		for _, af := range chunk {
-			storageKeysToDelete = append(storageKeysToDelete, af.StorageKey.String())
-		}
+		// 削除成功したストレージキーとブロブを記録
+		storageKeysToDelete = append(storageKeysToDelete, chunkKeys...)
+		releasedBlobs = append(releasedBlobs, chunkBlobs...)
 	}
 
 	// 4. MinIOからオブジェクト削除（トランザクション外）
@@ -113,6 +153,26 @@ func (c *EmptyTrashCommand) Execute(ctx context.Context, input EmptyTrashInput)
 			)
 		}
 	}
+	c.blobService.Purge(ctx, releasedBlobs)
+
+	// 5. アーカイブフォルダを削除（配下のファイルは削除済み）
+	if len(archivedFolders) > 0 {
+		err = c.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+			for _, af := range archivedFolders {
+				if err := c.archivedSubfolderRepo.DeleteByArchivedFolderID(ctx, af.ID); err != nil {
+					return err
+				}
+				if err := c.archivedFolderRepo.Delete(ctx, af.ID); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return &EmptyTrashOutput{DeletedCount: totalDeleted}, err
+		}
+		totalDeleted += len(archivedFolders)
+	}
 
 	return &EmptyTrashOutput{DeletedCount: totalDeleted}, nil
 }
@@ -20,7 +20,11 @@ import (
 type emptyTrashTestDeps struct {
 	archivedFileRepo        *mocks.MockArchivedFileRepository
 	archivedFileVersionRepo *mocks.MockArchivedFileVersionRepository
+	archivedFolderRepo      *mocks.MockArchivedFolderRepository
+	archivedSubfolderRepo   *mocks.MockArchivedSubfolderRepository
+	storageUsageRepo        *mocks.MockStorageUsageRepository
 	storageService          *mocks.MockStorageService
+	blobService             *mocks.MockBlobService
 	txManager               *mocks.MockTransactionManager
 }
 
@@ -29,7 +33,11 @@ func newEmptyTrashTestDeps(t *testing.T) *emptyTrashTestDeps {
 	return &emptyTrashTestDeps{
 		archivedFileRepo:        mocks.NewMockArchivedFileRepository(t),
 		archivedFileVersionRepo: mocks.NewMockArchivedFileVersionRepository(t),
+		archivedFolderRepo:      mocks.NewMockArchivedFolderRepository(t),
+		archivedSubfolderRepo:   mocks.NewMockArchivedSubfolderRepository(t),
+		storageUsageRepo:        mocks.NewMockStorageUsageRepository(t),
 		storageService:          mocks.NewMockStorageService(t),
+		blobService:             mocks.NewMockBlobService(t),
 		txManager:               mocks.NewMockTransactionManager(t),
 	}
 }
@@ -38,7 +46,11 @@ func (d *emptyTrashTestDeps) newCommand() *command.EmptyTrashCommand {
 	return command.NewEmptyTrashCommand(
 		d.archivedFileRepo,
 		d.archivedFileVersionRepo,
+		d.archivedFolderRepo,
+		d.archivedSubfolderRepo,
+		d.storageUsageRepo,
 		d.storageService,
+		d.blobService,
 		d.txManager,
 	)
 }
@@ -62,6 +74,7 @@ func newArchivedFileEntry(ownerID uuid.UUID, name string) *entity.ArchivedFile {
 		time.Now().Add(-time.Hour),
 		ownerID,
 		time.Now().Add(29*24*time.Hour),
+		nil,
 	)
 }
 
@@ -72,6 +85,7 @@ func TestEmptyTrashCommand_Execute_EmptyTrash_ReturnsZeroDeleted(t *testing.T) {
 	ownerID := uuid.New()
 
 	deps.archivedFileRepo.On("FindByOwner", ctx, ownerID).Return([]*entity.ArchivedFile{}, nil)
+	deps.archivedFolderRepo.On("FindByOwner", ctx, ownerID).Return([]*entity.ArchivedFolder{}, nil)
 
 	cmd := deps.newCommand()
 	output, err := cmd.Execute(ctx, command.EmptyTrashInput{
@@ -93,13 +107,25 @@ func TestEmptyTrashCommand_Execute_WithFiles_DeletesAllAndReturnsCount(t *testin
 	file2 := newArchivedFileEntry(ownerID, "b.txt")
 	archivedFiles := []*entity.ArchivedFile{file1, file2}
 
+	// file2の内容は他のファイルと共有されているため、キーは削除せず参照だけを解放する
+	sharedBlobID := uuid.New()
+	file2Versions := []*entity.ArchivedFileVersion{
+		{ID: uuid.New(), ArchivedFileID: file2.ID, VersionNumber: 1, Size: 100, BlobID: &sharedBlobID},
+	}
+
 	deps.archivedFileRepo.On("FindByOwner", ctx, ownerID).Return(archivedFiles, nil)
+	deps.archivedFolderRepo.On("FindByOwner", ctx, ownerID).Return([]*entity.ArchivedFolder{}, nil)
+	deps.archivedFileVersionRepo.On("FindByArchivedFileID", ctx, file1.ID).Return([]*entity.ArchivedFileVersion{}, nil)
 	deps.archivedFileVersionRepo.On("DeleteByArchivedFileID", ctx, file1.ID).Return(nil)
 	deps.archivedFileRepo.On("Delete", ctx, file1.ID).Return(nil)
+	deps.archivedFileVersionRepo.On("FindByArchivedFileID", ctx, file2.ID).Return(file2Versions, nil)
 	deps.archivedFileVersionRepo.On("DeleteByArchivedFileID", ctx, file2.ID).Return(nil)
 	deps.archivedFileRepo.On("Delete", ctx, file2.ID).Return(nil)
+	// file1はバージョン情報がないためファイルサイズ(256)、file2はバージョン合計(100)
+	deps.storageUsageRepo.On("AddUsedBytes", ctx, ownerID, int64(-356)).Return(nil)
+	deps.blobService.On("Release", ctx, []uuid.UUID{sharedBlobID}).Return([]*entity.Blob{}, nil)
 	deps.storageService.On("DeleteObject", ctx, file1.StorageKey.String()).Return(nil)
-	deps.storageService.On("DeleteObject", ctx, file2.StorageKey.String()).Return(nil)
+	deps.blobService.On("Purge", ctx, []*entity.Blob(nil)).Return()
 
 	cmd := deps.newCommand()
 	output, err := cmd.Execute(ctx, command.EmptyTrashInput{
@@ -112,6 +138,38 @@ func TestEmptyTrashCommand_Execute_WithFiles_DeletesAllAndReturnsCount(t *testin
 	assert.Equal(t, 2, output.DeletedCount)
 }
 
+func TestEmptyTrashCommand_Execute_WithArchivedFolder_DeletesFolderAndItsFiles(t *testing.T) {
+	ctx := context.Background()
+	deps := newEmptyTrashTestDeps(t)
+
+	ownerID := uuid.New()
+	folderName, _ := valueobject.NewFolderName("projects")
+	archivedFolder := entity.NewArchivedFolder(uuid.New(), nil, "/projects", folderName, ownerID, ownerID, ownerID)
+	file := newArchivedFileEntry(ownerID, "a.txt")
+	file.AttachToArchivedFolder(archivedFolder)
+
+	deps.archivedFileRepo.On("FindByOwner", ctx, ownerID).Return([]*entity.ArchivedFile{file}, nil)
+	deps.archivedFolderRepo.On("FindByOwner", ctx, ownerID).Return([]*entity.ArchivedFolder{archivedFolder}, nil)
+	deps.archivedFileVersionRepo.On("FindByArchivedFileID", ctx, file.ID).Return([]*entity.ArchivedFileVersion{}, nil)
+	deps.archivedFileVersionRepo.On("DeleteByArchivedFileID", ctx, file.ID).Return(nil)
+	deps.archivedFileRepo.On("Delete", ctx, file.ID).Return(nil)
+	deps.storageUsageRepo.On("AddUsedBytes", ctx, ownerID, int64(-256)).Return(nil)
+	deps.blobService.On("Release", ctx, []uuid.UUID(nil)).Return([]*entity.Blob(nil), nil)
+	deps.storageService.On("DeleteObject", ctx, file.StorageKey.String()).Return(nil)
+	deps.blobService.On("Purge", ctx, []*entity.Blob(nil)).Return()
+	deps.archivedSubfolderRepo.On("DeleteByArchivedFolderID", ctx, archivedFolder.ID).Return(nil)
+	deps.archivedFolderRepo.On("Delete", ctx, archivedFolder.ID).Return(nil)
+
+	output, err := deps.newCommand().Execute(ctx, command.EmptyTrashInput{
+		OwnerID: ownerID,
+		UserID:  ownerID,
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, output)
+	assert.Equal(t, 2, output.DeletedCount)
+}
+
 func TestEmptyTrashCommand_Execute_NotOwner_ReturnsForbidden(t *testing.T) {
 	ctx := context.Background()
 	deps := newEmptyTrashTestDeps(t)
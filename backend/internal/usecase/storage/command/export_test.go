@@ -0,0 +1,8 @@
+package command
+
+import "time"
+
+// SetHeartbeatInterval はテストからコピージョブのハートビート間隔を短縮します
+func (c *RunCopyJobsCommand) SetHeartbeatInterval(interval time.Duration) {
+	c.heartbeatInterval = interval
+}
@@ -0,0 +1,161 @@
+package command
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// maxRenameAttempts は rename 戦略で試行する連番の上限です
+const maxRenameAttempts = 1000
+
+// fileConflictResolution は同名ファイル競合の解決結果です
+type fileConflictResolution struct {
+	Name     valueobject.FileName // 使用するファイル名（rename 時は連番付き）
+	Existing *entity.File         // overwrite_as_new_version / skip 時の既存ファイル
+	Skip     bool
+}
+
+// Overwrite は既存ファイルの新しいバージョンとして保存するかを判定します
+func (r *fileConflictResolution) Overwrite() bool {
+	return r.Existing != nil && !r.Skip
+}
+
+// resolveFileConflict はフォルダ内の同名ファイルとの競合を解決方法に従って解決します
+// 競合がない場合は name をそのまま返します。解決方法が未指定の場合は fail として扱います
+func resolveFileConflict(
+	ctx context.Context,
+	fileRepo repository.FileRepository,
+	name valueobject.FileName,
+	folderID uuid.UUID,
+	strategy valueobject.ConflictStrategy,
+	conflictMessage string,
+) (*fileConflictResolution, error) {
+	exists, err := fileRepo.ExistsByNameAndFolder(ctx, name, folderID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return &fileConflictResolution{Name: name}, nil
+	}
+
+	switch strategy {
+	case valueobject.ConflictStrategyRename:
+		for n := 1; n <= maxRenameAttempts; n++ {
+			candidate, err := name.WithSequence(n)
+			if err != nil {
+				return nil, apperror.NewValidationError(err.Error(), nil)
+			}
+			exists, err := fileRepo.ExistsByNameAndFolder(ctx, candidate, folderID)
+			if err != nil {
+				return nil, err
+			}
+			if !exists {
+				return &fileConflictResolution{Name: candidate}, nil
+			}
+		}
+		return nil, apperror.NewConflictError("no available file name found")
+
+	case valueobject.ConflictStrategySkip:
+		// アップロード中のファイルはアクティブでないため取得できないが、スキップは可能
+		existing, err := fileRepo.FindByNameAndFolder(ctx, name, folderID)
+		if err != nil && !apperror.IsNotFound(err) {
+			return nil, err
+		}
+		return &fileConflictResolution{Name: name, Existing: existing, Skip: true}, nil
+
+	case valueobject.ConflictStrategyOverwriteAsNewVersion:
+		existing, err := fileRepo.FindByNameAndFolder(ctx, name, folderID)
+		if err != nil {
+			if apperror.IsNotFound(err) {
+				return nil, apperror.NewConflictError("file with same name is still being uploaded")
+			}
+			return nil, err
+		}
+		return &fileConflictResolution{Name: name, Existing: existing}, nil
+
+	default:
+		return nil, apperror.NewConflictError(conflictMessage)
+	}
+}
+
+// fileVersionCopyDeps は既存ファイルへのバージョン追加に必要な依存関係です
+type fileVersionCopyDeps struct {
+	fileRepo         repository.FileRepository
+	fileVersionRepo  repository.FileVersionRepository
+	storageUsageRepo repository.StorageUsageRepository
+	storageService   service.StorageService
+	blobService      service.BlobService
+	quotaService     service.StorageQuotaService
+	txManager        repository.TransactionManager
+}
+
+// copyAsNewVersion はコピー元バージョンの内容を既存ファイルの新しいバージョンとしてコピーします
+// ブロブを共有しているコピー元は参照を追加するだけで、オブジェクトはコピーしません
+// inTx はバージョン登録と同じトランザクション内で実行され、コピー元の後始末に使用します
+func copyAsNewVersion(
+	ctx context.Context,
+	deps fileVersionCopyDeps,
+	target *entity.File,
+	source *entity.FileVersion,
+	userID uuid.UUID,
+	inTx func(ctx context.Context) error,
+) (*entity.FileVersion, error) {
+	if !target.IsActive() {
+		return nil, apperror.NewValidationError("file is not active", nil)
+	}
+
+	// 1. クォータチェック（新バージョン分の容量はファイル所有者に計上される）
+	if err := deps.quotaService.EnsureCapacity(ctx, target.OwnerID, source.Size); err != nil {
+		return nil, err
+	}
+
+	// 2. ブロブを共有していない場合は、MinIO上でコピー元の内容を既存ファイルのキーにコピーし、新しいバージョンを作成
+	var minioVersionID string
+	if !source.IsShared() {
+		var err error
+		minioVersionID, err = deps.storageService.CopyObjectVersion(ctx, source.StorageKey.String(), source.MinioVersionID, target.StorageKey.String())
+		if err != nil {
+			return nil, apperror.NewInternalError(err)
+		}
+	}
+
+	// 3. 新バージョンを記録（トランザクション）
+	target.IncrementVersion()
+	version := entity.NewFileVersion(target.ID, target.CurrentVersion, minioVersionID, source.Size, source.Checksum, userID)
+	err := deps.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		if source.IsShared() {
+			blob, err := deps.blobService.Acquire(ctx, *source.BlobID)
+			if err != nil {
+				return err
+			}
+			version.ReferenceBlob(blob)
+		}
+		if err := deps.fileVersionRepo.Create(ctx, version); err != nil {
+			return err
+		}
+
+		target.UpdateSize(source.Size)
+		if err := deps.fileRepo.Update(ctx, target); err != nil {
+			return err
+		}
+
+		// 所有者のストレージ使用量を加算
+		if err := deps.storageUsageRepo.AddUsedBytes(ctx, target.OwnerID, source.Size); err != nil {
+			return err
+		}
+
+		return inTx(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return version, nil
+}
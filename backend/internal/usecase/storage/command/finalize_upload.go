@@ -0,0 +1,170 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// FinalizeUploadInput はクライアントからのアップロード完了通知の入力を定義します
+type FinalizeUploadInput struct {
+	SessionID uuid.UUID
+	UserID    uuid.UUID
+}
+
+// FinalizeUploadCommand はPresigned URLでのアップロード後にクライアントが完了を通知するコマンドです
+// Webhookが届かない環境向けのフォールバックで、ストレージ上のオブジェクトを確認してから完了します
+type FinalizeUploadCommand struct {
+	uploadSessionRepo     repository.UploadSessionRepository
+	uploadPartRepo        repository.UploadPartRepository
+	storageService        service.StorageService
+	blobService           service.BlobService
+	completeUploadCommand *CompleteUploadCommand
+}
+
+// NewFinalizeUploadCommand は新しいFinalizeUploadCommandを作成します
+func NewFinalizeUploadCommand(
+	uploadSessionRepo repository.UploadSessionRepository,
+	uploadPartRepo repository.UploadPartRepository,
+	storageService service.StorageService,
+	blobService service.BlobService,
+	completeUploadCommand *CompleteUploadCommand,
+) *FinalizeUploadCommand {
+	return &FinalizeUploadCommand{
+		uploadSessionRepo:     uploadSessionRepo,
+		uploadPartRepo:        uploadPartRepo,
+		storageService:        storageService,
+		blobService:           blobService,
+		completeUploadCommand: completeUploadCommand,
+	}
+}
+
+// Execute はアップロードされたオブジェクトを確認し、アップロードを完了します
+func (c *FinalizeUploadCommand) Execute(ctx context.Context, input FinalizeUploadInput) (*CompleteUploadOutput, error) {
+	// 1. セッション取得
+	session, err := c.uploadSessionRepo.FindByID(ctx, input.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. 所有者またはアップロード開始者のみ完了可能
+	if !session.IsOwnedBy(input.UserID) && !session.IsCreatedBy(input.UserID) {
+		return nil, apperror.NewForbiddenError("not authorized to complete this upload session")
+	}
+
+	// 3. セッションの状態チェック（Webhookで完了済み・検証待ちの場合は冪等性のため成功を返す）
+	if session.IsCompleted() || session.IsVerifying() {
+		return sessionOutput(session), nil
+	}
+	if !session.CanAcceptUpload() {
+		return nil, apperror.NewValidationError("upload session cannot accept uploads", nil)
+	}
+
+	// 4. マルチパートは全パーツが揃っていることを確認して結合
+	if session.IsMultipart && !session.AllPartsUploaded() {
+		if err := c.assembleParts(ctx, session); err != nil {
+			return nil, err
+		}
+	}
+
+	// 5. オブジェクトが宣言したサイズでアップロードされていることを確認
+	info, err := c.storageService.GetObjectInfo(ctx, session.StorageKey.String())
+	if err != nil {
+		if errors.Is(err, service.ErrObjectNotFound) {
+			return nil, apperror.NewValidationError("uploaded object not found", nil)
+		}
+		return nil, apperror.NewInternalError(err)
+	}
+	if info.Size != session.TotalSize {
+		return nil, apperror.NewValidationError(
+			fmt.Sprintf("uploaded object size %d does not match the declared size %d", info.Size, session.TotalSize), nil)
+	}
+
+	// 6. オブジェクトがこのセッションでアップロードされたものであることを確認
+	if err := c.ensureUploadedForSession(ctx, session, info); err != nil {
+		return nil, err
+	}
+
+	// 7. Webhookと同じ完了処理
+	return c.completeUploadCommand.CompleteSession(ctx, session, info.VersionID, info.Size)
+}
+
+// ensureUploadedForSession は最新のオブジェクトバージョンがこのセッションで作成されたものかを確認します
+// 新バージョンのアップロードは既存ファイルと同じキーを使うため、アップロードせずに完了を通知すると
+// 既存の最新バージョンを新しい内容として完了し、検証の失敗や重複排除で削除してしまいます
+func (c *FinalizeUploadCommand) ensureUploadedForSession(ctx context.Context, session *entity.UploadSession, info *service.ObjectInfo) error {
+	// LastModifiedは秒精度のため、セッション作成と同じ秒のアップロードを許容する
+	if info.LastModified.Before(session.CreatedAt.Truncate(time.Second)) {
+		return apperror.NewValidationError("uploaded object not found", nil)
+	}
+
+	referenced, err := c.blobService.IsReferenced(ctx, session.StorageKey, info.VersionID)
+	if err != nil {
+		return apperror.NewInternalError(err)
+	}
+	if referenced {
+		return apperror.NewValidationError("uploaded object not found", nil)
+	}
+	return nil
+}
+
+// assembleParts はストレージ上の全パーツを記録し、パーツ番号順に結合します
+func (c *FinalizeUploadCommand) assembleParts(ctx context.Context, session *entity.UploadSession) error {
+	if session.MinioUploadID == nil {
+		return apperror.NewInternalError(errors.New("multipart upload ID is missing"))
+	}
+	uploadID := *session.MinioUploadID
+	key := session.StorageKey.String()
+
+	stored, err := c.storageService.ListParts(ctx, key, uploadID)
+	if err != nil {
+		return apperror.NewInternalError(err)
+	}
+	storedByNumber := make(map[int]service.StoredPart, len(stored))
+	for _, part := range stored {
+		storedByNumber[part.PartNumber] = part
+	}
+
+	recorded, err := c.uploadPartRepo.FindBySessionID(ctx, session.ID)
+	if err != nil {
+		return err
+	}
+	recordedNumbers := make(map[int]bool, len(recorded))
+	for _, part := range recorded {
+		recordedNumbers[part.PartNumber] = true
+	}
+
+	etags := make([]string, session.TotalParts)
+	for n := 1; n <= session.TotalParts; n++ {
+		part, ok := storedByNumber[n]
+		if !ok || part.Size != session.PartSize(n) {
+			return apperror.NewValidationError(fmt.Sprintf("part %d has not been uploaded", n), nil)
+		}
+		etags[n-1] = part.ETag
+
+		if !recordedNumbers[n] {
+			if err := c.uploadPartRepo.Create(ctx, entity.NewUploadPart(session.ID, n, part.Size, part.ETag)); err != nil {
+				return err
+			}
+		}
+	}
+
+	// 結合したオブジェクトのWebhookが完了処理に進むよう、結合前に全パーツ記録済みとして保存
+	session.SetUploadedParts(session.TotalParts)
+	if err := c.uploadSessionRepo.Update(ctx, session); err != nil {
+		return err
+	}
+
+	if _, err := c.storageService.CompleteMultipartUpload(ctx, key, uploadID, etags); err != nil {
+		return apperror.NewInternalError(err)
+	}
+	return nil
+}
@@ -0,0 +1,283 @@
+package command_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/command"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+func (d *completeUploadTestDeps) newFinalizeUploadCommand() *command.FinalizeUploadCommand {
+	return command.NewFinalizeUploadCommand(
+		d.uploadSessionRepo,
+		d.uploadPartRepo,
+		d.storageService,
+		d.blobService,
+		d.newCommand(),
+	)
+}
+
+func TestFinalizeUploadCommand_Execute_SinglePartUploaded_Completes(t *testing.T) {
+	ctx := context.Background()
+	deps := newCompleteUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	folderID := uuid.New()
+	file := newUploadingFileEntity(ownerID, folderID)
+	session := newPendingSession(file.ID, ownerID, folderID)
+
+	deps.uploadSessionRepo.On("FindByID", ctx, session.ID).Return(session, nil)
+	deps.uploadSessionRepo.On("FindByIDForUpdate", ctx, session.ID).Return(session, nil)
+	deps.storageService.On("GetObjectInfo", ctx, session.StorageKey.String()).
+		Return(&service.ObjectInfo{VersionID: "v1", Size: session.TotalSize, LastModified: time.Now()}, nil)
+	deps.blobService.On("IsReferenced", ctx, session.StorageKey, "v1").Return(false, nil)
+	deps.expectCompletion(ctx, session, file, "v1")
+
+	output, err := deps.newFinalizeUploadCommand().Execute(ctx, command.FinalizeUploadInput{
+		SessionID: session.ID,
+		UserID:    ownerID,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, output.Completed)
+	assert.Equal(t, entity.UploadSessionStatusCompleted, session.Status)
+	assert.Equal(t, entity.FileStatusActive, file.Status)
+}
+
+func TestFinalizeUploadCommand_Execute_ObjectMissing_ReturnsValidationError(t *testing.T) {
+	ctx := context.Background()
+	deps := newCompleteUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	session := newPendingSession(uuid.New(), ownerID, uuid.New())
+
+	deps.uploadSessionRepo.On("FindByID", ctx, session.ID).Return(session, nil)
+	deps.storageService.On("GetObjectInfo", ctx, session.StorageKey.String()).Return(nil, service.ErrObjectNotFound)
+
+	output, err := deps.newFinalizeUploadCommand().Execute(ctx, command.FinalizeUploadInput{
+		SessionID: session.ID,
+		UserID:    ownerID,
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	appErr, ok := err.(*apperror.AppError)
+	require.True(t, ok)
+	assert.Equal(t, apperror.CodeValidationError, appErr.Code)
+	assert.Equal(t, entity.UploadSessionStatusPending, session.Status)
+}
+
+func TestFinalizeUploadCommand_Execute_SizeMismatch_ReturnsValidationError(t *testing.T) {
+	ctx := context.Background()
+	deps := newCompleteUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	session := newPendingSession(uuid.New(), ownerID, uuid.New())
+
+	deps.uploadSessionRepo.On("FindByID", ctx, session.ID).Return(session, nil)
+	deps.storageService.On("GetObjectInfo", ctx, session.StorageKey.String()).
+		Return(&service.ObjectInfo{VersionID: "v1", Size: 10}, nil)
+
+	output, err := deps.newFinalizeUploadCommand().Execute(ctx, command.FinalizeUploadInput{
+		SessionID: session.ID,
+		UserID:    ownerID,
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	appErr, ok := err.(*apperror.AppError)
+	require.True(t, ok)
+	assert.Equal(t, apperror.CodeValidationError, appErr.Code)
+}
+
+func TestFinalizeUploadCommand_Execute_VersionSessionWithoutUpload_ReturnsValidationError(t *testing.T) {
+	ctx := context.Background()
+	deps := newCompleteUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	file := newUploadingFileEntity(ownerID, uuid.New())
+	require.NoError(t, file.Activate())
+	session := entity.NewVersionUploadSession(file, ownerID, file.Size, nil)
+
+	// 何もアップロードしていないため、最新のオブジェクトバージョンは既存ファイルの内容
+	deps.uploadSessionRepo.On("FindByID", ctx, session.ID).Return(session, nil)
+	deps.storageService.On("GetObjectInfo", ctx, session.StorageKey.String()).
+		Return(&service.ObjectInfo{VersionID: "v1", Size: file.Size, LastModified: session.CreatedAt.Add(-time.Hour)}, nil)
+
+	output, err := deps.newFinalizeUploadCommand().Execute(ctx, command.FinalizeUploadInput{
+		SessionID: session.ID,
+		UserID:    ownerID,
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	appErr, ok := err.(*apperror.AppError)
+	require.True(t, ok)
+	assert.Equal(t, apperror.CodeValidationError, appErr.Code)
+	assert.Equal(t, entity.FileStatusActive, file.Status)
+	deps.storageService.AssertNotCalled(t, "DeleteObjectVersion", mock.Anything, mock.Anything, mock.Anything)
+	deps.blobService.AssertNotCalled(t, "Register", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestFinalizeUploadCommand_Execute_ReferencedObjectVersion_ReturnsValidationError(t *testing.T) {
+	ctx := context.Background()
+	deps := newCompleteUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	file := newUploadingFileEntity(ownerID, uuid.New())
+	require.NoError(t, file.Activate())
+	session := entity.NewVersionUploadSession(file, ownerID, file.Size, nil)
+
+	// 最新のオブジェクトバージョンは既にファイルバージョンとして登録されている
+	deps.uploadSessionRepo.On("FindByID", ctx, session.ID).Return(session, nil)
+	deps.storageService.On("GetObjectInfo", ctx, session.StorageKey.String()).
+		Return(&service.ObjectInfo{VersionID: "v1", Size: file.Size, LastModified: time.Now()}, nil)
+	deps.blobService.On("IsReferenced", ctx, session.StorageKey, "v1").Return(true, nil)
+
+	output, err := deps.newFinalizeUploadCommand().Execute(ctx, command.FinalizeUploadInput{
+		SessionID: session.ID,
+		UserID:    ownerID,
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	appErr, ok := err.(*apperror.AppError)
+	require.True(t, ok)
+	assert.Equal(t, apperror.CodeValidationError, appErr.Code)
+	deps.storageService.AssertNotCalled(t, "DeleteObjectVersion", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestFinalizeUploadCommand_Execute_MultipartAllPartsStored_ComposesAndCompletes(t *testing.T) {
+	ctx := context.Background()
+	deps := newCompleteUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	folderID := uuid.New()
+	file := newUploadingFileEntity(ownerID, folderID)
+	session := newMultipartSession(file.ID, ownerID, folderID, 2, 0)
+	key := session.StorageKey.String()
+
+	deps.uploadSessionRepo.On("FindByID", ctx, session.ID).Return(session, nil)
+	deps.uploadSessionRepo.On("FindByIDForUpdate", ctx, session.ID).Return(session, nil)
+	deps.storageService.On("ListParts", ctx, key, "minio-upload-id").Return([]service.StoredPart{
+		{PartNumber: 1, Size: entity.MinPartSize, ETag: "etag-1"},
+		{PartNumber: 2, Size: entity.MinPartSize, ETag: "etag-2"},
+	}, nil)
+	deps.uploadPartRepo.On("FindBySessionID", ctx, session.ID).Return([]*entity.UploadPart{
+		entity.NewUploadPart(session.ID, 1, entity.MinPartSize, "etag-1"),
+	}, nil)
+	deps.uploadPartRepo.On("Create", ctx, mock.MatchedBy(func(p *entity.UploadPart) bool {
+		return p.PartNumber == 2 && p.ETag == "etag-2"
+	})).Return(nil).Once()
+	deps.storageService.On("CompleteMultipartUpload", ctx, key, "minio-upload-id", []string{"etag-1", "etag-2"}).
+		Return("v-composed", nil)
+	deps.storageService.On("GetObjectInfo", ctx, key).
+		Return(&service.ObjectInfo{VersionID: "v-composed", Size: session.TotalSize, LastModified: time.Now()}, nil)
+	deps.blobService.On("IsReferenced", ctx, session.StorageKey, "v-composed").Return(false, nil)
+	deps.expectCompletion(ctx, session, file, "v-composed")
+
+	output, err := deps.newFinalizeUploadCommand().Execute(ctx, command.FinalizeUploadInput{
+		SessionID: session.ID,
+		UserID:    ownerID,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, output.Completed)
+	assert.Equal(t, 2, session.UploadedParts)
+	assert.Equal(t, session.TotalSize, file.Size)
+}
+
+func TestFinalizeUploadCommand_Execute_MultipartPartMissing_ReturnsValidationError(t *testing.T) {
+	ctx := context.Background()
+	deps := newCompleteUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	session := newMultipartSession(uuid.New(), ownerID, uuid.New(), 2, 0)
+
+	deps.uploadSessionRepo.On("FindByID", ctx, session.ID).Return(session, nil)
+	deps.storageService.On("ListParts", ctx, session.StorageKey.String(), "minio-upload-id").Return([]service.StoredPart{
+		{PartNumber: 1, Size: entity.MinPartSize, ETag: "etag-1"},
+	}, nil)
+	deps.uploadPartRepo.On("FindBySessionID", ctx, session.ID).Return([]*entity.UploadPart{}, nil)
+	deps.uploadPartRepo.On("Create", ctx, mock.AnythingOfType("*entity.UploadPart")).Return(nil).Once()
+
+	output, err := deps.newFinalizeUploadCommand().Execute(ctx, command.FinalizeUploadInput{
+		SessionID: session.ID,
+		UserID:    ownerID,
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	appErr, ok := err.(*apperror.AppError)
+	require.True(t, ok)
+	assert.Equal(t, apperror.CodeValidationError, appErr.Code)
+}
+
+func TestFinalizeUploadCommand_Execute_AlreadyCompleted_ReturnsIdempotentSuccess(t *testing.T) {
+	ctx := context.Background()
+	deps := newCompleteUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	session := newPendingSession(uuid.New(), ownerID, uuid.New())
+	require.NoError(t, session.Complete())
+
+	deps.uploadSessionRepo.On("FindByID", ctx, session.ID).Return(session, nil)
+
+	output, err := deps.newFinalizeUploadCommand().Execute(ctx, command.FinalizeUploadInput{
+		SessionID: session.ID,
+		UserID:    ownerID,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, output.Completed)
+}
+
+func TestFinalizeUploadCommand_Execute_Verifying_ReturnsIdempotentVerifying(t *testing.T) {
+	ctx := context.Background()
+	deps := newCompleteUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	session := newPendingSession(uuid.New(), ownerID, uuid.New())
+	require.NoError(t, session.StartVerification("v1", 1024))
+
+	deps.uploadSessionRepo.On("FindByID", ctx, session.ID).Return(session, nil)
+
+	output, err := deps.newFinalizeUploadCommand().Execute(ctx, command.FinalizeUploadInput{
+		SessionID: session.ID,
+		UserID:    ownerID,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, output.Completed)
+	assert.True(t, output.Verifying)
+	deps.storageService.AssertNotCalled(t, "GetObjectInfo", mock.Anything, mock.Anything)
+}
+
+func TestFinalizeUploadCommand_Execute_OtherUser_ReturnsForbidden(t *testing.T) {
+	ctx := context.Background()
+	deps := newCompleteUploadTestDeps(t)
+
+	session := newPendingSession(uuid.New(), uuid.New(), uuid.New())
+
+	deps.uploadSessionRepo.On("FindByID", ctx, session.ID).Return(session, nil)
+
+	output, err := deps.newFinalizeUploadCommand().Execute(ctx, command.FinalizeUploadInput{
+		SessionID: session.ID,
+		UserID:    uuid.New(),
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	appErr, ok := err.(*apperror.AppError)
+	require.True(t, ok)
+	assert.Equal(t, apperror.CodeForbidden, appErr.Code)
+}
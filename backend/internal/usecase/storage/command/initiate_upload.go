@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
@@ -20,6 +21,10 @@ type InitiateUploadInput struct {
 	MimeType string
 	Size     int64
 	OwnerID  uuid.UUID // 作成者のユーザーID
+	// 同名ファイルが存在する場合の解決方法（未指定の場合は fail）
+	ConflictStrategy valueobject.ConflictStrategy
+	// アップロード完了時に検証するチェックサム（未指定の場合は検証しない）
+	ExpectedChecksum *valueobject.ContentChecksum
 }
 
 // UploadURL はアップロードURL情報を表します
@@ -27,24 +32,31 @@ type UploadURL struct {
 	PartNumber int
 	URL        string
 	ExpiresAt  time.Time
+	Headers    map[string]string // アップロード時に送信が必要なヘッダー（チェックサムを申告した場合）
 }
 
 // InitiateUploadOutput はアップロード開始の出力を定義します
+// Skipped の場合はアップロードセッションを作成せず、FileIDは既存ファイルのID（アップロード中の場合はuuid.Nil）です
 type InitiateUploadOutput struct {
-	SessionID   uuid.UUID
-	FileID      uuid.UUID
-	IsMultipart bool
-	UploadURLs  []UploadURL
-	ExpiresAt   time.Time
+	SessionID    uuid.UUID
+	FileID       uuid.UUID
+	FileName     string // 競合解決後のファイル名
+	IsMultipart  bool
+	IsNewVersion bool // 既存ファイルの新バージョンとしてアップロードする場合true
+	Skipped      bool
+	UploadURLs   []UploadURL
+	ExpiresAt    time.Time
 }
 
 // InitiateUploadCommand はアップロード開始コマンドです
 type InitiateUploadCommand struct {
-	fileRepo          repository.FileRepository
-	folderRepo        repository.FolderRepository
-	uploadSessionRepo repository.UploadSessionRepository
-	storageService    service.StorageService
-	txManager         repository.TransactionManager
+	fileRepo           repository.FileRepository
+	folderRepo         repository.FolderRepository
+	uploadSessionRepo  repository.UploadSessionRepository
+	storageService     service.StorageService
+	quotaService       service.StorageQuotaService
+	permissionResolver authz.PermissionResolver
+	txManager          repository.TransactionManager
 }
 
 // NewInitiateUploadCommand は新しいInitiateUploadCommandを作成します
@@ -53,14 +65,18 @@ func NewInitiateUploadCommand(
 	folderRepo repository.FolderRepository,
 	uploadSessionRepo repository.UploadSessionRepository,
 	storageService service.StorageService,
+	quotaService service.StorageQuotaService,
+	permissionResolver authz.PermissionResolver,
 	txManager repository.TransactionManager,
 ) *InitiateUploadCommand {
 	return &InitiateUploadCommand{
-		fileRepo:          fileRepo,
-		folderRepo:        folderRepo,
-		uploadSessionRepo: uploadSessionRepo,
-		storageService:    storageService,
-		txManager:         txManager,
+		fileRepo:           fileRepo,
+		folderRepo:         folderRepo,
+		uploadSessionRepo:  uploadSessionRepo,
+		storageService:     storageService,
+		quotaService:       quotaService,
+		permissionResolver: permissionResolver,
+		txManager:          txManager,
 	}
 }
 
@@ -84,18 +100,39 @@ func (c *InitiateUploadCommand) Execute(ctx context.Context, input InitiateUploa
 		return nil, err
 	}
 
-	// 所有者チェック
-	if !folder.IsOwnedBy(input.OwnerID) {
+	hasPermission, err := c.permissionResolver.HasPermission(ctx, input.OwnerID, authz.ResourceTypeFolder, folder.ID, authz.PermFileWrite)
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
 		return nil, apperror.NewForbiddenError("not authorized to upload to this folder")
 	}
 
-	// 4. 同名ファイルの存在チェック
-	exists, err := c.fileRepo.ExistsByNameAndFolder(ctx, fileName, input.FolderID)
+	// 4. 同名ファイルとの競合を解決
+	resolution, err := resolveFileConflict(ctx, c.fileRepo, fileName, input.FolderID, input.ConflictStrategy,
+		"file with same name already exists")
 	if err != nil {
 		return nil, err
 	}
-	if exists {
-		return nil, apperror.NewConflictError("file with same name already exists")
+	if resolution.Skip {
+		output := &InitiateUploadOutput{FileName: fileName.String(), Skipped: true}
+		if resolution.Existing != nil {
+			output.FileID = resolution.Existing.ID
+		}
+		return output, nil
+	}
+	if resolution.Overwrite() {
+		// 既存ファイルの新バージョンとしてアップロード（容量はファイル所有者に計上される）
+		if err := c.quotaService.EnsureCapacity(ctx, resolution.Existing.OwnerID, input.Size); err != nil {
+			return nil, err
+		}
+		return startVersionUpload(ctx, c.uploadSessionRepo, c.storageService, resolution.Existing, input.OwnerID, input.Size, input.ExpectedChecksum)
+	}
+	fileName = resolution.Name
+
+	// クォータチェック（個人クォータと所属グループのクォータ）
+	if err := c.quotaService.EnsureCapacity(ctx, input.OwnerID, input.Size); err != nil {
+		return nil, err
 	}
 
 	// 5. ファイルIDを生成（File と UploadSession で共有）
@@ -135,6 +172,7 @@ func (c *InitiateUploadCommand) Execute(ctx context.Context, input InitiateUploa
 		input.Size,
 		minioUploadID,
 	)
+	session.SetExpectedChecksum(input.ExpectedChecksum)
 
 	// 9. トランザクションで保存
 	err = c.txManager.WithTransaction(ctx, func(ctx context.Context) error {
@@ -153,13 +191,30 @@ func (c *InitiateUploadCommand) Execute(ctx context.Context, input InitiateUploa
 	}
 
 	// 10. Presigned URL を生成
+	uploadURLs, err := generateUploadURLs(ctx, c.storageService, session)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InitiateUploadOutput{
+		SessionID:   session.ID,
+		FileID:      file.ID,
+		FileName:    fileName.String(),
+		IsMultipart: isMultipart,
+		UploadURLs:  uploadURLs,
+		ExpiresAt:   session.ExpiresAt,
+	}, nil
+}
+
+// generateUploadURLs はアップロードセッションに対応するPresigned URLを生成します
+func generateUploadURLs(ctx context.Context, storageService service.StorageService, session *entity.UploadSession) ([]UploadURL, error) {
 	uploadURLs := make([]UploadURL, 0, session.TotalParts)
-	expiry := time.Until(session.ExpiresAt)
+	storageKey := session.StorageKey.String()
 
-	if isMultipart {
+	if session.IsMultipart {
 		// マルチパートの場合は各パートのURLを生成
 		for i := 1; i <= session.TotalParts; i++ {
-			partURL, err := c.storageService.GeneratePartUploadURL(ctx, file.StorageKey.String(), *minioUploadID, i)
+			partURL, err := storageService.GeneratePartUploadURL(ctx, storageKey, *session.MinioUploadID, i)
 			if err != nil {
 				return nil, apperror.NewInternalError(err)
 			}
@@ -169,24 +224,19 @@ func (c *InitiateUploadCommand) Execute(ctx context.Context, input InitiateUploa
 				ExpiresAt:  partURL.ExpiresAt,
 			})
 		}
-	} else {
-		// シングルパートの場合
-		putURL, err := c.storageService.GeneratePutURL(ctx, file.StorageKey.String(), expiry)
-		if err != nil {
-			return nil, apperror.NewInternalError(err)
-		}
-		uploadURLs = append(uploadURLs, UploadURL{
-			PartNumber: 1,
-			URL:        putURL.URL,
-			ExpiresAt:  putURL.ExpiresAt,
-		})
+		return uploadURLs, nil
 	}
 
-	return &InitiateUploadOutput{
-		SessionID:   session.ID,
-		FileID:      file.ID,
-		IsMultipart: isMultipart,
-		UploadURLs:  uploadURLs,
-		ExpiresAt:   session.ExpiresAt,
-	}, nil
+	// シングルパートの場合は申告されたチェックサムを署名に含め、MinIOにアップロード時の検証と記録をさせる
+	putURL, err := storageService.GeneratePutURL(ctx, storageKey, time.Until(session.ExpiresAt), session.ExpectedChecksum)
+	if err != nil {
+		return nil, apperror.NewInternalError(err)
+	}
+	uploadURLs = append(uploadURLs, UploadURL{
+		PartNumber: 1,
+		URL:        putURL.URL,
+		ExpiresAt:  putURL.ExpiresAt,
+		Headers:    putURL.Headers,
+	})
+	return uploadURLs, nil
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
@@ -20,21 +21,25 @@ import (
 )
 
 type initiateUploadTestDeps struct {
-	fileRepo          *mocks.MockFileRepository
-	folderRepo        *mocks.MockFolderRepository
-	uploadSessionRepo *mocks.MockUploadSessionRepository
-	storageService    *mocks.MockStorageService
-	txManager         *mocks.MockTransactionManager
+	fileRepo           *mocks.MockFileRepository
+	folderRepo         *mocks.MockFolderRepository
+	uploadSessionRepo  *mocks.MockUploadSessionRepository
+	storageService     *mocks.MockStorageService
+	quotaService       *mocks.MockStorageQuotaService
+	permissionResolver *mocks.MockPermissionResolver
+	txManager          *mocks.MockTransactionManager
 }
 
 func newInitiateUploadTestDeps(t *testing.T) *initiateUploadTestDeps {
 	t.Helper()
 	return &initiateUploadTestDeps{
-		fileRepo:          mocks.NewMockFileRepository(t),
-		folderRepo:        mocks.NewMockFolderRepository(t),
-		uploadSessionRepo: mocks.NewMockUploadSessionRepository(t),
-		storageService:    mocks.NewMockStorageService(t),
-		txManager:         mocks.NewMockTransactionManager(t),
+		fileRepo:           mocks.NewMockFileRepository(t),
+		folderRepo:         mocks.NewMockFolderRepository(t),
+		uploadSessionRepo:  mocks.NewMockUploadSessionRepository(t),
+		storageService:     mocks.NewMockStorageService(t),
+		quotaService:       mocks.NewMockStorageQuotaService(t),
+		permissionResolver: mocks.NewMockPermissionResolver(t),
+		txManager:          mocks.NewMockTransactionManager(t),
 	}
 }
 
@@ -44,6 +49,8 @@ func (d *initiateUploadTestDeps) newCommand() *command.InitiateUploadCommand {
 		d.folderRepo,
 		d.uploadSessionRepo,
 		d.storageService,
+		d.quotaService,
+		d.permissionResolver,
 		d.txManager,
 	)
 }
@@ -73,10 +80,12 @@ func TestInitiateUploadCommand_Execute_SinglePart_ReturnsOutput(t *testing.T) {
 	}
 
 	deps.folderRepo.On("FindByID", ctx, folder.ID).Return(folder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFolder, folder.ID, authz.PermFileWrite).Return(true, nil)
 	deps.fileRepo.On("ExistsByNameAndFolder", ctx, mock.AnythingOfType("valueobject.FileName"), folder.ID).Return(false, nil)
+	deps.quotaService.On("EnsureCapacity", ctx, ownerID, input.Size).Return(nil)
 	deps.fileRepo.On("Create", ctx, mock.AnythingOfType("*entity.File")).Return(nil)
 	deps.uploadSessionRepo.On("Create", ctx, mock.AnythingOfType("*entity.UploadSession")).Return(nil)
-	deps.storageService.On("GeneratePutURL", ctx, mock.AnythingOfType("string"), mock.AnythingOfType("time.Duration")).
+	deps.storageService.On("GeneratePutURL", ctx, mock.AnythingOfType("string"), mock.AnythingOfType("time.Duration"), mock.Anything).
 		Return(&service.PresignedURL{URL: "https://example.com/upload", ExpiresAt: time.Now().Add(time.Hour)}, nil)
 
 	cmd := deps.newCommand()
@@ -90,6 +99,43 @@ func TestInitiateUploadCommand_Execute_SinglePart_ReturnsOutput(t *testing.T) {
 	assert.NotEqual(t, uuid.Nil, output.FileID)
 }
 
+func TestInitiateUploadCommand_Execute_ExpectedChecksum_StoredOnSession(t *testing.T) {
+	ctx := context.Background()
+	deps := newInitiateUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	folder := newActiveFolderEntity(ownerID)
+	checksum, _ := valueobject.NewContentChecksum("crc32c", "0a0b0c0d")
+
+	input := command.InitiateUploadInput{
+		FolderID:         folder.ID,
+		FileName:         "document.pdf",
+		MimeType:         "application/pdf",
+		Size:             1024,
+		OwnerID:          ownerID,
+		ExpectedChecksum: &checksum,
+	}
+
+	deps.folderRepo.On("FindByID", ctx, folder.ID).Return(folder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFolder, folder.ID, authz.PermFileWrite).Return(true, nil)
+	deps.fileRepo.On("ExistsByNameAndFolder", ctx, mock.AnythingOfType("valueobject.FileName"), folder.ID).Return(false, nil)
+	deps.quotaService.On("EnsureCapacity", ctx, ownerID, input.Size).Return(nil)
+	deps.fileRepo.On("Create", ctx, mock.AnythingOfType("*entity.File")).Return(nil)
+	deps.uploadSessionRepo.On("Create", ctx, mock.MatchedBy(func(s *entity.UploadSession) bool {
+		return s.ExpectedChecksum != nil && *s.ExpectedChecksum == checksum
+	})).Return(nil)
+	headers := map[string]string{"x-amz-checksum-crc32c": checksum.Base64()}
+	deps.storageService.On("GeneratePutURL", ctx, mock.AnythingOfType("string"), mock.AnythingOfType("time.Duration"), &checksum).
+		Return(&service.PresignedURL{URL: "https://example.com/upload", ExpiresAt: time.Now().Add(time.Hour), Headers: headers}, nil)
+
+	output, err := deps.newCommand().Execute(ctx, input)
+
+	require.NoError(t, err)
+	require.Len(t, output.UploadURLs, 1)
+	// 申告したチェックサムはPresigned URLの署名に含まれ、アップロード時に送信するヘッダーとして返す
+	assert.Equal(t, headers, output.UploadURLs[0].Headers)
+}
+
 func TestInitiateUploadCommand_Execute_Multipart_ReturnsOutput(t *testing.T) {
 	ctx := context.Background()
 	deps := newInitiateUploadTestDeps(t)
@@ -109,7 +155,9 @@ func TestInitiateUploadCommand_Execute_Multipart_ReturnsOutput(t *testing.T) {
 
 	uploadID := "minio-upload-id"
 	deps.folderRepo.On("FindByID", ctx, folder.ID).Return(folder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFolder, folder.ID, authz.PermFileWrite).Return(true, nil)
 	deps.fileRepo.On("ExistsByNameAndFolder", ctx, mock.AnythingOfType("valueobject.FileName"), folder.ID).Return(false, nil)
+	deps.quotaService.On("EnsureCapacity", ctx, ownerID, input.Size).Return(nil)
 	deps.storageService.On("CreateMultipartUpload", ctx, mock.AnythingOfType("string")).Return(uploadID, nil)
 	deps.fileRepo.On("Create", ctx, mock.AnythingOfType("*entity.File")).Return(nil)
 	deps.uploadSessionRepo.On("Create", ctx, mock.AnythingOfType("*entity.UploadSession")).Return(nil)
@@ -200,7 +248,7 @@ func TestInitiateUploadCommand_Execute_FolderNotFound_PropagatesError(t *testing
 	assert.Equal(t, apperror.CodeNotFound, appErr.Code)
 }
 
-func TestInitiateUploadCommand_Execute_NotFolderOwner_ReturnsForbidden(t *testing.T) {
+func TestInitiateUploadCommand_Execute_WithoutWritePermission_ReturnsForbidden(t *testing.T) {
 	ctx := context.Background()
 	deps := newInitiateUploadTestDeps(t)
 
@@ -217,6 +265,7 @@ func TestInitiateUploadCommand_Execute_NotFolderOwner_ReturnsForbidden(t *testin
 	}
 
 	deps.folderRepo.On("FindByID", ctx, folder.ID).Return(folder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, differentUserID, authz.ResourceTypeFolder, folder.ID, authz.PermFileWrite).Return(false, nil)
 
 	cmd := deps.newCommand()
 	output, err := cmd.Execute(ctx, input)
@@ -244,6 +293,7 @@ func TestInitiateUploadCommand_Execute_DuplicateFileName_ReturnsConflict(t *test
 	}
 
 	deps.folderRepo.On("FindByID", ctx, folder.ID).Return(folder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFolder, folder.ID, authz.PermFileWrite).Return(true, nil)
 	deps.fileRepo.On("ExistsByNameAndFolder", ctx, mock.AnythingOfType("valueobject.FileName"), folder.ID).Return(true, nil)
 
 	cmd := deps.newCommand()
@@ -255,3 +305,133 @@ func TestInitiateUploadCommand_Execute_DuplicateFileName_ReturnsConflict(t *test
 	require.True(t, errors.As(err, &appErr))
 	assert.Equal(t, apperror.CodeConflict, appErr.Code)
 }
+
+func TestInitiateUploadCommand_Execute_QuotaExceeded_ReturnsQuotaExceeded(t *testing.T) {
+	ctx := context.Background()
+	deps := newInitiateUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	folder := newActiveFolderEntity(ownerID)
+
+	input := command.InitiateUploadInput{
+		FolderID: folder.ID,
+		FileName: "large.bin",
+		MimeType: "application/octet-stream",
+		Size:     1024 * 1024,
+		OwnerID:  ownerID,
+	}
+
+	deps.folderRepo.On("FindByID", ctx, folder.ID).Return(folder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFolder, folder.ID, authz.PermFileWrite).Return(true, nil)
+	deps.fileRepo.On("ExistsByNameAndFolder", ctx, mock.AnythingOfType("valueobject.FileName"), folder.ID).Return(false, nil)
+	deps.quotaService.On("EnsureCapacity", ctx, ownerID, input.Size).Return(apperror.NewQuotaExceededError("storage quota exceeded"))
+
+	cmd := deps.newCommand()
+	output, err := cmd.Execute(ctx, input)
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeQuotaExceeded, appErr.Code)
+}
+
+func TestInitiateUploadCommand_Execute_RenameStrategy_UsesSequencedName(t *testing.T) {
+	ctx := context.Background()
+	deps := newInitiateUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	folder := newActiveFolderEntity(ownerID)
+	name, _ := valueobject.NewFileName("document.pdf")
+	renamed, _ := name.WithSequence(1)
+
+	input := command.InitiateUploadInput{
+		FolderID:         folder.ID,
+		FileName:         "document.pdf",
+		MimeType:         "application/pdf",
+		Size:             1024,
+		OwnerID:          ownerID,
+		ConflictStrategy: valueobject.ConflictStrategyRename,
+	}
+
+	deps.folderRepo.On("FindByID", ctx, folder.ID).Return(folder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFolder, folder.ID, authz.PermFileWrite).Return(true, nil)
+	deps.fileRepo.On("ExistsByNameAndFolder", ctx, name, folder.ID).Return(true, nil)
+	deps.fileRepo.On("ExistsByNameAndFolder", ctx, renamed, folder.ID).Return(false, nil)
+	deps.quotaService.On("EnsureCapacity", ctx, ownerID, input.Size).Return(nil)
+	deps.fileRepo.On("Create", ctx, mock.MatchedBy(func(f *entity.File) bool {
+		return f.Name.Equals(renamed)
+	})).Return(nil)
+	deps.uploadSessionRepo.On("Create", ctx, mock.AnythingOfType("*entity.UploadSession")).Return(nil)
+	deps.storageService.On("GeneratePutURL", ctx, mock.AnythingOfType("string"), mock.AnythingOfType("time.Duration"), mock.Anything).
+		Return(&service.PresignedURL{URL: "https://example.com/upload", ExpiresAt: time.Now().Add(time.Hour)}, nil)
+
+	output, err := deps.newCommand().Execute(ctx, input)
+
+	require.NoError(t, err)
+	assert.Equal(t, "document (1).pdf", output.FileName)
+	assert.False(t, output.IsNewVersion)
+}
+
+func TestInitiateUploadCommand_Execute_SkipStrategy_ReturnsExistingFileWithoutSession(t *testing.T) {
+	ctx := context.Background()
+	deps := newInitiateUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	folder := newActiveFolderEntity(ownerID)
+	existing := newActiveFileEntity(ownerID, folder.ID)
+
+	deps.folderRepo.On("FindByID", ctx, folder.ID).Return(folder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFolder, folder.ID, authz.PermFileWrite).Return(true, nil)
+	deps.fileRepo.On("ExistsByNameAndFolder", ctx, mock.AnythingOfType("valueobject.FileName"), folder.ID).Return(true, nil)
+	deps.fileRepo.On("FindByNameAndFolder", ctx, mock.AnythingOfType("valueobject.FileName"), folder.ID).Return(existing, nil)
+
+	output, err := deps.newCommand().Execute(ctx, command.InitiateUploadInput{
+		FolderID:         folder.ID,
+		FileName:         "original.txt",
+		MimeType:         "text/plain",
+		Size:             1024,
+		OwnerID:          ownerID,
+		ConflictStrategy: valueobject.ConflictStrategySkip,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, output.Skipped)
+	assert.Equal(t, existing.ID, output.FileID)
+	assert.Equal(t, uuid.Nil, output.SessionID)
+	assert.Empty(t, output.UploadURLs)
+}
+
+func TestInitiateUploadCommand_Execute_OverwriteStrategy_StartsVersionUpload(t *testing.T) {
+	ctx := context.Background()
+	deps := newInitiateUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	folder := newActiveFolderEntity(ownerID)
+	existing := newActiveFileEntity(ownerID, folder.ID)
+
+	deps.folderRepo.On("FindByID", ctx, folder.ID).Return(folder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFolder, folder.ID, authz.PermFileWrite).Return(true, nil)
+	deps.fileRepo.On("ExistsByNameAndFolder", ctx, mock.AnythingOfType("valueobject.FileName"), folder.ID).Return(true, nil)
+	deps.fileRepo.On("FindByNameAndFolder", ctx, mock.AnythingOfType("valueobject.FileName"), folder.ID).Return(existing, nil)
+	deps.quotaService.On("EnsureCapacity", ctx, ownerID, int64(1024)).Return(nil)
+	deps.uploadSessionRepo.On("Create", ctx, mock.MatchedBy(func(s *entity.UploadSession) bool {
+		return s.FileID == existing.ID
+	})).Return(nil)
+	deps.storageService.On("GeneratePutURL", ctx, existing.StorageKey.String(), mock.AnythingOfType("time.Duration"), mock.Anything).
+		Return(&service.PresignedURL{URL: "https://example.com/upload", ExpiresAt: time.Now().Add(time.Hour)}, nil)
+
+	output, err := deps.newCommand().Execute(ctx, command.InitiateUploadInput{
+		FolderID:         folder.ID,
+		FileName:         "original.txt",
+		MimeType:         "text/plain",
+		Size:             1024,
+		OwnerID:          ownerID,
+		ConflictStrategy: valueobject.ConflictStrategyOverwriteAsNewVersion,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, output.IsNewVersion)
+	assert.Equal(t, existing.ID, output.FileID)
+	assert.Len(t, output.UploadURLs, 1)
+}
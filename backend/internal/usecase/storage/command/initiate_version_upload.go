@@ -0,0 +1,129 @@
+package command
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// InitiateVersionUploadInput は新バージョンアップロード開始の入力を定義します
+type InitiateVersionUploadInput struct {
+	FileID uuid.UUID
+	Size   int64
+	UserID uuid.UUID // アップロードするユーザーID
+	// アップロード完了時に検証するチェックサム（未指定の場合は検証しない）
+	ExpectedChecksum *valueobject.ContentChecksum
+}
+
+// InitiateVersionUploadCommand は既存ファイルの新バージョンアップロード開始コマンドです
+type InitiateVersionUploadCommand struct {
+	fileRepo           repository.FileRepository
+	uploadSessionRepo  repository.UploadSessionRepository
+	storageService     service.StorageService
+	quotaService       service.StorageQuotaService
+	permissionResolver authz.PermissionResolver
+}
+
+// NewInitiateVersionUploadCommand は新しいInitiateVersionUploadCommandを作成します
+func NewInitiateVersionUploadCommand(
+	fileRepo repository.FileRepository,
+	uploadSessionRepo repository.UploadSessionRepository,
+	storageService service.StorageService,
+	quotaService service.StorageQuotaService,
+	permissionResolver authz.PermissionResolver,
+) *InitiateVersionUploadCommand {
+	return &InitiateVersionUploadCommand{
+		fileRepo:           fileRepo,
+		uploadSessionRepo:  uploadSessionRepo,
+		storageService:     storageService,
+		quotaService:       quotaService,
+		permissionResolver: permissionResolver,
+	}
+}
+
+// Execute は新バージョンのアップロード開始を実行します
+// バージョンの確定はアップロード完了時（CompleteUpload）に行われます
+func (c *InitiateVersionUploadCommand) Execute(ctx context.Context, input InitiateVersionUploadInput) (*InitiateUploadOutput, error) {
+	// 1. ファイル取得
+	file, err := c.fileRepo.FindByID(ctx, input.FileID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. アクティブなファイルのみ新バージョンを追加可能
+	if !file.IsActive() {
+		return nil, apperror.NewValidationError("file is not active", nil)
+	}
+
+	// 3. 書き込み権限チェック
+	hasPermission, err := c.permissionResolver.HasPermission(ctx, input.UserID, authz.ResourceTypeFile, file.ID, authz.PermFileWrite)
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, apperror.NewForbiddenError("not authorized to upload a new version of this file")
+	}
+
+	// 4. クォータチェック（容量はファイル所有者に計上される）
+	if err := c.quotaService.EnsureCapacity(ctx, file.OwnerID, input.Size); err != nil {
+		return nil, err
+	}
+
+	// 5. アップロードセッションを作成
+	return startVersionUpload(ctx, c.uploadSessionRepo, c.storageService, file, input.UserID, input.Size, input.ExpectedChecksum)
+}
+
+// startVersionUpload は既存ファイルの新バージョン用のアップロードセッションを作成し、Presigned URLを生成します
+func startVersionUpload(
+	ctx context.Context,
+	uploadSessionRepo repository.UploadSessionRepository,
+	storageService service.StorageService,
+	file *entity.File,
+	userID uuid.UUID,
+	size int64,
+	expectedChecksum *valueobject.ContentChecksum,
+) (*InitiateUploadOutput, error) {
+	// 1. マルチパートの場合はMinIOでアップロード開始
+	var minioUploadID *string
+	if size >= entity.MultipartThreshold {
+		uploadID, err := storageService.CreateMultipartUpload(ctx, file.StorageKey.String())
+		if err != nil {
+			return nil, apperror.NewInternalError(err)
+		}
+		minioUploadID = &uploadID
+	}
+
+	// 2. UploadSession を作成（既存ファイルのストレージキーを共有）
+	session := entity.NewVersionUploadSession(file, userID, size, minioUploadID)
+	session.SetExpectedChecksum(expectedChecksum)
+	if err := uploadSessionRepo.Create(ctx, session); err != nil {
+		// MinIOのマルチパートアップロードをキャンセル
+		if minioUploadID != nil {
+			_ = storageService.AbortMultipartUpload(ctx, file.StorageKey.String(), *minioUploadID)
+		}
+		return nil, err
+	}
+
+	// 3. Presigned URL を生成
+	uploadURLs, err := generateUploadURLs(ctx, storageService, session)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InitiateUploadOutput{
+		SessionID:    session.ID,
+		FileID:       file.ID,
+		FileName:     file.Name.String(),
+		IsMultipart:  session.IsMultipart,
+		IsNewVersion: true,
+		UploadURLs:   uploadURLs,
+		ExpiresAt:    session.ExpiresAt,
+	}, nil
+}
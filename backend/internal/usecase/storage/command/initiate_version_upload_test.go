@@ -0,0 +1,226 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/command"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
+)
+
+type initiateVersionUploadTestDeps struct {
+	fileRepo           *mocks.MockFileRepository
+	uploadSessionRepo  *mocks.MockUploadSessionRepository
+	storageService     *mocks.MockStorageService
+	quotaService       *mocks.MockStorageQuotaService
+	permissionResolver *mocks.MockPermissionResolver
+}
+
+func newInitiateVersionUploadTestDeps(t *testing.T) *initiateVersionUploadTestDeps {
+	t.Helper()
+	return &initiateVersionUploadTestDeps{
+		fileRepo:           mocks.NewMockFileRepository(t),
+		uploadSessionRepo:  mocks.NewMockUploadSessionRepository(t),
+		storageService:     mocks.NewMockStorageService(t),
+		quotaService:       mocks.NewMockStorageQuotaService(t),
+		permissionResolver: mocks.NewMockPermissionResolver(t),
+	}
+}
+
+func (d *initiateVersionUploadTestDeps) newCommand() *command.InitiateVersionUploadCommand {
+	return command.NewInitiateVersionUploadCommand(
+		d.fileRepo,
+		d.uploadSessionRepo,
+		d.storageService,
+		d.quotaService,
+		d.permissionResolver,
+	)
+}
+
+func TestInitiateVersionUploadCommand_Execute_SinglePart_ReturnsOutput(t *testing.T) {
+	ctx := context.Background()
+	deps := newInitiateVersionUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	editorID := uuid.New()
+	file := newActiveFileEntity(ownerID, uuid.New())
+
+	input := command.InitiateVersionUploadInput{
+		FileID: file.ID,
+		Size:   2048,
+		UserID: editorID,
+	}
+
+	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, editorID, authz.ResourceTypeFile, file.ID, authz.PermFileWrite).Return(true, nil)
+	// 容量はファイル所有者のクォータで確認される
+	deps.quotaService.On("EnsureCapacity", ctx, ownerID, input.Size).Return(nil)
+	deps.uploadSessionRepo.On("Create", ctx, mock.MatchedBy(func(s *entity.UploadSession) bool {
+		return s.FileID == file.ID && s.OwnerID == ownerID && s.CreatedBy == editorID &&
+			s.StorageKey.String() == file.StorageKey.String()
+	})).Return(nil)
+	deps.storageService.On("GeneratePutURL", ctx, file.StorageKey.String(), mock.AnythingOfType("time.Duration"), mock.Anything).
+		Return(&service.PresignedURL{URL: "https://example.com/upload", ExpiresAt: time.Now().Add(time.Hour)}, nil)
+
+	cmd := deps.newCommand()
+	output, err := cmd.Execute(ctx, input)
+
+	require.NoError(t, err)
+	require.NotNil(t, output)
+	assert.Equal(t, file.ID, output.FileID)
+	assert.False(t, output.IsMultipart)
+	assert.Len(t, output.UploadURLs, 1)
+	assert.NotEqual(t, uuid.Nil, output.SessionID)
+}
+
+func TestInitiateVersionUploadCommand_Execute_Multipart_ReturnsOutput(t *testing.T) {
+	ctx := context.Background()
+	deps := newInitiateVersionUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	file := newActiveFileEntity(ownerID, uuid.New())
+
+	const fileSize = 10 * 1024 * 1024 // 10MB
+	input := command.InitiateVersionUploadInput{
+		FileID: file.ID,
+		Size:   fileSize,
+		UserID: ownerID,
+	}
+
+	uploadID := "minio-upload-id"
+	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFile, file.ID, authz.PermFileWrite).Return(true, nil)
+	deps.quotaService.On("EnsureCapacity", ctx, ownerID, input.Size).Return(nil)
+	deps.storageService.On("CreateMultipartUpload", ctx, file.StorageKey.String()).Return(uploadID, nil)
+	deps.uploadSessionRepo.On("Create", ctx, mock.AnythingOfType("*entity.UploadSession")).Return(nil)
+	deps.storageService.On("GeneratePartUploadURL", ctx, file.StorageKey.String(), uploadID, 1).
+		Return(&service.MultipartUploadURL{PartNumber: 1, URL: "https://example.com/part1", ExpiresAt: time.Now().Add(time.Hour)}, nil)
+	deps.storageService.On("GeneratePartUploadURL", ctx, file.StorageKey.String(), uploadID, 2).
+		Return(&service.MultipartUploadURL{PartNumber: 2, URL: "https://example.com/part2", ExpiresAt: time.Now().Add(time.Hour)}, nil)
+
+	cmd := deps.newCommand()
+	output, err := cmd.Execute(ctx, input)
+
+	require.NoError(t, err)
+	require.NotNil(t, output)
+	assert.True(t, output.IsMultipart)
+	assert.Len(t, output.UploadURLs, 2)
+}
+
+func TestInitiateVersionUploadCommand_Execute_NoWritePermission_ReturnsForbidden(t *testing.T) {
+	ctx := context.Background()
+	deps := newInitiateVersionUploadTestDeps(t)
+
+	file := newActiveFileEntity(uuid.New(), uuid.New())
+	viewerID := uuid.New()
+
+	input := command.InitiateVersionUploadInput{
+		FileID: file.ID,
+		Size:   2048,
+		UserID: viewerID,
+	}
+
+	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, viewerID, authz.ResourceTypeFile, file.ID, authz.PermFileWrite).Return(false, nil)
+
+	cmd := deps.newCommand()
+	output, err := cmd.Execute(ctx, input)
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeForbidden, appErr.Code)
+}
+
+func TestInitiateVersionUploadCommand_Execute_FileNotActive_ReturnsValidationError(t *testing.T) {
+	ctx := context.Background()
+	deps := newInitiateVersionUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	file := newUploadingFileEntity(ownerID, uuid.New())
+
+	input := command.InitiateVersionUploadInput{
+		FileID: file.ID,
+		Size:   2048,
+		UserID: ownerID,
+	}
+
+	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+
+	cmd := deps.newCommand()
+	output, err := cmd.Execute(ctx, input)
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeValidationError, appErr.Code)
+}
+
+func TestInitiateVersionUploadCommand_Execute_QuotaExceeded_ReturnsError(t *testing.T) {
+	ctx := context.Background()
+	deps := newInitiateVersionUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	file := newActiveFileEntity(ownerID, uuid.New())
+
+	input := command.InitiateVersionUploadInput{
+		FileID: file.ID,
+		Size:   2048,
+		UserID: ownerID,
+	}
+
+	quotaErr := apperror.NewQuotaExceededError("storage quota exceeded")
+	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFile, file.ID, authz.PermFileWrite).Return(true, nil)
+	deps.quotaService.On("EnsureCapacity", ctx, ownerID, input.Size).Return(quotaErr)
+
+	cmd := deps.newCommand()
+	output, err := cmd.Execute(ctx, input)
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	assert.Equal(t, quotaErr, err)
+}
+
+func TestInitiateVersionUploadCommand_Execute_SessionCreateFails_AbortsMultipart(t *testing.T) {
+	ctx := context.Background()
+	deps := newInitiateVersionUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	file := newActiveFileEntity(ownerID, uuid.New())
+
+	input := command.InitiateVersionUploadInput{
+		FileID: file.ID,
+		Size:   10 * 1024 * 1024,
+		UserID: ownerID,
+	}
+
+	uploadID := "minio-upload-id"
+	dbErr := errors.New("db error")
+	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFile, file.ID, authz.PermFileWrite).Return(true, nil)
+	deps.quotaService.On("EnsureCapacity", ctx, ownerID, input.Size).Return(nil)
+	deps.storageService.On("CreateMultipartUpload", ctx, file.StorageKey.String()).Return(uploadID, nil)
+	deps.uploadSessionRepo.On("Create", ctx, mock.AnythingOfType("*entity.UploadSession")).Return(dbErr)
+	deps.storageService.On("AbortMultipartUpload", ctx, file.StorageKey.String(), uploadID).Return(nil)
+
+	cmd := deps.newCommand()
+	output, err := cmd.Execute(ctx, input)
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	assert.Equal(t, dbErr, err)
+}
@@ -2,11 +2,15 @@ package command
 
 import (
 	"context"
+	"log/slog"
 
 	"github.com/google/uuid"
 
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
 	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
 )
 
@@ -16,31 +20,57 @@ type MoveFileInput struct {
 	FileID      uuid.UUID
 	NewFolderID uuid.UUID // 必須 - 移動先フォルダID
 	UserID      uuid.UUID
+	// 移動先に同名ファイルが存在する場合の解決方法（未指定の場合は fail）
+	ConflictStrategy valueobject.ConflictStrategy
 }
 
 // MoveFileOutput はファイル移動の出力を定義します
+// overwrite_as_new_version の場合、FileIDは移動先の既存ファイルのIDになります
 type MoveFileOutput struct {
-	FileID   uuid.UUID
-	FolderID uuid.UUID // 必須 - 移動先フォルダID
+	FileID           uuid.UUID
+	FolderID         uuid.UUID // 必須 - 移動先フォルダID
+	PreviousFolderID uuid.UUID // 移動元フォルダID
+	Name             string
+	Skipped          bool // skip により移動しなかった場合true
+	Overwritten      bool // 既存ファイルの新バージョンとして統合した場合true
+	VersionNumber    int  // Overwritten の場合の新しいバージョン番号
 }
 
 // MoveFileCommand はファイルを別フォルダに移動するコマンドです
 type MoveFileCommand struct {
 	fileRepo           repository.FileRepository
+	fileVersionRepo    repository.FileVersionRepository
 	folderRepo         repository.FolderRepository
+	storageUsageRepo   repository.StorageUsageRepository
+	storageService     service.StorageService
+	blobService        service.BlobService
+	quotaService       service.StorageQuotaService
 	permissionResolver authz.PermissionResolver
+	txManager          repository.TransactionManager
 }
 
 // NewMoveFileCommand は新しいMoveFileCommandを作成します
 func NewMoveFileCommand(
 	fileRepo repository.FileRepository,
+	fileVersionRepo repository.FileVersionRepository,
 	folderRepo repository.FolderRepository,
+	storageUsageRepo repository.StorageUsageRepository,
+	storageService service.StorageService,
+	blobService service.BlobService,
+	quotaService service.StorageQuotaService,
 	permissionResolver authz.PermissionResolver,
+	txManager repository.TransactionManager,
 ) *MoveFileCommand {
 	return &MoveFileCommand{
 		fileRepo:           fileRepo,
+		fileVersionRepo:    fileVersionRepo,
 		folderRepo:         folderRepo,
+		storageUsageRepo:   storageUsageRepo,
+		storageService:     storageService,
+		blobService:        blobService,
+		quotaService:       quotaService,
 		permissionResolver: permissionResolver,
+		txManager:          txManager,
 	}
 }
 
@@ -64,8 +94,10 @@ func (c *MoveFileCommand) Execute(ctx context.Context, input MoveFileInput) (*Mo
 	// 3. 同じフォルダへの移動はスキップ
 	if file.FolderID == input.NewFolderID {
 		return &MoveFileOutput{
-			FileID:   file.ID,
-			FolderID: file.FolderID,
+			FileID:           file.ID,
+			FolderID:         file.FolderID,
+			PreviousFolderID: file.FolderID,
+			Name:             file.Name.String(),
 		}, nil
 	}
 
@@ -84,16 +116,32 @@ func (c *MoveFileCommand) Execute(ctx context.Context, input MoveFileInput) (*Mo
 		return nil, apperror.NewForbiddenError("not authorized to move to this folder")
 	}
 
-	// 5. 移動先での同名ファイル存在チェック
-	exists, err := c.fileRepo.ExistsByNameAndFolder(ctx, file.Name, input.NewFolderID)
+	// 5. 移動先での同名ファイルとの競合を解決
+	resolution, err := resolveFileConflict(ctx, c.fileRepo, file.Name, input.NewFolderID, input.ConflictStrategy,
+		"file with same name already exists in destination folder")
 	if err != nil {
 		return nil, err
 	}
-	if exists {
-		return nil, apperror.NewConflictError("file with same name already exists in destination folder")
+	if resolution.Skip {
+		return &MoveFileOutput{
+			FileID:           file.ID,
+			FolderID:         file.FolderID,
+			PreviousFolderID: file.FolderID,
+			Name:             file.Name.String(),
+			Skipped:          true,
+		}, nil
+	}
+	if resolution.Overwrite() {
+		return c.mergeIntoExisting(ctx, file, resolution.Existing, input.UserID)
 	}
 
 	// 6. ファイルを移動（エンティティメソッド使用）
+	previousFolderID := file.FolderID
+	if !resolution.Name.Equals(file.Name) {
+		if err := file.Rename(resolution.Name); err != nil {
+			return nil, err
+		}
+	}
 	if err := file.MoveTo(input.NewFolderID); err != nil {
 		return nil, err
 	}
@@ -104,7 +152,87 @@ func (c *MoveFileCommand) Execute(ctx context.Context, input MoveFileInput) (*Mo
 	}
 
 	return &MoveFileOutput{
-		FileID:   file.ID,
-		FolderID: file.FolderID,
+		FileID:           file.ID,
+		FolderID:         file.FolderID,
+		PreviousFolderID: previousFolderID,
+		Name:             file.Name.String(),
+	}, nil
+}
+
+// mergeIntoExisting は移動元ファイルの最新内容を移動先の既存ファイルの新しいバージョンとして統合します
+// 統合後、移動元ファイルはバージョンとオブジェクトを含めて削除されます
+func (c *MoveFileCommand) mergeIntoExisting(ctx context.Context, file, existing *entity.File, userID uuid.UUID) (*MoveFileOutput, error) {
+	if !file.IsActive() {
+		return nil, apperror.NewValidationError("only active files can be moved as a new version", nil)
+	}
+
+	// 既存ファイルへの書き込み権限チェック
+	canWrite, err := c.permissionResolver.HasPermission(ctx, userID, authz.ResourceTypeFile, existing.ID, authz.PermFileWrite)
+	if err != nil {
+		return nil, err
+	}
+	if !canWrite {
+		return nil, apperror.NewForbiddenError("not authorized to overwrite the existing file")
+	}
+
+	source, err := c.fileVersionRepo.FindByFileAndVersion(ctx, file.ID, file.CurrentVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	deps := fileVersionCopyDeps{
+		fileRepo:         c.fileRepo,
+		fileVersionRepo:  c.fileVersionRepo,
+		storageUsageRepo: c.storageUsageRepo,
+		storageService:   c.storageService,
+		blobService:      c.blobService,
+		quotaService:     c.quotaService,
+		txManager:        c.txManager,
+	}
+	var releasedBlobs []*entity.Blob
+	version, err := copyAsNewVersion(ctx, deps, existing, source, userID,
+		func(ctx context.Context) error {
+			// 移動元ファイルを削除し、所有者のストレージ使用量を減算
+			versions, err := c.fileVersionRepo.FindByFileID(ctx, file.ID)
+			if err != nil {
+				return err
+			}
+			var freed int64
+			for _, v := range versions {
+				freed += v.Size
+			}
+			if err := c.fileVersionRepo.DeleteByFileID(ctx, file.ID); err != nil {
+				return err
+			}
+			if err := c.fileRepo.Delete(ctx, file.ID); err != nil {
+				return err
+			}
+			// 移動元のバージョンが参照していたブロブを解放
+			releasedBlobs, err = c.blobService.Release(ctx, entity.FileVersionBlobIDs(versions))
+			if err != nil {
+				return err
+			}
+			return c.storageUsageRepo.AddUsedBytes(ctx, file.OwnerID, -freed)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	// MinIOから移動元のオブジェクトと参照がなくなったブロブを削除（トランザクション外）
+	if err := c.storageService.DeleteObject(ctx, file.StorageKey.String()); err != nil {
+		slog.Error("failed to delete storage object",
+			"storage_key", file.StorageKey.String(),
+			"error", err,
+		)
+	}
+	c.blobService.Purge(ctx, releasedBlobs)
+
+	return &MoveFileOutput{
+		FileID:           existing.ID,
+		FolderID:         existing.FolderID,
+		PreviousFolderID: file.FolderID,
+		Name:             existing.Name.String(),
+		Overwritten:      true,
+		VersionNumber:    version.VersionNumber,
 	}, nil
 }
@@ -21,21 +21,43 @@ import (
 
 type moveFileTestDeps struct {
 	fileRepo           *mocks.MockFileRepository
+	fileVersionRepo    *mocks.MockFileVersionRepository
 	folderRepo         *mocks.MockFolderRepository
+	storageUsageRepo   *mocks.MockStorageUsageRepository
+	storageService     *mocks.MockStorageService
+	blobService        *mocks.MockBlobService
+	quotaService       *mocks.MockStorageQuotaService
 	permissionResolver *mocks.MockPermissionResolver
+	txManager          *mocks.MockTransactionManager
 }
 
 func newMoveFileTestDeps(t *testing.T) *moveFileTestDeps {
 	t.Helper()
 	return &moveFileTestDeps{
 		fileRepo:           mocks.NewMockFileRepository(t),
+		fileVersionRepo:    mocks.NewMockFileVersionRepository(t),
 		folderRepo:         mocks.NewMockFolderRepository(t),
+		storageUsageRepo:   mocks.NewMockStorageUsageRepository(t),
+		storageService:     mocks.NewMockStorageService(t),
+		blobService:        mocks.NewMockBlobService(t),
+		quotaService:       mocks.NewMockStorageQuotaService(t),
 		permissionResolver: mocks.NewMockPermissionResolver(t),
+		txManager:          mocks.NewMockTransactionManager(t),
 	}
 }
 
 func (d *moveFileTestDeps) newCommand() *command.MoveFileCommand {
-	return command.NewMoveFileCommand(d.fileRepo, d.folderRepo, d.permissionResolver)
+	return command.NewMoveFileCommand(
+		d.fileRepo,
+		d.fileVersionRepo,
+		d.folderRepo,
+		d.storageUsageRepo,
+		d.storageService,
+		d.blobService,
+		d.quotaService,
+		d.permissionResolver,
+		d.txManager,
+	)
 }
 
 func newFolderEntity(ownerID uuid.UUID) *entity.Folder {
@@ -75,6 +97,7 @@ func TestMoveFileCommand_Execute_ValidInput_ReturnsOutput(t *testing.T) {
 	assert.NotNil(t, output)
 	assert.Equal(t, file.ID, output.FileID)
 	assert.Equal(t, destFolder.ID, output.FolderID)
+	assert.Equal(t, sourceFolderID, output.PreviousFolderID)
 }
 
 func TestMoveFileCommand_Execute_SameFolder_ReturnsCurrentState(t *testing.T) {
@@ -277,3 +300,114 @@ func TestMoveFileCommand_Execute_UploadingFile_ReturnsError(t *testing.T) {
 	assert.Nil(t, output)
 	assert.ErrorIs(t, err, entity.ErrFileNotActive)
 }
+
+func TestMoveFileCommand_Execute_RenameStrategy_MovesWithSequencedName(t *testing.T) {
+	ctx := context.Background()
+	deps := newMoveFileTestDeps(t)
+
+	ownerID := uuid.New()
+	sourceFolderID := uuid.New()
+	file := newActiveFileEntity(ownerID, sourceFolderID)
+	destFolder := newFolderEntity(ownerID)
+	renamed, _ := file.Name.WithSequence(1)
+	renamedTwice, _ := file.Name.WithSequence(2)
+
+	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFolder, sourceFolderID, authz.PermFileMoveOut).Return(true, nil)
+	deps.folderRepo.On("FindByID", ctx, destFolder.ID).Return(destFolder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFolder, destFolder.ID, authz.PermFileMoveIn).Return(true, nil)
+	deps.fileRepo.On("ExistsByNameAndFolder", ctx, file.Name, destFolder.ID).Return(true, nil)
+	deps.fileRepo.On("ExistsByNameAndFolder", ctx, renamed, destFolder.ID).Return(true, nil)
+	deps.fileRepo.On("ExistsByNameAndFolder", ctx, renamedTwice, destFolder.ID).Return(false, nil)
+	deps.fileRepo.On("Update", ctx, mock.MatchedBy(func(f *entity.File) bool {
+		return f.Name.Equals(renamedTwice) && f.FolderID == destFolder.ID
+	})).Return(nil)
+
+	output, err := deps.newCommand().Execute(ctx, command.MoveFileInput{
+		FileID:           file.ID,
+		NewFolderID:      destFolder.ID,
+		UserID:           ownerID,
+		ConflictStrategy: valueobject.ConflictStrategyRename,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "original (2).txt", output.Name)
+	assert.Equal(t, destFolder.ID, output.FolderID)
+}
+
+func TestMoveFileCommand_Execute_SkipStrategy_LeavesFileInPlace(t *testing.T) {
+	ctx := context.Background()
+	deps := newMoveFileTestDeps(t)
+
+	ownerID := uuid.New()
+	sourceFolderID := uuid.New()
+	file := newActiveFileEntity(ownerID, sourceFolderID)
+	destFolder := newFolderEntity(ownerID)
+	existing := newActiveFileEntity(ownerID, destFolder.ID)
+
+	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFolder, sourceFolderID, authz.PermFileMoveOut).Return(true, nil)
+	deps.folderRepo.On("FindByID", ctx, destFolder.ID).Return(destFolder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFolder, destFolder.ID, authz.PermFileMoveIn).Return(true, nil)
+	deps.fileRepo.On("ExistsByNameAndFolder", ctx, file.Name, destFolder.ID).Return(true, nil)
+	deps.fileRepo.On("FindByNameAndFolder", ctx, file.Name, destFolder.ID).Return(existing, nil)
+
+	output, err := deps.newCommand().Execute(ctx, command.MoveFileInput{
+		FileID:           file.ID,
+		NewFolderID:      destFolder.ID,
+		UserID:           ownerID,
+		ConflictStrategy: valueobject.ConflictStrategySkip,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, output.Skipped)
+	assert.Equal(t, file.ID, output.FileID)
+	assert.Equal(t, sourceFolderID, output.FolderID)
+}
+
+func TestMoveFileCommand_Execute_OverwriteStrategy_MergesIntoExistingAsNewVersion(t *testing.T) {
+	ctx := context.Background()
+	deps := newMoveFileTestDeps(t)
+
+	ownerID := uuid.New()
+	sourceFolderID := uuid.New()
+	file := newActiveFileEntity(ownerID, sourceFolderID)
+	destFolder := newFolderEntity(ownerID)
+	existing := newActiveFileEntity(ownerID, destFolder.ID)
+	source := entity.NewFileVersion(file.ID, 1, "minio-src", 1024, "checksum", ownerID)
+
+	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFolder, sourceFolderID, authz.PermFileMoveOut).Return(true, nil)
+	deps.folderRepo.On("FindByID", ctx, destFolder.ID).Return(destFolder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFolder, destFolder.ID, authz.PermFileMoveIn).Return(true, nil)
+	deps.fileRepo.On("ExistsByNameAndFolder", ctx, file.Name, destFolder.ID).Return(true, nil)
+	deps.fileRepo.On("FindByNameAndFolder", ctx, file.Name, destFolder.ID).Return(existing, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFile, existing.ID, authz.PermFileWrite).Return(true, nil)
+	deps.fileVersionRepo.On("FindByFileAndVersion", ctx, file.ID, 1).Return(source, nil)
+	deps.quotaService.On("EnsureCapacity", ctx, ownerID, int64(1024)).Return(nil)
+	deps.storageService.On("CopyObjectVersion", ctx, source.StorageKey.String(), "minio-src", existing.StorageKey.String()).Return("minio-new", nil)
+	deps.fileVersionRepo.On("Create", ctx, mock.MatchedBy(func(v *entity.FileVersion) bool {
+		return v.FileID == existing.ID && v.VersionNumber == 2 && v.MinioVersionID == "minio-new"
+	})).Return(nil)
+	deps.fileRepo.On("Update", ctx, existing).Return(nil)
+	deps.storageUsageRepo.On("AddUsedBytes", ctx, ownerID, int64(1024)).Return(nil).Once()
+	deps.fileVersionRepo.On("FindByFileID", ctx, file.ID).Return([]*entity.FileVersion{source}, nil)
+	deps.blobService.On("Release", ctx, []uuid.UUID{}).Return([]*entity.Blob(nil), nil)
+	deps.fileVersionRepo.On("DeleteByFileID", ctx, file.ID).Return(nil)
+	deps.fileRepo.On("Delete", ctx, file.ID).Return(nil)
+	deps.storageUsageRepo.On("AddUsedBytes", ctx, ownerID, int64(-1024)).Return(nil).Once()
+	deps.storageService.On("DeleteObject", ctx, file.StorageKey.String()).Return(nil)
+	deps.blobService.On("Purge", ctx, []*entity.Blob(nil)).Return()
+
+	output, err := deps.newCommand().Execute(ctx, command.MoveFileInput{
+		FileID:           file.ID,
+		NewFolderID:      destFolder.ID,
+		UserID:           ownerID,
+		ConflictStrategy: valueobject.ConflictStrategyOverwriteAsNewVersion,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, output.Overwritten)
+	assert.Equal(t, existing.ID, output.FileID)
+	assert.Equal(t, 2, output.VersionNumber)
+}
@@ -20,7 +20,8 @@ type MoveFolderInput struct {
 
 // MoveFolderOutput はフォルダ移動の出力を定義します
 type MoveFolderOutput struct {
-	Folder *entity.Folder
+	Folder           *entity.Folder
+	PreviousParentID *uuid.UUID // nil の場合はルートから移動
 }
 
 // MoveFolderCommand はフォルダ移動コマンドです
@@ -85,7 +86,7 @@ func (c *MoveFolderCommand) Execute(ctx context.Context, input MoveFolderInput)
 	// 4. 同じ場所への移動は何もしない
 	if (folder.ParentID == nil && input.NewParentID == nil) ||
 		(folder.ParentID != nil && input.NewParentID != nil && *folder.ParentID == *input.NewParentID) {
-		return &MoveFolderOutput{Folder: folder}, nil
+		return &MoveFolderOutput{Folder: folder, PreviousParentID: folder.ParentID}, nil
 	}
 
 	// 5. 移動先の検証 (AC-51: folder:move_in)
@@ -155,6 +156,7 @@ func (c *MoveFolderCommand) Execute(ctx context.Context, input MoveFolderInput)
 	}
 
 	// 9. トランザクションでフォルダと閉包テーブルを更新
+	previousParentID := folder.ParentID
 	err = c.txManager.WithTransaction(ctx, func(ctx context.Context) error {
 		// 新しい親のパスを取得
 		var newParentPaths []*entity.FolderPath
@@ -200,5 +202,5 @@ func (c *MoveFolderCommand) Execute(ctx context.Context, input MoveFolderInput)
 		return nil, err
 	}
 
-	return &MoveFolderOutput{Folder: folder}, nil
+	return &MoveFolderOutput{Folder: folder, PreviousParentID: previousParentID}, nil
 }
@@ -82,6 +82,7 @@ func TestMoveFolderCommand_Execute_MoveToNewParent_ReturnsFolder(t *testing.T) {
 	require.NotNil(t, output)
 	assert.Equal(t, folder.ID, output.Folder.ID)
 	assert.Equal(t, &newParentID, output.Folder.ParentID)
+	assert.Nil(t, output.PreviousParentID)
 }
 func TestMoveFolderCommand_Execute_MoveToRoot_ReturnsFolder(t *testing.T) {
 	ctx := context.Background()
@@ -114,6 +115,8 @@ func TestMoveFolderCommand_Execute_MoveToRoot_ReturnsFolder(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, output)
 	assert.Nil(t, output.Folder.ParentID)
+	require.NotNil(t, output.PreviousParentID)
+	assert.Equal(t, parentID, *output.PreviousParentID)
 }
 func TestMoveFolderCommand_Execute_SameLocation_ReturnsNoOp(t *testing.T) {
 	ctx := context.Background()
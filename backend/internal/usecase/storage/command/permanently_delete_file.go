@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
 	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
@@ -21,7 +22,9 @@ type PermanentlyDeleteFileInput struct {
 type PermanentlyDeleteFileCommand struct {
 	archivedFileRepo        repository.ArchivedFileRepository
 	archivedFileVersionRepo repository.ArchivedFileVersionRepository
+	storageUsageRepo        repository.StorageUsageRepository
 	storageService          service.StorageService
+	blobService             service.BlobService
 	txManager               repository.TransactionManager
 }
 
@@ -29,13 +32,17 @@ type PermanentlyDeleteFileCommand struct {
 func NewPermanentlyDeleteFileCommand(
 	archivedFileRepo repository.ArchivedFileRepository,
 	archivedFileVersionRepo repository.ArchivedFileVersionRepository,
+	storageUsageRepo repository.StorageUsageRepository,
 	storageService service.StorageService,
+	blobService service.BlobService,
 	txManager repository.TransactionManager,
 ) *PermanentlyDeleteFileCommand {
 	return &PermanentlyDeleteFileCommand{
 		archivedFileRepo:        archivedFileRepo,
 		archivedFileVersionRepo: archivedFileVersionRepo,
+		storageUsageRepo:        storageUsageRepo,
 		storageService:          storageService,
+		blobService:             blobService,
 		txManager:               txManager,
 	}
 }
@@ -57,13 +64,30 @@ func (c *PermanentlyDeleteFileCommand) Execute(ctx context.Context, input Perman
 	storageKey := archivedFile.StorageKey.String()
 
 	// 3. トランザクションでDB削除
+	deleteKey := false
+	var releasedBlobs []*entity.Blob
 	err = c.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		// 解放する容量と参照中のブロブを算出するためにバージョンを取得
+		versions, err := c.archivedFileVersionRepo.FindByArchivedFileID(ctx, archivedFile.ID)
+		if err != nil {
+			return err
+		}
+		deleteKey = archivedFile.HasUnsharedContent(versions)
 		// バージョン削除
 		if err := c.archivedFileVersionRepo.DeleteByArchivedFileID(ctx, archivedFile.ID); err != nil {
 			return err
 		}
 		// ファイル削除
-		return c.archivedFileRepo.Delete(ctx, archivedFile.ID)
+		if err := c.archivedFileRepo.Delete(ctx, archivedFile.ID); err != nil {
+			return err
+		}
+		// ブロブの参照を解放
+		releasedBlobs, err = c.blobService.Release(ctx, entity.ArchivedFileVersionBlobIDs(versions))
+		if err != nil {
+			return err
+		}
+		// 所有者のストレージ使用量を減算
+		return c.storageUsageRepo.AddUsedBytes(ctx, archivedFile.OwnerID, -archivedFile.StoredBytes(versions))
 	})
 
 	if err != nil {
@@ -71,12 +95,16 @@ func (c *PermanentlyDeleteFileCommand) Execute(ctx context.Context, input Perman
 	}
 
 	// 4. MinIOからオブジェクト削除（トランザクション外）
-	if err := c.storageService.DeleteObject(ctx, storageKey); err != nil {
-		slog.Error("failed to delete storage object",
-			"storage_key", storageKey,
-			"error", err,
-		)
+	// ブロブを共有する内容は最後の参照がなくなった場合のみ削除する
+	if deleteKey {
+		if err := c.storageService.DeleteObject(ctx, storageKey); err != nil {
+			slog.Error("failed to delete storage object",
+				"storage_key", storageKey,
+				"error", err,
+			)
+		}
 	}
+	c.blobService.Purge(ctx, releasedBlobs)
 
 	return nil
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
@@ -20,7 +21,9 @@ import (
 type permDeleteTestDeps struct {
 	archivedFileRepo        *mocks.MockArchivedFileRepository
 	archivedFileVersionRepo *mocks.MockArchivedFileVersionRepository
+	storageUsageRepo        *mocks.MockStorageUsageRepository
 	storageService          *mocks.MockStorageService
+	blobService             *mocks.MockBlobService
 	txManager               *mocks.MockTransactionManager
 }
 
@@ -29,7 +32,9 @@ func newPermDeleteTestDeps(t *testing.T) *permDeleteTestDeps {
 	return &permDeleteTestDeps{
 		archivedFileRepo:        mocks.NewMockArchivedFileRepository(t),
 		archivedFileVersionRepo: mocks.NewMockArchivedFileVersionRepository(t),
+		storageUsageRepo:        mocks.NewMockStorageUsageRepository(t),
 		storageService:          mocks.NewMockStorageService(t),
+		blobService:             mocks.NewMockBlobService(t),
 		txManager:               mocks.NewMockTransactionManager(t),
 	}
 }
@@ -38,7 +43,9 @@ func (d *permDeleteTestDeps) newCommand() *command.PermanentlyDeleteFileCommand
 	return command.NewPermanentlyDeleteFileCommand(
 		d.archivedFileRepo,
 		d.archivedFileVersionRepo,
+		d.storageUsageRepo,
 		d.storageService,
+		d.blobService,
 		d.txManager,
 	)
 }
@@ -62,6 +69,7 @@ func newArchivedFileForDelete(ownerID uuid.UUID) *entity.ArchivedFile {
 		time.Now().Add(-time.Hour),
 		ownerID,
 		time.Now().Add(29*24*time.Hour),
+		nil,
 	)
 }
 
@@ -73,10 +81,20 @@ func TestPermanentlyDeleteFileCommand_Execute_ValidDelete_DeletesDBAndStorage(t
 	archivedFile := newArchivedFileForDelete(ownerID)
 	storageKey := archivedFile.StorageKey.String()
 
+	versions := []*entity.ArchivedFileVersion{
+		{ID: uuid.New(), ArchivedFileID: archivedFile.ID, VersionNumber: 1, Size: 100},
+		{ID: uuid.New(), ArchivedFileID: archivedFile.ID, VersionNumber: 2, Size: 200},
+	}
+
 	deps.archivedFileRepo.On("FindByID", ctx, archivedFile.ID).Return(archivedFile, nil)
+	deps.archivedFileVersionRepo.On("FindByArchivedFileID", ctx, archivedFile.ID).Return(versions, nil)
 	deps.archivedFileVersionRepo.On("DeleteByArchivedFileID", ctx, archivedFile.ID).Return(nil)
 	deps.archivedFileRepo.On("Delete", ctx, archivedFile.ID).Return(nil)
+	// 全バージョンの合計サイズ分の使用量を減算
+	deps.storageUsageRepo.On("AddUsedBytes", ctx, ownerID, int64(-300)).Return(nil)
+	deps.blobService.On("Release", ctx, []uuid.UUID{}).Return([]*entity.Blob(nil), nil)
 	deps.storageService.On("DeleteObject", ctx, storageKey).Return(nil)
+	deps.blobService.On("Purge", ctx, []*entity.Blob(nil)).Return()
 
 	cmd := deps.newCommand()
 	err := cmd.Execute(ctx, command.PermanentlyDeleteFileInput{
@@ -88,6 +106,41 @@ func TestPermanentlyDeleteFileCommand_Execute_ValidDelete_DeletesDBAndStorage(t
 	deps.storageService.AssertExpectations(t)
 }
 
+func TestPermanentlyDeleteFileCommand_Execute_SharedContent_PurgesOnlyUnreferencedBlobs(t *testing.T) {
+	ctx := context.Background()
+	deps := newPermDeleteTestDeps(t)
+
+	ownerID := uuid.New()
+	archivedFile := newArchivedFileForDelete(ownerID)
+
+	// 両バージョンとも重複排除されたブロブを参照しているため、ファイル自身のキーは削除しない
+	sharedBlobID := uuid.New()
+	lastRefBlobID := uuid.New()
+	versions := []*entity.ArchivedFileVersion{
+		{ID: uuid.New(), ArchivedFileID: archivedFile.ID, VersionNumber: 1, Size: 100, BlobID: &sharedBlobID},
+		{ID: uuid.New(), ArchivedFileID: archivedFile.ID, VersionNumber: 2, Size: 200, BlobID: &lastRefBlobID},
+	}
+	released := []*entity.Blob{
+		entity.ReconstructBlob(lastRefBlobID, "checksum", valueobject.NewStorageKey(uuid.New()), "minio-v1", 200, 0, time.Now()),
+	}
+
+	deps.archivedFileRepo.On("FindByID", ctx, archivedFile.ID).Return(archivedFile, nil)
+	deps.archivedFileVersionRepo.On("FindByArchivedFileID", ctx, archivedFile.ID).Return(versions, nil)
+	deps.archivedFileVersionRepo.On("DeleteByArchivedFileID", ctx, archivedFile.ID).Return(nil)
+	deps.archivedFileRepo.On("Delete", ctx, archivedFile.ID).Return(nil)
+	deps.storageUsageRepo.On("AddUsedBytes", ctx, ownerID, int64(-300)).Return(nil)
+	deps.blobService.On("Release", ctx, []uuid.UUID{sharedBlobID, lastRefBlobID}).Return(released, nil)
+	deps.blobService.On("Purge", ctx, released).Return()
+
+	err := deps.newCommand().Execute(ctx, command.PermanentlyDeleteFileInput{
+		ArchivedFileID: archivedFile.ID,
+		UserID:         ownerID,
+	})
+
+	require.NoError(t, err)
+	deps.storageService.AssertNotCalled(t, "DeleteObject", mock.Anything, mock.Anything)
+}
+
 func TestPermanentlyDeleteFileCommand_Execute_NotOwner_ReturnsForbidden(t *testing.T) {
 	ctx := context.Background()
 	deps := newPermDeleteTestDeps(t)
@@ -0,0 +1,110 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/command"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
+)
+
+type pinFileVersionTestDeps struct {
+	fileRepo           *mocks.MockFileRepository
+	fileVersionRepo    *mocks.MockFileVersionRepository
+	permissionResolver *mocks.MockPermissionResolver
+}
+
+func newPinFileVersionTestDeps(t *testing.T) *pinFileVersionTestDeps {
+	t.Helper()
+	return &pinFileVersionTestDeps{
+		fileRepo:           mocks.NewMockFileRepository(t),
+		fileVersionRepo:    mocks.NewMockFileVersionRepository(t),
+		permissionResolver: mocks.NewMockPermissionResolver(t),
+	}
+}
+
+func (d *pinFileVersionTestDeps) newCommand() *command.PinFileVersionCommand {
+	return command.NewPinFileVersionCommand(d.fileRepo, d.fileVersionRepo, d.permissionResolver)
+}
+
+func TestPinFileVersionCommand_Execute_Pin_UpdatesVersion(t *testing.T) {
+	ctx := context.Background()
+	deps := newPinFileVersionTestDeps(t)
+
+	ownerID := uuid.New()
+	file := newActiveFileEntity(ownerID, uuid.New())
+	file.CurrentVersion = 3
+	version := newStoredFileVersion(file.ID, 1, 512)
+
+	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFile, file.ID, authz.PermFileWrite).Return(true, nil)
+	deps.fileVersionRepo.On("FindByFileAndVersion", ctx, file.ID, 1).Return(version, nil)
+	deps.fileVersionRepo.On("UpdatePinned", ctx, version.ID, true).Return(nil)
+
+	output, err := deps.newCommand().Execute(ctx, command.PinFileVersionInput{
+		FileID:        file.ID,
+		VersionNumber: 1,
+		Pinned:        true,
+		UserID:        ownerID,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, output.IsPinned)
+	assert.False(t, output.WasPinned)
+	assert.Equal(t, 1, output.VersionNumber)
+}
+
+func TestPinFileVersionCommand_Execute_AlreadyUnpinned_SkipsUpdate(t *testing.T) {
+	ctx := context.Background()
+	deps := newPinFileVersionTestDeps(t)
+
+	ownerID := uuid.New()
+	file := newActiveFileEntity(ownerID, uuid.New())
+	version := newStoredFileVersion(file.ID, 1, 512)
+
+	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFile, file.ID, authz.PermFileWrite).Return(true, nil)
+	deps.fileVersionRepo.On("FindByFileAndVersion", ctx, file.ID, 1).Return(version, nil)
+
+	output, err := deps.newCommand().Execute(ctx, command.PinFileVersionInput{
+		FileID:        file.ID,
+		VersionNumber: 1,
+		Pinned:        false,
+		UserID:        ownerID,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, output.IsPinned)
+	assert.False(t, output.WasPinned)
+}
+
+func TestPinFileVersionCommand_Execute_NoWritePermission_ReturnsForbidden(t *testing.T) {
+	ctx := context.Background()
+	deps := newPinFileVersionTestDeps(t)
+
+	file := newActiveFileEntity(uuid.New(), uuid.New())
+	viewerID := uuid.New()
+
+	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, viewerID, authz.ResourceTypeFile, file.ID, authz.PermFileWrite).Return(false, nil)
+
+	output, err := deps.newCommand().Execute(ctx, command.PinFileVersionInput{
+		FileID:        file.ID,
+		VersionNumber: 1,
+		Pinned:        true,
+		UserID:        viewerID,
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeForbidden, appErr.Code)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
 	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
@@ -19,19 +20,22 @@ type RenameFileInput struct {
 
 // RenameFileOutput はファイル名変更の出力を定義します
 type RenameFileOutput struct {
-	FileID uuid.UUID
-	Name   string
+	FileID       uuid.UUID
+	Name         string
+	PreviousName string
 }
 
 // RenameFileCommand はファイル名を変更するコマンドです
 type RenameFileCommand struct {
-	fileRepo repository.FileRepository
+	fileRepo           repository.FileRepository
+	permissionResolver authz.PermissionResolver
 }
 
 // NewRenameFileCommand は新しいRenameFileCommandを作成します
-func NewRenameFileCommand(fileRepo repository.FileRepository) *RenameFileCommand {
+func NewRenameFileCommand(fileRepo repository.FileRepository, permissionResolver authz.PermissionResolver) *RenameFileCommand {
 	return &RenameFileCommand{
-		fileRepo: fileRepo,
+		fileRepo:           fileRepo,
+		permissionResolver: permissionResolver,
 	}
 }
 
@@ -49,8 +53,12 @@ func (c *RenameFileCommand) Execute(ctx context.Context, input RenameFileInput)
 		return nil, err
 	}
 
-	// 3. 所有者チェック
-	if !file.IsOwnedBy(input.UserID) {
+	// 3. 権限チェック (file:rename)
+	hasPermission, err := c.permissionResolver.HasPermission(ctx, input.UserID, authz.ResourceTypeFile, file.ID, authz.PermFileRename)
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
 		return nil, apperror.NewForbiddenError("not authorized to rename this file")
 	}
 
@@ -66,6 +74,7 @@ func (c *RenameFileCommand) Execute(ctx context.Context, input RenameFileInput)
 	}
 
 	// 5. ファイル名を変更（エンティティメソッド使用）
+	previousName := file.Name.String()
 	if err := file.Rename(newName); err != nil {
 		return nil, apperror.NewValidationError(err.Error(), nil)
 	}
@@ -76,7 +85,8 @@ func (c *RenameFileCommand) Execute(ctx context.Context, input RenameFileInput)
 	}
 
 	return &RenameFileOutput{
-		FileID: file.ID,
-		Name:   file.Name.String(),
+		FileID:       file.ID,
+		Name:         file.Name.String(),
+		PreviousName: previousName,
 	}, nil
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/command"
@@ -19,18 +20,20 @@ import (
 )
 
 type renameFileTestDeps struct {
-	fileRepo *mocks.MockFileRepository
+	fileRepo           *mocks.MockFileRepository
+	permissionResolver *mocks.MockPermissionResolver
 }
 
 func newRenameFileTestDeps(t *testing.T) *renameFileTestDeps {
 	t.Helper()
 	return &renameFileTestDeps{
-		fileRepo: mocks.NewMockFileRepository(t),
+		fileRepo:           mocks.NewMockFileRepository(t),
+		permissionResolver: mocks.NewMockPermissionResolver(t),
 	}
 }
 
 func (d *renameFileTestDeps) newCommand() *command.RenameFileCommand {
-	return command.NewRenameFileCommand(d.fileRepo)
+	return command.NewRenameFileCommand(d.fileRepo, d.permissionResolver)
 }
 
 func newActiveFileEntity(ownerID, folderID uuid.UUID) *entity.File {
@@ -59,8 +62,10 @@ func TestRenameFileCommand_Execute_ValidInput_ReturnsOutput(t *testing.T) {
 	}
 
 	deps.fileRepo.On("FindByID", ctx, input.FileID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFile, file.ID, authz.PermFileRename).Return(true, nil)
 	deps.fileRepo.On("ExistsByNameAndFolder", ctx, mock.AnythingOfType("valueobject.FileName"), folderID).Return(false, nil)
 	deps.fileRepo.On("Update", ctx, file).Return(nil)
+	originalName := file.Name.String()
 
 	cmd := deps.newCommand()
 	output, err := cmd.Execute(ctx, input)
@@ -69,6 +74,7 @@ func TestRenameFileCommand_Execute_ValidInput_ReturnsOutput(t *testing.T) {
 	require.NotNil(t, output)
 	assert.Equal(t, file.ID, output.FileID)
 	assert.Equal(t, "renamed.txt", output.Name)
+	assert.Equal(t, originalName, output.PreviousName)
 }
 
 func TestRenameFileCommand_Execute_InvalidName_ReturnsValidationError(t *testing.T) {
@@ -136,7 +142,7 @@ func TestRenameFileCommand_Execute_FileNotFound_PropagatesError(t *testing.T) {
 	assert.Equal(t, apperror.CodeNotFound, appErr.Code)
 }
 
-func TestRenameFileCommand_Execute_NotOwner_ReturnsForbidden(t *testing.T) {
+func TestRenameFileCommand_Execute_WithoutRenamePermission_ReturnsForbidden(t *testing.T) {
 	ctx := context.Background()
 	deps := newRenameFileTestDeps(t)
 
@@ -152,6 +158,7 @@ func TestRenameFileCommand_Execute_NotOwner_ReturnsForbidden(t *testing.T) {
 	}
 
 	deps.fileRepo.On("FindByID", ctx, input.FileID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, differentUserID, authz.ResourceTypeFile, file.ID, authz.PermFileRename).Return(false, nil)
 
 	cmd := deps.newCommand()
 	output, err := cmd.Execute(ctx, input)
@@ -178,6 +185,7 @@ func TestRenameFileCommand_Execute_DuplicateName_ReturnsConflict(t *testing.T) {
 	}
 
 	deps.fileRepo.On("FindByID", ctx, input.FileID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFile, file.ID, authz.PermFileRename).Return(true, nil)
 	deps.fileRepo.On("ExistsByNameAndFolder", ctx, mock.AnythingOfType("valueobject.FileName"), folderID).Return(true, nil)
 
 	cmd := deps.newCommand()
@@ -213,6 +221,7 @@ func TestRenameFileCommand_Execute_UploadingFile_ReturnsError(t *testing.T) {
 	}
 
 	deps.fileRepo.On("FindByID", ctx, input.FileID).Return(uploadingFile, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFile, uploadingFile.ID, authz.PermFileRename).Return(true, nil)
 	deps.fileRepo.On("ExistsByNameAndFolder", ctx, mock.AnythingOfType("valueobject.FileName"), folderID).Return(false, nil)
 
 	cmd := deps.newCommand()
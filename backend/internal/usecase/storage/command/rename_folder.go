@@ -5,6 +5,7 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
@@ -20,23 +21,27 @@ type RenameFolderInput struct {
 
 // RenameFolderOutput はフォルダ名変更の出力を定義します
 type RenameFolderOutput struct {
-	Folder *entity.Folder
+	Folder       *entity.Folder
+	PreviousName string
 }
 
 // RenameFolderCommand はフォルダ名変更コマンドです
 type RenameFolderCommand struct {
-	folderRepo repository.FolderRepository
-	userRepo   repository.UserRepository
+	folderRepo         repository.FolderRepository
+	userRepo           repository.UserRepository
+	permissionResolver authz.PermissionResolver
 }
 
 // NewRenameFolderCommand は新しいRenameFolderCommandを作成します
 func NewRenameFolderCommand(
 	folderRepo repository.FolderRepository,
 	userRepo repository.UserRepository,
+	permissionResolver authz.PermissionResolver,
 ) *RenameFolderCommand {
 	return &RenameFolderCommand{
-		folderRepo: folderRepo,
-		userRepo:   userRepo,
+		folderRepo:         folderRepo,
+		userRepo:           userRepo,
+		permissionResolver: permissionResolver,
 	}
 }
 
@@ -54,13 +59,17 @@ func (c *RenameFolderCommand) Execute(ctx context.Context, input RenameFolderInp
 		return nil, err
 	}
 
-	// 3. 所有者チェック
-	if !folder.IsOwnedBy(input.UserID) {
+	// 3. 権限チェック (folder:rename)
+	hasPermission, err := c.permissionResolver.HasPermission(ctx, input.UserID, authz.ResourceTypeFolder, folder.ID, authz.PermFolderRename)
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
 		return nil, apperror.NewForbiddenError("not authorized to rename this folder")
 	}
 
 	// 4. パーソナルフォルダチェック (R-FD009)
-	user, err := c.userRepo.FindByID(ctx, input.UserID)
+	user, err := c.userRepo.FindByID(ctx, folder.OwnerID)
 	if err != nil {
 		return nil, err
 	}
@@ -85,6 +94,7 @@ func (c *RenameFolderCommand) Execute(ctx context.Context, input RenameFolderInp
 	}
 
 	// 7. フォルダ名変更（エンティティメソッド）
+	previousName := folder.Name.String()
 	folder.Rename(newName)
 
 	// 8. 永続化
@@ -92,5 +102,5 @@ func (c *RenameFolderCommand) Execute(ctx context.Context, input RenameFolderInp
 		return nil, err
 	}
 
-	return &RenameFolderOutput{Folder: folder}, nil
+	return &RenameFolderOutput{Folder: folder, PreviousName: previousName}, nil
 }
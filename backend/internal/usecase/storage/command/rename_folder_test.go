@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/command"
@@ -19,20 +20,22 @@ import (
 )
 
 type renameFolderTestDeps struct {
-	folderRepo *mocks.MockFolderRepository
-	userRepo   *mocks.MockUserRepository
+	folderRepo         *mocks.MockFolderRepository
+	userRepo           *mocks.MockUserRepository
+	permissionResolver *mocks.MockPermissionResolver
 }
 
 func newRenameFolderTestDeps(t *testing.T) *renameFolderTestDeps {
 	t.Helper()
 	return &renameFolderTestDeps{
-		folderRepo: mocks.NewMockFolderRepository(t),
-		userRepo:   mocks.NewMockUserRepository(t),
+		folderRepo:         mocks.NewMockFolderRepository(t),
+		userRepo:           mocks.NewMockUserRepository(t),
+		permissionResolver: mocks.NewMockPermissionResolver(t),
 	}
 }
 
 func (d *renameFolderTestDeps) newCommand() *command.RenameFolderCommand {
-	return command.NewRenameFolderCommand(d.folderRepo, d.userRepo)
+	return command.NewRenameFolderCommand(d.folderRepo, d.userRepo, d.permissionResolver)
 }
 
 func newRootFolderEntity(ownerID uuid.UUID) *entity.Folder {
@@ -79,9 +82,11 @@ func TestRenameFolderCommand_Execute_ValidRename_ReturnsFolder(t *testing.T) {
 	}
 
 	deps.folderRepo.On("FindByID", ctx, folder.ID).Return(folder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFolder, folder.ID, authz.PermFolderRename).Return(true, nil)
 	deps.userRepo.On("FindByID", ctx, ownerID).Return(user, nil)
 	deps.folderRepo.On("ExistsByNameAndOwnerRoot", ctx, mock.AnythingOfType("valueobject.FolderName"), ownerID).Return(false, nil)
 	deps.folderRepo.On("Update", ctx, folder).Return(nil)
+	originalName := folder.Name.String()
 
 	cmd := deps.newCommand()
 	output, err := cmd.Execute(ctx, input)
@@ -90,6 +95,7 @@ func TestRenameFolderCommand_Execute_ValidRename_ReturnsFolder(t *testing.T) {
 	require.NotNil(t, output)
 	assert.Equal(t, folder.ID, output.Folder.ID)
 	assert.Equal(t, "renamed-folder", output.Folder.Name.String())
+	assert.Equal(t, originalName, output.PreviousName)
 }
 
 func TestRenameFolderCommand_Execute_SameName_SkipsDuplicateCheck(t *testing.T) {
@@ -108,6 +114,7 @@ func TestRenameFolderCommand_Execute_SameName_SkipsDuplicateCheck(t *testing.T)
 	}
 
 	deps.folderRepo.On("FindByID", ctx, folder.ID).Return(folder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFolder, folder.ID, authz.PermFolderRename).Return(true, nil)
 	deps.userRepo.On("FindByID", ctx, ownerID).Return(user, nil)
 	deps.folderRepo.On("Update", ctx, folder).Return(nil)
 
@@ -135,6 +142,7 @@ func TestRenameFolderCommand_Execute_WithParent_ChecksNameInParent(t *testing.T)
 	}
 
 	deps.folderRepo.On("FindByID", ctx, folder.ID).Return(folder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFolder, folder.ID, authz.PermFolderRename).Return(true, nil)
 	deps.userRepo.On("FindByID", ctx, ownerID).Return(user, nil)
 	deps.folderRepo.On("ExistsByNameAndParent", ctx, mock.AnythingOfType("valueobject.FolderName"), &parentID, ownerID).Return(false, nil)
 	deps.folderRepo.On("Update", ctx, folder).Return(nil)
@@ -191,7 +199,7 @@ func TestRenameFolderCommand_Execute_FolderNotFound_PropagatesError(t *testing.T
 	assert.Equal(t, apperror.CodeNotFound, appErr.Code)
 }
 
-func TestRenameFolderCommand_Execute_NotOwner_ReturnsForbidden(t *testing.T) {
+func TestRenameFolderCommand_Execute_WithoutRenamePermission_ReturnsForbidden(t *testing.T) {
 	ctx := context.Background()
 	deps := newRenameFolderTestDeps(t)
 
@@ -206,6 +214,7 @@ func TestRenameFolderCommand_Execute_NotOwner_ReturnsForbidden(t *testing.T) {
 	}
 
 	deps.folderRepo.On("FindByID", ctx, folder.ID).Return(folder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, differentUserID, authz.ResourceTypeFolder, folder.ID, authz.PermFolderRename).Return(false, nil)
 
 	cmd := deps.newCommand()
 	output, err := cmd.Execute(ctx, input)
@@ -233,6 +242,7 @@ func TestRenameFolderCommand_Execute_PersonalFolder_ReturnsForbidden(t *testing.
 	}
 
 	deps.folderRepo.On("FindByID", ctx, folder.ID).Return(folder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFolder, folder.ID, authz.PermFolderRename).Return(true, nil)
 	deps.userRepo.On("FindByID", ctx, ownerID).Return(user, nil)
 
 	cmd := deps.newCommand()
@@ -261,6 +271,7 @@ func TestRenameFolderCommand_Execute_DuplicateName_ReturnsConflict(t *testing.T)
 	}
 
 	deps.folderRepo.On("FindByID", ctx, folder.ID).Return(folder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFolder, folder.ID, authz.PermFolderRename).Return(true, nil)
 	deps.userRepo.On("FindByID", ctx, ownerID).Return(user, nil)
 	deps.folderRepo.On("ExistsByNameAndOwnerRoot", ctx, mock.AnythingOfType("valueobject.FolderName"), ownerID).Return(true, nil)
 
@@ -289,6 +300,7 @@ func TestRenameFolderCommand_Execute_UserRepoError_PropagatesError(t *testing.T)
 	}
 
 	deps.folderRepo.On("FindByID", ctx, folder.ID).Return(folder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFolder, folder.ID, authz.PermFolderRename).Return(true, nil)
 	deps.userRepo.On("FindByID", ctx, ownerID).Return(nil, repoErr)
 
 	cmd := deps.newCommand()
@@ -3,11 +3,15 @@ package command
 import (
 	"context"
 	"errors"
+	"log/slog"
 
 	"github.com/google/uuid"
 
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
 	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
 )
 
@@ -17,13 +21,19 @@ type RestoreFileInput struct {
 	ArchivedFileID  uuid.UUID
 	RestoreFolderID *uuid.UUID // nilの場合は元のフォルダに復元を試みる
 	UserID          uuid.UUID
+	// 復元先に同名ファイルが存在する場合の解決方法（未指定の場合は fail）
+	ConflictStrategy valueobject.ConflictStrategy
 }
 
 // RestoreFileOutput はファイル復元の出力を定義します
+// overwrite_as_new_version の場合、FileIDは復元先の既存ファイルのIDになります
 type RestoreFileOutput struct {
-	FileID   uuid.UUID
-	FolderID uuid.UUID // 必須 - 復元先フォルダID
-	Name     string
+	FileID        uuid.UUID
+	FolderID      uuid.UUID // 必須 - 復元先フォルダID
+	Name          string
+	Skipped       bool // skip により復元しなかった場合true（アーカイブはゴミ箱に残る）
+	Overwritten   bool // 既存ファイルの新バージョンとして復元した場合true
+	VersionNumber int  // Overwritten の場合の新しいバージョン番号
 }
 
 // RestoreFileCommand はファイルをゴミ箱から復元するコマンドです
@@ -33,7 +43,12 @@ type RestoreFileCommand struct {
 	folderRepo              repository.FolderRepository
 	archivedFileRepo        repository.ArchivedFileRepository
 	archivedFileVersionRepo repository.ArchivedFileVersionRepository
+	storageUsageRepo        repository.StorageUsageRepository
+	storageService          service.StorageService
+	blobService             service.BlobService
+	quotaService            service.StorageQuotaService
 	userRepo                repository.UserRepository
+	permissionResolver      authz.PermissionResolver
 	txManager               repository.TransactionManager
 }
 
@@ -44,7 +59,12 @@ func NewRestoreFileCommand(
 	folderRepo repository.FolderRepository,
 	archivedFileRepo repository.ArchivedFileRepository,
 	archivedFileVersionRepo repository.ArchivedFileVersionRepository,
+	storageUsageRepo repository.StorageUsageRepository,
+	storageService service.StorageService,
+	blobService service.BlobService,
+	quotaService service.StorageQuotaService,
 	userRepo repository.UserRepository,
+	permissionResolver authz.PermissionResolver,
 	txManager repository.TransactionManager,
 ) *RestoreFileCommand {
 	return &RestoreFileCommand{
@@ -53,7 +73,12 @@ func NewRestoreFileCommand(
 		folderRepo:              folderRepo,
 		archivedFileRepo:        archivedFileRepo,
 		archivedFileVersionRepo: archivedFileVersionRepo,
+		storageUsageRepo:        storageUsageRepo,
+		storageService:          storageService,
+		blobService:             blobService,
+		quotaService:            quotaService,
 		userRepo:                userRepo,
+		permissionResolver:      permissionResolver,
 		txManager:               txManager,
 	}
 }
@@ -66,9 +91,16 @@ func (c *RestoreFileCommand) Execute(ctx context.Context, input RestoreFileInput
 		return nil, err
 	}
 
-	// 2. 所有者チェック
+	// 2. 権限チェック
+	// 所有者は元のフォルダが削除されていても復元できる。それ以外は元のフォルダに対する file:restore 権限が必要
 	if !archivedFile.IsOwnedBy(input.UserID) {
-		return nil, apperror.NewForbiddenError("not authorized to restore this file")
+		hasPermission, err := c.permissionResolver.HasPermission(ctx, input.UserID, authz.ResourceTypeFolder, archivedFile.OriginalFolderID, authz.PermFileRestore)
+		if err != nil {
+			return nil, err
+		}
+		if !hasPermission {
+			return nil, apperror.NewForbiddenError("not authorized to restore this file")
+		}
 	}
 
 	// 3. 期限切れチェック
@@ -77,75 +109,119 @@ func (c *RestoreFileCommand) Execute(ctx context.Context, input RestoreFileInput
 	}
 
 	// 4. 復元先フォルダを決定
-	restoreFolderID, err := c.determineRestoreFolder(ctx, archivedFile, input.RestoreFolderID)
+	restoreFolderID, err := c.determineRestoreFolder(ctx, archivedFile, input.RestoreFolderID, input.UserID)
 	if err != nil {
 		return nil, err
 	}
 
-	// 5. 同名ファイルの存在チェック
-	exists, err := c.fileRepo.ExistsByNameAndFolder(ctx, archivedFile.Name, restoreFolderID)
+	// 5. 同名ファイルとの競合を解決
+	resolution, err := resolveFileConflict(ctx, c.fileRepo, archivedFile.Name, restoreFolderID, input.ConflictStrategy,
+		"file with same name already exists in restore folder")
 	if err != nil {
 		return nil, err
 	}
-	if exists {
-		return nil, apperror.NewConflictError("file with same name already exists in restore folder")
+	if resolution.Skip {
+		return &RestoreFileOutput{
+			FileID:   archivedFile.OriginalFileID,
+			FolderID: restoreFolderID,
+			Name:     archivedFile.Name.String(),
+			Skipped:  true,
+		}, nil
+	}
+	if resolution.Overwrite() {
+		return c.restoreAsNewVersion(ctx, archivedFile, resolution.Existing, input.UserID)
 	}
 
-	// 6. アーカイブバージョンを取得
-	archivedVersions, err := c.archivedFileVersionRepo.FindByArchivedFileID(ctx, archivedFile.ID)
+	// 6. トランザクションで復元処理
+	var file *entity.File
+	err = c.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		restored, err := restoreArchivedFile(ctx, restoreFileDeps{
+			fileRepo:                c.fileRepo,
+			fileVersionRepo:         c.fileVersionRepo,
+			archivedFileRepo:        c.archivedFileRepo,
+			archivedFileVersionRepo: c.archivedFileVersionRepo,
+		}, archivedFile, restoreFolderID, resolution.Name)
+		if err != nil {
+			return err
+		}
+		file = restored
+		return nil
+	})
+
 	if err != nil {
 		return nil, err
 	}
 
-	// 7. ファイルデータを復元形式に変換
-	file := archivedFile.ToFile(restoreFolderID) // restoreFolderID is now uuid.UUID
+	return &RestoreFileOutput{
+		FileID:   file.ID,
+		FolderID: restoreFolderID,
+		Name:     file.Name.String(),
+	}, nil
+}
 
-	// バージョン数を計算して設定
-	if len(archivedVersions) > 0 {
-		maxVersion := 0
-		for _, v := range archivedVersions {
-			if v.VersionNumber > maxVersion {
-				maxVersion = v.VersionNumber
-			}
-		}
-		file.CurrentVersion = maxVersion
+// restoreAsNewVersion はアーカイブファイルの最新内容を復元先の既存ファイルの新しいバージョンとして復元します
+// 復元後、アーカイブファイルはバージョンとオブジェクトを含めて完全に削除されます
+func (c *RestoreFileCommand) restoreAsNewVersion(ctx context.Context, archivedFile *entity.ArchivedFile, existing *entity.File, userID uuid.UUID) (*RestoreFileOutput, error) {
+	archivedVersions, err := c.archivedFileVersionRepo.FindByArchivedFileID(ctx, archivedFile.ID)
+	if err != nil {
+		return nil, err
 	}
-
-	// 8. トランザクションで復元処理
-	err = c.txManager.WithTransaction(ctx, func(ctx context.Context) error {
-		// ファイル作成
-		if err := c.fileRepo.Create(ctx, file); err != nil {
-			return err
+	var latest *entity.ArchivedFileVersion
+	for _, v := range archivedVersions {
+		if latest == nil || v.VersionNumber > latest.VersionNumber {
+			latest = v
 		}
+	}
+	if latest == nil {
+		return nil, apperror.NewValidationError("archived file has no versions", nil)
+	}
 
-		// バージョン復元
-		if len(archivedVersions) > 0 {
-			versions := make([]*entity.FileVersion, len(archivedVersions))
-			for i, av := range archivedVersions {
-				versions[i] = av.ToFileVersion(file.ID)
+	deps := fileVersionCopyDeps{
+		fileRepo:         c.fileRepo,
+		fileVersionRepo:  c.fileVersionRepo,
+		storageUsageRepo: c.storageUsageRepo,
+		storageService:   c.storageService,
+		blobService:      c.blobService,
+		quotaService:     c.quotaService,
+		txManager:        c.txManager,
+	}
+	var releasedBlobs []*entity.Blob
+	version, err := copyAsNewVersion(ctx, deps, existing, latest.ToFileVersion(archivedFile.OriginalFileID), userID,
+		func(ctx context.Context) error {
+			// アーカイブファイルを削除し、所有者のストレージ使用量を減算
+			if err := c.archivedFileVersionRepo.DeleteByArchivedFileID(ctx, archivedFile.ID); err != nil {
+				return err
 			}
-			if err := c.fileVersionRepo.BulkCreate(ctx, versions); err != nil {
+			if err := c.archivedFileRepo.Delete(ctx, archivedFile.ID); err != nil {
 				return err
 			}
-		}
-
-		// アーカイブバージョン削除
-		if err := c.archivedFileVersionRepo.DeleteByArchivedFileID(ctx, archivedFile.ID); err != nil {
-			return err
-		}
-
-		// アーカイブファイル削除
-		return c.archivedFileRepo.Delete(ctx, archivedFile.ID)
-	})
-
+			// アーカイブのバージョンが参照していたブロブを解放
+			var err error
+			releasedBlobs, err = c.blobService.Release(ctx, entity.ArchivedFileVersionBlobIDs(archivedVersions))
+			if err != nil {
+				return err
+			}
+			return c.storageUsageRepo.AddUsedBytes(ctx, archivedFile.OwnerID, -archivedFile.StoredBytes(archivedVersions))
+		})
 	if err != nil {
 		return nil, err
 	}
 
+	// MinIOからアーカイブファイルのオブジェクトと参照がなくなったブロブを削除（トランザクション外）
+	if err := c.storageService.DeleteObject(ctx, archivedFile.StorageKey.String()); err != nil {
+		slog.Error("failed to delete storage object",
+			"storage_key", archivedFile.StorageKey.String(),
+			"error", err,
+		)
+	}
+	c.blobService.Purge(ctx, releasedBlobs)
+
 	return &RestoreFileOutput{
-		FileID:   file.ID,
-		FolderID: restoreFolderID,
-		Name:     archivedFile.Name.String(),
+		FileID:        existing.ID,
+		FolderID:      existing.FolderID,
+		Name:          existing.Name.String(),
+		Overwritten:   true,
+		VersionNumber: version.VersionNumber,
 	}, nil
 }
 
@@ -155,17 +231,21 @@ func (c *RestoreFileCommand) determineRestoreFolder(
 	ctx context.Context,
 	archivedFile *entity.ArchivedFile,
 	requestedFolderID *uuid.UUID,
+	userID uuid.UUID,
 ) (uuid.UUID, error) {
 	// 明示的に指定された場合
 	if requestedFolderID != nil {
 		// フォルダが存在するか確認
-		folder, err := c.folderRepo.FindByID(ctx, *requestedFolderID)
-		if err != nil {
+		if _, err := c.folderRepo.FindByID(ctx, *requestedFolderID); err != nil {
 			return uuid.Nil, apperror.NewNotFoundError("restore folder not found")
 		}
 
-		// 所有者チェック
-		if !folder.IsOwnedBy(archivedFile.OwnerID) {
+		// 復元先フォルダへのfolder:create権限チェック
+		hasPermission, err := c.permissionResolver.HasPermission(ctx, userID, authz.ResourceTypeFolder, *requestedFolderID, authz.PermFolderCreate)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		if !hasPermission {
 			return uuid.Nil, apperror.NewForbiddenError("not authorized to restore to this folder")
 		}
 
@@ -191,3 +271,65 @@ func (c *RestoreFileCommand) determineRestoreFolder(
 	}
 	return *user.PersonalFolderID, nil
 }
+
+// restoreFileDeps はアーカイブファイルの復元に必要な依存関係です
+// RestoreFileCommandとRestoreFolderCommandで共有します
+type restoreFileDeps struct {
+	fileRepo                repository.FileRepository
+	fileVersionRepo         repository.FileVersionRepository
+	archivedFileRepo        repository.ArchivedFileRepository
+	archivedFileVersionRepo repository.ArchivedFileVersionRepository
+}
+
+// restoreArchivedFile はアーカイブファイルを指定フォルダに指定名のファイルとして復元し、アーカイブを削除します
+// トランザクション内で呼び出されることを前提とします
+func restoreArchivedFile(ctx context.Context, deps restoreFileDeps, archivedFile *entity.ArchivedFile, folderID uuid.UUID, name valueobject.FileName) (*entity.File, error) {
+	// アーカイブバージョンを取得
+	archivedVersions, err := deps.archivedFileVersionRepo.FindByArchivedFileID(ctx, archivedFile.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// ファイルデータを復元形式に変換（名前は競合解決後のものを使用）
+	file := archivedFile.ToFile(folderID)
+	file.Name = name
+
+	// バージョン数を計算して設定
+	if len(archivedVersions) > 0 {
+		maxVersion := 0
+		for _, v := range archivedVersions {
+			if v.VersionNumber > maxVersion {
+				maxVersion = v.VersionNumber
+			}
+		}
+		file.CurrentVersion = maxVersion
+	}
+
+	// ファイル作成
+	if err := deps.fileRepo.Create(ctx, file); err != nil {
+		return nil, err
+	}
+
+	// バージョン復元
+	if len(archivedVersions) > 0 {
+		versions := make([]*entity.FileVersion, len(archivedVersions))
+		for i, av := range archivedVersions {
+			versions[i] = av.ToFileVersion(file.ID)
+		}
+		if err := deps.fileVersionRepo.BulkCreate(ctx, versions); err != nil {
+			return nil, err
+		}
+	}
+
+	// アーカイブバージョン削除
+	if err := deps.archivedFileVersionRepo.DeleteByArchivedFileID(ctx, archivedFile.ID); err != nil {
+		return nil, err
+	}
+
+	// アーカイブファイル削除
+	if err := deps.archivedFileRepo.Delete(ctx, archivedFile.ID); err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
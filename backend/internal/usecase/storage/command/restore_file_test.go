@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/command"
@@ -24,7 +25,12 @@ type restoreFileTestDeps struct {
 	folderRepo              *mocks.MockFolderRepository
 	archivedFileRepo        *mocks.MockArchivedFileRepository
 	archivedFileVersionRepo *mocks.MockArchivedFileVersionRepository
+	storageUsageRepo        *mocks.MockStorageUsageRepository
+	storageService          *mocks.MockStorageService
+	blobService             *mocks.MockBlobService
+	quotaService            *mocks.MockStorageQuotaService
 	userRepo                *mocks.MockUserRepository
+	permissionResolver      *mocks.MockPermissionResolver
 	txManager               *mocks.MockTransactionManager
 }
 
@@ -36,7 +42,12 @@ func newRestoreFileTestDeps(t *testing.T) *restoreFileTestDeps {
 		folderRepo:              mocks.NewMockFolderRepository(t),
 		archivedFileRepo:        mocks.NewMockArchivedFileRepository(t),
 		archivedFileVersionRepo: mocks.NewMockArchivedFileVersionRepository(t),
+		storageUsageRepo:        mocks.NewMockStorageUsageRepository(t),
+		storageService:          mocks.NewMockStorageService(t),
+		blobService:             mocks.NewMockBlobService(t),
+		quotaService:            mocks.NewMockStorageQuotaService(t),
 		userRepo:                mocks.NewMockUserRepository(t),
+		permissionResolver:      mocks.NewMockPermissionResolver(t),
 		txManager:               mocks.NewMockTransactionManager(t),
 	}
 }
@@ -48,7 +59,12 @@ func (d *restoreFileTestDeps) newCommand() *command.RestoreFileCommand {
 		d.folderRepo,
 		d.archivedFileRepo,
 		d.archivedFileVersionRepo,
+		d.storageUsageRepo,
+		d.storageService,
+		d.blobService,
+		d.quotaService,
 		d.userRepo,
+		d.permissionResolver,
 		d.txManager,
 	)
 }
@@ -71,6 +87,7 @@ func newArchivedFile(ownerID, originalFolderID uuid.UUID) *entity.ArchivedFile {
 		time.Now().Add(-time.Hour),
 		ownerID,
 		time.Now().Add(29*24*time.Hour), // not expired
+		nil,
 	)
 }
 
@@ -92,6 +109,7 @@ func newExpiredArchivedFile(ownerID, originalFolderID uuid.UUID) *entity.Archive
 		time.Now().Add(-31*24*time.Hour),
 		ownerID,
 		time.Now().Add(-time.Hour), // expired
+		nil,
 	)
 }
 
@@ -162,7 +180,64 @@ func TestRestoreFileCommand_Execute_OriginalFolderMissing_FallsBackToPersonalFol
 	assert.Equal(t, personalFolderID, output.FolderID)
 }
 
-func TestRestoreFileCommand_Execute_NotOwner_ReturnsForbidden(t *testing.T) {
+func TestRestoreFileCommand_Execute_GrantedMember_RestoresToOriginalFolder(t *testing.T) {
+	ctx := context.Background()
+	deps := newRestoreFileTestDeps(t)
+
+	ownerID := uuid.New()
+	memberID := uuid.New()
+	originalFolderID := uuid.New()
+	archivedFile := newArchivedFile(ownerID, originalFolderID)
+
+	deps.archivedFileRepo.On("FindByID", ctx, archivedFile.ID).Return(archivedFile, nil)
+	deps.permissionResolver.On("HasPermission", ctx, memberID, authz.ResourceTypeFolder, originalFolderID, authz.PermFileRestore).Return(true, nil)
+	deps.folderRepo.On("ExistsByID", ctx, originalFolderID).Return(true, nil)
+	deps.fileRepo.On("ExistsByNameAndFolder", ctx, archivedFile.Name, originalFolderID).Return(false, nil)
+	deps.archivedFileVersionRepo.On("FindByArchivedFileID", ctx, archivedFile.ID).Return([]*entity.ArchivedFileVersion{}, nil)
+	deps.fileRepo.On("Create", ctx, mock.AnythingOfType("*entity.File")).Return(nil)
+	deps.archivedFileVersionRepo.On("DeleteByArchivedFileID", ctx, archivedFile.ID).Return(nil)
+	deps.archivedFileRepo.On("Delete", ctx, archivedFile.ID).Return(nil)
+
+	output, err := deps.newCommand().Execute(ctx, command.RestoreFileInput{
+		ArchivedFileID: archivedFile.ID,
+		UserID:         memberID,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, originalFolderID, output.FolderID)
+}
+
+func TestRestoreFileCommand_Execute_RequestedFolderWithoutCreatePermission_ReturnsForbidden(t *testing.T) {
+	ctx := context.Background()
+	deps := newRestoreFileTestDeps(t)
+
+	ownerID := uuid.New()
+	originalFolderID := uuid.New()
+	archivedFile := newArchivedFile(ownerID, originalFolderID)
+	requestedFolderID := uuid.New()
+	folderName, _ := valueobject.NewFolderName("other")
+	requestedFolder := entity.ReconstructFolder(
+		requestedFolderID, folderName, nil, uuid.New(), uuid.New(), 0,
+		entity.FolderStatusActive, time.Now(), time.Now(),
+	)
+
+	deps.archivedFileRepo.On("FindByID", ctx, archivedFile.ID).Return(archivedFile, nil)
+	deps.folderRepo.On("FindByID", ctx, requestedFolderID).Return(requestedFolder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFolder, requestedFolderID, authz.PermFolderCreate).Return(false, nil)
+
+	_, err := deps.newCommand().Execute(ctx, command.RestoreFileInput{
+		ArchivedFileID:  archivedFile.ID,
+		RestoreFolderID: &requestedFolderID,
+		UserID:          ownerID,
+	})
+
+	require.Error(t, err)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeForbidden, appErr.Code)
+}
+
+func TestRestoreFileCommand_Execute_WithoutRestorePermission_ReturnsForbidden(t *testing.T) {
 	ctx := context.Background()
 	deps := newRestoreFileTestDeps(t)
 
@@ -172,6 +247,7 @@ func TestRestoreFileCommand_Execute_NotOwner_ReturnsForbidden(t *testing.T) {
 	archivedFile := newArchivedFile(ownerID, originalFolderID)
 
 	deps.archivedFileRepo.On("FindByID", ctx, archivedFile.ID).Return(archivedFile, nil)
+	deps.permissionResolver.On("HasPermission", ctx, otherUserID, authz.ResourceTypeFolder, originalFolderID, authz.PermFileRestore).Return(false, nil)
 
 	cmd := deps.newCommand()
 	output, err := cmd.Execute(ctx, command.RestoreFileInput{
@@ -236,3 +312,72 @@ func TestRestoreFileCommand_Execute_DuplicateName_ReturnsConflict(t *testing.T)
 	require.True(t, errors.As(err, &appErr))
 	assert.Equal(t, apperror.CodeConflict, appErr.Code)
 }
+
+func TestRestoreFileCommand_Execute_RenameStrategy_RestoresWithSequencedName(t *testing.T) {
+	ctx := context.Background()
+	deps := newRestoreFileTestDeps(t)
+
+	ownerID := uuid.New()
+	originalFolderID := uuid.New()
+	archivedFile := newArchivedFile(ownerID, originalFolderID)
+	renamed, _ := archivedFile.Name.WithSequence(1)
+
+	deps.archivedFileRepo.On("FindByID", ctx, archivedFile.ID).Return(archivedFile, nil)
+	deps.folderRepo.On("ExistsByID", ctx, originalFolderID).Return(true, nil)
+	deps.fileRepo.On("ExistsByNameAndFolder", ctx, archivedFile.Name, originalFolderID).Return(true, nil)
+	deps.fileRepo.On("ExistsByNameAndFolder", ctx, renamed, originalFolderID).Return(false, nil)
+	deps.archivedFileVersionRepo.On("FindByArchivedFileID", ctx, archivedFile.ID).Return([]*entity.ArchivedFileVersion{}, nil)
+	deps.fileRepo.On("Create", ctx, mock.MatchedBy(func(f *entity.File) bool {
+		return f.Name.Equals(renamed)
+	})).Return(nil)
+	deps.archivedFileVersionRepo.On("DeleteByArchivedFileID", ctx, archivedFile.ID).Return(nil)
+	deps.archivedFileRepo.On("Delete", ctx, archivedFile.ID).Return(nil)
+
+	output, err := deps.newCommand().Execute(ctx, command.RestoreFileInput{
+		ArchivedFileID:   archivedFile.ID,
+		UserID:           ownerID,
+		ConflictStrategy: valueobject.ConflictStrategyRename,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "report (1).pdf", output.Name)
+}
+
+func TestRestoreFileCommand_Execute_OverwriteStrategy_RestoresAsNewVersionOfExisting(t *testing.T) {
+	ctx := context.Background()
+	deps := newRestoreFileTestDeps(t)
+
+	ownerID := uuid.New()
+	originalFolderID := uuid.New()
+	archivedFile := newArchivedFile(ownerID, originalFolderID)
+	existing := newActiveFileEntity(ownerID, originalFolderID)
+	latest := entity.NewArchivedFileVersion(archivedFile.ID, uuid.New(), 3, archivedFile.StorageKey, "minio-v3", nil, 2048, "checksum", ownerID, time.Now())
+
+	deps.archivedFileRepo.On("FindByID", ctx, archivedFile.ID).Return(archivedFile, nil)
+	deps.folderRepo.On("ExistsByID", ctx, originalFolderID).Return(true, nil)
+	deps.fileRepo.On("ExistsByNameAndFolder", ctx, archivedFile.Name, originalFolderID).Return(true, nil)
+	deps.fileRepo.On("FindByNameAndFolder", ctx, archivedFile.Name, originalFolderID).Return(existing, nil)
+	deps.archivedFileVersionRepo.On("FindByArchivedFileID", ctx, archivedFile.ID).Return([]*entity.ArchivedFileVersion{latest}, nil)
+	deps.quotaService.On("EnsureCapacity", ctx, ownerID, int64(2048)).Return(nil)
+	deps.storageService.On("CopyObjectVersion", ctx, archivedFile.StorageKey.String(), "minio-v3", existing.StorageKey.String()).Return("minio-new", nil)
+	deps.fileVersionRepo.On("Create", ctx, mock.AnythingOfType("*entity.FileVersion")).Return(nil)
+	deps.fileRepo.On("Update", ctx, existing).Return(nil)
+	deps.storageUsageRepo.On("AddUsedBytes", ctx, ownerID, int64(2048)).Return(nil).Once()
+	deps.blobService.On("Release", ctx, []uuid.UUID{}).Return([]*entity.Blob(nil), nil)
+	deps.archivedFileVersionRepo.On("DeleteByArchivedFileID", ctx, archivedFile.ID).Return(nil)
+	deps.archivedFileRepo.On("Delete", ctx, archivedFile.ID).Return(nil)
+	deps.storageUsageRepo.On("AddUsedBytes", ctx, ownerID, int64(-2048)).Return(nil).Once()
+	deps.storageService.On("DeleteObject", ctx, archivedFile.StorageKey.String()).Return(nil)
+	deps.blobService.On("Purge", ctx, []*entity.Blob(nil)).Return()
+
+	output, err := deps.newCommand().Execute(ctx, command.RestoreFileInput{
+		ArchivedFileID:   archivedFile.ID,
+		UserID:           ownerID,
+		ConflictStrategy: valueobject.ConflictStrategyOverwriteAsNewVersion,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, output.Overwritten)
+	assert.Equal(t, existing.ID, output.FileID)
+	assert.Equal(t, 2, output.VersionNumber)
+}
@@ -0,0 +1,152 @@
+package command
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// RestoreFileVersionInput はファイルバージョン復元の入力を定義します
+type RestoreFileVersionInput struct {
+	FileID        uuid.UUID
+	VersionNumber int // 復元元のバージョン番号
+	UserID        uuid.UUID
+}
+
+// RestoreFileVersionOutput はファイルバージョン復元の出力を定義します
+type RestoreFileVersionOutput struct {
+	FileID              uuid.UUID
+	RestoredFromVersion int
+	VersionNumber       int // 新しく作成された最新バージョン番号
+	Size                int64
+}
+
+// RestoreFileVersionCommand は過去のバージョンを最新バージョンとして復元するコマンドです
+type RestoreFileVersionCommand struct {
+	fileRepo           repository.FileRepository
+	fileVersionRepo    repository.FileVersionRepository
+	storageUsageRepo   repository.StorageUsageRepository
+	storageService     service.StorageService
+	blobService        service.BlobService
+	quotaService       service.StorageQuotaService
+	permissionResolver authz.PermissionResolver
+	txManager          repository.TransactionManager
+}
+
+// NewRestoreFileVersionCommand は新しいRestoreFileVersionCommandを作成します
+func NewRestoreFileVersionCommand(
+	fileRepo repository.FileRepository,
+	fileVersionRepo repository.FileVersionRepository,
+	storageUsageRepo repository.StorageUsageRepository,
+	storageService service.StorageService,
+	blobService service.BlobService,
+	quotaService service.StorageQuotaService,
+	permissionResolver authz.PermissionResolver,
+	txManager repository.TransactionManager,
+) *RestoreFileVersionCommand {
+	return &RestoreFileVersionCommand{
+		fileRepo:           fileRepo,
+		fileVersionRepo:    fileVersionRepo,
+		storageUsageRepo:   storageUsageRepo,
+		storageService:     storageService,
+		blobService:        blobService,
+		quotaService:       quotaService,
+		permissionResolver: permissionResolver,
+		txManager:          txManager,
+	}
+}
+
+// Execute はファイルバージョンの復元を実行します
+// 指定バージョンの内容をコピーした新しいバージョンを作成し、過去のバージョンは保持されます
+func (c *RestoreFileVersionCommand) Execute(ctx context.Context, input RestoreFileVersionInput) (*RestoreFileVersionOutput, error) {
+	// 1. ファイル取得
+	file, err := c.fileRepo.FindByID(ctx, input.FileID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. アクティブなファイルのみ復元可能
+	if !file.IsActive() {
+		return nil, apperror.NewValidationError("file is not active", nil)
+	}
+
+	// 3. 書き込み権限チェック
+	hasPermission, err := c.permissionResolver.HasPermission(ctx, input.UserID, authz.ResourceTypeFile, file.ID, authz.PermFileWrite)
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, apperror.NewForbiddenError("not authorized to restore versions of this file")
+	}
+
+	// 4. 復元元バージョン取得
+	if input.VersionNumber == file.CurrentVersion {
+		return nil, apperror.NewValidationError("version is already the current version", nil)
+	}
+	source, err := c.fileVersionRepo.FindByFileAndVersion(ctx, file.ID, input.VersionNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	// 5. クォータチェック（新バージョン分の容量はファイル所有者に計上される）
+	if err := c.quotaService.EnsureCapacity(ctx, file.OwnerID, source.Size); err != nil {
+		return nil, err
+	}
+
+	// 6. ブロブを共有していない場合は、MinIO上で復元元バージョンを同じキーにコピーし、新しいバージョンを作成
+	// ブロブを共有している場合は参照を追加するだけで、オブジェクトはコピーしない
+	var minioVersionID string
+	if !source.IsShared() {
+		minioVersionID, err = c.storageService.CopyObjectVersion(ctx, source.StorageKey.String(), source.MinioVersionID, file.StorageKey.String())
+		if err != nil {
+			return nil, apperror.NewInternalError(err)
+		}
+	}
+
+	// 7. 新バージョンを記録（トランザクション）
+	file.IncrementVersion()
+	err = c.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		version := entity.NewFileVersion(
+			file.ID,
+			file.CurrentVersion,
+			minioVersionID,
+			source.Size,
+			source.Checksum,
+			input.UserID,
+		)
+		if source.IsShared() {
+			blob, err := c.blobService.Acquire(ctx, *source.BlobID)
+			if err != nil {
+				return err
+			}
+			version.ReferenceBlob(blob)
+		}
+		if err := c.fileVersionRepo.Create(ctx, version); err != nil {
+			return err
+		}
+
+		file.UpdateSize(source.Size)
+		if err := c.fileRepo.Update(ctx, file); err != nil {
+			return err
+		}
+
+		// 所有者のストレージ使用量を加算
+		return c.storageUsageRepo.AddUsedBytes(ctx, file.OwnerID, source.Size)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RestoreFileVersionOutput{
+		FileID:              file.ID,
+		RestoredFromVersion: source.VersionNumber,
+		VersionNumber:       file.CurrentVersion,
+		Size:                source.Size,
+	}, nil
+}
@@ -0,0 +1,227 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/command"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
+)
+
+type restoreFileVersionTestDeps struct {
+	fileRepo           *mocks.MockFileRepository
+	fileVersionRepo    *mocks.MockFileVersionRepository
+	storageUsageRepo   *mocks.MockStorageUsageRepository
+	storageService     *mocks.MockStorageService
+	blobService        *mocks.MockBlobService
+	quotaService       *mocks.MockStorageQuotaService
+	permissionResolver *mocks.MockPermissionResolver
+	txManager          *mocks.MockTransactionManager
+}
+
+func newRestoreFileVersionTestDeps(t *testing.T) *restoreFileVersionTestDeps {
+	t.Helper()
+	return &restoreFileVersionTestDeps{
+		fileRepo:           mocks.NewMockFileRepository(t),
+		fileVersionRepo:    mocks.NewMockFileVersionRepository(t),
+		storageUsageRepo:   mocks.NewMockStorageUsageRepository(t),
+		storageService:     mocks.NewMockStorageService(t),
+		blobService:        mocks.NewMockBlobService(t),
+		quotaService:       mocks.NewMockStorageQuotaService(t),
+		permissionResolver: mocks.NewMockPermissionResolver(t),
+		txManager:          mocks.NewMockTransactionManager(t),
+	}
+}
+
+func (d *restoreFileVersionTestDeps) newCommand() *command.RestoreFileVersionCommand {
+	return command.NewRestoreFileVersionCommand(
+		d.fileRepo,
+		d.fileVersionRepo,
+		d.storageUsageRepo,
+		d.storageService,
+		d.blobService,
+		d.quotaService,
+		d.permissionResolver,
+		d.txManager,
+	)
+}
+
+func newStoredFileVersion(fileID uuid.UUID, versionNumber int, size int64) *entity.FileVersion {
+	return &entity.FileVersion{
+		ID:             uuid.New(),
+		FileID:         fileID,
+		VersionNumber:  versionNumber,
+		StorageKey:     valueobject.NewStorageKey(fileID),
+		MinioVersionID: fmt.Sprintf("minio-v%d", versionNumber),
+		Size:           size,
+		Checksum:       "checksum",
+		UploadedBy:     uuid.New(),
+		CreatedAt:      time.Now(),
+	}
+}
+
+func TestRestoreFileVersionCommand_Execute_ValidInput_CreatesNewHeadVersion(t *testing.T) {
+	ctx := context.Background()
+	deps := newRestoreFileVersionTestDeps(t)
+
+	ownerID := uuid.New()
+	editorID := uuid.New()
+	file := newActiveFileEntity(ownerID, uuid.New())
+	file.CurrentVersion = 3
+	source := newStoredFileVersion(file.ID, 1, 512)
+
+	input := command.RestoreFileVersionInput{
+		FileID:        file.ID,
+		VersionNumber: 1,
+		UserID:        editorID,
+	}
+
+	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, editorID, authz.ResourceTypeFile, file.ID, authz.PermFileWrite).Return(true, nil)
+	deps.fileVersionRepo.On("FindByFileAndVersion", ctx, file.ID, 1).Return(source, nil)
+	deps.quotaService.On("EnsureCapacity", ctx, ownerID, int64(512)).Return(nil)
+	deps.storageService.On("CopyObjectVersion", ctx, source.StorageKey.String(), source.MinioVersionID, file.StorageKey.String()).Return("minio-v4", nil)
+	deps.fileVersionRepo.On("Create", ctx, mock.MatchedBy(func(v *entity.FileVersion) bool {
+		return v.VersionNumber == 4 && v.MinioVersionID == "minio-v4" &&
+			v.Size == 512 && v.Checksum == source.Checksum && v.UploadedBy == editorID
+	})).Return(nil)
+	deps.fileRepo.On("Update", ctx, file).Return(nil)
+	deps.storageUsageRepo.On("AddUsedBytes", ctx, ownerID, int64(512)).Return(nil)
+
+	cmd := deps.newCommand()
+	output, err := cmd.Execute(ctx, input)
+
+	require.NoError(t, err)
+	require.NotNil(t, output)
+	assert.Equal(t, 1, output.RestoredFromVersion)
+	assert.Equal(t, 4, output.VersionNumber)
+	assert.Equal(t, int64(512), output.Size)
+	assert.Equal(t, 4, file.CurrentVersion)
+	assert.Equal(t, int64(512), file.Size)
+}
+
+func TestRestoreFileVersionCommand_Execute_NoWritePermission_ReturnsForbidden(t *testing.T) {
+	ctx := context.Background()
+	deps := newRestoreFileVersionTestDeps(t)
+
+	file := newActiveFileEntity(uuid.New(), uuid.New())
+	file.CurrentVersion = 2
+	viewerID := uuid.New()
+
+	input := command.RestoreFileVersionInput{
+		FileID:        file.ID,
+		VersionNumber: 1,
+		UserID:        viewerID,
+	}
+
+	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, viewerID, authz.ResourceTypeFile, file.ID, authz.PermFileWrite).Return(false, nil)
+
+	cmd := deps.newCommand()
+	output, err := cmd.Execute(ctx, input)
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeForbidden, appErr.Code)
+}
+
+func TestRestoreFileVersionCommand_Execute_CurrentVersion_ReturnsValidationError(t *testing.T) {
+	ctx := context.Background()
+	deps := newRestoreFileVersionTestDeps(t)
+
+	ownerID := uuid.New()
+	file := newActiveFileEntity(ownerID, uuid.New())
+	file.CurrentVersion = 2
+
+	input := command.RestoreFileVersionInput{
+		FileID:        file.ID,
+		VersionNumber: 2,
+		UserID:        ownerID,
+	}
+
+	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFile, file.ID, authz.PermFileWrite).Return(true, nil)
+
+	cmd := deps.newCommand()
+	output, err := cmd.Execute(ctx, input)
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeValidationError, appErr.Code)
+}
+
+func TestRestoreFileVersionCommand_Execute_VersionNotFound_ReturnsNotFound(t *testing.T) {
+	ctx := context.Background()
+	deps := newRestoreFileVersionTestDeps(t)
+
+	ownerID := uuid.New()
+	file := newActiveFileEntity(ownerID, uuid.New())
+	file.CurrentVersion = 2
+
+	input := command.RestoreFileVersionInput{
+		FileID:        file.ID,
+		VersionNumber: 9,
+		UserID:        ownerID,
+	}
+
+	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFile, file.ID, authz.PermFileWrite).Return(true, nil)
+	deps.fileVersionRepo.On("FindByFileAndVersion", ctx, file.ID, 9).Return(nil, apperror.NewNotFoundError("file version"))
+
+	cmd := deps.newCommand()
+	output, err := cmd.Execute(ctx, input)
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeNotFound, appErr.Code)
+}
+
+func TestRestoreFileVersionCommand_Execute_CopyFails_ReturnsInternalError(t *testing.T) {
+	ctx := context.Background()
+	deps := newRestoreFileVersionTestDeps(t)
+
+	ownerID := uuid.New()
+	file := newActiveFileEntity(ownerID, uuid.New())
+	file.CurrentVersion = 2
+	source := newStoredFileVersion(file.ID, 1, 512)
+
+	input := command.RestoreFileVersionInput{
+		FileID:        file.ID,
+		VersionNumber: 1,
+		UserID:        ownerID,
+	}
+
+	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFile, file.ID, authz.PermFileWrite).Return(true, nil)
+	deps.fileVersionRepo.On("FindByFileAndVersion", ctx, file.ID, 1).Return(source, nil)
+	deps.quotaService.On("EnsureCapacity", ctx, ownerID, int64(512)).Return(nil)
+	deps.storageService.On("CopyObjectVersion", ctx, source.StorageKey.String(), source.MinioVersionID, file.StorageKey.String()).Return("", errors.New("minio error"))
+
+	cmd := deps.newCommand()
+	output, err := cmd.Execute(ctx, input)
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeInternalError, appErr.Code)
+	assert.Equal(t, 2, file.CurrentVersion)
+}
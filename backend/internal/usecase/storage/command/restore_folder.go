@@ -0,0 +1,310 @@
+package command
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// RestoreFolderInput はフォルダ復元の入力を定義します
+type RestoreFolderInput struct {
+	ArchivedFolderID uuid.UUID
+	RestoreFolderID  *uuid.UUID // 復元先の親フォルダ。nilの場合は元の親フォルダに復元を試みる
+	UserID           uuid.UUID
+}
+
+// RestoreFolderOutput はフォルダ復元の出力を定義します
+type RestoreFolderOutput struct {
+	FolderID    uuid.UUID
+	ParentID    *uuid.UUID
+	Name        string
+	FolderCount int // 復元したフォルダ数（ルートを含む）
+	FileCount   int
+}
+
+// RestoreFolderCommand はフォルダをサブツリーごとゴミ箱から復元するコマンドです
+type RestoreFolderCommand struct {
+	folderRepo              repository.FolderRepository
+	folderClosureRepo       repository.FolderClosureRepository
+	fileRepo                repository.FileRepository
+	fileVersionRepo         repository.FileVersionRepository
+	archivedFolderRepo      repository.ArchivedFolderRepository
+	archivedSubfolderRepo   repository.ArchivedSubfolderRepository
+	archivedFileRepo        repository.ArchivedFileRepository
+	archivedFileVersionRepo repository.ArchivedFileVersionRepository
+	relationshipRepo        authz.RelationshipRepository
+	userRepo                repository.UserRepository
+	permissionResolver      authz.PermissionResolver
+	txManager               repository.TransactionManager
+}
+
+// NewRestoreFolderCommand は新しいRestoreFolderCommandを作成します
+func NewRestoreFolderCommand(
+	folderRepo repository.FolderRepository,
+	folderClosureRepo repository.FolderClosureRepository,
+	fileRepo repository.FileRepository,
+	fileVersionRepo repository.FileVersionRepository,
+	archivedFolderRepo repository.ArchivedFolderRepository,
+	archivedSubfolderRepo repository.ArchivedSubfolderRepository,
+	archivedFileRepo repository.ArchivedFileRepository,
+	archivedFileVersionRepo repository.ArchivedFileVersionRepository,
+	relationshipRepo authz.RelationshipRepository,
+	userRepo repository.UserRepository,
+	permissionResolver authz.PermissionResolver,
+	txManager repository.TransactionManager,
+) *RestoreFolderCommand {
+	return &RestoreFolderCommand{
+		folderRepo:              folderRepo,
+		folderClosureRepo:       folderClosureRepo,
+		fileRepo:                fileRepo,
+		fileVersionRepo:         fileVersionRepo,
+		archivedFolderRepo:      archivedFolderRepo,
+		archivedSubfolderRepo:   archivedSubfolderRepo,
+		archivedFileRepo:        archivedFileRepo,
+		archivedFileVersionRepo: archivedFileVersionRepo,
+		relationshipRepo:        relationshipRepo,
+		userRepo:                userRepo,
+		permissionResolver:      permissionResolver,
+		txManager:               txManager,
+	}
+}
+
+// Execute はフォルダをゴミ箱から復元します
+// 元のフォルダIDで階層（folders + folder_paths）を再構築し、アーカイブされたファイルを元のフォルダに戻します
+func (c *RestoreFolderCommand) Execute(ctx context.Context, input RestoreFolderInput) (*RestoreFolderOutput, error) {
+	// 1. アーカイブフォルダ取得
+	archivedFolder, err := c.archivedFolderRepo.FindByID(ctx, input.ArchivedFolderID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. 権限チェック
+	// 所有者は元の親フォルダが削除されていても復元できる。それ以外は元の親フォルダに対する folder:delete 権限が必要
+	if !archivedFolder.IsOwnedBy(input.UserID) {
+		if archivedFolder.OriginalParentID == nil {
+			return nil, apperror.NewForbiddenError("not authorized to restore this folder")
+		}
+		hasPermission, err := c.permissionResolver.HasPermission(ctx, input.UserID, authz.ResourceTypeFolder, *archivedFolder.OriginalParentID, authz.PermFolderDelete)
+		if err != nil {
+			return nil, err
+		}
+		if !hasPermission {
+			return nil, apperror.NewForbiddenError("not authorized to restore this folder")
+		}
+	}
+
+	// 3. 期限切れチェック
+	if archivedFolder.IsExpired() {
+		return nil, apperror.NewValidationError("archived folder has expired", nil)
+	}
+
+	// 4. 復元先の親フォルダを決定
+	parent, err := c.determineRestoreParent(ctx, archivedFolder, input.RestoreFolderID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	var parentID *uuid.UUID
+	rootDepth := 0
+	if parent != nil {
+		parentID = &parent.ID
+		rootDepth = parent.Depth + 1
+	}
+
+	// 5. サブツリーを取得して深さ制限をチェック
+	subfolders, err := c.archivedSubfolderRepo.FindByArchivedFolderID(ctx, archivedFolder.ID)
+	if err != nil {
+		return nil, err
+	}
+	maxRelativeDepth := 0
+	for _, sub := range subfolders {
+		if sub.RelativeDepth > maxRelativeDepth {
+			maxRelativeDepth = sub.RelativeDepth
+		}
+	}
+	if rootDepth+maxRelativeDepth > entity.MaxFolderDepth {
+		return nil, apperror.NewValidationError(entity.ErrFolderMaxDepthExceeded.Error(), nil)
+	}
+
+	// 6. 復元先での同名フォルダ存在チェック
+	var exists bool
+	if parentID != nil {
+		exists, err = c.folderRepo.ExistsByNameAndParent(ctx, archivedFolder.Name, parentID, archivedFolder.OwnerID)
+	} else {
+		exists, err = c.folderRepo.ExistsByNameAndOwnerRoot(ctx, archivedFolder.Name, archivedFolder.OwnerID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, apperror.NewConflictError("folder with same name already exists in restore folder")
+	}
+
+	// 7. アーカイブフォルダに含まれるファイルを取得
+	archivedFiles, err := c.archivedFileRepo.FindByArchivedFolderID(ctx, archivedFolder.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 8. トランザクションで階層とファイルを復元
+	root := archivedFolder.ToFolder(parentID, rootDepth)
+	fileDeps := restoreFileDeps{
+		fileRepo:                c.fileRepo,
+		fileVersionRepo:         c.fileVersionRepo,
+		archivedFileRepo:        c.archivedFileRepo,
+		archivedFileVersionRepo: c.archivedFileVersionRepo,
+	}
+	var folderCount int
+	err = c.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		// 復元したフォルダID
+		restored := make(map[uuid.UUID]struct{}, len(subfolders)+1)
+		// フォルダID -> 祖先パス（自己参照を除く）
+		ancestorPaths := make(map[uuid.UUID][]*entity.FolderPath, len(subfolders)+2)
+		if parentID != nil {
+			parentPaths, err := c.folderClosureRepo.FindAncestorPaths(ctx, *parentID)
+			if err != nil {
+				return err
+			}
+			ancestorPaths[*parentID] = parentPaths
+		}
+
+		restoreOne := func(folder *entity.Folder) error {
+			var paths []*entity.FolderPath
+			if folder.ParentID != nil {
+				paths = []*entity.FolderPath{entity.NewFolderPath(*folder.ParentID, folder.ID, 1)}
+				for _, path := range ancestorPaths[*folder.ParentID] {
+					paths = append(paths, entity.NewFolderPath(path.AncestorID, folder.ID, path.PathLength+1))
+				}
+			}
+
+			if err := c.folderRepo.Create(ctx, folder); err != nil {
+				return err
+			}
+			if err := c.folderClosureRepo.InsertSelfReference(ctx, folder.ID); err != nil {
+				return err
+			}
+			if len(paths) > 0 {
+				if err := c.folderClosureRepo.InsertAncestorPaths(ctx, paths); err != nil {
+					return err
+				}
+			}
+
+			// 削除前のリレーションが残っている場合に備えて張り直す
+			if err := c.relationshipRepo.DeleteByObject(ctx, authz.ObjectTypeFolder, folder.ID); err != nil {
+				return err
+			}
+			if folder.ParentID != nil {
+				if err := c.relationshipRepo.Create(ctx, authz.NewParentRelationship(authz.ObjectTypeFolder, *folder.ParentID, authz.ObjectTypeFolder, folder.ID)); err != nil {
+					return err
+				}
+			}
+			if err := c.relationshipRepo.Create(ctx, authz.NewOwnerRelationship(folder.OwnerID, authz.ObjectTypeFolder, folder.ID)); err != nil {
+				return err
+			}
+
+			restored[folder.ID] = struct{}{}
+			ancestorPaths[folder.ID] = paths
+			return nil
+		}
+
+		// 8a. フォルダ階層を浅い順に復元
+		if err := restoreOne(root); err != nil {
+			return err
+		}
+		for _, sub := range subfolders {
+			if _, ok := restored[sub.OriginalParentID]; !ok {
+				continue
+			}
+			if err := restoreOne(sub.ToFolder(rootDepth)); err != nil {
+				return err
+			}
+		}
+		folderCount = len(restored)
+
+		// 8b. ファイルを元のフォルダに復元（元のフォルダが無い場合はルートへ）
+		for _, af := range archivedFiles {
+			folderID := af.OriginalFolderID
+			if _, ok := restored[folderID]; !ok {
+				folderID = root.ID
+			}
+			if _, err := restoreArchivedFile(ctx, fileDeps, af, folderID, af.Name); err != nil {
+				return err
+			}
+		}
+
+		// 8c. アーカイブフォルダを削除
+		if err := c.archivedSubfolderRepo.DeleteByArchivedFolderID(ctx, archivedFolder.ID); err != nil {
+			return err
+		}
+		return c.archivedFolderRepo.Delete(ctx, archivedFolder.ID)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &RestoreFolderOutput{
+		FolderID:    root.ID,
+		ParentID:    parentID,
+		Name:        root.Name.String(),
+		FolderCount: folderCount,
+		FileCount:   len(archivedFiles),
+	}, nil
+}
+
+// determineRestoreParent は復元先の親フォルダを決定します
+// 戻り値がnilの場合はルートフォルダとして復元します
+func (c *RestoreFolderCommand) determineRestoreParent(
+	ctx context.Context,
+	archivedFolder *entity.ArchivedFolder,
+	requestedFolderID *uuid.UUID,
+	userID uuid.UUID,
+) (*entity.Folder, error) {
+	// 明示的に指定された場合
+	if requestedFolderID != nil {
+		folder, err := c.folderRepo.FindByID(ctx, *requestedFolderID)
+		if err != nil {
+			return nil, apperror.NewNotFoundError("restore folder not found")
+		}
+
+		// 復元先フォルダへのfolder:create権限チェック
+		hasPermission, err := c.permissionResolver.HasPermission(ctx, userID, authz.ResourceTypeFolder, folder.ID, authz.PermFolderCreate)
+		if err != nil {
+			return nil, err
+		}
+		if !hasPermission {
+			return nil, apperror.NewForbiddenError("not authorized to restore to this folder")
+		}
+
+		return folder, nil
+	}
+
+	// 元がルートフォルダの場合はルートに復元
+	if archivedFolder.OriginalParentID == nil {
+		return nil, nil
+	}
+
+	// 元の親フォルダが存在する場合はそこへ復元
+	exists, err := c.folderRepo.ExistsByID(ctx, *archivedFolder.OriginalParentID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return c.folderRepo.FindByID(ctx, *archivedFolder.OriginalParentID)
+	}
+
+	// 元の親フォルダが存在しない場合はPersonal Folderにフォールバック (R-AF003)
+	user, err := c.userRepo.FindByID(ctx, archivedFolder.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+	if user.PersonalFolderID == nil {
+		return nil, apperror.NewInternalError(errors.New("user has no personal folder"))
+	}
+	return c.folderRepo.FindByID(ctx, *user.PersonalFolderID)
+}
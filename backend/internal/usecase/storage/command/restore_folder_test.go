@@ -0,0 +1,284 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/command"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
+)
+
+type restoreFolderTestDeps struct {
+	folderRepo              *mocks.MockFolderRepository
+	folderClosureRepo       *mocks.MockFolderClosureRepository
+	fileRepo                *mocks.MockFileRepository
+	fileVersionRepo         *mocks.MockFileVersionRepository
+	archivedFolderRepo      *mocks.MockArchivedFolderRepository
+	archivedSubfolderRepo   *mocks.MockArchivedSubfolderRepository
+	archivedFileRepo        *mocks.MockArchivedFileRepository
+	archivedFileVersionRepo *mocks.MockArchivedFileVersionRepository
+	relationshipRepo        *mocks.MockRelationshipRepository
+	userRepo                *mocks.MockUserRepository
+	permissionResolver      *mocks.MockPermissionResolver
+	txManager               *mocks.MockTransactionManager
+}
+
+func newRestoreFolderTestDeps(t *testing.T) *restoreFolderTestDeps {
+	t.Helper()
+	return &restoreFolderTestDeps{
+		folderRepo:              mocks.NewMockFolderRepository(t),
+		folderClosureRepo:       mocks.NewMockFolderClosureRepository(t),
+		fileRepo:                mocks.NewMockFileRepository(t),
+		fileVersionRepo:         mocks.NewMockFileVersionRepository(t),
+		archivedFolderRepo:      mocks.NewMockArchivedFolderRepository(t),
+		archivedSubfolderRepo:   mocks.NewMockArchivedSubfolderRepository(t),
+		archivedFileRepo:        mocks.NewMockArchivedFileRepository(t),
+		archivedFileVersionRepo: mocks.NewMockArchivedFileVersionRepository(t),
+		relationshipRepo:        mocks.NewMockRelationshipRepository(t),
+		userRepo:                mocks.NewMockUserRepository(t),
+		permissionResolver:      mocks.NewMockPermissionResolver(t),
+		txManager:               mocks.NewMockTransactionManager(t),
+	}
+}
+
+func (d *restoreFolderTestDeps) newCommand() *command.RestoreFolderCommand {
+	return command.NewRestoreFolderCommand(
+		d.folderRepo,
+		d.folderClosureRepo,
+		d.fileRepo,
+		d.fileVersionRepo,
+		d.archivedFolderRepo,
+		d.archivedSubfolderRepo,
+		d.archivedFileRepo,
+		d.archivedFileVersionRepo,
+		d.relationshipRepo,
+		d.userRepo,
+		d.permissionResolver,
+		d.txManager,
+	)
+}
+
+func newArchivedFolder(ownerID uuid.UUID, originalParentID *uuid.UUID, expiresAt time.Time) *entity.ArchivedFolder {
+	name, _ := valueobject.NewFolderName("projects")
+	return entity.ReconstructArchivedFolder(
+		uuid.New(),
+		uuid.New(),
+		originalParentID,
+		"/projects",
+		name,
+		ownerID,
+		ownerID,
+		time.Now().Add(-time.Hour),
+		ownerID,
+		expiresAt,
+	)
+}
+
+func TestRestoreFolderCommand_Execute_Success_RebuildsHierarchyUnderOriginalParent(t *testing.T) {
+	ctx := context.Background()
+	deps := newRestoreFolderTestDeps(t)
+
+	ownerID := uuid.New()
+	parent := newRootFolderEntity(ownerID)
+	archivedFolder := newArchivedFolder(ownerID, &parent.ID, time.Now().Add(29*24*time.Hour))
+	subName, _ := valueobject.NewFolderName("drafts")
+	sub := entity.ReconstructArchivedSubfolder(
+		uuid.New(), archivedFolder.ID, uuid.New(), archivedFolder.OriginalFolderID, subName, ownerID, ownerID, 1,
+	)
+	rootFile := newArchivedFile(ownerID, archivedFolder.OriginalFolderID)
+	rootFile.AttachToArchivedFolder(archivedFolder)
+	subFile := newArchivedFile(ownerID, sub.OriginalFolderID)
+	subFile.AttachToArchivedFolder(archivedFolder)
+
+	deps.archivedFolderRepo.On("FindByID", ctx, archivedFolder.ID).Return(archivedFolder, nil)
+	deps.folderRepo.On("ExistsByID", ctx, parent.ID).Return(true, nil)
+	deps.folderRepo.On("FindByID", ctx, parent.ID).Return(parent, nil)
+	deps.archivedSubfolderRepo.On("FindByArchivedFolderID", ctx, archivedFolder.ID).Return([]*entity.ArchivedSubfolder{sub}, nil)
+	deps.folderRepo.On("ExistsByNameAndParent", ctx, archivedFolder.Name, &parent.ID, ownerID).Return(false, nil)
+	deps.archivedFileRepo.On("FindByArchivedFolderID", ctx, archivedFolder.ID).Return([]*entity.ArchivedFile{rootFile, subFile}, nil)
+
+	// フォルダ階層の再構築
+	deps.folderClosureRepo.On("FindAncestorPaths", ctx, parent.ID).Return([]*entity.FolderPath{}, nil)
+	deps.folderRepo.On("Create", ctx, mock.MatchedBy(func(f *entity.Folder) bool {
+		return f.ID == archivedFolder.OriginalFolderID && *f.ParentID == parent.ID && f.Depth == 1
+	})).Return(nil)
+	deps.folderRepo.On("Create", ctx, mock.MatchedBy(func(f *entity.Folder) bool {
+		return f.ID == sub.OriginalFolderID && *f.ParentID == archivedFolder.OriginalFolderID && f.Depth == 2
+	})).Return(nil)
+	deps.folderClosureRepo.On("InsertSelfReference", ctx, archivedFolder.OriginalFolderID).Return(nil)
+	deps.folderClosureRepo.On("InsertSelfReference", ctx, sub.OriginalFolderID).Return(nil)
+	deps.folderClosureRepo.On("InsertAncestorPaths", ctx, mock.MatchedBy(func(paths []*entity.FolderPath) bool {
+		return len(paths) == 1 && paths[0].DescendantID == archivedFolder.OriginalFolderID
+	})).Return(nil)
+	deps.folderClosureRepo.On("InsertAncestorPaths", ctx, mock.MatchedBy(func(paths []*entity.FolderPath) bool {
+		return len(paths) == 2 && paths[0].DescendantID == sub.OriginalFolderID &&
+			paths[1].AncestorID == parent.ID && paths[1].PathLength == 2
+	})).Return(nil)
+	deps.relationshipRepo.On("DeleteByObject", ctx, authz.ObjectTypeFolder, archivedFolder.OriginalFolderID).Return(nil)
+	deps.relationshipRepo.On("DeleteByObject", ctx, authz.ObjectTypeFolder, sub.OriginalFolderID).Return(nil)
+	deps.relationshipRepo.On("Create", ctx, mock.AnythingOfType("*authz.Relationship")).Return(nil).Times(4)
+
+	// ファイルの復元
+	for _, af := range []*entity.ArchivedFile{rootFile, subFile} {
+		deps.archivedFileVersionRepo.On("FindByArchivedFileID", ctx, af.ID).Return([]*entity.ArchivedFileVersion{}, nil)
+		deps.archivedFileVersionRepo.On("DeleteByArchivedFileID", ctx, af.ID).Return(nil)
+		deps.archivedFileRepo.On("Delete", ctx, af.ID).Return(nil)
+	}
+	deps.fileRepo.On("Create", ctx, mock.MatchedBy(func(f *entity.File) bool {
+		return f.ID == rootFile.OriginalFileID && f.FolderID == archivedFolder.OriginalFolderID
+	})).Return(nil)
+	deps.fileRepo.On("Create", ctx, mock.MatchedBy(func(f *entity.File) bool {
+		return f.ID == subFile.OriginalFileID && f.FolderID == sub.OriginalFolderID
+	})).Return(nil)
+
+	deps.archivedSubfolderRepo.On("DeleteByArchivedFolderID", ctx, archivedFolder.ID).Return(nil)
+	deps.archivedFolderRepo.On("Delete", ctx, archivedFolder.ID).Return(nil)
+
+	output, err := deps.newCommand().Execute(ctx, command.RestoreFolderInput{
+		ArchivedFolderID: archivedFolder.ID,
+		UserID:           ownerID,
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, output)
+	assert.Equal(t, archivedFolder.OriginalFolderID, output.FolderID)
+	assert.Equal(t, &parent.ID, output.ParentID)
+	assert.Equal(t, "projects", output.Name)
+	assert.Equal(t, 2, output.FolderCount)
+	assert.Equal(t, 2, output.FileCount)
+}
+
+func TestRestoreFolderCommand_Execute_NotOwnerOfRootFolder_ReturnsForbidden(t *testing.T) {
+	ctx := context.Background()
+	deps := newRestoreFolderTestDeps(t)
+
+	archivedFolder := newArchivedFolder(uuid.New(), nil, time.Now().Add(29*24*time.Hour))
+
+	deps.archivedFolderRepo.On("FindByID", ctx, archivedFolder.ID).Return(archivedFolder, nil)
+
+	output, err := deps.newCommand().Execute(ctx, command.RestoreFolderInput{
+		ArchivedFolderID: archivedFolder.ID,
+		UserID:           uuid.New(),
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeForbidden, appErr.Code)
+}
+
+func TestRestoreFolderCommand_Execute_NotOwnerWithoutDeletePermission_ReturnsForbidden(t *testing.T) {
+	ctx := context.Background()
+	deps := newRestoreFolderTestDeps(t)
+
+	userID := uuid.New()
+	parentID := uuid.New()
+	archivedFolder := newArchivedFolder(uuid.New(), &parentID, time.Now().Add(29*24*time.Hour))
+
+	deps.archivedFolderRepo.On("FindByID", ctx, archivedFolder.ID).Return(archivedFolder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFolder, parentID, authz.PermFolderDelete).Return(false, nil)
+
+	output, err := deps.newCommand().Execute(ctx, command.RestoreFolderInput{
+		ArchivedFolderID: archivedFolder.ID,
+		UserID:           userID,
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeForbidden, appErr.Code)
+}
+
+func TestRestoreFolderCommand_Execute_NotOwnerWithDeletePermission_RestoresUnderOriginalParent(t *testing.T) {
+	ctx := context.Background()
+	deps := newRestoreFolderTestDeps(t)
+
+	ownerID := uuid.New()
+	managerID := uuid.New()
+	parent := newRootFolderEntity(ownerID)
+	archivedFolder := newArchivedFolder(ownerID, &parent.ID, time.Now().Add(29*24*time.Hour))
+
+	deps.archivedFolderRepo.On("FindByID", ctx, archivedFolder.ID).Return(archivedFolder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, managerID, authz.ResourceTypeFolder, parent.ID, authz.PermFolderDelete).Return(true, nil)
+	deps.folderRepo.On("ExistsByID", ctx, parent.ID).Return(true, nil)
+	deps.folderRepo.On("FindByID", ctx, parent.ID).Return(parent, nil)
+	deps.archivedSubfolderRepo.On("FindByArchivedFolderID", ctx, archivedFolder.ID).Return([]*entity.ArchivedSubfolder{}, nil)
+	deps.folderRepo.On("ExistsByNameAndParent", ctx, archivedFolder.Name, &parent.ID, ownerID).Return(false, nil)
+	deps.archivedFileRepo.On("FindByArchivedFolderID", ctx, archivedFolder.ID).Return([]*entity.ArchivedFile{}, nil)
+	deps.folderClosureRepo.On("FindAncestorPaths", ctx, parent.ID).Return([]*entity.FolderPath{}, nil)
+	deps.folderRepo.On("Create", ctx, mock.MatchedBy(func(f *entity.Folder) bool {
+		return f.ID == archivedFolder.OriginalFolderID && f.OwnerID == ownerID
+	})).Return(nil)
+	deps.folderClosureRepo.On("InsertSelfReference", ctx, archivedFolder.OriginalFolderID).Return(nil)
+	deps.folderClosureRepo.On("InsertAncestorPaths", ctx, mock.Anything).Return(nil)
+	deps.relationshipRepo.On("DeleteByObject", ctx, authz.ObjectTypeFolder, archivedFolder.OriginalFolderID).Return(nil)
+	deps.relationshipRepo.On("Create", ctx, mock.AnythingOfType("*authz.Relationship")).Return(nil).Times(2)
+	deps.archivedSubfolderRepo.On("DeleteByArchivedFolderID", ctx, archivedFolder.ID).Return(nil)
+	deps.archivedFolderRepo.On("Delete", ctx, archivedFolder.ID).Return(nil)
+
+	output, err := deps.newCommand().Execute(ctx, command.RestoreFolderInput{
+		ArchivedFolderID: archivedFolder.ID,
+		UserID:           managerID,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, archivedFolder.OriginalFolderID, output.FolderID)
+	assert.Equal(t, &parent.ID, output.ParentID)
+}
+
+func TestRestoreFolderCommand_Execute_Expired_ReturnsValidationError(t *testing.T) {
+	ctx := context.Background()
+	deps := newRestoreFolderTestDeps(t)
+
+	ownerID := uuid.New()
+	archivedFolder := newArchivedFolder(ownerID, nil, time.Now().Add(-time.Hour))
+
+	deps.archivedFolderRepo.On("FindByID", ctx, archivedFolder.ID).Return(archivedFolder, nil)
+
+	output, err := deps.newCommand().Execute(ctx, command.RestoreFolderInput{
+		ArchivedFolderID: archivedFolder.ID,
+		UserID:           ownerID,
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeValidationError, appErr.Code)
+}
+
+func TestRestoreFolderCommand_Execute_DuplicateNameAtRoot_ReturnsConflict(t *testing.T) {
+	ctx := context.Background()
+	deps := newRestoreFolderTestDeps(t)
+
+	ownerID := uuid.New()
+	archivedFolder := newArchivedFolder(ownerID, nil, time.Now().Add(29*24*time.Hour))
+
+	deps.archivedFolderRepo.On("FindByID", ctx, archivedFolder.ID).Return(archivedFolder, nil)
+	deps.archivedSubfolderRepo.On("FindByArchivedFolderID", ctx, archivedFolder.ID).Return([]*entity.ArchivedSubfolder{}, nil)
+	deps.folderRepo.On("ExistsByNameAndOwnerRoot", ctx, archivedFolder.Name, ownerID).Return(true, nil)
+
+	output, err := deps.newCommand().Execute(ctx, command.RestoreFolderInput{
+		ArchivedFolderID: archivedFolder.ID,
+		UserID:           ownerID,
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeConflict, appErr.Code)
+}
@@ -0,0 +1,167 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+const (
+	// copyJobBatchSize は1回の実行で処理するコピージョブの上限です
+	copyJobBatchSize = 10
+	// copyJobHeartbeatInterval は実行中のジョブのハートビートを更新する間隔です
+	copyJobHeartbeatInterval = 30 * time.Second
+	// copyJobLeaseTimeout はハートビートが途絶えたジョブを中断扱いにするまでの時間です
+	// 一時的なDB障害でハートビートを数回取りこぼしても回収されないよう、更新間隔より十分長くします
+	copyJobLeaseTimeout = 2 * time.Minute
+	// copyJobInterruptedMessage は中断扱いにしたジョブの失敗理由です
+	copyJobInterruptedMessage = "copy was interrupted"
+)
+
+// RunCopyJobsOutput はコピージョブ実行の出力を定義します
+type RunCopyJobsOutput struct {
+	Succeeded   int
+	Failed      int
+	Interrupted int // ハートビートが途絶えて失敗扱いにしたジョブ数と、実行中にリースを失ったジョブ数
+}
+
+// RunCopyJobsCommand は登録済みのフォルダコピージョブを実行するコマンドです
+// バックグラウンドワーカーから定期的に呼び出され、複数レプリカで同時に実行しても同じジョブを二重に実行しません
+// ジョブを取得したレプリカはリースを保持し、実行中はハートビートを更新し続けます
+type RunCopyJobsCommand struct {
+	copyFolderCommand *CopyFolderCommand
+	copyJobRepo       repository.CopyJobRepository
+	heartbeatInterval time.Duration
+}
+
+// NewRunCopyJobsCommand は新しいRunCopyJobsCommandを作成します
+func NewRunCopyJobsCommand(
+	copyFolderCommand *CopyFolderCommand,
+	copyJobRepo repository.CopyJobRepository,
+) *RunCopyJobsCommand {
+	return &RunCopyJobsCommand{
+		copyFolderCommand: copyFolderCommand,
+		copyJobRepo:       copyJobRepo,
+		heartbeatInterval: copyJobHeartbeatInterval,
+	}
+}
+
+// Execute は中断されたジョブを失敗として記録し、実行待ちのジョブを登録順に実行します
+// コピーはトランザクションで行われるため、中断されたジョブがフォルダやファイルを中途半端に残すことはありません
+func (c *RunCopyJobsCommand) Execute(ctx context.Context) (*RunCopyJobsOutput, error) {
+	output := &RunCopyJobsOutput{}
+
+	// 1. ハートビートが途絶えたジョブのみを中断として記録
+	// 他のレプリカが実行中のジョブはハートビートが更新され続けるため対象にならない
+	interrupted, err := c.copyJobRepo.FailRunningHeartbeatBefore(ctx, time.Now().Add(-copyJobLeaseTimeout), copyJobInterruptedMessage)
+	if err != nil {
+		return nil, err
+	}
+	output.Interrupted = int(interrupted)
+
+	// 2. 実行待ちのジョブを1件ずつ取得して実行
+	for i := 0; i < copyJobBatchSize; i++ {
+		leaseToken := uuid.New()
+		job, err := c.copyJobRepo.ClaimNextPending(ctx, leaseToken)
+		if err != nil {
+			if apperror.IsNotFound(err) {
+				break
+			}
+			return nil, err
+		}
+		leaseLost, err := c.run(ctx, job, leaseToken)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case leaseLost:
+			output.Interrupted++
+		case job.Status == entity.CopyJobStatusSucceeded:
+			output.Succeeded++
+		default:
+			output.Failed++
+		}
+	}
+
+	return output, nil
+}
+
+// run は取得済みのジョブをleaseTokenのリースを保持したまま実行し、結果を記録します
+// コピーの失敗はジョブの失敗として記録し、状態の記録に失敗した場合のみエラーを返します
+// 実行中にリースを失った場合はコピーを中止し、結果を記録せずにtrueを返します
+func (c *RunCopyJobsCommand) run(ctx context.Context, job *entity.CopyJob, leaseToken uuid.UUID) (bool, error) {
+	copyCtx, cancel := context.WithCancel(ctx)
+	leaseLost := c.holdLease(copyCtx, cancel, job.ID, leaseToken)
+
+	// 登録後に権限やコピー先が変わっている可能性があるため、実行時に改めて検証する
+	result, err := c.copyFolderCommand.Execute(copyCtx, CopyFolderInput{
+		FolderID:            job.SourceFolderID,
+		DestinationFolderID: job.DestinationFolderID,
+		UserID:              job.UserID,
+	})
+	cancel()
+	if <-leaseLost {
+		slog.Warn("copy job lease lost: result discarded", "copy_job_id", job.ID)
+		return true, nil
+	}
+
+	if err != nil {
+		slog.Error("copy job failed", "copy_job_id", job.ID, "error", err)
+		job.Fail(copyJobFailureMessage(err))
+	} else {
+		job.Succeed(result.Folder.ID, result.FolderCount, result.FileCount)
+	}
+
+	return false, c.copyJobRepo.Update(ctx, job)
+}
+
+// holdLease はctxがキャンセルされるまでジョブのハートビートを更新し続けます
+// リースを失った場合はcancelでコピーを中止します
+// 返り値のチャネルには、終了時にリースを失っていたかどうかが1度だけ送られます
+func (c *RunCopyJobsCommand) holdLease(ctx context.Context, cancel context.CancelFunc, jobID, leaseToken uuid.UUID) <-chan bool {
+	leaseLost := make(chan bool, 1)
+
+	go func() {
+		ticker := time.NewTicker(c.heartbeatInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				leaseLost <- false
+				return
+			case <-ticker.C:
+				held, err := c.copyJobRepo.Heartbeat(ctx, jobID, leaseToken)
+				if err != nil {
+					// 一時的な障害の可能性があるため、リースの期限内は更新を再試行する
+					slog.Warn("failed to heartbeat copy job", "copy_job_id", jobID, "error", err)
+					continue
+				}
+				if !held {
+					cancel()
+					leaseLost <- true
+					return
+				}
+			}
+		}
+	}()
+
+	return leaseLost
+}
+
+// copyJobFailureMessage はクライアントに返す失敗理由を返します
+// 内部エラーの詳細は公開しません
+func copyJobFailureMessage(err error) string {
+	var appErr *apperror.AppError
+	if errors.As(err, &appErr) && appErr.Code != apperror.CodeInternalError {
+		return appErr.Message
+	}
+	return "internal error"
+}
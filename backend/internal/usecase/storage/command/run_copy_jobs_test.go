@@ -0,0 +1,128 @@
+package command_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/command"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
+)
+
+func TestRunCopyJobsCommand_Execute_PendingJob_CopiesAndRecordsSuccess(t *testing.T) {
+	ctx := context.Background()
+	deps := newCopyFolderTestDeps(t)
+	copyJobRepo := mocks.NewMockCopyJobRepository(t)
+
+	userID := uuid.New()
+	source := newRootFolderEntity(userID)
+	dest := newFolderEntity(userID)
+	job := entity.NewCopyJob(userID, source.ID, dest.ID)
+
+	copyJobRepo.On("FailRunningHeartbeatBefore", ctx, mock.AnythingOfType("time.Time"), "copy was interrupted").Return(int64(0), nil)
+	copyJobRepo.On("ClaimNextPending", ctx, mock.AnythingOfType("uuid.UUID")).Return(job, nil).Once()
+	copyJobRepo.On("ClaimNextPending", ctx, mock.AnythingOfType("uuid.UUID")).Return(nil, apperror.NewNotFoundError("pending copy job")).Once()
+	copyJobRepo.On("Update", ctx, job).Return(nil).Once()
+
+	// コピーはリースの保持中だけ有効な派生コンテキストで実行される
+	copyCtx := mock.Anything
+	expectFolderCopyPrepared(copyCtx, deps, userID, source, dest, []*entity.File{})
+	deps.folderClosureRepo.On("FindAncestorPaths", copyCtx, dest.ID).Return([]*entity.FolderPath{}, nil)
+	deps.folderRepo.On("Create", copyCtx, mock.AnythingOfType("*entity.Folder")).Return(nil)
+	deps.folderClosureRepo.On("InsertSelfReference", copyCtx, mock.Anything).Return(nil)
+	deps.folderClosureRepo.On("InsertAncestorPaths", copyCtx, mock.Anything).Return(nil)
+	deps.relationshipRepo.On("Create", copyCtx, mock.Anything).Return(nil)
+
+	output, err := command.NewRunCopyJobsCommand(deps.newCommand(), copyJobRepo).Execute(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, output.Succeeded)
+	assert.Equal(t, 0, output.Failed)
+	assert.Equal(t, entity.CopyJobStatusSucceeded, job.Status)
+	require.NotNil(t, job.ResultFolderID)
+	assert.Equal(t, 1, job.FolderCount)
+	assert.NotNil(t, job.FinishedAt)
+}
+
+func TestRunCopyJobsCommand_Execute_SourceDeleted_RecordsFailure(t *testing.T) {
+	ctx := context.Background()
+	deps := newCopyFolderTestDeps(t)
+	copyJobRepo := mocks.NewMockCopyJobRepository(t)
+
+	job := entity.NewCopyJob(uuid.New(), uuid.New(), uuid.New())
+
+	copyJobRepo.On("FailRunningHeartbeatBefore", ctx, mock.AnythingOfType("time.Time"), "copy was interrupted").Return(int64(0), nil)
+	copyJobRepo.On("ClaimNextPending", ctx, mock.AnythingOfType("uuid.UUID")).Return(job, nil).Once()
+	copyJobRepo.On("ClaimNextPending", ctx, mock.AnythingOfType("uuid.UUID")).Return(nil, apperror.NewNotFoundError("pending copy job")).Once()
+	copyJobRepo.On("Update", ctx, job).Return(nil).Once()
+	deps.folderRepo.On("FindByID", mock.Anything, job.SourceFolderID).Return(nil, apperror.NewNotFoundError("folder"))
+
+	output, err := command.NewRunCopyJobsCommand(deps.newCommand(), copyJobRepo).Execute(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, output.Succeeded)
+	assert.Equal(t, 1, output.Failed)
+	assert.Equal(t, entity.CopyJobStatusFailed, job.Status)
+	require.NotNil(t, job.ErrorMessage)
+	assert.Equal(t, "folder not found", *job.ErrorMessage)
+	assert.Nil(t, job.ResultFolderID)
+}
+
+func TestRunCopyJobsCommand_Execute_HeartbeatExpired_MarkedInterrupted(t *testing.T) {
+	ctx := context.Background()
+	deps := newCopyFolderTestDeps(t)
+	copyJobRepo := mocks.NewMockCopyJobRepository(t)
+
+	// ハートビートが途絶えてからリースの期限を過ぎたジョブのみが対象になる
+	heartbeatBefore := mock.MatchedBy(func(before time.Time) bool {
+		return time.Since(before) >= 2*time.Minute && time.Since(before) < 3*time.Minute
+	})
+	copyJobRepo.On("FailRunningHeartbeatBefore", ctx, heartbeatBefore, "copy was interrupted").Return(int64(1), nil)
+	copyJobRepo.On("ClaimNextPending", ctx, mock.AnythingOfType("uuid.UUID")).Return(nil, apperror.NewNotFoundError("pending copy job"))
+
+	output, err := command.NewRunCopyJobsCommand(deps.newCommand(), copyJobRepo).Execute(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, output.Interrupted)
+}
+
+func TestRunCopyJobsCommand_Execute_LeaseLost_CancelsCopyWithoutRecording(t *testing.T) {
+	ctx := context.Background()
+	deps := newCopyFolderTestDeps(t)
+	copyJobRepo := mocks.NewMockCopyJobRepository(t)
+
+	job := entity.NewCopyJob(uuid.New(), uuid.New(), uuid.New())
+
+	var leaseToken uuid.UUID
+	copyJobRepo.On("FailRunningHeartbeatBefore", ctx, mock.AnythingOfType("time.Time"), "copy was interrupted").Return(int64(0), nil)
+	copyJobRepo.On("ClaimNextPending", ctx, mock.AnythingOfType("uuid.UUID")).
+		Run(func(args mock.Arguments) { leaseToken = args.Get(1).(uuid.UUID) }).
+		Return(job, nil).Once()
+	copyJobRepo.On("ClaimNextPending", ctx, mock.AnythingOfType("uuid.UUID")).Return(nil, apperror.NewNotFoundError("pending copy job")).Once()
+	// 他のレプリカにリースを奪われた状態を再現する
+	copyJobRepo.On("Heartbeat", mock.Anything, job.ID, mock.MatchedBy(func(token uuid.UUID) bool {
+		return token == leaseToken
+	})).Return(false, nil).Once()
+
+	// コピーはリースを失うまで終わらない
+	deps.folderRepo.On("FindByID", mock.Anything, job.SourceFolderID).
+		Run(func(args mock.Arguments) { <-args.Get(0).(context.Context).Done() }).
+		Return(nil, context.Canceled)
+
+	cmd := command.NewRunCopyJobsCommand(deps.newCommand(), copyJobRepo)
+	cmd.SetHeartbeatInterval(10 * time.Millisecond)
+	output, err := cmd.Execute(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, output.Interrupted)
+	assert.Equal(t, 0, output.Failed)
+	assert.Equal(t, entity.CopyJobStatusPending, job.Status)
+	copyJobRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
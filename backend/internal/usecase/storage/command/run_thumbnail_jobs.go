@@ -0,0 +1,117 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+const (
+	// thumbnailJobBatchSize は1回の実行で生成するサムネイルの上限です
+	thumbnailJobBatchSize = 20
+	// thumbnailJobStaleAfter は生成中のまま放置されたサムネイルを中断扱いにするまでの時間です
+	thumbnailJobStaleAfter = 15 * time.Minute
+)
+
+// RunThumbnailJobsOutput はサムネイル生成ジョブ実行の出力を定義します
+type RunThumbnailJobsOutput struct {
+	Generated   int
+	Failed      int
+	Interrupted int // 生成中にプロセスが停止し、失敗扱いにしたサムネイル数
+}
+
+// RunThumbnailJobsCommand は登録済みのサムネイル生成を実行するコマンドです
+// バックグラウンドワーカーから定期的に呼び出され、複数レプリカで同時に実行しても同じサムネイルを二重に生成しません
+type RunThumbnailJobsCommand struct {
+	thumbnailRepo    repository.ThumbnailRepository
+	thumbnailService service.ThumbnailService
+}
+
+// NewRunThumbnailJobsCommand は新しいRunThumbnailJobsCommandを作成します
+func NewRunThumbnailJobsCommand(
+	thumbnailRepo repository.ThumbnailRepository,
+	thumbnailService service.ThumbnailService,
+) *RunThumbnailJobsCommand {
+	return &RunThumbnailJobsCommand{
+		thumbnailRepo:    thumbnailRepo,
+		thumbnailService: thumbnailService,
+	}
+}
+
+// Execute は中断された生成を失敗として記録し、生成待ちのサムネイルを登録順に生成します
+func (c *RunThumbnailJobsCommand) Execute(ctx context.Context) (*RunThumbnailJobsOutput, error) {
+	output := &RunThumbnailJobsOutput{}
+
+	// 1. 中断された生成を失敗として記録
+	stale, err := c.thumbnailRepo.FindRunningStartedBefore(ctx, time.Now().Add(-thumbnailJobStaleAfter))
+	if err != nil {
+		return nil, err
+	}
+	for _, thumbnail := range stale {
+		thumbnail.Fail("thumbnail generation was interrupted")
+		if err := c.thumbnailRepo.Update(ctx, thumbnail); err != nil {
+			return nil, err
+		}
+		output.Interrupted++
+	}
+
+	// 2. 生成待ちのサムネイルを1件ずつ取得して生成
+	for i := 0; i < thumbnailJobBatchSize; i++ {
+		thumbnail, err := c.thumbnailRepo.ClaimNextPending(ctx)
+		if err != nil {
+			if apperror.IsNotFound(err) {
+				break
+			}
+			return nil, err
+		}
+		if err := c.run(ctx, thumbnail); err != nil {
+			return nil, err
+		}
+		if thumbnail.Status == entity.ThumbnailStatusReady {
+			output.Generated++
+		} else {
+			output.Failed++
+		}
+	}
+
+	return output, nil
+}
+
+// run は取得済みのサムネイルを生成し、結果を記録します
+// 生成の失敗はサムネイルの失敗として記録し、状態の記録に失敗した場合のみエラーを返します
+func (c *RunThumbnailJobsCommand) run(ctx context.Context, thumbnail *entity.Thumbnail) error {
+	if err := c.thumbnailService.Render(ctx, thumbnail); err != nil {
+		slog.Warn("thumbnail generation failed",
+			"thumbnail_id", thumbnail.ID,
+			"file_id", thumbnail.FileID,
+			"version", thumbnail.VersionNumber,
+			"error", err,
+		)
+		thumbnail.Fail(thumbnailFailureMessage(err))
+	} else {
+		thumbnail.MarkReady()
+	}
+
+	return c.thumbnailRepo.Update(ctx, thumbnail)
+}
+
+// thumbnailFailureMessage は記録する失敗理由を返します
+// 画像自体が原因の場合のみ理由を記録し、内部エラーの詳細は記録しません
+func thumbnailFailureMessage(err error) string {
+	for _, known := range []error{
+		service.ErrUnsupportedImageFormat,
+		service.ErrInvalidImage,
+		service.ErrImageTooLarge,
+	} {
+		if errors.Is(err, known) {
+			return known.Error()
+		}
+	}
+	return "internal error"
+}
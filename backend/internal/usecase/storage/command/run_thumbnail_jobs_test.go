@@ -0,0 +1,117 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/command"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
+)
+
+type runThumbnailJobsTestDeps struct {
+	thumbnailRepo    *mocks.MockThumbnailRepository
+	thumbnailService *mocks.MockThumbnailService
+}
+
+func newRunThumbnailJobsTestDeps(t *testing.T) *runThumbnailJobsTestDeps {
+	t.Helper()
+	return &runThumbnailJobsTestDeps{
+		thumbnailRepo:    mocks.NewMockThumbnailRepository(t),
+		thumbnailService: mocks.NewMockThumbnailService(t),
+	}
+}
+
+func (d *runThumbnailJobsTestDeps) newCommand() *command.RunThumbnailJobsCommand {
+	return command.NewRunThumbnailJobsCommand(d.thumbnailRepo, d.thumbnailService)
+}
+
+func newPendingThumbnail() *entity.Thumbnail {
+	return entity.NewThumbnail(entity.NewFileVersion(uuid.New(), 1, "minio-v1", 1024, "checksum", uuid.New()))
+}
+
+func TestRunThumbnailJobsCommand_Execute_PendingThumbnail_RendersAndMarksReady(t *testing.T) {
+	ctx := context.Background()
+	deps := newRunThumbnailJobsTestDeps(t)
+	thumbnail := newPendingThumbnail()
+
+	deps.thumbnailRepo.On("FindRunningStartedBefore", ctx, mock.AnythingOfType("time.Time")).Return([]*entity.Thumbnail{}, nil)
+	deps.thumbnailRepo.On("ClaimNextPending", ctx).Return(thumbnail, nil).Once()
+	deps.thumbnailRepo.On("ClaimNextPending", ctx).Return(nil, apperror.NewNotFoundError("pending thumbnail")).Once()
+	deps.thumbnailService.On("Render", ctx, thumbnail).Return(nil)
+	deps.thumbnailRepo.On("Update", ctx, thumbnail).Return(nil).Once()
+
+	output, err := deps.newCommand().Execute(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, output.Generated)
+	assert.Equal(t, 0, output.Failed)
+	assert.Equal(t, entity.ThumbnailStatusReady, thumbnail.Status)
+	assert.NotNil(t, thumbnail.FinishedAt)
+}
+
+func TestRunThumbnailJobsCommand_Execute_UnsupportedImage_RecordsReason(t *testing.T) {
+	ctx := context.Background()
+	deps := newRunThumbnailJobsTestDeps(t)
+	thumbnail := newPendingThumbnail()
+
+	deps.thumbnailRepo.On("FindRunningStartedBefore", ctx, mock.AnythingOfType("time.Time")).Return([]*entity.Thumbnail{}, nil)
+	deps.thumbnailRepo.On("ClaimNextPending", ctx).Return(thumbnail, nil).Once()
+	deps.thumbnailRepo.On("ClaimNextPending", ctx).Return(nil, apperror.NewNotFoundError("pending thumbnail")).Once()
+	deps.thumbnailService.On("Render", ctx, thumbnail).Return(service.ErrUnsupportedImageFormat)
+	deps.thumbnailRepo.On("Update", ctx, thumbnail).Return(nil).Once()
+
+	output, err := deps.newCommand().Execute(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, output.Generated)
+	assert.Equal(t, 1, output.Failed)
+	assert.Equal(t, entity.ThumbnailStatusFailed, thumbnail.Status)
+	require.NotNil(t, thumbnail.ErrorMessage)
+	assert.Equal(t, "unsupported image format", *thumbnail.ErrorMessage)
+}
+
+func TestRunThumbnailJobsCommand_Execute_StorageError_HidesDetails(t *testing.T) {
+	ctx := context.Background()
+	deps := newRunThumbnailJobsTestDeps(t)
+	thumbnail := newPendingThumbnail()
+
+	deps.thumbnailRepo.On("FindRunningStartedBefore", ctx, mock.AnythingOfType("time.Time")).Return([]*entity.Thumbnail{}, nil)
+	deps.thumbnailRepo.On("ClaimNextPending", ctx).Return(thumbnail, nil).Once()
+	deps.thumbnailRepo.On("ClaimNextPending", ctx).Return(nil, apperror.NewNotFoundError("pending thumbnail")).Once()
+	deps.thumbnailService.On("Render", ctx, thumbnail).Return(errors.New("dial tcp minio:9000: connection refused"))
+	deps.thumbnailRepo.On("Update", ctx, thumbnail).Return(nil).Once()
+
+	output, err := deps.newCommand().Execute(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, output.Failed)
+	require.NotNil(t, thumbnail.ErrorMessage)
+	assert.Equal(t, "internal error", *thumbnail.ErrorMessage)
+}
+
+func TestRunThumbnailJobsCommand_Execute_StaleRunningThumbnail_MarkedInterrupted(t *testing.T) {
+	ctx := context.Background()
+	deps := newRunThumbnailJobsTestDeps(t)
+	stale := newPendingThumbnail()
+	stale.Status = entity.ThumbnailStatusRunning
+
+	deps.thumbnailRepo.On("FindRunningStartedBefore", ctx, mock.AnythingOfType("time.Time")).Return([]*entity.Thumbnail{stale}, nil)
+	deps.thumbnailRepo.On("Update", ctx, stale).Return(nil).Once()
+	deps.thumbnailRepo.On("ClaimNextPending", ctx).Return(nil, apperror.NewNotFoundError("pending thumbnail")).Once()
+
+	output, err := deps.newCommand().Execute(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, output.Interrupted)
+	assert.Equal(t, entity.ThumbnailStatusFailed, stale.Status)
+	deps.thumbnailService.AssertNotCalled(t, "Render", mock.Anything, mock.Anything)
+}
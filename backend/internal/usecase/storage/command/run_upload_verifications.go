@@ -0,0 +1,72 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// uploadVerificationBatchSize は1回の実行で検証するアップロードの上限です
+const uploadVerificationBatchSize = 20
+
+// RunUploadVerificationsOutput はアップロード検証の実行の出力を定義します
+type RunUploadVerificationsOutput struct {
+	Completed int
+	Rejected  int // 内容が申告されたチェックサムと一致せず、失敗として記録したアップロード数
+	Failed    int // 検証中にエラーが発生し、次回の実行で再試行するアップロード数
+}
+
+// RunUploadVerificationsCommand は検証待ちのアップロードの内容を再計算し、アップロードを完了するコマンドです
+// バックグラウンドワーカーから定期的に呼び出されます
+// 完了処理はセッションの行ロックで直列化されるため、Webhookなどと並行しても同じアップロードを二重に完了しません
+type RunUploadVerificationsCommand struct {
+	uploadSessionRepo     repository.UploadSessionRepository
+	completeUploadCommand *CompleteUploadCommand
+}
+
+// NewRunUploadVerificationsCommand は新しいRunUploadVerificationsCommandを作成します
+func NewRunUploadVerificationsCommand(
+	uploadSessionRepo repository.UploadSessionRepository,
+	completeUploadCommand *CompleteUploadCommand,
+) *RunUploadVerificationsCommand {
+	return &RunUploadVerificationsCommand{
+		uploadSessionRepo:     uploadSessionRepo,
+		completeUploadCommand: completeUploadCommand,
+	}
+}
+
+// Execute は検証待ちのセッションを古い順に検証して完了します
+// 1件の検証に失敗しても残りのセッションの検証を続け、失敗したセッションは次回の実行で再試行します
+func (c *RunUploadVerificationsCommand) Execute(ctx context.Context) (*RunUploadVerificationsOutput, error) {
+	sessions, err := c.uploadSessionRepo.FindVerifying(ctx, uploadVerificationBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &RunUploadVerificationsOutput{}
+	for _, session := range sessions {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		_, err := c.completeUploadCommand.VerifySession(ctx, session)
+		switch {
+		case err == nil:
+			output.Completed++
+		case apperror.IsClientError(err):
+			// 内容の不一致はVerifySession内で失敗として記録済み
+			output.Rejected++
+		default:
+			slog.Error("upload verification failed",
+				"session_id", session.ID,
+				"file_id", session.FileID,
+				"error", err,
+			)
+			output.Failed++
+		}
+	}
+
+	return output, nil
+}
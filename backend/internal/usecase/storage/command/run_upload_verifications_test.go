@@ -0,0 +1,70 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/command"
+)
+
+func TestRunUploadVerificationsCommand_Execute_ChecksumMismatch_CountsRejected(t *testing.T) {
+	ctx := context.Background()
+	deps := newCompleteUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	folderID := uuid.New()
+	file := newUploadingFileEntity(ownerID, folderID)
+	session := newPendingSession(file.ID, ownerID, folderID)
+	expected, _ := valueobject.NewContentChecksum("crc32c", "ffffffff")
+	session.SetExpectedChecksum(&expected)
+	require.NoError(t, session.StartVerification("v1", 1024))
+
+	storageKey := file.StorageKey.String()
+	deps.uploadSessionRepo.On("FindVerifying", ctx, 20).Return([]*entity.UploadSession{session}, nil)
+	deps.fileRepo.On("FindByID", ctx, session.FileID).Return(file, nil)
+	deps.blobService.On("ComputeDigest", ctx, storageKey, "v1").Return(testDigest, nil)
+	deps.uploadSessionRepo.On("FindByIDForUpdate", ctx, session.ID).Return(session, nil)
+	deps.uploadSessionRepo.On("Update", ctx, session).Return(nil)
+	deps.fileRepo.On("UpdateStatus", ctx, file.ID, entity.FileStatusUploadFailed).Return(nil)
+	deps.blobService.On("IsReferenced", ctx, file.StorageKey, "v1").Return(false, nil)
+	deps.storageService.On("DeleteObjectVersion", ctx, storageKey, "v1").Return(nil)
+
+	output, err := command.NewRunUploadVerificationsCommand(deps.uploadSessionRepo, deps.newCommand()).Execute(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, output.Completed)
+	assert.Equal(t, 1, output.Rejected)
+	assert.Equal(t, 0, output.Failed)
+	assert.Equal(t, entity.UploadSessionStatusAborted, session.Status)
+}
+
+func TestRunUploadVerificationsCommand_Execute_ReadFailure_LeavesSessionForRetry(t *testing.T) {
+	ctx := context.Background()
+	deps := newCompleteUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	folderID := uuid.New()
+	file := newUploadingFileEntity(ownerID, folderID)
+	session := newPendingSession(file.ID, ownerID, folderID)
+	require.NoError(t, session.StartVerification("v1", 1024))
+
+	deps.uploadSessionRepo.On("FindVerifying", ctx, 20).Return([]*entity.UploadSession{session}, nil)
+	deps.fileRepo.On("FindByID", ctx, session.FileID).Return(file, nil)
+	deps.blobService.On("ComputeDigest", ctx, file.StorageKey.String(), "v1").Return(nil, errors.New("connection reset"))
+
+	output, err := command.NewRunUploadVerificationsCommand(deps.uploadSessionRepo, deps.newCommand()).Execute(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, output.Completed)
+	assert.Equal(t, 0, output.Rejected)
+	assert.Equal(t, 1, output.Failed)
+	assert.Equal(t, entity.UploadSessionStatusVerifying, session.Status)
+	assert.Equal(t, entity.FileStatusUploading, file.Status)
+}
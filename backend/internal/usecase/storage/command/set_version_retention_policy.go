@@ -0,0 +1,119 @@
+package command
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// SetVersionRetentionPolicyInput はバージョン保持ポリシー設定の入力を定義します
+type SetVersionRetentionPolicyInput struct {
+	SubjectType entity.RetentionSubjectType
+	SubjectID   uuid.UUID
+	KeepLastN   *int // KeepLastN と KeepDays が両方nilの場合はポリシーを解除
+	KeepDays    *int
+	UserID      uuid.UUID
+}
+
+// SetVersionRetentionPolicyOutput はバージョン保持ポリシー設定の出力を定義します
+type SetVersionRetentionPolicyOutput struct {
+	Policy            *entity.VersionRetentionPolicy // 解除した場合はnil
+	PreviousKeepLastN *int                           // 変更前のルール（ポリシーが無かった場合はnil）
+	PreviousKeepDays  *int
+}
+
+// SetVersionRetentionPolicyCommand はバージョン保持ポリシー設定コマンドです
+// Note: ユーザーのポリシーは本人のみ、フォルダのポリシーはフォルダ所有者のみ設定できる
+type SetVersionRetentionPolicyCommand struct {
+	folderRepo repository.FolderRepository
+	policyRepo repository.VersionRetentionPolicyRepository
+}
+
+// NewSetVersionRetentionPolicyCommand は新しいSetVersionRetentionPolicyCommandを作成します
+func NewSetVersionRetentionPolicyCommand(
+	folderRepo repository.FolderRepository,
+	policyRepo repository.VersionRetentionPolicyRepository,
+) *SetVersionRetentionPolicyCommand {
+	return &SetVersionRetentionPolicyCommand{
+		folderRepo: folderRepo,
+		policyRepo: policyRepo,
+	}
+}
+
+// Execute はバージョン保持ポリシー設定を実行します
+func (c *SetVersionRetentionPolicyCommand) Execute(ctx context.Context, input SetVersionRetentionPolicyInput) (*SetVersionRetentionPolicyOutput, error) {
+	// 1. 対象への権限チェック
+	if err := authorizeRetentionSubject(ctx, c.folderRepo, input.SubjectType, input.SubjectID, input.UserID); err != nil {
+		return nil, err
+	}
+
+	// 2. 既存ポリシーを取得（変更前のルールを出力に含める）
+	policy, err := c.policyRepo.FindBySubject(ctx, input.SubjectType, input.SubjectID)
+	if err != nil && !apperror.IsNotFound(err) {
+		return nil, err
+	}
+	output := &SetVersionRetentionPolicyOutput{}
+	if policy != nil {
+		output.PreviousKeepLastN = policy.KeepLastN
+		output.PreviousKeepDays = policy.KeepDays
+	}
+
+	// 3. ポリシー解除
+	if input.KeepLastN == nil && input.KeepDays == nil {
+		if err := c.policyRepo.DeleteBySubject(ctx, input.SubjectType, input.SubjectID); err != nil {
+			return nil, err
+		}
+		return output, nil
+	}
+
+	// 4. 既存ポリシーの更新または新規作成
+	if policy != nil {
+		if err := policy.UpdateRules(input.KeepLastN, input.KeepDays); err != nil {
+			return nil, apperror.NewValidationError(err.Error(), nil)
+		}
+	} else {
+		policy, err = entity.NewVersionRetentionPolicy(input.SubjectType, input.SubjectID, input.KeepLastN, input.KeepDays)
+		if err != nil {
+			return nil, apperror.NewValidationError(err.Error(), nil)
+		}
+	}
+
+	if err := c.policyRepo.Upsert(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	output.Policy = policy
+	return output, nil
+}
+
+// authorizeRetentionSubject は保持ポリシー対象への操作権限を検証します
+func authorizeRetentionSubject(
+	ctx context.Context,
+	folderRepo repository.FolderRepository,
+	subjectType entity.RetentionSubjectType,
+	subjectID uuid.UUID,
+	userID uuid.UUID,
+) error {
+	switch subjectType {
+	case entity.RetentionSubjectUser:
+		if subjectID != userID {
+			return apperror.NewForbiddenError("not authorized to manage retention policy of another user")
+		}
+		return nil
+	case entity.RetentionSubjectFolder:
+		folder, err := folderRepo.FindByID(ctx, subjectID)
+		if err != nil {
+			return err
+		}
+		if !folder.IsOwnedBy(userID) {
+			return apperror.NewForbiddenError("not authorized to manage retention policy of this folder")
+		}
+		return nil
+	default:
+		return apperror.NewValidationError("invalid retention subject type", nil)
+	}
+}
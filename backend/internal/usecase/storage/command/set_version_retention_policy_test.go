@@ -0,0 +1,179 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/command"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
+)
+
+type setVersionRetentionPolicyTestDeps struct {
+	folderRepo *mocks.MockFolderRepository
+	policyRepo *mocks.MockVersionRetentionPolicyRepository
+}
+
+func newSetVersionRetentionPolicyTestDeps(t *testing.T) *setVersionRetentionPolicyTestDeps {
+	t.Helper()
+	return &setVersionRetentionPolicyTestDeps{
+		folderRepo: mocks.NewMockFolderRepository(t),
+		policyRepo: mocks.NewMockVersionRetentionPolicyRepository(t),
+	}
+}
+
+func (d *setVersionRetentionPolicyTestDeps) newCommand() *command.SetVersionRetentionPolicyCommand {
+	return command.NewSetVersionRetentionPolicyCommand(d.folderRepo, d.policyRepo)
+}
+
+func TestSetVersionRetentionPolicyCommand_Execute_UserSetsOwnPolicy_Success(t *testing.T) {
+	ctx := context.Background()
+	deps := newSetVersionRetentionPolicyTestDeps(t)
+
+	userID := uuid.New()
+	keepLastN := 5
+
+	deps.policyRepo.On("FindBySubject", ctx, entity.RetentionSubjectUser, userID).Return(nil, apperror.NewNotFoundError("version retention policy"))
+	deps.policyRepo.On("Upsert", ctx, mock.MatchedBy(func(p *entity.VersionRetentionPolicy) bool {
+		return p.SubjectType == entity.RetentionSubjectUser && p.SubjectID == userID &&
+			p.KeepLastN != nil && *p.KeepLastN == keepLastN && p.KeepDays == nil
+	})).Return(nil)
+
+	output, err := deps.newCommand().Execute(ctx, command.SetVersionRetentionPolicyInput{
+		SubjectType: entity.RetentionSubjectUser,
+		SubjectID:   userID,
+		KeepLastN:   &keepLastN,
+		UserID:      userID,
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, output.Policy)
+	assert.Equal(t, keepLastN, *output.Policy.KeepLastN)
+}
+
+func TestSetVersionRetentionPolicyCommand_Execute_AnotherUser_ReturnsForbidden(t *testing.T) {
+	ctx := context.Background()
+	deps := newSetVersionRetentionPolicyTestDeps(t)
+
+	keepDays := 30
+
+	output, err := deps.newCommand().Execute(ctx, command.SetVersionRetentionPolicyInput{
+		SubjectType: entity.RetentionSubjectUser,
+		SubjectID:   uuid.New(),
+		KeepDays:    &keepDays,
+		UserID:      uuid.New(),
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeForbidden, appErr.Code)
+}
+
+func TestSetVersionRetentionPolicyCommand_Execute_FolderOwnerUpdatesPolicy_Success(t *testing.T) {
+	ctx := context.Background()
+	deps := newSetVersionRetentionPolicyTestDeps(t)
+
+	ownerID := uuid.New()
+	folder := newFolderEntity(ownerID)
+	oldKeep := 3
+	existing, err := entity.NewVersionRetentionPolicy(entity.RetentionSubjectFolder, folder.ID, &oldKeep, nil)
+	require.NoError(t, err)
+	keepDays := 7
+
+	deps.folderRepo.On("FindByID", ctx, folder.ID).Return(folder, nil)
+	deps.policyRepo.On("FindBySubject", ctx, entity.RetentionSubjectFolder, folder.ID).Return(existing, nil)
+	deps.policyRepo.On("Upsert", ctx, existing).Return(nil)
+
+	output, err := deps.newCommand().Execute(ctx, command.SetVersionRetentionPolicyInput{
+		SubjectType: entity.RetentionSubjectFolder,
+		SubjectID:   folder.ID,
+		KeepDays:    &keepDays,
+		UserID:      ownerID,
+	})
+
+	require.NoError(t, err)
+	assert.Nil(t, output.Policy.KeepLastN)
+	assert.Equal(t, keepDays, *output.Policy.KeepDays)
+	require.NotNil(t, output.PreviousKeepLastN)
+	assert.Equal(t, oldKeep, *output.PreviousKeepLastN)
+	assert.Nil(t, output.PreviousKeepDays)
+}
+
+func TestSetVersionRetentionPolicyCommand_Execute_FolderNotOwned_ReturnsForbidden(t *testing.T) {
+	ctx := context.Background()
+	deps := newSetVersionRetentionPolicyTestDeps(t)
+
+	folder := newFolderEntity(uuid.New())
+	keepDays := 7
+
+	deps.folderRepo.On("FindByID", ctx, folder.ID).Return(folder, nil)
+
+	output, err := deps.newCommand().Execute(ctx, command.SetVersionRetentionPolicyInput{
+		SubjectType: entity.RetentionSubjectFolder,
+		SubjectID:   folder.ID,
+		KeepDays:    &keepDays,
+		UserID:      uuid.New(),
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeForbidden, appErr.Code)
+}
+
+func TestSetVersionRetentionPolicyCommand_Execute_NoRules_RemovesPolicy(t *testing.T) {
+	ctx := context.Background()
+	deps := newSetVersionRetentionPolicyTestDeps(t)
+
+	userID := uuid.New()
+	oldKeep := 5
+	existing, err := entity.NewVersionRetentionPolicy(entity.RetentionSubjectUser, userID, &oldKeep, nil)
+	require.NoError(t, err)
+
+	deps.policyRepo.On("FindBySubject", ctx, entity.RetentionSubjectUser, userID).Return(existing, nil)
+	deps.policyRepo.On("DeleteBySubject", ctx, entity.RetentionSubjectUser, userID).Return(nil)
+
+	output, err := deps.newCommand().Execute(ctx, command.SetVersionRetentionPolicyInput{
+		SubjectType: entity.RetentionSubjectUser,
+		SubjectID:   userID,
+		UserID:      userID,
+	})
+
+	require.NoError(t, err)
+	assert.Nil(t, output.Policy)
+	require.NotNil(t, output.PreviousKeepLastN)
+	assert.Equal(t, oldKeep, *output.PreviousKeepLastN)
+}
+
+func TestSetVersionRetentionPolicyCommand_Execute_NonPositiveRule_ReturnsValidationError(t *testing.T) {
+	ctx := context.Background()
+	deps := newSetVersionRetentionPolicyTestDeps(t)
+
+	userID := uuid.New()
+	keepLastN := 0
+
+	deps.policyRepo.On("FindBySubject", ctx, entity.RetentionSubjectUser, userID).Return(nil, apperror.NewNotFoundError("version retention policy"))
+
+	output, err := deps.newCommand().Execute(ctx, command.SetVersionRetentionPolicyInput{
+		SubjectType: entity.RetentionSubjectUser,
+		SubjectID:   userID,
+		KeepLastN:   &keepLastN,
+		UserID:      userID,
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeValidationError, appErr.Code)
+}
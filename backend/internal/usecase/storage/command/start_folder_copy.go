@@ -0,0 +1,73 @@
+package command
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+)
+
+// asyncFolderCopyThreshold はこの件数（フォルダ数+ファイル数）を超えるコピーを非同期ジョブで実行する閾値です
+const asyncFolderCopyThreshold = 100
+
+// StartFolderCopyInput はフォルダコピー開始の入力を定義します
+type StartFolderCopyInput struct {
+	FolderID            uuid.UUID
+	DestinationFolderID uuid.UUID
+	UserID              uuid.UUID
+}
+
+// StartFolderCopyOutput はフォルダコピー開始の出力を定義します
+// 同期的にコピーした場合は Result、ジョブとして登録した場合は Job が設定されます
+type StartFolderCopyOutput struct {
+	Result *CopyFolderOutput
+	Job    *entity.CopyJob
+}
+
+// StartFolderCopyCommand はフォルダコピーを開始するコマンドです
+// 小さなサブツリーはその場でコピーし、大きなサブツリーはバックグラウンドジョブとして登録します
+type StartFolderCopyCommand struct {
+	copyFolderCommand *CopyFolderCommand
+	copyJobRepo       repository.CopyJobRepository
+}
+
+// NewStartFolderCopyCommand は新しいStartFolderCopyCommandを作成します
+func NewStartFolderCopyCommand(
+	copyFolderCommand *CopyFolderCommand,
+	copyJobRepo repository.CopyJobRepository,
+) *StartFolderCopyCommand {
+	return &StartFolderCopyCommand{
+		copyFolderCommand: copyFolderCommand,
+		copyJobRepo:       copyJobRepo,
+	}
+}
+
+// Execute はフォルダコピーを開始します
+// 権限・コピー先・クォータの検証はジョブ登録前に行い、明らかに失敗するジョブを登録しません
+func (c *StartFolderCopyCommand) Execute(ctx context.Context, input StartFolderCopyInput) (*StartFolderCopyOutput, error) {
+	plan, err := c.copyFolderCommand.prepare(ctx, CopyFolderInput{
+		FolderID:            input.FolderID,
+		DestinationFolderID: input.DestinationFolderID,
+		UserID:              input.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if plan.ItemCount() <= asyncFolderCopyThreshold {
+		result, err := c.copyFolderCommand.apply(ctx, plan, input.UserID)
+		if err != nil {
+			return nil, err
+		}
+		return &StartFolderCopyOutput{Result: result}, nil
+	}
+
+	job := entity.NewCopyJob(input.UserID, input.FolderID, input.DestinationFolderID)
+	if err := c.copyJobRepo.Create(ctx, job); err != nil {
+		return nil, err
+	}
+
+	return &StartFolderCopyOutput{Job: job}, nil
+}
@@ -0,0 +1,101 @@
+package command_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/command"
+	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
+)
+
+// expectFolderCopyPrepared sets up the validation steps shared by every folder copy.
+// ctx にはコピーを実行するコンテキスト、またはmock.Anythingを指定する
+func expectFolderCopyPrepared(ctx any, deps *copyFolderTestDeps, userID uuid.UUID, source, dest *entity.Folder, files []*entity.File) {
+	var totalSize int64
+	for _, f := range files {
+		totalSize += f.Size
+	}
+	deps.folderRepo.On("FindByID", ctx, source.ID).Return(source, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFolder, source.ID, authz.PermFolderRead).Return(true, nil)
+	deps.folderRepo.On("FindByID", ctx, dest.ID).Return(dest, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFolder, dest.ID, authz.PermFolderCreate).Return(true, nil)
+	deps.folderClosureRepo.On("FindDescendantIDs", ctx, source.ID).Return([]uuid.UUID{}, nil)
+	deps.folderClosureRepo.On("FindDescendantsWithDepth", ctx, source.ID).Return(map[uuid.UUID]int{}, nil)
+	deps.folderRepo.On("ExistsByNameAndParent", ctx, source.Name, &dest.ID, userID).Return(false, nil)
+	deps.folderRepo.On("FindByIDs", ctx, []uuid.UUID{}).Return([]*entity.Folder{}, nil)
+	deps.fileRepo.On("FindByFolderIDs", ctx, []uuid.UUID{source.ID}).Return(files, nil)
+	deps.quotaService.On("EnsureCapacity", ctx, userID, totalSize).Return(nil)
+}
+
+func TestStartFolderCopyCommand_Execute_SmallFolder_CopiesSynchronously(t *testing.T) {
+	ctx := context.Background()
+	deps := newCopyFolderTestDeps(t)
+	copyJobRepo := mocks.NewMockCopyJobRepository(t)
+
+	userID := uuid.New()
+	source := newRootFolderEntity(userID)
+	dest := newFolderEntity(userID)
+
+	expectFolderCopyPrepared(ctx, deps, userID, source, dest, []*entity.File{})
+	deps.folderClosureRepo.On("FindAncestorPaths", ctx, dest.ID).Return([]*entity.FolderPath{}, nil)
+	deps.folderRepo.On("Create", ctx, mock.AnythingOfType("*entity.Folder")).Return(nil)
+	deps.folderClosureRepo.On("InsertSelfReference", ctx, mock.Anything).Return(nil)
+	deps.folderClosureRepo.On("InsertAncestorPaths", ctx, mock.Anything).Return(nil)
+	deps.relationshipRepo.On("Create", ctx, mock.Anything).Return(nil)
+
+	cmd := command.NewStartFolderCopyCommand(deps.newCommand(), copyJobRepo)
+	output, err := cmd.Execute(ctx, command.StartFolderCopyInput{
+		FolderID:            source.ID,
+		DestinationFolderID: dest.ID,
+		UserID:              userID,
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, output.Result)
+	assert.Nil(t, output.Job)
+	assert.Equal(t, 1, output.Result.FolderCount)
+	assert.Equal(t, dest.ID, *output.Result.Folder.ParentID)
+}
+
+func TestStartFolderCopyCommand_Execute_LargeFolder_EnqueuesJob(t *testing.T) {
+	ctx := context.Background()
+	deps := newCopyFolderTestDeps(t)
+	copyJobRepo := mocks.NewMockCopyJobRepository(t)
+
+	userID := uuid.New()
+	source := newRootFolderEntity(userID)
+	dest := newFolderEntity(userID)
+	files := make([]*entity.File, 150)
+	for i := range files {
+		files[i] = newActiveFileEntity(userID, source.ID)
+	}
+
+	expectFolderCopyPrepared(ctx, deps, userID, source, dest, files)
+	var created *entity.CopyJob
+	copyJobRepo.On("Create", ctx, mock.AnythingOfType("*entity.CopyJob")).
+		Run(func(args mock.Arguments) { created = args.Get(1).(*entity.CopyJob) }).
+		Return(nil)
+
+	cmd := command.NewStartFolderCopyCommand(deps.newCommand(), copyJobRepo)
+	output, err := cmd.Execute(ctx, command.StartFolderCopyInput{
+		FolderID:            source.ID,
+		DestinationFolderID: dest.ID,
+		UserID:              userID,
+	})
+
+	require.NoError(t, err)
+	assert.Nil(t, output.Result)
+	require.NotNil(t, output.Job)
+	assert.Equal(t, created, output.Job)
+	assert.Equal(t, entity.CopyJobStatusPending, output.Job.Status)
+	assert.Equal(t, source.ID, output.Job.SourceFolderID)
+	assert.Equal(t, dest.ID, output.Job.DestinationFolderID)
+	assert.Equal(t, userID, output.Job.UserID)
+}
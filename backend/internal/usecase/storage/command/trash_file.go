@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
 	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
@@ -20,6 +21,7 @@ type TrashFileInput struct {
 // TrashFileOutput はファイルのゴミ箱移動出力を定義します
 type TrashFileOutput struct {
 	ArchivedFileID uuid.UUID
+	OriginalPath   string
 	ExpiresAt      time.Time
 }
 
@@ -31,6 +33,7 @@ type TrashFileCommand struct {
 	folderClosureRepo       repository.FolderClosureRepository
 	archivedFileRepo        repository.ArchivedFileRepository
 	archivedFileVersionRepo repository.ArchivedFileVersionRepository
+	permissionResolver      authz.PermissionResolver
 	txManager               repository.TransactionManager
 }
 
@@ -42,6 +45,7 @@ func NewTrashFileCommand(
 	folderClosureRepo repository.FolderClosureRepository,
 	archivedFileRepo repository.ArchivedFileRepository,
 	archivedFileVersionRepo repository.ArchivedFileVersionRepository,
+	permissionResolver authz.PermissionResolver,
 	txManager repository.TransactionManager,
 ) *TrashFileCommand {
 	return &TrashFileCommand{
@@ -51,6 +55,7 @@ func NewTrashFileCommand(
 		folderClosureRepo:       folderClosureRepo,
 		archivedFileRepo:        archivedFileRepo,
 		archivedFileVersionRepo: archivedFileVersionRepo,
+		permissionResolver:      permissionResolver,
 		txManager:               txManager,
 	}
 }
@@ -63,8 +68,12 @@ func (c *TrashFileCommand) Execute(ctx context.Context, input TrashFileInput) (*
 		return nil, err
 	}
 
-	// 2. 所有者チェック
-	if !file.IsOwnedBy(input.UserID) {
+	// 2. 権限チェック (file:delete)
+	hasPermission, err := c.permissionResolver.HasPermission(ctx, input.UserID, authz.ResourceTypeFile, file.ID, authz.PermFileDelete)
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
 		return nil, apperror.NewForbiddenError("not authorized to trash this file")
 	}
 
@@ -121,6 +130,7 @@ func (c *TrashFileCommand) Execute(ctx context.Context, input TrashFileInput) (*
 
 	return &TrashFileOutput{
 		ArchivedFileID: archivedFile.ID,
+		OriginalPath:   archivedFile.OriginalPath,
 		ExpiresAt:      archivedFile.ExpiresAt,
 	}, nil
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/command"
@@ -25,6 +26,7 @@ type trashFileTestDeps struct {
 	folderClosureRepo       *mocks.MockFolderClosureRepository
 	archivedFileRepo        *mocks.MockArchivedFileRepository
 	archivedFileVersionRepo *mocks.MockArchivedFileVersionRepository
+	permissionResolver      *mocks.MockPermissionResolver
 	txManager               *mocks.MockTransactionManager
 }
 
@@ -37,6 +39,7 @@ func newTrashFileTestDeps(t *testing.T) *trashFileTestDeps {
 		folderClosureRepo:       mocks.NewMockFolderClosureRepository(t),
 		archivedFileRepo:        mocks.NewMockArchivedFileRepository(t),
 		archivedFileVersionRepo: mocks.NewMockArchivedFileVersionRepository(t),
+		permissionResolver:      mocks.NewMockPermissionResolver(t),
 		txManager:               mocks.NewMockTransactionManager(t),
 	}
 }
@@ -49,6 +52,7 @@ func (d *trashFileTestDeps) newCommand() *command.TrashFileCommand {
 		d.folderClosureRepo,
 		d.archivedFileRepo,
 		d.archivedFileVersionRepo,
+		d.permissionResolver,
 		d.txManager,
 	)
 }
@@ -84,6 +88,7 @@ func TestTrashFileCommand_Execute_Success_ArchivesFile(t *testing.T) {
 	folder := newTrashFolderEntity(folderID, ownerID)
 
 	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFile, file.ID, authz.PermFileDelete).Return(true, nil)
 	deps.folderClosureRepo.On("FindAncestorIDs", ctx, folderID).Return([]uuid.UUID{}, nil)
 	deps.folderRepo.On("FindByID", ctx, folderID).Return(folder, nil)
 	deps.archivedFileRepo.On("Create", ctx, mock.AnythingOfType("*entity.ArchivedFile")).Return(nil)
@@ -100,6 +105,7 @@ func TestTrashFileCommand_Execute_Success_ArchivesFile(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, output)
 	assert.NotEqual(t, uuid.Nil, output.ArchivedFileID)
+	assert.Contains(t, output.OriginalPath, "test.txt")
 }
 
 func TestTrashFileCommand_Execute_OutputIncludesExpiresAt(t *testing.T) {
@@ -113,6 +119,7 @@ func TestTrashFileCommand_Execute_OutputIncludesExpiresAt(t *testing.T) {
 	folder := newTrashFolderEntity(folderID, ownerID)
 
 	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFile, file.ID, authz.PermFileDelete).Return(true, nil)
 	deps.folderClosureRepo.On("FindAncestorIDs", ctx, folderID).Return([]uuid.UUID{}, nil)
 	deps.folderRepo.On("FindByID", ctx, folderID).Return(folder, nil)
 	deps.archivedFileRepo.On("Create", ctx, mock.AnythingOfType("*entity.ArchivedFile")).Return(nil)
@@ -132,7 +139,7 @@ func TestTrashFileCommand_Execute_OutputIncludesExpiresAt(t *testing.T) {
 	assert.True(t, output.ExpiresAt.After(time.Now()), "ExpiresAt should be in the future")
 }
 
-func TestTrashFileCommand_Execute_NotOwner_ReturnsForbidden(t *testing.T) {
+func TestTrashFileCommand_Execute_WithoutDeletePermission_ReturnsForbidden(t *testing.T) {
 	ctx := context.Background()
 	deps := newTrashFileTestDeps(t)
 
@@ -142,6 +149,7 @@ func TestTrashFileCommand_Execute_NotOwner_ReturnsForbidden(t *testing.T) {
 	file := newActiveFileForTrash(ownerID, folderID)
 
 	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, otherUserID, authz.ResourceTypeFile, file.ID, authz.PermFileDelete).Return(false, nil)
 
 	cmd := deps.newCommand()
 	output, err := cmd.Execute(ctx, command.TrashFileInput{
@@ -173,6 +181,7 @@ func TestTrashFileCommand_Execute_NotActive_ReturnsValidationError(t *testing.T)
 	)
 
 	deps.fileRepo.On("FindByID", ctx, uploadingFile.ID).Return(uploadingFile, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFile, uploadingFile.ID, authz.PermFileDelete).Return(true, nil)
 
 	cmd := deps.newCommand()
 	output, err := cmd.Execute(ctx, command.TrashFileInput{
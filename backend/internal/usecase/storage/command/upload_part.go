@@ -0,0 +1,194 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// UploadPartInput はサーバー経由のパートアップロードの入力を定義します
+type UploadPartInput struct {
+	SessionID  uuid.UUID
+	PartNumber int
+	Body       io.Reader
+	Size       int64 // リクエストボディのサイズ（Content-Length）
+	UserID     uuid.UUID
+}
+
+// UploadPartOutput はサーバー経由のパートアップロードの出力を定義します
+type UploadPartOutput struct {
+	SessionID     uuid.UUID
+	FileID        uuid.UUID
+	PartNumber    int
+	UploadedParts int
+	TotalParts    int
+	Completed     bool // 全パーツが揃いアップロードが完了したかどうか
+	Verifying     bool // 全パーツが揃い、バックグラウンドで内容の検証を待っているかどうか
+}
+
+// UploadPartCommand はPresigned URLを使えないクライアント向けに、
+// リクエストボディをサーバー経由でストレージに書き込むコマンドです
+type UploadPartCommand struct {
+	uploadSessionRepo     repository.UploadSessionRepository
+	uploadPartRepo        repository.UploadPartRepository
+	storageService        service.StorageService
+	completeUploadCommand *CompleteUploadCommand
+	txManager             repository.TransactionManager
+}
+
+// NewUploadPartCommand は新しいUploadPartCommandを作成します
+func NewUploadPartCommand(
+	uploadSessionRepo repository.UploadSessionRepository,
+	uploadPartRepo repository.UploadPartRepository,
+	storageService service.StorageService,
+	completeUploadCommand *CompleteUploadCommand,
+	txManager repository.TransactionManager,
+) *UploadPartCommand {
+	return &UploadPartCommand{
+		uploadSessionRepo:     uploadSessionRepo,
+		uploadPartRepo:        uploadPartRepo,
+		storageService:        storageService,
+		completeUploadCommand: completeUploadCommand,
+		txManager:             txManager,
+	}
+}
+
+// Execute はパートをアップロードし、全パーツが揃った場合はアップロードを完了します
+func (c *UploadPartCommand) Execute(ctx context.Context, input UploadPartInput) (*UploadPartOutput, error) {
+	// 1. セッション取得
+	session, err := c.uploadSessionRepo.FindByID(ctx, input.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. 所有者またはアップロード開始者のみアップロード可能
+	if !session.IsOwnedBy(input.UserID) && !session.IsCreatedBy(input.UserID) {
+		return nil, apperror.NewForbiddenError("not authorized to upload to this session")
+	}
+
+	// 3. セッションの状態とパーツ番号・サイズを検証
+	if !session.CanAcceptUpload() {
+		return nil, apperror.NewValidationError("upload session cannot accept uploads", nil)
+	}
+	if !session.IsValidPartNumber(input.PartNumber) {
+		return nil, apperror.NewValidationError(
+			fmt.Sprintf("part number must be between 1 and %d", session.TotalParts), nil)
+	}
+	partSize := session.PartSize(input.PartNumber)
+	if input.Size != partSize {
+		return nil, apperror.NewValidationError(
+			fmt.Sprintf("part %d must be exactly %d bytes", input.PartNumber, partSize), nil)
+	}
+
+	// 4. シングルパートはオブジェクトとして書き込み、そのまま完了
+	if !session.IsMultipart {
+		object, err := c.storageService.PutObject(ctx, session.StorageKey.String(), input.Body, partSize, session.MimeType.String())
+		if err != nil {
+			return nil, apperror.NewInternalError(err)
+		}
+		return c.completeSession(ctx, session, input.PartNumber, object.VersionID)
+	}
+
+	// 5. マルチパートはパートとして書き込み、パーツを記録
+	if session.MinioUploadID == nil {
+		return nil, apperror.NewInternalError(errors.New("multipart upload ID is missing"))
+	}
+	uploadID := *session.MinioUploadID
+
+	parts, err := c.uploadPartRepo.FindBySessionID(ctx, session.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, part := range parts {
+		if part.PartNumber == input.PartNumber {
+			return nil, apperror.NewConflictError(fmt.Sprintf("part %d already uploaded", input.PartNumber))
+		}
+	}
+
+	etag, err := c.storageService.UploadPart(ctx, session.StorageKey.String(), uploadID, input.PartNumber, input.Body, partSize)
+	if err != nil {
+		return nil, apperror.NewInternalError(err)
+	}
+	if err := c.uploadPartRepo.Create(ctx, entity.NewUploadPart(session.ID, input.PartNumber, partSize, etag)); err != nil {
+		return nil, err
+	}
+
+	// 6. 並行してアップロードされたパーツも含めて記録済みのパーツ数をセッションの行ロック下で保存
+	// 結合したオブジェクトのWebhookが完了処理に進むよう結合前に保存し、
+	// 全パーツを揃えたリクエストだけが結合を行う
+	assemble := false
+	err = c.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		locked, err := c.uploadSessionRepo.FindByIDForUpdate(ctx, session.ID)
+		if err != nil {
+			return err
+		}
+		session = locked
+		// 既に他のリクエストが全パーツを揃えている場合は結合を任せる
+		if session.IsCompleted() || session.AllPartsUploaded() {
+			return nil
+		}
+		if !session.CanAcceptUpload() {
+			return apperror.NewValidationError("upload session cannot accept uploads", nil)
+		}
+
+		parts, err = c.uploadPartRepo.FindBySessionID(ctx, session.ID)
+		if err != nil {
+			return err
+		}
+		session.SetUploadedParts(len(parts))
+		assemble = session.AllPartsUploaded()
+		return c.uploadSessionRepo.Update(ctx, session)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !assemble {
+		return &UploadPartOutput{
+			SessionID:     session.ID,
+			FileID:        session.FileID,
+			PartNumber:    input.PartNumber,
+			UploadedParts: session.UploadedParts,
+			TotalParts:    session.TotalParts,
+			Completed:     session.IsCompleted(),
+			Verifying:     session.IsVerifying(),
+		}, nil
+	}
+
+	// 7. 全パーツが揃ったらパーツ番号順に結合して完了
+	etags := make([]string, len(parts))
+	for i, part := range parts {
+		etags[i] = part.ETag
+	}
+	versionID, err := c.storageService.CompleteMultipartUpload(ctx, session.StorageKey.String(), uploadID, etags)
+	if err != nil {
+		return nil, apperror.NewInternalError(err)
+	}
+
+	return c.completeSession(ctx, session, input.PartNumber, versionID)
+}
+
+// completeSession はWebhookと同じ完了処理でアップロードを完了します
+func (c *UploadPartCommand) completeSession(ctx context.Context, session *entity.UploadSession, partNumber int, minioVersionID string) (*UploadPartOutput, error) {
+	output, err := c.completeUploadCommand.CompleteSession(ctx, session, minioVersionID, session.TotalSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UploadPartOutput{
+		SessionID:     output.SessionID,
+		FileID:        output.FileID,
+		PartNumber:    partNumber,
+		UploadedParts: session.TotalParts,
+		TotalParts:    session.TotalParts,
+		Completed:     output.Completed,
+		Verifying:     output.Verifying,
+	}, nil
+}
@@ -0,0 +1,283 @@
+package command_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/command"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+func (d *completeUploadTestDeps) newUploadPartCommand() *command.UploadPartCommand {
+	return command.NewUploadPartCommand(
+		d.uploadSessionRepo,
+		d.uploadPartRepo,
+		d.storageService,
+		d.newCommand(),
+		d.txManager,
+	)
+}
+
+// expectCompletion はセッションのアップロードが新規ファイルとして完了することを設定します
+func (d *completeUploadTestDeps) expectCompletion(ctx context.Context, session *entity.UploadSession, file *entity.File, versionID string) {
+	d.fileRepo.On("FindByID", ctx, session.FileID).Return(file, nil)
+	d.expectNewBlob(ctx, session.StorageKey.String(), versionID, session.TotalSize)
+	d.fileVersionRepo.On("Create", ctx, mock.AnythingOfType("*entity.FileVersion")).Return(nil)
+	d.fileRepo.On("Update", ctx, file).Return(nil)
+	d.fileRepo.On("UpdateStatus", ctx, file.ID, entity.FileStatusActive).Return(nil)
+	d.storageUsageRepo.On("AddUsedBytes", ctx, session.OwnerID, session.TotalSize).Return(nil)
+	d.thumbnailService.On("Schedule", ctx, file, mock.AnythingOfType("*entity.FileVersion")).Return(nil)
+	d.uploadSessionRepo.On("Update", ctx, session).Return(nil)
+}
+
+func TestUploadPartCommand_Execute_SinglePart_PutsObjectAndCompletes(t *testing.T) {
+	ctx := context.Background()
+	deps := newCompleteUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	folderID := uuid.New()
+	file := newUploadingFileEntity(ownerID, folderID)
+	session := newPendingSession(file.ID, ownerID, folderID)
+	body := strings.NewReader(strings.Repeat("a", 1024))
+
+	deps.uploadSessionRepo.On("FindByID", ctx, session.ID).Return(session, nil)
+	deps.uploadSessionRepo.On("FindByIDForUpdate", ctx, session.ID).Return(session, nil)
+	deps.storageService.On("PutObject", ctx, session.StorageKey.String(), body, int64(1024), "text/plain").
+		Return(&service.UploadedObject{VersionID: "v1", ETag: "etag-1"}, nil)
+	deps.expectCompletion(ctx, session, file, "v1")
+
+	output, err := deps.newUploadPartCommand().Execute(ctx, command.UploadPartInput{
+		SessionID:  session.ID,
+		PartNumber: 1,
+		Body:       body,
+		Size:       1024,
+		UserID:     ownerID,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, output.Completed)
+	assert.Equal(t, file.ID, output.FileID)
+	assert.Equal(t, entity.UploadSessionStatusCompleted, session.Status)
+	assert.Equal(t, entity.FileStatusActive, file.Status)
+}
+
+func TestUploadPartCommand_Execute_SizeMismatch_ReturnsValidationError(t *testing.T) {
+	ctx := context.Background()
+	deps := newCompleteUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	session := newPendingSession(uuid.New(), ownerID, uuid.New())
+
+	deps.uploadSessionRepo.On("FindByID", ctx, session.ID).Return(session, nil)
+
+	output, err := deps.newUploadPartCommand().Execute(ctx, command.UploadPartInput{
+		SessionID:  session.ID,
+		PartNumber: 1,
+		Body:       strings.NewReader("too short"),
+		Size:       9,
+		UserID:     ownerID,
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	appErr, ok := err.(*apperror.AppError)
+	require.True(t, ok)
+	assert.Equal(t, apperror.CodeValidationError, appErr.Code)
+}
+
+func TestUploadPartCommand_Execute_PartNumberOutOfRange_ReturnsValidationError(t *testing.T) {
+	ctx := context.Background()
+	deps := newCompleteUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	session := newMultipartSession(uuid.New(), ownerID, uuid.New(), 2, 0)
+
+	deps.uploadSessionRepo.On("FindByID", ctx, session.ID).Return(session, nil)
+
+	output, err := deps.newUploadPartCommand().Execute(ctx, command.UploadPartInput{
+		SessionID:  session.ID,
+		PartNumber: 3,
+		Body:       strings.NewReader(""),
+		Size:       entity.MinPartSize,
+		UserID:     ownerID,
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	appErr, ok := err.(*apperror.AppError)
+	require.True(t, ok)
+	assert.Equal(t, apperror.CodeValidationError, appErr.Code)
+}
+
+func TestUploadPartCommand_Execute_OtherUser_ReturnsForbidden(t *testing.T) {
+	ctx := context.Background()
+	deps := newCompleteUploadTestDeps(t)
+
+	session := newPendingSession(uuid.New(), uuid.New(), uuid.New())
+
+	deps.uploadSessionRepo.On("FindByID", ctx, session.ID).Return(session, nil)
+
+	output, err := deps.newUploadPartCommand().Execute(ctx, command.UploadPartInput{
+		SessionID:  session.ID,
+		PartNumber: 1,
+		Body:       strings.NewReader(""),
+		Size:       1024,
+		UserID:     uuid.New(),
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	appErr, ok := err.(*apperror.AppError)
+	require.True(t, ok)
+	assert.Equal(t, apperror.CodeForbidden, appErr.Code)
+}
+
+func TestUploadPartCommand_Execute_MultipartPartial_RecordsPart(t *testing.T) {
+	ctx := context.Background()
+	deps := newCompleteUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	session := newMultipartSession(uuid.New(), ownerID, uuid.New(), 2, 0)
+	body := strings.NewReader("part")
+	recorded := entity.NewUploadPart(session.ID, 1, entity.MinPartSize, "etag-1")
+
+	deps.uploadSessionRepo.On("FindByID", ctx, session.ID).Return(session, nil)
+	deps.uploadSessionRepo.On("FindByIDForUpdate", ctx, session.ID).Return(session, nil)
+	deps.uploadPartRepo.On("FindBySessionID", ctx, session.ID).Return([]*entity.UploadPart{}, nil).Once()
+	deps.storageService.On("UploadPart", ctx, session.StorageKey.String(), "minio-upload-id", 1, body, int64(entity.MinPartSize)).
+		Return("etag-1", nil)
+	deps.uploadPartRepo.On("Create", ctx, mock.MatchedBy(func(p *entity.UploadPart) bool {
+		return p.PartNumber == 1 && p.ETag == "etag-1" && p.Size == entity.MinPartSize
+	})).Return(nil)
+	deps.uploadPartRepo.On("FindBySessionID", ctx, session.ID).Return([]*entity.UploadPart{recorded}, nil).Once()
+	deps.uploadSessionRepo.On("Update", ctx, session).Return(nil)
+
+	output, err := deps.newUploadPartCommand().Execute(ctx, command.UploadPartInput{
+		SessionID:  session.ID,
+		PartNumber: 1,
+		Body:       body,
+		Size:       entity.MinPartSize,
+		UserID:     ownerID,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, output.Completed)
+	assert.Equal(t, 1, output.UploadedParts)
+	assert.Equal(t, 2, output.TotalParts)
+	assert.Equal(t, entity.UploadSessionStatusInProgress, session.Status)
+}
+
+func TestUploadPartCommand_Execute_MultipartFinalPart_ComposesAndCompletes(t *testing.T) {
+	ctx := context.Background()
+	deps := newCompleteUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	folderID := uuid.New()
+	file := newUploadingFileEntity(ownerID, folderID)
+	session := newMultipartSession(file.ID, ownerID, folderID, 2, 1)
+	body := strings.NewReader("part")
+	part1 := entity.NewUploadPart(session.ID, 1, entity.MinPartSize, "etag-1")
+	part2 := entity.NewUploadPart(session.ID, 2, entity.MinPartSize, "etag-2")
+
+	deps.uploadSessionRepo.On("FindByID", ctx, session.ID).Return(session, nil)
+	deps.uploadSessionRepo.On("FindByIDForUpdate", ctx, session.ID).Return(session, nil)
+	deps.uploadPartRepo.On("FindBySessionID", ctx, session.ID).Return([]*entity.UploadPart{part1}, nil).Once()
+	deps.storageService.On("UploadPart", ctx, session.StorageKey.String(), "minio-upload-id", 2, body, int64(entity.MinPartSize)).
+		Return("etag-2", nil)
+	deps.uploadPartRepo.On("Create", ctx, mock.AnythingOfType("*entity.UploadPart")).Return(nil)
+	deps.uploadPartRepo.On("FindBySessionID", ctx, session.ID).Return([]*entity.UploadPart{part1, part2}, nil).Once()
+	// 結合したオブジェクトのWebhookが完了処理に進めるよう、結合前に全パーツ記録済みとして保存する
+	savedParts := 0
+	deps.uploadSessionRepo.On("Update", ctx, session).Run(func(args mock.Arguments) {
+		savedParts = args.Get(1).(*entity.UploadSession).UploadedParts
+	}).Return(nil).Once()
+	deps.storageService.On("CompleteMultipartUpload", ctx, session.StorageKey.String(), "minio-upload-id", []string{"etag-1", "etag-2"}).
+		Run(func(args mock.Arguments) {
+			assert.Equal(t, 2, savedParts)
+		}).
+		Return("v-composed", nil)
+	deps.expectCompletion(ctx, session, file, "v-composed")
+
+	output, err := deps.newUploadPartCommand().Execute(ctx, command.UploadPartInput{
+		SessionID:  session.ID,
+		PartNumber: 2,
+		Body:       body,
+		Size:       entity.MinPartSize,
+		UserID:     ownerID,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, output.Completed)
+	assert.Equal(t, 2, output.UploadedParts)
+	assert.Equal(t, entity.UploadSessionStatusCompleted, session.Status)
+	// ファイルサイズは最終パーツではなく合計サイズになる
+	assert.Equal(t, session.TotalSize, file.Size)
+}
+
+func TestUploadPartCommand_Execute_ConcurrentFinalPart_LeavesAssemblyToOtherRequest(t *testing.T) {
+	ctx := context.Background()
+	deps := newCompleteUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	session := newMultipartSession(uuid.New(), ownerID, uuid.New(), 2, 0)
+	body := strings.NewReader("part")
+
+	// ロック取得時には並行する最終パーツのリクエストが全パーツ記録済みとして保存している
+	lockedSession := newMultipartSession(session.FileID, ownerID, session.FolderID, 2, 2)
+	lockedSession.ID = session.ID
+
+	deps.uploadSessionRepo.On("FindByID", ctx, session.ID).Return(session, nil)
+	deps.uploadPartRepo.On("FindBySessionID", ctx, session.ID).Return([]*entity.UploadPart{}, nil).Once()
+	deps.storageService.On("UploadPart", ctx, session.StorageKey.String(), "minio-upload-id", 1, body, int64(entity.MinPartSize)).
+		Return("etag-1", nil)
+	deps.uploadPartRepo.On("Create", ctx, mock.AnythingOfType("*entity.UploadPart")).Return(nil)
+	deps.uploadSessionRepo.On("FindByIDForUpdate", ctx, session.ID).Return(lockedSession, nil)
+
+	output, err := deps.newUploadPartCommand().Execute(ctx, command.UploadPartInput{
+		SessionID:  session.ID,
+		PartNumber: 1,
+		Body:       body,
+		Size:       entity.MinPartSize,
+		UserID:     ownerID,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, output.Completed)
+	assert.Equal(t, 2, output.UploadedParts)
+	deps.storageService.AssertNotCalled(t, "CompleteMultipartUpload", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	deps.uploadSessionRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestUploadPartCommand_Execute_PartAlreadyUploaded_ReturnsConflict(t *testing.T) {
+	ctx := context.Background()
+	deps := newCompleteUploadTestDeps(t)
+
+	ownerID := uuid.New()
+	session := newMultipartSession(uuid.New(), ownerID, uuid.New(), 2, 1)
+	part1 := entity.NewUploadPart(session.ID, 1, entity.MinPartSize, "etag-1")
+
+	deps.uploadSessionRepo.On("FindByID", ctx, session.ID).Return(session, nil)
+	deps.uploadPartRepo.On("FindBySessionID", ctx, session.ID).Return([]*entity.UploadPart{part1}, nil)
+
+	output, err := deps.newUploadPartCommand().Execute(ctx, command.UploadPartInput{
+		SessionID:  session.ID,
+		PartNumber: 1,
+		Body:       strings.NewReader("part"),
+		Size:       entity.MinPartSize,
+		UserID:     ownerID,
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	appErr, ok := err.(*apperror.AppError)
+	require.True(t, ok)
+	assert.Equal(t, apperror.CodeConflict, appErr.Code)
+}
@@ -0,0 +1,141 @@
+package command
+
+import (
+	"context"
+	"io"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// WriteUploadChunkInput はレジューム可能なアップロードへの書き込みの入力を定義します
+type WriteUploadChunkInput struct {
+	SessionID uuid.UUID
+	UserID    uuid.UUID
+	Offset    int64 // クライアントが認識している受信済みバイト数
+	Body      io.Reader
+	Size      int64 // リクエストボディのサイズ（Content-Length）
+}
+
+// WriteUploadChunkOutput はレジューム可能なアップロードへの書き込みの出力を定義します
+type WriteUploadChunkOutput struct {
+	SessionID uuid.UUID
+	FileID    uuid.UUID
+	Offset    int64 // 書き込み後の受信済みバイト数
+	Completed bool  // 全バイトを受信しアップロードが完了したかどうか
+	Verifying bool  // 全バイトを受信し、バックグラウンドで内容の検証を待っているかどうか
+}
+
+// WriteUploadChunkCommand は受信済みバイト数の位置からアップロードを再開して書き込むコマンドです
+type WriteUploadChunkCommand struct {
+	uploadSessionRepo      repository.UploadSessionRepository
+	resumableUploadService service.ResumableUploadService
+	completeUploadCommand  *CompleteUploadCommand
+	txManager              repository.TransactionManager
+}
+
+// NewWriteUploadChunkCommand は新しいWriteUploadChunkCommandを作成します
+func NewWriteUploadChunkCommand(
+	uploadSessionRepo repository.UploadSessionRepository,
+	resumableUploadService service.ResumableUploadService,
+	completeUploadCommand *CompleteUploadCommand,
+	txManager repository.TransactionManager,
+) *WriteUploadChunkCommand {
+	return &WriteUploadChunkCommand{
+		uploadSessionRepo:      uploadSessionRepo,
+		resumableUploadService: resumableUploadService,
+		completeUploadCommand:  completeUploadCommand,
+		txManager:              txManager,
+	}
+}
+
+// Execute はチャンクを書き込み、全バイトを受信した場合はアップロードを完了します
+func (c *WriteUploadChunkCommand) Execute(ctx context.Context, input WriteUploadChunkInput) (*WriteUploadChunkOutput, error) {
+	// 1. セッション取得
+	session, err := c.uploadSessionRepo.FindByID(ctx, input.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. 所有者またはアップロード開始者のみアップロード可能
+	if !session.IsOwnedBy(input.UserID) && !session.IsCreatedBy(input.UserID) {
+		return nil, apperror.NewForbiddenError("not authorized to upload to this session")
+	}
+	if !session.CanAcceptUpload() {
+		return nil, apperror.NewGoneError("upload session is no longer available")
+	}
+
+	// 3. クライアントの再開位置が受信済みバイト数と一致するか検証
+	progress, err := c.resumableUploadService.Progress(ctx, session)
+	if err != nil {
+		return nil, apperror.NewInternalError(err)
+	}
+	if input.Offset != progress.Offset {
+		return nil, apperror.NewConflictError("upload offset does not match the received bytes")
+	}
+	if input.Offset+input.Size > session.TotalSize {
+		return nil, apperror.NewValidationError("chunk exceeds the upload length", nil)
+	}
+
+	// 4. 書き込み
+	progress, err = c.resumableUploadService.Append(ctx, session, progress, input.Body, input.Size)
+	if err != nil {
+		return nil, apperror.NewInternalError(err)
+	}
+
+	// 5. 記録済みのパーツ数をセッションの行ロック下で保存
+	// 結合したオブジェクトのWebhookが完了処理に進むよう結合前に保存し、
+	// 全バイトを揃えたリクエストだけが結合を行う
+	assemble := false
+	err = c.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		locked, err := c.uploadSessionRepo.FindByIDForUpdate(ctx, session.ID)
+		if err != nil {
+			return err
+		}
+		session = locked
+		// 既に他のリクエストが全パーツを揃えている場合は結合を任せる
+		if session.IsCompleted() || session.AllPartsUploaded() {
+			return nil
+		}
+		if !session.CanAcceptUpload() {
+			return apperror.NewGoneError("upload session is no longer available")
+		}
+
+		session.SetUploadedParts(len(progress.Parts))
+		assemble = progress.Offset >= session.TotalSize
+		return c.uploadSessionRepo.Update(ctx, session)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !assemble {
+		return &WriteUploadChunkOutput{
+			SessionID: session.ID,
+			FileID:    session.FileID,
+			Offset:    progress.Offset,
+			Completed: session.IsCompleted(),
+			Verifying: session.IsVerifying(),
+		}, nil
+	}
+
+	// 6. 全バイトを受信したらパーツを結合し、Webhookと同じ完了処理を行う
+	versionID, err := c.resumableUploadService.Assemble(ctx, session, progress)
+	if err != nil {
+		return nil, apperror.NewInternalError(err)
+	}
+	output, err := c.completeUploadCommand.CompleteSession(ctx, session, versionID, session.TotalSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WriteUploadChunkOutput{
+		SessionID: output.SessionID,
+		FileID:    output.FileID,
+		Offset:    progress.Offset,
+		Completed: output.Completed,
+		Verifying: output.Verifying,
+	}, nil
+}
@@ -0,0 +1,219 @@
+package command_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/command"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
+)
+
+type writeUploadChunkTestDeps struct {
+	*completeUploadTestDeps
+	resumableUploadService *mocks.MockResumableUploadService
+}
+
+func newWriteUploadChunkTestDeps(t *testing.T) *writeUploadChunkTestDeps {
+	t.Helper()
+	return &writeUploadChunkTestDeps{
+		completeUploadTestDeps: newCompleteUploadTestDeps(t),
+		resumableUploadService: mocks.NewMockResumableUploadService(t),
+	}
+}
+
+func (d *writeUploadChunkTestDeps) newWriteUploadChunkCommand() *command.WriteUploadChunkCommand {
+	return command.NewWriteUploadChunkCommand(d.uploadSessionRepo, d.resumableUploadService, d.newCommand(), d.txManager)
+}
+
+func TestWriteUploadChunkCommand_Execute_PartialChunk_UpdatesOffset(t *testing.T) {
+	ctx := context.Background()
+	deps := newWriteUploadChunkTestDeps(t)
+
+	ownerID := uuid.New()
+	session := newPendingSession(uuid.New(), ownerID, uuid.New())
+	body := strings.NewReader(strings.Repeat("a", 100))
+	current := &service.ResumableUploadProgress{}
+	updated := &service.ResumableUploadProgress{Offset: 100, Partial: &service.StoredPart{PartNumber: 1, Size: 100}}
+
+	deps.uploadSessionRepo.On("FindByID", ctx, session.ID).Return(session, nil)
+	deps.resumableUploadService.On("Progress", ctx, session).Return(current, nil)
+	deps.resumableUploadService.On("Append", ctx, session, current, body, int64(100)).Return(updated, nil)
+	deps.uploadSessionRepo.On("FindByIDForUpdate", ctx, session.ID).Return(session, nil)
+	deps.uploadSessionRepo.On("Update", ctx, session).Return(nil)
+
+	output, err := deps.newWriteUploadChunkCommand().Execute(ctx, command.WriteUploadChunkInput{
+		SessionID: session.ID,
+		UserID:    ownerID,
+		Offset:    0,
+		Body:      body,
+		Size:      100,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, output.Completed)
+	assert.Equal(t, int64(100), output.Offset)
+}
+
+func TestWriteUploadChunkCommand_Execute_FinalChunk_AssemblesAndCompletes(t *testing.T) {
+	ctx := context.Background()
+	deps := newWriteUploadChunkTestDeps(t)
+
+	ownerID := uuid.New()
+	folderID := uuid.New()
+	file := newUploadingFileEntity(ownerID, folderID)
+	session := newPendingSession(file.ID, ownerID, folderID)
+	body := strings.NewReader(strings.Repeat("a", 24))
+	current := &service.ResumableUploadProgress{Offset: 1000, Partial: &service.StoredPart{PartNumber: 1, Size: 1000}}
+	updated := &service.ResumableUploadProgress{
+		Offset: 1024,
+		Parts:  []*entity.UploadPart{entity.NewUploadPart(session.ID, 1, 1024, "etag-1")},
+	}
+
+	deps.uploadSessionRepo.On("FindByID", ctx, session.ID).Return(session, nil)
+	deps.uploadSessionRepo.On("FindByIDForUpdate", ctx, session.ID).Return(session, nil)
+	deps.resumableUploadService.On("Progress", ctx, session).Return(current, nil)
+	deps.resumableUploadService.On("Append", ctx, session, current, body, int64(24)).Return(updated, nil)
+	// 結合したオブジェクトのWebhookが完了処理に進めるよう、結合前に全パーツ記録済みとして保存する
+	savedParts := 0
+	deps.uploadSessionRepo.On("Update", ctx, session).Run(func(args mock.Arguments) {
+		savedParts = args.Get(1).(*entity.UploadSession).UploadedParts
+	}).Return(nil).Once()
+	deps.resumableUploadService.On("Assemble", ctx, session, updated).
+		Run(func(args mock.Arguments) {
+			assert.Equal(t, 1, savedParts)
+		}).
+		Return("v-assembled", nil)
+	deps.expectCompletion(ctx, session, file, "v-assembled")
+
+	output, err := deps.newWriteUploadChunkCommand().Execute(ctx, command.WriteUploadChunkInput{
+		SessionID: session.ID,
+		UserID:    ownerID,
+		Offset:    1000,
+		Body:      body,
+		Size:      24,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, output.Completed)
+	assert.Equal(t, int64(1024), output.Offset)
+	assert.Equal(t, entity.UploadSessionStatusCompleted, session.Status)
+	assert.Equal(t, entity.FileStatusActive, file.Status)
+}
+
+func TestWriteUploadChunkCommand_Execute_ConcurrentFinalChunk_LeavesAssemblyToOtherRequest(t *testing.T) {
+	ctx := context.Background()
+	deps := newWriteUploadChunkTestDeps(t)
+
+	ownerID := uuid.New()
+	session := newPendingSession(uuid.New(), ownerID, uuid.New())
+	body := strings.NewReader(strings.Repeat("a", 24))
+	current := &service.ResumableUploadProgress{Offset: 1000, Partial: &service.StoredPart{PartNumber: 1, Size: 1000}}
+	updated := &service.ResumableUploadProgress{
+		Offset: 1024,
+		Parts:  []*entity.UploadPart{entity.NewUploadPart(session.ID, 1, 1024, "etag-1")},
+	}
+
+	// ロック取得時には並行する最終チャンクのリクエストが全パーツ記録済みとして保存している
+	lockedSession := newPendingSession(session.FileID, ownerID, session.FolderID)
+	lockedSession.ID = session.ID
+	lockedSession.SetUploadedParts(1)
+
+	deps.uploadSessionRepo.On("FindByID", ctx, session.ID).Return(session, nil)
+	deps.resumableUploadService.On("Progress", ctx, session).Return(current, nil)
+	deps.resumableUploadService.On("Append", ctx, session, current, body, int64(24)).Return(updated, nil)
+	deps.uploadSessionRepo.On("FindByIDForUpdate", ctx, session.ID).Return(lockedSession, nil)
+
+	output, err := deps.newWriteUploadChunkCommand().Execute(ctx, command.WriteUploadChunkInput{
+		SessionID: session.ID,
+		UserID:    ownerID,
+		Offset:    1000,
+		Body:      body,
+		Size:      24,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, output.Completed)
+	assert.Equal(t, int64(1024), output.Offset)
+	deps.resumableUploadService.AssertNotCalled(t, "Assemble", mock.Anything, mock.Anything, mock.Anything)
+	deps.uploadSessionRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestWriteUploadChunkCommand_Execute_OffsetMismatch_ReturnsConflict(t *testing.T) {
+	ctx := context.Background()
+	deps := newWriteUploadChunkTestDeps(t)
+
+	ownerID := uuid.New()
+	session := newPendingSession(uuid.New(), ownerID, uuid.New())
+
+	deps.uploadSessionRepo.On("FindByID", ctx, session.ID).Return(session, nil)
+	deps.resumableUploadService.On("Progress", ctx, session).Return(&service.ResumableUploadProgress{Offset: 200}, nil)
+
+	output, err := deps.newWriteUploadChunkCommand().Execute(ctx, command.WriteUploadChunkInput{
+		SessionID: session.ID,
+		UserID:    ownerID,
+		Offset:    100,
+		Body:      strings.NewReader("chunk"),
+		Size:      5,
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	appErr, ok := err.(*apperror.AppError)
+	require.True(t, ok)
+	assert.Equal(t, apperror.CodeConflict, appErr.Code)
+}
+
+func TestWriteUploadChunkCommand_Execute_ExceedsLength_ReturnsValidationError(t *testing.T) {
+	ctx := context.Background()
+	deps := newWriteUploadChunkTestDeps(t)
+
+	ownerID := uuid.New()
+	session := newPendingSession(uuid.New(), ownerID, uuid.New())
+
+	deps.uploadSessionRepo.On("FindByID", ctx, session.ID).Return(session, nil)
+	deps.resumableUploadService.On("Progress", ctx, session).Return(&service.ResumableUploadProgress{Offset: 1000}, nil)
+
+	output, err := deps.newWriteUploadChunkCommand().Execute(ctx, command.WriteUploadChunkInput{
+		SessionID: session.ID,
+		UserID:    ownerID,
+		Offset:    1000,
+		Body:      strings.NewReader(strings.Repeat("a", 100)),
+		Size:      100,
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	appErr, ok := err.(*apperror.AppError)
+	require.True(t, ok)
+	assert.Equal(t, apperror.CodeValidationError, appErr.Code)
+}
+
+func TestWriteUploadChunkCommand_Execute_OtherUser_ReturnsForbidden(t *testing.T) {
+	ctx := context.Background()
+	deps := newWriteUploadChunkTestDeps(t)
+
+	session := newPendingSession(uuid.New(), uuid.New(), uuid.New())
+
+	deps.uploadSessionRepo.On("FindByID", ctx, session.ID).Return(session, nil)
+
+	output, err := deps.newWriteUploadChunkCommand().Execute(ctx, command.WriteUploadChunkInput{
+		SessionID: session.ID,
+		UserID:    uuid.New(),
+		Body:      strings.NewReader(""),
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	appErr, ok := err.(*apperror.AppError)
+	require.True(t, ok)
+	assert.Equal(t, apperror.CodeForbidden, appErr.Code)
+}
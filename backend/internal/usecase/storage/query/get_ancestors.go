@@ -5,6 +5,7 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
 	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
@@ -23,18 +24,21 @@ type GetAncestorsOutput struct {
 
 // GetAncestorsQuery は祖先フォルダ取得クエリです（パンくずリスト用）
 type GetAncestorsQuery struct {
-	folderRepo        repository.FolderRepository
-	folderClosureRepo repository.FolderClosureRepository
+	folderRepo         repository.FolderRepository
+	folderClosureRepo  repository.FolderClosureRepository
+	permissionResolver authz.PermissionResolver
 }
 
 // NewGetAncestorsQuery は新しいGetAncestorsQueryを作成します
 func NewGetAncestorsQuery(
 	folderRepo repository.FolderRepository,
 	folderClosureRepo repository.FolderClosureRepository,
+	permissionResolver authz.PermissionResolver,
 ) *GetAncestorsQuery {
 	return &GetAncestorsQuery{
-		folderRepo:        folderRepo,
-		folderClosureRepo: folderClosureRepo,
+		folderRepo:         folderRepo,
+		folderClosureRepo:  folderClosureRepo,
+		permissionResolver: permissionResolver,
 	}
 }
 
@@ -46,8 +50,12 @@ func (q *GetAncestorsQuery) Execute(ctx context.Context, input GetAncestorsInput
 		return nil, err
 	}
 
-	// 所有者チェック
-	if !folder.IsOwnedBy(input.UserID) {
+	// 権限チェック (folder:read)
+	hasPermission, err := q.permissionResolver.HasPermission(ctx, input.UserID, authz.ResourceTypeFolder, folder.ID, authz.PermFolderRead)
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
 		return nil, apperror.NewForbiddenError("not authorized to access this folder")
 	}
 
@@ -63,13 +71,23 @@ func (q *GetAncestorsQuery) Execute(ctx context.Context, input GetAncestorsInput
 	}
 
 	// 3. 祖先フォルダを取得
-	ancestors := make([]*entity.Folder, len(ancestorIDs))
-	for i, id := range ancestorIDs {
+	// 共有されたフォルダより上の階層は共有元ユーザーのフォルダのため、
+	// 閲覧権限のない祖先に到達した時点で打ち切る
+	ancestors := make([]*entity.Folder, 0, len(ancestorIDs))
+	for _, id := range ancestorIDs {
+		canRead, err := q.permissionResolver.HasPermission(ctx, input.UserID, authz.ResourceTypeFolder, id, authz.PermFolderRead)
+		if err != nil {
+			return nil, err
+		}
+		if !canRead {
+			break
+		}
+
 		ancestor, err := q.folderRepo.FindByID(ctx, id)
 		if err != nil {
 			return nil, err
 		}
-		ancestors[i] = ancestor
+		ancestors = append(ancestors, ancestor)
 	}
 
 	// 4. 深さ順にソート（浅い順 = ルートから）
@@ -8,8 +8,10 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/query"
@@ -18,20 +20,22 @@ import (
 )
 
 type getAncestorsTestDeps struct {
-	folderRepo        *mocks.MockFolderRepository
-	folderClosureRepo *mocks.MockFolderClosureRepository
+	folderRepo         *mocks.MockFolderRepository
+	folderClosureRepo  *mocks.MockFolderClosureRepository
+	permissionResolver *mocks.MockPermissionResolver
 }
 
 func newGetAncestorsTestDeps(t *testing.T) *getAncestorsTestDeps {
 	t.Helper()
 	return &getAncestorsTestDeps{
-		folderRepo:        mocks.NewMockFolderRepository(t),
-		folderClosureRepo: mocks.NewMockFolderClosureRepository(t),
+		folderRepo:         mocks.NewMockFolderRepository(t),
+		folderClosureRepo:  mocks.NewMockFolderClosureRepository(t),
+		permissionResolver: mocks.NewMockPermissionResolver(t),
 	}
 }
 
 func (d *getAncestorsTestDeps) newQuery() *query.GetAncestorsQuery {
-	return query.NewGetAncestorsQuery(d.folderRepo, d.folderClosureRepo)
+	return query.NewGetAncestorsQuery(d.folderRepo, d.folderClosureRepo, d.permissionResolver)
 }
 
 func newAncestorFolderEntity(ownerID uuid.UUID, name string) *entity.Folder {
@@ -55,6 +59,7 @@ func TestGetAncestorsQuery_Execute_RootFolder_ReturnsEmptyAncestors(t *testing.T
 	}
 
 	deps.folderRepo.On("FindByID", ctx, folder.ID).Return(folder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFolder, folder.ID, authz.PermFolderRead).Return(true, nil)
 	deps.folderClosureRepo.On("FindAncestorIDs", ctx, folder.ID).Return([]uuid.UUID{}, nil)
 
 	q := deps.newQuery()
@@ -86,6 +91,7 @@ func TestGetAncestorsQuery_Execute_ChildFolder_ReturnsAncestorsInRootFirstOrder(
 	}
 
 	deps.folderRepo.On("FindByID", ctx, childFolder.ID).Return(childFolder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFolder, mock.Anything, authz.PermFolderRead).Return(true, nil)
 	deps.folderClosureRepo.On("FindAncestorIDs", ctx, childFolder.ID).Return(ancestorIDs, nil)
 	deps.folderRepo.On("FindByID", ctx, parentFolder.ID).Return(parentFolder, nil)
 	deps.folderRepo.On("FindByID", ctx, rootFolder.ID).Return(rootFolder, nil)
@@ -101,6 +107,37 @@ func TestGetAncestorsQuery_Execute_ChildFolder_ReturnsAncestorsInRootFirstOrder(
 	assert.Equal(t, parentFolder.ID, output.Ancestors[1].ID)
 }
 
+func TestGetAncestorsQuery_Execute_SharedFolder_StopsAtUnreadableAncestor(t *testing.T) {
+	ctx := context.Background()
+	deps := newGetAncestorsTestDeps(t)
+
+	ownerID := uuid.New()
+	memberID := uuid.New()
+	rootFolder := newAncestorFolderEntity(ownerID, "root")
+	sharedFolder := newAncestorFolderEntity(ownerID, "shared")
+	childFolder := newAncestorFolderEntity(ownerID, "child")
+
+	input := query.GetAncestorsInput{
+		FolderID: childFolder.ID,
+		UserID:   memberID,
+	}
+
+	deps.folderRepo.On("FindByID", ctx, childFolder.ID).Return(childFolder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, memberID, authz.ResourceTypeFolder, childFolder.ID, authz.PermFolderRead).Return(true, nil)
+	deps.folderClosureRepo.On("FindAncestorIDs", ctx, childFolder.ID).Return([]uuid.UUID{sharedFolder.ID, rootFolder.ID}, nil)
+	deps.permissionResolver.On("HasPermission", ctx, memberID, authz.ResourceTypeFolder, sharedFolder.ID, authz.PermFolderRead).Return(true, nil)
+	deps.folderRepo.On("FindByID", ctx, sharedFolder.ID).Return(sharedFolder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, memberID, authz.ResourceTypeFolder, rootFolder.ID, authz.PermFolderRead).Return(false, nil)
+
+	q := deps.newQuery()
+	output, err := q.Execute(ctx, input)
+
+	require.NoError(t, err)
+	require.Len(t, output.Ancestors, 1)
+	assert.Equal(t, sharedFolder.ID, output.Ancestors[0].ID)
+	deps.folderRepo.AssertNotCalled(t, "FindByID", ctx, rootFolder.ID)
+}
+
 func TestGetAncestorsQuery_Execute_FolderNotFound_PropagatesError(t *testing.T) {
 	ctx := context.Background()
 	deps := newGetAncestorsTestDeps(t)
@@ -125,7 +162,7 @@ func TestGetAncestorsQuery_Execute_FolderNotFound_PropagatesError(t *testing.T)
 	assert.Equal(t, apperror.CodeNotFound, appErr.Code)
 }
 
-func TestGetAncestorsQuery_Execute_NotOwner_ReturnsForbidden(t *testing.T) {
+func TestGetAncestorsQuery_Execute_WithoutReadPermission_ReturnsForbidden(t *testing.T) {
 	ctx := context.Background()
 	deps := newGetAncestorsTestDeps(t)
 
@@ -139,6 +176,7 @@ func TestGetAncestorsQuery_Execute_NotOwner_ReturnsForbidden(t *testing.T) {
 	}
 
 	deps.folderRepo.On("FindByID", ctx, folder.ID).Return(folder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, differentUserID, authz.ResourceTypeFolder, folder.ID, authz.PermFolderRead).Return(false, nil)
 
 	q := deps.newQuery()
 	output, err := q.Execute(ctx, input)
@@ -164,6 +202,7 @@ func TestGetAncestorsQuery_Execute_ClosureRepoError_PropagatesError(t *testing.T
 	}
 
 	deps.folderRepo.On("FindByID", ctx, folder.ID).Return(folder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFolder, folder.ID, authz.PermFolderRead).Return(true, nil)
 	deps.folderClosureRepo.On("FindAncestorIDs", ctx, folder.ID).Return(nil, repoErr)
 
 	q := deps.newQuery()
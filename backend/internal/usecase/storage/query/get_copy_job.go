@@ -0,0 +1,49 @@
+package query
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// GetCopyJobInput はコピージョブ取得の入力を定義します
+type GetCopyJobInput struct {
+	JobID  uuid.UUID
+	UserID uuid.UUID
+}
+
+// GetCopyJobOutput はコピージョブ取得の出力を定義します
+type GetCopyJobOutput struct {
+	Job *entity.CopyJob
+}
+
+// GetCopyJobQuery はコピージョブの進捗取得クエリです
+type GetCopyJobQuery struct {
+	copyJobRepo repository.CopyJobRepository
+}
+
+// NewGetCopyJobQuery は新しいGetCopyJobQueryを作成します
+func NewGetCopyJobQuery(copyJobRepo repository.CopyJobRepository) *GetCopyJobQuery {
+	return &GetCopyJobQuery{
+		copyJobRepo: copyJobRepo,
+	}
+}
+
+// Execute はコピージョブを取得します
+func (q *GetCopyJobQuery) Execute(ctx context.Context, input GetCopyJobInput) (*GetCopyJobOutput, error) {
+	job, err := q.copyJobRepo.FindByID(ctx, input.JobID)
+	if err != nil {
+		return nil, err
+	}
+
+	// ジョブを登録したユーザーのみ参照可能
+	if job.UserID != input.UserID {
+		return nil, apperror.NewForbiddenError("not authorized to view this copy job")
+	}
+
+	return &GetCopyJobOutput{Job: job}, nil
+}
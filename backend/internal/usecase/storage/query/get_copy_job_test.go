@@ -0,0 +1,52 @@
+package query_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/query"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
+)
+
+func TestGetCopyJobQuery_Execute_RequestingUser_ReturnsJob(t *testing.T) {
+	ctx := context.Background()
+	copyJobRepo := mocks.NewMockCopyJobRepository(t)
+
+	userID := uuid.New()
+	job := entity.NewCopyJob(userID, uuid.New(), uuid.New())
+	copyJobRepo.On("FindByID", ctx, job.ID).Return(job, nil)
+
+	output, err := query.NewGetCopyJobQuery(copyJobRepo).Execute(ctx, query.GetCopyJobInput{
+		JobID:  job.ID,
+		UserID: userID,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, job, output.Job)
+}
+
+func TestGetCopyJobQuery_Execute_OtherUser_ReturnsForbidden(t *testing.T) {
+	ctx := context.Background()
+	copyJobRepo := mocks.NewMockCopyJobRepository(t)
+
+	job := entity.NewCopyJob(uuid.New(), uuid.New(), uuid.New())
+	copyJobRepo.On("FindByID", ctx, job.ID).Return(job, nil)
+
+	output, err := query.NewGetCopyJobQuery(copyJobRepo).Execute(ctx, query.GetCopyJobInput{
+		JobID:  job.ID,
+		UserID: uuid.New(),
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeForbidden, appErr.Code)
+}
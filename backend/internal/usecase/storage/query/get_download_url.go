@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
@@ -35,9 +36,10 @@ type GetDownloadURLOutput struct {
 
 // GetDownloadURLQuery はダウンロードURL取得クエリです
 type GetDownloadURLQuery struct {
-	fileRepo        repository.FileRepository
-	fileVersionRepo repository.FileVersionRepository
-	storageService  service.StorageService
+	fileRepo           repository.FileRepository
+	fileVersionRepo    repository.FileVersionRepository
+	storageService     service.StorageService
+	permissionResolver authz.PermissionResolver
 }
 
 // NewGetDownloadURLQuery は新しいGetDownloadURLQueryを作成します
@@ -45,11 +47,13 @@ func NewGetDownloadURLQuery(
 	fileRepo repository.FileRepository,
 	fileVersionRepo repository.FileVersionRepository,
 	storageService service.StorageService,
+	permissionResolver authz.PermissionResolver,
 ) *GetDownloadURLQuery {
 	return &GetDownloadURLQuery{
-		fileRepo:        fileRepo,
-		fileVersionRepo: fileVersionRepo,
-		storageService:  storageService,
+		fileRepo:           fileRepo,
+		fileVersionRepo:    fileVersionRepo,
+		storageService:     storageService,
+		permissionResolver: permissionResolver,
 	}
 }
 
@@ -61,8 +65,12 @@ func (q *GetDownloadURLQuery) Execute(ctx context.Context, input GetDownloadURLI
 		return nil, err
 	}
 
-	// 2. 所有者チェック
-	if !file.IsOwnedBy(input.UserID) {
+	// 2. 権限チェック (file:download)
+	hasPermission, err := q.permissionResolver.HasPermission(ctx, input.UserID, authz.ResourceTypeFile, file.ID, authz.PermFileDownload)
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
 		return nil, apperror.NewForbiddenError("not authorized to download this file")
 	}
 
@@ -83,7 +91,7 @@ func (q *GetDownloadURLQuery) Execute(ctx context.Context, input GetDownloadURLI
 	}
 
 	// 5. Presigned URL生成
-	presigned, err := q.storageService.GenerateGetURL(ctx, file.StorageKey.String(), DownloadURLExpiry)
+	presigned, err := q.storageService.GenerateGetURL(ctx, version.StorageKey.String(), version.MinioVersionID, DownloadURLExpiry)
 	if err != nil {
 		return nil, apperror.NewInternalError(err)
 	}
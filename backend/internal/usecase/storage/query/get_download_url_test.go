@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
@@ -22,22 +23,24 @@ import (
 func intPtr(n int) *int { return &n }
 
 type getDownloadURLTestDeps struct {
-	fileRepo        *mocks.MockFileRepository
-	fileVersionRepo *mocks.MockFileVersionRepository
-	storageService  *mocks.MockStorageService
+	fileRepo           *mocks.MockFileRepository
+	fileVersionRepo    *mocks.MockFileVersionRepository
+	storageService     *mocks.MockStorageService
+	permissionResolver *mocks.MockPermissionResolver
 }
 
 func newGetDownloadURLTestDeps(t *testing.T) *getDownloadURLTestDeps {
 	t.Helper()
 	return &getDownloadURLTestDeps{
-		fileRepo:        mocks.NewMockFileRepository(t),
-		fileVersionRepo: mocks.NewMockFileVersionRepository(t),
-		storageService:  mocks.NewMockStorageService(t),
+		fileRepo:           mocks.NewMockFileRepository(t),
+		fileVersionRepo:    mocks.NewMockFileVersionRepository(t),
+		storageService:     mocks.NewMockStorageService(t),
+		permissionResolver: mocks.NewMockPermissionResolver(t),
 	}
 }
 
 func (d *getDownloadURLTestDeps) newQuery() *query.GetDownloadURLQuery {
-	return query.NewGetDownloadURLQuery(d.fileRepo, d.fileVersionRepo, d.storageService)
+	return query.NewGetDownloadURLQuery(d.fileRepo, d.fileVersionRepo, d.storageService, d.permissionResolver)
 }
 
 func newActiveFileForQuery(ownerID, folderID uuid.UUID) *entity.File {
@@ -66,8 +69,8 @@ func newUploadingFileForQuery(ownerID, folderID uuid.UUID) *entity.File {
 
 func newFileVersionForQuery(fileID uuid.UUID, versionNumber int) *entity.FileVersion {
 	return entity.ReconstructFileVersion(
-		uuid.New(), fileID, versionNumber, "minio-version-id",
-		10485760, "sha256:abc123", uuid.New(), time.Now(),
+		uuid.New(), fileID, versionNumber, valueobject.NewStorageKey(fileID), "minio-version-id", nil,
+		10485760, "sha256:abc123", uuid.New(), false, time.Now(),
 	)
 }
 
@@ -85,8 +88,9 @@ func TestGetDownloadURLQuery_Execute_ActiveFile_ReturnsPresignedURL(t *testing.T
 	}
 
 	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFile, file.ID, authz.PermFileDownload).Return(true, nil)
 	deps.fileVersionRepo.On("FindLatestByFileID", ctx, file.ID).Return(version, nil)
-	deps.storageService.On("GenerateGetURL", ctx, file.StorageKey.String(), query.DownloadURLExpiry).Return(presigned, nil)
+	deps.storageService.On("GenerateGetURL", ctx, version.StorageKey.String(), version.MinioVersionID, query.DownloadURLExpiry).Return(presigned, nil)
 
 	q := deps.newQuery()
 	output, err := q.Execute(ctx, query.GetDownloadURLInput{
@@ -119,8 +123,9 @@ func TestGetDownloadURLQuery_Execute_WithVersionNumber_ReturnsVersionedURL(t *te
 	}
 
 	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFile, file.ID, authz.PermFileDownload).Return(true, nil)
 	deps.fileVersionRepo.On("FindByFileAndVersion", ctx, file.ID, 1).Return(version, nil)
-	deps.storageService.On("GenerateGetURL", ctx, file.StorageKey.String(), query.DownloadURLExpiry).Return(presigned, nil)
+	deps.storageService.On("GenerateGetURL", ctx, version.StorageKey.String(), version.MinioVersionID, query.DownloadURLExpiry).Return(presigned, nil)
 
 	q := deps.newQuery()
 	output, err := q.Execute(ctx, query.GetDownloadURLInput{
@@ -158,7 +163,7 @@ func TestGetDownloadURLQuery_Execute_FileNotFound_PropagatesError(t *testing.T)
 	assert.Equal(t, apperror.CodeNotFound, appErr.Code)
 }
 
-func TestGetDownloadURLQuery_Execute_NotOwner_ReturnsForbidden(t *testing.T) {
+func TestGetDownloadURLQuery_Execute_WithoutDownloadPermission_ReturnsForbidden(t *testing.T) {
 	ctx := context.Background()
 	ownerID := uuid.New()
 	otherUserID := uuid.New()
@@ -168,6 +173,7 @@ func TestGetDownloadURLQuery_Execute_NotOwner_ReturnsForbidden(t *testing.T) {
 	file := newActiveFileForQuery(ownerID, folderID)
 
 	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, otherUserID, authz.ResourceTypeFile, file.ID, authz.PermFileDownload).Return(false, nil)
 
 	q := deps.newQuery()
 	output, err := q.Execute(ctx, query.GetDownloadURLInput{
@@ -182,6 +188,35 @@ func TestGetDownloadURLQuery_Execute_NotOwner_ReturnsForbidden(t *testing.T) {
 	assert.Equal(t, apperror.CodeForbidden, appErr.Code)
 }
 
+func TestGetDownloadURLQuery_Execute_GrantedNonOwner_ReturnsPresignedURL(t *testing.T) {
+	ctx := context.Background()
+	ownerID := uuid.New()
+	memberID := uuid.New()
+	folderID := uuid.New()
+
+	deps := newGetDownloadURLTestDeps(t)
+	file := newActiveFileForQuery(ownerID, folderID)
+	version := newFileVersionForQuery(file.ID, 2)
+	presigned := &service.PresignedURL{
+		URL:       "https://minio.example.com/presigned-url",
+		ExpiresAt: time.Now().Add(1 * time.Hour),
+	}
+
+	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, memberID, authz.ResourceTypeFile, file.ID, authz.PermFileDownload).Return(true, nil)
+	deps.fileVersionRepo.On("FindLatestByFileID", ctx, file.ID).Return(version, nil)
+	deps.storageService.On("GenerateGetURL", ctx, version.StorageKey.String(), version.MinioVersionID, query.DownloadURLExpiry).Return(presigned, nil)
+
+	q := deps.newQuery()
+	output, err := q.Execute(ctx, query.GetDownloadURLInput{
+		FileID: file.ID,
+		UserID: memberID,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, presigned.URL, output.DownloadURL)
+}
+
 func TestGetDownloadURLQuery_Execute_UploadingFile_ReturnsValidationError(t *testing.T) {
 	ctx := context.Background()
 	ownerID := uuid.New()
@@ -191,6 +226,7 @@ func TestGetDownloadURLQuery_Execute_UploadingFile_ReturnsValidationError(t *tes
 	file := newUploadingFileForQuery(ownerID, folderID)
 
 	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFile, file.ID, authz.PermFileDownload).Return(true, nil)
 
 	q := deps.newQuery()
 	output, err := q.Execute(ctx, query.GetDownloadURLInput{
@@ -215,6 +251,7 @@ func TestGetDownloadURLQuery_Execute_VersionNotFound_PropagatesError(t *testing.
 	notFoundErr := apperror.NewNotFoundError("file version")
 
 	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFile, file.ID, authz.PermFileDownload).Return(true, nil)
 	deps.fileVersionRepo.On("FindByFileAndVersion", ctx, file.ID, 99).Return(nil, notFoundErr)
 
 	q := deps.newQuery()
@@ -242,8 +279,9 @@ func TestGetDownloadURLQuery_Execute_StorageServiceError_ReturnsInternalError(t
 	storageErr := errors.New("storage connection failed")
 
 	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFile, file.ID, authz.PermFileDownload).Return(true, nil)
 	deps.fileVersionRepo.On("FindLatestByFileID", ctx, file.ID).Return(version, nil)
-	deps.storageService.On("GenerateGetURL", ctx, file.StorageKey.String(), query.DownloadURLExpiry).Return(nil, storageErr)
+	deps.storageService.On("GenerateGetURL", ctx, version.StorageKey.String(), version.MinioVersionID, query.DownloadURLExpiry).Return(nil, storageErr)
 
 	q := deps.newQuery()
 	output, err := q.Execute(ctx, query.GetDownloadURLInput{
@@ -0,0 +1,100 @@
+package query
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// GetFilePreviewInput はファイルプレビュー取得の入力を定義します
+type GetFilePreviewInput struct {
+	FileID   uuid.UUID
+	UserID   uuid.UUID
+	MaxBytes int // 0以下の場合は既定値
+}
+
+// GetFilePreviewOutput はファイルプレビュー取得の出力を定義します
+type GetFilePreviewOutput struct {
+	FileID        uuid.UUID
+	FileName      string
+	MimeType      string
+	Size          int64
+	VersionNumber int
+	Preview       *service.FilePreview
+}
+
+// GetFilePreviewQuery はファイルの現在のバージョンの先頭部分をインラインプレビューとして取得するクエリです
+type GetFilePreviewQuery struct {
+	fileRepo           repository.FileRepository
+	fileVersionRepo    repository.FileVersionRepository
+	filePreviewService service.FilePreviewService
+	permissionResolver authz.PermissionResolver
+}
+
+// NewGetFilePreviewQuery は新しいGetFilePreviewQueryを作成します
+func NewGetFilePreviewQuery(
+	fileRepo repository.FileRepository,
+	fileVersionRepo repository.FileVersionRepository,
+	filePreviewService service.FilePreviewService,
+	permissionResolver authz.PermissionResolver,
+) *GetFilePreviewQuery {
+	return &GetFilePreviewQuery{
+		fileRepo:           fileRepo,
+		fileVersionRepo:    fileVersionRepo,
+		filePreviewService: filePreviewService,
+		permissionResolver: permissionResolver,
+	}
+}
+
+// Execute はファイルプレビューを取得します
+func (q *GetFilePreviewQuery) Execute(ctx context.Context, input GetFilePreviewInput) (*GetFilePreviewOutput, error) {
+	// 1. ファイル取得
+	file, err := q.fileRepo.FindByID(ctx, input.FileID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. 権限チェック (file:read)
+	hasPermission, err := q.permissionResolver.HasPermission(ctx, input.UserID, authz.ResourceTypeFile, file.ID, authz.PermFileRead)
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, apperror.NewForbiddenError("not authorized to preview this file")
+	}
+
+	// 3. プレビュー可能な状態かチェック
+	if !file.CanDownload() {
+		return nil, apperror.NewValidationError("file cannot be previewed in current state", nil)
+	}
+
+	// 4. 最新バージョン取得
+	version, err := q.fileVersionRepo.FindLatestByFileID(ctx, file.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 5. 先頭部分を読み込んでプレビューを生成
+	preview, err := q.filePreviewService.Generate(ctx, file, version, input.MaxBytes)
+	if err != nil {
+		if errors.Is(err, service.ErrPreviewNotSupported) {
+			return nil, apperror.NewValidationError("preview is not available for this file type", nil)
+		}
+		return nil, apperror.NewInternalError(err)
+	}
+
+	return &GetFilePreviewOutput{
+		FileID:        file.ID,
+		FileName:      file.Name.String(),
+		MimeType:      file.MimeType.String(),
+		Size:          version.Size,
+		VersionNumber: version.VersionNumber,
+		Preview:       preview,
+	}, nil
+}
@@ -0,0 +1,147 @@
+package query_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/query"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
+)
+
+type getFilePreviewTestDeps struct {
+	fileRepo           *mocks.MockFileRepository
+	fileVersionRepo    *mocks.MockFileVersionRepository
+	filePreviewService *mocks.MockFilePreviewService
+	permissionResolver *mocks.MockPermissionResolver
+}
+
+func newGetFilePreviewTestDeps(t *testing.T) *getFilePreviewTestDeps {
+	t.Helper()
+	return &getFilePreviewTestDeps{
+		fileRepo:           mocks.NewMockFileRepository(t),
+		fileVersionRepo:    mocks.NewMockFileVersionRepository(t),
+		filePreviewService: mocks.NewMockFilePreviewService(t),
+		permissionResolver: mocks.NewMockPermissionResolver(t),
+	}
+}
+
+func (d *getFilePreviewTestDeps) newQuery() *query.GetFilePreviewQuery {
+	return query.NewGetFilePreviewQuery(d.fileRepo, d.fileVersionRepo, d.filePreviewService, d.permissionResolver)
+}
+
+func TestGetFilePreviewQuery_Execute_WithReadPermission_ReturnsPreview(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	deps := newGetFilePreviewTestDeps(t)
+	file := newActiveFileForQuery(uuid.New(), uuid.New())
+	version := newFileVersionForQuery(file.ID, 2)
+	preview := &service.FilePreview{
+		Format:    service.PreviewFormatText,
+		Encoding:  service.PreviewEncodingUTF8,
+		Content:   "hello\n",
+		LineCount: 1,
+		BytesRead: 6,
+	}
+
+	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFile, file.ID, authz.PermFileRead).Return(true, nil)
+	deps.fileVersionRepo.On("FindLatestByFileID", ctx, file.ID).Return(version, nil)
+	deps.filePreviewService.On("Generate", ctx, file, version, 4096).Return(preview, nil)
+
+	output, err := deps.newQuery().Execute(ctx, query.GetFilePreviewInput{
+		FileID:   file.ID,
+		UserID:   userID,
+		MaxBytes: 4096,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, file.ID, output.FileID)
+	assert.Equal(t, "report.pdf", output.FileName)
+	assert.Equal(t, version.Size, output.Size)
+	assert.Equal(t, 2, output.VersionNumber)
+	assert.Same(t, preview, output.Preview)
+}
+
+func TestGetFilePreviewQuery_Execute_WithoutReadPermission_ReturnsForbidden(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	deps := newGetFilePreviewTestDeps(t)
+	file := newActiveFileForQuery(uuid.New(), uuid.New())
+
+	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFile, file.ID, authz.PermFileRead).Return(false, nil)
+
+	_, err := deps.newQuery().Execute(ctx, query.GetFilePreviewInput{FileID: file.ID, UserID: userID})
+
+	require.Error(t, err)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeForbidden, appErr.Code)
+	deps.filePreviewService.AssertNotCalled(t, "Generate", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetFilePreviewQuery_Execute_UploadingFile_ReturnsValidationError(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	deps := newGetFilePreviewTestDeps(t)
+	file := newUploadingFileForQuery(userID, uuid.New())
+
+	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFile, file.ID, authz.PermFileRead).Return(true, nil)
+
+	_, err := deps.newQuery().Execute(ctx, query.GetFilePreviewInput{FileID: file.ID, UserID: userID})
+
+	require.Error(t, err)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeValidationError, appErr.Code)
+}
+
+func TestGetFilePreviewQuery_Execute_UnsupportedType_ReturnsValidationError(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	deps := newGetFilePreviewTestDeps(t)
+	file := newActiveFileForQuery(userID, uuid.New())
+	version := newFileVersionForQuery(file.ID, 1)
+
+	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFile, file.ID, authz.PermFileRead).Return(true, nil)
+	deps.fileVersionRepo.On("FindLatestByFileID", ctx, file.ID).Return(version, nil)
+	deps.filePreviewService.On("Generate", ctx, file, version, 0).Return(nil, service.ErrPreviewNotSupported)
+
+	_, err := deps.newQuery().Execute(ctx, query.GetFilePreviewInput{FileID: file.ID, UserID: userID})
+
+	require.Error(t, err)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeValidationError, appErr.Code)
+}
+
+func TestGetFilePreviewQuery_Execute_StorageFailure_ReturnsInternalError(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	deps := newGetFilePreviewTestDeps(t)
+	file := newActiveFileForQuery(userID, uuid.New())
+	version := newFileVersionForQuery(file.ID, 1)
+
+	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFile, file.ID, authz.PermFileRead).Return(true, nil)
+	deps.fileVersionRepo.On("FindLatestByFileID", ctx, file.ID).Return(version, nil)
+	deps.filePreviewService.On("Generate", ctx, file, version, 0).Return(nil, errors.New("connection reset"))
+
+	_, err := deps.newQuery().Execute(ctx, query.GetFilePreviewInput{FileID: file.ID, UserID: userID})
+
+	require.Error(t, err)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeInternalError, appErr.Code)
+}
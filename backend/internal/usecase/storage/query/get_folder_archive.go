@@ -0,0 +1,92 @@
+package query
+
+import (
+	"context"
+	"io"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// GetFolderArchiveInput はフォルダアーカイブ取得の入力を定義します
+type GetFolderArchiveInput struct {
+	FolderID uuid.UUID
+	UserID   uuid.UUID
+}
+
+// GetFolderArchiveOutput はフォルダアーカイブ取得の出力を定義します
+// ZIPの内容は WriteZip を呼び出した時点でストレージからストリーミングされます
+type GetFolderArchiveOutput struct {
+	ArchiveName string
+	Entries     []service.ArchiveEntry
+
+	archiveService service.FolderArchiveService
+}
+
+// WriteZip はアーカイブをZIPとしてwに書き込みます
+func (o *GetFolderArchiveOutput) WriteZip(ctx context.Context, w io.Writer) error {
+	return o.archiveService.WriteZip(ctx, w, o.Entries)
+}
+
+// GetFolderArchiveQuery はフォルダをZIPアーカイブとしてダウンロードするクエリです
+type GetFolderArchiveQuery struct {
+	folderRepo         repository.FolderRepository
+	archiveService     service.FolderArchiveService
+	permissionResolver authz.PermissionResolver
+}
+
+// NewGetFolderArchiveQuery は新しいGetFolderArchiveQueryを作成します
+func NewGetFolderArchiveQuery(
+	folderRepo repository.FolderRepository,
+	archiveService service.FolderArchiveService,
+	permissionResolver authz.PermissionResolver,
+) *GetFolderArchiveQuery {
+	return &GetFolderArchiveQuery{
+		folderRepo:         folderRepo,
+		archiveService:     archiveService,
+		permissionResolver: permissionResolver,
+	}
+}
+
+// Execute はアーカイブに含めるエントリを収集します
+// 閲覧権限のないサブフォルダ・ファイルはアーカイブから除外されます
+func (q *GetFolderArchiveQuery) Execute(ctx context.Context, input GetFolderArchiveInput) (*GetFolderArchiveOutput, error) {
+	// 1. フォルダ取得
+	folder, err := q.folderRepo.FindByID(ctx, input.FolderID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. 権限チェック (folder:read)
+	hasPermission, err := q.permissionResolver.HasPermission(ctx, input.UserID, authz.ResourceTypeFolder, folder.ID, authz.PermFolderRead)
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, apperror.NewForbiddenError("not authorized to download this folder")
+	}
+
+	// 3. サブツリーの各アイテムの閲覧権限を確認しながらエントリを収集
+	entries, err := q.archiveService.CollectEntries(ctx, folder, service.ArchiveFilter{
+		Folder: func(ctx context.Context, f *entity.Folder) (bool, error) {
+			return q.permissionResolver.HasPermission(ctx, input.UserID, authz.ResourceTypeFolder, f.ID, authz.PermFolderRead)
+		},
+		File: func(ctx context.Context, f *entity.File) (bool, error) {
+			return q.permissionResolver.HasPermission(ctx, input.UserID, authz.ResourceTypeFile, f.ID, authz.PermFileRead)
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetFolderArchiveOutput{
+		ArchiveName:    folder.Name.String() + ".zip",
+		Entries:        entries,
+		archiveService: q.archiveService,
+	}, nil
+}
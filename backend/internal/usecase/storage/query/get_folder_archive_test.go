@@ -0,0 +1,115 @@
+package query_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/query"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
+)
+
+type getFolderArchiveTestDeps struct {
+	folderRepo         *mocks.MockFolderRepository
+	archiveService     *mocks.MockFolderArchiveService
+	permissionResolver *mocks.MockPermissionResolver
+}
+
+func newGetFolderArchiveTestDeps(t *testing.T) *getFolderArchiveTestDeps {
+	t.Helper()
+	return &getFolderArchiveTestDeps{
+		folderRepo:         mocks.NewMockFolderRepository(t),
+		archiveService:     mocks.NewMockFolderArchiveService(t),
+		permissionResolver: mocks.NewMockPermissionResolver(t),
+	}
+}
+
+func (d *getFolderArchiveTestDeps) newQuery() *query.GetFolderArchiveQuery {
+	return query.NewGetFolderArchiveQuery(d.folderRepo, d.archiveService, d.permissionResolver)
+}
+
+func TestGetFolderArchiveQuery_Execute_WithPermission_FiltersItemsByPermission(t *testing.T) {
+	ctx := context.Background()
+	deps := newGetFolderArchiveTestDeps(t)
+
+	userID := uuid.New()
+	folder := newQueryFolderEntity(uuid.New())
+	readable := newListContentFolderEntity(uuid.New())
+	hidden := newListContentFolderEntity(uuid.New())
+	file := newActiveFileForQuery(uuid.New(), folder.ID)
+	entries := []service.ArchiveEntry{{Path: "my-folder/"}}
+
+	deps.folderRepo.On("FindByID", ctx, folder.ID).Return(folder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFolder, folder.ID, authz.PermFolderRead).Return(true, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFolder, readable.ID, authz.PermFolderRead).Return(true, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFolder, hidden.ID, authz.PermFolderRead).Return(false, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFile, file.ID, authz.PermFileRead).Return(true, nil)
+	deps.archiveService.On("CollectEntries", ctx, folder, mock.Anything).
+		Run(func(args mock.Arguments) {
+			filter := args.Get(2).(service.ArchiveFilter)
+			ok, err := filter.Folder(ctx, readable)
+			assert.NoError(t, err)
+			assert.True(t, ok)
+			ok, err = filter.Folder(ctx, hidden)
+			assert.NoError(t, err)
+			assert.False(t, ok)
+			ok, err = filter.File(ctx, file)
+			assert.NoError(t, err)
+			assert.True(t, ok)
+		}).
+		Return(entries, nil)
+
+	output, err := deps.newQuery().Execute(ctx, query.GetFolderArchiveInput{FolderID: folder.ID, UserID: userID})
+
+	require.NoError(t, err)
+	assert.Equal(t, "my-folder.zip", output.ArchiveName)
+	assert.Equal(t, entries, output.Entries)
+
+	var buf bytes.Buffer
+	deps.archiveService.On("WriteZip", ctx, &buf, entries).Return(nil)
+	require.NoError(t, output.WriteZip(ctx, &buf))
+}
+
+func TestGetFolderArchiveQuery_Execute_NoPermission_ReturnsForbidden(t *testing.T) {
+	ctx := context.Background()
+	deps := newGetFolderArchiveTestDeps(t)
+
+	userID := uuid.New()
+	folder := newQueryFolderEntity(uuid.New())
+
+	deps.folderRepo.On("FindByID", ctx, folder.ID).Return(folder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFolder, folder.ID, authz.PermFolderRead).Return(false, nil)
+
+	output, err := deps.newQuery().Execute(ctx, query.GetFolderArchiveInput{FolderID: folder.ID, UserID: userID})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeForbidden, appErr.Code)
+}
+
+func TestGetFolderArchiveQuery_Execute_FolderNotFound_ReturnsNotFound(t *testing.T) {
+	ctx := context.Background()
+	deps := newGetFolderArchiveTestDeps(t)
+
+	folderID := uuid.New()
+	deps.folderRepo.On("FindByID", ctx, folderID).Return(nil, apperror.NewNotFoundError("folder"))
+
+	output, err := deps.newQuery().Execute(ctx, query.GetFolderArchiveInput{FolderID: folderID, UserID: uuid.New()})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeNotFound, appErr.Code)
+}
@@ -0,0 +1,87 @@
+package query
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+)
+
+// GetStorageUsageInput はストレージ使用量取得の入力を定義します
+type GetStorageUsageInput struct {
+	UserID uuid.UUID
+}
+
+// GroupStorageUsage は所属グループのクォータと使用量を表します
+type GroupStorageUsage struct {
+	GroupID    uuid.UUID
+	LimitBytes int64
+	UsedBytes  int64 // メンバー全員の使用量の合計
+}
+
+// GetStorageUsageOutput はストレージ使用量取得の出力を定義します
+type GetStorageUsageOutput struct {
+	UsedBytes  int64
+	LimitBytes int64 // 0は無制限
+	Groups     []GroupStorageUsage
+}
+
+// GetStorageUsageQuery はストレージ使用量取得クエリです
+type GetStorageUsageQuery struct {
+	quotaService service.StorageQuotaService
+	quotaRepo    repository.StorageQuotaRepository
+	usageRepo    repository.StorageUsageRepository
+}
+
+// NewGetStorageUsageQuery は新しいGetStorageUsageQueryを作成します
+func NewGetStorageUsageQuery(
+	quotaService service.StorageQuotaService,
+	quotaRepo repository.StorageQuotaRepository,
+	usageRepo repository.StorageUsageRepository,
+) *GetStorageUsageQuery {
+	return &GetStorageUsageQuery{
+		quotaService: quotaService,
+		quotaRepo:    quotaRepo,
+		usageRepo:    usageRepo,
+	}
+}
+
+// Execute はストレージ使用量取得を実行します
+func (q *GetStorageUsageQuery) Execute(ctx context.Context, input GetStorageUsageInput) (*GetStorageUsageOutput, error) {
+	// 1. 個人の使用量と上限
+	used, err := q.usageRepo.GetUsedBytes(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	limit, err := q.quotaService.GetUserLimit(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. 所属グループのクォータと使用量
+	groupQuotas, err := q.quotaRepo.FindGroupQuotasByUserID(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]GroupStorageUsage, 0, len(groupQuotas))
+	for _, quota := range groupQuotas {
+		groupUsed, err := q.usageRepo.SumUsedBytesByGroup(ctx, quota.SubjectID)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, GroupStorageUsage{
+			GroupID:    quota.SubjectID,
+			LimitBytes: quota.LimitBytes,
+			UsedBytes:  groupUsed,
+		})
+	}
+
+	return &GetStorageUsageOutput{
+		UsedBytes:  used,
+		LimitBytes: limit,
+		Groups:     groups,
+	}, nil
+}
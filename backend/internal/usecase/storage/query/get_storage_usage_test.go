@@ -0,0 +1,73 @@
+package query_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/query"
+	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
+)
+
+type getStorageUsageTestDeps struct {
+	quotaService *mocks.MockStorageQuotaService
+	quotaRepo    *mocks.MockStorageQuotaRepository
+	usageRepo    *mocks.MockStorageUsageRepository
+}
+
+func newGetStorageUsageTestDeps(t *testing.T) *getStorageUsageTestDeps {
+	t.Helper()
+	return &getStorageUsageTestDeps{
+		quotaService: mocks.NewMockStorageQuotaService(t),
+		quotaRepo:    mocks.NewMockStorageQuotaRepository(t),
+		usageRepo:    mocks.NewMockStorageUsageRepository(t),
+	}
+}
+
+func (d *getStorageUsageTestDeps) newQuery() *query.GetStorageUsageQuery {
+	return query.NewGetStorageUsageQuery(d.quotaService, d.quotaRepo, d.usageRepo)
+}
+
+func TestGetStorageUsageQuery_Execute_ReturnsPersonalAndGroupUsage(t *testing.T) {
+	ctx := context.Background()
+	deps := newGetStorageUsageTestDeps(t)
+
+	userID := uuid.New()
+	groupQuota, _ := entity.NewStorageQuota(entity.QuotaSubjectGroup, uuid.New(), 8192)
+
+	deps.usageRepo.On("GetUsedBytes", ctx, userID).Return(int64(512), nil)
+	deps.quotaService.On("GetUserLimit", ctx, userID).Return(int64(2048), nil)
+	deps.quotaRepo.On("FindGroupQuotasByUserID", ctx, userID).Return([]*entity.StorageQuota{groupQuota}, nil)
+	deps.usageRepo.On("SumUsedBytesByGroup", ctx, groupQuota.SubjectID).Return(int64(4096), nil)
+
+	output, err := deps.newQuery().Execute(ctx, query.GetStorageUsageInput{UserID: userID})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(512), output.UsedBytes)
+	assert.Equal(t, int64(2048), output.LimitBytes)
+	require.Len(t, output.Groups, 1)
+	assert.Equal(t, groupQuota.SubjectID, output.Groups[0].GroupID)
+	assert.Equal(t, int64(8192), output.Groups[0].LimitBytes)
+	assert.Equal(t, int64(4096), output.Groups[0].UsedBytes)
+}
+
+func TestGetStorageUsageQuery_Execute_RepositoryError_PropagatesError(t *testing.T) {
+	ctx := context.Background()
+	deps := newGetStorageUsageTestDeps(t)
+
+	userID := uuid.New()
+	dbErr := errors.New("db error")
+
+	deps.usageRepo.On("GetUsedBytes", ctx, userID).Return(int64(0), dbErr)
+
+	output, err := deps.newQuery().Execute(ctx, query.GetStorageUsageInput{UserID: userID})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, dbErr)
+}
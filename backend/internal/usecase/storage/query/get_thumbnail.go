@@ -0,0 +1,126 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// サムネイルURL有効期限
+const ThumbnailURLExpiry = 1 * time.Hour
+
+// GetThumbnailInput はサムネイル取得の入力を定義します
+type GetThumbnailInput struct {
+	FileID uuid.UUID
+	UserID uuid.UUID
+	Size   valueobject.ThumbnailSize
+}
+
+// GetThumbnailOutput はサムネイル取得の出力を定義します
+type GetThumbnailOutput struct {
+	FileID        uuid.UUID
+	VersionNumber int
+	Ready         bool   // falseの場合は生成中のため、時間をおいて再取得する
+	URL           string // Readyの場合のみ設定
+	ExpiresAt     time.Time
+}
+
+// GetThumbnailQuery はファイルの現在のバージョンのサムネイルを取得するクエリです
+type GetThumbnailQuery struct {
+	fileRepo           repository.FileRepository
+	fileVersionRepo    repository.FileVersionRepository
+	thumbnailRepo      repository.ThumbnailRepository
+	thumbnailService   service.ThumbnailService
+	storageService     service.StorageService
+	permissionResolver authz.PermissionResolver
+}
+
+// NewGetThumbnailQuery は新しいGetThumbnailQueryを作成します
+func NewGetThumbnailQuery(
+	fileRepo repository.FileRepository,
+	fileVersionRepo repository.FileVersionRepository,
+	thumbnailRepo repository.ThumbnailRepository,
+	thumbnailService service.ThumbnailService,
+	storageService service.StorageService,
+	permissionResolver authz.PermissionResolver,
+) *GetThumbnailQuery {
+	return &GetThumbnailQuery{
+		fileRepo:           fileRepo,
+		fileVersionRepo:    fileVersionRepo,
+		thumbnailRepo:      thumbnailRepo,
+		thumbnailService:   thumbnailService,
+		storageService:     storageService,
+		permissionResolver: permissionResolver,
+	}
+}
+
+// Execute はサムネイルのURLを取得します
+// アップロード以外で作成されたバージョン（復元・コピーなど）でサムネイルが未登録の場合は、ここで生成を登録します
+func (q *GetThumbnailQuery) Execute(ctx context.Context, input GetThumbnailInput) (*GetThumbnailOutput, error) {
+	// 1. ファイル取得
+	file, err := q.fileRepo.FindByID(ctx, input.FileID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. 権限チェック (file:read)
+	hasPermission, err := q.permissionResolver.HasPermission(ctx, input.UserID, authz.ResourceTypeFile, file.ID, authz.PermFileRead)
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, apperror.NewForbiddenError("not authorized to view this file")
+	}
+
+	// 3. サムネイルを持つのはアクティブな画像ファイルのみ
+	if !file.CanDownload() || !file.MimeType.IsImage() {
+		return nil, apperror.NewNotFoundError("thumbnail")
+	}
+
+	output := &GetThumbnailOutput{
+		FileID:        file.ID,
+		VersionNumber: file.CurrentVersion,
+	}
+
+	// 4. 現在のバージョンのサムネイル取得（未登録の場合は生成を登録）
+	thumbnail, err := q.thumbnailRepo.FindByFileAndVersion(ctx, file.ID, file.CurrentVersion)
+	if err != nil {
+		if !apperror.IsNotFound(err) {
+			return nil, err
+		}
+		version, err := q.fileVersionRepo.FindByFileAndVersion(ctx, file.ID, file.CurrentVersion)
+		if err != nil {
+			return nil, err
+		}
+		if err := q.thumbnailService.Schedule(ctx, file, version); err != nil {
+			return nil, err
+		}
+		return output, nil
+	}
+
+	switch thumbnail.Status {
+	case entity.ThumbnailStatusFailed:
+		return nil, apperror.NewNotFoundError("thumbnail")
+	case entity.ThumbnailStatusPending, entity.ThumbnailStatusRunning:
+		return output, nil
+	}
+
+	// 5. Presigned URL生成
+	presigned, err := q.storageService.GenerateGetURL(ctx, thumbnail.ObjectKey(input.Size), "", ThumbnailURLExpiry)
+	if err != nil {
+		return nil, apperror.NewInternalError(err)
+	}
+
+	output.Ready = true
+	output.URL = presigned.URL
+	output.ExpiresAt = presigned.ExpiresAt
+	return output, nil
+}
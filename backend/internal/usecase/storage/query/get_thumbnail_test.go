@@ -0,0 +1,194 @@
+package query_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/query"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
+)
+
+type getThumbnailTestDeps struct {
+	fileRepo           *mocks.MockFileRepository
+	fileVersionRepo    *mocks.MockFileVersionRepository
+	thumbnailRepo      *mocks.MockThumbnailRepository
+	thumbnailService   *mocks.MockThumbnailService
+	storageService     *mocks.MockStorageService
+	permissionResolver *mocks.MockPermissionResolver
+}
+
+func newGetThumbnailTestDeps(t *testing.T) *getThumbnailTestDeps {
+	t.Helper()
+	return &getThumbnailTestDeps{
+		fileRepo:           mocks.NewMockFileRepository(t),
+		fileVersionRepo:    mocks.NewMockFileVersionRepository(t),
+		thumbnailRepo:      mocks.NewMockThumbnailRepository(t),
+		thumbnailService:   mocks.NewMockThumbnailService(t),
+		storageService:     mocks.NewMockStorageService(t),
+		permissionResolver: mocks.NewMockPermissionResolver(t),
+	}
+}
+
+func (d *getThumbnailTestDeps) newQuery() *query.GetThumbnailQuery {
+	return query.NewGetThumbnailQuery(d.fileRepo, d.fileVersionRepo, d.thumbnailRepo, d.thumbnailService, d.storageService, d.permissionResolver)
+}
+
+func newActiveImageForQuery(ownerID uuid.UUID) *entity.File {
+	file := newActiveFileForQuery(ownerID, uuid.New())
+	file.MimeType = valueobject.MimeTypeImageJPEG
+	return file
+}
+
+func TestGetThumbnailQuery_Execute_Ready_ReturnsPresignedURLForSize(t *testing.T) {
+	ctx := context.Background()
+	deps := newGetThumbnailTestDeps(t)
+	ownerID := uuid.New()
+	file := newActiveImageForQuery(ownerID)
+	thumbnail := entity.NewThumbnail(newFileVersionForQuery(file.ID, 2))
+	thumbnail.MarkReady()
+	expiresAt := time.Now().Add(time.Hour)
+
+	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFile, file.ID, authz.PermFileRead).Return(true, nil)
+	deps.thumbnailRepo.On("FindByFileAndVersion", ctx, file.ID, 2).Return(thumbnail, nil)
+	deps.storageService.On("GenerateGetURL", ctx, thumbnail.ObjectKey(valueobject.ThumbnailSizeSmall), "", query.ThumbnailURLExpiry).
+		Return(&service.PresignedURL{URL: "https://minio/thumb-small", ExpiresAt: expiresAt}, nil)
+
+	output, err := deps.newQuery().Execute(ctx, query.GetThumbnailInput{
+		FileID: file.ID,
+		UserID: ownerID,
+		Size:   valueobject.ThumbnailSizeSmall,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, output.Ready)
+	assert.Equal(t, "https://minio/thumb-small", output.URL)
+	assert.Equal(t, expiresAt, output.ExpiresAt)
+	assert.Equal(t, 2, output.VersionNumber)
+}
+
+func TestGetThumbnailQuery_Execute_Pending_ReturnsNotReady(t *testing.T) {
+	ctx := context.Background()
+	deps := newGetThumbnailTestDeps(t)
+	ownerID := uuid.New()
+	file := newActiveImageForQuery(ownerID)
+	thumbnail := entity.NewThumbnail(newFileVersionForQuery(file.ID, 2))
+
+	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFile, file.ID, authz.PermFileRead).Return(true, nil)
+	deps.thumbnailRepo.On("FindByFileAndVersion", ctx, file.ID, 2).Return(thumbnail, nil)
+
+	output, err := deps.newQuery().Execute(ctx, query.GetThumbnailInput{
+		FileID: file.ID,
+		UserID: ownerID,
+		Size:   valueobject.ThumbnailSizeMedium,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, output.Ready)
+	assert.Empty(t, output.URL)
+	deps.storageService.AssertNotCalled(t, "GenerateGetURL", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetThumbnailQuery_Execute_NotScheduled_SchedulesCurrentVersion(t *testing.T) {
+	ctx := context.Background()
+	deps := newGetThumbnailTestDeps(t)
+	ownerID := uuid.New()
+	file := newActiveImageForQuery(ownerID)
+	version := newFileVersionForQuery(file.ID, 2)
+
+	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFile, file.ID, authz.PermFileRead).Return(true, nil)
+	deps.thumbnailRepo.On("FindByFileAndVersion", ctx, file.ID, 2).Return(nil, apperror.NewNotFoundError("thumbnail"))
+	deps.fileVersionRepo.On("FindByFileAndVersion", ctx, file.ID, 2).Return(version, nil)
+	deps.thumbnailService.On("Schedule", ctx, file, version).Return(nil)
+
+	output, err := deps.newQuery().Execute(ctx, query.GetThumbnailInput{
+		FileID: file.ID,
+		UserID: ownerID,
+		Size:   valueobject.ThumbnailSizeMedium,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, output.Ready)
+}
+
+func TestGetThumbnailQuery_Execute_Failed_ReturnsNotFound(t *testing.T) {
+	ctx := context.Background()
+	deps := newGetThumbnailTestDeps(t)
+	ownerID := uuid.New()
+	file := newActiveImageForQuery(ownerID)
+	thumbnail := entity.NewThumbnail(newFileVersionForQuery(file.ID, 2))
+	thumbnail.Fail("unsupported image format")
+
+	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFile, file.ID, authz.PermFileRead).Return(true, nil)
+	deps.thumbnailRepo.On("FindByFileAndVersion", ctx, file.ID, 2).Return(thumbnail, nil)
+
+	_, err := deps.newQuery().Execute(ctx, query.GetThumbnailInput{
+		FileID: file.ID,
+		UserID: ownerID,
+		Size:   valueobject.ThumbnailSizeMedium,
+	})
+
+	require.Error(t, err)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeNotFound, appErr.Code)
+}
+
+func TestGetThumbnailQuery_Execute_PDF_ReturnsNotFound(t *testing.T) {
+	ctx := context.Background()
+	deps := newGetThumbnailTestDeps(t)
+	ownerID := uuid.New()
+	file := newActiveFileForQuery(ownerID, uuid.New())
+	require.True(t, file.MimeType.IsPDF())
+
+	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFile, file.ID, authz.PermFileRead).Return(true, nil)
+
+	_, err := deps.newQuery().Execute(ctx, query.GetThumbnailInput{
+		FileID: file.ID,
+		UserID: ownerID,
+		Size:   valueobject.ThumbnailSizeMedium,
+	})
+
+	require.Error(t, err)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeNotFound, appErr.Code)
+	deps.thumbnailRepo.AssertNotCalled(t, "FindByFileAndVersion", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetThumbnailQuery_Execute_WithoutReadPermission_ReturnsForbidden(t *testing.T) {
+	ctx := context.Background()
+	deps := newGetThumbnailTestDeps(t)
+	file := newActiveImageForQuery(uuid.New())
+	userID := uuid.New()
+
+	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFile, file.ID, authz.PermFileRead).Return(false, nil)
+
+	_, err := deps.newQuery().Execute(ctx, query.GetThumbnailInput{
+		FileID: file.ID,
+		UserID: userID,
+		Size:   valueobject.ThumbnailSizeMedium,
+	})
+
+	require.Error(t, err)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeForbidden, appErr.Code)
+}
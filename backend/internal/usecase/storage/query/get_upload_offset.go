@@ -0,0 +1,83 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// GetUploadOffsetInput は受信済みバイト数取得の入力を定義します
+type GetUploadOffsetInput struct {
+	SessionID uuid.UUID
+	UserID    uuid.UUID
+}
+
+// GetUploadOffsetOutput は受信済みバイト数取得の出力を定義します
+type GetUploadOffsetOutput struct {
+	SessionID uuid.UUID
+	FileID    uuid.UUID
+	Offset    int64 // 受信済みバイト数（再開位置）
+	Length    int64 // アップロードする合計サイズ
+	ExpiresAt time.Time
+}
+
+// GetUploadOffsetQuery はレジューム可能なアップロードの再開位置を取得するクエリです
+type GetUploadOffsetQuery struct {
+	uploadSessionRepo      repository.UploadSessionRepository
+	resumableUploadService service.ResumableUploadService
+}
+
+// NewGetUploadOffsetQuery は新しいGetUploadOffsetQueryを作成します
+func NewGetUploadOffsetQuery(
+	uploadSessionRepo repository.UploadSessionRepository,
+	resumableUploadService service.ResumableUploadService,
+) *GetUploadOffsetQuery {
+	return &GetUploadOffsetQuery{
+		uploadSessionRepo:      uploadSessionRepo,
+		resumableUploadService: resumableUploadService,
+	}
+}
+
+// Execute は受信済みバイト数を取得します
+func (q *GetUploadOffsetQuery) Execute(ctx context.Context, input GetUploadOffsetInput) (*GetUploadOffsetOutput, error) {
+	// 1. セッション取得
+	session, err := q.uploadSessionRepo.FindByID(ctx, input.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. 所有者またはアップロード開始者のみ参照可能
+	if !session.IsOwnedBy(input.UserID) && !session.IsCreatedBy(input.UserID) {
+		return nil, apperror.NewForbiddenError("not authorized to view this upload session")
+	}
+
+	output := &GetUploadOffsetOutput{
+		SessionID: session.ID,
+		FileID:    session.FileID,
+		Length:    session.TotalSize,
+		ExpiresAt: session.ExpiresAt,
+	}
+
+	// 3. 完了済みは全バイト受信済み、中断・期限切れは再開不可
+	if session.IsCompleted() {
+		output.Offset = session.TotalSize
+		return output, nil
+	}
+	if !session.CanAcceptUpload() {
+		return nil, apperror.NewGoneError("upload session is no longer available")
+	}
+
+	// 4. 記録済みのパーツと書きかけのパートから受信済みバイト数を求める
+	progress, err := q.resumableUploadService.Progress(ctx, session)
+	if err != nil {
+		return nil, apperror.NewInternalError(err)
+	}
+	output.Offset = progress.Offset
+
+	return output, nil
+}
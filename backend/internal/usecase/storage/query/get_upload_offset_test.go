@@ -0,0 +1,102 @@
+package query_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/query"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
+)
+
+type getUploadOffsetTestDeps struct {
+	uploadSessionRepo      *mocks.MockUploadSessionRepository
+	resumableUploadService *mocks.MockResumableUploadService
+}
+
+func newGetUploadOffsetTestDeps(t *testing.T) *getUploadOffsetTestDeps {
+	t.Helper()
+	return &getUploadOffsetTestDeps{
+		uploadSessionRepo:      mocks.NewMockUploadSessionRepository(t),
+		resumableUploadService: mocks.NewMockResumableUploadService(t),
+	}
+}
+
+func (d *getUploadOffsetTestDeps) newQuery() *query.GetUploadOffsetQuery {
+	return query.NewGetUploadOffsetQuery(d.uploadSessionRepo, d.resumableUploadService)
+}
+
+func TestGetUploadOffsetQuery_Execute_InProgress_ReturnsReceivedBytes(t *testing.T) {
+	ctx := context.Background()
+	deps := newGetUploadOffsetTestDeps(t)
+
+	ownerID := uuid.New()
+	session := newPendingUploadSession(ownerID, uuid.New())
+
+	deps.uploadSessionRepo.On("FindByID", ctx, session.ID).Return(session, nil)
+	deps.resumableUploadService.On("Progress", ctx, session).Return(&service.ResumableUploadProgress{Offset: 512}, nil)
+
+	output, err := deps.newQuery().Execute(ctx, query.GetUploadOffsetInput{SessionID: session.ID, UserID: ownerID})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(512), output.Offset)
+	assert.Equal(t, int64(1024), output.Length)
+	assert.Equal(t, session.FileID, output.FileID)
+}
+
+func TestGetUploadOffsetQuery_Execute_Completed_ReturnsTotalSize(t *testing.T) {
+	ctx := context.Background()
+	deps := newGetUploadOffsetTestDeps(t)
+
+	ownerID := uuid.New()
+	session := newPendingUploadSession(ownerID, uuid.New())
+	require.NoError(t, session.Complete())
+
+	deps.uploadSessionRepo.On("FindByID", ctx, session.ID).Return(session, nil)
+
+	output, err := deps.newQuery().Execute(ctx, query.GetUploadOffsetInput{SessionID: session.ID, UserID: ownerID})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1024), output.Offset)
+}
+
+func TestGetUploadOffsetQuery_Execute_Aborted_ReturnsGone(t *testing.T) {
+	ctx := context.Background()
+	deps := newGetUploadOffsetTestDeps(t)
+
+	ownerID := uuid.New()
+	session := newPendingUploadSession(ownerID, uuid.New())
+	require.NoError(t, session.Abort())
+
+	deps.uploadSessionRepo.On("FindByID", ctx, session.ID).Return(session, nil)
+
+	output, err := deps.newQuery().Execute(ctx, query.GetUploadOffsetInput{SessionID: session.ID, UserID: ownerID})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	appErr, ok := err.(*apperror.AppError)
+	require.True(t, ok)
+	assert.Equal(t, apperror.CodeGone, appErr.Code)
+}
+
+func TestGetUploadOffsetQuery_Execute_OtherUser_ReturnsForbidden(t *testing.T) {
+	ctx := context.Background()
+	deps := newGetUploadOffsetTestDeps(t)
+
+	session := newPendingUploadSession(uuid.New(), uuid.New())
+
+	deps.uploadSessionRepo.On("FindByID", ctx, session.ID).Return(session, nil)
+
+	output, err := deps.newQuery().Execute(ctx, query.GetUploadOffsetInput{SessionID: session.ID, UserID: uuid.New()})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	appErr, ok := err.(*apperror.AppError)
+	require.True(t, ok)
+	assert.Equal(t, apperror.CodeForbidden, appErr.Code)
+}
@@ -49,8 +49,8 @@ func (q *GetUploadStatusQuery) Execute(ctx context.Context, input GetUploadStatu
 		return nil, err
 	}
 
-	// 2. 所有者チェック
-	if !session.IsOwnedBy(input.UserID) {
+	// 2. 所有者またはアップロード開始者のみ参照可能
+	if !session.IsOwnedBy(input.UserID) && !session.IsCreatedBy(input.UserID) {
 		return nil, apperror.NewForbiddenError("not authorized to view this upload session")
 	}
 
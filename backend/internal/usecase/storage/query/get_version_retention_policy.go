@@ -0,0 +1,76 @@
+package query
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// GetVersionRetentionPolicyInput はバージョン保持ポリシー取得の入力を定義します
+type GetVersionRetentionPolicyInput struct {
+	SubjectType entity.RetentionSubjectType
+	SubjectID   uuid.UUID
+	UserID      uuid.UUID
+}
+
+// GetVersionRetentionPolicyOutput はバージョン保持ポリシー取得の出力を定義します
+type GetVersionRetentionPolicyOutput struct {
+	SubjectType entity.RetentionSubjectType
+	SubjectID   uuid.UUID
+	Policy      *entity.VersionRetentionPolicy // 未設定の場合はnil
+}
+
+// GetVersionRetentionPolicyQuery はバージョン保持ポリシー取得クエリです
+type GetVersionRetentionPolicyQuery struct {
+	folderRepo repository.FolderRepository
+	policyRepo repository.VersionRetentionPolicyRepository
+}
+
+// NewGetVersionRetentionPolicyQuery は新しいGetVersionRetentionPolicyQueryを作成します
+func NewGetVersionRetentionPolicyQuery(
+	folderRepo repository.FolderRepository,
+	policyRepo repository.VersionRetentionPolicyRepository,
+) *GetVersionRetentionPolicyQuery {
+	return &GetVersionRetentionPolicyQuery{
+		folderRepo: folderRepo,
+		policyRepo: policyRepo,
+	}
+}
+
+// Execute はバージョン保持ポリシー取得を実行します
+func (q *GetVersionRetentionPolicyQuery) Execute(ctx context.Context, input GetVersionRetentionPolicyInput) (*GetVersionRetentionPolicyOutput, error) {
+	// 1. 対象への権限チェック（ユーザーは本人、フォルダは所有者のみ）
+	switch input.SubjectType {
+	case entity.RetentionSubjectUser:
+		if input.SubjectID != input.UserID {
+			return nil, apperror.NewForbiddenError("not authorized to view retention policy of another user")
+		}
+	case entity.RetentionSubjectFolder:
+		folder, err := q.folderRepo.FindByID(ctx, input.SubjectID)
+		if err != nil {
+			return nil, err
+		}
+		if !folder.IsOwnedBy(input.UserID) {
+			return nil, apperror.NewForbiddenError("not authorized to view retention policy of this folder")
+		}
+	default:
+		return nil, apperror.NewValidationError("invalid retention subject type", nil)
+	}
+
+	// 2. ポリシー取得
+	output := &GetVersionRetentionPolicyOutput{
+		SubjectType: input.SubjectType,
+		SubjectID:   input.SubjectID,
+	}
+	policy, err := q.policyRepo.FindBySubject(ctx, input.SubjectType, input.SubjectID)
+	if err != nil && !apperror.IsNotFound(err) {
+		return nil, err
+	}
+	output.Policy = policy
+
+	return output, nil
+}
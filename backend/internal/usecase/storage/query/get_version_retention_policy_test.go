@@ -0,0 +1,96 @@
+package query_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/query"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
+)
+
+type getVersionRetentionPolicyTestDeps struct {
+	folderRepo *mocks.MockFolderRepository
+	policyRepo *mocks.MockVersionRetentionPolicyRepository
+}
+
+func newGetVersionRetentionPolicyTestDeps(t *testing.T) *getVersionRetentionPolicyTestDeps {
+	t.Helper()
+	return &getVersionRetentionPolicyTestDeps{
+		folderRepo: mocks.NewMockFolderRepository(t),
+		policyRepo: mocks.NewMockVersionRetentionPolicyRepository(t),
+	}
+}
+
+func (d *getVersionRetentionPolicyTestDeps) newQuery() *query.GetVersionRetentionPolicyQuery {
+	return query.NewGetVersionRetentionPolicyQuery(d.folderRepo, d.policyRepo)
+}
+
+func TestGetVersionRetentionPolicyQuery_Execute_UserPolicy_ReturnsPolicy(t *testing.T) {
+	ctx := context.Background()
+	deps := newGetVersionRetentionPolicyTestDeps(t)
+
+	userID := uuid.New()
+	keepLastN := 10
+	policy, err := entity.NewVersionRetentionPolicy(entity.RetentionSubjectUser, userID, &keepLastN, nil)
+	require.NoError(t, err)
+
+	deps.policyRepo.On("FindBySubject", ctx, entity.RetentionSubjectUser, userID).Return(policy, nil)
+
+	output, err := deps.newQuery().Execute(ctx, query.GetVersionRetentionPolicyInput{
+		SubjectType: entity.RetentionSubjectUser,
+		SubjectID:   userID,
+		UserID:      userID,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, policy, output.Policy)
+}
+
+func TestGetVersionRetentionPolicyQuery_Execute_NoPolicy_ReturnsNilPolicy(t *testing.T) {
+	ctx := context.Background()
+	deps := newGetVersionRetentionPolicyTestDeps(t)
+
+	ownerID := uuid.New()
+	folder := newQueryFolderEntity(ownerID)
+
+	deps.folderRepo.On("FindByID", ctx, folder.ID).Return(folder, nil)
+	deps.policyRepo.On("FindBySubject", ctx, entity.RetentionSubjectFolder, folder.ID).Return(nil, apperror.NewNotFoundError("version retention policy"))
+
+	output, err := deps.newQuery().Execute(ctx, query.GetVersionRetentionPolicyInput{
+		SubjectType: entity.RetentionSubjectFolder,
+		SubjectID:   folder.ID,
+		UserID:      ownerID,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, folder.ID, output.SubjectID)
+	assert.Nil(t, output.Policy)
+}
+
+func TestGetVersionRetentionPolicyQuery_Execute_FolderNotOwned_ReturnsForbidden(t *testing.T) {
+	ctx := context.Background()
+	deps := newGetVersionRetentionPolicyTestDeps(t)
+
+	folder := newQueryFolderEntity(uuid.New())
+
+	deps.folderRepo.On("FindByID", ctx, folder.ID).Return(folder, nil)
+
+	output, err := deps.newQuery().Execute(ctx, query.GetVersionRetentionPolicyInput{
+		SubjectType: entity.RetentionSubjectFolder,
+		SubjectID:   folder.ID,
+		UserID:      uuid.New(),
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeForbidden, appErr.Code)
+}
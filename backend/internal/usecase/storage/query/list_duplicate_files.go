@@ -0,0 +1,64 @@
+package query
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+)
+
+// ListDuplicateFilesInput は重複ファイル一覧取得の入力を定義します
+type ListDuplicateFilesInput struct {
+	UserID uuid.UUID
+}
+
+// DuplicateFileGroup は同じ内容を持つファイルのまとまりを表します
+type DuplicateFileGroup struct {
+	Checksum string
+	Size     int64
+	Files    []*entity.File
+}
+
+// ListDuplicateFilesOutput は重複ファイル一覧取得の出力を定義します
+type ListDuplicateFilesOutput struct {
+	Groups []DuplicateFileGroup
+}
+
+// ListDuplicateFilesQuery は重複ファイル一覧取得クエリです
+type ListDuplicateFilesQuery struct {
+	blobRepo repository.BlobRepository
+}
+
+// NewListDuplicateFilesQuery は新しいListDuplicateFilesQueryを作成します
+func NewListDuplicateFilesQuery(blobRepo repository.BlobRepository) *ListDuplicateFilesQuery {
+	return &ListDuplicateFilesQuery{
+		blobRepo: blobRepo,
+	}
+}
+
+// Execute は重複ファイル一覧取得を実行します
+func (q *ListDuplicateFilesQuery) Execute(ctx context.Context, input ListDuplicateFilesInput) (*ListDuplicateFilesOutput, error) {
+	duplicates, err := q.blobRepo.FindDuplicateFilesByOwner(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// リポジトリはチェックサムごとに連続して返すため、隣接する行をまとめる
+	groups := make([]DuplicateFileGroup, 0)
+	for _, d := range duplicates {
+		last := len(groups) - 1
+		if last >= 0 && groups[last].Checksum == d.Checksum {
+			groups[last].Files = append(groups[last].Files, d.File)
+			continue
+		}
+		groups = append(groups, DuplicateFileGroup{
+			Checksum: d.Checksum,
+			Size:     d.File.Size,
+			Files:    []*entity.File{d.File},
+		})
+	}
+
+	return &ListDuplicateFilesOutput{Groups: groups}, nil
+}
@@ -0,0 +1,88 @@
+package query_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/query"
+	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
+)
+
+func newDuplicateTestFile(t *testing.T, ownerID uuid.UUID, name string, size int64) *entity.File {
+	t.Helper()
+	fileName, err := valueobject.NewFileName(name)
+	require.NoError(t, err)
+	mimeType, err := valueobject.NewMimeType("text/plain")
+	require.NoError(t, err)
+	fileID := uuid.New()
+	return entity.ReconstructFile(fileID, uuid.New(), ownerID, ownerID, fileName, mimeType, size, valueobject.NewStorageKey(fileID), 1, entity.FileStatusActive, time.Now(), time.Now())
+}
+
+func TestListDuplicateFilesQuery_Execute_GroupsFilesByChecksum(t *testing.T) {
+	ctx := context.Background()
+	blobRepo := mocks.NewMockBlobRepository(t)
+
+	userID := uuid.New()
+	large1 := newDuplicateTestFile(t, userID, "video.mp4", 4096)
+	large2 := newDuplicateTestFile(t, userID, "video-copy.mp4", 4096)
+	small1 := newDuplicateTestFile(t, userID, "a.txt", 10)
+	small2 := newDuplicateTestFile(t, userID, "b.txt", 10)
+	small3 := newDuplicateTestFile(t, userID, "c.txt", 10)
+
+	blobRepo.On("FindDuplicateFilesByOwner", ctx, userID).Return([]*repository.DuplicateFile{
+		{Checksum: "aaa", File: large1},
+		{Checksum: "aaa", File: large2},
+		{Checksum: "bbb", File: small1},
+		{Checksum: "bbb", File: small2},
+		{Checksum: "bbb", File: small3},
+	}, nil)
+
+	output, err := query.NewListDuplicateFilesQuery(blobRepo).Execute(ctx, query.ListDuplicateFilesInput{UserID: userID})
+
+	require.NoError(t, err)
+	require.Len(t, output.Groups, 2)
+	assert.Equal(t, "aaa", output.Groups[0].Checksum)
+	assert.Equal(t, int64(4096), output.Groups[0].Size)
+	assert.Equal(t, []*entity.File{large1, large2}, output.Groups[0].Files)
+	assert.Equal(t, "bbb", output.Groups[1].Checksum)
+	assert.Equal(t, int64(10), output.Groups[1].Size)
+	assert.Equal(t, []*entity.File{small1, small2, small3}, output.Groups[1].Files)
+}
+
+func TestListDuplicateFilesQuery_Execute_NoDuplicates_ReturnsEmptyGroups(t *testing.T) {
+	ctx := context.Background()
+	blobRepo := mocks.NewMockBlobRepository(t)
+
+	userID := uuid.New()
+	blobRepo.On("FindDuplicateFilesByOwner", ctx, userID).Return([]*repository.DuplicateFile{}, nil)
+
+	output, err := query.NewListDuplicateFilesQuery(blobRepo).Execute(ctx, query.ListDuplicateFilesInput{UserID: userID})
+
+	require.NoError(t, err)
+	assert.NotNil(t, output.Groups)
+	assert.Empty(t, output.Groups)
+}
+
+func TestListDuplicateFilesQuery_Execute_RepositoryError_PropagatesError(t *testing.T) {
+	ctx := context.Background()
+	blobRepo := mocks.NewMockBlobRepository(t)
+
+	userID := uuid.New()
+	dbErr := errors.New("db error")
+	blobRepo.On("FindDuplicateFilesByOwner", ctx, userID).Return(nil, dbErr)
+
+	output, err := query.NewListDuplicateFilesQuery(blobRepo).Execute(ctx, query.ListDuplicateFilesInput{UserID: userID})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, dbErr)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
 	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
 )
@@ -25,6 +26,7 @@ type FileVersionInfo struct {
 	UploadedBy    uuid.UUID
 	CreatedAt     time.Time
 	IsLatest      bool
+	IsPinned      bool
 }
 
 // ListFileVersionsOutput はファイルバージョン一覧の出力を定義します
@@ -36,18 +38,21 @@ type ListFileVersionsOutput struct {
 
 // ListFileVersionsQuery はファイルバージョン一覧クエリです
 type ListFileVersionsQuery struct {
-	fileRepo        repository.FileRepository
-	fileVersionRepo repository.FileVersionRepository
+	fileRepo           repository.FileRepository
+	fileVersionRepo    repository.FileVersionRepository
+	permissionResolver authz.PermissionResolver
 }
 
 // NewListFileVersionsQuery は新しいListFileVersionsQueryを作成します
 func NewListFileVersionsQuery(
 	fileRepo repository.FileRepository,
 	fileVersionRepo repository.FileVersionRepository,
+	permissionResolver authz.PermissionResolver,
 ) *ListFileVersionsQuery {
 	return &ListFileVersionsQuery{
-		fileRepo:        fileRepo,
-		fileVersionRepo: fileVersionRepo,
+		fileRepo:           fileRepo,
+		fileVersionRepo:    fileVersionRepo,
+		permissionResolver: permissionResolver,
 	}
 }
 
@@ -59,8 +64,12 @@ func (q *ListFileVersionsQuery) Execute(ctx context.Context, input ListFileVersi
 		return nil, err
 	}
 
-	// 2. 所有者チェック
-	if !file.IsOwnedBy(input.UserID) {
+	// 2. 権限チェック (file:read)
+	hasPermission, err := q.permissionResolver.HasPermission(ctx, input.UserID, authz.ResourceTypeFile, file.ID, authz.PermFileRead)
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
 		return nil, apperror.NewForbiddenError("not authorized to view file versions")
 	}
 
@@ -81,6 +90,7 @@ func (q *ListFileVersionsQuery) Execute(ctx context.Context, input ListFileVersi
 			UploadedBy:    v.UploadedBy,
 			CreatedAt:     v.CreatedAt,
 			IsLatest:      v.VersionNumber == file.CurrentVersion,
+			IsPinned:      v.IsPinned,
 		}
 	}
 
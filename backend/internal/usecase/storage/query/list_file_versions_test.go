@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/query"
@@ -18,20 +19,22 @@ import (
 )
 
 type listFileVersionsTestDeps struct {
-	fileRepo        *mocks.MockFileRepository
-	fileVersionRepo *mocks.MockFileVersionRepository
+	fileRepo           *mocks.MockFileRepository
+	fileVersionRepo    *mocks.MockFileVersionRepository
+	permissionResolver *mocks.MockPermissionResolver
 }
 
 func newListFileVersionsTestDeps(t *testing.T) *listFileVersionsTestDeps {
 	t.Helper()
 	return &listFileVersionsTestDeps{
-		fileRepo:        mocks.NewMockFileRepository(t),
-		fileVersionRepo: mocks.NewMockFileVersionRepository(t),
+		fileRepo:           mocks.NewMockFileRepository(t),
+		fileVersionRepo:    mocks.NewMockFileVersionRepository(t),
+		permissionResolver: mocks.NewMockPermissionResolver(t),
 	}
 }
 
 func (d *listFileVersionsTestDeps) newQuery() *query.ListFileVersionsQuery {
-	return query.NewListFileVersionsQuery(d.fileRepo, d.fileVersionRepo)
+	return query.NewListFileVersionsQuery(d.fileRepo, d.fileVersionRepo, d.permissionResolver)
 }
 
 func newActiveFileForVersionList(ownerID uuid.UUID) *entity.File {
@@ -55,8 +58,8 @@ func TestListFileVersionsQuery_Execute_TwoVersions_ReturnsVersionList(t *testing
 
 	uploaderID := uuid.New()
 	versions := []*entity.FileVersion{
-		entity.ReconstructFileVersion(uuid.New(), file.ID, 1, "mv1", 1024, "sha256:v1", uploaderID, time.Now().Add(-1*time.Hour)),
-		entity.ReconstructFileVersion(uuid.New(), file.ID, 2, "mv2", 2048, "sha256:v2", uploaderID, time.Now()),
+		entity.ReconstructFileVersion(uuid.New(), file.ID, 1, file.StorageKey, "mv1", nil, 1024, "sha256:v1", uploaderID, false, time.Now().Add(-1*time.Hour)),
+		entity.ReconstructFileVersion(uuid.New(), file.ID, 2, file.StorageKey, "mv2", nil, 2048, "sha256:v2", uploaderID, false, time.Now()),
 	}
 
 	input := query.ListFileVersionsInput{
@@ -65,6 +68,7 @@ func TestListFileVersionsQuery_Execute_TwoVersions_ReturnsVersionList(t *testing
 	}
 
 	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFile, file.ID, authz.PermFileRead).Return(true, nil)
 	deps.fileVersionRepo.On("FindByFileID", ctx, file.ID).Return(versions, nil)
 
 	q := deps.newQuery()
@@ -94,6 +98,7 @@ func TestListFileVersionsQuery_Execute_EmptyVersions_ReturnsEmptyList(t *testing
 	}
 
 	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFile, file.ID, authz.PermFileRead).Return(true, nil)
 	deps.fileVersionRepo.On("FindByFileID", ctx, file.ID).Return([]*entity.FileVersion{}, nil)
 
 	q := deps.newQuery()
@@ -105,7 +110,7 @@ func TestListFileVersionsQuery_Execute_EmptyVersions_ReturnsEmptyList(t *testing
 	assert.Empty(t, output.Versions)
 }
 
-func TestListFileVersionsQuery_Execute_NotOwner_ReturnsForbidden(t *testing.T) {
+func TestListFileVersionsQuery_Execute_WithoutReadPermission_ReturnsForbidden(t *testing.T) {
 	ctx := context.Background()
 	deps := newListFileVersionsTestDeps(t)
 
@@ -119,6 +124,7 @@ func TestListFileVersionsQuery_Execute_NotOwner_ReturnsForbidden(t *testing.T) {
 	}
 
 	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.permissionResolver.On("HasPermission", ctx, differentUserID, authz.ResourceTypeFile, file.ID, authz.PermFileRead).Return(false, nil)
 
 	q := deps.newQuery()
 	output, err := q.Execute(ctx, input)
@@ -5,6 +5,7 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
 	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
@@ -26,18 +27,21 @@ type ListFolderContentsOutput struct {
 
 // ListFolderContentsQuery はフォルダ内容一覧クエリです
 type ListFolderContentsQuery struct {
-	folderRepo repository.FolderRepository
-	fileRepo   repository.FileRepository
+	folderRepo         repository.FolderRepository
+	fileRepo           repository.FileRepository
+	permissionResolver authz.PermissionResolver
 }
 
 // NewListFolderContentsQuery は新しいListFolderContentsQueryを作成します
 func NewListFolderContentsQuery(
 	folderRepo repository.FolderRepository,
 	fileRepo repository.FileRepository,
+	permissionResolver authz.PermissionResolver,
 ) *ListFolderContentsQuery {
 	return &ListFolderContentsQuery{
-		folderRepo: folderRepo,
-		fileRepo:   fileRepo,
+		folderRepo:         folderRepo,
+		fileRepo:           fileRepo,
+		permissionResolver: permissionResolver,
 	}
 }
 
@@ -53,8 +57,12 @@ func (q *ListFolderContentsQuery) Execute(ctx context.Context, input ListFolderC
 			return nil, err
 		}
 
-		// 所有者チェック
-		if !folder.IsOwnedBy(input.UserID) {
+		// 権限チェック (folder:read)
+		hasPermission, err := q.permissionResolver.HasPermission(ctx, input.UserID, authz.ResourceTypeFolder, folder.ID, authz.PermFolderRead)
+		if err != nil {
+			return nil, err
+		}
+		if !hasPermission {
 			return nil, apperror.NewForbiddenError("not authorized to access this folder")
 		}
 
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/query"
@@ -18,20 +19,22 @@ import (
 )
 
 type listFolderContentsTestDeps struct {
-	folderRepo *mocks.MockFolderRepository
-	fileRepo   *mocks.MockFileRepository
+	folderRepo         *mocks.MockFolderRepository
+	fileRepo           *mocks.MockFileRepository
+	permissionResolver *mocks.MockPermissionResolver
 }
 
 func newListFolderContentsTestDeps(t *testing.T) *listFolderContentsTestDeps {
 	t.Helper()
 	return &listFolderContentsTestDeps{
-		folderRepo: mocks.NewMockFolderRepository(t),
-		fileRepo:   mocks.NewMockFileRepository(t),
+		folderRepo:         mocks.NewMockFolderRepository(t),
+		fileRepo:           mocks.NewMockFileRepository(t),
+		permissionResolver: mocks.NewMockPermissionResolver(t),
 	}
 }
 
 func (d *listFolderContentsTestDeps) newQuery() *query.ListFolderContentsQuery {
-	return query.NewListFolderContentsQuery(d.folderRepo, d.fileRepo)
+	return query.NewListFolderContentsQuery(d.folderRepo, d.fileRepo, d.permissionResolver)
 }
 
 func newListContentFolderEntity(ownerID uuid.UUID) *entity.Folder {
@@ -95,6 +98,7 @@ func TestListFolderContentsQuery_Execute_SpecificFolder_ReturnsFoldersAndFiles(t
 	}
 
 	deps.folderRepo.On("FindByID", ctx, folderID).Return(folder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFolder, folderID, authz.PermFolderRead).Return(true, nil)
 	deps.folderRepo.On("FindByParentID", ctx, &folderID, ownerID).Return([]*entity.Folder{subFolder}, nil)
 	deps.fileRepo.On("FindByFolderID", ctx, folderID).Return([]*entity.File{activeFile}, nil)
 
@@ -125,6 +129,7 @@ func TestListFolderContentsQuery_Execute_FiltersInactiveFiles(t *testing.T) {
 	}
 
 	deps.folderRepo.On("FindByID", ctx, folderID).Return(folder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, ownerID, authz.ResourceTypeFolder, folderID, authz.PermFolderRead).Return(true, nil)
 	deps.folderRepo.On("FindByParentID", ctx, &folderID, ownerID).Return([]*entity.Folder{}, nil)
 	deps.fileRepo.On("FindByFolderID", ctx, folderID).Return([]*entity.File{activeFile, uploadingFile}, nil)
 
@@ -137,6 +142,35 @@ func TestListFolderContentsQuery_Execute_FiltersInactiveFiles(t *testing.T) {
 	assert.Equal(t, activeFile.ID, output.Files[0].ID)
 }
 
+func TestListFolderContentsQuery_Execute_GrantedMember_ListsOwnersContents(t *testing.T) {
+	ctx := context.Background()
+	deps := newListFolderContentsTestDeps(t)
+
+	ownerID := uuid.New()
+	memberID := uuid.New()
+	folder := newListContentFolderEntity(ownerID)
+	activeFile := newListContentFileEntity(ownerID, folder.ID, entity.FileStatusActive)
+
+	folderID := folder.ID
+	input := query.ListFolderContentsInput{
+		FolderID: &folderID,
+		OwnerID:  memberID,
+		UserID:   memberID,
+	}
+
+	deps.folderRepo.On("FindByID", ctx, folderID).Return(folder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, memberID, authz.ResourceTypeFolder, folderID, authz.PermFolderRead).Return(true, nil)
+	deps.folderRepo.On("FindByParentID", ctx, &folderID, ownerID).Return([]*entity.Folder{}, nil)
+	deps.fileRepo.On("FindByFolderID", ctx, folderID).Return([]*entity.File{activeFile}, nil)
+
+	q := deps.newQuery()
+	output, err := q.Execute(ctx, input)
+
+	require.NoError(t, err)
+	assert.Equal(t, folder.ID, output.Folder.ID)
+	assert.Len(t, output.Files, 1)
+}
+
 func TestListFolderContentsQuery_Execute_FolderNotFound_PropagatesError(t *testing.T) {
 	ctx := context.Background()
 	deps := newListFolderContentsTestDeps(t)
@@ -162,7 +196,7 @@ func TestListFolderContentsQuery_Execute_FolderNotFound_PropagatesError(t *testi
 	assert.Equal(t, apperror.CodeNotFound, appErr.Code)
 }
 
-func TestListFolderContentsQuery_Execute_NotOwner_ReturnsForbidden(t *testing.T) {
+func TestListFolderContentsQuery_Execute_WithoutReadPermission_ReturnsForbidden(t *testing.T) {
 	ctx := context.Background()
 	deps := newListFolderContentsTestDeps(t)
 
@@ -178,6 +212,7 @@ func TestListFolderContentsQuery_Execute_NotOwner_ReturnsForbidden(t *testing.T)
 	}
 
 	deps.folderRepo.On("FindByID", ctx, folderID).Return(folder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, differentUserID, authz.ResourceTypeFolder, folderID, authz.PermFolderRead).Return(false, nil)
 
 	q := deps.newQuery()
 	output, err := q.Execute(ctx, input)
@@ -0,0 +1,348 @@
+package query
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// ページネーションのデフォルト値
+const (
+	DefaultSharedWithMeLimit = 50
+	MaxSharedWithMeLimit     = 100
+)
+
+// SharedWithMeSortKey は共有アイテム一覧の並び替えキーを定義します
+type SharedWithMeSortKey string
+
+const (
+	SharedWithMeSortBySharedAt SharedWithMeSortKey = "sharedAt"
+	SharedWithMeSortByName     SharedWithMeSortKey = "name"
+)
+
+// IsValid は並び替えキーが有効かを判定します
+func (k SharedWithMeSortKey) IsValid() bool {
+	return k == SharedWithMeSortBySharedAt || k == SharedWithMeSortByName
+}
+
+// SortOrder は並び順を定義します
+type SortOrder string
+
+const (
+	SortOrderAsc  SortOrder = "asc"
+	SortOrderDesc SortOrder = "desc"
+)
+
+// IsValid は並び順が有効かを判定します
+func (o SortOrder) IsValid() bool {
+	return o == SortOrderAsc || o == SortOrderDesc
+}
+
+// ListSharedWithMeInput は共有アイテム一覧の入力を定義します
+type ListSharedWithMeInput struct {
+	UserID    uuid.UUID
+	SortBy    SharedWithMeSortKey // デフォルト: sharedAt
+	SortOrder SortOrder           // デフォルト: sharedAtはdesc、nameはasc
+	Limit     int                 // 取得件数（デフォルト: 50, 最大: 100）
+	Cursor    string              // ページネーションカーソル（前回のNextCursor）
+}
+
+// SharedItem は自分に共有されたアイテムを定義します
+// Note: File / Folder はTypeに応じていずれか一方のみ設定される
+type SharedItem struct {
+	Type      authz.ResourceType
+	File      *entity.File
+	Folder    *entity.Folder
+	Role      authz.Role        // 直接付与とグループ経由の付与のうち最も高いロール
+	SharedVia authz.GranteeType // Roleを与えている付与の対象種別
+	SharedBy  uuid.UUID         // Roleを与えている付与の付与者
+	Grantor   *entity.User      // 付与者が削除済みの場合はnil
+	SharedAt  time.Time
+}
+
+// ID はアイテムのIDを返します
+func (i *SharedItem) ID() uuid.UUID {
+	if i.Type == authz.ResourceTypeFolder {
+		return i.Folder.ID
+	}
+	return i.File.ID
+}
+
+// Name はアイテムの名前を返します
+func (i *SharedItem) Name() string {
+	if i.Type == authz.ResourceTypeFolder {
+		return i.Folder.Name.String()
+	}
+	return i.File.Name.String()
+}
+
+// ListSharedWithMeOutput は共有アイテム一覧の出力を定義します
+type ListSharedWithMeOutput struct {
+	Items      []*SharedItem
+	NextCursor *string // 次ページのカーソル
+	HasMore    bool    // 次ページが存在するか
+}
+
+// ListSharedWithMeQuery は他のユーザーから共有されたファイル・フォルダの一覧クエリです
+// 直接付与とグループ経由の付与をリソースごとにまとめ、最も高いロールを有効ロールとして返します
+// Note: 付与はユーザー単位で件数が限られるため、リソースの解決と並び替えはメモリ上で行う
+type ListSharedWithMeQuery struct {
+	permissionGrantRepo authz.PermissionGrantRepository
+	membershipRepo      repository.MembershipRepository
+	fileRepo            repository.FileRepository
+	folderRepo          repository.FolderRepository
+	userRepo            repository.UserRepository
+}
+
+// NewListSharedWithMeQuery は新しいListSharedWithMeQueryを作成します
+func NewListSharedWithMeQuery(
+	permissionGrantRepo authz.PermissionGrantRepository,
+	membershipRepo repository.MembershipRepository,
+	fileRepo repository.FileRepository,
+	folderRepo repository.FolderRepository,
+	userRepo repository.UserRepository,
+) *ListSharedWithMeQuery {
+	return &ListSharedWithMeQuery{
+		permissionGrantRepo: permissionGrantRepo,
+		membershipRepo:      membershipRepo,
+		fileRepo:            fileRepo,
+		folderRepo:          folderRepo,
+		userRepo:            userRepo,
+	}
+}
+
+// Execute は共有アイテム一覧を取得します
+func (q *ListSharedWithMeQuery) Execute(ctx context.Context, input ListSharedWithMeInput) (*ListSharedWithMeOutput, error) {
+	// 1. 入力のバリデーションと正規化
+	sortBy := input.SortBy
+	if sortBy == "" {
+		sortBy = SharedWithMeSortBySharedAt
+	}
+	if !sortBy.IsValid() {
+		return nil, apperror.NewValidationError("invalid sort key", nil)
+	}
+	sortOrder := input.SortOrder
+	if sortOrder == "" {
+		sortOrder = SortOrderDesc
+		if sortBy == SharedWithMeSortByName {
+			sortOrder = SortOrderAsc
+		}
+	}
+	if !sortOrder.IsValid() {
+		return nil, apperror.NewValidationError("invalid sort order", nil)
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = DefaultSharedWithMeLimit
+	}
+	if limit > MaxSharedWithMeLimit {
+		limit = MaxSharedWithMeLimit
+	}
+
+	cursor, err := decodeSharedWithMeCursor(input.Cursor)
+	if err != nil {
+		return nil, apperror.NewValidationError("invalid cursor", nil)
+	}
+
+	// 2. 直接付与とグループ経由の付与を収集
+	grants, err := q.permissionGrantRepo.FindByGrantee(ctx, authz.GranteeTypeUser, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	memberships, err := q.membershipRepo.FindByUserID(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	for _, membership := range memberships {
+		groupGrants, err := q.permissionGrantRepo.FindByGrantee(ctx, authz.GranteeTypeGroup, membership.GroupID)
+		if err != nil {
+			return nil, err
+		}
+		grants = append(grants, groupGrants...)
+	}
+
+	// 3. リソースごとに最も高いロールの付与を選択
+	effective := make(map[authz.Resource]*authz.PermissionGrant)
+	resources := make([]authz.Resource, 0, len(grants))
+	for _, grant := range grants {
+		key := authz.Resource{Type: grant.ResourceType, ID: grant.ResourceID}
+		current, ok := effective[key]
+		if !ok {
+			effective[key] = grant
+			resources = append(resources, key)
+			continue
+		}
+		if grant.Role.Level() > current.Role.Level() ||
+			(grant.Role.Level() == current.Role.Level() && grant.GrantedAt.Before(current.GrantedAt)) {
+			effective[key] = grant
+		}
+	}
+
+	// 4. リソースを解決（削除済み・自分が所有するアイテムは除外）
+	items := make([]*SharedItem, 0, len(resources))
+	for _, resource := range resources {
+		item, err := q.resolveItem(ctx, input.UserID, effective[resource])
+		if err != nil {
+			return nil, err
+		}
+		if item != nil {
+			items = append(items, item)
+		}
+	}
+
+	// 5. 並び替えてカーソル以降を切り出す
+	before := sharedItemBefore(sortBy, sortOrder)
+	sort.Slice(items, func(i, j int) bool {
+		return before(items[i].sortKey(), items[j].sortKey())
+	})
+	if cursor != nil {
+		start := 0
+		for start < len(items) && !before(*cursor, items[start].sortKey()) {
+			start++
+		}
+		items = items[start:]
+	}
+
+	// 6. 次ページの存在確認とカーソル設定
+	output := &ListSharedWithMeOutput{Items: items}
+	if len(items) > limit {
+		output.Items = items[:limit]
+		output.HasMore = true
+		next := encodeSharedWithMeCursor(output.Items[limit-1].sortKey())
+		output.NextCursor = &next
+	}
+
+	// 7. ページ内のアイテムの付与者を取得
+	grantors := make(map[uuid.UUID]*entity.User)
+	for _, item := range output.Items {
+		grantor, ok := grantors[item.SharedBy]
+		if !ok {
+			grantor, err = q.userRepo.FindByID(ctx, item.SharedBy)
+			if err != nil && !apperror.IsNotFound(err) {
+				return nil, err
+			}
+			grantors[item.SharedBy] = grantor
+		}
+		item.Grantor = grantor
+	}
+
+	return output, nil
+}
+
+// resolveItem は付与対象のリソースを取得して共有アイテムに変換します
+// 閲覧対象として表示しないリソースの場合はnilを返します
+func (q *ListSharedWithMeQuery) resolveItem(ctx context.Context, userID uuid.UUID, grant *authz.PermissionGrant) (*SharedItem, error) {
+	item := &SharedItem{
+		Type:      grant.ResourceType,
+		Role:      grant.Role,
+		SharedVia: grant.GranteeType,
+		SharedBy:  grant.GrantedBy,
+		SharedAt:  grant.GrantedAt,
+	}
+
+	if grant.ResourceType == authz.ResourceTypeFolder {
+		folder, err := q.folderRepo.FindByID(ctx, grant.ResourceID)
+		if err != nil {
+			if apperror.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		if folder.IsOwnedBy(userID) {
+			return nil, nil
+		}
+		item.Folder = folder
+		return item, nil
+	}
+
+	file, err := q.fileRepo.FindByID(ctx, grant.ResourceID)
+	if err != nil {
+		if apperror.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !file.IsActive() || file.IsOwnedBy(userID) {
+		return nil, nil
+	}
+	item.File = file
+	return item, nil
+}
+
+// sharedItemKey は共有アイテムの並び替えとカーソルに使うキーです
+type sharedItemKey struct {
+	sharedAt time.Time
+	id       uuid.UUID
+	name     string
+}
+
+// sortKey はアイテムの並び替えキーを返します
+func (i *SharedItem) sortKey() sharedItemKey {
+	return sharedItemKey{sharedAt: i.SharedAt, id: i.ID(), name: i.Name()}
+}
+
+// sharedItemBefore は並び替え条件でaがbより前に来るかを判定する関数を返します
+// 同順位の場合はIDで順序を確定させます
+func sharedItemBefore(sortBy SharedWithMeSortKey, sortOrder SortOrder) func(a, b sharedItemKey) bool {
+	return func(a, b sharedItemKey) bool {
+		cmp := 0
+		switch sortBy {
+		case SharedWithMeSortByName:
+			cmp = strings.Compare(strings.ToLower(a.name), strings.ToLower(b.name))
+		default:
+			cmp = a.sharedAt.Compare(b.sharedAt)
+		}
+		if cmp == 0 {
+			cmp = bytes.Compare(a.id[:], b.id[:])
+		}
+		if sortOrder == SortOrderDesc {
+			return cmp > 0
+		}
+		return cmp < 0
+	}
+}
+
+// encodeSharedWithMeCursor は並び替えキーを不透明なカーソル文字列に変換します
+// 並び替え条件に関わらず比較できるよう、共有日時・ID・名前をすべて含めます
+func encodeSharedWithMeCursor(key sharedItemKey) string {
+	raw := strconv.FormatInt(key.sharedAt.UnixNano(), 10) + "_" + key.id.String() + "_" + key.name
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeSharedWithMeCursor は文字列から並び替えキーを復元します（空文字列の場合はnil）
+func decodeSharedWithMeCursor(s string) (*sharedItemKey, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	parts := strings.SplitN(string(raw), "_", 3)
+	if len(parts) != 3 {
+		return nil, apperror.NewValidationError("invalid cursor", nil)
+	}
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	id, err := uuid.Parse(parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return &sharedItemKey{sharedAt: time.Unix(0, nanos), id: id, name: parts[2]}, nil
+}
@@ -0,0 +1,213 @@
+package query_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/query"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
+)
+
+type listSharedWithMeTestDeps struct {
+	permissionGrantRepo *mocks.MockPermissionGrantRepository
+	membershipRepo      *mocks.MockMembershipRepository
+	fileRepo            *mocks.MockFileRepository
+	folderRepo          *mocks.MockFolderRepository
+	userRepo            *mocks.MockUserRepository
+}
+
+func newListSharedWithMeTestDeps(t *testing.T) *listSharedWithMeTestDeps {
+	t.Helper()
+	return &listSharedWithMeTestDeps{
+		permissionGrantRepo: mocks.NewMockPermissionGrantRepository(t),
+		membershipRepo:      mocks.NewMockMembershipRepository(t),
+		fileRepo:            mocks.NewMockFileRepository(t),
+		folderRepo:          mocks.NewMockFolderRepository(t),
+		userRepo:            mocks.NewMockUserRepository(t),
+	}
+}
+
+func (d *listSharedWithMeTestDeps) newQuery() *query.ListSharedWithMeQuery {
+	return query.NewListSharedWithMeQuery(d.permissionGrantRepo, d.membershipRepo, d.fileRepo, d.folderRepo, d.userRepo)
+}
+
+func newSharedFolderEntity(ownerID uuid.UUID, name string) *entity.Folder {
+	folderName, _ := valueobject.NewFolderName(name)
+	return entity.ReconstructFolder(
+		uuid.New(), folderName, nil, ownerID, ownerID, 0,
+		entity.FolderStatusActive, time.Now(), time.Now(),
+	)
+}
+
+func newSharedGrant(resourceType authz.ResourceType, resourceID uuid.UUID, granteeType authz.GranteeType, granteeID uuid.UUID, role authz.Role, grantedBy uuid.UUID, grantedAt time.Time) *authz.PermissionGrant {
+	return authz.ReconstructPermissionGrant(uuid.New(), resourceType, resourceID, granteeType, granteeID, role, grantedBy, grantedAt)
+}
+
+func newGrantorUser(id uuid.UUID) *entity.User {
+	email, _ := valueobject.NewEmail("grantor@example.com")
+	return &entity.User{ID: id, Email: email, Name: "Grantor", Status: entity.UserStatusActive}
+}
+
+func TestListSharedWithMeQuery_Execute_DirectAndGroupGrants_ReturnsHighestRoleWithGrantor(t *testing.T) {
+	ctx := context.Background()
+	deps := newListSharedWithMeTestDeps(t)
+
+	userID := uuid.New()
+	ownerID := uuid.New()
+	groupID := uuid.New()
+	folder := newSharedFolderEntity(ownerID, "Team")
+	file := newActiveFileForQuery(ownerID, uuid.New())
+	now := time.Now()
+
+	userGrants := []*authz.PermissionGrant{
+		newSharedGrant(authz.ResourceTypeFolder, folder.ID, authz.GranteeTypeUser, userID, authz.RoleViewer, ownerID, now.Add(-2*time.Hour)),
+		newSharedGrant(authz.ResourceTypeFile, file.ID, authz.GranteeTypeUser, userID, authz.RoleViewer, ownerID, now.Add(-1*time.Hour)),
+	}
+	groupGrants := []*authz.PermissionGrant{
+		newSharedGrant(authz.ResourceTypeFolder, folder.ID, authz.GranteeTypeGroup, groupID, authz.RoleContributor, ownerID, now.Add(-3*time.Hour)),
+	}
+	grantor := newGrantorUser(ownerID)
+
+	deps.permissionGrantRepo.On("FindByGrantee", ctx, authz.GranteeTypeUser, userID).Return(userGrants, nil)
+	deps.membershipRepo.On("FindByUserID", ctx, userID).Return([]*entity.Membership{
+		entity.NewMembership(groupID, userID, valueobject.GroupRoleViewer),
+	}, nil)
+	deps.permissionGrantRepo.On("FindByGrantee", ctx, authz.GranteeTypeGroup, groupID).Return(groupGrants, nil)
+	deps.folderRepo.On("FindByID", ctx, folder.ID).Return(folder, nil)
+	deps.fileRepo.On("FindByID", ctx, file.ID).Return(file, nil)
+	deps.userRepo.On("FindByID", ctx, ownerID).Return(grantor, nil).Once()
+
+	output, err := deps.newQuery().Execute(ctx, query.ListSharedWithMeInput{UserID: userID})
+
+	require.NoError(t, err)
+	require.Len(t, output.Items, 2)
+	assert.False(t, output.HasMore)
+	assert.Nil(t, output.NextCursor)
+
+	// 共有日時の新しい順
+	assert.Equal(t, file.ID, output.Items[0].ID())
+	assert.Equal(t, authz.RoleViewer, output.Items[0].Role)
+	assert.Equal(t, authz.GranteeTypeUser, output.Items[0].SharedVia)
+
+	assert.Equal(t, folder.ID, output.Items[1].ID())
+	assert.Equal(t, authz.RoleContributor, output.Items[1].Role)
+	assert.Equal(t, authz.GranteeTypeGroup, output.Items[1].SharedVia)
+	assert.Equal(t, ownerID, output.Items[1].SharedBy)
+	assert.Same(t, grantor, output.Items[1].Grantor)
+}
+
+func TestListSharedWithMeQuery_Execute_SkipsOwnedMissingAndInactiveItems(t *testing.T) {
+	ctx := context.Background()
+	deps := newListSharedWithMeTestDeps(t)
+
+	userID := uuid.New()
+	ownerID := uuid.New()
+	ownFolder := newSharedFolderEntity(userID, "Mine")
+	uploadingFile := newUploadingFileForQuery(ownerID, uuid.New())
+	missingFolderID := uuid.New()
+	now := time.Now()
+
+	deps.permissionGrantRepo.On("FindByGrantee", ctx, authz.GranteeTypeUser, userID).Return([]*authz.PermissionGrant{
+		newSharedGrant(authz.ResourceTypeFolder, ownFolder.ID, authz.GranteeTypeUser, userID, authz.RoleViewer, ownerID, now),
+		newSharedGrant(authz.ResourceTypeFile, uploadingFile.ID, authz.GranteeTypeUser, userID, authz.RoleViewer, ownerID, now),
+		newSharedGrant(authz.ResourceTypeFolder, missingFolderID, authz.GranteeTypeUser, userID, authz.RoleViewer, ownerID, now),
+	}, nil)
+	deps.membershipRepo.On("FindByUserID", ctx, userID).Return([]*entity.Membership{}, nil)
+	deps.folderRepo.On("FindByID", ctx, ownFolder.ID).Return(ownFolder, nil)
+	deps.fileRepo.On("FindByID", ctx, uploadingFile.ID).Return(uploadingFile, nil)
+	deps.folderRepo.On("FindByID", ctx, missingFolderID).Return(nil, apperror.NewNotFoundError("folder"))
+
+	output, err := deps.newQuery().Execute(ctx, query.ListSharedWithMeInput{UserID: userID})
+
+	require.NoError(t, err)
+	assert.Empty(t, output.Items)
+	deps.userRepo.AssertNotCalled(t, "FindByID")
+}
+
+func TestListSharedWithMeQuery_Execute_SortByNameWithCursor_ReturnsNextPage(t *testing.T) {
+	ctx := context.Background()
+	deps := newListSharedWithMeTestDeps(t)
+
+	userID := uuid.New()
+	ownerID := uuid.New()
+	alpha := newSharedFolderEntity(ownerID, "alpha")
+	bravo := newSharedFolderEntity(ownerID, "Bravo")
+	charlie := newSharedFolderEntity(ownerID, "charlie")
+	now := time.Now()
+
+	deps.permissionGrantRepo.On("FindByGrantee", ctx, authz.GranteeTypeUser, userID).Return([]*authz.PermissionGrant{
+		newSharedGrant(authz.ResourceTypeFolder, charlie.ID, authz.GranteeTypeUser, userID, authz.RoleViewer, ownerID, now),
+		newSharedGrant(authz.ResourceTypeFolder, alpha.ID, authz.GranteeTypeUser, userID, authz.RoleViewer, ownerID, now),
+		newSharedGrant(authz.ResourceTypeFolder, bravo.ID, authz.GranteeTypeUser, userID, authz.RoleViewer, ownerID, now),
+	}, nil)
+	deps.membershipRepo.On("FindByUserID", ctx, userID).Return([]*entity.Membership{}, nil)
+	deps.folderRepo.On("FindByID", ctx, alpha.ID).Return(alpha, nil)
+	deps.folderRepo.On("FindByID", ctx, bravo.ID).Return(bravo, nil)
+	deps.folderRepo.On("FindByID", ctx, charlie.ID).Return(charlie, nil)
+	deps.userRepo.On("FindByID", ctx, ownerID).Return(newGrantorUser(ownerID), nil)
+
+	first, err := deps.newQuery().Execute(ctx, query.ListSharedWithMeInput{
+		UserID: userID,
+		SortBy: query.SharedWithMeSortByName,
+		Limit:  2,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, first.Items, 2)
+	assert.Equal(t, alpha.ID, first.Items[0].ID())
+	assert.Equal(t, bravo.ID, first.Items[1].ID())
+	assert.True(t, first.HasMore)
+	require.NotNil(t, first.NextCursor)
+
+	second, err := deps.newQuery().Execute(ctx, query.ListSharedWithMeInput{
+		UserID: userID,
+		SortBy: query.SharedWithMeSortByName,
+		Limit:  2,
+		Cursor: *first.NextCursor,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, second.Items, 1)
+	assert.Equal(t, charlie.ID, second.Items[0].ID())
+	assert.False(t, second.HasMore)
+}
+
+func TestListSharedWithMeQuery_Execute_InvalidSortKey_ReturnsValidationError(t *testing.T) {
+	ctx := context.Background()
+	deps := newListSharedWithMeTestDeps(t)
+
+	_, err := deps.newQuery().Execute(ctx, query.ListSharedWithMeInput{
+		UserID: uuid.New(),
+		SortBy: query.SharedWithMeSortKey("size"),
+	})
+
+	require.Error(t, err)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeValidationError, appErr.Code)
+}
+
+func TestListSharedWithMeQuery_Execute_InvalidCursor_ReturnsValidationError(t *testing.T) {
+	ctx := context.Background()
+	deps := newListSharedWithMeTestDeps(t)
+
+	_, err := deps.newQuery().Execute(ctx, query.ListSharedWithMeInput{
+		UserID: uuid.New(),
+		Cursor: "not-a-cursor",
+	})
+
+	require.Error(t, err)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeValidationError, appErr.Code)
+}
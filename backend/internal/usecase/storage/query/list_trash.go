@@ -1,11 +1,13 @@
 package query
 
 import (
+	"bytes"
 	"context"
 	"time"
 
 	"github.com/google/uuid"
 
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
 	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
 )
@@ -21,14 +23,25 @@ type ListTrashInput struct {
 	OwnerID uuid.UUID
 	UserID  uuid.UUID
 	Limit   int        // 取得件数（デフォルト: 50, 最大: 100）
-	Cursor  *uuid.UUID // ページネーションカーソル（前回の最後のID）
+	Cursor  *uuid.UUID // ページネーションカーソル（前回の最後のアイテムのID）
 }
 
+// TrashItemType はゴミ箱アイテムの種別を定義します
+type TrashItemType string
+
+const (
+	TrashItemTypeFile   TrashItemType = "file"
+	TrashItemTypeFolder TrashItemType = "folder"
+)
+
 // TrashItem はゴミ箱内のアイテム情報を定義します
+// フォルダの場合、OriginalFileIDはuuid.Nil、OriginalFolderIDは元のフォルダIDです
 type TrashItem struct {
 	ID               uuid.UUID
+	Type             TrashItemType
 	OriginalFileID   uuid.UUID
-	OriginalFolderID uuid.UUID // 必須 - 復元先フォルダID
+	OriginalFolderID uuid.UUID  // ファイル: 復元先フォルダID / フォルダ: 元のフォルダID
+	OriginalParentID *uuid.UUID // フォルダのみ: 復元先の親フォルダID（ルートフォルダの場合はnil）
 	OriginalPath     string
 	Name             string
 	MimeType         string
@@ -46,14 +59,20 @@ type ListTrashOutput struct {
 }
 
 // ListTrashQuery はゴミ箱一覧クエリです
+// 単独でゴミ箱に移動されたファイルと、サブツリーごとゴミ箱に移動されたフォルダを削除日時の新しい順に返します
 type ListTrashQuery struct {
-	archivedFileRepo repository.ArchivedFileRepository
+	archivedFileRepo   repository.ArchivedFileRepository
+	archivedFolderRepo repository.ArchivedFolderRepository
 }
 
 // NewListTrashQuery は新しいListTrashQueryを作成します
-func NewListTrashQuery(archivedFileRepo repository.ArchivedFileRepository) *ListTrashQuery {
+func NewListTrashQuery(
+	archivedFileRepo repository.ArchivedFileRepository,
+	archivedFolderRepo repository.ArchivedFolderRepository,
+) *ListTrashQuery {
 	return &ListTrashQuery{
-		archivedFileRepo: archivedFileRepo,
+		archivedFileRepo:   archivedFileRepo,
+		archivedFolderRepo: archivedFolderRepo,
 	}
 }
 
@@ -73,36 +92,49 @@ func (q *ListTrashQuery) Execute(ctx context.Context, input ListTrashInput) (*Li
 		limit = MaxTrashLimit
 	}
 
-	// 3. アーカイブファイル一覧を取得（1件多く取得して次ページの存在を確認）
-	archivedFiles, err := q.archivedFileRepo.FindByOwnerWithPagination(ctx, input.OwnerID, limit+1, input.Cursor)
+	// 3. カーソルのアイテムから並び順上の位置を解決
+	var cursor *repository.TrashCursor
+	if input.Cursor != nil {
+		resolved, err := q.resolveCursor(ctx, input.OwnerID, *input.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		cursor = resolved
+	}
+
+	// 4. ファイルとフォルダをそれぞれ取得（1件多く取得して次ページの存在を確認）
+	archivedFiles, err := q.archivedFileRepo.FindByOwnerWithPagination(ctx, input.OwnerID, limit+1, cursor)
+	if err != nil {
+		return nil, err
+	}
+	archivedFolders, err := q.archivedFolderRepo.FindByOwnerWithPagination(ctx, input.OwnerID, limit+1, cursor)
 	if err != nil {
 		return nil, err
 	}
 
-	// 4. 次ページの存在確認
-	hasMore := len(archivedFiles) > limit
-	if hasMore {
-		archivedFiles = archivedFiles[:limit]
-	}
-
-	// 5. 出力形式に変換
-	items := make([]TrashItem, len(archivedFiles))
-	for i, af := range archivedFiles {
-		items[i] = TrashItem{
-			ID:               af.ID,
-			OriginalFileID:   af.OriginalFileID,
-			OriginalFolderID: af.OriginalFolderID,
-			OriginalPath:     af.OriginalPath,
-			Name:             af.Name.String(),
-			MimeType:         af.MimeType.String(),
-			Size:             af.Size,
-			ArchivedAt:       af.ArchivedAt,
-			ExpiresAt:        af.ExpiresAt,
-			DaysUntilExpiry:  af.DaysUntilExpiration(),
+	// 5. 削除日時の新しい順にマージ
+	items := make([]TrashItem, 0, len(archivedFiles)+len(archivedFolders))
+	fi, di := 0, 0
+	for fi < len(archivedFiles) || di < len(archivedFolders) {
+		if di >= len(archivedFolders) || (fi < len(archivedFiles) && trashItemBefore(
+			archivedFiles[fi].ArchivedAt, archivedFiles[fi].ID,
+			archivedFolders[di].ArchivedAt, archivedFolders[di].ID,
+		)) {
+			items = append(items, toFileTrashItem(archivedFiles[fi]))
+			fi++
+		} else {
+			items = append(items, toFolderTrashItem(archivedFolders[di]))
+			di++
 		}
 	}
 
-	// 6. 次ページのカーソルを設定
+	// 6. 次ページの存在確認
+	hasMore := len(items) > limit
+	if hasMore {
+		items = items[:limit]
+	}
+
+	// 7. 次ページのカーソルを設定
 	var nextCursor *uuid.UUID
 	if hasMore && len(items) > 0 {
 		lastID := items[len(items)-1].ID
@@ -115,3 +147,64 @@ func (q *ListTrashQuery) Execute(ctx context.Context, input ListTrashInput) (*Li
 		HasMore:    hasMore,
 	}, nil
 }
+
+// resolveCursor はカーソルのIDに対応するゴミ箱アイテムを探し、その削除日時とIDを返します
+func (q *ListTrashQuery) resolveCursor(ctx context.Context, ownerID uuid.UUID, cursorID uuid.UUID) (*repository.TrashCursor, error) {
+	archivedFile, err := q.archivedFileRepo.FindByID(ctx, cursorID)
+	if err == nil && archivedFile.IsOwnedBy(ownerID) {
+		return &repository.TrashCursor{ArchivedAt: archivedFile.ArchivedAt, ID: archivedFile.ID}, nil
+	}
+	if err != nil && !apperror.IsNotFound(err) {
+		return nil, err
+	}
+
+	archivedFolder, err := q.archivedFolderRepo.FindByID(ctx, cursorID)
+	if err == nil && archivedFolder.IsOwnedBy(ownerID) {
+		return &repository.TrashCursor{ArchivedAt: archivedFolder.ArchivedAt, ID: archivedFolder.ID}, nil
+	}
+	if err != nil && !apperror.IsNotFound(err) {
+		return nil, err
+	}
+
+	return nil, apperror.NewValidationError("invalid cursor", nil)
+}
+
+// trashItemBefore は一覧の並び順（archived_at DESC, id DESC）でaがbより前に来るかどうかを判定します
+func trashItemBefore(aArchivedAt time.Time, aID uuid.UUID, bArchivedAt time.Time, bID uuid.UUID) bool {
+	if !aArchivedAt.Equal(bArchivedAt) {
+		return aArchivedAt.After(bArchivedAt)
+	}
+	return bytes.Compare(aID[:], bID[:]) > 0
+}
+
+// toFileTrashItem はアーカイブファイルをゴミ箱アイテムに変換します
+func toFileTrashItem(af *entity.ArchivedFile) TrashItem {
+	return TrashItem{
+		ID:               af.ID,
+		Type:             TrashItemTypeFile,
+		OriginalFileID:   af.OriginalFileID,
+		OriginalFolderID: af.OriginalFolderID,
+		OriginalPath:     af.OriginalPath,
+		Name:             af.Name.String(),
+		MimeType:         af.MimeType.String(),
+		Size:             af.Size,
+		ArchivedAt:       af.ArchivedAt,
+		ExpiresAt:        af.ExpiresAt,
+		DaysUntilExpiry:  af.DaysUntilExpiration(),
+	}
+}
+
+// toFolderTrashItem はアーカイブフォルダをゴミ箱アイテムに変換します
+func toFolderTrashItem(af *entity.ArchivedFolder) TrashItem {
+	return TrashItem{
+		ID:               af.ID,
+		Type:             TrashItemTypeFolder,
+		OriginalFolderID: af.OriginalFolderID,
+		OriginalParentID: af.OriginalParentID,
+		OriginalPath:     af.OriginalPath,
+		Name:             af.Name.String(),
+		ArchivedAt:       af.ArchivedAt,
+		ExpiresAt:        af.ExpiresAt,
+		DaysUntilExpiry:  af.DaysUntilExpiration(),
+	}
+}
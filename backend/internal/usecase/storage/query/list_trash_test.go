@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
 	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/query"
 	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
@@ -18,18 +19,20 @@ import (
 )
 
 type listTrashTestDeps struct {
-	archivedFileRepo *mocks.MockArchivedFileRepository
+	archivedFileRepo   *mocks.MockArchivedFileRepository
+	archivedFolderRepo *mocks.MockArchivedFolderRepository
 }
 
 func newListTrashTestDeps(t *testing.T) *listTrashTestDeps {
 	t.Helper()
 	return &listTrashTestDeps{
-		archivedFileRepo: mocks.NewMockArchivedFileRepository(t),
+		archivedFileRepo:   mocks.NewMockArchivedFileRepository(t),
+		archivedFolderRepo: mocks.NewMockArchivedFolderRepository(t),
 	}
 }
 
 func (d *listTrashTestDeps) newQuery() *query.ListTrashQuery {
-	return query.NewListTrashQuery(d.archivedFileRepo)
+	return query.NewListTrashQuery(d.archivedFileRepo, d.archivedFolderRepo)
 }
 
 func newArchivedFileItem(ownerID uuid.UUID, name string) *entity.ArchivedFile {
@@ -51,6 +54,7 @@ func newArchivedFileItem(ownerID uuid.UUID, name string) *entity.ArchivedFile {
 		time.Now().Add(-time.Hour),
 		ownerID,
 		time.Now().Add(29*24*time.Hour),
+		nil,
 	)
 }
 
@@ -63,8 +67,10 @@ func TestListTrashQuery_Execute_ReturnsItems(t *testing.T) {
 	file2 := newArchivedFileItem(ownerID, "notes.txt")
 	archivedFiles := []*entity.ArchivedFile{file1, file2}
 
-	deps.archivedFileRepo.On("FindByOwnerWithPagination", ctx, ownerID, query.DefaultTrashLimit+1, (*uuid.UUID)(nil)).
+	deps.archivedFileRepo.On("FindByOwnerWithPagination", ctx, ownerID, query.DefaultTrashLimit+1, (*repository.TrashCursor)(nil)).
 		Return(archivedFiles, nil)
+	deps.archivedFolderRepo.On("FindByOwnerWithPagination", ctx, ownerID, query.DefaultTrashLimit+1, (*repository.TrashCursor)(nil)).
+		Return([]*entity.ArchivedFolder{}, nil)
 
 	q := deps.newQuery()
 	output, err := q.Execute(ctx, query.ListTrashInput{
@@ -94,8 +100,10 @@ func TestListTrashQuery_Execute_WithPagination_SetsNextCursor(t *testing.T) {
 		files[i] = newArchivedFileItem(ownerID, "file.txt")
 	}
 
-	deps.archivedFileRepo.On("FindByOwnerWithPagination", ctx, ownerID, limit+1, (*uuid.UUID)(nil)).
+	deps.archivedFileRepo.On("FindByOwnerWithPagination", ctx, ownerID, limit+1, (*repository.TrashCursor)(nil)).
 		Return(files, nil)
+	deps.archivedFolderRepo.On("FindByOwnerWithPagination", ctx, ownerID, limit+1, (*repository.TrashCursor)(nil)).
+		Return([]*entity.ArchivedFolder{}, nil)
 
 	q := deps.newQuery()
 	output, err := q.Execute(ctx, query.ListTrashInput{
@@ -138,8 +146,10 @@ func TestListTrashQuery_Execute_LimitExceedsMax_ClampsToMax(t *testing.T) {
 
 	ownerID := uuid.New()
 
-	deps.archivedFileRepo.On("FindByOwnerWithPagination", ctx, ownerID, query.MaxTrashLimit+1, (*uuid.UUID)(nil)).
+	deps.archivedFileRepo.On("FindByOwnerWithPagination", ctx, ownerID, query.MaxTrashLimit+1, (*repository.TrashCursor)(nil)).
 		Return([]*entity.ArchivedFile{}, nil)
+	deps.archivedFolderRepo.On("FindByOwnerWithPagination", ctx, ownerID, query.MaxTrashLimit+1, (*repository.TrashCursor)(nil)).
+		Return([]*entity.ArchivedFolder{}, nil)
 
 	q := deps.newQuery()
 	output, err := q.Execute(ctx, query.ListTrashInput{
@@ -159,8 +169,10 @@ func TestListTrashQuery_Execute_ZeroLimit_UsesDefault(t *testing.T) {
 
 	ownerID := uuid.New()
 
-	deps.archivedFileRepo.On("FindByOwnerWithPagination", ctx, ownerID, query.DefaultTrashLimit+1, (*uuid.UUID)(nil)).
+	deps.archivedFileRepo.On("FindByOwnerWithPagination", ctx, ownerID, query.DefaultTrashLimit+1, (*repository.TrashCursor)(nil)).
 		Return([]*entity.ArchivedFile{}, nil)
+	deps.archivedFolderRepo.On("FindByOwnerWithPagination", ctx, ownerID, query.DefaultTrashLimit+1, (*repository.TrashCursor)(nil)).
+		Return([]*entity.ArchivedFolder{}, nil)
 
 	q := deps.newQuery()
 	output, err := q.Execute(ctx, query.ListTrashInput{
@@ -173,3 +185,99 @@ func TestListTrashQuery_Execute_ZeroLimit_UsesDefault(t *testing.T) {
 	require.NotNil(t, output)
 	assert.Empty(t, output.Items)
 }
+
+func newArchivedFolderItem(ownerID uuid.UUID, name string, archivedAt time.Time) *entity.ArchivedFolder {
+	folderName, _ := valueobject.NewFolderName(name)
+	parentID := uuid.New()
+	return entity.ReconstructArchivedFolder(
+		uuid.New(),
+		uuid.New(),
+		&parentID,
+		"/docs/"+name,
+		folderName,
+		ownerID,
+		ownerID,
+		archivedAt,
+		ownerID,
+		archivedAt.AddDate(0, 0, entity.TrashRetentionDays),
+	)
+}
+
+func TestListTrashQuery_Execute_MixesFilesAndFoldersByArchivedAt(t *testing.T) {
+	ctx := context.Background()
+	deps := newListTrashTestDeps(t)
+
+	ownerID := uuid.New()
+	file := newArchivedFileItem(ownerID, "report.txt") // archived 1 hour ago
+	newer := newArchivedFolderItem(ownerID, "projects", time.Now().Add(-time.Minute))
+	older := newArchivedFolderItem(ownerID, "archive", time.Now().Add(-2*time.Hour))
+
+	deps.archivedFileRepo.On("FindByOwnerWithPagination", ctx, ownerID, query.DefaultTrashLimit+1, (*repository.TrashCursor)(nil)).
+		Return([]*entity.ArchivedFile{file}, nil)
+	deps.archivedFolderRepo.On("FindByOwnerWithPagination", ctx, ownerID, query.DefaultTrashLimit+1, (*repository.TrashCursor)(nil)).
+		Return([]*entity.ArchivedFolder{newer, older}, nil)
+
+	output, err := deps.newQuery().Execute(ctx, query.ListTrashInput{
+		OwnerID: ownerID,
+		UserID:  ownerID,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, output.Items, 3)
+	assert.Equal(t, newer.ID, output.Items[0].ID)
+	assert.Equal(t, query.TrashItemTypeFolder, output.Items[0].Type)
+	assert.Equal(t, newer.OriginalFolderID, output.Items[0].OriginalFolderID)
+	assert.Equal(t, file.ID, output.Items[1].ID)
+	assert.Equal(t, query.TrashItemTypeFile, output.Items[1].Type)
+	assert.Equal(t, older.ID, output.Items[2].ID)
+}
+
+func TestListTrashQuery_Execute_FolderCursor_ContinuesAfterFolder(t *testing.T) {
+	ctx := context.Background()
+	deps := newListTrashTestDeps(t)
+
+	ownerID := uuid.New()
+	cursorFolder := newArchivedFolderItem(ownerID, "projects", time.Now().Add(-time.Minute))
+	file := newArchivedFileItem(ownerID, "report.txt")
+	cursor := &repository.TrashCursor{ArchivedAt: cursorFolder.ArchivedAt, ID: cursorFolder.ID}
+
+	deps.archivedFileRepo.On("FindByID", ctx, cursorFolder.ID).Return(nil, apperror.NewNotFoundError("archived file"))
+	deps.archivedFolderRepo.On("FindByID", ctx, cursorFolder.ID).Return(cursorFolder, nil)
+	deps.archivedFileRepo.On("FindByOwnerWithPagination", ctx, ownerID, query.DefaultTrashLimit+1, cursor).
+		Return([]*entity.ArchivedFile{file}, nil)
+	deps.archivedFolderRepo.On("FindByOwnerWithPagination", ctx, ownerID, query.DefaultTrashLimit+1, cursor).
+		Return([]*entity.ArchivedFolder{}, nil)
+
+	output, err := deps.newQuery().Execute(ctx, query.ListTrashInput{
+		OwnerID: ownerID,
+		UserID:  ownerID,
+		Cursor:  &cursorFolder.ID,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, output.Items, 1)
+	assert.Equal(t, file.ID, output.Items[0].ID)
+}
+
+func TestListTrashQuery_Execute_UnknownCursor_ReturnsValidationError(t *testing.T) {
+	ctx := context.Background()
+	deps := newListTrashTestDeps(t)
+
+	ownerID := uuid.New()
+	cursorID := uuid.New()
+
+	deps.archivedFileRepo.On("FindByID", ctx, cursorID).Return(nil, apperror.NewNotFoundError("archived file"))
+	deps.archivedFolderRepo.On("FindByID", ctx, cursorID).Return(nil, apperror.NewNotFoundError("archived folder"))
+
+	output, err := deps.newQuery().Execute(ctx, query.ListTrashInput{
+		OwnerID: ownerID,
+		UserID:  ownerID,
+		Cursor:  &cursorID,
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeValidationError, appErr.Code)
+}
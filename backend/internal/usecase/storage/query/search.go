@@ -0,0 +1,237 @@
+package query
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+)
+
+// 検索関連の定数
+const (
+	DefaultSearchLimit  = 50
+	MaxSearchLimit      = 100
+	MaxSearchQueryChars = 255
+	// MaxSearchScan は1リクエストで権限チェックする候補の上限です
+	// 閲覧できない候補が続く場合でもリクエスト時間を一定に抑えるため
+	MaxSearchScan = 1000
+)
+
+// SearchInput は検索の入力を定義します
+type SearchInput struct {
+	UserID         uuid.UUID
+	Query          string
+	ItemType       *repository.SearchItemType
+	MimeCategory   *valueobject.MimeCategory
+	MinSize        *int64
+	MaxSize        *int64
+	OwnerID        *uuid.UUID
+	ModifiedAfter  *time.Time
+	ModifiedBefore *time.Time
+	FolderID       *uuid.UUID // 指定フォルダ配下に限定
+	Limit          int        // 取得件数（デフォルト: 50, 最大: 100）
+	Cursor         string     // ページネーションカーソル（前回のNextCursor）
+}
+
+// SearchOutput は検索の出力を定義します
+type SearchOutput struct {
+	Items      []*repository.SearchResult
+	NextCursor *string // 次ページのカーソル
+	HasMore    bool    // 次ページが存在するか
+}
+
+// SearchQuery はファイル・フォルダ横断検索クエリです
+type SearchQuery struct {
+	searchRepo         repository.SearchRepository
+	folderRepo         repository.FolderRepository
+	permissionResolver authz.PermissionResolver
+}
+
+// NewSearchQuery は新しいSearchQueryを作成します
+func NewSearchQuery(
+	searchRepo repository.SearchRepository,
+	folderRepo repository.FolderRepository,
+	permissionResolver authz.PermissionResolver,
+) *SearchQuery {
+	return &SearchQuery{
+		searchRepo:         searchRepo,
+		folderRepo:         folderRepo,
+		permissionResolver: permissionResolver,
+	}
+}
+
+// Execute は検索を実行します
+// Note: 候補はリポジトリで閲覧し得るサブツリーに限定し、最後にPermissionResolverで閲覧可能なアイテムのみに絞り込む
+func (q *SearchQuery) Execute(ctx context.Context, input SearchInput) (*SearchOutput, error) {
+	// 1. 入力のバリデーション
+	query := strings.TrimSpace(input.Query)
+	if utf8.RuneCountInString(query) > MaxSearchQueryChars {
+		return nil, apperror.NewValidationError("search query is too long", nil)
+	}
+	if input.ItemType != nil && !input.ItemType.IsValid() {
+		return nil, apperror.NewValidationError("invalid item type", nil)
+	}
+	if input.MimeCategory != nil && !input.MimeCategory.IsValid() {
+		return nil, apperror.NewValidationError("invalid mime category", nil)
+	}
+	if (input.MinSize != nil && *input.MinSize < 0) || (input.MaxSize != nil && *input.MaxSize < 0) {
+		return nil, apperror.NewValidationError("size must not be negative", nil)
+	}
+	if input.MinSize != nil && input.MaxSize != nil && *input.MinSize > *input.MaxSize {
+		return nil, apperror.NewValidationError("minSize must be less than or equal to maxSize", nil)
+	}
+	if input.ModifiedAfter != nil && input.ModifiedBefore != nil && input.ModifiedAfter.After(*input.ModifiedBefore) {
+		return nil, apperror.NewValidationError("modifiedAfter must be before modifiedBefore", nil)
+	}
+
+	cursor, err := DecodeSearchCursor(input.Cursor)
+	if err != nil {
+		return nil, apperror.NewValidationError("invalid cursor", nil)
+	}
+
+	// 2. 起点フォルダの閲覧権限チェック
+	if input.FolderID != nil {
+		if _, err := q.folderRepo.FindByID(ctx, *input.FolderID); err != nil {
+			return nil, err
+		}
+		hasPermission, err := q.permissionResolver.HasPermission(ctx, input.UserID, authz.ResourceTypeFolder, *input.FolderID, authz.PermFolderRead)
+		if err != nil {
+			return nil, err
+		}
+		if !hasPermission {
+			return nil, apperror.NewForbiddenError("not authorized to search this folder")
+		}
+	}
+
+	// 3. Limit の正規化
+	limit := input.Limit
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+	if limit > MaxSearchLimit {
+		limit = MaxSearchLimit
+	}
+
+	criteria := repository.SearchCriteria{
+		ViewerID:       input.UserID,
+		Query:          query,
+		ItemType:       input.ItemType,
+		MimeCategory:   input.MimeCategory,
+		MinSize:        input.MinSize,
+		MaxSize:        input.MaxSize,
+		OwnerID:        input.OwnerID,
+		ModifiedAfter:  input.ModifiedAfter,
+		ModifiedBefore: input.ModifiedBefore,
+		FolderID:       input.FolderID,
+		Cursor:         cursor,
+		Limit:          limit + 1,
+	}
+
+	// 4. 候補を取得して権限で絞り込む
+	// 閲覧可能なアイテムが limit+1 件揃うか、候補が尽きるか、走査上限に達するまで繰り返す
+	items := make([]*repository.SearchResult, 0, limit+1)
+	var lastScanned *repository.SearchResult
+	scanned := 0
+	exhausted := false
+	for len(items) <= limit && scanned < MaxSearchScan {
+		candidates, err := q.searchRepo.Search(ctx, criteria)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, candidate := range candidates {
+			scanned++
+			lastScanned = candidate
+
+			readable, err := q.canRead(ctx, input.UserID, candidate)
+			if err != nil {
+				return nil, err
+			}
+			if readable {
+				items = append(items, candidate)
+				if len(items) > limit {
+					break
+				}
+			}
+		}
+
+		if len(candidates) < criteria.Limit {
+			exhausted = true
+			break
+		}
+		criteria.Cursor = &repository.SearchCursor{UpdatedAt: lastScanned.UpdatedAt(), ID: lastScanned.ID()}
+	}
+
+	// 5. 次ページの存在確認とカーソル設定
+	output := &SearchOutput{Items: items}
+	switch {
+	case len(items) > limit:
+		output.Items = items[:limit]
+		output.HasMore = true
+		last := output.Items[limit-1]
+		next := EncodeSearchCursor(&repository.SearchCursor{UpdatedAt: last.UpdatedAt(), ID: last.ID()})
+		output.NextCursor = &next
+	case !exhausted && lastScanned != nil:
+		// 走査上限に達した場合は最後に走査した候補から再開させる
+		output.HasMore = true
+		next := EncodeSearchCursor(&repository.SearchCursor{UpdatedAt: lastScanned.UpdatedAt(), ID: lastScanned.ID()})
+		output.NextCursor = &next
+	}
+
+	return output, nil
+}
+
+// canRead はユーザーが検索結果アイテムを閲覧できるかを判定します
+// ファイルは親フォルダの閲覧権限で判定し、閲覧できない場合のみファイルに直接付与された権限を確認します
+func (q *SearchQuery) canRead(ctx context.Context, userID uuid.UUID, item *repository.SearchResult) (bool, error) {
+	if item.Type == repository.SearchItemTypeFolder {
+		return q.permissionResolver.HasPermission(ctx, userID, authz.ResourceTypeFolder, item.Folder.ID, authz.PermFolderRead)
+	}
+
+	readable, err := q.permissionResolver.HasPermission(ctx, userID, authz.ResourceTypeFolder, item.File.FolderID, authz.PermFolderRead)
+	if err != nil || readable {
+		return readable, err
+	}
+	return q.permissionResolver.HasPermission(ctx, userID, authz.ResourceTypeFile, item.File.ID, authz.PermFileRead)
+}
+
+// EncodeSearchCursor は検索カーソルを不透明な文字列に変換します
+func EncodeSearchCursor(cursor *repository.SearchCursor) string {
+	raw := strconv.FormatInt(cursor.UpdatedAt.UnixNano(), 10) + "_" + cursor.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeSearchCursor は文字列から検索カーソルを復元します（空文字列の場合はnil）
+func DecodeSearchCursor(s string) (*repository.SearchCursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	nanos, idStr, ok := strings.Cut(string(raw), "_")
+	if !ok {
+		return nil, apperror.NewValidationError("invalid cursor", nil)
+	}
+	ts, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.SearchCursor{UpdatedAt: time.Unix(0, ts), ID: id}, nil
+}
@@ -0,0 +1,238 @@
+package query_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/authz"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/usecase/storage/query"
+	"github.com/Hiro-mackay/gc-storage/backend/pkg/apperror"
+	"github.com/Hiro-mackay/gc-storage/backend/tests/testutil/mocks"
+)
+
+type searchTestDeps struct {
+	searchRepo         *mocks.MockSearchRepository
+	folderRepo         *mocks.MockFolderRepository
+	permissionResolver *mocks.MockPermissionResolver
+}
+
+func newSearchTestDeps(t *testing.T) *searchTestDeps {
+	t.Helper()
+	return &searchTestDeps{
+		searchRepo:         mocks.NewMockSearchRepository(t),
+		folderRepo:         mocks.NewMockFolderRepository(t),
+		permissionResolver: mocks.NewMockPermissionResolver(t),
+	}
+}
+
+func (d *searchTestDeps) newQuery() *query.SearchQuery {
+	return query.NewSearchQuery(d.searchRepo, d.folderRepo, d.permissionResolver)
+}
+
+func newSearchFileResult(ownerID uuid.UUID, name string, updatedAt time.Time) *repository.SearchResult {
+	fileID := uuid.New()
+	fileName, _ := valueobject.NewFileName(name)
+	mimeType, _ := valueobject.NewMimeType("text/plain")
+	return &repository.SearchResult{
+		Type: repository.SearchItemTypeFile,
+		File: entity.ReconstructFile(
+			fileID, uuid.New(), ownerID, ownerID, fileName, mimeType, 1024,
+			valueobject.NewStorageKey(fileID), 1, entity.FileStatusActive,
+			updatedAt, updatedAt,
+		),
+	}
+}
+
+func newSearchFolderResult(ownerID uuid.UUID, name string, updatedAt time.Time) *repository.SearchResult {
+	folderName, _ := valueobject.NewFolderName(name)
+	return &repository.SearchResult{
+		Type: repository.SearchItemTypeFolder,
+		Folder: entity.ReconstructFolder(
+			uuid.New(), folderName, nil, ownerID, ownerID, 0,
+			entity.FolderStatusActive, updatedAt, updatedAt,
+		),
+	}
+}
+
+func TestSearchQuery_Execute_FiltersUnreadableItems(t *testing.T) {
+	ctx := context.Background()
+	deps := newSearchTestDeps(t)
+
+	userID := uuid.New()
+	now := time.Now()
+	readableFile := newSearchFileResult(userID, "report.txt", now)
+	hiddenFile := newSearchFileResult(uuid.New(), "report-secret.txt", now.Add(-time.Minute))
+	readableFolder := newSearchFolderResult(userID, "reports", now.Add(-2*time.Minute))
+
+	deps.searchRepo.On("Search", ctx, mock.MatchedBy(func(c repository.SearchCriteria) bool {
+		return c.ViewerID == userID && c.Query == "report" && c.Limit == query.DefaultSearchLimit+1 && c.Cursor == nil
+	})).Return([]*repository.SearchResult{readableFile, hiddenFile, readableFolder}, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFolder, readableFile.File.FolderID, authz.PermFolderRead).Return(true, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFolder, hiddenFile.File.FolderID, authz.PermFolderRead).Return(false, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFile, hiddenFile.File.ID, authz.PermFileRead).Return(false, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFolder, readableFolder.Folder.ID, authz.PermFolderRead).Return(true, nil)
+
+	q := deps.newQuery()
+	output, err := q.Execute(ctx, query.SearchInput{
+		UserID: userID,
+		Query:  "  report ",
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, output)
+	require.Len(t, output.Items, 2)
+	assert.Equal(t, readableFile.File.ID, output.Items[0].ID())
+	assert.Equal(t, readableFolder.Folder.ID, output.Items[1].ID())
+	assert.False(t, output.HasMore)
+	assert.Nil(t, output.NextCursor)
+}
+
+func TestSearchQuery_Execute_FileSharedDirectly_IsReadable(t *testing.T) {
+	ctx := context.Background()
+	deps := newSearchTestDeps(t)
+
+	userID := uuid.New()
+	shared := newSearchFileResult(uuid.New(), "shared.txt", time.Now())
+
+	deps.searchRepo.On("Search", ctx, mock.Anything).Return([]*repository.SearchResult{shared}, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFolder, shared.File.FolderID, authz.PermFolderRead).Return(false, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFile, shared.File.ID, authz.PermFileRead).Return(true, nil)
+
+	q := deps.newQuery()
+	output, err := q.Execute(ctx, query.SearchInput{UserID: userID})
+
+	require.NoError(t, err)
+	require.Len(t, output.Items, 1)
+	assert.Equal(t, shared.File.ID, output.Items[0].ID())
+}
+
+func TestSearchQuery_Execute_WithMoreResults_SetsNextCursor(t *testing.T) {
+	ctx := context.Background()
+	deps := newSearchTestDeps(t)
+
+	userID := uuid.New()
+	now := time.Now()
+	limit := 2
+	results := []*repository.SearchResult{
+		newSearchFileResult(userID, "a.txt", now),
+		newSearchFileResult(userID, "b.txt", now.Add(-time.Minute)),
+		newSearchFileResult(userID, "c.txt", now.Add(-2*time.Minute)),
+	}
+
+	deps.searchRepo.On("Search", ctx, mock.Anything).Return(results, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFolder, mock.Anything, authz.PermFolderRead).Return(true, nil)
+
+	q := deps.newQuery()
+	output, err := q.Execute(ctx, query.SearchInput{
+		UserID: userID,
+		Limit:  limit,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, output.Items, limit)
+	assert.True(t, output.HasMore)
+	require.NotNil(t, output.NextCursor)
+
+	cursor, err := query.DecodeSearchCursor(*output.NextCursor)
+	require.NoError(t, err)
+	assert.Equal(t, results[1].File.ID, cursor.ID)
+	assert.True(t, results[1].File.UpdatedAt.Equal(cursor.UpdatedAt))
+}
+
+func TestSearchQuery_Execute_RefetchesWhenBatchFilteredOut(t *testing.T) {
+	ctx := context.Background()
+	deps := newSearchTestDeps(t)
+
+	userID := uuid.New()
+	now := time.Now()
+	limit := 1
+	hidden1 := newSearchFileResult(uuid.New(), "x.txt", now)
+	hidden2 := newSearchFileResult(uuid.New(), "y.txt", now.Add(-time.Minute))
+	visible := newSearchFileResult(userID, "z.txt", now.Add(-2*time.Minute))
+
+	deps.searchRepo.On("Search", ctx, mock.MatchedBy(func(c repository.SearchCriteria) bool {
+		return c.Cursor == nil
+	})).Return([]*repository.SearchResult{hidden1, hidden2}, nil).Once()
+	deps.searchRepo.On("Search", ctx, mock.MatchedBy(func(c repository.SearchCriteria) bool {
+		return c.Cursor != nil && c.Cursor.ID == hidden2.File.ID
+	})).Return([]*repository.SearchResult{visible}, nil).Once()
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFolder, hidden1.File.FolderID, authz.PermFolderRead).Return(false, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFile, hidden1.File.ID, authz.PermFileRead).Return(false, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFolder, hidden2.File.FolderID, authz.PermFolderRead).Return(false, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFile, hidden2.File.ID, authz.PermFileRead).Return(false, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFolder, visible.File.FolderID, authz.PermFolderRead).Return(true, nil)
+
+	q := deps.newQuery()
+	output, err := q.Execute(ctx, query.SearchInput{
+		UserID: userID,
+		Limit:  limit,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, output.Items, 1)
+	assert.Equal(t, visible.File.ID, output.Items[0].ID())
+	assert.False(t, output.HasMore)
+}
+
+func TestSearchQuery_Execute_FolderWithoutPermission_ReturnsForbidden(t *testing.T) {
+	ctx := context.Background()
+	deps := newSearchTestDeps(t)
+
+	userID := uuid.New()
+	folder := newSearchFolderResult(uuid.New(), "other", time.Now()).Folder
+
+	deps.folderRepo.On("FindByID", ctx, folder.ID).Return(folder, nil)
+	deps.permissionResolver.On("HasPermission", ctx, userID, authz.ResourceTypeFolder, folder.ID, authz.PermFolderRead).Return(false, nil)
+
+	q := deps.newQuery()
+	output, err := q.Execute(ctx, query.SearchInput{
+		UserID:   userID,
+		FolderID: &folder.ID,
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, output)
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeForbidden, appErr.Code)
+}
+
+func TestSearchQuery_Execute_InvalidInput_ReturnsValidationError(t *testing.T) {
+	minSize := int64(100)
+	maxSize := int64(10)
+	invalidCategory := valueobject.MimeCategory("spreadsheet")
+
+	tests := []struct {
+		name  string
+		input query.SearchInput
+	}{
+		{name: "size range inverted", input: query.SearchInput{MinSize: &minSize, MaxSize: &maxSize}},
+		{name: "unknown mime category", input: query.SearchInput{MimeCategory: &invalidCategory}},
+		{name: "malformed cursor", input: query.SearchInput{Cursor: "not-a-cursor"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps := newSearchTestDeps(t)
+			tt.input.UserID = uuid.New()
+
+			output, err := deps.newQuery().Execute(context.Background(), tt.input)
+
+			require.Error(t, err)
+			assert.Nil(t, output)
+			var appErr *apperror.AppError
+			require.True(t, errors.As(err, &appErr))
+			assert.Equal(t, apperror.CodeValidationError, appErr.Code)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package apperror
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 )
@@ -189,3 +190,12 @@ func IsForbidden(err error) bool {
 	}
 	return false
 }
+
+// IsClientError はクライアント起因（4xx）のアプリケーションエラーかどうかを判定します
+func IsClientError(err error) bool {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr.HTTPStatus >= 400 && appErr.HTTPStatus < 500
+	}
+	return false
+}
@@ -17,6 +17,7 @@ type Config struct {
 	Security SecurityConfig
 	App      AppConfig
 	Storage  StorageConfig
+	Jobs     JobsConfig
 }
 
 // ServerConfig はサーバー設定を定義します
@@ -59,6 +60,8 @@ type SecurityConfig struct {
 	CORSOrigins   []string
 	EnableHSTS    bool
 	SecureCookies bool // HTTPS環境でのみCookieを送信するか
+	// AuditCheckpointSigningKey は監査ログ日次チェックポイントの署名に使うEd25519シード（Base64、未設定の場合は署名しない）
+	AuditCheckpointSigningKey string
 }
 
 // AppConfig はアプリケーション設定を定義します
@@ -73,6 +76,32 @@ type StorageConfig struct {
 	SecretAccessKey string
 	BucketName      string
 	UseSSL          bool
+	// DefaultUserQuotaBytes は個別設定のないユーザーのクォータ上限（0は無制限）
+	DefaultUserQuotaBytes int64
+	// WebhookAuthToken はバケット通知Webhookの認証に使う共有トークン（Authorization: Bearer）
+	WebhookAuthToken string
+	// WebhookHMACSecret はバケット通知WebhookのHMAC-SHA256署名の検証に使う共有シークレット
+	WebhookHMACSecret string
+}
+
+// JobsConfig はバックグラウンドジョブの実行間隔を定義します
+type JobsConfig struct {
+	TrashExpiryInterval        time.Duration
+	ShareLinkExpiryInterval    time.Duration
+	InvitationExpiryInterval   time.Duration
+	AccessLogAnonymizeInterval time.Duration
+	VersionPruneInterval       time.Duration
+	UploadSessionReapInterval  time.Duration
+	// CopyJobInterval は非同期フォルダコピージョブを取り出す間隔
+	CopyJobInterval time.Duration
+	// ThumbnailJobInterval は画像サムネイルの生成待ちを取り出す間隔
+	ThumbnailJobInterval time.Duration
+	// UploadVerificationInterval はアップロード内容の検証待ちを取り出す間隔
+	UploadVerificationInterval time.Duration
+	// RunHistoryRetention は実行履歴の保持期間
+	RunHistoryRetention time.Duration
+	// AuditCheckpointInterval は監査ログの日次チェックポイントを作成する間隔
+	AuditCheckpointInterval time.Duration
 }
 
 // Load は環境変数から設定を読み込みます
@@ -86,6 +115,18 @@ func Load() (*Config, error) {
 
 	appURL := getEnv("APP_URL", "http://localhost:3000")
 
+	defaultUserQuota := int64(10 << 30) // 10GiB
+	if q := os.Getenv("STORAGE_DEFAULT_USER_QUOTA_BYTES"); q != "" {
+		if _, err := fmt.Sscanf(q, "%d", &defaultUserQuota); err != nil {
+			return nil, fmt.Errorf("invalid STORAGE_DEFAULT_USER_QUOTA_BYTES: %w", err)
+		}
+	}
+
+	jobs, err := loadJobsConfig()
+	if err != nil {
+		return nil, err
+	}
+
 	return &Config{
 		Server: ServerConfig{
 			Port:  port,
@@ -116,6 +157,8 @@ func Load() (*Config, error) {
 			CORSOrigins:   parseCORSOrigins(getEnv("CORS_ALLOWED_ORIGINS", appURL)),
 			EnableHSTS:    os.Getenv("ENABLE_HSTS") == "true",
 			SecureCookies: os.Getenv("SECURE_COOKIES") == "true",
+
+			AuditCheckpointSigningKey: os.Getenv("AUDIT_CHECKPOINT_SIGNING_KEY"),
 		},
 		App: AppConfig{
 			URL: appURL,
@@ -126,10 +169,56 @@ func Load() (*Config, error) {
 			SecretAccessKey: getEnv("MINIO_SECRET_KEY", "minioadmin"),
 			BucketName:      getEnv("MINIO_BUCKET", "gc-storage"),
 			UseSSL:          os.Getenv("MINIO_USE_SSL") == "true",
+
+			DefaultUserQuotaBytes: defaultUserQuota,
+			WebhookAuthToken:      os.Getenv("MINIO_WEBHOOK_AUTH_TOKEN"),
+			WebhookHMACSecret:     os.Getenv("MINIO_WEBHOOK_HMAC_SECRET"),
 		},
+		Jobs: jobs,
 	}, nil
 }
 
+// loadJobsConfig はバックグラウンドジョブ設定を読み込みます
+func loadJobsConfig() (JobsConfig, error) {
+	var cfg JobsConfig
+	var err error
+
+	if cfg.TrashExpiryInterval, err = getDurationEnv("JOB_TRASH_EXPIRY_INTERVAL", 24*time.Hour); err != nil {
+		return cfg, err
+	}
+	if cfg.ShareLinkExpiryInterval, err = getDurationEnv("JOB_SHARE_LINK_EXPIRY_INTERVAL", time.Hour); err != nil {
+		return cfg, err
+	}
+	if cfg.InvitationExpiryInterval, err = getDurationEnv("JOB_INVITATION_EXPIRY_INTERVAL", time.Hour); err != nil {
+		return cfg, err
+	}
+	if cfg.AccessLogAnonymizeInterval, err = getDurationEnv("JOB_ACCESS_LOG_ANONYMIZE_INTERVAL", 24*time.Hour); err != nil {
+		return cfg, err
+	}
+	if cfg.VersionPruneInterval, err = getDurationEnv("JOB_VERSION_PRUNE_INTERVAL", 24*time.Hour); err != nil {
+		return cfg, err
+	}
+	if cfg.UploadSessionReapInterval, err = getDurationEnv("JOB_UPLOAD_SESSION_REAP_INTERVAL", time.Hour); err != nil {
+		return cfg, err
+	}
+	if cfg.CopyJobInterval, err = getDurationEnv("JOB_COPY_JOB_INTERVAL", 15*time.Second); err != nil {
+		return cfg, err
+	}
+	if cfg.ThumbnailJobInterval, err = getDurationEnv("JOB_THUMBNAIL_JOB_INTERVAL", 10*time.Second); err != nil {
+		return cfg, err
+	}
+	if cfg.UploadVerificationInterval, err = getDurationEnv("JOB_UPLOAD_VERIFICATION_INTERVAL", 5*time.Second); err != nil {
+		return cfg, err
+	}
+	if cfg.RunHistoryRetention, err = getDurationEnv("JOB_RUN_HISTORY_RETENTION", 30*24*time.Hour); err != nil {
+		return cfg, err
+	}
+	if cfg.AuditCheckpointInterval, err = getDurationEnv("JOB_AUDIT_CHECKPOINT_INTERVAL", time.Hour); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
 // parseCORSOrigins はカンマ区切りのオリジン文字列をスライスに変換します
 func parseCORSOrigins(origins string) []string {
 	parts := strings.Split(origins, ",")
@@ -143,6 +232,22 @@ func parseCORSOrigins(origins string) []string {
 	return result
 }
 
+// getDurationEnv は環境変数をtime.Durationとして取得し、存在しない場合はデフォルト値を返します
+func getDurationEnv(key string, defaultValue time.Duration) (time.Duration, error) {
+	v := os.Getenv(key)
+	if v == "" {
+		return defaultValue, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", key, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid %s: must be positive", key)
+	}
+	return d, nil
+}
+
 // getEnv は環境変数を取得し、存在しない場合はデフォルト値を返します
 func getEnv(key, defaultValue string) string {
 	if v := os.Getenv(key); v != "" {
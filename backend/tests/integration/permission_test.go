@@ -770,3 +770,379 @@ func (s *PermissionTestSuite) TestMoveFolder_R_RL004_ContributorCanMoveIn() {
 
 	resp.AssertStatus(http.StatusOK)
 }
+
+// =============================================================================
+// Group Grant Tests
+// =============================================================================
+
+func (s *PermissionTestSuite) createGroup(sessionID, name string) string {
+	resp := testutil.DoRequest(s.T(), s.server.Echo, testutil.HTTPRequest{
+		Method:    http.MethodPost,
+		Path:      "/api/v1/groups",
+		SessionID: sessionID,
+		Body: map[string]string{
+			"name": name,
+		},
+	})
+	resp.AssertStatus(http.StatusCreated)
+	group := resp.GetJSONData()["group"].(map[string]interface{})
+	return group["id"].(string)
+}
+
+func (s *PermissionTestSuite) inviteAndAcceptMember(ownerSessionID, memberSessionID, groupID, email string) {
+	testutil.DoRequest(s.T(), s.server.Echo, testutil.HTTPRequest{
+		Method:    http.MethodPost,
+		Path:      "/api/v1/groups/" + groupID + "/invitations",
+		SessionID: ownerSessionID,
+		Body: map[string]string{
+			"email": email,
+			"role":  "viewer",
+		},
+	}).AssertStatus(http.StatusCreated)
+
+	var token string
+	err := s.server.Pool.QueryRow(
+		context.Background(),
+		"SELECT token FROM invitations WHERE email = $1 AND group_id = $2::uuid",
+		email, groupID,
+	).Scan(&token)
+	s.Require().NoError(err)
+
+	testutil.DoRequest(s.T(), s.server.Echo, testutil.HTTPRequest{
+		Method:    http.MethodPost,
+		Path:      "/api/v1/invitations/" + token + "/accept",
+		SessionID: memberSessionID,
+	}).AssertStatus(http.StatusOK)
+}
+
+func (s *PermissionTestSuite) grantGroupRole(sessionID, folderID, groupID, role string) {
+	testutil.DoRequest(s.T(), s.server.Echo, testutil.HTTPRequest{
+		Method:    http.MethodPost,
+		Path:      "/api/v1/folders/" + folderID + "/permissions",
+		SessionID: sessionID,
+		Body: map[string]interface{}{
+			"granteeType": "group",
+			"granteeId":   groupID,
+			"role":        role,
+		},
+	}).AssertStatus(http.StatusCreated)
+}
+
+// createActiveFile creates a file via the upload API then marks it active in the DB
+// with a single file_version row, simulating a completed upload.
+func (s *PermissionTestSuite) createActiveFile(sessionID, folderID, fileName string) string {
+	uploadResp := testutil.DoRequest(s.T(), s.server.Echo, testutil.HTTPRequest{
+		Method:    http.MethodPost,
+		Path:      "/api/v1/files/upload",
+		SessionID: sessionID,
+		Body: map[string]interface{}{
+			"folderId": folderID,
+			"fileName": fileName,
+			"mimeType": "text/plain",
+			"size":     1024,
+		},
+	})
+	uploadResp.AssertStatus(http.StatusCreated)
+	fileID := uploadResp.GetJSONData()["fileId"].(string)
+
+	_, err := s.server.Pool.Exec(
+		context.Background(),
+		"UPDATE files SET status = 'active' WHERE id = $1",
+		fileID,
+	)
+	s.Require().NoError(err)
+
+	_, err = s.server.Pool.Exec(
+		context.Background(),
+		`INSERT INTO file_versions (id, file_id, version_number, minio_version_id, size, checksum, uploaded_by, created_at)
+		 SELECT gen_random_uuid(), id, 1, 'mock-minio-v1', 1024, 'sha256:abc123', owner_id, NOW()
+		 FROM files WHERE id = $1`,
+		fileID,
+	)
+	s.Require().NoError(err)
+	return fileID
+}
+
+func (s *PermissionTestSuite) TestGroupViewer_CanListFolderAndDownloadFile() {
+	ownerSessionID := s.createUser("owner@example.com", "Password123", "Owner User")
+	memberSessionID := s.createUser("member@example.com", "Password123", "Member User")
+
+	folderID := s.createFolder(ownerSessionID, "Team Folder")
+	fileID := s.createActiveFile(ownerSessionID, folderID, "report.txt")
+
+	groupID := s.createGroup(ownerSessionID, "Team")
+	s.inviteAndAcceptMember(ownerSessionID, memberSessionID, groupID, "member@example.com")
+	s.grantGroupRole(ownerSessionID, folderID, groupID, "viewer")
+
+	// Member lists folder contents via the group grant
+	resp := testutil.DoRequest(s.T(), s.server.Echo, testutil.HTTPRequest{
+		Method:    http.MethodGet,
+		Path:      "/api/v1/folders/" + folderID + "/contents",
+		SessionID: memberSessionID,
+	})
+	resp.AssertStatus(http.StatusOK)
+	files := resp.GetJSONData()["files"].([]interface{})
+	s.Require().Len(files, 1)
+	s.Equal(fileID, files[0].(map[string]interface{})["id"])
+
+	// Member downloads the file inherited from the folder grant
+	testutil.DoRequest(s.T(), s.server.Echo, testutil.HTTPRequest{
+		Method:    http.MethodGet,
+		Path:      "/api/v1/files/" + fileID + "/download",
+		SessionID: memberSessionID,
+	}).AssertStatus(http.StatusOK).
+		AssertJSONPathExists("data.downloadUrl").
+		AssertJSONPath("data.fileName", "report.txt")
+}
+
+func (s *PermissionTestSuite) TestGroupViewer_CannotRenameFile() {
+	ownerSessionID := s.createUser("owner@example.com", "Password123", "Owner User")
+	memberSessionID := s.createUser("member@example.com", "Password123", "Member User")
+
+	folderID := s.createFolder(ownerSessionID, "Team Folder")
+	fileID := s.createActiveFile(ownerSessionID, folderID, "report.txt")
+
+	groupID := s.createGroup(ownerSessionID, "Team")
+	s.inviteAndAcceptMember(ownerSessionID, memberSessionID, groupID, "member@example.com")
+	s.grantGroupRole(ownerSessionID, folderID, groupID, "viewer")
+
+	testutil.DoRequest(s.T(), s.server.Echo, testutil.HTTPRequest{
+		Method:    http.MethodPatch,
+		Path:      "/api/v1/files/" + fileID + "/rename",
+		SessionID: memberSessionID,
+		Body: map[string]string{
+			"name": "renamed.txt",
+		},
+	}).AssertStatus(http.StatusForbidden)
+}
+
+func (s *PermissionTestSuite) TestGroupContributor_CanRenameAndTrashFile() {
+	ownerSessionID := s.createUser("owner@example.com", "Password123", "Owner User")
+	memberSessionID := s.createUser("member@example.com", "Password123", "Member User")
+
+	folderID := s.createFolder(ownerSessionID, "Team Folder")
+	fileID := s.createActiveFile(ownerSessionID, folderID, "report.txt")
+
+	groupID := s.createGroup(ownerSessionID, "Team")
+	s.inviteAndAcceptMember(ownerSessionID, memberSessionID, groupID, "member@example.com")
+	s.grantGroupRole(ownerSessionID, folderID, groupID, "contributor")
+
+	testutil.DoRequest(s.T(), s.server.Echo, testutil.HTTPRequest{
+		Method:    http.MethodPatch,
+		Path:      "/api/v1/files/" + fileID + "/rename",
+		SessionID: memberSessionID,
+		Body: map[string]string{
+			"name": "renamed.txt",
+		},
+	}).AssertStatus(http.StatusOK).
+		AssertJSONPath("data.name", "renamed.txt")
+
+	testutil.DoRequest(s.T(), s.server.Echo, testutil.HTTPRequest{
+		Method:    http.MethodPost,
+		Path:      "/api/v1/files/" + fileID + "/trash",
+		SessionID: memberSessionID,
+	}).AssertStatus(http.StatusOK)
+}
+
+func (s *PermissionTestSuite) TestGroupGrant_NonMemberCannotDownloadFile() {
+	ownerSessionID := s.createUser("owner@example.com", "Password123", "Owner User")
+	otherSessionID := s.createUser("other@example.com", "Password123", "Other User")
+
+	folderID := s.createFolder(ownerSessionID, "Team Folder")
+	fileID := s.createActiveFile(ownerSessionID, folderID, "report.txt")
+
+	groupID := s.createGroup(ownerSessionID, "Team")
+	s.grantGroupRole(ownerSessionID, folderID, groupID, "viewer")
+
+	testutil.DoRequest(s.T(), s.server.Echo, testutil.HTTPRequest{
+		Method:    http.MethodGet,
+		Path:      "/api/v1/files/" + fileID + "/download",
+		SessionID: otherSessionID,
+	}).AssertStatus(http.StatusForbidden)
+}
+
+func (s *PermissionTestSuite) TestGroupContributor_CanUploadToFolder() {
+	ownerSessionID := s.createUser("owner@example.com", "Password123", "Owner User")
+	memberSessionID := s.createUser("member@example.com", "Password123", "Member User")
+
+	folderID := s.createFolder(ownerSessionID, "Team Folder")
+
+	groupID := s.createGroup(ownerSessionID, "Team")
+	s.inviteAndAcceptMember(ownerSessionID, memberSessionID, groupID, "member@example.com")
+	s.grantGroupRole(ownerSessionID, folderID, groupID, "contributor")
+
+	testutil.DoRequest(s.T(), s.server.Echo, testutil.HTTPRequest{
+		Method:    http.MethodPost,
+		Path:      "/api/v1/files/upload",
+		SessionID: memberSessionID,
+		Body: map[string]interface{}{
+			"folderId": folderID,
+			"fileName": "notes.txt",
+			"mimeType": "text/plain",
+			"size":     1024,
+		},
+	}).AssertStatus(http.StatusCreated).
+		AssertJSONPathExists("data.fileId")
+}
+
+func (s *PermissionTestSuite) TestGroupViewer_CannotUploadToFolder() {
+	ownerSessionID := s.createUser("owner@example.com", "Password123", "Owner User")
+	memberSessionID := s.createUser("member@example.com", "Password123", "Member User")
+
+	folderID := s.createFolder(ownerSessionID, "Team Folder")
+
+	groupID := s.createGroup(ownerSessionID, "Team")
+	s.inviteAndAcceptMember(ownerSessionID, memberSessionID, groupID, "member@example.com")
+	s.grantGroupRole(ownerSessionID, folderID, groupID, "viewer")
+
+	testutil.DoRequest(s.T(), s.server.Echo, testutil.HTTPRequest{
+		Method:    http.MethodPost,
+		Path:      "/api/v1/files/upload",
+		SessionID: memberSessionID,
+		Body: map[string]interface{}{
+			"folderId": folderID,
+			"fileName": "notes.txt",
+			"mimeType": "text/plain",
+			"size":     1024,
+		},
+	}).AssertStatus(http.StatusForbidden)
+}
+
+func (s *PermissionTestSuite) TestGroupContentManager_CanRestoreTrashedSubfolder() {
+	ownerSessionID := s.createUser("owner@example.com", "Password123", "Owner User")
+	memberSessionID := s.createUser("member@example.com", "Password123", "Member User")
+
+	folderID := s.createFolder(ownerSessionID, "Team Folder")
+	subResp := testutil.DoRequest(s.T(), s.server.Echo, testutil.HTTPRequest{
+		Method:    http.MethodPost,
+		Path:      "/api/v1/folders",
+		SessionID: ownerSessionID,
+		Body: map[string]interface{}{
+			"name":     "Drafts",
+			"parentId": folderID,
+		},
+	})
+	subResp.AssertStatus(http.StatusCreated)
+	subfolderID := subResp.GetJSONData()["id"].(string)
+
+	groupID := s.createGroup(ownerSessionID, "Team")
+	s.inviteAndAcceptMember(ownerSessionID, memberSessionID, groupID, "member@example.com")
+	s.grantGroupRole(ownerSessionID, folderID, groupID, "content_manager")
+
+	testutil.DoRequest(s.T(), s.server.Echo, testutil.HTTPRequest{
+		Method:    http.MethodDelete,
+		Path:      "/api/v1/folders/" + subfolderID,
+		SessionID: ownerSessionID,
+	}).AssertStatus(http.StatusNoContent)
+
+	var archivedFolderID string
+	err := s.server.Pool.QueryRow(
+		context.Background(),
+		"SELECT id FROM archived_folders WHERE original_folder_id = $1::uuid",
+		subfolderID,
+	).Scan(&archivedFolderID)
+	s.Require().NoError(err)
+
+	// The member restores the owner's subfolder through the grant on its original parent
+	testutil.DoRequest(s.T(), s.server.Echo, testutil.HTTPRequest{
+		Method:    http.MethodPost,
+		Path:      "/api/v1/trash/folders/" + archivedFolderID + "/restore",
+		SessionID: memberSessionID,
+	}).AssertStatus(http.StatusOK).
+		AssertJSONPath("data.folderId", subfolderID)
+}
+
+// =============================================================================
+// Shared With Me Tests
+// =============================================================================
+
+func (s *PermissionTestSuite) TestSharedWithMe_ListsDirectAndGroupGrants() {
+	ownerSessionID := s.createUser("owner@example.com", "Password123", "Owner User")
+	memberSessionID := s.createUser("member@example.com", "Password123", "Member User")
+	memberID := s.getUserID("member@example.com")
+
+	teamFolderID := s.createFolder(ownerSessionID, "Team Folder")
+	docsFolderID := s.createFolder(ownerSessionID, "Docs Folder")
+	s.createFolder(memberSessionID, "Own Folder")
+
+	groupID := s.createGroup(ownerSessionID, "Team")
+	s.inviteAndAcceptMember(ownerSessionID, memberSessionID, groupID, "member@example.com")
+
+	// Team Folder: viewer directly and contributor via group -> contributor
+	testutil.DoRequest(s.T(), s.server.Echo, testutil.HTTPRequest{
+		Method:    http.MethodPost,
+		Path:      "/api/v1/folders/" + teamFolderID + "/permissions",
+		SessionID: ownerSessionID,
+		Body: map[string]interface{}{
+			"granteeType": "user",
+			"granteeId":   memberID,
+			"role":        "viewer",
+		},
+	}).AssertStatus(http.StatusCreated)
+	s.grantGroupRole(ownerSessionID, teamFolderID, groupID, "contributor")
+	s.grantGroupRole(ownerSessionID, docsFolderID, groupID, "viewer")
+
+	resp := testutil.DoRequest(s.T(), s.server.Echo, testutil.HTTPRequest{
+		Method:    http.MethodGet,
+		Path:      "/api/v1/shared-with-me?sort=name",
+		SessionID: memberSessionID,
+	})
+	resp.AssertStatus(http.StatusOK)
+
+	items := resp.GetJSONData()["items"].([]interface{})
+	s.Require().Len(items, 2)
+
+	docs := items[0].(map[string]interface{})
+	s.Equal(docsFolderID, docs["id"])
+	s.Equal("viewer", docs["role"])
+	s.Equal("group", docs["sharedVia"])
+
+	team := items[1].(map[string]interface{})
+	s.Equal(teamFolderID, team["id"])
+	s.Equal("contributor", team["role"])
+	s.Equal("group", team["sharedVia"])
+	s.Equal("Owner User", team["sharedBy"].(map[string]interface{})["name"])
+}
+
+func (s *PermissionTestSuite) TestSharedWithMe_Paginates() {
+	ownerSessionID := s.createUser("owner@example.com", "Password123", "Owner User")
+	memberSessionID := s.createUser("member@example.com", "Password123", "Member User")
+	memberID := s.getUserID("member@example.com")
+
+	for _, name := range []string{"Folder A", "Folder B", "Folder C"} {
+		folderID := s.createFolder(ownerSessionID, name)
+		testutil.DoRequest(s.T(), s.server.Echo, testutil.HTTPRequest{
+			Method:    http.MethodPost,
+			Path:      "/api/v1/folders/" + folderID + "/permissions",
+			SessionID: ownerSessionID,
+			Body: map[string]interface{}{
+				"granteeType": "user",
+				"granteeId":   memberID,
+				"role":        "viewer",
+			},
+		}).AssertStatus(http.StatusCreated)
+	}
+
+	first := testutil.DoRequest(s.T(), s.server.Echo, testutil.HTTPRequest{
+		Method:    http.MethodGet,
+		Path:      "/api/v1/shared-with-me?sort=name&limit=2",
+		SessionID: memberSessionID,
+	})
+	first.AssertStatus(http.StatusOK)
+	firstData := first.GetJSONData()
+	s.Len(firstData["items"].([]interface{}), 2)
+	cursor, ok := firstData["nextCursor"].(string)
+	s.Require().True(ok)
+
+	second := testutil.DoRequest(s.T(), s.server.Echo, testutil.HTTPRequest{
+		Method:    http.MethodGet,
+		Path:      "/api/v1/shared-with-me?sort=name&limit=2&cursor=" + cursor,
+		SessionID: memberSessionID,
+	})
+	second.AssertStatus(http.StatusOK)
+	secondData := second.GetJSONData()
+	items := secondData["items"].([]interface{})
+	s.Require().Len(items, 1)
+	s.Equal("Folder C", items[0].(map[string]interface{})["name"])
+	s.Nil(secondData["nextCursor"])
+}
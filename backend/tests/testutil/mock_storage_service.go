@@ -3,22 +3,33 @@ package testutil
 import (
 	"context"
 	"fmt"
+	"io"
+	"strings"
 	"time"
 
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
 )
 
 // MockStorageService はテスト用のStorageService実装です
 type MockStorageService struct {
 	// エラーを返すように設定できる
-	PutURLError            error
-	GetURLError            error
-	CreateMultipartError   error
-	GeneratePartURLError   error
-	CompleteMultipartError error
-	AbortMultipartError    error
-	DeleteObjectError      error
-	DeleteObjectsError     error
+	PutURLError              error
+	GetURLError              error
+	CreateMultipartError     error
+	GeneratePartURLError     error
+	UploadPartError          error
+	CompleteMultipartError   error
+	AbortMultipartError      error
+	ListIncompleteError      error
+	GetObjectError           error
+	GetObjectInfoError       error
+	PutObjectError           error
+	DeleteObjectError        error
+	DeleteObjectsError       error
+	CopyObjectVersionError   error
+	DeleteObjectVersionError error
+	WalkObjectVersionsError  error
 }
 
 // NewMockStorageService は新しいMockStorageServiceを作成します
@@ -27,7 +38,7 @@ func NewMockStorageService() *MockStorageService {
 }
 
 // GeneratePutURL はアップロード用Presigned URLを生成します
-func (m *MockStorageService) GeneratePutURL(ctx context.Context, objectKey string, expiry time.Duration) (*service.PresignedURL, error) {
+func (m *MockStorageService) GeneratePutURL(ctx context.Context, objectKey string, expiry time.Duration, checksum *valueobject.ContentChecksum) (*service.PresignedURL, error) {
 	if m.PutURLError != nil {
 		return nil, m.PutURLError
 	}
@@ -68,12 +79,33 @@ func (m *MockStorageService) GeneratePartUploadURL(ctx context.Context, objectKe
 	}, nil
 }
 
+// UploadPart はパートをアップロードします（モックでは内容を読み捨てる）
+func (m *MockStorageService) UploadPart(ctx context.Context, objectKey, uploadID string, partNumber int, reader io.Reader, size int64) (string, error) {
+	if m.UploadPartError != nil {
+		return "", m.UploadPartError
+	}
+	if _, err := io.CopyN(io.Discard, reader, size); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("mock-etag-%s-%d", objectKey, partNumber), nil
+}
+
+// ListParts はアップロード済みのパートを一覧します（モックではパートなしとして扱う）
+func (m *MockStorageService) ListParts(ctx context.Context, objectKey, uploadID string) ([]service.StoredPart, error) {
+	return nil, nil
+}
+
+// GetPart はパートの内容を取得します（モックでは空の内容を返す）
+func (m *MockStorageService) GetPart(ctx context.Context, objectKey, uploadID string, partNumber int) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader("")), nil
+}
+
 // CompleteMultipartUpload はマルチパートアップロードを完了します
-func (m *MockStorageService) CompleteMultipartUpload(ctx context.Context, objectKey, uploadID string, etags []string) error {
+func (m *MockStorageService) CompleteMultipartUpload(ctx context.Context, objectKey, uploadID string, etags []string) (string, error) {
 	if m.CompleteMultipartError != nil {
-		return m.CompleteMultipartError
+		return "", m.CompleteMultipartError
 	}
-	return nil
+	return fmt.Sprintf("mock-version-%s", objectKey), nil
 }
 
 // AbortMultipartUpload はマルチパートアップロードを中断します
@@ -84,6 +116,58 @@ func (m *MockStorageService) AbortMultipartUpload(ctx context.Context, objectKey
 	return nil
 }
 
+// ListIncompleteUploads は未完了のマルチパートアップロードを一覧します
+func (m *MockStorageService) ListIncompleteUploads(ctx context.Context, prefix string) ([]service.IncompleteMultipartUpload, error) {
+	if m.ListIncompleteError != nil {
+		return nil, m.ListIncompleteError
+	}
+	return nil, nil
+}
+
+// GetObject はオブジェクトを取得します（モックでは空の内容を返す）
+func (m *MockStorageService) GetObject(ctx context.Context, objectKey string) (io.ReadCloser, error) {
+	if m.GetObjectError != nil {
+		return nil, m.GetObjectError
+	}
+	return io.NopCloser(strings.NewReader("")), nil
+}
+
+// GetObjectInfo はオブジェクトの情報を取得します（モックでは空のオブジェクトとして扱う）
+func (m *MockStorageService) GetObjectInfo(ctx context.Context, objectKey string) (*service.ObjectInfo, error) {
+	if m.GetObjectInfoError != nil {
+		return nil, m.GetObjectInfoError
+	}
+	return &service.ObjectInfo{
+		VersionID: fmt.Sprintf("mock-version-%s", objectKey),
+		ETag:      fmt.Sprintf("mock-etag-%s", objectKey),
+	}, nil
+}
+
+// GetObjectVersionInfo はオブジェクトバージョンの情報を取得します（モックではチェックサムの記録がないものとして扱う）
+func (m *MockStorageService) GetObjectVersionInfo(ctx context.Context, objectKey, versionID string) (*service.ObjectInfo, error) {
+	if m.GetObjectInfoError != nil {
+		return nil, m.GetObjectInfoError
+	}
+	return &service.ObjectInfo{
+		VersionID: versionID,
+		ETag:      fmt.Sprintf("mock-etag-%s", objectKey),
+	}, nil
+}
+
+// PutObject はオブジェクトをアップロードします（モックでは内容を読み捨てる）
+func (m *MockStorageService) PutObject(ctx context.Context, objectKey string, reader io.Reader, size int64, contentType string) (*service.UploadedObject, error) {
+	if m.PutObjectError != nil {
+		return nil, m.PutObjectError
+	}
+	if _, err := io.CopyN(io.Discard, reader, size); err != nil {
+		return nil, err
+	}
+	return &service.UploadedObject{
+		VersionID: fmt.Sprintf("mock-version-%s", objectKey),
+		ETag:      fmt.Sprintf("mock-etag-%s", objectKey),
+	}, nil
+}
+
 // DeleteObject はオブジェクトを削除します
 func (m *MockStorageService) DeleteObject(ctx context.Context, objectKey string) error {
 	if m.DeleteObjectError != nil {
@@ -100,6 +184,24 @@ func (m *MockStorageService) DeleteObjects(ctx context.Context, objectKeys []str
 	return nil
 }
 
+// CopyObjectVersion はオブジェクトの特定バージョンをコピーします
+func (m *MockStorageService) CopyObjectVersion(ctx context.Context, srcKey, srcVersionID, dstKey string) (string, error) {
+	if m.CopyObjectVersionError != nil {
+		return "", m.CopyObjectVersionError
+	}
+	return fmt.Sprintf("mock-version-%s", dstKey), nil
+}
+
+// DeleteObjectVersion はオブジェクトの特定バージョンを削除します
+func (m *MockStorageService) DeleteObjectVersion(ctx context.Context, objectKey, versionID string) error {
+	return m.DeleteObjectVersionError
+}
+
+// WalkObjectVersions はバケット内の全オブジェクトバージョンを走査します（モックでは空のバケットとして扱う）
+func (m *MockStorageService) WalkObjectVersions(ctx context.Context, prefix string, fn func(service.ObjectVersion) error) error {
+	return m.WalkObjectVersionsError
+}
+
 // SetPutURLError はGeneratePutURLでエラーを返すように設定します
 func (m *MockStorageService) SetPutURLError(err error) {
 	m.PutURLError = err
@@ -116,8 +218,16 @@ func (m *MockStorageService) Reset() {
 	m.GetURLError = nil
 	m.CreateMultipartError = nil
 	m.GeneratePartURLError = nil
+	m.UploadPartError = nil
 	m.CompleteMultipartError = nil
 	m.AbortMultipartError = nil
+	m.ListIncompleteError = nil
+	m.GetObjectError = nil
+	m.GetObjectInfoError = nil
+	m.PutObjectError = nil
 	m.DeleteObjectError = nil
 	m.DeleteObjectsError = nil
+	m.CopyObjectVersionError = nil
+	m.DeleteObjectVersionError = nil
+	m.WalkObjectVersionsError = nil
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
 )
 
 // MockArchivedFileRepository is a mock of repository.ArchivedFileRepository
@@ -48,7 +49,7 @@ func (m *MockArchivedFileRepository) FindByOwner(ctx context.Context, ownerID uu
 	return args.Get(0).([]*entity.ArchivedFile), args.Error(1)
 }
 
-func (m *MockArchivedFileRepository) FindByOwnerWithPagination(ctx context.Context, ownerID uuid.UUID, limit int, cursor *uuid.UUID) ([]*entity.ArchivedFile, error) {
+func (m *MockArchivedFileRepository) FindByOwnerWithPagination(ctx context.Context, ownerID uuid.UUID, limit int, cursor *repository.TrashCursor) ([]*entity.ArchivedFile, error) {
 	args := m.Called(ctx, ownerID, limit, cursor)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -72,6 +73,14 @@ func (m *MockArchivedFileRepository) FindByOriginalFileID(ctx context.Context, o
 	return args.Get(0).(*entity.ArchivedFile), args.Error(1)
 }
 
+func (m *MockArchivedFileRepository) FindByArchivedFolderID(ctx context.Context, archivedFolderID uuid.UUID) ([]*entity.ArchivedFile, error) {
+	args := m.Called(ctx, archivedFolderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ArchivedFile), args.Error(1)
+}
+
 // MockArchivedFileVersionRepository is a mock of repository.ArchivedFileVersionRepository
 type MockArchivedFileVersionRepository struct {
 	mock.Mock
@@ -0,0 +1,96 @@
+package mocks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+)
+
+// MockArchivedFolderRepository is a mock of repository.ArchivedFolderRepository
+type MockArchivedFolderRepository struct {
+	mock.Mock
+}
+
+func NewMockArchivedFolderRepository(t *testing.T) *MockArchivedFolderRepository {
+	m := &MockArchivedFolderRepository{}
+	m.Mock.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *MockArchivedFolderRepository) Create(ctx context.Context, archivedFolder *entity.ArchivedFolder) error {
+	args := m.Called(ctx, archivedFolder)
+	return args.Error(0)
+}
+
+func (m *MockArchivedFolderRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.ArchivedFolder, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ArchivedFolder), args.Error(1)
+}
+
+func (m *MockArchivedFolderRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockArchivedFolderRepository) FindByOwner(ctx context.Context, ownerID uuid.UUID) ([]*entity.ArchivedFolder, error) {
+	args := m.Called(ctx, ownerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ArchivedFolder), args.Error(1)
+}
+
+func (m *MockArchivedFolderRepository) FindByOwnerWithPagination(ctx context.Context, ownerID uuid.UUID, limit int, cursor *repository.TrashCursor) ([]*entity.ArchivedFolder, error) {
+	args := m.Called(ctx, ownerID, limit, cursor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ArchivedFolder), args.Error(1)
+}
+
+func (m *MockArchivedFolderRepository) FindExpired(ctx context.Context) ([]*entity.ArchivedFolder, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ArchivedFolder), args.Error(1)
+}
+
+// MockArchivedSubfolderRepository is a mock of repository.ArchivedSubfolderRepository
+type MockArchivedSubfolderRepository struct {
+	mock.Mock
+}
+
+func NewMockArchivedSubfolderRepository(t *testing.T) *MockArchivedSubfolderRepository {
+	m := &MockArchivedSubfolderRepository{}
+	m.Mock.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *MockArchivedSubfolderRepository) BulkCreate(ctx context.Context, subfolders []*entity.ArchivedSubfolder) error {
+	args := m.Called(ctx, subfolders)
+	return args.Error(0)
+}
+
+func (m *MockArchivedSubfolderRepository) FindByArchivedFolderID(ctx context.Context, archivedFolderID uuid.UUID) ([]*entity.ArchivedSubfolder, error) {
+	args := m.Called(ctx, archivedFolderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ArchivedSubfolder), args.Error(1)
+}
+
+func (m *MockArchivedSubfolderRepository) DeleteByArchivedFolderID(ctx context.Context, archivedFolderID uuid.UUID) error {
+	args := m.Called(ctx, archivedFolderID)
+	return args.Error(0)
+}
@@ -0,0 +1,83 @@
+package mocks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+)
+
+// MockAuditLogRepository is a mock of repository.AuditLogRepository
+type MockAuditLogRepository struct {
+	mock.Mock
+}
+
+func NewMockAuditLogRepository(t *testing.T) *MockAuditLogRepository {
+	m := &MockAuditLogRepository{}
+	m.Mock.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *MockAuditLogRepository) Create(ctx context.Context, log *entity.AuditLog) error {
+	args := m.Called(ctx, log)
+	return args.Error(0)
+}
+
+func (m *MockAuditLogRepository) ListByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entity.AuditLog, error) {
+	args := m.Called(ctx, userID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.AuditLog), args.Error(1)
+}
+
+func (m *MockAuditLogRepository) ListByResource(ctx context.Context, resourceType entity.AuditResourceType, resourceID uuid.UUID, limit, offset int) ([]*entity.AuditLog, error) {
+	args := m.Called(ctx, resourceType, resourceID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.AuditLog), args.Error(1)
+}
+
+func (m *MockAuditLogRepository) CountByUserID(ctx context.Context, userID uuid.UUID) (int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockAuditLogRepository) Search(ctx context.Context, criteria repository.AuditLogCriteria) ([]*entity.AuditLog, error) {
+	args := m.Called(ctx, criteria)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.AuditLog), args.Error(1)
+}
+
+func (m *MockAuditLogRepository) LockChainHead(ctx context.Context) (*entity.AuditLog, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.AuditLog), args.Error(1)
+}
+
+func (m *MockAuditLogRepository) FindChainHeadBefore(ctx context.Context, before time.Time) (*entity.AuditLog, error) {
+	args := m.Called(ctx, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.AuditLog), args.Error(1)
+}
+
+func (m *MockAuditLogRepository) ListChainAfter(ctx context.Context, afterSequence int64, limit int) ([]*entity.AuditLog, error) {
+	args := m.Called(ctx, afterSequence, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.AuditLog), args.Error(1)
+}
@@ -0,0 +1,137 @@
+package mocks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
+)
+
+// MockBlobRepository is a mock of repository.BlobRepository
+type MockBlobRepository struct {
+	mock.Mock
+}
+
+func NewMockBlobRepository(t *testing.T) *MockBlobRepository {
+	m := &MockBlobRepository{}
+	m.Mock.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *MockBlobRepository) Acquire(ctx context.Context, blob *entity.Blob) (*entity.Blob, error) {
+	args := m.Called(ctx, blob)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Blob), args.Error(1)
+}
+
+func (m *MockBlobRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Blob, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Blob), args.Error(1)
+}
+
+func (m *MockBlobRepository) IncrementRefCount(ctx context.Context, id uuid.UUID) (*entity.Blob, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Blob), args.Error(1)
+}
+
+func (m *MockBlobRepository) DecrementRefCount(ctx context.Context, id uuid.UUID) (*entity.Blob, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Blob), args.Error(1)
+}
+
+func (m *MockBlobRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockBlobRepository) IsObjectVersionReferenced(ctx context.Context, storageKey valueobject.StorageKey, versionID string) (bool, error) {
+	args := m.Called(ctx, storageKey, versionID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockBlobRepository) FindDuplicateFilesByOwner(ctx context.Context, ownerID uuid.UUID) ([]*repository.DuplicateFile, error) {
+	args := m.Called(ctx, ownerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repository.DuplicateFile), args.Error(1)
+}
+
+// MockBlobService is a mock of service.BlobService
+type MockBlobService struct {
+	mock.Mock
+}
+
+func NewMockBlobService(t *testing.T) *MockBlobService {
+	m := &MockBlobService{}
+	m.Mock.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *MockBlobService) ComputeDigest(ctx context.Context, storageKey, versionID string) (*service.ContentDigest, error) {
+	args := m.Called(ctx, storageKey, versionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.ContentDigest), args.Error(1)
+}
+
+func (m *MockBlobService) StoredDigest(ctx context.Context, storageKey, versionID string) (*service.ContentDigest, error) {
+	args := m.Called(ctx, storageKey, versionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.ContentDigest), args.Error(1)
+}
+
+func (m *MockBlobService) Register(ctx context.Context, checksum string, storageKey valueobject.StorageKey, versionID string, size int64) (*entity.Blob, bool, error) {
+	args := m.Called(ctx, checksum, storageKey, versionID, size)
+	if args.Get(0) == nil {
+		return nil, false, args.Error(2)
+	}
+	return args.Get(0).(*entity.Blob), args.Bool(1), args.Error(2)
+}
+
+func (m *MockBlobService) Acquire(ctx context.Context, blobID uuid.UUID) (*entity.Blob, error) {
+	args := m.Called(ctx, blobID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Blob), args.Error(1)
+}
+
+func (m *MockBlobService) Release(ctx context.Context, blobIDs []uuid.UUID) ([]*entity.Blob, error) {
+	args := m.Called(ctx, blobIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Blob), args.Error(1)
+}
+
+func (m *MockBlobService) Purge(ctx context.Context, blobs []*entity.Blob) {
+	m.Called(ctx, blobs)
+}
+
+func (m *MockBlobService) IsReferenced(ctx context.Context, storageKey valueobject.StorageKey, versionID string) (bool, error) {
+	args := m.Called(ctx, storageKey, versionID)
+	return args.Bool(0), args.Error(1)
+}
@@ -0,0 +1,60 @@
+package mocks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+)
+
+// MockCopyJobRepository is a mock of repository.CopyJobRepository
+type MockCopyJobRepository struct {
+	mock.Mock
+}
+
+func NewMockCopyJobRepository(t *testing.T) *MockCopyJobRepository {
+	m := &MockCopyJobRepository{}
+	m.Mock.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *MockCopyJobRepository) Create(ctx context.Context, job *entity.CopyJob) error {
+	args := m.Called(ctx, job)
+	return args.Error(0)
+}
+
+func (m *MockCopyJobRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.CopyJob, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CopyJob), args.Error(1)
+}
+
+func (m *MockCopyJobRepository) Update(ctx context.Context, job *entity.CopyJob) error {
+	args := m.Called(ctx, job)
+	return args.Error(0)
+}
+
+func (m *MockCopyJobRepository) ClaimNextPending(ctx context.Context, leaseToken uuid.UUID) (*entity.CopyJob, error) {
+	args := m.Called(ctx, leaseToken)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CopyJob), args.Error(1)
+}
+
+func (m *MockCopyJobRepository) Heartbeat(ctx context.Context, id, leaseToken uuid.UUID) (bool, error) {
+	args := m.Called(ctx, id, leaseToken)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockCopyJobRepository) FailRunningHeartbeatBefore(ctx context.Context, before time.Time, message string) (int64, error) {
+	args := m.Called(ctx, before, message)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,48 @@
+package mocks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+)
+
+// MockFilePreviewService is a mock of service.FilePreviewService
+type MockFilePreviewService struct {
+	mock.Mock
+}
+
+func NewMockFilePreviewService(t *testing.T) *MockFilePreviewService {
+	m := &MockFilePreviewService{}
+	m.Mock.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *MockFilePreviewService) Generate(ctx context.Context, file *entity.File, version *entity.FileVersion, maxBytes int) (*service.FilePreview, error) {
+	args := m.Called(ctx, file, version, maxBytes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.FilePreview), args.Error(1)
+}
+
+// MockMarkdownRenderer is a mock of service.MarkdownRenderer
+type MockMarkdownRenderer struct {
+	mock.Mock
+}
+
+func NewMockMarkdownRenderer(t *testing.T) *MockMarkdownRenderer {
+	m := &MockMarkdownRenderer{}
+	m.Mock.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *MockMarkdownRenderer) Render(src string) (string, error) {
+	args := m.Called(src)
+	return args.String(0), args.Error(1)
+}
@@ -86,3 +86,16 @@ func (m *MockFileVersionRepository) CountByFileID(ctx context.Context, fileID uu
 	args := m.Called(ctx, fileID)
 	return args.Int(0), args.Error(1)
 }
+
+func (m *MockFileVersionRepository) UpdatePinned(ctx context.Context, id uuid.UUID, pinned bool) error {
+	args := m.Called(ctx, id, pinned)
+	return args.Error(0)
+}
+
+func (m *MockFileVersionRepository) FindFileIDsWithPrunableVersions(ctx context.Context, afterID uuid.UUID, limit int) ([]uuid.UUID, error) {
+	args := m.Called(ctx, afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
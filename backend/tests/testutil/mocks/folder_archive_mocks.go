@@ -0,0 +1,37 @@
+package mocks
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+)
+
+// MockFolderArchiveService is a mock of service.FolderArchiveService
+type MockFolderArchiveService struct {
+	mock.Mock
+}
+
+func NewMockFolderArchiveService(t *testing.T) *MockFolderArchiveService {
+	m := &MockFolderArchiveService{}
+	m.Mock.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *MockFolderArchiveService) CollectEntries(ctx context.Context, root *entity.Folder, filter service.ArchiveFilter) ([]service.ArchiveEntry, error) {
+	args := m.Called(ctx, root, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]service.ArchiveEntry), args.Error(1)
+}
+
+func (m *MockFolderArchiveService) WriteZip(ctx context.Context, w io.Writer, entries []service.ArchiveEntry) error {
+	args := m.Called(ctx, w, entries)
+	return args.Error(0)
+}
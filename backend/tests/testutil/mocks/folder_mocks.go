@@ -36,6 +36,14 @@ func (m *MockFolderRepository) FindByID(ctx context.Context, id uuid.UUID) (*ent
 	return args.Get(0).(*entity.Folder), args.Error(1)
 }
 
+func (m *MockFolderRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.Folder, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Folder), args.Error(1)
+}
+
 func (m *MockFolderRepository) Update(ctx context.Context, folder *entity.Folder) error {
 	args := m.Called(ctx, folder)
 	return args.Error(0)
@@ -0,0 +1,45 @@
+package mocks
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+)
+
+// MockResumableUploadService is a mock of service.ResumableUploadService
+type MockResumableUploadService struct {
+	mock.Mock
+}
+
+func NewMockResumableUploadService(t *testing.T) *MockResumableUploadService {
+	m := &MockResumableUploadService{}
+	m.Mock.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *MockResumableUploadService) Progress(ctx context.Context, session *entity.UploadSession) (*service.ResumableUploadProgress, error) {
+	args := m.Called(ctx, session)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.ResumableUploadProgress), args.Error(1)
+}
+
+func (m *MockResumableUploadService) Append(ctx context.Context, session *entity.UploadSession, progress *service.ResumableUploadProgress, body io.Reader, size int64) (*service.ResumableUploadProgress, error) {
+	args := m.Called(ctx, session, progress, body, size)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.ResumableUploadProgress), args.Error(1)
+}
+
+func (m *MockResumableUploadService) Assemble(ctx context.Context, session *entity.UploadSession, progress *service.ResumableUploadProgress) (string, error) {
+	args := m.Called(ctx, session, progress)
+	return args.String(0), args.Error(1)
+}
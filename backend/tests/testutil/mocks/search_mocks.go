@@ -0,0 +1,30 @@
+package mocks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+)
+
+// MockSearchRepository is a mock of repository.SearchRepository
+type MockSearchRepository struct {
+	mock.Mock
+}
+
+func NewMockSearchRepository(t *testing.T) *MockSearchRepository {
+	m := &MockSearchRepository{}
+	m.Mock.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *MockSearchRepository) Search(ctx context.Context, criteria repository.SearchCriteria) ([]*repository.SearchResult, error) {
+	args := m.Called(ctx, criteria)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repository.SearchResult), args.Error(1)
+}
@@ -0,0 +1,39 @@
+package mocks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/repository"
+)
+
+// MockStorageInventoryRepository is a mock of repository.StorageInventoryRepository
+type MockStorageInventoryRepository struct {
+	mock.Mock
+}
+
+func NewMockStorageInventoryRepository(t *testing.T) *MockStorageInventoryRepository {
+	m := &MockStorageInventoryRepository{}
+	m.Mock.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *MockStorageInventoryRepository) ListFileVersionRefs(ctx context.Context, afterID uuid.UUID, limit int) ([]*repository.StoredObjectRef, error) {
+	args := m.Called(ctx, afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repository.StoredObjectRef), args.Error(1)
+}
+
+func (m *MockStorageInventoryRepository) ListArchivedFileVersionRefs(ctx context.Context, afterID uuid.UUID, limit int) ([]*repository.StoredObjectRef, error) {
+	args := m.Called(ctx, afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repository.StoredObjectRef), args.Error(1)
+}
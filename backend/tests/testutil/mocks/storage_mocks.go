@@ -2,12 +2,14 @@ package mocks
 
 import (
 	"context"
+	"io"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/mock"
 
 	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/service"
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/valueobject"
 )
 
 // MockStorageService is a mock of service.StorageService
@@ -22,16 +24,16 @@ func NewMockStorageService(t *testing.T) *MockStorageService {
 	return m
 }
 
-func (m *MockStorageService) GeneratePutURL(ctx context.Context, objectKey string, expiry time.Duration) (*service.PresignedURL, error) {
-	args := m.Called(ctx, objectKey, expiry)
+func (m *MockStorageService) GeneratePutURL(ctx context.Context, objectKey string, expiry time.Duration, checksum *valueobject.ContentChecksum) (*service.PresignedURL, error) {
+	args := m.Called(ctx, objectKey, expiry, checksum)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*service.PresignedURL), args.Error(1)
 }
 
-func (m *MockStorageService) GenerateGetURL(ctx context.Context, objectKey string, expiry time.Duration) (*service.PresignedURL, error) {
-	args := m.Called(ctx, objectKey, expiry)
+func (m *MockStorageService) GenerateGetURL(ctx context.Context, objectKey, versionID string, expiry time.Duration) (*service.PresignedURL, error) {
+	args := m.Called(ctx, objectKey, versionID, expiry)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -51,9 +53,30 @@ func (m *MockStorageService) GeneratePartUploadURL(ctx context.Context, objectKe
 	return args.Get(0).(*service.MultipartUploadURL), args.Error(1)
 }
 
-func (m *MockStorageService) CompleteMultipartUpload(ctx context.Context, objectKey, uploadID string, etags []string) error {
+func (m *MockStorageService) UploadPart(ctx context.Context, objectKey, uploadID string, partNumber int, reader io.Reader, size int64) (string, error) {
+	args := m.Called(ctx, objectKey, uploadID, partNumber, reader, size)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockStorageService) ListParts(ctx context.Context, objectKey, uploadID string) ([]service.StoredPart, error) {
+	args := m.Called(ctx, objectKey, uploadID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]service.StoredPart), args.Error(1)
+}
+
+func (m *MockStorageService) GetPart(ctx context.Context, objectKey, uploadID string, partNumber int) (io.ReadCloser, error) {
+	args := m.Called(ctx, objectKey, uploadID, partNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func (m *MockStorageService) CompleteMultipartUpload(ctx context.Context, objectKey, uploadID string, etags []string) (string, error) {
 	args := m.Called(ctx, objectKey, uploadID, etags)
-	return args.Error(0)
+	return args.String(0), args.Error(1)
 }
 
 func (m *MockStorageService) AbortMultipartUpload(ctx context.Context, objectKey, uploadID string) error {
@@ -61,6 +84,46 @@ func (m *MockStorageService) AbortMultipartUpload(ctx context.Context, objectKey
 	return args.Error(0)
 }
 
+func (m *MockStorageService) ListIncompleteUploads(ctx context.Context, prefix string) ([]service.IncompleteMultipartUpload, error) {
+	args := m.Called(ctx, prefix)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]service.IncompleteMultipartUpload), args.Error(1)
+}
+
+func (m *MockStorageService) GetObject(ctx context.Context, objectKey, versionID string) (io.ReadCloser, error) {
+	args := m.Called(ctx, objectKey, versionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func (m *MockStorageService) GetObjectInfo(ctx context.Context, objectKey string) (*service.ObjectInfo, error) {
+	args := m.Called(ctx, objectKey)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.ObjectInfo), args.Error(1)
+}
+
+func (m *MockStorageService) GetObjectVersionInfo(ctx context.Context, objectKey, versionID string) (*service.ObjectInfo, error) {
+	args := m.Called(ctx, objectKey, versionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.ObjectInfo), args.Error(1)
+}
+
+func (m *MockStorageService) PutObject(ctx context.Context, objectKey string, reader io.Reader, size int64, contentType string) (*service.UploadedObject, error) {
+	args := m.Called(ctx, objectKey, reader, size, contentType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.UploadedObject), args.Error(1)
+}
+
 func (m *MockStorageService) DeleteObject(ctx context.Context, objectKey string) error {
 	args := m.Called(ctx, objectKey)
 	return args.Error(0)
@@ -70,3 +133,18 @@ func (m *MockStorageService) DeleteObjects(ctx context.Context, objectKeys []str
 	args := m.Called(ctx, objectKeys)
 	return args.Error(0)
 }
+
+func (m *MockStorageService) CopyObjectVersion(ctx context.Context, srcKey, srcVersionID, dstKey string) (string, error) {
+	args := m.Called(ctx, srcKey, srcVersionID, dstKey)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockStorageService) DeleteObjectVersion(ctx context.Context, objectKey, versionID string) error {
+	args := m.Called(ctx, objectKey, versionID)
+	return args.Error(0)
+}
+
+func (m *MockStorageService) WalkObjectVersions(ctx context.Context, prefix string, fn func(service.ObjectVersion) error) error {
+	args := m.Called(ctx, prefix, fn)
+	return args.Error(0)
+}
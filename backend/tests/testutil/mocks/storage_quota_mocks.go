@@ -0,0 +1,98 @@
+package mocks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+)
+
+// MockStorageQuotaRepository is a mock of repository.StorageQuotaRepository
+type MockStorageQuotaRepository struct {
+	mock.Mock
+}
+
+func NewMockStorageQuotaRepository(t *testing.T) *MockStorageQuotaRepository {
+	m := &MockStorageQuotaRepository{}
+	m.Mock.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *MockStorageQuotaRepository) Upsert(ctx context.Context, quota *entity.StorageQuota) error {
+	args := m.Called(ctx, quota)
+	return args.Error(0)
+}
+
+func (m *MockStorageQuotaRepository) FindBySubject(ctx context.Context, subjectType entity.QuotaSubjectType, subjectID uuid.UUID) (*entity.StorageQuota, error) {
+	args := m.Called(ctx, subjectType, subjectID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.StorageQuota), args.Error(1)
+}
+
+func (m *MockStorageQuotaRepository) FindGroupQuotasByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.StorageQuota, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.StorageQuota), args.Error(1)
+}
+
+func (m *MockStorageQuotaRepository) DeleteBySubject(ctx context.Context, subjectType entity.QuotaSubjectType, subjectID uuid.UUID) error {
+	args := m.Called(ctx, subjectType, subjectID)
+	return args.Error(0)
+}
+
+// MockStorageUsageRepository is a mock of repository.StorageUsageRepository
+type MockStorageUsageRepository struct {
+	mock.Mock
+}
+
+func NewMockStorageUsageRepository(t *testing.T) *MockStorageUsageRepository {
+	m := &MockStorageUsageRepository{}
+	m.Mock.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *MockStorageUsageRepository) GetUsedBytes(ctx context.Context, userID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockStorageUsageRepository) AddUsedBytes(ctx context.Context, userID uuid.UUID, delta int64) error {
+	args := m.Called(ctx, userID, delta)
+	return args.Error(0)
+}
+
+func (m *MockStorageUsageRepository) SumUsedBytesByGroup(ctx context.Context, groupID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, groupID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// MockStorageQuotaService is a mock of service.StorageQuotaService
+type MockStorageQuotaService struct {
+	mock.Mock
+}
+
+func NewMockStorageQuotaService(t *testing.T) *MockStorageQuotaService {
+	m := &MockStorageQuotaService{}
+	m.Mock.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *MockStorageQuotaService) GetUserLimit(ctx context.Context, userID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockStorageQuotaService) EnsureCapacity(ctx context.Context, userID uuid.UUID, additionalBytes int64) error {
+	args := m.Called(ctx, userID, additionalBytes)
+	return args.Error(0)
+}
@@ -0,0 +1,80 @@
+package mocks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+)
+
+// MockThumbnailRepository is a mock of repository.ThumbnailRepository
+type MockThumbnailRepository struct {
+	mock.Mock
+}
+
+func NewMockThumbnailRepository(t *testing.T) *MockThumbnailRepository {
+	m := &MockThumbnailRepository{}
+	m.Mock.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *MockThumbnailRepository) Create(ctx context.Context, thumbnail *entity.Thumbnail) error {
+	args := m.Called(ctx, thumbnail)
+	return args.Error(0)
+}
+
+func (m *MockThumbnailRepository) FindByFileAndVersion(ctx context.Context, fileID uuid.UUID, versionNumber int) (*entity.Thumbnail, error) {
+	args := m.Called(ctx, fileID, versionNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Thumbnail), args.Error(1)
+}
+
+func (m *MockThumbnailRepository) Update(ctx context.Context, thumbnail *entity.Thumbnail) error {
+	args := m.Called(ctx, thumbnail)
+	return args.Error(0)
+}
+
+func (m *MockThumbnailRepository) ClaimNextPending(ctx context.Context) (*entity.Thumbnail, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Thumbnail), args.Error(1)
+}
+
+func (m *MockThumbnailRepository) FindRunningStartedBefore(ctx context.Context, before time.Time) ([]*entity.Thumbnail, error) {
+	args := m.Called(ctx, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Thumbnail), args.Error(1)
+}
+
+// MockThumbnailService is a mock of service.ThumbnailService
+type MockThumbnailService struct {
+	mock.Mock
+}
+
+func NewMockThumbnailService(t *testing.T) *MockThumbnailService {
+	m := &MockThumbnailService{}
+	m.Mock.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *MockThumbnailService) Schedule(ctx context.Context, file *entity.File, version *entity.FileVersion) error {
+	args := m.Called(ctx, file, version)
+	return args.Error(0)
+}
+
+func (m *MockThumbnailService) Render(ctx context.Context, thumbnail *entity.Thumbnail) error {
+	args := m.Called(ctx, thumbnail)
+	return args.Error(0)
+}
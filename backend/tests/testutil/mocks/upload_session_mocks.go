@@ -62,6 +62,14 @@ func (m *MockUploadSessionRepository) FindByStorageKey(ctx context.Context, stor
 	return args.Get(0).(*entity.UploadSession), args.Error(1)
 }
 
+func (m *MockUploadSessionRepository) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.UploadSession, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.UploadSession), args.Error(1)
+}
+
 func (m *MockUploadSessionRepository) FindExpired(ctx context.Context) ([]*entity.UploadSession, error) {
 	args := m.Called(ctx)
 	if args.Get(0) == nil {
@@ -70,6 +78,14 @@ func (m *MockUploadSessionRepository) FindExpired(ctx context.Context) ([]*entit
 	return args.Get(0).([]*entity.UploadSession), args.Error(1)
 }
 
+func (m *MockUploadSessionRepository) FindVerifying(ctx context.Context, limit int) ([]*entity.UploadSession, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.UploadSession), args.Error(1)
+}
+
 func (m *MockUploadSessionRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status entity.UploadSessionStatus) error {
 	args := m.Called(ctx, id, status)
 	return args.Error(0)
@@ -0,0 +1,41 @@
+package mocks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Hiro-mackay/gc-storage/backend/internal/domain/entity"
+)
+
+// MockVersionRetentionPolicyRepository is a mock of repository.VersionRetentionPolicyRepository
+type MockVersionRetentionPolicyRepository struct {
+	mock.Mock
+}
+
+func NewMockVersionRetentionPolicyRepository(t *testing.T) *MockVersionRetentionPolicyRepository {
+	m := &MockVersionRetentionPolicyRepository{}
+	m.Mock.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *MockVersionRetentionPolicyRepository) Upsert(ctx context.Context, policy *entity.VersionRetentionPolicy) error {
+	args := m.Called(ctx, policy)
+	return args.Error(0)
+}
+
+func (m *MockVersionRetentionPolicyRepository) FindBySubject(ctx context.Context, subjectType entity.RetentionSubjectType, subjectID uuid.UUID) (*entity.VersionRetentionPolicy, error) {
+	args := m.Called(ctx, subjectType, subjectID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.VersionRetentionPolicy), args.Error(1)
+}
+
+func (m *MockVersionRetentionPolicyRepository) DeleteBySubject(ctx context.Context, subjectType entity.RetentionSubjectType, subjectID uuid.UUID) error {
+	args := m.Called(ctx, subjectType, subjectID)
+	return args.Error(0)
+}
@@ -98,6 +98,7 @@ func NewTestServer(t *testing.T) *TestServer {
 	container.InitCollaborationUseCases()
 	container.InitAuthzUseCases()
 	container.InitSharingUseCases(mockStorageService)
+	container.InitAuditUseCases()
 	handlers := di.NewHandlersForTest(container)
 	middlewares := di.NewMiddlewares(container)
 
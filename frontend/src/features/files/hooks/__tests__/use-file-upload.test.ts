@@ -76,6 +76,7 @@ describe('useFileUpload', () => {
     mockApi.POST.mockResolvedValueOnce({
       data: {
         data: {
+          sessionId: 'upload-session-1',
           fileId,
           uploadUrls: [{ url: uploadUrl }],
         },
@@ -123,14 +124,12 @@ describe('useFileUpload', () => {
       },
     });
 
-    expect(mockApi.POST).toHaveBeenCalledWith('/files/upload/complete', {
-      body: {
-        storageKey: 'uploaded-file-1',
-        etag: '"etag-123"',
-        size: 1024,
-        minioVersionId: '',
+    expect(mockApi.POST).toHaveBeenCalledWith(
+      '/files/upload/{sessionId}/complete',
+      {
+        params: { path: { sessionId: 'upload-session-1' } },
       },
-    });
+    );
 
     const upload = useUploadStore.getState().uploads.get('test-uuid');
     expect(upload?.status).toBe('completed');
@@ -48,10 +48,10 @@ export function useFileUpload() {
           }
         };
 
-        const etag = await new Promise<string>((resolve, reject) => {
+        await new Promise<void>((resolve, reject) => {
           xhr.onload = () => {
             if (xhr.status >= 200 && xhr.status < 300) {
-              resolve(xhr.getResponseHeader('ETag') ?? '');
+              resolve();
             } else {
               reject(new Error(`Storage upload failed: ${xhr.status}`));
             }
@@ -60,16 +60,11 @@ export function useFileUpload() {
           xhr.send(file);
         });
 
-        // Complete upload
+        // Complete upload (the server verifies the stored object)
         const { error: completeError } = await api.POST(
-          '/files/upload/complete',
+          '/files/upload/{sessionId}/complete',
           {
-            body: {
-              storageKey: uploadData.fileId ?? '',
-              etag,
-              size: file.size,
-              minioVersionId: '',
-            },
+            params: { path: { sessionId: uploadData.sessionId ?? '' } },
           },
         );
 